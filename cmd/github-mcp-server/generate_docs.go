@@ -64,7 +64,10 @@ func generateReadmeDocs(readmePath string) error {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t)
+	tsg, err := github.DefaultToolsetGroup(github.ServerInfo{}, mockGetClient, mockGetGQLClient, mockGetRawClient, t)
+	if err != nil {
+		return fmt.Errorf("failed to create toolsets: %w", err)
+	}
 
 	// Generate toolsets documentation
 	toolsetsDoc := generateToolsetsDoc(tsg)
@@ -101,7 +104,10 @@ func generateRemoteServerDocs(docsPath string) error {
 		return fmt.Errorf("failed to read docs file: %w", err)
 	}
 
-	toolsetsDoc := generateRemoteToolsetsDoc()
+	toolsetsDoc, err := generateRemoteToolsetsDoc()
+	if err != nil {
+		return err
+	}
 
 	// Replace content between markers
 	startMarker := "<!-- START AUTOMATED TOOLSETS -->"
@@ -130,20 +136,12 @@ func generateToolsetsDoc(tsg *toolsets.ToolsetGroup) string {
 	// Add the context toolset row (handled separately in README)
 	lines = append(lines, "| `context`               | **Strongly recommended**: Tools that provide context about the current user and GitHub context you are operating in |")
 
-	// Get all toolsets except context (which is handled separately above)
-	var toolsetNames []string
-	for name := range tsg.Toolsets {
-		if name != "context" && name != "dynamic" { // Skip context and dynamic toolsets as they're handled separately
-			toolsetNames = append(toolsetNames, name)
+	// Get all toolsets except context (which is handled separately above), sorted for consistent output
+	for _, toolset := range tsg.List() {
+		if toolset.Name == "context" || toolset.Name == "dynamic" { // Skip context and dynamic toolsets as they're handled separately
+			continue
 		}
-	}
-
-	// Sort toolset names for consistent output
-	sort.Strings(toolsetNames)
-
-	for _, name := range toolsetNames {
-		toolset := tsg.Toolsets[name]
-		lines = append(lines, fmt.Sprintf("| `%s` | %s |", name, toolset.Description))
+		lines = append(lines, fmt.Sprintf("| `%s` | %s |", toolset.Name, toolset.Description))
 	}
 
 	return strings.Join(lines, "\n")
@@ -152,17 +150,11 @@ func generateToolsetsDoc(tsg *toolsets.ToolsetGroup) string {
 func generateToolsDoc(tsg *toolsets.ToolsetGroup) string {
 	var sections []string
 
-	// Get all toolset names and sort them alphabetically for deterministic order
-	var toolsetNames []string
-	for name := range tsg.Toolsets {
-		if name != "dynamic" { // Skip dynamic toolset as it's handled separately
-			toolsetNames = append(toolsetNames, name)
+	// Toolsets come sorted alphabetically for deterministic order
+	for _, toolset := range tsg.Toolsets() {
+		if toolset.Name == "dynamic" { // Skip dynamic toolset as it's handled separately
+			continue
 		}
-	}
-	sort.Strings(toolsetNames)
-
-	for _, toolsetName := range toolsetNames {
-		toolset := tsg.Toolsets[toolsetName]
 
 		tools := toolset.GetAvailableTools()
 		if len(tools) == 0 {
@@ -175,7 +167,7 @@ func generateToolsDoc(tsg *toolsets.ToolsetGroup) string {
 		})
 
 		// Generate section header - capitalize first letter and replace underscores
-		sectionName := formatToolsetName(toolsetName)
+		sectionName := formatToolsetName(toolset.Name)
 
 		var toolDocs []string
 		for _, serverTool := range tools {
@@ -295,34 +287,31 @@ func replaceSection(content, startMarker, endMarker, newContent string) string {
 	return re.ReplaceAllString(content, replacement)
 }
 
-func generateRemoteToolsetsDoc() string {
+func generateRemoteToolsetsDoc() (string, error) {
 	var buf strings.Builder
 
 	// Create translation helper
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t)
+	tsg, err := github.DefaultToolsetGroup(github.ServerInfo{}, mockGetClient, mockGetGQLClient, mockGetRawClient, t)
+	if err != nil {
+		return "", fmt.Errorf("failed to create toolsets: %w", err)
+	}
 
 	// Generate table header
 	buf.WriteString("| Name           | Description                                      | API URL                                               | 1-Click Install (VS Code)                                                                                                                                                                                                 | Read-only Link                                                                                                 | 1-Click Read-only Install (VS Code)                                                                                                                                                                                                 |\n")
 	buf.WriteString("|----------------|--------------------------------------------------|-------------------------------------------------------|----------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------|---------------------------------------------------------------------------------------------------------------|-----------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------------|\n")
 
-	// Get all toolsets
-	toolsetNames := make([]string, 0, len(tsg.Toolsets))
-	for name := range tsg.Toolsets {
-		if name != "context" && name != "dynamic" { // Skip context and dynamic toolsets as they're handled separately
-			toolsetNames = append(toolsetNames, name)
-		}
-	}
-	sort.Strings(toolsetNames)
-
 	// Add "all" toolset first (special case)
 	buf.WriteString("| all            | All available GitHub MCP tools                    | https://api.githubcopilot.com/mcp/                    | [Install](https://insiders.vscode.dev/redirect/mcp/install?name=github&config=%7B%22type%22%3A%20%22http%22%2C%22url%22%3A%20%22https%3A%2F%2Fapi.githubcopilot.com%2Fmcp%2F%22%7D)                                      | [read-only](https://api.githubcopilot.com/mcp/readonly)                                                      | [Install read-only](https://insiders.vscode.dev/redirect/mcp/install?name=github&config=%7B%22type%22%3A%20%22http%22%2C%22url%22%3A%20%22https%3A%2F%2Fapi.githubcopilot.com%2Fmcp%2Freadonly%22%7D) |\n")
 
 	// Add individual toolsets
-	for _, name := range toolsetNames {
-		toolset := tsg.Toolsets[name]
+	for _, toolset := range tsg.List() {
+		name := toolset.Name
+		if name == "context" || name == "dynamic" { // Skip context and dynamic toolsets as they're handled separately
+			continue
+		}
 
 		formattedName := formatToolsetName(name)
 		description := toolset.Description
@@ -350,5 +339,5 @@ func generateRemoteToolsetsDoc() string {
 		))
 	}
 
-	return buf.String()
+	return buf.String(), nil
 }
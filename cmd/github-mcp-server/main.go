@@ -7,7 +7,9 @@ import (
 	"strings"
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
+	"github.com/github/github-mcp-server/pkg/cache"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -45,16 +47,36 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			var accounts []ghmcp.Account
+			if path := viper.GetString("accounts_file"); path != "" {
+				var err error
+				if accounts, err = ghmcp.LoadAccounts(path); err != nil {
+					return err
+				}
+			}
+
 			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
+				Version:                  version,
+				Host:                     viper.GetString("host"),
+				Token:                    token,
+				EnabledToolsets:          enabledToolsets,
+				DynamicToolsets:          viper.GetBool("dynamic_toolsets"),
+				ReadOnly:                 viper.GetBool("read-only"),
+				ProtectDefaultBranch:     viper.GetBool("protect_default_branch"),
+				GistMaxBytes:             viper.GetInt("gist_max_bytes"),
+				ReleaseAssetMaxBytes:     viper.GetInt("release_asset_max_bytes"),
+				ExportTranslations:       viper.GetBool("export-translations"),
+				TranslationsDir:          viper.GetString("translations_dir"),
+				Locale:                   viper.GetString("locale"),
+				EnableCommandLogging:     viper.GetBool("enable-command-logging"),
+				LogFilePath:              viper.GetString("log-file"),
+				MaxConcurrentGitHubCalls: viper.GetInt("max_concurrent_github_calls"),
+				MaxRetries:               viper.GetInt("max_retries"),
+				EnableHTTPCache:          viper.GetBool("enable_http_cache"),
+				HTTPCacheMaxEntries:      viper.GetInt("http_cache_max_entries"),
+				HTTPCacheMaxBytes:        viper.GetInt64("http_cache_max_bytes"),
+				ReportAPICost:            viper.GetBool("report_api_cost"),
+				Accounts:                 accounts,
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
@@ -71,19 +93,43 @@ func init() {
 	rootCmd.PersistentFlags().StringSlice("toolsets", github.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
+	rootCmd.PersistentFlags().Bool("protect-default-branch", false, "Refuse file writes to a repository's default branch unless a tool call explicitly allows it")
+	rootCmd.PersistentFlags().Int("gist-max-bytes", github.DefaultGistMaxBytes, "Maximum total size in bytes of the files of a gist created by create_gist")
+	rootCmd.PersistentFlags().Int("release-asset-max-bytes", github.DefaultReleaseAssetMaxBytes, "Maximum size in bytes of a release asset downloaded by download_release_asset")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
+	rootCmd.PersistentFlags().String("translations-dir", "", "Directory of translations.<locale>.json files")
+	rootCmd.PersistentFlags().String("locale", "", "Locale of the tool descriptions, falling back to English for missing translations")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
+	rootCmd.PersistentFlags().Bool("report-api-cost", false, "Append the number of GitHub API requests and bytes transferred to each tool result")
+	rootCmd.PersistentFlags().String("accounts-file", "", "Path to a JSON file of further GitHub accounts that tool calls can select with the account parameter")
+	rootCmd.PersistentFlags().Int("max-concurrent-github-calls", ghmcp.DefaultMaxConcurrentGitHubCalls, "Maximum number of GitHub API requests in flight at once, 0 for no limit")
+	rootCmd.PersistentFlags().Int("max-retries", ratelimit.DefaultMaxRetries, "Maximum number of times a GitHub API request turned away by a rate limit is retried, 0 to never retry")
+	rootCmd.PersistentFlags().Bool("enable-http-cache", false, "Revalidate repeated GitHub REST API reads with ETags and serve unchanged responses from memory")
+	rootCmd.PersistentFlags().Int("http-cache-max-entries", cache.DefaultMaxEntries, "Maximum number of responses kept by the HTTP cache")
+	rootCmd.PersistentFlags().Int64("http-cache-max-bytes", cache.DefaultMaxBytes, "Maximum total size in bytes of the response bodies kept by the HTTP cache")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
+	_ = viper.BindPFlag("protect_default_branch", rootCmd.PersistentFlags().Lookup("protect-default-branch"))
+	_ = viper.BindPFlag("gist_max_bytes", rootCmd.PersistentFlags().Lookup("gist-max-bytes"))
+	_ = viper.BindPFlag("release_asset_max_bytes", rootCmd.PersistentFlags().Lookup("release-asset-max-bytes"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
+	_ = viper.BindPFlag("translations_dir", rootCmd.PersistentFlags().Lookup("translations-dir"))
+	_ = viper.BindPFlag("locale", rootCmd.PersistentFlags().Lookup("locale"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("report_api_cost", rootCmd.PersistentFlags().Lookup("report-api-cost"))
+	_ = viper.BindPFlag("accounts_file", rootCmd.PersistentFlags().Lookup("accounts-file"))
+	_ = viper.BindPFlag("max_concurrent_github_calls", rootCmd.PersistentFlags().Lookup("max-concurrent-github-calls"))
+	_ = viper.BindPFlag("max_retries", rootCmd.PersistentFlags().Lookup("max-retries"))
+	_ = viper.BindPFlag("enable_http_cache", rootCmd.PersistentFlags().Lookup("enable-http-cache"))
+	_ = viper.BindPFlag("http_cache_max_entries", rootCmd.PersistentFlags().Lookup("http-cache-max-entries"))
+	_ = viper.BindPFlag("http_cache_max_bytes", rootCmd.PersistentFlags().Lookup("http-cache-max-bytes"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
@@ -0,0 +1,174 @@
+// Package diff parses unified diffs, such as the patch of a pull request file, into hunks
+// with the line numbers of each line on both sides of the change.
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kinds of diff lines.
+const (
+	LineContext = "context"
+	LineAdded   = "added"
+	LineDeleted = "deleted"
+)
+
+// Line is a line of a hunk. OldLine is set for context and deleted lines, NewLine for context and
+// added lines.
+type Line struct {
+	Kind    string `json:"kind"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+	Content string `json:"content"`
+	// NoNewlineAtEOF is set when the line is the last line of its file and has no trailing newline.
+	NoNewlineAtEOF bool `json:"no_newline_at_eof,omitempty"`
+}
+
+// Hunk is a contiguous block of changes. Section is the text after the closing @@ of the hunk
+// header, usually the enclosing function.
+type Hunk struct {
+	OldStart int    `json:"old_start"`
+	OldLines int    `json:"old_lines"`
+	NewStart int    `json:"new_start"`
+	NewLines int    `json:"new_lines"`
+	Section  string `json:"section,omitempty"`
+	Lines    []Line `json:"lines"`
+}
+
+// LineRange is an inclusive range of line numbers.
+type LineRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// Parse parses a unified diff of a single file into its hunks. File headers before the first hunk,
+// such as "diff --git", "---" and "+++" lines, are skipped. An empty patch, as GitHub returns for
+// renames without changes and for binary files, has no hunks.
+func Parse(patch string) ([]Hunk, error) {
+	lines := strings.Split(strings.TrimSuffix(patch, "\n"), "\n")
+	if patch == "" {
+		lines = nil
+	}
+
+	hunks := []Hunk{}
+	var current *Hunk
+	// oldLine and newLine are the numbers of the next line on each side, and oldLeft and newLeft
+	// the number of lines the current hunk header says are still to come.
+	var oldLine, newLine, oldLeft, newLeft int
+	for i, text := range lines {
+		if strings.HasPrefix(text, "@@") {
+			if current != nil && (oldLeft > 0 || newLeft > 0) {
+				return nil, fmt.Errorf("line %d: hunk ends %d old and %d new lines short", i+1, oldLeft, newLeft)
+			}
+			hunk, err := parseHunkHeader(text)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			hunks = append(hunks, hunk)
+			current = &hunks[len(hunks)-1]
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+			continue
+		}
+		if current == nil {
+			// File headers come before the first hunk
+			continue
+		}
+
+		if strings.HasPrefix(text, `\`) {
+			if len(current.Lines) == 0 {
+				return nil, fmt.Errorf("line %d: %q does not follow a line", i+1, text)
+			}
+			current.Lines[len(current.Lines)-1].NoNewlineAtEOF = true
+			continue
+		}
+		if oldLeft == 0 && newLeft == 0 {
+			return nil, fmt.Errorf("line %d: unexpected line after the end of the hunk", i+1)
+		}
+
+		// Some tools strip the trailing space of empty context lines
+		if text == "" {
+			text = " "
+		}
+		line := Line{Content: text[1:]}
+		switch text[0] {
+		case ' ':
+			line.Kind, line.OldLine, line.NewLine = LineContext, oldLine, newLine
+			oldLine, newLine = oldLine+1, newLine+1
+			oldLeft, newLeft = oldLeft-1, newLeft-1
+		case '-':
+			line.Kind, line.OldLine = LineDeleted, oldLine
+			oldLine, oldLeft = oldLine+1, oldLeft-1
+		case '+':
+			line.Kind, line.NewLine = LineAdded, newLine
+			newLine, newLeft = newLine+1, newLeft-1
+		default:
+			return nil, fmt.Errorf("line %d: unexpected line prefix %q", i+1, text[0])
+		}
+		if oldLeft < 0 || newLeft < 0 {
+			return nil, fmt.Errorf("line %d: hunk has more lines than its header says", i+1)
+		}
+		current.Lines = append(current.Lines, line)
+	}
+	if current != nil && (oldLeft > 0 || newLeft > 0) {
+		return nil, fmt.Errorf("hunk ends %d old and %d new lines short", oldLeft, newLeft)
+	}
+	return hunks, nil
+}
+
+func parseHunkHeader(text string) (Hunk, error) {
+	match := hunkHeaderPattern.FindStringSubmatch(text)
+	if match == nil {
+		return Hunk{}, fmt.Errorf("invalid hunk header %q", text)
+	}
+	// Omitted line counts are 1
+	count := func(s string) int {
+		if s == "" {
+			return 1
+		}
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	oldStart, _ := strconv.Atoi(match[1])
+	newStart, _ := strconv.Atoi(match[3])
+	return Hunk{
+		OldStart: oldStart,
+		OldLines: count(match[2]),
+		NewStart: newStart,
+		NewLines: count(match[4]),
+		Section:  match[5],
+		Lines:    []Line{},
+	}, nil
+}
+
+// CommentableRanges returns the line ranges that review comments can be placed on: on the left
+// side, the deleted and context lines by their old line number, and on the right side, the added
+// and context lines by their new line number.
+func CommentableRanges(hunks []Hunk) (left, right []LineRange) {
+	left, right = []LineRange{}, []LineRange{}
+	for _, hunk := range hunks {
+		for _, line := range hunk.Lines {
+			if line.Kind != LineAdded {
+				left = extendRanges(left, line.OldLine)
+			}
+			if line.Kind != LineDeleted {
+				right = extendRanges(right, line.NewLine)
+			}
+		}
+	}
+	return left, right
+}
+
+// extendRanges adds line to the last range if it directly follows it, or starts a new range.
+func extendRanges(ranges []LineRange, line int) []LineRange {
+	if n := len(ranges); n > 0 && ranges[n-1].End+1 == line {
+		ranges[n-1].End = line
+		return ranges
+	}
+	return append(ranges, LineRange{Start: line, End: line})
+}
@@ -0,0 +1,197 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		patch         string
+		expectedHunks []Hunk
+		expectedLeft  []LineRange
+		expectedRight []LineRange
+	}{
+		{
+			name:          "empty patch",
+			patch:         "",
+			expectedHunks: []Hunk{},
+			expectedLeft:  []LineRange{},
+			expectedRight: []LineRange{},
+		},
+		{
+			name:  "modification with context",
+			patch: "@@ -10,4 +10,5 @@ func main() {\n \ta := 1\n-\tb := 2\n+\tb := 3\n+\tc := 4\n \treturn\n }",
+			expectedHunks: []Hunk{
+				{
+					OldStart: 10, OldLines: 4, NewStart: 10, NewLines: 5, Section: "func main() {",
+					Lines: []Line{
+						{Kind: LineContext, OldLine: 10, NewLine: 10, Content: "\ta := 1"},
+						{Kind: LineDeleted, OldLine: 11, Content: "\tb := 2"},
+						{Kind: LineAdded, NewLine: 11, Content: "\tb := 3"},
+						{Kind: LineAdded, NewLine: 12, Content: "\tc := 4"},
+						{Kind: LineContext, OldLine: 12, NewLine: 13, Content: "\treturn"},
+						{Kind: LineContext, OldLine: 13, NewLine: 14, Content: "}"},
+					},
+				},
+			},
+			expectedLeft:  []LineRange{{Start: 10, End: 13}},
+			expectedRight: []LineRange{{Start: 10, End: 14}},
+		},
+		{
+			name:  "add only",
+			patch: "@@ -0,0 +1,3 @@\n+package main\n+\n+func main() {}\n",
+			expectedHunks: []Hunk{
+				{
+					OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 3,
+					Lines: []Line{
+						{Kind: LineAdded, NewLine: 1, Content: "package main"},
+						{Kind: LineAdded, NewLine: 2, Content: ""},
+						{Kind: LineAdded, NewLine: 3, Content: "func main() {}"},
+					},
+				},
+			},
+			expectedLeft:  []LineRange{},
+			expectedRight: []LineRange{{Start: 1, End: 3}},
+		},
+		{
+			name:  "delete only",
+			patch: "@@ -1,2 +0,0 @@\n-first\n-second",
+			expectedHunks: []Hunk{
+				{
+					OldStart: 1, OldLines: 2, NewStart: 0, NewLines: 0,
+					Lines: []Line{
+						{Kind: LineDeleted, OldLine: 1, Content: "first"},
+						{Kind: LineDeleted, OldLine: 2, Content: "second"},
+					},
+				},
+			},
+			expectedLeft:  []LineRange{{Start: 1, End: 2}},
+			expectedRight: []LineRange{},
+		},
+		{
+			name: "rename with changes and file headers",
+			patch: "diff --git a/old.txt b/new.txt\nsimilarity index 90%\nrename from old.txt\nrename to new.txt\n" +
+				"--- a/old.txt\n+++ b/new.txt\n@@ -1 +1 @@\n-hello\n+hello world\n",
+			expectedHunks: []Hunk{
+				{
+					OldStart: 1, OldLines: 1, NewStart: 1, NewLines: 1,
+					Lines: []Line{
+						{Kind: LineDeleted, OldLine: 1, Content: "hello"},
+						{Kind: LineAdded, NewLine: 1, Content: "hello world"},
+					},
+				},
+			},
+			expectedLeft:  []LineRange{{Start: 1, End: 1}},
+			expectedRight: []LineRange{{Start: 1, End: 1}},
+		},
+		{
+			name:  "no newline at end of file",
+			patch: "@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+			expectedHunks: []Hunk{
+				{
+					OldStart: 1, OldLines: 2, NewStart: 1, NewLines: 2,
+					Lines: []Line{
+						{Kind: LineContext, OldLine: 1, NewLine: 1, Content: "a"},
+						{Kind: LineDeleted, OldLine: 2, Content: "b", NoNewlineAtEOF: true},
+						{Kind: LineAdded, NewLine: 2, Content: "b"},
+					},
+				},
+			},
+			expectedLeft:  []LineRange{{Start: 1, End: 2}},
+			expectedRight: []LineRange{{Start: 1, End: 2}},
+		},
+		{
+			name:  "multiple hunks and stripped empty context line",
+			patch: "@@ -1,3 +1,3 @@\n one\n\n-three\n+3\n@@ -20,2 +20,3 @@ type T struct\n twenty\n+inserted\n twenty-one",
+			expectedHunks: []Hunk{
+				{
+					OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 3,
+					Lines: []Line{
+						{Kind: LineContext, OldLine: 1, NewLine: 1, Content: "one"},
+						{Kind: LineContext, OldLine: 2, NewLine: 2, Content: ""},
+						{Kind: LineDeleted, OldLine: 3, Content: "three"},
+						{Kind: LineAdded, NewLine: 3, Content: "3"},
+					},
+				},
+				{
+					OldStart: 20, OldLines: 2, NewStart: 20, NewLines: 3, Section: "type T struct",
+					Lines: []Line{
+						{Kind: LineContext, OldLine: 20, NewLine: 20, Content: "twenty"},
+						{Kind: LineAdded, NewLine: 21, Content: "inserted"},
+						{Kind: LineContext, OldLine: 21, NewLine: 22, Content: "twenty-one"},
+					},
+				},
+			},
+			expectedLeft:  []LineRange{{Start: 1, End: 3}, {Start: 20, End: 21}},
+			expectedRight: []LineRange{{Start: 1, End: 3}, {Start: 20, End: 22}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			hunks, err := Parse(tc.patch)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHunks, hunks)
+
+			left, right := CommentableRanges(hunks)
+			assert.Equal(t, tc.expectedLeft, left)
+			assert.Equal(t, tc.expectedRight, right)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		patch         string
+		expectedError string
+	}{
+		{
+			name:          "invalid hunk header",
+			patch:         "@@ -a +b @@\n",
+			expectedError: `line 1: invalid hunk header "@@ -a +b @@"`,
+		},
+		{
+			name:          "truncated hunk",
+			patch:         "@@ -1,5 +1,10 @@",
+			expectedError: "hunk ends 5 old and 10 new lines short",
+		},
+		{
+			name:          "hunk followed by the next one too early",
+			patch:         "@@ -1,2 +1,2 @@\n a\n@@ -9 +9 @@\n-b\n+c",
+			expectedError: "line 3: hunk ends 1 old and 1 new lines short",
+		},
+		{
+			name:          "more lines than the header",
+			patch:         "@@ -1 +1 @@\n-a\n-b\n+c",
+			expectedError: "line 3: hunk has more lines than its header says",
+		},
+		{
+			name:          "line after the end of the hunk",
+			patch:         "@@ -1 +1 @@\n-a\n+b\n c",
+			expectedError: "line 4: unexpected line after the end of the hunk",
+		},
+		{
+			name:          "unknown line prefix",
+			patch:         "@@ -1 +1 @@\n*a",
+			expectedError: `line 2: unexpected line prefix '*'`,
+		},
+		{
+			name:          "no newline marker without a line",
+			patch:         "@@ -0,0 +0,0 @@\n\\ No newline at end of file",
+			expectedError: "does not follow a line",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.patch)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
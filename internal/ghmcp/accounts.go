@@ -0,0 +1,202 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/cache"
+	gogithub "github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// PrimaryAccount names the account of the server's own host and token, which tool calls use
+// unless they select another account.
+const PrimaryAccount = "primary"
+
+// Account is a named GitHub host and token that tool calls can select with the account parameter.
+type Account struct {
+	Name  string `json:"name"`
+	Host  string `json:"host"`
+	Token string `json:"token"`
+}
+
+// LoadAccounts reads the accounts of a JSON file holding an array of {"name", "host", "token"}
+// objects. An empty host targets github.com.
+func LoadAccounts(path string) ([]Account, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accounts file: %w", err)
+	}
+	var accounts []Account
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, fmt.Errorf("failed to parse accounts file %s: %w", path, err)
+	}
+	return accounts, nil
+}
+
+// githubClients are the clients of one account.
+type githubClients struct {
+	rest          *gogithub.Client
+	gqlHTTPClient *http.Client
+	gql           *githubv4.Client
+	rawURL        *url.URL
+}
+
+// newGitHubClients constructs the clients of one account. The REST client sends its requests through
+// restTransport and the GraphQL client through gqlTransport.
+func newGitHubClients(version, host, token string, restTransport, gqlTransport http.RoundTripper) (*githubClients, error) {
+	apiHost, err := parseAPIHost(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API host: %w", err)
+	}
+
+	// Construct our REST client. Its HTTP client also fetches the signed URLs that release assets
+	// redirect to, so the token is limited to the hosts of the API.
+	restClient := gogithub.NewClient(&http.Client{
+		Transport: &bearerAuthTransport{
+			transport: restTransport,
+			token:     token,
+			hosts:     []string{apiHost.baseRESTURL.Host, apiHost.uploadURL.Host, apiHost.rawURL.Host},
+		},
+	})
+	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", version)
+	restClient.BaseURL = apiHost.baseRESTURL
+	restClient.UploadURL = apiHost.uploadURL
+
+	// Construct our GraphQL client
+	// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
+	// did the necessary API host parsing so that github.com will return the correct URL anyway.
+	gqlHTTPClient := &http.Client{
+		Transport: &bearerAuthTransport{
+			transport: gqlTransport,
+			token:     token,
+		},
+	} // We're going to wrap the Transport later in beforeInit
+	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
+
+	return &githubClients{
+		rest:          restClient,
+		gqlHTTPClient: gqlHTTPClient,
+		gql:           gqlClient,
+		rawURL:        apiHost.rawURL,
+	}, nil
+}
+
+// setUserAgent makes both clients send userAgent.
+func (c *githubClients) setUserAgent(userAgent string) {
+	c.rest.UserAgent = userAgent
+	c.gqlHTTPClient.Transport = &userAgentTransport{
+		transport: c.gqlHTTPClient.Transport,
+		agent:     userAgent,
+	}
+}
+
+// accountClients holds the clients of the primary account and of each configured account.
+type accountClients struct {
+	// names lists the accounts in the order configured, starting with the primary account
+	names   []string
+	clients map[string]*githubClients
+}
+
+func newAccountClients(cfg MCPServerConfig, transport http.RoundTripper) (*accountClients, error) {
+	// One cache serves the REST clients of all accounts, as its entries are keyed by token
+	restTransport := transport
+	if cfg.EnableHTTPCache {
+		restTransport = cache.NewTransport(transport, cache.New(cfg.HTTPCacheMaxEntries, cfg.HTTPCacheMaxBytes))
+	}
+
+	primary, err := newGitHubClients(cfg.Version, cfg.Host, cfg.Token, restTransport, transport)
+	if err != nil {
+		return nil, err
+	}
+	a := &accountClients{
+		names:   []string{PrimaryAccount},
+		clients: map[string]*githubClients{PrimaryAccount: primary},
+	}
+
+	for _, account := range cfg.Accounts {
+		if account.Name == "" {
+			return nil, fmt.Errorf("account name must not be empty")
+		}
+		if _, exists := a.clients[account.Name]; exists {
+			return nil, fmt.Errorf("account %q is configured more than once", account.Name)
+		}
+		if account.Token == "" {
+			return nil, fmt.Errorf("account %q has no token", account.Name)
+		}
+		clients, err := newGitHubClients(cfg.Version, account.Host, account.Token, restTransport, transport)
+		if err != nil {
+			return nil, fmt.Errorf("account %q: %w", account.Name, err)
+		}
+		a.names = append(a.names, account.Name)
+		a.clients[account.Name] = clients
+	}
+	return a, nil
+}
+
+func (a *accountClients) setUserAgent(userAgent string) {
+	for _, clients := range a.clients {
+		clients.setUserAgent(userAgent)
+	}
+}
+
+type accountKey struct{}
+
+// fromContext returns the clients of the account accountMiddleware selected for the tool call,
+// or of the primary account.
+func (a *accountClients) fromContext(ctx context.Context) *githubClients {
+	if name, ok := ctx.Value(accountKey{}).(string); ok {
+		if clients, ok := a.clients[name]; ok {
+			return clients
+		}
+	}
+	return a.clients[PrimaryAccount]
+}
+
+// middleware selects the account named by the account argument of each tool call, rejecting
+// names that are not configured.
+func (a *accountClients) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		value, ok := request.GetArguments()["account"]
+		if !ok || value == nil {
+			return next(ctx, request)
+		}
+		name, ok := value.(string)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("parameter account is not of type string, is %T", value)), nil
+		}
+		if _, ok := a.clients[name]; !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("unknown account %q, configured accounts: %s", name, strings.Join(a.names, ", "))), nil
+		}
+		return next(context.WithValue(ctx, accountKey{}, name), request)
+	}
+}
+
+// toolFilter adds the optional account parameter to the schema of each listed tool.
+func (a *accountClients) toolFilter(_ context.Context, tools []mcp.Tool) []mcp.Tool {
+	property := map[string]any{
+		"type":        "string",
+		"description": fmt.Sprintf("Account to call GitHub as. Defaults to %s", PrimaryAccount),
+		"enum":        a.names,
+	}
+	filtered := make([]mcp.Tool, len(tools))
+	for i, tool := range tools {
+		// The registered tool shares its properties, so they are copied rather than changed
+		properties := maps.Clone(tool.InputSchema.Properties)
+		if properties == nil {
+			properties = map[string]any{}
+		}
+		properties["account"] = property
+		tool.InputSchema.Properties = properties
+		filtered[i] = tool
+	}
+	return filtered
+}
@@ -0,0 +1,193 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTransport answers like fakeGitHubTransport and records the URL and authorization of each request.
+type recordingTransport struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req.Header.Get("Authorization")+" "+req.URL.String())
+	t.mu.Unlock()
+	return fakeGitHubTransport{}.RoundTrip(req)
+}
+
+func (t *recordingTransport) reset() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	requests := t.requests
+	t.requests = nil
+	return requests
+}
+
+func TestAccountMiddlewareSelectsClientsPerCall(t *testing.T) {
+	primaryTransport, workTransport := &recordingTransport{}, &recordingTransport{}
+	primary, err := newGitHubClients("test", "", "primary-token", primaryTransport, primaryTransport)
+	require.NoError(t, err)
+	work, err := newGitHubClients("test", "https://ghe.example.com", "work-token", workTransport, workTransport)
+	require.NoError(t, err)
+	accounts := &accountClients{
+		names:   []string{PrimaryAccount, "work"},
+		clients: map[string]*githubClients{PrimaryAccount: primary, "work": work},
+	}
+
+	// A tool that makes one REST call and one GraphQL query with the clients of its call
+	handler := accounts.middleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		clients := accounts.fromContext(ctx)
+		if _, _, err := clients.rest.Users.Get(ctx, ""); err != nil {
+			return nil, err
+		}
+		var query struct {
+			Viewer struct {
+				Login githubv4.String
+			}
+		}
+		if err := clients.gql.Query(ctx, &query, nil); err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText("done"), nil
+	})
+	call := func(args map[string]any) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = args
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("defaults to the primary account", func(t *testing.T) {
+		result := call(map[string]any{"owner": "octocat"})
+		require.False(t, result.IsError)
+		assert.Equal(t, []string{
+			"Bearer primary-token https://api.github.com/user",
+			"Bearer primary-token https://api.github.com/graphql",
+		}, primaryTransport.reset())
+		assert.Empty(t, workTransport.reset())
+	})
+
+	t.Run("uses the selected account", func(t *testing.T) {
+		result := call(map[string]any{"account": "work"})
+		require.False(t, result.IsError)
+		assert.Equal(t, []string{
+			"Bearer work-token https://ghe.example.com/api/v3/user",
+			"Bearer work-token https://ghe.example.com/api/graphql",
+		}, workTransport.reset())
+		assert.Empty(t, primaryTransport.reset())
+
+		// The next call without an account is back on the primary account
+		result = call(map[string]any{})
+		require.False(t, result.IsError)
+		assert.Len(t, primaryTransport.reset(), 2)
+		assert.Empty(t, workTransport.reset())
+	})
+
+	t.Run("rejects an unknown account", func(t *testing.T) {
+		result := call(map[string]any{"account": "personal"})
+		require.True(t, result.IsError)
+		assert.Equal(t, `unknown account "personal", configured accounts: primary, work`, result.Content[0].(mcp.TextContent).Text)
+		assert.Empty(t, primaryTransport.reset())
+		assert.Empty(t, workTransport.reset())
+	})
+}
+
+func TestRESTClientSendsTokenOnlyToGitHubHosts(t *testing.T) {
+	transport := &recordingTransport{}
+	clients, err := newGitHubClients("test", "", "token", transport, transport)
+	require.NoError(t, err)
+
+	for _, url := range []string{
+		"https://api.github.com/user",
+		"https://uploads.github.com/repos/owner/repo/releases/1/assets",
+		"https://raw.githubusercontent.com/owner/repo/main/README.md",
+		"https://objects.githubusercontent.com/release-assets/1?signature=abc",
+	} {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+		require.NoError(t, err)
+		resp, err := clients.rest.Client().Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	// Signed release asset URLs are fetched through the same client, without the token
+	assert.Equal(t, []string{
+		"Bearer token https://api.github.com/user",
+		"Bearer token https://uploads.github.com/repos/owner/repo/releases/1/assets",
+		"Bearer token https://raw.githubusercontent.com/owner/repo/main/README.md",
+		" https://objects.githubusercontent.com/release-assets/1?signature=abc",
+	}, transport.reset())
+}
+
+func TestAccountToolFilterAddsAccountParameter(t *testing.T) {
+	accounts := &accountClients{names: []string{PrimaryAccount, "work"}}
+	tool := mcp.NewTool("get_me", mcp.WithString("reason"))
+
+	filtered := accounts.toolFilter(context.Background(), []mcp.Tool{tool, mcp.NewTool("no_params")})
+	require.Len(t, filtered, 2)
+	for _, filteredTool := range filtered {
+		property, ok := filteredTool.InputSchema.Properties["account"].(map[string]any)
+		require.True(t, ok, filteredTool.Name)
+		assert.Equal(t, []string{PrimaryAccount, "work"}, property["enum"])
+		assert.NotContains(t, filteredTool.InputSchema.Required, "account")
+	}
+	assert.Contains(t, filtered[0].InputSchema.Properties, "reason")
+
+	// The registered tool is left alone
+	assert.NotContains(t, tool.InputSchema.Properties, "account")
+}
+
+func TestNewAccountClientsValidatesAccounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		accounts []Account
+		expected string
+	}{
+		{"missing name", []Account{{Token: "token"}}, "account name must not be empty"},
+		{"duplicate", []Account{{Name: "work", Token: "a"}, {Name: "work", Token: "b"}}, `account "work" is configured more than once`},
+		{"primary name", []Account{{Name: PrimaryAccount, Token: "token"}}, `account "primary" is configured more than once`},
+		{"missing token", []Account{{Name: "work"}}, `account "work" has no token`},
+		{"bad host", []Account{{Name: "work", Host: "ghe.example.com", Token: "token"}}, `account "work": failed to parse API host`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newAccountClients(MCPServerConfig{Token: "primary-token", Accounts: tc.accounts}, http.DefaultTransport)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+
+	accounts, err := newAccountClients(MCPServerConfig{
+		Token:    "primary-token",
+		Accounts: []Account{{Name: "work", Host: "https://ghe.example.com", Token: "work-token"}},
+	}, http.DefaultTransport)
+	require.NoError(t, err)
+	assert.Equal(t, []string{PrimaryAccount, "work"}, accounts.names)
+	assert.Equal(t, "https://ghe.example.com/api/v3/", accounts.clients["work"].rest.BaseURL.String())
+}
+
+func TestLoadAccounts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "work", "host": "https://ghe.example.com", "token": "work-token"}]`), 0600))
+
+	accounts, err := LoadAccounts(path)
+	require.NoError(t, err)
+	assert.Equal(t, []Account{{Name: "work", Host: "https://ghe.example.com", Token: "work-token"}}, accounts)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "work"}`), 0600))
+	_, err = LoadAccounts(path)
+	assert.ErrorContains(t, err, "failed to parse accounts file")
+}
@@ -0,0 +1,174 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// apiCost counts the GitHub API requests made for one tool call.
+type apiCost struct {
+	restCalls    atomic.Int64
+	graphqlCalls atomic.Int64
+	bytes        atomic.Int64
+
+	// graphqlPoints are only reported when a GraphQL response carried rate limit headers
+	graphqlPoints      atomic.Int64
+	graphqlPointsKnown atomic.Bool
+}
+
+// APICostReport is what --report-api-cost appends to each tool result.
+type APICostReport struct {
+	RESTCalls     int64  `json:"rest_calls"`
+	GraphQLCalls  int64  `json:"graphql_calls"`
+	GraphQLPoints *int64 `json:"graphql_points,omitempty"`
+	Bytes         int64  `json:"bytes"`
+}
+
+func (c *apiCost) report() APICostReport {
+	report := APICostReport{
+		RESTCalls:    c.restCalls.Load(),
+		GraphQLCalls: c.graphqlCalls.Load(),
+		Bytes:        c.bytes.Load(),
+	}
+	if c.graphqlPointsKnown.Load() {
+		points := c.graphqlPoints.Load()
+		report.GraphQLPoints = &points
+	}
+	return report
+}
+
+type apiCostKey struct{}
+
+// withAPICost returns a context that counts the requests made with it through an apiCostTransport.
+func withAPICost(ctx context.Context) (context.Context, *apiCost) {
+	cost := &apiCost{}
+	return context.WithValue(ctx, apiCostKey{}, cost), cost
+}
+
+func apiCostFromContext(ctx context.Context) *apiCost {
+	cost, _ := ctx.Value(apiCostKey{}).(*apiCost)
+	return cost
+}
+
+// apiCostTransport counts the requests whose context carries an apiCost, along with the bytes of
+// their request and response bodies. Response bytes are counted as the body is read.
+//
+// GitHub does not report the points of a single GraphQL query, only the points used so far in the
+// rate limit window of the token, in X-RateLimit-Used. The points of a GraphQL response are taken
+// as the rise of that header since the previous response of the same token in the same window, so
+// they include the points of other requests made with the token in between. The first response of
+// a window counts all of its used points, and the first response seen by the process counts 1, the
+// cost of the cheapest query.
+type apiCostTransport struct {
+	transport http.RoundTripper
+
+	mu sync.Mutex
+	// graphqlUsed is the last GraphQL rate limit seen by host and Authorization header
+	graphqlUsed map[string]graphqlRateLimit
+}
+
+type graphqlRateLimit struct {
+	used  int64
+	reset string
+}
+
+func (t *apiCostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cost := apiCostFromContext(req.Context())
+	if cost == nil {
+		return t.transport.RoundTrip(req)
+	}
+
+	if strings.HasSuffix(req.URL.Path, "/graphql") {
+		cost.graphqlCalls.Add(1)
+	} else {
+		cost.restCalls.Add(1)
+	}
+	if req.ContentLength > 0 {
+		cost.bytes.Add(req.ContentLength)
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if points, ok := t.graphqlPoints(req, resp); ok {
+		cost.graphqlPoints.Add(points)
+		cost.graphqlPointsKnown.Store(true)
+	}
+	resp.Body = &countingReadCloser{ReadCloser: resp.Body, bytes: &cost.bytes}
+	return resp, nil
+}
+
+// graphqlPoints returns the GraphQL points of resp from its rate limit headers, if it has any.
+func (t *apiCostTransport) graphqlPoints(req *http.Request, resp *http.Response) (int64, bool) {
+	if resp.Header.Get("X-RateLimit-Resource") != "graphql" {
+		return 0, false
+	}
+	used, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Used"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	current := graphqlRateLimit{used: used, reset: resp.Header.Get("X-RateLimit-Reset")}
+	key := req.URL.Host + " " + req.Header.Get("Authorization")
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.graphqlUsed == nil {
+		t.graphqlUsed = make(map[string]graphqlRateLimit)
+	}
+	previous, seen := t.graphqlUsed[key]
+	switch {
+	case !seen:
+		t.graphqlUsed[key] = current
+		return 1, true
+	case previous.reset != current.reset:
+		t.graphqlUsed[key] = current
+		return used, true
+	case used > previous.used:
+		t.graphqlUsed[key] = current
+		return used - previous.used, true
+	default:
+		// A concurrent request already accounted for these points
+		return 0, true
+	}
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	bytes *atomic.Int64
+}
+
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.bytes.Add(int64(n))
+	return n, err
+}
+
+// apiCostMiddleware counts the GitHub API requests of each tool call and appends the count to
+// its result as a text content: {"api_cost": {"rest_calls": n, "graphql_calls": m, "bytes": b}},
+// with "graphql_points" when GitHub reported the GraphQL rate limit.
+func apiCostMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, cost := withAPICost(ctx)
+		result, err := next(ctx, request)
+		if err != nil || result == nil {
+			return result, err
+		}
+
+		report, err := json.Marshal(map[string]APICostReport{"api_cost": cost.report()})
+		if err != nil {
+			return result, nil
+		}
+		result.Content = append(result.Content, mcp.NewTextContent(string(report)))
+		return result, nil
+	}
+}
@@ -0,0 +1,162 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	gogithub "github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitHubTransport answers REST requests with a small JSON object and GraphQL requests with an empty data object.
+type fakeGitHubTransport struct{}
+
+func (fakeGitHubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := `{"login":"octocat"}`
+	if strings.HasSuffix(req.URL.Path, "/graphql") {
+		body = `{"data":{"viewer":{"login":"octocat"}}}`
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestAPICostTransportCountsRequestsOfTheContext(t *testing.T) {
+	client := &http.Client{Transport: &apiCostTransport{transport: fakeGitHubTransport{}}}
+
+	ctx, cost := withAPICost(context.Background())
+	for _, url := range []string{"https://api.github.com/user", "https://api.github.com/graphql"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(`{"query":"{}"}`))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	// Requests without an apiCost in their context are not counted
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.github.com/user", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	requestBytes := int64(2 * len(`{"query":"{}"}`))
+	responseBytes := int64(len(`{"login":"octocat"}`) + len(`{"data":{"viewer":{"login":"octocat"}}}`))
+	assert.Equal(t, APICostReport{RESTCalls: 1, GraphQLCalls: 1, Bytes: requestBytes + responseBytes}, cost.report())
+}
+
+// graphqlRateLimitTransport answers GraphQL requests with the rate limit headers of usedReset in turn.
+type graphqlRateLimitTransport struct {
+	usedReset [][2]string
+}
+
+func (t *graphqlRateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	if len(t.usedReset) > 0 {
+		header.Set("X-RateLimit-Resource", "graphql")
+		header.Set("X-RateLimit-Used", t.usedReset[0][0])
+		header.Set("X-RateLimit-Reset", t.usedReset[0][1])
+		t.usedReset = t.usedReset[1:]
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(`{"data":{}}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestAPICostTransportCountsGraphQLPointsFromRateLimitHeaders(t *testing.T) {
+	fake := &graphqlRateLimitTransport{}
+	client := &http.Client{Transport: &apiCostTransport{transport: fake}}
+	query := func(usedReset ...[2]string) APICostReport {
+		t.Helper()
+		fake.usedReset = usedReset
+		ctx, cost := withAPICost(context.Background())
+		for range usedReset {
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.github.com/graphql", strings.NewReader(`{"query":"{}"}`))
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+		}
+		return cost.report()
+	}
+	points := func(report APICostReport) int64 {
+		t.Helper()
+		require.NotNil(t, report.GraphQLPoints)
+		return *report.GraphQLPoints
+	}
+
+	// The first response of the process counts the cheapest query, later ones the rise of the used points
+	assert.Equal(t, int64(1+3+1), points(query([2]string{"40", "1700000000"}, [2]string{"43", "1700000000"}, [2]string{"44", "1700000000"})))
+	// A new window counts all of its used points
+	assert.Equal(t, int64(2), points(query([2]string{"2", "1700003600"})))
+	// Responses without rate limit headers leave the points out
+	fake.usedReset = nil
+	ctx, cost := withAPICost(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.github.com/graphql", strings.NewReader(`{"query":"{}"}`))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Nil(t, cost.report().GraphQLPoints)
+	assert.Equal(t, int64(1), cost.report().GraphQLCalls)
+}
+
+func TestAPICostMiddlewareReportsCostOfCompositeTool(t *testing.T) {
+	httpClient := &http.Client{Transport: &apiCostTransport{transport: fakeGitHubTransport{}}}
+	restClient := gogithub.NewClient(httpClient)
+	gqlClient := githubv4.NewClient(httpClient)
+
+	// A tool that makes two REST calls and one GraphQL query
+	handler := apiCostMiddleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		for range 2 {
+			if _, _, err := restClient.Users.Get(ctx, ""); err != nil {
+				return nil, err
+			}
+		}
+		var query struct {
+			Viewer struct {
+				Login githubv4.String
+			}
+		}
+		if err := gqlClient.Query(ctx, &query, nil); err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, "done", result.Content[0].(mcp.TextContent).Text)
+
+	var report struct {
+		APICost APICostReport `json:"api_cost"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[1].(mcp.TextContent).Text), &report))
+	assert.Equal(t, int64(2), report.APICost.RESTCalls)
+	assert.Equal(t, int64(1), report.APICost.GraphQLCalls)
+	assert.Greater(t, report.APICost.Bytes, int64(2*len(`{"login":"octocat"}`)))
+}
+
+func TestAPICostMiddlewareLeavesErrorsAlone(t *testing.T) {
+	handler := apiCostMiddleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, assert.AnError
+	})
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,64 @@
+package ghmcp
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxConcurrentGitHubCalls is the default limit of GitHub API requests in flight at once.
+const DefaultMaxConcurrentGitHubCalls = 10
+
+// maxQueueJitter is the longest random delay a request waits after it had to queue for a slot,
+// so that queued requests are not released to GitHub in a burst.
+const maxQueueJitter = 250 * time.Millisecond
+
+// concurrencyLimitTransport limits the number of requests in flight through it. Many tool calls
+// firing at once trip GitHub's secondary rate limits, so the REST and GraphQL clients share one.
+// A slot is held until the response headers arrive, and a request whose context is cancelled
+// while it waits gives up its place in the queue.
+type concurrencyLimitTransport struct {
+	transport http.RoundTripper
+	slots     chan struct{}
+	jitter    func() time.Duration
+}
+
+// newConcurrencyLimitTransport returns transport limited to limit requests in flight. A limit of
+// zero or less leaves transport unlimited.
+func newConcurrencyLimitTransport(transport http.RoundTripper, limit int) http.RoundTripper {
+	if limit <= 0 {
+		return transport
+	}
+	return &concurrencyLimitTransport{
+		transport: transport,
+		slots:     make(chan struct{}, limit),
+		jitter: func() time.Duration {
+			return rand.N(maxQueueJitter) // #nosec G404 - the jitter does not need to be unpredictable
+		},
+	}
+}
+
+func (t *concurrencyLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	select {
+	case t.slots <- struct{}{}:
+	default:
+		// All slots are taken, so wait for one and then spread out from the other waiters
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		timer := time.NewTimer(t.jitter())
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			<-t.slots
+			return nil, ctx.Err()
+		}
+	}
+	defer func() { <-t.slots }()
+
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,128 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowTransport holds each request until release is closed and records the most requests it saw in flight.
+type slowTransport struct {
+	release     chan struct{}
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+	started     chan struct{}
+}
+
+func newSlowTransport() *slowTransport {
+	return &slowTransport{release: make(chan struct{}), started: make(chan struct{}, 100)}
+}
+
+func (t *slowTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	n := t.inFlight.Add(1)
+	for {
+		maxN := t.maxInFlight.Load()
+		if n <= maxN || t.maxInFlight.CompareAndSwap(maxN, n) {
+			break
+		}
+	}
+	t.started <- struct{}{}
+	<-t.release
+	t.inFlight.Add(-1)
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func newLimitedTransport(transport http.RoundTripper, limit int) *concurrencyLimitTransport {
+	limited := newConcurrencyLimitTransport(transport, limit).(*concurrencyLimitTransport)
+	limited.jitter = func() time.Duration { return time.Millisecond }
+	return limited
+}
+
+func newRequest(ctx context.Context, t *testing.T) *http.Request {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	require.NoError(t, err)
+	return req
+}
+
+func TestConcurrencyLimitTransportCapsRequestsInFlight(t *testing.T) {
+	slow := newSlowTransport()
+	transport := newLimitedTransport(slow, 3)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := transport.RoundTrip(newRequest(context.Background(), t))
+			assert.NoError(t, err)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+
+	// Wait for the first three requests, then check that no more get through
+	for range 3 {
+		<-slow.started
+	}
+	select {
+	case <-slow.started:
+		t.Fatal("a fourth request started while three were in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(slow.release)
+	wg.Wait()
+	assert.Equal(t, int32(3), slow.maxInFlight.Load())
+	assert.Empty(t, transport.slots)
+}
+
+func TestConcurrencyLimitTransportCancelledWaiterGivesUpItsSlot(t *testing.T) {
+	slow := newSlowTransport()
+	transport := newLimitedTransport(slow, 1)
+
+	// Take the only slot
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		resp, err := transport.RoundTrip(newRequest(context.Background(), t))
+		assert.NoError(t, err)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-slow.started
+
+	// A waiting request returns as soon as its context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	waiterErr := make(chan error, 1)
+	go func() {
+		_, err := transport.RoundTrip(newRequest(ctx, t))
+		waiterErr <- err
+	}()
+	cancel()
+	select {
+	case err := <-waiterErr:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("cancelled request is still waiting for a slot")
+	}
+
+	// The cancelled request did not take a slot, so the next one runs once the first finishes
+	close(slow.release)
+	<-done
+	resp, err := transport.RoundTrip(newRequest(context.Background(), t))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Empty(t, transport.slots)
+}
+
+func TestNewConcurrencyLimitTransportWithoutLimit(t *testing.T) {
+	assert.Same(t, http.DefaultTransport, newConcurrencyLimitTransport(http.DefaultTransport, 0))
+}
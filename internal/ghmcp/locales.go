@@ -0,0 +1,106 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// localizedTools holds the tools of each translated locale by name. The server registers the
+// English tools, and tools/list swaps in the translations of the locale in the request context,
+// so that sessions with different locales can share one server. The tools of a locale are built
+// by the first session that asks for them.
+type localizedTools struct {
+	bundles translations.Bundles
+	build   func(locale string) ([]server.ServerTool, error)
+
+	mu       sync.Mutex
+	byLocale map[string]map[string]mcp.Tool
+}
+
+func newLocalizedTools(bundles translations.Bundles, build func(locale string) ([]server.ServerTool, error)) *localizedTools {
+	return &localizedTools{
+		bundles:  bundles,
+		build:    build,
+		byLocale: make(map[string]map[string]mcp.Tool),
+	}
+}
+
+// tools returns the tools translated into locale by name, building them on first use. It returns
+// nil for locales without translations, and when the tools cannot be built.
+func (l *localizedTools) tools(locale string) map[string]mcp.Tool {
+	if _, ok := l.bundles[locale]; !ok {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if byName, ok := l.byLocale[locale]; ok {
+		return byName
+	}
+	tools, err := l.build(locale)
+	if err != nil {
+		return nil
+	}
+	byName := make(map[string]mcp.Tool, len(tools))
+	for _, tool := range tools {
+		byName[tool.Tool.Name] = tool.Tool
+	}
+	l.byLocale[locale] = byName
+	return byName
+}
+
+// toolFilter replaces each listed tool with its translation into the locale of the request, if any.
+func (l *localizedTools) toolFilter(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	byName := l.tools(translations.LocaleFromContext(ctx))
+	if byName == nil {
+		return tools
+	}
+	localized := make([]mcp.Tool, len(tools))
+	for i, tool := range tools {
+		if translated, ok := byName[tool.Name]; ok {
+			tool = translated
+		}
+		localized[i] = tool
+	}
+	return localized
+}
+
+// LocaleHTTPContextFunc negotiates the locale of each request to an HTTP transport from its
+// X-Locale header, falling back to Accept-Language. Use it with server.WithHTTPContextFunc when
+// serving NewMCPServer over HTTP; this binary only serves stdio, whose locale is set by a flag.
+func LocaleHTTPContextFunc(bundles translations.Bundles) server.HTTPContextFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		return translations.ContextWithLocale(ctx, bundles.NegotiateRequest(r))
+	}
+}
+
+// loadLocales loads the translations of dir and negotiates the locale of the stdio session, which
+// is fixed for the lifetime of the process.
+func loadLocales(dir, locale string) (translations.Bundles, string, error) {
+	if dir == "" {
+		if locale != "" && locale != translations.DefaultLocale {
+			return nil, "", fmt.Errorf("locale %q requires a translations directory", locale)
+		}
+		return nil, translations.DefaultLocale, nil
+	}
+
+	bundles, err := translations.LoadBundles(dir)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load translations: %w", err)
+	}
+	if locale == "" || locale == translations.DefaultLocale {
+		return bundles, translations.DefaultLocale, nil
+	}
+	selected := bundles.Negotiate(locale)
+	if selected == translations.DefaultLocale {
+		return nil, "", fmt.Errorf("no translations for locale %q in %s (available: %s)", locale, dir, strings.Join(bundles.Locales(), ", "))
+	}
+	return bundles, selected, nil
+}
@@ -0,0 +1,92 @@
+package ghmcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalizedToolDescriptionsPerSession(t *testing.T) {
+	bundles := translations.Bundles{
+		"ja": {"TOOL_GET_ME_DESCRIPTION": "認証済みユーザーの詳細を取得します。"},
+		"de": {"TOOL_GET_ME_DESCRIPTION": "Details des authentifizierten Benutzers abrufen."},
+	}
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "token",
+		EnabledToolsets: []string{"context"},
+		Accounts:        []Account{{Name: "work", Token: "work-token"}},
+		Translator:      translations.NullTranslationHelper,
+		Locales:         bundles,
+	})
+	require.NoError(t, err)
+
+	httpServer := server.NewTestStreamableHTTPServer(ghServer, server.WithHTTPContextFunc(LocaleHTTPContextFunc(bundles)))
+	defer httpServer.Close()
+
+	getMe := func(headers map[string]string) mcp.Tool {
+		t.Helper()
+		ctx := context.Background()
+		c, err := client.NewStreamableHttpClient(httpServer.URL+"/mcp", transport.WithHTTPHeaders(headers))
+		require.NoError(t, err)
+		defer func() { _ = c.Close() }()
+
+		_, err = c.Initialize(ctx, mcp.InitializeRequest{})
+		require.NoError(t, err)
+		result, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+		require.NoError(t, err)
+		for _, tool := range result.Tools {
+			if tool.Name == "get_me" {
+				return tool
+			}
+		}
+		t.Fatalf("get_me tool not found")
+		return mcp.Tool{}
+	}
+
+	ja := getMe(map[string]string{translations.LocaleHeader: "ja-JP"})
+	de := getMe(map[string]string{"Accept-Language": "de-DE, en;q=0.5"})
+	en := getMe(nil)
+
+	assert.Equal(t, "認証済みユーザーの詳細を取得します。", ja.Description)
+	assert.Equal(t, "Details des authentifizierten Benutzers abrufen.", de.Description)
+	assert.NotEqual(t, ja.Description, en.Description)
+	assert.NotEqual(t, de.Description, en.Description)
+
+	// Other tool filters still apply to the translated tools
+	for _, tool := range []mcp.Tool{ja, de, en} {
+		assert.Contains(t, tool.InputSchema.Properties, "account")
+	}
+}
+
+func TestLocalizedToolsBuildEachLocaleOnFirstUse(t *testing.T) {
+	bundles := translations.Bundles{
+		"ja": {"TOOL_GET_ME_DESCRIPTION": "認証済みユーザーの詳細を取得します。"},
+		"de": {"TOOL_GET_ME_DESCRIPTION": "Details des authentifizierten Benutzers abrufen."},
+	}
+	var built []string
+	localized := newLocalizedTools(bundles, func(locale string) ([]server.ServerTool, error) {
+		built = append(built, locale)
+		return []server.ServerTool{{Tool: mcp.NewTool("get_me", mcp.WithDescription(bundles[locale]["TOOL_GET_ME_DESCRIPTION"]))}}, nil
+	})
+	tools := []mcp.Tool{mcp.NewTool("get_me", mcp.WithDescription("Get details of the authenticated user"))}
+	assert.Empty(t, built)
+
+	filter := func(locale string) string {
+		return localized.toolFilter(translations.ContextWithLocale(context.Background(), locale), tools)[0].Description
+	}
+	assert.Equal(t, "認証済みユーザーの詳細を取得します。", filter("ja"))
+	assert.Equal(t, "認証済みユーザーの詳細を取得します。", filter("ja"))
+	assert.Equal(t, "Get details of the authenticated user", filter(translations.DefaultLocale))
+	assert.Equal(t, []string{"ja"}, built)
+
+	assert.Equal(t, "Details des authentifizierten Benutzers abrufen.", filter("de"))
+	assert.Equal(t, []string{"ja", "de"}, built)
+}
@@ -9,12 +9,14 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
 
 	"github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/github"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/ratelimit"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v73/github"
@@ -45,32 +47,61 @@ type MCPServerConfig struct {
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
 
+	// ProtectDefaultBranch indicates if file write tools should refuse to write to a repository's default branch
+	ProtectDefaultBranch bool
+
+	// GistMaxBytes limits the total size of the files of a gist created by create_gist
+	GistMaxBytes int
+
+	// ReleaseAssetMaxBytes limits the size of a release asset downloaded by download_release_asset
+	ReleaseAssetMaxBytes int
+
+	// MaxConcurrentGitHubCalls limits the GitHub API requests in flight at once, across REST and GraphQL.
+	// Zero or less disables the limit.
+	MaxConcurrentGitHubCalls int
+
+	// MaxRetries is how often a GitHub API request turned away by a rate limit is retried. Zero or less
+	// disables retries.
+	MaxRetries int
+
+	// EnableHTTPCache revalidates repeated REST reads with ETags, serving unchanged responses from memory
+	EnableHTTPCache bool
+
+	// HTTPCacheMaxEntries and HTTPCacheMaxBytes bound the number and total body size of cached responses
+	HTTPCacheMaxEntries int
+	HTTPCacheMaxBytes   int64
+
+	// ReportAPICost appends the number of GitHub API requests and bytes transferred to each tool result
+	ReportAPICost bool
+
+	// Accounts are further GitHub hosts and tokens that tool calls can select with the account
+	// parameter, which is only offered when there are any. Host and Token are the primary account.
+	Accounts []Account
+
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
+
+	// Locales are translations of the tool descriptions on top of Translator. tools/list serves the
+	// locale in the request context, see translations.ContextWithLocale and LocaleHTTPContextFunc.
+	Locales translations.Bundles
+
+	// Logger receives debug logs of retried requests, defaulting to the standard logrus logger
+	Logger *logrus.Logger
 }
 
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
-	apiHost, err := parseAPIHost(cfg.Host)
+	// The REST and GraphQL clients share one limit on requests in flight. Retries wait outside of
+	// the limit, and are counted in the API cost like any other request.
+	transport := newConcurrencyLimitTransport(http.DefaultTransport, cfg.MaxConcurrentGitHubCalls)
+	transport = ratelimit.NewTransport(transport, cfg.MaxRetries, cfg.Logger)
+	if cfg.ReportAPICost {
+		transport = &apiCostTransport{transport: transport}
+	}
+
+	accounts, err := newAccountClients(cfg, transport)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse API host: %w", err)
-	}
-
-	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
-	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
-	restClient.BaseURL = apiHost.baseRESTURL
-	restClient.UploadURL = apiHost.uploadURL
-
-	// Construct our GraphQL client
-	// We're using NewEnterpriseClient here unconditionally as opposed to NewClient because we already
-	// did the necessary API host parsing so that github.com will return the correct URL anyway.
-	gqlHTTPClient := &http.Client{
-		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
-			token:     cfg.Token,
-		},
-	} // We're going to wrap the Transport later in beforeInit
-	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
+		return nil, err
+	}
 
 	// When a client send an initialize request, update the user agent to include the client info.
 	beforeInit := func(_ context.Context, _ any, message *mcp.InitializeRequest) {
@@ -81,12 +112,7 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 			message.Params.ClientInfo.Version,
 		)
 
-		restClient.UserAgent = userAgent
-
-		gqlHTTPClient.Transport = &userAgentTransport{
-			transport: gqlHTTPClient.Transport,
-			agent:     userAgent,
-		}
+		accounts.setUserAgent(userAgent)
 	}
 
 	hooks := &server.Hooks{
@@ -100,7 +126,25 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		},
 	}
 
-	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
+	serverOpts := []server.ServerOption{server.WithHooks(hooks)}
+	// Translate the tools before other filters change them, such as the account parameter. The
+	// translated tools are built further below, once the server they register on exists.
+	var localized *localizedTools
+	if len(cfg.Locales) > 0 {
+		serverOpts = append(serverOpts, server.WithToolFilter(func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+			return localized.toolFilter(ctx, tools)
+		}))
+	}
+	if cfg.ReportAPICost {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(apiCostMiddleware))
+	}
+	if len(cfg.Accounts) > 0 {
+		serverOpts = append(serverOpts,
+			server.WithToolHandlerMiddleware(accounts.middleware),
+			server.WithToolFilter(accounts.toolFilter),
+		)
+	}
+	ghServer := github.NewServer(cfg.Version, serverOpts...)
 
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
@@ -113,24 +157,32 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
-	getClient := func(_ context.Context) (*gogithub.Client, error) {
-		return restClient, nil // closing over client
+	getClient := func(ctx context.Context) (*gogithub.Client, error) {
+		return accounts.fromContext(ctx).rest, nil // closing over clients
 	}
 
-	getGQLClient := func(_ context.Context) (*githubv4.Client, error) {
-		return gqlClient, nil // closing over client
+	getGQLClient := func(ctx context.Context) (*githubv4.Client, error) {
+		return accounts.fromContext(ctx).gql, nil // closing over clients
 	}
 
 	getRawClient := func(ctx context.Context) (*raw.Client, error) {
-		client, err := getClient(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-		}
-		return raw.NewClient(client, apiHost.rawURL), nil // closing over client
+		clients := accounts.fromContext(ctx)
+		return raw.NewClient(clients.rest, clients.rawURL), nil // closing over clients
 	}
 
 	// Create default toolsets
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator)
+	serverInfo := github.ServerInfo{
+		Version:              cfg.Version,
+		Host:                 cfg.Host,
+		ReadOnly:             cfg.ReadOnly,
+		ProtectDefaultBranch: cfg.ProtectDefaultBranch,
+		GistMaxBytes:         cfg.GistMaxBytes,
+		ReleaseAssetMaxBytes: cfg.ReleaseAssetMaxBytes,
+	}
+	tsg, err := github.DefaultToolsetGroup(serverInfo, getClient, getGQLClient, getRawClient, cfg.Translator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create toolsets: %w", err)
+	}
 	err = tsg.EnableToolsets(enabledToolsets)
 
 	if err != nil {
@@ -145,6 +197,24 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		dynamic.RegisterTools(ghServer)
 	}
 
+	// Build the tools once more for each locale that a session asks for, for their descriptions.
+	// Their handlers are never called.
+	localized = newLocalizedTools(cfg.Locales, func(locale string) ([]server.ServerTool, error) {
+		t := cfg.Locales.Helper(cfg.Translator, locale)
+		localizedTsg, err := github.DefaultToolsetGroup(serverInfo, getClient, getGQLClient, getRawClient, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create toolsets for locale %q: %w", locale, err)
+		}
+		var tools []server.ServerTool
+		for _, toolset := range localizedTsg.Toolsets() {
+			tools = append(tools, toolset.GetAvailableTools()...)
+		}
+		if cfg.DynamicToolsets {
+			tools = append(tools, github.InitDynamicToolset(ghServer, localizedTsg, t).GetAvailableTools()...)
+		}
+		return tools, nil
+	})
+
 	return ghServer, nil
 }
 
@@ -169,10 +239,47 @@ type StdioServerConfig struct {
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
 
+	// ProtectDefaultBranch indicates if file write tools should refuse to write to a repository's default branch
+	ProtectDefaultBranch bool
+
+	// GistMaxBytes limits the total size of the files of a gist created by create_gist
+	GistMaxBytes int
+
+	// ReleaseAssetMaxBytes limits the size of a release asset downloaded by download_release_asset
+	ReleaseAssetMaxBytes int
+
+	// MaxConcurrentGitHubCalls limits the GitHub API requests in flight at once, across REST and GraphQL.
+	// Zero or less disables the limit.
+	MaxConcurrentGitHubCalls int
+
+	// MaxRetries is how often a GitHub API request turned away by a rate limit is retried. Zero or less
+	// disables retries.
+	MaxRetries int
+
+	// EnableHTTPCache revalidates repeated REST reads with ETags, serving unchanged responses from memory
+	EnableHTTPCache bool
+
+	// HTTPCacheMaxEntries and HTTPCacheMaxBytes bound the number and total body size of cached responses
+	HTTPCacheMaxEntries int
+	HTTPCacheMaxBytes   int64
+
+	// ReportAPICost appends the number of GitHub API requests and bytes transferred to each tool result
+	ReportAPICost bool
+
+	// Accounts are further GitHub hosts and tokens that tool calls can select with the account parameter
+	Accounts []Account
+
 	// ExportTranslations indicates if we should export translations
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
 
+	// TranslationsDir is a directory of translations.<locale>.json files
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
+	TranslationsDir string
+
+	// Locale selects the translations from TranslationsDir to use, falling back to English for missing keys
+	Locale string
+
 	// EnableCommandLogging indicates if we should log commands
 	EnableCommandLogging bool
 
@@ -187,22 +294,11 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	defer stop()
 
 	t, dumpTranslations := translations.TranslationHelper()
-
-	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:         cfg.Version,
-		Host:            cfg.Host,
-		Token:           cfg.Token,
-		EnabledToolsets: cfg.EnabledToolsets,
-		DynamicToolsets: cfg.DynamicToolsets,
-		ReadOnly:        cfg.ReadOnly,
-		Translator:      t,
-	})
+	locales, locale, err := loadLocales(cfg.TranslationsDir, cfg.Locale)
 	if err != nil {
-		return fmt.Errorf("failed to create MCP server: %w", err)
+		return err
 	}
 
-	stdioServer := server.NewStdioServer(ghServer)
-
 	logrusLogger := logrus.New()
 	if cfg.LogFilePath != "" {
 		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
@@ -213,8 +309,39 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		logrusLogger.SetLevel(logrus.DebugLevel)
 		logrusLogger.SetOutput(file)
 	}
+
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:                  cfg.Version,
+		Host:                     cfg.Host,
+		Token:                    cfg.Token,
+		EnabledToolsets:          cfg.EnabledToolsets,
+		DynamicToolsets:          cfg.DynamicToolsets,
+		ReadOnly:                 cfg.ReadOnly,
+		ProtectDefaultBranch:     cfg.ProtectDefaultBranch,
+		GistMaxBytes:             cfg.GistMaxBytes,
+		ReleaseAssetMaxBytes:     cfg.ReleaseAssetMaxBytes,
+		MaxConcurrentGitHubCalls: cfg.MaxConcurrentGitHubCalls,
+		MaxRetries:               cfg.MaxRetries,
+		EnableHTTPCache:          cfg.EnableHTTPCache,
+		HTTPCacheMaxEntries:      cfg.HTTPCacheMaxEntries,
+		HTTPCacheMaxBytes:        cfg.HTTPCacheMaxBytes,
+		ReportAPICost:            cfg.ReportAPICost,
+		Accounts:                 cfg.Accounts,
+		Translator:               t,
+		Locales:                  locales,
+		Logger:                   logrusLogger,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+
+	stdioServer := server.NewStdioServer(ghServer)
 	stdLogger := log.New(logrusLogger.Writer(), "stdioserver", 0)
 	stdioServer.SetErrorLogger(stdLogger)
+	// The stdio server has a single session, whose locale is set by the flag
+	stdioServer.SetContextFunc(func(ctx context.Context) context.Context {
+		return translations.ContextWithLocale(ctx, locale)
+	})
 
 	if cfg.ExportTranslations {
 		// Once server is initialized, all translations are loaded
@@ -396,12 +523,19 @@ func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error
 	return t.transport.RoundTrip(req)
 }
 
+// bearerAuthTransport authenticates requests with token. When hosts is set, only requests to those
+// hosts are authenticated, so that the token is not sent on to the storage URLs that the API
+// redirects release asset downloads to.
 type bearerAuthTransport struct {
 	transport http.RoundTripper
 	token     string
+	hosts     []string
 }
 
 func (t *bearerAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.hosts) > 0 && !slices.Contains(t.hosts, req.URL.Host) {
+		return t.transport.RoundTrip(req)
+	}
 	req = req.Clone(req.Context())
 	req.Header.Set("Authorization", "Bearer "+t.token)
 	return t.transport.RoundTrip(req)
@@ -7,13 +7,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/josephburnett/jd/v2"
 )
 
+// snapDir is the directory, relative to the package under test, that snapshots are stored in.
+const snapDir = "__toolsnaps__"
+
 // Test checks that the JSON schema for a tool has not changed unexpectedly.
 // It compares the marshaled JSON of the provided tool against a stored snapshot file.
-// If the UPDATE_TOOLSNAPS environment variable is set to "true", it updates the snapshot file instead.
+// If the UPDATE_TOOLSNAPS environment variable is set to "true" or "1", it updates the snapshot file instead.
 // If the snapshot does not exist and not running in CI, it creates the snapshot file.
 // If the snapshot does not exist and running in CI (GITHUB_ACTIONS="true"), it returns an error.
 // If the snapshot exists, it compares the tool's JSON to the snapshot and returns an error if they differ.
@@ -24,10 +29,10 @@ func Test(toolName string, tool any) error {
 		return fmt.Errorf("failed to marshal tool %s: %w", toolName, err)
 	}
 
-	snapPath := fmt.Sprintf("__toolsnaps__/%s.snap", toolName)
+	snapPath := filepath.Join(snapDir, toolName+".snap")
 
 	// If UPDATE_TOOLSNAPS is set, then we write the tool JSON to the snapshot file and exit
-	if os.Getenv("UPDATE_TOOLSNAPS") == "true" {
+	if updateRequested() {
 		return writeSnap(snapPath, toolJSON)
 	}
 
@@ -66,6 +71,51 @@ func Test(toolName string, tool any) error {
 	return nil
 }
 
+// CheckOrphans checks that every snapshot file belongs to one of the given tool names, which catches
+// tools that were renamed or removed without their snapshot being cleaned up.
+// If the UPDATE_TOOLSNAPS environment variable is set to "true" or "1", orphaned snapshots are deleted instead.
+// Returns an error listing the orphaned snapshots, or if the snapshot directory cannot be read.
+func CheckOrphans(toolNames []string) error {
+	registered := make(map[string]bool, len(toolNames))
+	for _, name := range toolNames {
+		registered[name] = true
+	}
+
+	snapPaths, err := filepath.Glob(filepath.Join(snapDir, "*.snap"))
+	if err != nil {
+		return fmt.Errorf("failed to list snapshot files: %w", err)
+	}
+
+	var orphans []string
+	for _, snapPath := range snapPaths {
+		toolName := strings.TrimSuffix(filepath.Base(snapPath), ".snap")
+		if registered[toolName] {
+			continue
+		}
+
+		if updateRequested() {
+			if err := os.Remove(snapPath); err != nil {
+				return fmt.Errorf("failed to remove orphaned snapshot file %s: %w", snapPath, err)
+			}
+			continue
+		}
+		orphans = append(orphans, toolName)
+	}
+
+	if len(orphans) > 0 {
+		sort.Strings(orphans)
+		return fmt.Errorf("tool snapshots exist for tools that are no longer registered: %s\nrun with `UPDATE_TOOLSNAPS=true` to remove them if this is expected", strings.Join(orphans, ", "))
+	}
+
+	return nil
+}
+
+// updateRequested reports whether snapshots should be rewritten rather than compared.
+func updateRequested() bool {
+	v := os.Getenv("UPDATE_TOOLSNAPS")
+	return v == "true" || v == "1"
+}
+
 func writeSnap(snapPath string, contents []byte) error {
 	// Ensure the directory exists
 	if err := os.MkdirAll(filepath.Dir(snapPath), 0700); err != nil {
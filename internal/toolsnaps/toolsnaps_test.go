@@ -113,6 +113,80 @@ func TestUpdateToolsnaps(t *testing.T) {
 	assert.NoError(t, statErr, "expected snapshot file to be written")
 }
 
+func TestUpdateToolsnapsWithOne(t *testing.T) {
+	withIsolatedWorkingDir(t)
+
+	// Given UPDATE_TOOLSNAPS is set to 1 and a non-matching snapshot file exists
+	t.Setenv("UPDATE_TOOLSNAPS", "1")
+	require.NoError(t, os.MkdirAll("__toolsnaps__", 0700))
+	require.NoError(t, os.WriteFile(filepath.Join("__toolsnaps__", "dummy.snap"), []byte(`{"name":"foo","value":1}`), 0600))
+	tool := dummyTool{"foo", 42}
+
+	// When we test the snapshot
+	err := Test("dummy", tool)
+
+	// Then it should succeed and rewrite the snapshot file with the new schema
+	require.NoError(t, err)
+	b, err := os.ReadFile(filepath.Join("__toolsnaps__", "dummy.snap"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"foo","value":42}`, string(b))
+}
+
+func TestCheckOrphansNoOrphans(t *testing.T) {
+	withIsolatedWorkingDir(t)
+	t.Setenv("UPDATE_TOOLSNAPS", "false")
+
+	// Given only snapshots for registered tools exist
+	require.NoError(t, os.MkdirAll("__toolsnaps__", 0700))
+	require.NoError(t, os.WriteFile(filepath.Join("__toolsnaps__", "dummy.snap"), []byte(`{}`), 0600))
+
+	// When we check for orphans
+	err := CheckOrphans([]string{"dummy", "another"})
+
+	// Then it should succeed
+	require.NoError(t, err)
+}
+
+func TestCheckOrphansDetectsRenamedTool(t *testing.T) {
+	withIsolatedWorkingDir(t)
+	t.Setenv("UPDATE_TOOLSNAPS", "false")
+
+	// Given snapshots exist for tools that are no longer registered
+	require.NoError(t, os.MkdirAll("__toolsnaps__", 0700))
+	require.NoError(t, os.WriteFile(filepath.Join("__toolsnaps__", "old_name.snap"), []byte(`{}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join("__toolsnaps__", "new_name.snap"), []byte(`{}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join("__toolsnaps__", "removed.snap"), []byte(`{}`), 0600))
+
+	// When we check for orphans
+	err := CheckOrphans([]string{"new_name"})
+
+	// Then it should error listing the orphaned snapshots, and leave them in place
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "old_name, removed")
+	_, statErr := os.Stat(filepath.Join("__toolsnaps__", "old_name.snap"))
+	assert.NoError(t, statErr, "expected orphaned snapshot file to be kept")
+}
+
+func TestCheckOrphansRemovesOrphansOnUpdate(t *testing.T) {
+	withIsolatedWorkingDir(t)
+
+	// Given UPDATE_TOOLSNAPS is set and a snapshot exists for a tool that is no longer registered
+	t.Setenv("UPDATE_TOOLSNAPS", "true")
+	require.NoError(t, os.MkdirAll("__toolsnaps__", 0700))
+	require.NoError(t, os.WriteFile(filepath.Join("__toolsnaps__", "old_name.snap"), []byte(`{}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join("__toolsnaps__", "new_name.snap"), []byte(`{}`), 0600))
+
+	// When we check for orphans
+	err := CheckOrphans([]string{"new_name"})
+
+	// Then it should succeed and remove only the orphaned snapshot
+	require.NoError(t, err)
+	_, statErr := os.Stat(filepath.Join("__toolsnaps__", "old_name.snap"))
+	assert.True(t, os.IsNotExist(statErr), "expected orphaned snapshot file to be removed")
+	_, statErr = os.Stat(filepath.Join("__toolsnaps__", "new_name.snap"))
+	assert.NoError(t, statErr, "expected registered snapshot file to be kept")
+}
+
 func TestMalformedSnapshotJSON(t *testing.T) {
 	withIsolatedWorkingDir(t)
 	// Ensure that UPDATE_TOOLSNAPS is not set for this test, which it might be if someone is running
@@ -0,0 +1,199 @@
+// Package cache revalidates repeated GitHub API reads with ETags, so that unchanged resources are
+// served from memory. GitHub does not count conditional requests answered with 304 Not Modified
+// against the rate limit.
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const (
+	// DefaultMaxEntries is the default number of responses kept in the cache.
+	DefaultMaxEntries = 100
+
+	// DefaultMaxBytes is the default total size of the response bodies kept in the cache.
+	DefaultMaxBytes = 10 << 20
+
+	// maxEntryFraction limits a single response body to this fraction of the cache's size, so that
+	// one large file does not push out everything else.
+	maxEntryFraction = 10
+)
+
+// entry is a cached response.
+type entry struct {
+	key    string
+	etag   string
+	status int
+	header http.Header
+	body   []byte
+}
+
+// Cache holds the most recently used responses, evicting the least recently used ones once it
+// holds more than maxEntries responses or maxBytes of response bodies. It is safe for concurrent use.
+type Cache struct {
+	mu            sync.Mutex
+	maxEntries    int
+	maxBytes      int64
+	maxEntryBytes int64
+	bytes         int64
+	order         *list.List // most recently used first
+	entries       map[string]*list.Element
+}
+
+// New returns an empty cache of at most maxEntries responses and maxBytes of response bodies.
+// Responses with a body larger than a tenth of maxBytes are not cached.
+func New(maxEntries int, maxBytes int64) *Cache {
+	return &Cache{
+		maxEntries:    maxEntries,
+		maxBytes:      maxBytes,
+		maxEntryBytes: maxBytes / maxEntryFraction,
+		order:         list.New(),
+		entries:       make(map[string]*list.Element),
+	}
+}
+
+// Len returns the number of cached responses.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *Cache) get(key string) (*entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*entry), true
+}
+
+func (c *Cache) add(e *entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[e.key]; ok {
+		c.remove(element)
+	}
+	c.entries[e.key] = c.order.PushFront(e)
+	c.bytes += int64(len(e.body))
+	for c.order.Len() > c.maxEntries || c.bytes > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache) remove(element *list.Element) {
+	e := c.order.Remove(element).(*entry)
+	delete(c.entries, e.key)
+	c.bytes -= int64(len(e.body))
+}
+
+// Transport sends GET requests whose response is cached with If-None-Match, and answers them
+// from the cache when GitHub replies 304 Not Modified, so callers only ever see the full response.
+type Transport struct {
+	transport http.RoundTripper
+	cache     *Cache
+}
+
+// NewTransport returns transport caching responses in cache.
+func NewTransport(transport http.RoundTripper, cache *Cache) *Transport {
+	return &Transport{transport: transport, cache: cache}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests that are already conditional or partial are left to the caller
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" ||
+		req.Header.Get("If-Modified-Since") != "" || req.Header.Get("Range") != "" {
+		return t.transport.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	cached, ok := t.cache.get(key)
+	if ok {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return cached.response(req, resp.Header), nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+
+	// Read at most one byte more than an entry may hold, to tell whether the body fits
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.cache.maxEntryBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(body)) > t.cache.maxEntryBytes {
+		resp.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+
+	t.cache.add(&entry{
+		key:    key,
+		etag:   etag,
+		status: resp.StatusCode,
+		header: resp.Header.Clone(),
+		body:   body,
+	})
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// response rebuilds the cached response to req. Headers of the 304 response, such as the current
+// rate limit, take precedence over the cached ones.
+func (e *entry) response(req *http.Request, notModified http.Header) *http.Response {
+	header := e.header.Clone()
+	for name, values := range notModified {
+		if name != "Content-Length" {
+			header[name] = values
+		}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(e.body)))
+
+	return &http.Response{
+		Status:        strconv.Itoa(e.status) + " " + http.StatusText(e.status),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// cacheKey identifies the response to req. Responses differ by media type, and must never be
+// served to a request made with another token, so both are part of the key. The token only goes
+// into the key as a hash.
+func cacheKey(req *http.Request) string {
+	auth := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.Method + " " + req.URL.String() + "\n" + req.Header.Get("Accept") + "\n" + hex.EncodeToString(auth[:])
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// etagServer serves bodies[path] with an ETag derived from it, answering 304 when the request's
+// If-None-Match matches. It records the If-None-Match header of each request.
+type etagServer struct {
+	*httptest.Server
+	mu          sync.Mutex
+	bodies      map[string]string
+	ifNoneMatch []string
+}
+
+func newETagServer(t *testing.T, bodies map[string]string) *etagServer {
+	s := &etagServer{bodies: bodies}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		body := s.bodies[r.URL.Path]
+		s.ifNoneMatch = append(s.ifNoneMatch, r.Header.Get("If-None-Match"))
+		requests := len(s.ifNoneMatch)
+		s.mu.Unlock()
+
+		etag := fmt.Sprintf(`"%x"`, sha256.Sum256([]byte(body)))
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(5000-requests))
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *etagServer) setBody(path, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bodies[path] = body
+}
+
+func get(t *testing.T, transport http.RoundTripper, url string, header map[string]string) (*http.Response, string) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestTransportServesCachedBodyOnNotModified(t *testing.T) {
+	server := newETagServer(t, map[string]string{"/repos/owner/repo": `{"name": "repo"}`})
+	transport := NewTransport(http.DefaultTransport, New(DefaultMaxEntries, DefaultMaxBytes))
+
+	first, body := get(t, transport, server.URL+"/repos/owner/repo", nil)
+	assert.Equal(t, http.StatusOK, first.StatusCode)
+	assert.Equal(t, `{"name": "repo"}`, body)
+
+	second, body := get(t, transport, server.URL+"/repos/owner/repo", nil)
+	assert.Equal(t, http.StatusOK, second.StatusCode)
+	assert.Equal(t, `{"name": "repo"}`, body)
+	assert.Equal(t, "application/json", second.Header.Get("Content-Type"))
+	// Headers of the 304 response replace the cached ones
+	assert.Equal(t, "4998", second.Header.Get("X-RateLimit-Remaining"))
+
+	assert.Equal(t, []string{"", first.Header.Get("ETag")}, server.ifNoneMatch)
+}
+
+func TestTransportRefreshesChangedResources(t *testing.T) {
+	server := newETagServer(t, map[string]string{"/repos/owner/repo": `{"name": "repo"}`})
+	transport := NewTransport(http.DefaultTransport, New(DefaultMaxEntries, DefaultMaxBytes))
+
+	get(t, transport, server.URL+"/repos/owner/repo", nil)
+	server.setBody("/repos/owner/repo", `{"name": "renamed"}`)
+
+	_, body := get(t, transport, server.URL+"/repos/owner/repo", nil)
+	assert.Equal(t, `{"name": "renamed"}`, body)
+
+	// The new response replaced the old one
+	_, body = get(t, transport, server.URL+"/repos/owner/repo", nil)
+	assert.Equal(t, `{"name": "renamed"}`, body)
+	assert.Equal(t, 1, transport.cache.Len())
+}
+
+func TestTransportKeysByTokenAndMediaType(t *testing.T) {
+	server := newETagServer(t, map[string]string{"/repos/owner/repo/pulls/1": `{"number": 1}`})
+	transport := NewTransport(http.DefaultTransport, New(DefaultMaxEntries, DefaultMaxBytes))
+	url := server.URL + "/repos/owner/repo/pulls/1"
+
+	get(t, transport, url, map[string]string{"Authorization": "Bearer one"})
+	get(t, transport, url, map[string]string{"Authorization": "Bearer two"})
+	get(t, transport, url, map[string]string{"Authorization": "Bearer one", "Accept": "application/vnd.github.v3.diff"})
+
+	assert.Equal(t, []string{"", "", ""}, server.ifNoneMatch)
+	assert.Equal(t, 3, transport.cache.Len())
+}
+
+func TestTransportSkipsUncacheableResponses(t *testing.T) {
+	large := strings.Repeat("x", 200)
+	server := newETagServer(t, map[string]string{"/small": "small", "/large": large})
+	transport := NewTransport(http.DefaultTransport, New(DefaultMaxEntries, 1000))
+
+	// A body over a tenth of the cache is passed through whole but not cached
+	_, body := get(t, transport, server.URL+"/large", nil)
+	assert.Equal(t, large, body)
+	get(t, transport, server.URL+"/large", nil)
+
+	// Requests other than GET are not cached
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/small", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, []string{"", "", ""}, server.ifNoneMatch)
+	assert.Zero(t, transport.cache.Len())
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	t.Run("by number of entries", func(t *testing.T) {
+		server := newETagServer(t, map[string]string{"/a": "a", "/b": "b", "/c": "c"})
+		transport := NewTransport(http.DefaultTransport, New(2, DefaultMaxBytes))
+
+		get(t, transport, server.URL+"/a", nil)
+		get(t, transport, server.URL+"/b", nil)
+		// Using a makes b the least recently used, so c evicts it
+		get(t, transport, server.URL+"/a", nil)
+		get(t, transport, server.URL+"/c", nil)
+		server.ifNoneMatch = nil
+
+		get(t, transport, server.URL+"/a", nil)
+		get(t, transport, server.URL+"/b", nil)
+		assert.NotEmpty(t, server.ifNoneMatch[0], "a should still be cached")
+		assert.Empty(t, server.ifNoneMatch[1], "b should have been evicted")
+	})
+
+	t.Run("by size of bodies", func(t *testing.T) {
+		bodies := map[string]string{}
+		for i := range 11 {
+			bodies[fmt.Sprintf("/%d", i)] = strings.Repeat("x", 10)
+		}
+		server := newETagServer(t, bodies)
+		cache := New(DefaultMaxEntries, 100)
+		transport := NewTransport(http.DefaultTransport, cache)
+
+		for i := range 11 {
+			get(t, transport, fmt.Sprintf("%s/%d", server.URL, i), nil)
+		}
+
+		assert.Equal(t, 10, cache.Len())
+		assert.Equal(t, int64(100), cache.bytes)
+		_, ok := cache.entries[cacheKey(mustRequest(t, server.URL+"/0"))]
+		assert.False(t, ok, "the first response should have been evicted")
+	})
+}
+
+func mustRequest(t *testing.T, url string) *http.Request {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	return req
+}
@@ -3,6 +3,8 @@ package errors
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -106,15 +108,32 @@ func addGitHubGraphQLErrorToContext(ctx context.Context, err *GitHubGraphQLError
 	return nil, fmt.Errorf("context does not contain GitHubCtxErrors")
 }
 
-// NewGitHubAPIErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
+// NewGitHubAPIErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware.
+// When the response shows the rate limit is used up, the result also says when it resets.
 func NewGitHubAPIErrorResponse(ctx context.Context, message string, resp *github.Response, err error) *mcp.CallToolResult {
 	apiErr := newGitHubAPIError(message, resp, err)
 	if ctx != nil {
 		_, _ = addGitHubAPIErrorToContext(ctx, apiErr) // Explicitly ignore error for graceful handling
 	}
+	if reset, ok := rateLimitReset(resp); ok {
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %v (rate limit exhausted, resets at %s, in %s)",
+			message, err, reset.UTC().Format(time.RFC3339), max(time.Until(reset), 0).Round(time.Second)))
+	}
 	return mcp.NewToolResultErrorFromErr(message, err)
 }
 
+// rateLimitReset returns when the rate limit resets if resp shows that no requests remain.
+func rateLimitReset(resp *github.Response) (time.Time, bool) {
+	if resp == nil || resp.Response == nil || resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return time.Time{}, false
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(reset, 0), true
+}
+
 // NewGitHubGraphQLErrorResponse returns an mcp.NewToolResultError and retains the error in the context for access via middleware
 func NewGitHubGraphQLErrorResponse(ctx context.Context, message string, err error) *mcp.CallToolResult {
 	graphQLErr := newGitHubGraphQLError(message, err)
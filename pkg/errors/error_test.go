@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -208,6 +211,38 @@ func TestGitHubErrorContext(t *testing.T) {
 		assert.Equal(t, originalErr, apiError.Err)
 	})
 
+	t.Run("NewGitHubAPIErrorResponse says when an exhausted rate limit resets", func(t *testing.T) {
+		ctx := ContextWithGitHubErrors(context.Background())
+
+		reset := time.Now().Add(90 * time.Second).Truncate(time.Second)
+		header := http.Header{}
+		header.Set("X-RateLimit-Remaining", "0")
+		header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		resp := &github.Response{Response: &http.Response{StatusCode: 403, Header: header}}
+		originalErr := fmt.Errorf("API rate limit exceeded")
+
+		result := NewGitHubAPIErrorResponse(ctx, "failed to list issues", resp, originalErr)
+
+		require.NotNil(t, result)
+		assert.True(t, result.IsError)
+		require.Len(t, result.Content, 1)
+		text := result.Content[0].(mcp.TextContent).Text
+		assert.Contains(t, text, "failed to list issues: API rate limit exceeded")
+		assert.Contains(t, text, "rate limit exhausted, resets at "+reset.UTC().Format(time.RFC3339))
+	})
+
+	t.Run("NewGitHubAPIErrorResponse leaves other errors as they are", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-RateLimit-Remaining", "4999")
+		header.Set("X-RateLimit-Reset", "1700000000")
+		resp := &github.Response{Response: &http.Response{StatusCode: 404, Header: header}}
+
+		result := NewGitHubAPIErrorResponse(context.Background(), "failed to get issue", resp, fmt.Errorf("not found"))
+
+		require.Len(t, result.Content, 1)
+		assert.Equal(t, "failed to get issue: not found", result.Content[0].(mcp.TextContent).Text)
+	})
+
 	t.Run("NewGitHubGraphQLErrorResponse creates MCP error result and stores context error", func(t *testing.T) {
 		// Given a context with GitHub error tracking enabled
 		ctx := ContextWithGitHubErrors(context.Background())
@@ -1,13 +1,16 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -84,7 +87,7 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 // ListWorkflowRuns creates a tool to list workflow runs for a specific workflow
 func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflow_runs",
-			mcp.WithDescription(t("TOOL_LIST_WORKFLOW_RUNS_DESCRIPTION", "List workflow runs for a specific workflow")),
+			mcp.WithDescription(t("TOOL_LIST_WORKFLOW_RUNS_DESCRIPTION", "List workflow runs of a repository, or of one of its workflows")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_WORKFLOW_RUNS_USER_TITLE", "List workflow runs"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -98,8 +101,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description(DescriptionRepositoryName),
 			),
 			mcp.WithString("workflow_id",
-				mcp.Required(),
-				mcp.Description("The workflow ID or workflow file name"),
+				mcp.Description("The workflow ID or workflow file name. Lists the runs of all workflows when omitted"),
 			),
 			mcp.WithString("actor",
 				mcp.Description("Returns someone's workflow runs. Use the login for the user who created the workflow run."),
@@ -148,6 +150,10 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description("Returns workflow runs with the check run status"),
 				mcp.Enum("queued", "in_progress", "completed", "requested", "waiting"),
 			),
+			mcp.WithBoolean("summary_only",
+				mcp.Description("Return counts of the runs by conclusion and their mean duration instead of the list of runs"),
+				mcp.DefaultBool(false),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -159,7 +165,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			workflowID, err := RequiredParam[string](request, "workflow_id")
+			workflowID, err := OptionalParam[string](request, "workflow_id")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -181,6 +187,10 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			summaryOnly, err := OptionalParam[bool](request, "summary_only")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			// Get optional pagination parameters
 			pagination, err := OptionalPaginationParams(request)
@@ -205,11 +215,20 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				},
 			}
 
-			workflowRuns, resp, err := client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, opts)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+			var workflowRuns *github.WorkflowRuns
+			var resp *github.Response
+			if workflowID != "" {
+				workflowRuns, resp, err = client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowID, opts)
+			} else {
+				workflowRuns, resp, err = client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to list workflow runs", resp, err); !ok {
+				return result, err
+			}
+
+			if summaryOnly {
+				return MarshalledTextResult(summarizeWorkflowRuns(workflowRuns)), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
 
 			r, err := json.Marshal(workflowRuns)
 			if err != nil {
@@ -220,6 +239,43 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 		}
 }
 
+// WorkflowRunsSummary is the result of list_workflow_runs with summary_only set.
+type WorkflowRunsSummary struct {
+	TotalCount          int            `json:"total_count"`
+	Runs                int            `json:"runs"`
+	ByConclusion        map[string]int `json:"by_conclusion"`
+	MeanDurationSeconds float64        `json:"mean_duration_seconds"`
+}
+
+// summarizeWorkflowRuns counts a page of workflow runs by conclusion, using the status for runs that have not
+// concluded yet, and averages the duration of the completed runs from their start to their last update.
+func summarizeWorkflowRuns(runs *github.WorkflowRuns) WorkflowRunsSummary {
+	summary := WorkflowRunsSummary{
+		TotalCount:   runs.GetTotalCount(),
+		Runs:         len(runs.WorkflowRuns),
+		ByConclusion: map[string]int{},
+	}
+	var total time.Duration
+	var completed int
+	for _, run := range runs.WorkflowRuns {
+		conclusion := run.GetConclusion()
+		if conclusion == "" {
+			conclusion = run.GetStatus()
+		}
+		summary.ByConclusion[conclusion]++
+
+		if run.GetStatus() != "completed" || run.RunStartedAt == nil || run.UpdatedAt == nil {
+			continue
+		}
+		total += run.GetUpdatedAt().Sub(run.GetRunStartedAt().Time)
+		completed++
+	}
+	if completed > 0 {
+		summary.MeanDurationSeconds = (total / time.Duration(completed)).Seconds()
+	}
+	return summary
+}
+
 // RunWorkflow creates a tool to run an Actions workflow
 func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("run_workflow",
@@ -245,7 +301,7 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("The git reference for the workflow. The reference can be a branch or tag name."),
 			),
 			mcp.WithObject("inputs",
-				mcp.Description("Inputs the workflow accepts"),
+				mcp.Description("Inputs the workflow accepts. Every value must be a string, as the API requires, even for boolean and number inputs"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -273,6 +329,9 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 					inputs = inputsMap
 				}
 			}
+			if keys := nonStringInputs(inputs); len(keys) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("workflow inputs must be strings, but these are not: %s", strings.Join(keys, ", "))), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -295,10 +354,10 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				workflowType = "workflow_file"
 			}
 
-			if err != nil {
-				return nil, fmt.Errorf("failed to run workflow: %w", err)
+			// The API answers 422 when an input is not defined by the workflow.
+			if result, ok, err := handleRESTResponse(ctx, "failed to run workflow", resp, err, http.StatusNoContent); !ok {
+				return result, err
 			}
-			defer func() { _ = resp.Body.Close() }()
 
 			result := map[string]any{
 				"message":       "Workflow run has been queued",
@@ -319,6 +378,89 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 		}
 }
 
+// nonStringInputs returns the sorted keys of the workflow inputs whose values are not strings.
+func nonStringInputs(inputs map[string]any) []string {
+	var keys []string
+	for key, value := range inputs {
+		if _, ok := value.(string); !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// maxClientPayloadProperties is the most top-level properties the API accepts in a repository dispatch payload.
+const maxClientPayloadProperties = 10
+
+// CreateRepositoryDispatch creates a tool to trigger a repository_dispatch event
+func CreateRepositoryDispatch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository_dispatch",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_DISPATCH_DESCRIPTION", "Trigger a repository_dispatch event, which starts the workflows of the default branch that run on repository_dispatch with this event type. Use run_workflow to run a single workflow_dispatch workflow instead.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REPOSITORY_DISPATCH_USER_TITLE", "Create repository dispatch event"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("event_type",
+				mcp.Required(),
+				mcp.Description("Custom event type, matched against the types of the workflows' repository_dispatch trigger"),
+			),
+			mcp.WithObject("client_payload",
+				mcp.Description(fmt.Sprintf("JSON data passed to the workflows as github.event.client_payload, with at most %d top-level properties", maxClientPayloadProperties)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			eventType, err := RequiredParam[string](request, "event_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := github.DispatchRequestOptions{EventType: eventType}
+			if requestPayload, ok := request.GetArguments()["client_payload"]; ok && requestPayload != nil {
+				payload, ok := requestPayload.(map[string]any)
+				if !ok {
+					return mcp.NewToolResultError("client_payload must be a JSON object"), nil
+				}
+				if len(payload) > maxClientPayloadProperties {
+					return mcp.NewToolResultError(fmt.Sprintf("client_payload has %d top-level properties, more than the limit of %d", len(payload), maxClientPayloadProperties)), nil
+				}
+				raw, err := json.Marshal(payload)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal client_payload: %w", err)
+				}
+				opts.ClientPayload = (*json.RawMessage)(&raw)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			_, resp, err := client.Repositories.Dispatch(ctx, owner, repo, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create repository dispatch event", resp, err, http.StatusNoContent); !ok {
+				return result, err
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Repository dispatch event %q created in %s/%s", eventType, owner, repo)), nil
+		}
+}
+
 // GetWorkflowRun creates a tool to get details of a specific workflow run
 func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_workflow_run",
@@ -361,10 +503,9 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 			}
 
 			workflowRun, resp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get workflow run: %w", err)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get workflow run", resp, err); !ok {
+				return result, err
 			}
-			defer func() { _ = resp.Body.Close() }()
 
 			r, err := json.Marshal(workflowRun)
 			if err != nil {
@@ -558,7 +699,7 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("Returns actual log content instead of URLs"),
 			),
 			mcp.WithNumber("tail_lines",
-				mcp.Description("Number of lines to return from the end of the log"),
+				mcp.Description("Number of lines to return from the end of the log. At most the last 256 KiB of each log are returned"),
 				mcp.DefaultNumber(500),
 			),
 		),
@@ -754,7 +895,7 @@ func downloadLogContent(logURL string, tailLines int) (string, int, *http.Respon
 		return "", 0, httpResp, fmt.Errorf("failed to download logs: HTTP %d", httpResp.StatusCode)
 	}
 
-	content, err := io.ReadAll(httpResp.Body)
+	content, err := readLogTail(httpResp.Body, maxJobLogBytes)
 	if err != nil {
 		return "", 0, httpResp, fmt.Errorf("failed to read log content: %w", err)
 	}
@@ -766,6 +907,42 @@ func downloadLogContent(logURL string, tailLines int) (string, int, *http.Respon
 	return trimmedContent, lineCount, httpResp, nil
 }
 
+// maxJobLogBytes bounds how much of the end of a job log is kept, so that a log with very long lines
+// cannot blow up the tool result however few tail_lines are asked for.
+const maxJobLogBytes = 256 * 1024
+
+// readLogTail reads r to the end and returns its last maxBytes bytes at most, without holding more
+// than twice that in memory. When the log is cut, the partial first line is dropped.
+func readLogTail(r io.Reader, maxBytes int) ([]byte, error) {
+	var tail []byte
+	chunk := make([]byte, 32*1024)
+	cut := false
+	for {
+		n, err := r.Read(chunk)
+		tail = append(tail, chunk[:n]...)
+		if len(tail) > 2*maxBytes {
+			tail = append(tail[:0], tail[len(tail)-maxBytes:]...)
+			cut = true
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(tail) > maxBytes {
+		tail = tail[len(tail)-maxBytes:]
+		cut = true
+	}
+	if cut {
+		if i := bytes.IndexByte(tail, '\n'); i >= 0 {
+			tail = tail[i+1:]
+		}
+	}
+	return tail, nil
+}
+
 // trimContent trims the content to a maximum length and returns the trimmed content and an original length
 func trimContent(content string, tailLines int) (string, int) {
 	// Truncate to tail_lines if specified
@@ -975,6 +1152,284 @@ func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFu
 		}
 }
 
+// checkRunSummaryMaxBytes caps the output summary of each check run returned by list_check_runs_for_ref.
+// Summaries can hold whole test reports, and the full text is always available at the details URL.
+const checkRunSummaryMaxBytes = 2 * 1024
+
+// CheckRunEntry is a check run as returned by list_check_runs_for_ref.
+type CheckRunEntry struct {
+	ID               int64  `json:"id"`
+	Name             string `json:"name"`
+	Status           string `json:"status"`
+	Conclusion       string `json:"conclusion,omitempty"`
+	StartedAt        string `json:"started_at,omitempty"`
+	CompletedAt      string `json:"completed_at,omitempty"`
+	DetailsURL       string `json:"details_url,omitempty"`
+	Summary          string `json:"summary,omitempty"`
+	SummaryTruncated bool   `json:"summary_truncated,omitempty"`
+}
+
+// CheckRunsResult is a page of the check runs for a ref. ByConclusion counts the runs of the page by
+// conclusion, using the status for runs that have not concluded yet, and AllPassed is true when the page
+// holds at least one run and every run concluded with success, neutral or skipped.
+type CheckRunsResult struct {
+	TotalCount   int             `json:"total_count"`
+	ByConclusion map[string]int  `json:"by_conclusion"`
+	AllPassed    bool            `json:"all_passed"`
+	CheckRuns    []CheckRunEntry `json:"check_runs"`
+	NextPageInfo
+}
+
+// ListCheckRunsForRef creates a tool to list the check runs for a ref
+func ListCheckRunsForRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_check_runs_for_ref",
+			mcp.WithDescription(t("TOOL_LIST_CHECK_RUNS_FOR_REF_DESCRIPTION", "List the check runs for a commit SHA, branch or tag, with counts by conclusion and whether all of them passed. Check has_next_page: the counts only cover the returned page")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CHECK_RUNS_FOR_REF_USER_TITLE", "List check runs for ref"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch name or tag name"),
+			),
+			mcp.WithString("check_name",
+				mcp.Description("Only list check runs with this name"),
+			),
+			mcp.WithString("status",
+				mcp.Description("Only list check runs with this status"),
+				mcp.Enum("queued", "in_progress", "completed"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("Whether to list only the latest check run of each name, or all of them including re-runs"),
+				mcp.Enum("latest", "all"),
+				mcp.DefaultString("latest"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := RequiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkName, err := OptionalParam[string](request, "check_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, err := OptionalParam[string](request, "status")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListCheckRunsOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if checkName != "" {
+				opts.CheckName = github.Ptr(checkName)
+			}
+			if status != "" {
+				opts.Status = github.Ptr(status)
+			}
+			if filter != "" {
+				opts.Filter = github.Ptr(filter)
+			}
+			checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list check runs", resp, err); !ok {
+				return result, err
+			}
+
+			result := summarizeCheckRuns(checkRuns.CheckRuns, checkRunSummaryMaxBytes)
+			result.TotalCount = checkRuns.GetTotal()
+			result.HasNextPage = resp.NextPage > 0
+			result.NextPage = resp.NextPage
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// summarizeCheckRuns condenses a page of check runs, cutting each output summary to at most maxSummaryBytes
+// without splitting a UTF-8 sequence, and counts the runs by conclusion.
+func summarizeCheckRuns(runs []*github.CheckRun, maxSummaryBytes int) CheckRunsResult {
+	result := CheckRunsResult{
+		ByConclusion: map[string]int{},
+		AllPassed:    len(runs) > 0,
+		CheckRuns:    make([]CheckRunEntry, 0, len(runs)),
+	}
+	for _, run := range runs {
+		entry := CheckRunEntry{
+			ID:         run.GetID(),
+			Name:       run.GetName(),
+			Status:     run.GetStatus(),
+			Conclusion: run.GetConclusion(),
+			DetailsURL: run.GetDetailsURL(),
+			Summary:    run.GetOutput().GetSummary(),
+		}
+		if run.StartedAt != nil {
+			entry.StartedAt = run.StartedAt.UTC().Format(time.RFC3339)
+		}
+		if run.CompletedAt != nil {
+			entry.CompletedAt = run.CompletedAt.UTC().Format(time.RFC3339)
+		}
+		if len(entry.Summary) > maxSummaryBytes {
+			entry.Summary = entry.Summary[:runeBoundaryCut(entry.Summary, maxSummaryBytes)]
+			entry.SummaryTruncated = true
+		}
+		result.CheckRuns = append(result.CheckRuns, entry)
+
+		conclusion := run.GetConclusion()
+		if conclusion == "" {
+			conclusion = run.GetStatus()
+		}
+		result.ByConclusion[conclusion]++
+		switch conclusion {
+		case "success", "neutral", "skipped":
+		default:
+			result.AllPassed = false
+		}
+	}
+	return result
+}
+
+// RerequestCheckRun creates a tool to re-request a check run
+func RerequestCheckRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rerequest_check_run",
+			mcp.WithDescription(t("TOOL_REREQUEST_CHECK_RUN_DESCRIPTION", "Re-request a check run, e.g. one stuck in progress, so that the GitHub App that created it runs it again. Only check runs created by the GitHub App making the request can be re-requested")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REREQUEST_CHECK_RUN_USER_TITLE", "Re-request check run"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("check_run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the check run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkRunID, err := RequiredInt(request, "check_run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Checks.ReRequestCheckRun(ctx, owner, repo, int64(checkRunID))
+			return checkRerequestResult(ctx, "check run", "check_run_id", int64(checkRunID), resp, err), nil
+		}
+}
+
+// RerequestCheckSuite creates a tool to re-request a check suite
+func RerequestCheckSuite(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rerequest_check_suite",
+			mcp.WithDescription(t("TOOL_REREQUEST_CHECK_SUITE_DESCRIPTION", "Re-request a check suite, so that the GitHub App that created it runs all of its checks again without a new push. Only check suites created by the GitHub App making the request can be re-requested")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REREQUEST_CHECK_SUITE_USER_TITLE", "Re-request check suite"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("check_suite_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the check suite"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkSuiteID, err := RequiredInt(request, "check_suite_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Checks.ReRequestCheckSuite(ctx, owner, repo, int64(checkSuiteID))
+			return checkRerequestResult(ctx, "check suite", "check_suite_id", int64(checkSuiteID), resp, err), nil
+		}
+}
+
+// checkRerequestResult builds the result of re-requesting a check run or suite. GitHub only lets the
+// GitHub App that created a check re-request it, and answers 403 to anyone else, including users.
+func checkRerequestResult(ctx context.Context, kind, idField string, id int64, resp *github.Response, err error) *mcp.CallToolResult {
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusForbidden {
+			closeResponseBody(resp)
+			return mcp.NewToolResultError(fmt.Sprintf("cannot re-request %s %d: only the GitHub App that created it can re-request it, and this token does not belong to that app. Re-run the workflow or push a new commit instead", kind, id))
+		}
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to re-request %s", kind), resp, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return MarshalledTextResult(map[string]any{
+		"message":     fmt.Sprintf("The %s has been re-requested", kind),
+		idField:       id,
+		"status":      resp.Status,
+		"status_code": resp.StatusCode,
+	})
+}
+
 // ListWorkflowRunArtifacts creates a tool to list artifacts for a workflow run
 func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_workflow_run_artifacts",
@@ -1228,3 +1683,155 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// ListPendingDeployments creates a tool to list the deployments of a workflow run that are waiting for approval
+func ListPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pending_deployments",
+			mcp.WithDescription(t("TOOL_LIST_PENDING_DEPLOYMENTS_DESCRIPTION", "List the environments of a workflow run that are waiting for deployment protection rules to pass, along with their required reviewers and whether the current user can approve them")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PENDING_DEPLOYMENTS_USER_TITLE", "List pending deployments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deployments, resp, err := client.Actions.GetPendingDeployments(ctx, owner, repo, runID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pending deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(deployments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ReviewPendingDeployments creates a tool to approve or reject the pending deployments of a workflow run
+func ReviewPendingDeployments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("review_pending_deployments",
+			mcp.WithDescription(t("TOOL_REVIEW_PENDING_DEPLOYMENTS_DESCRIPTION", "Approve or reject deployments of a workflow run that are waiting for approval by a required reviewer. Use list_pending_deployments to find the environment IDs.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REVIEW_PENDING_DEPLOYMENTS_USER_TITLE", "Review pending deployments"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryOwner),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description(DescriptionRepositoryName),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("The unique identifier of the workflow run"),
+			),
+			mcp.WithArray("environment_ids",
+				mcp.Required(),
+				mcp.Description("IDs of the environments to approve or reject"),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("Whether to approve or reject the deployments"),
+				mcp.Enum("approved", "rejected"),
+			),
+			mcp.WithString("comment",
+				mcp.Required(),
+				mcp.Description("A comment to accompany the review"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runIDInt, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID := int64(runIDInt)
+			environmentIDs, err := OptionalInt64ArrayParam(request, "environment_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(environmentIDs) == 0 {
+				return mcp.NewToolResultError("environment_ids must contain at least one environment ID"), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if state != "approved" && state != "rejected" {
+				return mcp.NewToolResultError(fmt.Sprintf("state must be one of approved, rejected, got %q", state)), nil
+			}
+			comment, err := RequiredParam[string](request, "comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			deployments, resp, err := client.Actions.PendingDeployments(ctx, owner, repo, runID, &github.PendingDeploymentsRequest{
+				EnvironmentIDs: environmentIDs,
+				State:          state,
+				Comment:        comment,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to review pending deployments", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(deployments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
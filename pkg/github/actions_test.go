@@ -3,9 +3,14 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"maps"
 	"net/http"
 	"net/http/httptest"
+	"path"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
@@ -125,6 +130,248 @@ func Test_ListWorkflows(t *testing.T) {
 	}
 }
 
+func Test_ListWorkflowRuns(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWorkflowRuns(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_workflow_runs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "workflow_id")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "event")
+	assert.Contains(t, tool.InputSchema.Properties, "status")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	runs := &github.WorkflowRuns{
+		TotalCount: github.Ptr(1),
+		WorkflowRuns: []*github.WorkflowRun{
+			{ID: github.Ptr(int64(30433642)), Name: github.Ptr("CI"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "filters the runs of a workflow",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					expectQueryParams(t, map[string]string{
+						"branch":   "main",
+						"event":    "push",
+						"status":   "completed",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, runs),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "ci.yml",
+				"branch":      "main",
+				"event":       "push",
+				"status":      "completed",
+				"page":        float64(2),
+				"perPage":     float64(10),
+			},
+		},
+		{
+			name: "lists the runs of all workflows without workflow_id",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, runs),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+		},
+		{
+			name: "workflow not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "missing.yml",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list workflow runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response github.WorkflowRuns
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, runs, &response)
+		})
+	}
+}
+
+func Test_GetWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_workflow_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	run := &github.WorkflowRun{
+		ID:           github.Ptr(int64(30433642)),
+		Name:         github.Ptr("CI"),
+		Status:       github.Ptr("completed"),
+		Conclusion:   github.Ptr("failure"),
+		RunStartedAt: &github.Timestamp{Time: start},
+		UpdatedAt:    &github.Timestamp{Time: start.Add(3 * time.Minute)},
+	}
+
+	t.Run("returns the run", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsRunsByOwnerByRepoByRunId,
+				expectPath(t, "/repos/owner/repo/actions/runs/30433642").andThen(
+					mockResponse(t, http.StatusOK, run),
+				),
+			),
+		))
+		_, handler := GetWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"run_id": float64(30433642),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var response github.WorkflowRun
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, run, &response)
+	})
+
+	t.Run("run not found", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposActionsRunsByOwnerByRepoByRunId,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+		))
+		_, handler := GetWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":  "owner",
+			"repo":   "repo",
+			"run_id": float64(1),
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get workflow run")
+	})
+}
+
+func Test_ListWorkflowRunsSummaryOnly(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	runs := &github.WorkflowRuns{
+		TotalCount: github.Ptr(57),
+		WorkflowRuns: []*github.WorkflowRun{
+			{ID: github.Ptr(int64(1)), Name: github.Ptr("CI run one"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), RunStartedAt: &github.Timestamp{Time: start}, UpdatedAt: &github.Timestamp{Time: start.Add(2 * time.Minute)}},
+			{ID: github.Ptr(int64(2)), Name: github.Ptr("CI run two"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"), RunStartedAt: &github.Timestamp{Time: start}, UpdatedAt: &github.Timestamp{Time: start.Add(4 * time.Minute)}},
+		},
+	}
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowId,
+			runs,
+		),
+	))
+	_, handler := ListWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"workflow_id":  "ci.yml",
+		"summary_only": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	text := getTextResult(t, result).Text
+	assert.NotContains(t, text, "CI run one")
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(text), &fields))
+	assert.ElementsMatch(t, []string{"total_count", "runs", "by_conclusion", "mean_duration_seconds"}, slices.Collect(maps.Keys(fields)))
+
+	var summary WorkflowRunsSummary
+	require.NoError(t, json.Unmarshal([]byte(text), &summary))
+	assert.Equal(t, WorkflowRunsSummary{
+		TotalCount:          57,
+		Runs:                2,
+		ByConclusion:        map[string]int{"success": 1, "failure": 1},
+		MeanDurationSeconds: 180,
+	}, summary)
+}
+
+func Test_SummarizeWorkflowRuns(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	runs := &github.WorkflowRuns{
+		TotalCount: github.Ptr(4),
+		WorkflowRuns: []*github.WorkflowRun{
+			{Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), RunStartedAt: &github.Timestamp{Time: start}, UpdatedAt: &github.Timestamp{Time: start.Add(90 * time.Second)}},
+			{Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), RunStartedAt: &github.Timestamp{Time: start}, UpdatedAt: &github.Timestamp{Time: start.Add(30 * time.Second)}},
+			{Status: github.Ptr("completed"), Conclusion: github.Ptr("cancelled")},
+			{Status: github.Ptr("in_progress"), RunStartedAt: &github.Timestamp{Time: start}, UpdatedAt: &github.Timestamp{Time: start.Add(time.Hour)}},
+		},
+	}
+
+	assert.Equal(t, WorkflowRunsSummary{
+		TotalCount:          4,
+		Runs:                4,
+		ByConclusion:        map[string]int{"success": 2, "cancelled": 1, "in_progress": 1},
+		MeanDurationSeconds: 60,
+	}, summarizeWorkflowRuns(runs))
+
+	assert.Equal(t, WorkflowRunsSummary{ByConclusion: map[string]int{}}, summarizeWorkflowRuns(&github.WorkflowRuns{}))
+}
+
 func Test_RunWorkflow(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -145,6 +392,8 @@ func Test_RunWorkflow(t *testing.T) {
 		requestArgs    map[string]any
 		expectError    bool
 		expectedErrMsg string
+		// expectedErrRegexp matches the whole error of API failures, whose URL has the mock server's port
+		expectedErrRegexp string
 	}{
 		{
 			name: "successful workflow run",
@@ -164,6 +413,71 @@ func Test_RunWorkflow(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "sends the inputs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsWorkflowsDispatchesByOwnerByRepoByWorkflowId,
+					expectRequestBody(t, map[string]any{
+						"ref": "main",
+						"inputs": map[string]any{
+							"environment": "staging",
+							"dry_run":     "true",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "deploy.yml",
+				"ref":         "main",
+				"inputs": map[string]any{
+					"environment": "staging",
+					"dry_run":     "true",
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:         "inputs that are not strings",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "deploy.yml",
+				"ref":         "main",
+				"inputs": map[string]any{
+					"environment": "staging",
+					"dry_run":     true,
+					"replicas":    float64(3),
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "workflow inputs must be strings, but these are not: dry_run, replicas",
+		},
+		{
+			name: "input not defined by the workflow",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsWorkflowsDispatchesByOwnerByRepoByWorkflowId,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Unexpected inputs provided: [\"colour\"]"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"workflow_id": "deploy.yml",
+				"ref":         "main",
+				"inputs": map[string]any{
+					"colour": "blue",
+				},
+			},
+			expectError:       true,
+			expectedErrRegexp: `^failed to run workflow: POST http://127\.0\.0\.1:\d+/repos/owner/repo/actions/workflows/deploy\.yml/dispatches: 422 Unexpected inputs provided: \["colour"\] \[\]$`,
+		},
 		{
 			name:         "missing required parameter workflow_id",
 			mockedClient: mock.NewMockedHTTPClient(),
@@ -199,6 +513,10 @@ func Test_RunWorkflow(t *testing.T) {
 				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
 				return
 			}
+			if tc.expectedErrRegexp != "" {
+				assert.Regexp(t, tc.expectedErrRegexp, textContent.Text)
+				return
+			}
 
 			// Unmarshal and verify the result
 			var response map[string]any
@@ -301,6 +619,143 @@ func Test_RunWorkflow_WithFilename(t *testing.T) {
 	}
 }
 
+func Test_CreateRepositoryDispatch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepositoryDispatch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_repository_dispatch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "event_type")
+	assert.Contains(t, tool.InputSchema.Properties, "client_payload")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "event_type"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "sends the event type and payload",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDispatchesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"event_type": "deploy",
+						"client_payload": map[string]any{
+							"environment": "production",
+							"services":    []any{"api", "web"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"event_type": "deploy",
+				"client_payload": map[string]any{
+					"environment": "production",
+					"services":    []any{"api", "web"},
+				},
+			},
+			expectedText: `Repository dispatch event "deploy" created in owner/repo`,
+		},
+		{
+			name: "without a payload",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDispatchesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"event_type": "nightly",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"event_type": "nightly",
+			},
+			expectedText: `Repository dispatch event "nightly" created in owner/repo`,
+		},
+		{
+			name:         "too many payload properties",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"event_type": "deploy",
+				"client_payload": map[string]any{
+					"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8, "i": 9, "j": 10, "k": 11,
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "client_payload has 11 top-level properties, more than the limit of 10",
+		},
+		{
+			name: "no access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposDispatchesByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"event_type": "deploy",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository dispatch event",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepositoryDispatch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_DispatchToolsAreNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		actions, err := tsg.GetToolset("actions")
+		require.NoError(t, err)
+
+		var names []string
+		for _, st := range actions.GetAvailableTools() {
+			names = append(names, st.Tool.Name)
+		}
+		for _, name := range []string{"run_workflow", "create_repository_dispatch"} {
+			if readOnly {
+				assert.NotContains(t, names, name)
+			} else {
+				assert.Contains(t, names, name)
+			}
+		}
+	}
+}
+
 func Test_CancelWorkflowRun(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -331,54 +786,444 @@ func Test_CancelWorkflowRun(t *testing.T) {
 					"", // Empty response body for 202 Accepted
 				),
 			),
-			requestArgs: map[string]any{
-				"owner":  "owner",
-				"repo":   "repo",
-				"run_id": float64(12345),
-			},
-			expectError: false,
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectError: false,
+		},
+		{
+			name:         "missing required parameter run_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: run_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CancelWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response map[string]any
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			assert.Equal(t, "Workflow run has been cancelled", response["message"])
+			assert.Equal(t, float64(12345), response["run_id"])
+		})
+	}
+}
+
+func Test_ListCheckRunsForRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCheckRunsForRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_check_runs_for_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "check_name")
+	assert.Contains(t, tool.InputSchema.Properties, "status")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	started := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	mockCheckRuns := &github.ListCheckRunsResults{
+		Total: github.Ptr(3),
+		CheckRuns: []*github.CheckRun{
+			{
+				ID:          github.Ptr(int64(1)),
+				Name:        github.Ptr("build"),
+				Status:      github.Ptr("completed"),
+				Conclusion:  github.Ptr("success"),
+				StartedAt:   &github.Timestamp{Time: started},
+				CompletedAt: &github.Timestamp{Time: started.Add(3 * time.Minute)},
+				DetailsURL:  github.Ptr("https://ci.example.com/build/1"),
+				Output:      &github.CheckRunOutput{Summary: github.Ptr("Built in 3 minutes")},
+			},
+			{
+				ID:         github.Ptr(int64(2)),
+				Name:       github.Ptr("test"),
+				Status:     github.Ptr("completed"),
+				Conclusion: github.Ptr("failure"),
+				StartedAt:  &github.Timestamp{Time: started},
+				Output:     &github.CheckRunOutput{Summary: github.Ptr(strings.Repeat("FAIL ", 1000))},
+			},
+			{
+				ID:     github.Ptr(int64(3)),
+				Name:   github.Ptr("lint"),
+				Status: github.Ptr("in_progress"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "sends the filters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectQueryParams(t, map[string]string{
+						"check_name": "test",
+						"status":     "completed",
+						"filter":     "all",
+						"page":       "2",
+						"per_page":   "50",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/commits/main/check-runs?page=3&per_page=50>; rel="next"`)
+							_ = json.NewEncoder(w).Encode(mockCheckRuns)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"ref":        "main",
+				"check_name": "test",
+				"status":     "completed",
+				"filter":     "all",
+				"page":       float64(2),
+				"perPage":    float64(50),
+			},
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: nope"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "nope",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list check runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCheckRunsForRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned CheckRunsResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, 3, returned.TotalCount)
+			assert.Equal(t, map[string]int{"success": 1, "failure": 1, "in_progress": 1}, returned.ByConclusion)
+			assert.False(t, returned.AllPassed)
+			assert.True(t, returned.HasNextPage)
+			assert.Equal(t, 3, returned.NextPage)
+			require.Len(t, returned.CheckRuns, 3)
+			assert.Equal(t, CheckRunEntry{
+				ID:          1,
+				Name:        "build",
+				Status:      "completed",
+				Conclusion:  "success",
+				StartedAt:   "2025-07-01T12:00:00Z",
+				CompletedAt: "2025-07-01T12:03:00Z",
+				DetailsURL:  "https://ci.example.com/build/1",
+				Summary:     "Built in 3 minutes",
+			}, returned.CheckRuns[0])
+			assert.Len(t, returned.CheckRuns[1].Summary, checkRunSummaryMaxBytes)
+			assert.True(t, returned.CheckRuns[1].SummaryTruncated)
+		})
+	}
+}
+
+func Test_SummarizeCheckRuns(t *testing.T) {
+	run := func(status, conclusion string) *github.CheckRun {
+		r := &github.CheckRun{Name: github.Ptr("check"), Status: github.Ptr(status)}
+		if conclusion != "" {
+			r.Conclusion = github.Ptr(conclusion)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name         string
+		runs         []*github.CheckRun
+		byConclusion map[string]int
+		allPassed    bool
+	}{
+		{
+			name:         "no runs have not passed",
+			runs:         nil,
+			byConclusion: map[string]int{},
+			allPassed:    false,
+		},
+		{
+			name:         "success, neutral and skipped pass",
+			runs:         []*github.CheckRun{run("completed", "success"), run("completed", "success"), run("completed", "neutral"), run("completed", "skipped")},
+			byConclusion: map[string]int{"success": 2, "neutral": 1, "skipped": 1},
+			allPassed:    true,
+		},
+		{
+			name:         "a queued run has not passed yet",
+			runs:         []*github.CheckRun{run("completed", "success"), run("queued", "")},
+			byConclusion: map[string]int{"success": 1, "queued": 1},
+			allPassed:    false,
+		},
+		{
+			name:         "cancelled and timed out runs fail",
+			runs:         []*github.CheckRun{run("completed", "cancelled"), run("completed", "timed_out")},
+			byConclusion: map[string]int{"cancelled": 1, "timed_out": 1},
+			allPassed:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := summarizeCheckRuns(tc.runs, checkRunSummaryMaxBytes)
+			assert.Equal(t, tc.byConclusion, result.ByConclusion)
+			assert.Equal(t, tc.allPassed, result.AllPassed)
+		})
+	}
+}
+
+func Test_SummarizeCheckRunsTruncatesOnCharacterBoundary(t *testing.T) {
+	runs := []*github.CheckRun{{
+		Status:     github.Ptr("completed"),
+		Conclusion: github.Ptr("success"),
+		Output:     &github.CheckRunOutput{Summary: github.Ptr("ab✓✓")},
+	}}
+
+	// ✓ is three bytes, so a 4 byte cap must drop the first ✓ entirely rather than split it.
+	result := summarizeCheckRuns(runs, 4)
+	assert.Equal(t, "ab", result.CheckRuns[0].Summary)
+	assert.True(t, result.CheckRuns[0].SummaryTruncated)
+
+	result = summarizeCheckRuns(runs, 8)
+	assert.Equal(t, "ab✓✓", result.CheckRuns[0].Summary)
+	assert.False(t, result.CheckRuns[0].SummaryTruncated)
+}
+
+func Test_RerequestCheckRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RerequestCheckRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "rerequest_check_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "check_run_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_run_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedStatus float64
+	}{
+		{
+			name: "successful check run re-request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckRunsRerequestByOwnerByRepoByCheckRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(4242),
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name: "check run created by another GitHub App",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckRunsRerequestByOwnerByRepoByCheckRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(4242),
+			},
+			expectError:    true,
+			expectedErrMsg: "cannot re-request check run 4242: only the GitHub App that created it can re-request it",
+		},
+		{
+			name: "check run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckRunsRerequestByOwnerByRepoByCheckRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"check_run_id": float64(4242),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to re-request check run",
+		},
+		{
+			name:         "missing required parameter check_run_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: check_run_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RerequestCheckRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, "The check run has been re-requested", response["message"])
+			assert.Equal(t, float64(4242), response["check_run_id"])
+			assert.Equal(t, tc.expectedStatus, response["status_code"])
+		})
+	}
+}
+
+func Test_RerequestCheckSuite(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RerequestCheckSuite(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "rerequest_check_suite", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "check_suite_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_suite_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful check suite re-request",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckSuitesRerequestByOwnerByRepoByCheckSuiteId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
 		},
 		{
-			name:         "missing required parameter run_id",
-			mockedClient: mock.NewMockedHTTPClient(),
-			requestArgs: map[string]any{
-				"owner": "owner",
-				"repo":  "repo",
-			},
+			name: "check suite created by another GitHub App",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckSuitesRerequestByOwnerByRepoByCheckSuiteId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
 			expectError:    true,
-			expectedErrMsg: "missing required parameter: run_id",
+			expectedErrMsg: "cannot re-request check suite 777: only the GitHub App that created it can re-request it",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := CancelWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
+			_, handler := RerequestCheckSuite(stubGetClientFn(client), translations.NullTranslationHelper)
 
-			// Call handler
-			result, err := handler(context.Background(), request)
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"check_suite_id": float64(777),
+			}))
 
 			require.NoError(t, err)
 			require.Equal(t, tc.expectError, result.IsError)
-
-			// Parse the result and get the text content
 			textContent := getTextResult(t, result)
 
-			if tc.expectedErrMsg != "" {
-				assert.Equal(t, tc.expectedErrMsg, textContent.Text)
+			if tc.expectError {
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			// Unmarshal and verify the result
 			var response map[string]any
-			err = json.Unmarshal([]byte(textContent.Text), &response)
-			require.NoError(t, err)
-			assert.Equal(t, "Workflow run has been cancelled", response["message"])
-			assert.Equal(t, float64(12345), response["run_id"])
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, "The check suite has been re-requested", response["message"])
+			assert.Equal(t, float64(777), response["check_suite_id"])
+			assert.Equal(t, float64(http.StatusNoContent), response["status_code"])
 		})
 	}
 }
@@ -1144,3 +1989,370 @@ func Test_GetJobLogs_WithContentReturnAndTailLines(t *testing.T) {
 	assert.Equal(t, "Job logs content retrieved successfully", response["message"])
 	assert.NotContains(t, response, "logs_url") // Should not have URL when returning content
 }
+
+func Test_GetJobLogs_FailedOnlyWithContentReturn(t *testing.T) {
+	logs := map[string]string{
+		"/logs/2": "Run go build\nok\nRun go test\n--- FAIL: TestParse\nFAIL",
+		"/logs/3": "Run golangci-lint\nissues.go:12: unused variable\nError: Process completed with exit code 1.",
+	}
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(logs[r.URL.Path]))
+	}))
+	defer logServer.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+			&github.Jobs{
+				TotalCount: github.Ptr(3),
+				Jobs: []*github.WorkflowJob{
+					{ID: github.Ptr(int64(1)), Name: github.Ptr("docs"), Conclusion: github.Ptr("success")},
+					{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Conclusion: github.Ptr("failure")},
+					{ID: github.Ptr(int64(3)), Name: github.Ptr("lint"), Conclusion: github.Ptr("failure")},
+				},
+			},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Location", logServer.URL+"/logs/"+path.Base(path.Dir(r.URL.Path)))
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "owner",
+		"repo":           "repo",
+		"run_id":         float64(7),
+		"failed_only":    true,
+		"return_content": true,
+		"tail_lines":     float64(2),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response struct {
+		FailedJobs int `json:"failed_jobs"`
+		Logs       []struct {
+			JobID       int64  `json:"job_id"`
+			JobName     string `json:"job_name"`
+			LogsContent string `json:"logs_content"`
+		} `json:"logs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, 2, response.FailedJobs)
+	require.Len(t, response.Logs, 2)
+	assert.Equal(t, "test", response.Logs[0].JobName)
+	assert.Equal(t, "--- FAIL: TestParse\nFAIL", response.Logs[0].LogsContent)
+	assert.Equal(t, "lint", response.Logs[1].JobName)
+	assert.Equal(t, "issues.go:12: unused variable\nError: Process completed with exit code 1.", response.Logs[1].LogsContent)
+}
+
+func Test_GetJobLogs_WithContentReturnCapsLogSize(t *testing.T) {
+	// A log far larger than the cap, whose last line is still returned in full
+	line := strings.Repeat("x", 1000) + "\n"
+	logContent := strings.Repeat(line, 2*maxJobLogBytes/len(line)) + "last line"
+
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(logContent))
+	}))
+	defer logServer.Close()
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Location", logServer.URL)
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetJobLogs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "owner",
+		"repo":           "repo",
+		"job_id":         float64(123),
+		"return_content": true,
+		"tail_lines":     float64(100000),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	content := response["logs_content"].(string)
+	assert.LessOrEqual(t, len(content), maxJobLogBytes)
+	assert.True(t, strings.HasPrefix(content, strings.Repeat("x", 1000)), "the cut partial line is dropped")
+	assert.True(t, strings.HasSuffix(content, "\nlast line"))
+}
+
+func Test_ReadLogTail(t *testing.T) {
+	tail, err := readLogTail(strings.NewReader("one\ntwo\nthree"), 100)
+	require.NoError(t, err)
+	assert.Equal(t, "one\ntwo\nthree", string(tail))
+
+	tail, err = readLogTail(strings.NewReader("one\ntwo\nthree"), 8)
+	require.NoError(t, err)
+	assert.Equal(t, "three", string(tail), "the partial line before the cut is dropped")
+
+	// Reads in chunks much larger than the limit still keep only the end
+	tail, err = readLogTail(strings.NewReader(strings.Repeat("a\n", 100000)+"end"), 10)
+	require.NoError(t, err)
+	assert.Equal(t, "a\na\na\nend", string(tail))
+}
+
+func Test_ListPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_pending_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	mockDeployments := []*github.PendingDeployment{
+		{
+			Environment: &github.PendingDeploymentEnvironment{
+				ID:   github.Ptr(int64(161088068)),
+				Name: github.Ptr("production"),
+			},
+			CurrentUserCanApprove: github.Ptr(true),
+			Reviewers: []*github.RequiredReviewer{
+				{
+					Type:     github.Ptr("User"),
+					Reviewer: &github.User{Login: github.Ptr("octocat")},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful pending deployments listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					mockDeployments,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectError: false,
+		},
+		{
+			name: "pending deployments listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(12345),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pending deployments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response []*github.PendingDeployment
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			require.Len(t, response, 1)
+			assert.Equal(t, "production", response[0].GetEnvironment().GetName())
+			assert.True(t, response[0].GetCurrentUserCanApprove())
+			require.Len(t, response[0].Reviewers, 1)
+			assert.Equal(t, "User", response[0].Reviewers[0].GetType())
+		})
+	}
+}
+
+func Test_ReviewPendingDeployments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReviewPendingDeployments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "review_pending_deployments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "environment_ids")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "comment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id", "environment_ids", "state", "comment"})
+
+	mockDeployments := []*github.Deployment{
+		{
+			ID:          github.Ptr(int64(42)),
+			Environment: github.Ptr("production"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "approves pending deployments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]any{
+						"environment_ids": []any{float64(161088068), float64(161088069)},
+						"state":           "approved",
+						"comment":         "Ship it!",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockDeployments),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"environment_ids": []any{float64(161088068), float64(161088069)},
+				"state":           "approved",
+				"comment":         "Ship it!",
+			},
+			expectError: false,
+		},
+		{
+			name: "rejects pending deployments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsPendingDeploymentsByOwnerByRepoByRunId,
+					expectRequestBody(t, map[string]any{
+						"environment_ids": []any{float64(161088068)},
+						"state":           "rejected",
+						"comment":         "Not during the freeze",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockDeployments),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"environment_ids": []any{float64(161088068)},
+				"state":           "rejected",
+				"comment":         "Not during the freeze",
+			},
+			expectError: false,
+		},
+		{
+			name:         "empty environment_ids is rejected locally",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"environment_ids": []any{},
+				"state":           "approved",
+				"comment":         "Ship it!",
+			},
+			expectError:    true,
+			expectedErrMsg: "environment_ids must contain at least one environment ID",
+		},
+		{
+			name:         "invalid state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"run_id":          float64(12345),
+				"environment_ids": []any{float64(161088068)},
+				"state":           "pending",
+				"comment":         "Ship it!",
+			},
+			expectError:    true,
+			expectedErrMsg: "state must be one of approved, rejected",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReviewPendingDeployments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectError, result.IsError)
+
+			// Parse the result and get the text content
+			textContent := getTextResult(t, result)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var response []*github.Deployment
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+			require.Len(t, response, 1)
+			assert.Equal(t, int64(42), response[0].GetID())
+		})
+	}
+}
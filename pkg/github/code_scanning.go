@@ -2,12 +2,11 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"slices"
+	"strings"
 
-	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -54,35 +53,20 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 			}
 
 			alert, resp, err := client.CodeScanning.GetAlert(ctx, owner, repo, int64(alertNumber))
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get alert",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get alert: %s", string(body))), nil
+			if result := codeScanningDisabledResult(owner, repo, resp, err); result != nil {
+				return result, nil
 			}
-
-			r, err := json.Marshal(alert)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alert: %w", err)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get alert", resp, err); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(alert), nil
 		}
 }
 
 func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_code_scanning_alerts",
-			mcp.WithDescription(t("TOOL_LIST_CODE_SCANNING_ALERTS_DESCRIPTION", "List code scanning alerts in a GitHub repository.")),
+			mcp.WithDescription(t("TOOL_LIST_CODE_SCANNING_ALERTS_DESCRIPTION", "List code scanning alerts in a GitHub repository with the rule, severity, state and location of each. Use get_code_scanning_alert for the full details of an alert.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_CODE_SCANNING_ALERTS_USER_TITLE", "List code scanning alerts"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -110,6 +94,7 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 			mcp.WithString("tool_name",
 				mcp.Description("The name of the tool used for code scanning."),
 			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -136,34 +121,486 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			alerts, resp, err := client.CodeScanning.ListAlertsForRepo(ctx, owner, repo, &github.AlertListOptions{
+				Ref:      ref,
+				State:    state,
+				Severity: severity,
+				ToolName: toolName,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if result := codeScanningDisabledResult(owner, repo, resp, err); result != nil {
+				return result, nil
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to list alerts", resp, err); !ok {
+				return result, err
+			}
+
+			result := make([]CodeScanningAlert, 0, len(alerts))
+			for _, alert := range alerts {
+				result = append(result, newCodeScanningAlert(alert))
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// CodeScanningAlert is the condensed view of a code scanning alert returned by list_code_scanning_alerts.
+// Severity is the rule's security severity when it has one, such as high for a CodeQL security
+// query, and its plain severity otherwise. Path and StartLine locate the most recent instance.
+type CodeScanningAlert struct {
+	Number          int    `json:"number"`
+	RuleID          string `json:"rule_id"`
+	RuleDescription string `json:"rule_description"`
+	Severity        string `json:"severity"`
+	State           string `json:"state"`
+	Tool            string `json:"tool,omitempty"`
+	Path            string `json:"path,omitempty"`
+	StartLine       int    `json:"start_line,omitempty"`
+	HTMLURL         string `json:"html_url"`
+}
+
+func newCodeScanningAlert(alert *github.Alert) CodeScanningAlert {
+	rule := alert.GetRule()
+	severity := rule.GetSecuritySeverityLevel()
+	if severity == "" {
+		severity = rule.GetSeverity()
+	}
+	location := alert.GetMostRecentInstance().GetLocation()
+	return CodeScanningAlert{
+		Number:          alert.GetNumber(),
+		RuleID:          rule.GetID(),
+		RuleDescription: rule.GetDescription(),
+		Severity:        severity,
+		State:           alert.GetState(),
+		Tool:            alert.GetTool().GetName(),
+		Path:            location.GetPath(),
+		StartLine:       location.GetStartLine(),
+		HTMLURL:         alert.GetHTMLURL(),
+	}
+}
+
+// codeScanningDisabledResult returns a tool error saying that code scanning is not set up for the
+// repository when err is the 403 the API responds with in that case, and nil otherwise.
+func codeScanningDisabledResult(owner, repo string, resp *github.Response, err error) *mcp.CallToolResult {
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	message := strings.ToLower(err.Error())
+	if !strings.Contains(message, "not enabled") && !strings.Contains(message, "must be enabled") {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("code scanning is not enabled for %s/%s; it can be set up under the repository's Security settings, e.g. with CodeQL default setup", owner, repo))
+}
+
+// OrgCodeScanningAlertsResult is a page of an organization's code scanning alerts.
+type OrgCodeScanningAlertsResult struct {
+	Alerts []*github.Alert `json:"alerts"`
+	NextPageInfo
+}
+
+// ListOrgCodeScanningAlerts creates a tool to list the code scanning alerts of all repositories in an organization.
+func ListOrgCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_code_scanning_alerts",
+			mcp.WithDescription(t("TOOL_LIST_ORG_CODE_SCANNING_ALERTS_DESCRIPTION", "List code scanning alerts across all repositories of a GitHub organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_CODE_SCANNING_ALERTS_USER_TITLE", "List organization code scanning alerts"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization login."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter code scanning alerts by state. Defaults to open"),
+				mcp.DefaultString("open"),
+				mcp.Enum("open", "closed", "dismissed", "fixed"),
+			),
+			mcp.WithString("severity",
+				mcp.Description("Filter code scanning alerts by severity"),
+				mcp.Enum("critical", "high", "medium", "low", "warning", "note", "error"),
+			),
+			mcp.WithString("tool_name",
+				mcp.Description("The name of the tool used for code scanning."),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			severity, err := OptionalParam[string](request, "severity")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			toolName, err := OptionalParam[string](request, "tool_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			alerts, resp, err := client.CodeScanning.ListAlertsForOrg(ctx, org, &github.AlertListOptions{
+				State:    state,
+				Severity: severity,
+				ToolName: toolName,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to list alerts for organization '%s'", org), resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(OrgCodeScanningAlertsResult{
+				Alerts:       alerts,
+				NextPageInfo: nextPageInfo(resp),
+			}), nil
+		}
+}
+
+// codeScanningDismissedReasons are the reasons the API accepts when dismissing a code scanning alert.
+var codeScanningDismissedReasons = []string{"false positive", "won't fix", "used in tests"}
+
+// UpdateCodeScanningAlert creates a tool to dismiss or reopen a code scanning alert.
+func UpdateCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_code_scanning_alert",
+			mcp.WithDescription(t("TOOL_UPDATE_CODE_SCANNING_ALERT_DESCRIPTION", "Dismiss or reopen a code scanning alert in a GitHub repository. A reason is required when dismissing.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_CODE_SCANNING_ALERT_USER_TITLE", "Update code scanning alert"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("alertNumber",
+				mcp.Required(),
+				mcp.Description("The number of the alert."),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("The new state of the alert."),
+				mcp.Enum("dismissed", "open"),
+			),
+			mcp.WithString("dismissed_reason",
+				mcp.Description("The reason for dismissing the alert. Required when state is dismissed."),
+				mcp.Enum(codeScanningDismissedReasons...),
+			),
+			mcp.WithString("dismissed_comment",
+				mcp.Description("A comment explaining the dismissal."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertNumber, err := RequiredInt(request, "alertNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reason, err := OptionalParam[string](request, "dismissed_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			comment, err := OptionalParam[string](request, "dismissed_comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			stateInfo, err := newCodeScanningAlertState(state, reason, comment)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			alerts, resp, err := client.CodeScanning.ListAlertsForRepo(ctx, owner, repo, &github.AlertListOptions{Ref: ref, State: state, Severity: severity, ToolName: toolName})
+
+			alert, resp, err := client.CodeScanning.UpdateAlert(ctx, owner, repo, int64(alertNumber), stateInfo)
+			if result, ok, err := handleRESTResponse(ctx, "failed to update alert", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(alert), nil
+		}
+}
+
+// newCodeScanningAlertState validates the requested alert state before it is sent, so that a missing or
+// unknown dismissal reason is reported without a round trip to the API.
+func newCodeScanningAlertState(state, reason, comment string) (*github.CodeScanningAlertState, error) {
+	switch state {
+	case "dismissed":
+		if reason == "" {
+			return nil, fmt.Errorf("dismissed_reason is required when state is dismissed (one of: %s)", strings.Join(codeScanningDismissedReasons, ", "))
+		}
+		if !slices.Contains(codeScanningDismissedReasons, reason) {
+			return nil, fmt.Errorf("invalid dismissed_reason %q (one of: %s)", reason, strings.Join(codeScanningDismissedReasons, ", "))
+		}
+		stateInfo := &github.CodeScanningAlertState{
+			State:           state,
+			DismissedReason: github.Ptr(reason),
+		}
+		if comment != "" {
+			stateInfo.DismissedComment = github.Ptr(comment)
+		}
+		return stateInfo, nil
+	case "open":
+		if reason != "" || comment != "" {
+			return nil, fmt.Errorf("dismissed_reason and dismissed_comment can only be set when state is dismissed")
+		}
+		return &github.CodeScanningAlertState{State: state}, nil
+	default:
+		return nil, fmt.Errorf("invalid state %q (one of: dismissed, open)", state)
+	}
+}
+
+// codeScanningAutofixMaxLength caps the autofix description returned to the model. Suggested fixes
+// for large files can be long, and the full text is always available on the alert page.
+const codeScanningAutofixMaxLength = 16 * 1024
+
+// CodeScanningAutofix is the status of the autofix for a code scanning alert.
+type CodeScanningAutofix struct {
+	Status      string            `json:"status"`
+	Description string            `json:"description,omitempty"`
+	StartedAt   *github.Timestamp `json:"started_at,omitempty"`
+	// Truncated reports that the description was cut to codeScanningAutofixMaxLength bytes,
+	// in which case OriginalLength holds its full length.
+	Truncated      bool `json:"truncated,omitempty"`
+	OriginalLength int  `json:"original_length,omitempty"`
+}
+
+// GetCodeScanningAlertAutofix creates a tool to get the autofix status and suggestion for a code scanning alert.
+func GetCodeScanningAlertAutofix(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_code_scanning_alert_autofix",
+			mcp.WithDescription(t("TOOL_GET_CODE_SCANNING_ALERT_AUTOFIX_DESCRIPTION", "Get the Copilot Autofix status of a code scanning alert (pending, error, success or outdated) and, when available, the description of the suggested fix. Long descriptions are truncated. GitHub only provides the changes of the fix as a commit, which commit_code_scanning_alert_autofix creates and returns the patch of.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODE_SCANNING_ALERT_AUTOFIX_USER_TITLE", "Get code scanning alert autofix"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("alertNumber",
+				mcp.Required(),
+				mcp.Description("The number of the alert."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertNumber, err := RequiredInt(request, "alertNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github does not wrap the autofix endpoint yet
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d/autofix", owner, repo, alertNumber), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var autofix CodeScanningAutofix
+			resp, err := client.Do(ctx, req, &autofix)
+			if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultError(fmt.Sprintf("no autofix is available for alert %d in %s/%s", alertNumber, owner, repo)), nil
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to get autofix", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(truncateAutofix(autofix, codeScanningAutofixMaxLength)), nil
+		}
+}
+
+// CodeScanningAutofixCommit is the result of commit_code_scanning_alert_autofix: the commit that applies
+// an autofix and the files it changes. The patches are cut to codeScanningAutofixMaxLength bytes in total.
+type CodeScanningAutofixCommit struct {
+	TargetRef string         `json:"target_ref"`
+	SHA       string         `json:"sha"`
+	Files     []ComparedFile `json:"files"`
+}
+
+// CommitCodeScanningAlertAutofix creates a tool to commit the autofix of a code scanning alert to a branch
+// and return its patch.
+func CommitCodeScanningAlertAutofix(getClient GetClientFn, guard *DefaultBranchGuard, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("commit_code_scanning_alert_autofix",
+			mcp.WithDescription(t("TOOL_COMMIT_CODE_SCANNING_ALERT_AUTOFIX_DESCRIPTION", fmt.Sprintf("Commit the Copilot Autofix of a code scanning alert to an existing branch and return the patch of the fix, cut to %d bytes in total. The autofix must have status success, see get_code_scanning_alert_autofix.", codeScanningAutofixMaxLength))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMMIT_CODE_SCANNING_ALERT_AUTOFIX_USER_TITLE", "Commit code scanning alert autofix"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("alertNumber",
+				mcp.Required(),
+				mcp.Description("The number of the alert."),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Existing branch to commit the fix to"),
+			),
+			mcp.WithString("message",
+				mcp.Description("Commit message. GitHub writes one if omitted"),
+			),
+			WithAllowDefaultBranch(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
 			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to list alerts",
-					resp,
-					err,
-				), nil
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertNumber, err := RequiredInt(request, "alertNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := OptionalParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowDefaultBranch, err := OptionalParam[bool](request, "allow_default_branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result := guard.Check(ctx, client, owner, repo, branch, allowDefaultBranch); result != nil {
+				return result, nil
 			}
 
-			r, err := json.Marshal(alerts)
+			// go-github does not wrap the autofix endpoints yet
+			body := map[string]string{"target_ref": "refs/heads/" + strings.TrimPrefix(branch, "refs/heads/")}
+			if message != "" {
+				body["message"] = message
+			}
+			req, err := client.NewRequest(http.MethodPost, fmt.Sprintf("repos/%s/%s/code-scanning/alerts/%d/autofix/commits", owner, repo, alertNumber), body)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var created struct {
+				TargetRef string `json:"target_ref"`
+				SHA       string `json:"sha"`
+			}
+			resp, err := client.Do(ctx, req, &created)
+			if result, ok, err := handleRESTResponse(ctx, "failed to commit autofix", resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, created.SHA, nil)
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to get autofix commit %s", created.SHA), resp, err); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(CodeScanningAutofixCommit{
+				TargetRef: created.TargetRef,
+				SHA:       created.SHA,
+				Files:     autofixFiles(commit.Files, codeScanningAutofixMaxLength),
+			}), nil
+		}
+}
+
+// autofixFiles lists the files changed by an autofix commit with their patches, which share a budget
+// of maxBytes. A patch that does not fit is cut at a line boundary, and the patches after it are left out.
+func autofixFiles(files []*github.CommitFile, maxBytes int) []ComparedFile {
+	result := make([]ComparedFile, 0, len(files))
+	remaining := maxBytes
+	for _, file := range files {
+		compared := ComparedFile{
+			Filename:         file.GetFilename(),
+			PreviousFilename: file.GetPreviousFilename(),
+			Status:           file.GetStatus(),
+			Additions:        file.GetAdditions(),
+			Deletions:        file.GetDeletions(),
+			Patch:            file.GetPatch(),
 		}
+		if len(compared.Patch) > remaining {
+			compared.Patch = compared.Patch[:lineBoundaryCut(compared.Patch, remaining)]
+			compared.PatchTruncated = true
+			remaining = 0
+		} else {
+			remaining -= len(compared.Patch)
+		}
+		result = append(result, compared)
+	}
+	return result
+}
+
+// truncateAutofix cuts the autofix description to at most maxLength bytes without splitting a UTF-8 sequence.
+func truncateAutofix(autofix CodeScanningAutofix, maxLength int) CodeScanningAutofix {
+	if len(autofix.Description) <= maxLength {
+		return autofix
+	}
+	autofix.OriginalLength = len(autofix.Description)
+	autofix.Description = autofix.Description[:runeBoundaryCut(autofix.Description, maxLength)]
+	autofix.Truncated = true
+	return autofix
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/github/github-mcp-server/internal/toolsnaps"
@@ -78,6 +79,22 @@ func Test_GetCodeScanningAlert(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to get alert",
 		},
+		{
+			name: "advanced security not enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusForbidden, `{"message": "Advanced Security must be enabled for this repository to use code scanning."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "code scanning is not enabled for owner/repo",
+		},
 	}
 
 	for _, tc := range tests {
@@ -134,6 +151,8 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "state")
 	assert.Contains(t, tool.InputSchema.Properties, "severity")
 	assert.Contains(t, tool.InputSchema.Properties, "tool_name")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Setup mock alerts for success case
@@ -141,8 +160,13 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 		{
 			Number:  github.Ptr(42),
 			State:   github.Ptr("open"),
-			Rule:    &github.Rule{ID: github.Ptr("test-rule-1"), Description: github.Ptr("Test Rule 1")},
+			Rule:    &github.Rule{ID: github.Ptr("test-rule-1"), Description: github.Ptr("Test Rule 1"), Severity: github.Ptr("error"), SecuritySeverityLevel: github.Ptr("high")},
+			Tool:    &github.Tool{Name: github.Ptr("CodeQL")},
 			HTMLURL: github.Ptr("https://github.com/owner/repo/security/code-scanning/42"),
+			MostRecentInstance: &github.MostRecentInstance{
+				Ref:      github.Ptr("refs/heads/main"),
+				Location: &github.Location{Path: github.Ptr("src/app.go"), StartLine: github.Ptr(17), EndLine: github.Ptr(19)},
+			},
 		},
 		{
 			Number:  github.Ptr(43),
@@ -157,7 +181,7 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedAlerts []*github.Alert
+		expectedAlerts []CodeScanningAlert
 		expectedErrMsg string
 	}{
 		{
@@ -170,6 +194,8 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 						"state":     "open",
 						"severity":  "high",
 						"tool_name": "codeql",
+						"page":      "2",
+						"per_page":  "50",
 					}).andThen(
 						mockResponse(t, http.StatusOK, mockAlerts),
 					),
@@ -182,9 +208,60 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 				"state":     "open",
 				"severity":  "high",
 				"tool_name": "codeql",
+				"page":      float64(2),
+				"perPage":   float64(50),
 			},
-			expectError:    false,
-			expectedAlerts: mockAlerts,
+			expectError: false,
+			expectedAlerts: []CodeScanningAlert{
+				{
+					Number:          42,
+					RuleID:          "test-rule-1",
+					RuleDescription: "Test Rule 1",
+					Severity:        "high",
+					State:           "open",
+					Tool:            "CodeQL",
+					Path:            "src/app.go",
+					StartLine:       17,
+					HTMLURL:         "https://github.com/owner/repo/security/code-scanning/42",
+				},
+				{
+					Number:          43,
+					RuleID:          "test-rule-2",
+					RuleDescription: "Test Rule 2",
+					State:           "fixed",
+					HTMLURL:         "https://github.com/owner/repo/security/code-scanning/43",
+				},
+			},
+		},
+		{
+			name: "code scanning not enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAlertsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Code scanning is not enabled for this repository. Please enable code scanning in the repository settings."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "code scanning is not enabled for owner/repo",
+		},
+		{
+			name: "other forbidden errors are passed through",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAlertsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list alerts",
 		},
 		{
 			name: "alerts listing fails",
@@ -234,16 +311,511 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedAlerts []*github.Alert
+			var returnedAlerts []CodeScanningAlert
 			err = json.Unmarshal([]byte(textContent.Text), &returnedAlerts)
-			assert.NoError(t, err)
-			assert.Len(t, returnedAlerts, len(tc.expectedAlerts))
-			for i, alert := range returnedAlerts {
-				assert.Equal(t, *tc.expectedAlerts[i].Number, *alert.Number)
-				assert.Equal(t, *tc.expectedAlerts[i].State, *alert.State)
-				assert.Equal(t, *tc.expectedAlerts[i].Rule.ID, *alert.Rule.ID)
-				assert.Equal(t, *tc.expectedAlerts[i].HTMLURL, *alert.HTMLURL)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAlerts, returnedAlerts)
+		})
+	}
+}
+
+func Test_UpdateCodeScanningAlert(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateCodeScanningAlert(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_code_scanning_alert", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "alertNumber", "state"})
+
+	mockAlert := &github.Alert{
+		Number:          github.Ptr(42),
+		State:           github.Ptr("dismissed"),
+		DismissedReason: github.Ptr("false positive"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "dismisses with reason and comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state":             "dismissed",
+						"dismissed_reason":  "false positive",
+						"dismissed_comment": "Input is sanitized upstream",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlert),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"alertNumber":       float64(42),
+				"state":             "dismissed",
+				"dismissed_reason":  "false positive",
+				"dismissed_comment": "Input is sanitized upstream",
+			},
+		},
+		{
+			name: "reopens without reason",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state": "open",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Alert{Number: github.Ptr(42), State: github.Ptr("open")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"state":       "open",
+			},
+		},
+		{
+			name: "dismissal without reason is rejected locally",
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"state":       "dismissed",
+			},
+			expectError:    true,
+			expectedErrMsg: "dismissed_reason is required when state is dismissed",
+		},
+		{
+			name: "unknown reason is rejected locally",
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"alertNumber":      float64(42),
+				"state":            "dismissed",
+				"dismissed_reason": "not a bug",
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid dismissed_reason "not a bug"`,
+		},
+		{
+			name: "update fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"alertNumber":      float64(42),
+				"state":            "dismissed",
+				"dismissed_reason": "won't fix",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update alert",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateCodeScanningAlert(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returnedAlert github.Alert
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlert))
+			assert.Equal(t, 42, returnedAlert.GetNumber())
+			assert.Equal(t, tc.requestArgs["state"], returnedAlert.GetState())
+		})
+	}
+}
+
+func Test_GetCodeScanningAlertAutofix(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeScanningAlertAutofix(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_code_scanning_alert_autofix", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "alertNumber"})
+
+	longDescription := strings.Repeat("Use a parameterized query. ", 1000)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectedErrMsg  string
+		expectedAutofix CodeScanningAutofix
+	}{
+		{
+			name: "returns autofix suggestion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCodeScanningAlertsAutofixByOwnerByRepoByAlertNumber,
+					map[string]interface{}{
+						"status":      "success",
+						"description": "Escape the user input before building the query.",
+					},
+				),
+			),
+			expectedAutofix: CodeScanningAutofix{
+				Status:      "success",
+				Description: "Escape the user input before building the query.",
+			},
+		},
+		{
+			name: "truncates long suggestion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCodeScanningAlertsAutofixByOwnerByRepoByAlertNumber,
+					map[string]interface{}{
+						"status":      "success",
+						"description": longDescription,
+					},
+				),
+			),
+			expectedAutofix: CodeScanningAutofix{
+				Status:         "success",
+				Description:    longDescription[:codeScanningAutofixMaxLength],
+				Truncated:      true,
+				OriginalLength: len(longDescription),
+			},
+		},
+		{
+			name: "no autofix available",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAlertsAutofixByOwnerByRepoByAlertNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "no autofix is available for alert 42 in owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCodeScanningAlertAutofix(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned CodeScanningAutofix
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedAutofix, returned)
+		})
+	}
+}
+
+func Test_TruncateAutofix(t *testing.T) {
+	short := CodeScanningAutofix{Status: "success", Description: "short"}
+	assert.Equal(t, short, truncateAutofix(short, 10))
+
+	// "é" is two bytes, so a cut at 4 bytes would split the second rune.
+	truncated := truncateAutofix(CodeScanningAutofix{Status: "success", Description: "aéé"}, 4)
+	assert.Equal(t, "aé", truncated.Description)
+	assert.True(t, truncated.Truncated)
+	assert.Equal(t, 5, truncated.OriginalLength)
+}
+
+func Test_CommitCodeScanningAlertAutofix(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CommitCodeScanningAlertAutofix(stubGetClientFn(mockClient), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "commit_code_scanning_alert_autofix", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "alertNumber", "branch"})
+
+	patch := "@@ -1 +1 @@\n-query(\"SELECT \" + input)\n+query(\"SELECT ?\", input)\n"
+	commit := &github.RepositoryCommit{
+		SHA: github.Ptr("fix123"),
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("db.go"), Status: github.Ptr("modified"), Additions: github.Ptr(1), Deletions: github.Ptr(1), Patch: github.Ptr(patch)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		guard          *DefaultBranchGuard
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedCommit CodeScanningAutofixCommit
+	}{
+		{
+			name: "commits the fix and returns its patch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodeScanningAlertsAutofixCommitsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"target_ref": "refs/heads/fix-sql",
+						"message":    "Fix SQL injection",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, map[string]string{"target_ref": "refs/heads/fix-sql", "sha": "fix123"}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					commit,
+				),
+			),
+			guard: NewDefaultBranchGuard(false),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"branch":      "fix-sql",
+				"message":     "Fix SQL injection",
+			},
+			expectedCommit: CodeScanningAutofixCommit{
+				TargetRef: "refs/heads/fix-sql",
+				SHA:       "fix123",
+				Files: []ComparedFile{
+					{Filename: "db.go", Status: "modified", Additions: 1, Deletions: 1, Patch: patch},
+				},
+			},
+		},
+		{
+			name: "refuses the default branch when it is protected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+			),
+			guard: NewDefaultBranchGuard(true),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"branch":      "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "refusing to write to main",
+		},
+		{
+			name: "autofix not ready",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodeScanningAlertsAutofixCommitsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Autofix is not ready"}),
+				),
+			),
+			guard: NewDefaultBranchGuard(false),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"branch":      "fix-sql",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to commit autofix",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CommitCodeScanningAlertAutofix(stubGetClientFn(client), tc.guard, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned CodeScanningAutofixCommit
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedCommit, returned)
+		})
+	}
+}
+
+func Test_AutofixFiles(t *testing.T) {
+	files := []*github.CommitFile{
+		{Filename: github.Ptr("a.go"), Patch: github.Ptr("@@ -1 +1 @@\n-a\n+b\n")},
+		{Filename: github.Ptr("b.go"), Patch: github.Ptr("@@ -1 +1 @@\n-c\n+d\n")},
+		{Filename: github.Ptr("c.go"), Patch: github.Ptr("@@ -1 +1 @@\n-e\n+f\n")},
+	}
+
+	// The first patch fits, the second is cut to its first line and the third is left out
+	returned := autofixFiles(files, len("@@ -1 +1 @@\n-a\n+b\n")+len("@@ -1 +1 @@\n")+2)
+	require.Len(t, returned, 3)
+	assert.Equal(t, "@@ -1 +1 @@\n-a\n+b\n", returned[0].Patch)
+	assert.False(t, returned[0].PatchTruncated)
+	assert.Equal(t, "@@ -1 +1 @@\n", returned[1].Patch)
+	assert.True(t, returned[1].PatchTruncated)
+	assert.Empty(t, returned[2].Patch)
+	assert.True(t, returned[2].PatchTruncated)
+}
+
+func Test_ListOrgCodeScanningAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgCodeScanningAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_code_scanning_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "severity")
+	assert.Contains(t, tool.InputSchema.Properties, "tool_name")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockAlerts := []*github.Alert{
+		{
+			Number:     github.Ptr(7),
+			State:      github.Ptr("open"),
+			Repository: &github.Repository{FullName: github.Ptr("octo-org/api")},
+			Rule:       &github.Rule{ID: github.Ptr("go/sql-injection"), SecuritySeverityLevel: github.Ptr("high")},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedAlerts   []*github.Alert
+		expectedNextPage int
+	}{
+		{
+			name: "filters by state, severity and tool",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					expectQueryParams(t, map[string]string{
+						"state":     "open",
+						"severity":  "high",
+						"tool_name": "CodeQL",
+						"page":      "1",
+						"per_page":  "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"state":     "open",
+				"severity":  "high",
+				"tool_name": "CodeQL",
+			},
+			expectedAlerts: mockAlerts,
+		},
+		{
+			name: "reports the next page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "50",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/orgs/octo-org/code-scanning/alerts?page=3&per_page=50>; rel="next"`)
+							_ = json.NewEncoder(w).Encode(mockAlerts)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "octo-org",
+				"page":    float64(2),
+				"perPage": float64(50),
+			},
+			expectedAlerts:   mockAlerts,
+			expectedNextPage: 3,
+		},
+		{
+			name: "alerts listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list alerts for organization 'missing-org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgCodeScanningAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
 			}
+
+			require.False(t, result.IsError)
+			var returned OrgCodeScanningAlertsResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedAlerts, returned.Alerts)
+			assert.Equal(t, tc.expectedNextPage, returned.NextPage)
 		})
 	}
 }
@@ -0,0 +1,239 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v73/github"
+)
+
+// codeownersPaths are the locations GitHub reads a CODEOWNERS file from, in order of precedence.
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeownersRule is a single line of a CODEOWNERS file.
+type codeownersRule struct {
+	pattern string
+	owners  []string
+}
+
+// parseCodeowners parses the content of a CODEOWNERS file. Owners are kept as written, e.g. "@octocat",
+// "@org/team" or an email address. A pattern without owners is kept, as it removes ownership of the
+// paths it matches.
+func parseCodeowners(content string) []codeownersRule {
+	var rules []codeownersRule
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rules = append(rules, codeownersRule{pattern: fields[0], owners: fields[1:]})
+	}
+	return rules
+}
+
+// codeownersFor returns the owners of filePath. As in GitHub, the last matching rule wins.
+func codeownersFor(rules []codeownersRule, filePath string) []string {
+	var owners []string
+	for _, rule := range rules {
+		if matchCodeownersPattern(rule.pattern, filePath) {
+			owners = rule.owners
+		}
+	}
+	return owners
+}
+
+// matchCodeownersPattern reports whether filePath matches a CODEOWNERS pattern. The syntax follows
+// gitignore: a pattern matching a directory owns everything beneath it, except that a trailing "*"
+// only matches the files directly inside a directory.
+func matchCodeownersPattern(pattern, filePath string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	candidates := []string{}
+	if !dirOnly {
+		candidates = append(candidates, filePath)
+	}
+	if !strings.HasSuffix(pattern, "*") || strings.HasSuffix(pattern, "**") {
+		for dir := path.Dir(filePath); dir != "."; dir = path.Dir(dir) {
+			candidates = append(candidates, dir)
+		}
+	}
+
+	for _, candidate := range candidates {
+		if anchored {
+			if matchGitPattern("/"+pattern, candidate) {
+				return true
+			}
+			continue
+		}
+		if matched, _ := path.Match(pattern, path.Base(candidate)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// CodeownerReviewRequest reports the code owners requested to review a pull request.
+type CodeownerReviewRequest struct {
+	Requested []RequestedCodeowner `json:"requested"`
+	Warnings  []string             `json:"warnings,omitempty"`
+}
+
+// RequestedCodeowner is a user or team requested as a reviewer, with the changed paths it owns.
+type RequestedCodeowner struct {
+	Owner string   `json:"owner"`
+	Type  string   `json:"type"`
+	Paths []string `json:"paths"`
+}
+
+// resolveCodeowners maps the owners of the changed files to reviewers. Users and teams of the repository
+// owner's organization can be requested; email owners and teams of other organizations cannot, so they are
+// reported as warnings. The author cannot review their own pull request and is left out.
+func resolveCodeowners(rules []codeownersRule, files []string, repoOwner, author string) ([]RequestedCodeowner, []string) {
+	var reviewers []RequestedCodeowner
+	index := map[string]int{}
+	skipped := map[string]bool{}
+	var warnings []string
+
+	for _, file := range files {
+		for _, owner := range codeownersFor(rules, file) {
+			name := strings.TrimPrefix(owner, "@")
+			kind := "user"
+			if org, team, isTeam := strings.Cut(name, "/"); isTeam {
+				kind = "team"
+				if !strings.EqualFold(org, repoOwner) || team == "" {
+					if !skipped[owner] {
+						skipped[owner] = true
+						warnings = append(warnings, fmt.Sprintf("skipped %s: only teams of %s can be requested", owner, repoOwner))
+					}
+					continue
+				}
+				name = team
+			} else if !strings.HasPrefix(owner, "@") {
+				if !skipped[owner] {
+					skipped[owner] = true
+					warnings = append(warnings, fmt.Sprintf("skipped %s: owners given by email cannot be requested", owner))
+				}
+				continue
+			}
+			if kind == "user" && strings.EqualFold(name, author) {
+				continue
+			}
+
+			key := kind + ":" + strings.ToLower(name)
+			i, found := index[key]
+			if !found {
+				i = len(reviewers)
+				index[key] = i
+				reviewers = append(reviewers, RequestedCodeowner{Owner: name, Type: kind})
+			}
+			if !slices.Contains(reviewers[i].Paths, file) {
+				reviewers[i].Paths = append(reviewers[i].Paths, file)
+			}
+		}
+	}
+	return reviewers, warnings
+}
+
+// getCodeowners fetches and parses the CODEOWNERS file of a repository at ref, and returns its path
+// as well. The path is empty if the repository has no CODEOWNERS file, and the rules are empty if
+// the file has none.
+func getCodeowners(ctx context.Context, client *github.Client, owner, repo, ref string) ([]codeownersRule, string, error) {
+	for _, p := range codeownersPaths {
+		file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, p, &github.RepositoryContentGetOptions{Ref: ref})
+		closeResponseBody(resp)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, "", fmt.Errorf("failed to get %s: %w", p, err)
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to decode %s: %w", p, err)
+		}
+		return parseCodeowners(content), p, nil
+	}
+	return nil, "", nil
+}
+
+// requestCodeownerReviews requests reviews from the code owners of the files changed by a pull request.
+// Nothing here fails the caller: problems are reported as warnings. If requesting all reviewers at once
+// is rejected, each is requested on its own so that the others still go through.
+func requestCodeownerReviews(ctx context.Context, client *github.Client, owner, repo string, pr *github.PullRequest) *CodeownerReviewRequest {
+	result := &CodeownerReviewRequest{Requested: []RequestedCodeowner{}}
+
+	rules, path, err := getCodeowners(ctx, client, owner, repo, pr.GetBase().GetRef())
+	if err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+		return result
+	}
+	if path == "" {
+		result.Warnings = append(result.Warnings, "no CODEOWNERS file found")
+		return result
+	}
+	if len(rules) == 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("CODEOWNERS file %s is empty or only has comments", path))
+		return result
+	}
+
+	var files []string
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pr.GetNumber(), opts)
+		closeResponseBody(resp)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to list changed files: %v", err))
+			return result
+		}
+		for _, file := range page {
+			files = append(files, file.GetFilename())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	reviewers, warnings := resolveCodeowners(rules, files, owner, pr.GetUser().GetLogin())
+	result.Warnings = append(result.Warnings, warnings...)
+	if len(reviewers) == 0 {
+		return result
+	}
+
+	if err := requestReviewers(ctx, client, owner, repo, pr.GetNumber(), reviewers); err == nil {
+		result.Requested = reviewers
+		return result
+	}
+	for _, reviewer := range reviewers {
+		if err := requestReviewers(ctx, client, owner, repo, pr.GetNumber(), []RequestedCodeowner{reviewer}); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to request review from %s %s: %v", reviewer.Type, reviewer.Owner, err))
+			continue
+		}
+		result.Requested = append(result.Requested, reviewer)
+	}
+	return result
+}
+
+func requestReviewers(ctx context.Context, client *github.Client, owner, repo string, number int, reviewers []RequestedCodeowner) error {
+	request := github.ReviewersRequest{}
+	for _, reviewer := range reviewers {
+		if reviewer.Type == "team" {
+			request.TeamReviewers = append(request.TeamReviewers, reviewer.Owner)
+		} else {
+			request.Reviewers = append(request.Reviewers, reviewer.Owner)
+		}
+	}
+	_, resp, err := client.PullRequests.RequestReviewers(ctx, owner, repo, number, request)
+	closeResponseBody(resp)
+	return err
+}
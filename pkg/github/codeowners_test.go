@@ -0,0 +1,73 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseCodeowners(t *testing.T) {
+	content := `# Default owners
+*       @octo-org/core
+
+/docs/  @writer docs@example.com   # inline comment
+*.go    @gopher @octo-org/go-team
+/vendor/
+`
+	assert.Equal(t, []codeownersRule{
+		{pattern: "*", owners: []string{"@octo-org/core"}},
+		{pattern: "/docs/", owners: []string{"@writer", "docs@example.com"}},
+		{pattern: "*.go", owners: []string{"@gopher", "@octo-org/go-team"}},
+		{pattern: "/vendor/", owners: []string{}},
+	}, parseCodeowners(content))
+}
+
+func Test_MatchCodeownersPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		filePath string
+		expected bool
+	}{
+		{pattern: "*", filePath: "a/b/c.go", expected: true},
+		{pattern: "*.js", filePath: "web/app.js", expected: true},
+		{pattern: "*.js", filePath: "web/app.ts", expected: false},
+		{pattern: "/docs/", filePath: "docs/guide/intro.md", expected: true},
+		{pattern: "/docs/", filePath: "api/docs/intro.md", expected: false},
+		{pattern: "apps/", filePath: "services/apps/main.go", expected: true},
+		{pattern: "docs/*", filePath: "docs/intro.md", expected: true},
+		{pattern: "docs/*", filePath: "docs/guide/intro.md", expected: false},
+		{pattern: "/build/logs", filePath: "build/logs/today.log", expected: true},
+		{pattern: "**/logs", filePath: "deep/tree/logs/today.log", expected: true},
+		{pattern: "/src/**", filePath: "src/a/b.go", expected: true},
+		{pattern: "README.md", filePath: "pkg/README.md", expected: true},
+		{pattern: "/README.md", filePath: "pkg/README.md", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pattern+" "+tc.filePath, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchCodeownersPattern(tc.pattern, tc.filePath))
+		})
+	}
+}
+
+func Test_ResolveCodeowners(t *testing.T) {
+	rules := parseCodeowners(`
+*             @octo-org/core
+*.go          @gopher @author
+/docs/        @writer docs@example.com
+/third_party/ @other-org/legal
+/vendor/
+`)
+	files := []string{"main.go", "pkg/server.go", "docs/intro.md", "third_party/LICENSE", "vendor/lib/lib.go", "Makefile"}
+
+	reviewers, warnings := resolveCodeowners(rules, files, "octo-org", "Author")
+	assert.Equal(t, []RequestedCodeowner{
+		{Owner: "gopher", Type: "user", Paths: []string{"main.go", "pkg/server.go"}},
+		{Owner: "writer", Type: "user", Paths: []string{"docs/intro.md"}},
+		{Owner: "core", Type: "team", Paths: []string{"Makefile"}},
+	}, reviewers)
+	assert.Equal(t, []string{
+		"skipped docs@example.com: owners given by email cannot be requested",
+		"skipped @other-org/legal: only teams of octo-org can be requested",
+	}, warnings)
+}
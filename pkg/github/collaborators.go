@@ -0,0 +1,165 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// collaboratorPermissions are the permissions a repository collaborator can be given, from least to most access.
+var collaboratorPermissions = []string{"pull", "triage", "push", "maintain", "admin"}
+
+// Collaborator is a repository collaborator as returned by list_collaborators.
+type Collaborator struct {
+	Login       string          `json:"login"`
+	Permissions map[string]bool `json:"permissions"`
+	RoleName    string          `json:"role_name"`
+}
+
+// ListCollaborators creates a tool to list the collaborators of a repository.
+func ListCollaborators(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_collaborators",
+			mcp.WithDescription(t("TOOL_LIST_COLLABORATORS_DESCRIPTION", "List the collaborators of a GitHub repository with their permissions and role. Use this to answer who can read, push to or administer a repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COLLABORATORS_USER_TITLE", "List repository collaborators"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("affiliation",
+				mcp.Description("Filter by affiliation: outside collaborators only, direct collaborators only, or all collaborators including those with access through organization membership or teams"),
+				mcp.Enum("outside", "direct", "all"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Only list collaborators with this permission"),
+				mcp.Enum(collaboratorPermissions...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			affiliation, err := OptionalParam[string](request, "affiliation")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListCollaboratorsOptions{
+				Affiliation: affiliation,
+				Permission:  permission,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			users, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list collaborators", resp, err); !ok {
+				return result, err
+			}
+
+			collaborators := make([]Collaborator, 0, len(users))
+			for _, user := range users {
+				collaborators = append(collaborators, Collaborator{
+					Login:       user.GetLogin(),
+					Permissions: user.GetPermissions(),
+					RoleName:    user.GetRoleName(),
+				})
+			}
+			return MarshalledTextResult(collaborators), nil
+		}
+}
+
+// AddCollaborator creates a tool to invite a user to collaborate on a repository.
+func AddCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_collaborator",
+			mcp.WithDescription(t("TOOL_ADD_COLLABORATOR_DESCRIPTION", "Add a user as a collaborator on a GitHub repository. Usually this sends an invitation the user has to accept, which is returned. If the user already has access, their permission is updated and no invitation is created.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_COLLABORATOR_USER_TITLE", "Add repository collaborator"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Login of the user to add"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Permission to grant"),
+				mcp.Enum(collaboratorPermissions...),
+				mcp.DefaultString("push"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if permission == "" {
+				permission = "push"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			invitation, resp, err := client.Repositories.AddCollaborator(ctx, owner, repo, username, &github.RepositoryAddCollaboratorOptions{Permission: permission})
+			if result, ok, err := handleRESTResponse(ctx, "failed to add collaborator", resp, err, http.StatusCreated, http.StatusNoContent); !ok {
+				return result, err
+			}
+
+			// GitHub answers 204 without an invitation when the user already has access to the repository
+			if resp.StatusCode == http.StatusNoContent {
+				return mcp.NewToolResultText(fmt.Sprintf("%s already has access to %s/%s, so no invitation was created.", username, owner, repo)), nil
+			}
+			return MarshalledTextResult(invitation), nil
+		}
+}
@@ -0,0 +1,266 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCollaborators(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCollaborators(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_collaborators", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "affiliation")
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockUsers := []*github.User{
+		{
+			Login:       github.Ptr("octocat"),
+			Permissions: map[string]bool{"admin": true, "push": true, "pull": true},
+			RoleName:    github.Ptr("admin"),
+			AvatarURL:   github.Ptr("https://avatars.githubusercontent.com/u/1"),
+		},
+		{
+			Login:       github.Ptr("hubot"),
+			Permissions: map[string]bool{"admin": false, "push": false, "pull": true},
+			RoleName:    github.Ptr("read"),
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		mockedClient          *http.Client
+		requestArgs           map[string]interface{}
+		expectError           bool
+		expectedCollaborators []Collaborator
+		expectedErrMsg        string
+	}{
+		{
+			name: "lists collaborators",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCollaboratorsByOwnerByRepo, mockUsers),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedCollaborators: []Collaborator{
+				{Login: "octocat", Permissions: map[string]bool{"admin": true, "push": true, "pull": true}, RoleName: "admin"},
+				{Login: "hubot", Permissions: map[string]bool{"admin": false, "push": false, "pull": true}, RoleName: "read"},
+			},
+		},
+		{
+			name: "passes filters and pagination",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"affiliation": "outside",
+						"permission":  "push",
+						"page":        "2",
+						"per_page":    "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.User{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"affiliation": "outside",
+				"permission":  "push",
+				"page":        float64(2),
+				"perPage":     float64(10),
+			},
+			expectedCollaborators: []Collaborator{},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have push access to view repository collaborators."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list collaborators",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCollaborators(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var collaborators []Collaborator
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &collaborators))
+			assert.Equal(t, tc.expectedCollaborators, collaborators)
+			assert.NotContains(t, textContent.Text, "avatar_url")
+		})
+	}
+}
+
+func Test_AddCollaborator(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_collaborator", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+
+	mockInvitation := &github.CollaboratorInvitation{
+		ID:          github.Ptr(int64(42)),
+		Invitee:     &github.User{Login: github.Ptr("hubot")},
+		Permissions: github.Ptr("write"),
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/invitations"),
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedInvitation *github.CollaboratorInvitation
+		expectedText       string
+		expectedErrMsg     string
+	}{
+		{
+			name: "invitation created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{"permission": "maintain"}).andThen(
+						mockResponse(t, http.StatusCreated, mockInvitation),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"username":   "hubot",
+				"permission": "maintain",
+			},
+			expectedInvitation: mockInvitation,
+		},
+		{
+			name: "already a collaborator",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{"permission": "push"}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusNoContent)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "hubot",
+			},
+			expectedText: "hubot already has access to owner/repo, so no invitation was created.",
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "nobody",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add collaborator",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var invitation github.CollaboratorInvitation
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &invitation))
+			assert.Equal(t, tc.expectedInvitation.GetID(), invitation.GetID())
+			assert.Equal(t, tc.expectedInvitation.GetInvitee().GetLogin(), invitation.GetInvitee().GetLogin())
+			assert.Equal(t, tc.expectedInvitation.GetPermissions(), invitation.GetPermissions())
+		})
+	}
+}
+
+func Test_AddCollaboratorIsNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		repos, err := tsg.GetToolset("repos")
+		require.NoError(t, err)
+
+		var names []string
+		for _, st := range repos.GetAvailableTools() {
+			names = append(names, st.Tool.Name)
+		}
+		assert.Contains(t, names, "list_collaborators")
+		if readOnly {
+			assert.NotContains(t, names, "add_collaborator")
+		} else {
+			assert.Contains(t, names, "add_collaborator")
+		}
+	}
+}
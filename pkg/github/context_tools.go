@@ -5,7 +5,9 @@ import (
 	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -90,3 +92,120 @@ func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Too
 
 	return tool, handler
 }
+
+// ServerInfoResult is the payload returned by the get_server_info tool.
+type ServerInfoResult struct {
+	Version         string   `json:"version"`
+	Host            string   `json:"host"`
+	ReadOnly        bool     `json:"read_only"`
+	EnabledToolsets []string `json:"enabled_toolsets"`
+	ReadTools       int      `json:"read_tools"`
+	WriteTools      int      `json:"write_tools"`
+}
+
+// GetServerInfo creates a tool that reports how the server is configured and which tools it offers.
+// Toolsets can be enabled after construction, so enablement and tool counts are computed on each call.
+func GetServerInfo(info ServerInfo, toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("get_server_info",
+		mcp.WithDescription(t("TOOL_GET_SERVER_INFO_DESCRIPTION", "Get information about this GitHub MCP server: its version, the GitHub host it targets, whether read-only mode is active, which toolsets are enabled and how many read and write tools they offer.")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_GET_SERVER_INFO_USER_TITLE", "Get server information"),
+			ReadOnlyHint: ToBoolPtr(true),
+		}),
+	)
+
+	type args struct{}
+	handler := mcp.NewTypedToolHandler(func(_ context.Context, _ mcp.CallToolRequest, _ args) (*mcp.CallToolResult, error) {
+		host := info.Host
+		if host == "" {
+			host = "https://github.com"
+		}
+
+		result := ServerInfoResult{
+			Version:         info.Version,
+			Host:            host,
+			ReadOnly:        info.ReadOnly,
+			EnabledToolsets: []string{},
+		}
+
+		// List is a locked snapshot, so a toolset enabled meanwhile is either fully counted or not at all.
+		for _, info := range toolsetGroup.List() {
+			if !info.Enabled {
+				continue
+			}
+			toolset, err := toolsetGroup.GetToolset(info.Name)
+			if err != nil {
+				continue
+			}
+			result.EnabledToolsets = append(result.EnabledToolsets, info.Name)
+			for _, st := range toolset.GetAvailableTools() {
+				if st.Tool.Annotations.ReadOnlyHint != nil && *st.Tool.Annotations.ReadOnlyHint {
+					result.ReadTools++
+				} else {
+					result.WriteTools++
+				}
+			}
+		}
+
+		return MarshalledTextResult(result), nil
+	})
+
+	return tool, handler
+}
+
+// RateLimitBucket is one of the rate limits returned by the get_rate_limit tool.
+type RateLimitBucket struct {
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	Reset     string `json:"reset"`
+}
+
+// RateLimitStatus is the payload returned by the get_rate_limit tool.
+type RateLimitStatus struct {
+	Core    *RateLimitBucket `json:"core,omitempty"`
+	Search  *RateLimitBucket `json:"search,omitempty"`
+	GraphQL *RateLimitBucket `json:"graphql,omitempty"`
+}
+
+func newRateLimitBucket(rate *github.Rate) *RateLimitBucket {
+	if rate == nil {
+		return nil
+	}
+	return &RateLimitBucket{
+		Limit:     rate.Limit,
+		Remaining: rate.Remaining,
+		Reset:     rate.Reset.UTC().Format(time.RFC3339),
+	}
+}
+
+// GetRateLimit creates a tool that reports how much of the GitHub API rate limits remains.
+func GetRateLimit(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	tool := mcp.NewTool("get_rate_limit",
+		mcp.WithDescription(t("TOOL_GET_RATE_LIMIT_DESCRIPTION", "Get the GitHub API rate limits of the authenticated user: for the core REST API, search and GraphQL, the limit, the requests remaining and when the limit resets. Checking the rate limit does not count against it.")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        t("TOOL_GET_RATE_LIMIT_USER_TITLE", "Get API rate limits"),
+			ReadOnlyHint: ToBoolPtr(true),
+		}),
+	)
+
+	type args struct{}
+	handler := mcp.NewTypedToolHandler(func(ctx context.Context, _ mcp.CallToolRequest, _ args) (*mcp.CallToolResult, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to get GitHub client", err), nil
+		}
+
+		limits, resp, err := client.RateLimit.Get(ctx)
+		if result, ok, err := handleRESTResponse(ctx, "failed to get rate limits", resp, err); !ok {
+			return result, err
+		}
+
+		return MarshalledTextResult(RateLimitStatus{
+			Core:    newRateLimitBucket(limits.GetCore()),
+			Search:  newRateLimitBucket(limits.GetSearch()),
+			GraphQL: newRateLimitBucket(limits.GetGraphQL()),
+		}), nil
+	})
+
+	return tool, handler
+}
@@ -3,12 +3,15 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -139,3 +142,141 @@ func Test_GetMe(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetServerInfo(t *testing.T) {
+	t.Parallel()
+
+	tool, _ := GetServerInfo(ServerInfo{}, toolsets.NewToolsetGroup(false), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_server_info", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_server_info tool should be read-only")
+
+	// getServerInfo builds the default toolset group the same way the server does,
+	// enables the given toolsets and calls the get_server_info tool it registered.
+	getServerInfo := func(t *testing.T, info ServerInfo, enabledToolsets []string) ServerInfoResult {
+		tsg, err := DefaultToolsetGroup(info, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		require.NoError(t, tsg.EnableToolsets(enabledToolsets))
+
+		contextToolset, err := tsg.GetToolset("context")
+		require.NoError(t, err)
+
+		var handler server.ToolHandlerFunc
+		for _, st := range contextToolset.GetAvailableTools() {
+			if st.Tool.Name == "get_server_info" {
+				handler = st.Handler
+			}
+		}
+		require.NotNil(t, handler, "get_server_info should be registered in the context toolset")
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var serverInfo ServerInfoResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &serverInfo))
+		return serverInfo
+	}
+
+	t.Run("defaults to github.com with read and write tools", func(t *testing.T) {
+		info := getServerInfo(t, ServerInfo{Version: "1.2.3"}, []string{"context", "repos"})
+
+		assert.Equal(t, "1.2.3", info.Version)
+		assert.Equal(t, "https://github.com", info.Host)
+		assert.False(t, info.ReadOnly)
+		assert.Equal(t, []string{"context", "repos"}, info.EnabledToolsets)
+		assert.Positive(t, info.ReadTools)
+		assert.Positive(t, info.WriteTools)
+	})
+
+	t.Run("read-only mode reports no write tools", func(t *testing.T) {
+		info := getServerInfo(t, ServerInfo{Version: "1.2.3", Host: "https://ghe.example.com", ReadOnly: true}, []string{"all"})
+
+		assert.Equal(t, "https://ghe.example.com", info.Host)
+		assert.True(t, info.ReadOnly)
+		assert.Contains(t, info.EnabledToolsets, "issues")
+		assert.Contains(t, info.EnabledToolsets, "pull_requests")
+		assert.Positive(t, info.ReadTools)
+		assert.Zero(t, info.WriteTools)
+	})
+
+	t.Run("counts only tools of enabled toolsets", func(t *testing.T) {
+		contextOnly := getServerInfo(t, ServerInfo{}, []string{"context"})
+		withIssues := getServerInfo(t, ServerInfo{}, []string{"context", "issues"})
+
+		assert.Equal(t, []string{"context"}, contextOnly.EnabledToolsets)
+		assert.Zero(t, contextOnly.WriteTools)
+		assert.Greater(t, withIssues.ReadTools, contextOnly.ReadTools)
+		assert.Positive(t, withIssues.WriteTools)
+	})
+}
+
+func Test_GetRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tool, _ := GetRateLimit(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_rate_limit", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_rate_limit tool should be read-only")
+
+	reset := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	mockLimits := map[string]any{
+		"resources": map[string]any{
+			"core":    map[string]any{"limit": 5000, "remaining": 4321, "used": 679, "reset": reset.Unix()},
+			"search":  map[string]any{"limit": 30, "remaining": 0, "used": 30, "reset": reset.Add(time.Minute).Unix()},
+			"graphql": map[string]any{"limit": 5000, "remaining": 4999, "used": 1, "reset": reset.Unix()},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedStatus RateLimitStatus
+		expectedErrMsg string
+	}{
+		{
+			name: "returns core, search and graphql limits",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetRateLimit, mockLimits),
+			),
+			expectedStatus: RateLimitStatus{
+				Core:    &RateLimitBucket{Limit: 5000, Remaining: 4321, Reset: "2026-03-04T05:06:07Z"},
+				Search:  &RateLimitBucket{Limit: 30, Remaining: 0, Reset: "2026-03-04T05:07:07Z"},
+				GraphQL: &RateLimitBucket{Limit: 5000, Remaining: 4999, Reset: "2026-03-04T05:06:07Z"},
+			},
+		},
+		{
+			name: "getting rate limits fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetRateLimit,
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Internal Server Error"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get rate limits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetRateLimit(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var status RateLimitStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
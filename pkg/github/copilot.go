@@ -0,0 +1,326 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const copilotTopLanguages = 5
+
+// CopilotSeat is a trimmed view of a Copilot seat assignment.
+type CopilotSeat struct {
+	Assignee                string            `json:"assignee"`
+	AssigneeType            string            `json:"assignee_type"`
+	AssigningTeam           string            `json:"assigning_team,omitempty"`
+	PlanType                string            `json:"plan_type,omitempty"`
+	LastActivityAt          *github.Timestamp `json:"last_activity_at,omitempty"`
+	LastActivityEditor      string            `json:"last_activity_editor,omitempty"`
+	PendingCancellationDate string            `json:"pending_cancellation_date,omitempty"`
+	CreatedAt               *github.Timestamp `json:"created_at,omitempty"`
+}
+
+// CopilotSeatsResult is a single page of Copilot seat assignments for an organization.
+type CopilotSeatsResult struct {
+	TotalSeats int64         `json:"total_seats"`
+	Seats      []CopilotSeat `json:"seats"`
+	NextPageInfo
+}
+
+// CopilotLanguageUsage aggregates code completion metrics for a single language.
+type CopilotLanguageUsage struct {
+	Name                  string  `json:"name"`
+	TotalSuggestions      int     `json:"total_suggestions"`
+	TotalAcceptances      int     `json:"total_acceptances"`
+	TotalLinesSuggested   int     `json:"total_lines_suggested"`
+	TotalLinesAccepted    int     `json:"total_lines_accepted"`
+	AcceptanceRatePercent float64 `json:"acceptance_rate_percent"`
+}
+
+// CopilotUsageSummary summarizes daily Copilot metrics for an organization.
+type CopilotUsageSummary struct {
+	Org                   string                 `json:"org"`
+	Days                  int                    `json:"days"`
+	StartDate             string                 `json:"start_date,omitempty"`
+	EndDate               string                 `json:"end_date,omitempty"`
+	PeakActiveUsers       int                    `json:"peak_active_users"`
+	AverageActiveUsers    float64                `json:"average_active_users"`
+	PeakEngagedUsers      int                    `json:"peak_engaged_users"`
+	TotalSuggestions      int                    `json:"total_suggestions"`
+	TotalAcceptances      int                    `json:"total_acceptances"`
+	TotalLinesSuggested   int                    `json:"total_lines_suggested"`
+	TotalLinesAccepted    int                    `json:"total_lines_accepted"`
+	AcceptanceRatePercent float64                `json:"acceptance_rate_percent"`
+	TotalIDEChats         int                    `json:"total_ide_chats"`
+	TotalDotcomChats      int                    `json:"total_dotcom_chats"`
+	TotalPRSummaries      int                    `json:"total_pr_summaries"`
+	TopLanguages          []CopilotLanguageUsage `json:"top_languages"`
+}
+
+// GetCopilotBillingSeats creates a tool to list the Copilot seat assignments of an organization.
+func GetCopilotBillingSeats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_copilot_billing_seats",
+			mcp.WithDescription(t("TOOL_GET_COPILOT_BILLING_SEATS_DESCRIPTION", "List the GitHub Copilot seat assignments of an organization, including each assignee's last activity and any pending cancellation. Requires an organization with Copilot Business or Enterprise and organization admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COPILOT_BILLING_SEATS_USER_TITLE", "Get Copilot billing seats"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			seats, resp, err := client.Copilot.ListCopilotSeats(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if result, ok := copilotUnavailableResult(org, resp, err); ok {
+				return result, nil
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to list Copilot seats", resp, err); !ok {
+				return result, err
+			}
+
+			result := CopilotSeatsResult{
+				TotalSeats:   seats.TotalSeats,
+				Seats:        make([]CopilotSeat, 0, len(seats.Seats)),
+				NextPageInfo: nextPageInfo(resp),
+			}
+			for _, seat := range seats.Seats {
+				result.Seats = append(result.Seats, newCopilotSeat(seat))
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// GetCopilotUsageSummary creates a tool to summarize the daily Copilot metrics of an organization.
+func GetCopilotUsageSummary(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_copilot_usage_summary",
+			mcp.WithDescription(t("TOOL_GET_COPILOT_USAGE_SUMMARY_DESCRIPTION", "Summarize GitHub Copilot usage for an organization. Daily metrics are totalled into suggestion, acceptance and chat counts, with the top 5 languages by suggestions. Requires an organization with Copilot Business or Enterprise and organization admin access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_COPILOT_USAGE_SUMMARY_USER_TITLE", "Get Copilot usage summary"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only include days on or after this date (ISO 8601, e.g. 2025-01-01 or 2025-01-01T00:00:00Z). The API returns at most the last 100 days."),
+			),
+			mcp.WithString("until",
+				mcp.Description("Only include days on or before this date (ISO 8601)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			until, err := OptionalParam[string](request, "until")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.CopilotMetricsListOptions{
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			if since != "" {
+				sinceTime, err := parseISOTimestamp(since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid since: %s", err)), nil
+				}
+				opts.Since = &sinceTime
+			}
+			if until != "" {
+				untilTime, err := parseISOTimestamp(until)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid until: %s", err)), nil
+				}
+				opts.Until = &untilTime
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			metrics, resp, err := client.Copilot.GetOrganizationMetrics(ctx, org, opts)
+			if result, ok := copilotUnavailableResult(org, resp, err); ok {
+				return result, nil
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to get Copilot metrics", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(summarizeCopilotMetrics(org, metrics)), nil
+		}
+}
+
+// copilotUnavailableResult maps the 403/404 that GitHub returns for organizations
+// without Copilot Business (or without access to its admin data) to a clear message.
+func copilotUnavailableResult(org string, resp *github.Response, err error) (*mcp.CallToolResult, bool) {
+	if err == nil || resp == nil {
+		return nil, false
+	}
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusNotFound {
+		return nil, false
+	}
+	_ = resp.Body.Close()
+	return mcp.NewToolResultError(fmt.Sprintf("GitHub Copilot data is not available for this org (%s): the organization may not have Copilot Business or Enterprise, or the token lacks organization admin access (status %d)", org, resp.StatusCode)), true
+}
+
+func newCopilotSeat(seat *github.CopilotSeatDetails) CopilotSeat {
+	result := CopilotSeat{
+		PlanType:                seat.GetPlanType(),
+		LastActivityAt:          seat.LastActivityAt,
+		LastActivityEditor:      seat.GetLastActivityEditor(),
+		PendingCancellationDate: seat.GetPendingCancellationDate(),
+		CreatedAt:               seat.CreatedAt,
+	}
+	if user, ok := seat.GetUser(); ok {
+		result.Assignee = user.GetLogin()
+		result.AssigneeType = "User"
+	} else if team, ok := seat.GetTeam(); ok {
+		result.Assignee = team.GetSlug()
+		result.AssigneeType = "Team"
+	} else if org, ok := seat.GetOrganization(); ok {
+		result.Assignee = org.GetLogin()
+		result.AssigneeType = "Organization"
+	}
+	if seat.AssigningTeam != nil {
+		result.AssigningTeam = seat.AssigningTeam.GetSlug()
+	}
+	return result
+}
+
+// summarizeCopilotMetrics totals daily Copilot metrics and ranks languages by code suggestions.
+func summarizeCopilotMetrics(org string, metrics []*github.CopilotMetrics) CopilotUsageSummary {
+	summary := CopilotUsageSummary{
+		Org:          org,
+		TopLanguages: []CopilotLanguageUsage{},
+	}
+
+	languages := map[string]*CopilotLanguageUsage{}
+	totalActiveUsers := 0
+	for _, day := range metrics {
+		if day == nil {
+			continue
+		}
+		summary.Days++
+		if summary.StartDate == "" || day.Date < summary.StartDate {
+			summary.StartDate = day.Date
+		}
+		if day.Date > summary.EndDate {
+			summary.EndDate = day.Date
+		}
+
+		if day.TotalActiveUsers != nil {
+			totalActiveUsers += *day.TotalActiveUsers
+			summary.PeakActiveUsers = max(summary.PeakActiveUsers, *day.TotalActiveUsers)
+		}
+		if day.TotalEngagedUsers != nil {
+			summary.PeakEngagedUsers = max(summary.PeakEngagedUsers, *day.TotalEngagedUsers)
+		}
+
+		if completions := day.CopilotIDECodeCompletions; completions != nil {
+			for _, editor := range completions.Editors {
+				for _, model := range editor.Models {
+					for _, language := range model.Languages {
+						usage, ok := languages[language.Name]
+						if !ok {
+							usage = &CopilotLanguageUsage{Name: language.Name}
+							languages[language.Name] = usage
+						}
+						usage.TotalSuggestions += language.TotalCodeSuggestions
+						usage.TotalAcceptances += language.TotalCodeAcceptances
+						usage.TotalLinesSuggested += language.TotalCodeLinesSuggested
+						usage.TotalLinesAccepted += language.TotalCodeLinesAccepted
+
+						summary.TotalSuggestions += language.TotalCodeSuggestions
+						summary.TotalAcceptances += language.TotalCodeAcceptances
+						summary.TotalLinesSuggested += language.TotalCodeLinesSuggested
+						summary.TotalLinesAccepted += language.TotalCodeLinesAccepted
+					}
+				}
+			}
+		}
+		if chat := day.CopilotIDEChat; chat != nil {
+			for _, editor := range chat.Editors {
+				for _, model := range editor.Models {
+					summary.TotalIDEChats += model.TotalChats
+				}
+			}
+		}
+		if chat := day.CopilotDotcomChat; chat != nil {
+			for _, model := range chat.Models {
+				summary.TotalDotcomChats += model.TotalChats
+			}
+		}
+		if pulls := day.CopilotDotcomPullRequests; pulls != nil {
+			for _, repository := range pulls.Repositories {
+				for _, model := range repository.Models {
+					summary.TotalPRSummaries += model.TotalPRSummariesCreated
+				}
+			}
+		}
+	}
+
+	if summary.Days > 0 {
+		summary.AverageActiveUsers = math.Round(float64(totalActiveUsers)/float64(summary.Days)*10) / 10
+	}
+	summary.AcceptanceRatePercent = copilotAcceptanceRate(summary.TotalAcceptances, summary.TotalSuggestions)
+
+	for _, usage := range languages {
+		usage.AcceptanceRatePercent = copilotAcceptanceRate(usage.TotalAcceptances, usage.TotalSuggestions)
+		summary.TopLanguages = append(summary.TopLanguages, *usage)
+	}
+	slices.SortFunc(summary.TopLanguages, func(a, b CopilotLanguageUsage) int {
+		if c := cmp.Compare(b.TotalSuggestions, a.TotalSuggestions); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	if len(summary.TopLanguages) > copilotTopLanguages {
+		summary.TopLanguages = summary.TopLanguages[:copilotTopLanguages]
+	}
+
+	return summary
+}
+
+// copilotAcceptanceRate returns acceptances as a percentage of suggestions, rounded to one decimal place.
+func copilotAcceptanceRate(acceptances, suggestions int) float64 {
+	if suggestions == 0 {
+		return 0
+	}
+	return math.Round(float64(acceptances)/float64(suggestions)*1000) / 10
+}
@@ -0,0 +1,395 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCopilotBillingSeats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCopilotBillingSeats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_copilot_billing_seats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockSeats := map[string]any{
+		"total_seats": 3,
+		"seats": []map[string]any{
+			{
+				"created_at":           "2025-01-01T00:00:00Z",
+				"last_activity_at":     "2025-03-10T12:00:00Z",
+				"last_activity_editor": "vscode/1.98.0",
+				"plan_type":            "business",
+				"assignee":             map[string]any{"login": "octocat", "type": "User"},
+				"assigning_team":       map[string]any{"slug": "engineering"},
+			},
+			{
+				"created_at":                "2025-01-02T00:00:00Z",
+				"pending_cancellation_date": "2025-04-01",
+				"plan_type":                 "business",
+				"assignee":                  map[string]any{"login": "hubot", "type": "User"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult CopilotSeatsResult
+		expectedErrMsg string
+	}{
+		{
+			name: "successful seats listing with pagination",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "2",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/orgs/acme/copilot/billing/seats?page=3&per_page=2>; rel="next"`)
+							w.WriteHeader(http.StatusOK)
+							b, _ := json.Marshal(mockSeats)
+							_, _ = w.Write(b)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "acme",
+				"page":    float64(2),
+				"perPage": float64(2),
+			},
+			expectedResult: CopilotSeatsResult{
+				TotalSeats: 3,
+				Seats: []CopilotSeat{
+					{Assignee: "octocat", AssigneeType: "User", AssigningTeam: "engineering", PlanType: "business", LastActivityEditor: "vscode/1.98.0"},
+					{Assignee: "hubot", AssigneeType: "User", PlanType: "business", PendingCancellationDate: "2025-04-01"},
+				},
+				NextPageInfo: NextPageInfo{HasNextPage: true, NextPage: 3},
+			},
+		},
+		{
+			name: "org without Copilot Business",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "acme",
+			},
+			expectError:    true,
+			expectedErrMsg: "not available for this org (acme)",
+		},
+		{
+			name: "forbidden maps to unavailable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "acme",
+			},
+			expectError:    true,
+			expectedErrMsg: "not available for this org (acme)",
+		},
+		{
+			name: "server error is reported as is",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotBillingSeatsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "acme",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list Copilot seats",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCopilotBillingSeats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned CopilotSeatsResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult.TotalSeats, returned.TotalSeats)
+			assert.Equal(t, tc.expectedResult.NextPage, returned.NextPage)
+			require.Len(t, returned.Seats, len(tc.expectedResult.Seats))
+			for i, seat := range returned.Seats {
+				expected := tc.expectedResult.Seats[i]
+				assert.Equal(t, expected.Assignee, seat.Assignee)
+				assert.Equal(t, expected.AssigneeType, seat.AssigneeType)
+				assert.Equal(t, expected.AssigningTeam, seat.AssigningTeam)
+				assert.Equal(t, expected.PlanType, seat.PlanType)
+				assert.Equal(t, expected.LastActivityEditor, seat.LastActivityEditor)
+				assert.Equal(t, expected.PendingCancellationDate, seat.PendingCancellationDate)
+			}
+			assert.NotNil(t, returned.Seats[0].LastActivityAt)
+			assert.Nil(t, returned.Seats[1].LastActivityAt)
+		})
+	}
+}
+
+func Test_GetCopilotUsageSummary(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCopilotUsageSummary(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_copilot_usage_summary", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "until")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockMetrics := []*github.CopilotMetrics{
+		{
+			Date:             "2025-03-01",
+			TotalActiveUsers: github.Ptr(10),
+			CopilotIDECodeCompletions: &github.CopilotIDECodeCompletions{
+				Editors: []*github.CopilotIDECodeCompletionsEditor{
+					{Name: "vscode", Models: []*github.CopilotIDECodeCompletionsModel{
+						{Name: "default", Languages: []*github.CopilotIDECodeCompletionsModelLanguage{
+							{Name: "go", TotalCodeSuggestions: 100, TotalCodeAcceptances: 40},
+						}},
+					}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful usage summary",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotMetricsByOrg,
+					expectQueryParams(t, map[string]string{
+						"since":    "2025-03-01T00:00:00Z",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockMetrics),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":   "acme",
+				"since": "2025-03-01",
+			},
+		},
+		{
+			name: "org without Copilot Business",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCopilotMetricsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Copilot Usage Metrics API setting is disabled at the organization or enterprise level."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "acme",
+			},
+			expectError:    true,
+			expectedErrMsg: "not available for this org (acme)",
+		},
+		{
+			name:         "invalid since",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":   "acme",
+				"since": "last week",
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid since",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCopilotUsageSummary(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned CopilotUsageSummary
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "acme", returned.Org)
+			assert.Equal(t, 1, returned.Days)
+			assert.Equal(t, 100, returned.TotalSuggestions)
+			assert.Equal(t, 40.0, returned.AcceptanceRatePercent)
+		})
+	}
+}
+
+func Test_SummarizeCopilotMetrics(t *testing.T) {
+	language := func(name string, suggestions, acceptances int) *github.CopilotIDECodeCompletionsModelLanguage {
+		return &github.CopilotIDECodeCompletionsModelLanguage{
+			Name:                    name,
+			TotalCodeSuggestions:    suggestions,
+			TotalCodeAcceptances:    acceptances,
+			TotalCodeLinesSuggested: suggestions * 2,
+			TotalCodeLinesAccepted:  acceptances * 2,
+		}
+	}
+
+	metrics := []*github.CopilotMetrics{
+		{
+			Date:              "2025-03-02",
+			TotalActiveUsers:  github.Ptr(12),
+			TotalEngagedUsers: github.Ptr(8),
+			CopilotIDECodeCompletions: &github.CopilotIDECodeCompletions{
+				Editors: []*github.CopilotIDECodeCompletionsEditor{
+					{Name: "vscode", Models: []*github.CopilotIDECodeCompletionsModel{
+						{Name: "default", Languages: []*github.CopilotIDECodeCompletionsModelLanguage{
+							language("go", 100, 30),
+							language("python", 80, 40),
+							language("rust", 10, 1),
+						}},
+					}},
+					{Name: "jetbrains", Models: []*github.CopilotIDECodeCompletionsModel{
+						{Name: "default", Languages: []*github.CopilotIDECodeCompletionsModelLanguage{
+							language("go", 50, 20),
+							language("java", 60, 12),
+						}},
+					}},
+				},
+			},
+			CopilotIDEChat: &github.CopilotIDEChat{
+				Editors: []*github.CopilotIDEChatEditor{
+					{Name: "vscode", Models: []*github.CopilotIDEChatModel{{Name: "default", TotalChats: 7}}},
+				},
+			},
+			CopilotDotcomChat: &github.CopilotDotcomChat{
+				Models: []*github.CopilotDotcomChatModel{{Name: "default", TotalChats: 3}},
+			},
+			CopilotDotcomPullRequests: &github.CopilotDotcomPullRequests{
+				Repositories: []*github.CopilotDotcomPullRequestsRepository{
+					{Name: "acme/api", Models: []*github.CopilotDotcomPullRequestsModel{{Name: "default", TotalPRSummariesCreated: 2}}},
+				},
+			},
+		},
+		{
+			Date:              "2025-03-01",
+			TotalActiveUsers:  github.Ptr(7),
+			TotalEngagedUsers: github.Ptr(9),
+			CopilotIDECodeCompletions: &github.CopilotIDECodeCompletions{
+				Editors: []*github.CopilotIDECodeCompletionsEditor{
+					{Name: "vscode", Models: []*github.CopilotIDECodeCompletionsModel{
+						{Name: "default", Languages: []*github.CopilotIDECodeCompletionsModelLanguage{
+							language("typescript", 60, 30),
+							language("ruby", 5, 0),
+						}},
+					}},
+				},
+			},
+		},
+		nil,
+	}
+
+	summary := summarizeCopilotMetrics("acme", metrics)
+
+	assert.Equal(t, "acme", summary.Org)
+	assert.Equal(t, 2, summary.Days)
+	assert.Equal(t, "2025-03-01", summary.StartDate)
+	assert.Equal(t, "2025-03-02", summary.EndDate)
+	assert.Equal(t, 12, summary.PeakActiveUsers)
+	assert.Equal(t, 9.5, summary.AverageActiveUsers)
+	assert.Equal(t, 9, summary.PeakEngagedUsers)
+	assert.Equal(t, 365, summary.TotalSuggestions)
+	assert.Equal(t, 133, summary.TotalAcceptances)
+	assert.Equal(t, 730, summary.TotalLinesSuggested)
+	assert.Equal(t, 266, summary.TotalLinesAccepted)
+	assert.Equal(t, 36.4, summary.AcceptanceRatePercent)
+	assert.Equal(t, 7, summary.TotalIDEChats)
+	assert.Equal(t, 3, summary.TotalDotcomChats)
+	assert.Equal(t, 2, summary.TotalPRSummaries)
+
+	// go is merged across editors; java and typescript tie on suggestions and are ordered by name.
+	// ruby falls outside the top 5.
+	expectedTop := []CopilotLanguageUsage{
+		{Name: "go", TotalSuggestions: 150, TotalAcceptances: 50, TotalLinesSuggested: 300, TotalLinesAccepted: 100, AcceptanceRatePercent: 33.3},
+		{Name: "python", TotalSuggestions: 80, TotalAcceptances: 40, TotalLinesSuggested: 160, TotalLinesAccepted: 80, AcceptanceRatePercent: 50},
+		{Name: "java", TotalSuggestions: 60, TotalAcceptances: 12, TotalLinesSuggested: 120, TotalLinesAccepted: 24, AcceptanceRatePercent: 20},
+		{Name: "typescript", TotalSuggestions: 60, TotalAcceptances: 30, TotalLinesSuggested: 120, TotalLinesAccepted: 60, AcceptanceRatePercent: 50},
+		{Name: "rust", TotalSuggestions: 10, TotalAcceptances: 1, TotalLinesSuggested: 20, TotalLinesAccepted: 2, AcceptanceRatePercent: 10},
+	}
+	assert.Equal(t, expectedTop, summary.TopLanguages)
+}
+
+func Test_SummarizeCopilotMetricsEmpty(t *testing.T) {
+	summary := summarizeCopilotMetrics("acme", nil)
+
+	assert.Equal(t, 0, summary.Days)
+	assert.Equal(t, 0.0, summary.AverageActiveUsers)
+	assert.Equal(t, 0.0, summary.AcceptanceRatePercent)
+	assert.NotNil(t, summary.TopLanguages)
+	assert.Empty(t, summary.TopLanguages)
+}
@@ -0,0 +1,160 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CustomPropertyDefinition is a custom property defined by an organization for its repositories.
+type CustomPropertyDefinition struct {
+	PropertyName     string   `json:"property_name"`
+	ValueType        string   `json:"value_type"`
+	Required         bool     `json:"required"`
+	DefaultValue     string   `json:"default_value,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	AllowedValues    []string `json:"allowed_values,omitempty"`
+	ValuesEditableBy string   `json:"values_editable_by,omitempty"`
+	SourceType       string   `json:"source_type,omitempty"`
+}
+
+func newCustomPropertyDefinition(property *github.CustomProperty) CustomPropertyDefinition {
+	return CustomPropertyDefinition{
+		PropertyName:     property.GetPropertyName(),
+		ValueType:        property.ValueType,
+		Required:         property.GetRequired(),
+		DefaultValue:     property.GetDefaultValue(),
+		Description:      property.GetDescription(),
+		AllowedValues:    property.AllowedValues,
+		ValuesEditableBy: property.GetValuesEditableBy(),
+		SourceType:       property.GetSourceType(),
+	}
+}
+
+// RepositoryCustomPropertyValue is the value a repository has for a custom property, along with the
+// type and allowed values of the property when its definition could be read.
+type RepositoryCustomPropertyValue struct {
+	PropertyName  string   `json:"property_name"`
+	ValueType     string   `json:"value_type,omitempty"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+	Value         any      `json:"value"`
+}
+
+// RepositoryCustomPropertyValues lists the custom property values set on a repository.
+type RepositoryCustomPropertyValues struct {
+	Repository string                          `json:"repository"`
+	Values     []RepositoryCustomPropertyValue `json:"values"`
+	Warning    string                          `json:"warning,omitempty"`
+}
+
+// ListOrgCustomProperties creates a tool to list the custom properties an organization defines.
+func ListOrgCustomProperties(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_custom_properties",
+			mcp.WithDescription(t("TOOL_LIST_ORG_CUSTOM_PROPERTIES_DESCRIPTION", "List the custom properties an organization defines for its repositories, with each property's type, allowed values and default")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_CUSTOM_PROPERTIES_USER_TITLE", "List organization custom properties"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			properties, resp, err := client.Organizations.GetAllCustomProperties(ctx, org)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list custom properties", resp, err); !ok {
+				return result, err
+			}
+
+			definitions := make([]CustomPropertyDefinition, 0, len(properties))
+			for _, property := range properties {
+				definitions = append(definitions, newCustomPropertyDefinition(property))
+			}
+			return MarshalledTextResult(definitions), nil
+		}
+}
+
+// GetRepositoryCustomPropertyValues creates a tool to get the custom property values of a repository.
+func GetRepositoryCustomPropertyValues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_custom_property_values",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_CUSTOM_PROPERTY_VALUES_DESCRIPTION", "Get the custom property values set on an organization repository, with the type and allowed values of each property")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_CUSTOM_PROPERTY_VALUES_USER_TITLE", "Get repository custom property values"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Organization that owns the repository"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			values, resp, err := client.Repositories.GetAllCustomPropertyValues(ctx, owner, repo)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get custom property values", resp, err); !ok {
+				return result, err
+			}
+
+			result := RepositoryCustomPropertyValues{
+				Repository: owner + "/" + repo,
+				Values:     []RepositoryCustomPropertyValue{},
+			}
+
+			// The definitions only add the type and allowed values, so the values
+			// are still returned if they cannot be read.
+			definitions := map[string]*github.CustomProperty{}
+			properties, resp, err := client.Organizations.GetAllCustomProperties(ctx, owner)
+			closeResponseBody(resp)
+			if err != nil {
+				result.Warning = fmt.Sprintf("failed to read the property definitions of %s: %v", owner, err)
+			}
+			for _, property := range properties {
+				definitions[property.GetPropertyName()] = property
+			}
+
+			for _, value := range values {
+				// Properties without a value on this repository are listed with a null value
+				if value.Value == nil {
+					continue
+				}
+				projected := RepositoryCustomPropertyValue{
+					PropertyName: value.PropertyName,
+					Value:        value.Value,
+				}
+				if definition, ok := definitions[value.PropertyName]; ok {
+					projected.ValueType = definition.ValueType
+					projected.AllowedValues = definition.AllowedValues
+				}
+				result.Values = append(result.Values, projected)
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
@@ -0,0 +1,291 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mockCustomProperties = []*github.CustomProperty{
+	{
+		PropertyName:  github.Ptr("tier"),
+		ValueType:     "single_select",
+		Required:      github.Ptr(true),
+		DefaultValue:  github.Ptr("3"),
+		Description:   github.Ptr("Service tier"),
+		AllowedValues: []string{"1", "2", "3"},
+		SourceType:    github.Ptr("organization"),
+	},
+	{
+		PropertyName: github.Ptr("data-classification"),
+		ValueType:    "multi_select",
+		AllowedValues: []string{
+			"public",
+			"internal",
+			"pii",
+		},
+	},
+	{
+		PropertyName: github.Ptr("owner-team"),
+		ValueType:    "string",
+	},
+}
+
+func Test_ListOrgCustomProperties(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgCustomProperties(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_custom_properties", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name                string
+		mockedClient        *http.Client
+		requestArgs         map[string]interface{}
+		expectError         bool
+		expectedDefinitions []CustomPropertyDefinition
+		expectedErrMsg      string
+	}{
+		{
+			name: "lists the property schema",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsPropertiesSchemaByOrg,
+					mockCustomProperties,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectedDefinitions: []CustomPropertyDefinition{
+				{
+					PropertyName:  "tier",
+					ValueType:     "single_select",
+					Required:      true,
+					DefaultValue:  "3",
+					Description:   "Service tier",
+					AllowedValues: []string{"1", "2", "3"},
+					SourceType:    "organization",
+				},
+				{
+					PropertyName:  "data-classification",
+					ValueType:     "multi_select",
+					AllowedValues: []string{"public", "internal", "pii"},
+				},
+				{
+					PropertyName: "owner-team",
+					ValueType:    "string",
+				},
+			},
+		},
+		{
+			name: "organization without properties",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsPropertiesSchemaByOrg,
+					[]*github.CustomProperty{},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectedDefinitions: []CustomPropertyDefinition{},
+		},
+		{
+			name: "schema fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPropertiesSchemaByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list custom properties",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgCustomProperties(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var definitions []CustomPropertyDefinition
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &definitions))
+			assert.Equal(t, tc.expectedDefinitions, definitions)
+		})
+	}
+}
+
+func Test_GetRepositoryCustomPropertyValues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryCustomPropertyValues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_custom_property_values", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult RepositoryCustomPropertyValues
+		expectedErrMsg string
+	}{
+		{
+			name: "values are projected with their definitions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					[]*github.CustomPropertyValue{
+						{PropertyName: "tier", Value: "1"},
+						{PropertyName: "data-classification", Value: []string{"internal", "pii"}},
+						{PropertyName: "owner-team", Value: nil},
+					},
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsPropertiesSchemaByOrg,
+					mockCustomProperties,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"repo":  "payments",
+			},
+			expectedResult: RepositoryCustomPropertyValues{
+				Repository: "octo-org/payments",
+				Values: []RepositoryCustomPropertyValue{
+					{PropertyName: "tier", ValueType: "single_select", AllowedValues: []string{"1", "2", "3"}, Value: "1"},
+					{PropertyName: "data-classification", ValueType: "multi_select", AllowedValues: []string{"public", "internal", "pii"}, Value: []any{"internal", "pii"}},
+				},
+			},
+		},
+		{
+			name: "repository without values returns an empty array",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					[]*github.CustomPropertyValue{},
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsPropertiesSchemaByOrg,
+					mockCustomProperties,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"repo":  "sandbox",
+			},
+			expectedResult: RepositoryCustomPropertyValues{
+				Repository: "octo-org/sandbox",
+				Values:     []RepositoryCustomPropertyValue{},
+			},
+		},
+		{
+			name: "values are returned when the definitions cannot be read",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					[]*github.CustomPropertyValue{
+						{PropertyName: "tier", Value: "2"},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPropertiesSchemaByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"repo":  "payments",
+			},
+			expectedResult: RepositoryCustomPropertyValues{
+				Repository: "octo-org/payments",
+				Values: []RepositoryCustomPropertyValue{
+					{PropertyName: "tier", Value: "2"},
+				},
+				Warning: "failed to read the property definitions of octo-org",
+			},
+		},
+		{
+			name: "values fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPropertiesValuesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octo-org",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get custom property values",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryCustomPropertyValues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			text := getTextResult(t, result).Text
+			assert.Contains(t, text, `"values":[`)
+
+			var returned RepositoryCustomPropertyValues
+			require.NoError(t, json.Unmarshal([]byte(text), &returned))
+			// The warning carries the API error, so only its prefix is compared
+			assert.Contains(t, returned.Warning, tc.expectedResult.Warning)
+			returned.Warning = tc.expectedResult.Warning
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
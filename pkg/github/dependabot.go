@@ -2,12 +2,10 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"strings"
 
-	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -55,36 +53,21 @@ func GetDependabotAlert(getClient GetClientFn, t translations.TranslationHelperF
 			}
 
 			alert, resp, err := client.Dependabot.GetRepoAlert(ctx, owner, repo, alertNumber)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to get alert with number '%d'", alertNumber),
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get alert: %s", string(body))), nil
+			if result := dependabotDisabledResult(owner, repo, resp, err); result != nil {
+				return result, nil
 			}
-
-			r, err := json.Marshal(alert)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alert: %w", err)
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to get alert with number '%d'", alertNumber), resp, err); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(alert), nil
 		}
 }
 
 func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool(
 			"list_dependabot_alerts",
-			mcp.WithDescription(t("TOOL_LIST_DEPENDABOT_ALERTS_DESCRIPTION", "List dependabot alerts in a GitHub repository.")),
+			mcp.WithDescription(t("TOOL_LIST_DEPENDABOT_ALERTS_DESCRIPTION", "List dependabot alerts in a GitHub repository with the vulnerable package, affected versions, severity, advisory and first patched version of each. Use get_dependabot_alert for the full details of an alert. Pages by cursor: pass the returned next_cursor as after, or prev_cursor as before.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_DEPENDABOT_ALERTS_USER_TITLE", "List dependabot alerts"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -106,6 +89,25 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Description("Filter dependabot alerts by severity"),
 				mcp.Enum("low", "medium", "high", "critical"),
 			),
+			mcp.WithString("ecosystem",
+				mcp.Description("Filter dependabot alerts by package ecosystem"),
+				mcp.Enum("composer", "go", "maven", "npm", "nuget", "pip", "pub", "rubygems", "rust"),
+			),
+			mcp.WithString("package",
+				mcp.Description("Filter dependabot alerts by package name"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page for pagination (min 1, max 100, default 30)"),
+				mcp.Min(1),
+				mcp.Max(maxPerPage),
+				mcp.DefaultNumber(defaultPerPage),
+			),
+			mcp.WithString("after",
+				mcp.Description("Cursor for pagination. Use the next_cursor of the previous page."),
+			),
+			mcp.WithString("before",
+				mcp.Description("Cursor for pagination. Use the prev_cursor of the previous page to go back."),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -124,6 +126,25 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			ecosystem, err := OptionalParam[string](request, "ecosystem")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			packageName, err := OptionalParam[string](request, "package")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			before, err := OptionalParam[string](request, "before")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if before != "" && pagination.After != "" {
+				return mcp.NewToolResultError("before and after cannot be combined"), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -131,31 +152,171 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 			}
 
 			alerts, resp, err := client.Dependabot.ListRepoAlerts(ctx, owner, repo, &github.ListAlertsOptions{
-				State:    ToStringPtr(state),
-				Severity: ToStringPtr(severity),
+				State:     ToStringPtr(state),
+				Severity:  ToStringPtr(severity),
+				Ecosystem: ToStringPtr(ecosystem),
+				Package:   ToStringPtr(packageName),
+				ListCursorOptions: github.ListCursorOptions{
+					PerPage: pagination.PerPage,
+					After:   pagination.After,
+					Before:  before,
+				},
 			})
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to list alerts for repository '%s/%s'", owner, repo),
-					resp,
-					err,
-				), nil
+			if result := dependabotDisabledResult(owner, repo, resp, err); result != nil {
+				return result, nil
+			}
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to list alerts for repository '%s/%s'", owner, repo), resp, err); !ok {
+				return result, err
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
+			result := DependabotAlertsResult{
+				Alerts:     make([]DependabotAlert, 0, len(alerts)),
+				NextCursor: resp.After,
+				PrevCursor: resp.Before,
+			}
+			for _, alert := range alerts {
+				result.Alerts = append(result.Alerts, newDependabotAlert(alert))
 			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// DependabotAlertsResult is a page of a repository's Dependabot alerts. The endpoint pages by
+// cursor, so the next page is requested by passing NextCursor as "after", and the previous one by
+// passing PrevCursor as "before".
+type DependabotAlertsResult struct {
+	Alerts     []DependabotAlert `json:"alerts"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	PrevCursor string            `json:"prev_cursor,omitempty"`
+}
+
+// DependabotAlert is the condensed view of a Dependabot alert returned by list_dependabot_alerts.
+// FixedIn is the first version of the package that is not vulnerable, empty if there is none yet.
+type DependabotAlert struct {
+	Number                 int    `json:"number"`
+	State                  string `json:"state"`
+	Package                string `json:"package"`
+	Ecosystem              string `json:"ecosystem"`
+	ManifestPath           string `json:"manifest_path,omitempty"`
+	VulnerableVersionRange string `json:"vulnerable_version_range,omitempty"`
+	FixedIn                string `json:"fixed_in,omitempty"`
+	Severity               string `json:"severity"`
+	GHSAID                 string `json:"ghsa_id,omitempty"`
+	CVEID                  string `json:"cve_id,omitempty"`
+	Summary                string `json:"summary,omitempty"`
+	HTMLURL                string `json:"html_url"`
+}
+
+func newDependabotAlert(alert *github.DependabotAlert) DependabotAlert {
+	advisory := alert.GetSecurityAdvisory()
+	vulnerability := alert.GetSecurityVulnerability()
+	severity := vulnerability.GetSeverity()
+	if severity == "" {
+		severity = advisory.GetSeverity()
+	}
+	return DependabotAlert{
+		Number:                 alert.GetNumber(),
+		State:                  alert.GetState(),
+		Package:                alert.GetDependency().GetPackage().GetName(),
+		Ecosystem:              alert.GetDependency().GetPackage().GetEcosystem(),
+		ManifestPath:           alert.GetDependency().GetManifestPath(),
+		VulnerableVersionRange: vulnerability.GetVulnerableVersionRange(),
+		FixedIn:                vulnerability.GetFirstPatchedVersion().GetIdentifier(),
+		Severity:               severity,
+		GHSAID:                 advisory.GetGHSAID(),
+		CVEID:                  advisory.GetCVEID(),
+		Summary:                advisory.GetSummary(),
+		HTMLURL:                alert.GetHTMLURL(),
+	}
+}
+
+// dependabotDisabledResult returns a tool error saying that Dependabot alerts are turned off for the
+// repository when err is the 403 the API responds with in that case, and nil otherwise.
+func dependabotDisabledResult(owner, repo string, resp *github.Response, err error) *mcp.CallToolResult {
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	if !strings.Contains(strings.ToLower(err.Error()), "disabled") {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("Dependabot alerts are disabled for %s/%s; they can be enabled under the repository's Settings > Code security", owner, repo))
+}
+
+// OrgDependabotAlertsResult is a page of an organization's Dependabot alerts. The endpoint pages by
+// cursor, so the next page is requested by passing NextCursor as "after".
+type OrgDependabotAlertsResult struct {
+	Alerts     []*github.DependabotAlert `json:"alerts"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+}
 
-			r, err := json.Marshal(alerts)
+// ListOrgDependabotAlerts creates a tool to list the Dependabot alerts of all repositories in an organization.
+func ListOrgDependabotAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_dependabot_alerts",
+			mcp.WithDescription(t("TOOL_LIST_ORG_DEPENDABOT_ALERTS_DESCRIPTION", "List dependabot alerts across all repositories of a GitHub organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_DEPENDABOT_ALERTS_USER_TITLE", "List organization dependabot alerts"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization login."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter dependabot alerts by state. Defaults to open"),
+				mcp.DefaultString("open"),
+				mcp.Enum("open", "fixed", "dismissed", "auto_dismissed"),
+			),
+			mcp.WithString("severity",
+				mcp.Description("Filter dependabot alerts by severity"),
+				mcp.Enum("low", "medium", "high", "critical"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Results per page for pagination (min 1, max 100, default 30)"),
+				mcp.Min(1),
+				mcp.Max(maxPerPage),
+				mcp.DefaultNumber(defaultPerPage),
+			),
+			mcp.WithString("after",
+				mcp.Description("Cursor for pagination. Use the next_cursor of the previous page."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			severity, err := OptionalParam[string](request, "severity")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			alerts, resp, err := client.Dependabot.ListOrgAlerts(ctx, org, &github.ListAlertsOptions{
+				State:    ToStringPtr(state),
+				Severity: ToStringPtr(severity),
+				ListCursorOptions: github.ListCursorOptions{
+					PerPage: pagination.PerPage,
+					After:   pagination.After,
+				},
+			})
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to list alerts for organization '%s'", org), resp, err); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(OrgDependabotAlertsResult{
+				Alerts:     alerts,
+				NextCursor: resp.After,
+			}), nil
 		}
 }
@@ -78,6 +78,22 @@ func Test_GetDependabotAlert(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to get alert",
 		},
+		{
+			name: "dependabot alerts disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusForbidden, `{"message": "Dependabot alerts are disabled for this repository."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "Dependabot alerts are disabled for owner/repo",
+		},
 	}
 
 	for _, tc := range tests {
@@ -130,6 +146,12 @@ func Test_ListDependabotAlerts(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "state")
 	assert.Contains(t, tool.InputSchema.Properties, "severity")
+	assert.Contains(t, tool.InputSchema.Properties, "ecosystem")
+	assert.Contains(t, tool.InputSchema.Properties, "package")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.Contains(t, tool.InputSchema.Properties, "before")
+	assert.NotContains(t, tool.InputSchema.Properties, "page")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Setup mock alerts for success case
@@ -137,17 +159,62 @@ func Test_ListDependabotAlerts(t *testing.T) {
 		Number:  github.Ptr(1),
 		HTMLURL: github.Ptr("https://github.com/owner/repo/security/dependabot/1"),
 		State:   github.Ptr("open"),
+		Dependency: &github.Dependency{
+			Package:      &github.VulnerabilityPackage{Ecosystem: github.Ptr("npm"), Name: github.Ptr("lodash")},
+			ManifestPath: github.Ptr("package-lock.json"),
+		},
 		SecurityAdvisory: &github.DependabotSecurityAdvisory{
+			GHSAID:   github.Ptr("GHSA-jf85-cpcp-j695"),
+			CVEID:    github.Ptr("CVE-2019-10744"),
+			Summary:  github.Ptr("Prototype Pollution in lodash"),
 			Severity: github.Ptr("critical"),
 		},
+		SecurityVulnerability: &github.AdvisoryVulnerability{
+			Package:                &github.VulnerabilityPackage{Ecosystem: github.Ptr("npm"), Name: github.Ptr("lodash")},
+			Severity:               github.Ptr("critical"),
+			VulnerableVersionRange: github.Ptr("< 4.17.12"),
+			FirstPatchedVersion:    &github.FirstPatchedVersion{Identifier: github.Ptr("4.17.12")},
+		},
 	}
 	highSeverityAlert := github.DependabotAlert{
 		Number:  github.Ptr(2),
 		HTMLURL: github.Ptr("https://github.com/owner/repo/security/dependabot/2"),
 		State:   github.Ptr("fixed"),
+		Dependency: &github.Dependency{
+			Package: &github.VulnerabilityPackage{Ecosystem: github.Ptr("pip"), Name: github.Ptr("django")},
+		},
 		SecurityAdvisory: &github.DependabotSecurityAdvisory{
+			GHSAID:   github.Ptr("GHSA-xxxx-yyyy-zzzz"),
 			Severity: github.Ptr("high"),
 		},
+		SecurityVulnerability: &github.AdvisoryVulnerability{
+			VulnerableVersionRange: github.Ptr(">= 4.0, < 4.2.2"),
+		},
+	}
+
+	condensedCritical := DependabotAlert{
+		Number:                 1,
+		State:                  "open",
+		Package:                "lodash",
+		Ecosystem:              "npm",
+		ManifestPath:           "package-lock.json",
+		VulnerableVersionRange: "< 4.17.12",
+		FixedIn:                "4.17.12",
+		Severity:               "critical",
+		GHSAID:                 "GHSA-jf85-cpcp-j695",
+		CVEID:                  "CVE-2019-10744",
+		Summary:                "Prototype Pollution in lodash",
+		HTMLURL:                "https://github.com/owner/repo/security/dependabot/1",
+	}
+	condensedHigh := DependabotAlert{
+		Number:                 2,
+		State:                  "fixed",
+		Package:                "django",
+		Ecosystem:              "pip",
+		VulnerableVersionRange: ">= 4.0, < 4.2.2",
+		Severity:               "high",
+		GHSAID:                 "GHSA-xxxx-yyyy-zzzz",
+		HTMLURL:                "https://github.com/owner/repo/security/dependabot/2",
 	}
 
 	tests := []struct {
@@ -155,7 +222,8 @@ func Test_ListDependabotAlerts(t *testing.T) {
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedAlerts []*github.DependabotAlert
+		expectedAlerts []DependabotAlert
+		expectedResult DependabotAlertsResult
 		expectedErrMsg string
 	}{
 		{
@@ -164,7 +232,8 @@ func Test_ListDependabotAlerts(t *testing.T) {
 				mock.WithRequestMatchHandler(
 					mock.GetReposDependabotAlertsByOwnerByRepo,
 					expectQueryParams(t, map[string]string{
-						"state": "open",
+						"state":    "open",
+						"per_page": "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.DependabotAlert{&criticalAlert}),
 					),
@@ -176,34 +245,99 @@ func Test_ListDependabotAlerts(t *testing.T) {
 				"state": "open",
 			},
 			expectError:    false,
-			expectedAlerts: []*github.DependabotAlert{&criticalAlert},
+			expectedAlerts: []DependabotAlert{condensedCritical},
 		},
 		{
-			name: "successful severity filtered listing",
+			name: "successful filtered listing",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetReposDependabotAlertsByOwnerByRepo,
 					expectQueryParams(t, map[string]string{
-						"severity": "high",
+						"severity":  "high",
+						"ecosystem": "pip",
+						"package":   "django",
+						"per_page":  "100",
 					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.DependabotAlert{&highSeverityAlert}),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":    "owner",
-				"repo":     "repo",
-				"severity": "high",
+				"owner":     "owner",
+				"repo":      "repo",
+				"severity":  "high",
+				"ecosystem": "pip",
+				"package":   "django",
+				"perPage":   float64(100),
 			},
 			expectError:    false,
-			expectedAlerts: []*github.DependabotAlert{&highSeverityAlert},
+			expectedAlerts: []DependabotAlert{condensedHigh},
+		},
+		{
+			name: "passes the cursor and reports the next and previous ones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"per_page": "10",
+						"after":    "Y3Vyc29yOjE=",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/dependabot/alerts?per_page=10&after=Y3Vyc29yOjI%3D>; rel="next", <https://api.github.com/repos/owner/repo/dependabot/alerts?per_page=10&before=Y3Vyc29yOjE%3D>; rel="prev"`)
+							_ = json.NewEncoder(w).Encode([]*github.DependabotAlert{&criticalAlert})
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"perPage": float64(10),
+				"after":   "Y3Vyc29yOjE=",
+			},
+			expectedAlerts: []DependabotAlert{condensedCritical},
+			expectedResult: DependabotAlertsResult{NextCursor: "Y3Vyc29yOjI=", PrevCursor: "Y3Vyc29yOjE="},
+		},
+		{
+			name: "passes the before cursor",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"per_page": "30",
+						"before":   "Y3Vyc29yOjE=",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.DependabotAlert{&highSeverityAlert}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"before": "Y3Vyc29yOjE=",
+			},
+			expectedAlerts: []DependabotAlert{condensedHigh},
+		},
+		{
+			name:         "before and after together",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"after":  "Y3Vyc29yOjI=",
+				"before": "Y3Vyc29yOjE=",
+			},
+			expectError:    true,
+			expectedErrMsg: "before and after cannot be combined",
 		},
 		{
 			name: "successful all alerts listing",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetReposDependabotAlertsByOwnerByRepo,
-					expectQueryParams(t, map[string]string{}).andThen(
+					expectQueryParams(t, map[string]string{
+						"per_page": "30",
+					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.DependabotAlert{&criticalAlert, &highSeverityAlert}),
 					),
 				),
@@ -213,7 +347,22 @@ func Test_ListDependabotAlerts(t *testing.T) {
 				"repo":  "repo",
 			},
 			expectError:    false,
-			expectedAlerts: []*github.DependabotAlert{&criticalAlert, &highSeverityAlert},
+			expectedAlerts: []DependabotAlert{condensedCritical, condensedHigh},
+		},
+		{
+			name: "dependabot alerts disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Dependabot alerts are disabled for this repository."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "Dependabot alerts are disabled for owner/repo; they can be enabled under the repository's Settings > Code security",
 		},
 		{
 			name: "alerts listing fails",
@@ -258,19 +407,129 @@ func Test_ListDependabotAlerts(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedAlerts []*github.DependabotAlert
-			err = json.Unmarshal([]byte(textContent.Text), &returnedAlerts)
-			assert.NoError(t, err)
-			assert.Len(t, returnedAlerts, len(tc.expectedAlerts))
-			for i, alert := range returnedAlerts {
-				assert.Equal(t, *tc.expectedAlerts[i].Number, *alert.Number)
-				assert.Equal(t, *tc.expectedAlerts[i].HTMLURL, *alert.HTMLURL)
-				assert.Equal(t, *tc.expectedAlerts[i].State, *alert.State)
-				if tc.expectedAlerts[i].SecurityAdvisory != nil && tc.expectedAlerts[i].SecurityAdvisory.Severity != nil &&
-					alert.SecurityAdvisory != nil && alert.SecurityAdvisory.Severity != nil {
-					assert.Equal(t, *tc.expectedAlerts[i].SecurityAdvisory.Severity, *alert.SecurityAdvisory.Severity)
-				}
+			var returned DependabotAlertsResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAlerts, returned.Alerts)
+			assert.Equal(t, tc.expectedResult.NextCursor, returned.NextCursor)
+			assert.Equal(t, tc.expectedResult.PrevCursor, returned.PrevCursor)
+		})
+	}
+}
+
+func Test_ListOrgDependabotAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgDependabotAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_dependabot_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "severity")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	criticalAlert := &github.DependabotAlert{
+		Number:     github.Ptr(1),
+		State:      github.Ptr("open"),
+		Repository: &github.Repository{FullName: github.Ptr("octo-org/api")},
+		SecurityAdvisory: &github.DependabotSecurityAdvisory{
+			Severity: github.Ptr("critical"),
+		},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedErrMsg     string
+		expectedAlerts     []*github.DependabotAlert
+		expectedNextCursor string
+	}{
+		{
+			name: "filters by state and severity",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsDependabotAlertsByOrg,
+					expectQueryParams(t, map[string]string{
+						"state":    "open",
+						"severity": "critical",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.DependabotAlert{criticalAlert}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "octo-org",
+				"state":    "open",
+				"severity": "critical",
+			},
+			expectedAlerts: []*github.DependabotAlert{criticalAlert},
+		},
+		{
+			name: "passes the cursor and reports the next one",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsDependabotAlertsByOrg,
+					expectQueryParams(t, map[string]string{
+						"per_page": "10",
+						"after":    "Y3Vyc29yOjE=",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/orgs/octo-org/dependabot/alerts?per_page=10&after=Y3Vyc29yOjI%3D>; rel="next"`)
+							_ = json.NewEncoder(w).Encode([]*github.DependabotAlert{criticalAlert})
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "octo-org",
+				"perPage": float64(10),
+				"after":   "Y3Vyc29yOjE=",
+			},
+			expectedAlerts:     []*github.DependabotAlert{criticalAlert},
+			expectedNextCursor: "Y3Vyc29yOjI=",
+		},
+		{
+			name: "alerts listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsDependabotAlertsByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list alerts for organization 'octo-org'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgDependabotAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
 			}
+
+			require.False(t, result.IsError)
+			var returned OrgDependabotAlertsResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedAlerts, returned.Alerts)
+			assert.Equal(t, tc.expectedNextCursor, returned.NextCursor)
 		})
 	}
 }
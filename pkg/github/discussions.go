@@ -4,9 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
-	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -55,11 +56,11 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 			// Get pagination parameters and convert to GraphQL format
 			pagination, err := OptionalCursorPaginationParams(request)
 			if err != nil {
-				return nil, err
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 			paginationParams, err := pagination.ToGraphQLParams()
 			if err != nil {
-				return nil, err
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getGQLClient(ctx)
@@ -131,15 +132,19 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 				}
 
 				// Create response with pagination info
-				response := map[string]interface{}{
-					"discussions": discussions,
-					"pageInfo": map[string]interface{}{
-						"hasNextPage":     query.Repository.Discussions.PageInfo.HasNextPage,
-						"hasPreviousPage": query.Repository.Discussions.PageInfo.HasPreviousPage,
-						"startCursor":     query.Repository.Discussions.PageInfo.StartCursor,
-						"endCursor":       query.Repository.Discussions.PageInfo.EndCursor,
+				pageInfo := query.Repository.Discussions.PageInfo
+				response := DiscussionsResult{
+					Discussions: discussions,
+					Pagination: Pagination{
+						PageInfo: PageInfo{
+							HasNextPage:     pageInfo.HasNextPage,
+							HasPreviousPage: pageInfo.HasPreviousPage,
+							StartCursor:     pageInfo.StartCursor,
+							EndCursor:       pageInfo.EndCursor,
+						},
+						TotalCount: query.Repository.Discussions.TotalCount,
+						NextCall:   CursorNextCall(pageInfo.HasNextPage, pageInfo.EndCursor, *paginationParams.First),
 					},
-					"totalCount": query.Repository.Discussions.TotalCount,
 				}
 
 				out, err = json.Marshal(response)
@@ -199,15 +204,19 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 				}
 
 				// Create response with pagination info
-				response := map[string]interface{}{
-					"discussions": discussions,
-					"pageInfo": map[string]interface{}{
-						"hasNextPage":     query.Repository.Discussions.PageInfo.HasNextPage,
-						"hasPreviousPage": query.Repository.Discussions.PageInfo.HasPreviousPage,
-						"startCursor":     query.Repository.Discussions.PageInfo.StartCursor,
-						"endCursor":       query.Repository.Discussions.PageInfo.EndCursor,
+				pageInfo := query.Repository.Discussions.PageInfo
+				response := DiscussionsResult{
+					Discussions: discussions,
+					Pagination: Pagination{
+						PageInfo: PageInfo{
+							HasNextPage:     pageInfo.HasNextPage,
+							HasPreviousPage: pageInfo.HasPreviousPage,
+							StartCursor:     pageInfo.StartCursor,
+							EndCursor:       pageInfo.EndCursor,
+						},
+						TotalCount: query.Repository.Discussions.TotalCount,
+						NextCall:   CursorNextCall(pageInfo.HasNextPage, pageInfo.EndCursor, *paginationParams.First),
 					},
-					"totalCount": query.Repository.Discussions.TotalCount,
 				}
 
 				out, err = json.Marshal(response)
@@ -247,7 +256,7 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 				Repo             string
 				DiscussionNumber int32
 			}
-			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+			if err := decodeParams(request, &params); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 			client, err := getGQLClient(ctx)
@@ -305,6 +314,8 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 			mcp.WithString("owner", mcp.Required(), mcp.Description("Repository owner")),
 			mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name")),
 			mcp.WithNumber("discussionNumber", mcp.Required(), mcp.Description("Discussion Number")),
+			mcp.WithString("author", mcp.Description("Only return comments by this user login. Applied to the fetched page, so a page may return fewer comments than perPage.")),
+			mcp.WithString("since", mcp.Description("Only return comments created at or after this time (ISO 8601). Applied to the fetched page.")),
 			WithCursorPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -313,15 +324,25 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				Owner            string
 				Repo             string
 				DiscussionNumber int32
+				Author           string
+				Since            string
 			}
-			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+			if err := decodeParams(request, &params); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			var since time.Time
+			if params.Since != "" {
+				var err error
+				since, err = parseISOTimestamp(params.Since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid since: %s", err)), nil
+				}
+			}
 
 			// Get pagination parameters and convert to GraphQL format
 			pagination, err := OptionalCursorPaginationParams(request)
 			if err != nil {
-				return nil, err
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			// Check if pagination parameters were explicitly provided
@@ -330,7 +351,7 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 
 			paginationParams, err := pagination.ToGraphQLParams()
 			if err != nil {
-				return nil, err
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			// Use default of 30 if pagination was not explicitly provided
@@ -349,7 +370,11 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 					Discussion struct {
 						Comments struct {
 							Nodes []struct {
-								Body githubv4.String
+								Body   githubv4.String
+								Author struct {
+									Login githubv4.String
+								}
+								CreatedAt githubv4.DateTime
 							}
 							PageInfo struct {
 								HasNextPage     githubv4.Boolean
@@ -379,19 +404,29 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 
 			var comments []*github.IssueComment
 			for _, c := range q.Repository.Discussion.Comments.Nodes {
-				comments = append(comments, &github.IssueComment{Body: github.Ptr(string(c.Body))})
+				comments = append(comments, &github.IssueComment{
+					Body:      github.Ptr(string(c.Body)),
+					User:      &github.User{Login: github.Ptr(string(c.Author.Login))},
+					CreatedAt: &github.Timestamp{Time: c.CreatedAt.Time},
+				})
 			}
+			comments, filtered := filterDiscussionComments(comments, params.Author, since)
 
 			// Create response with pagination info
-			response := map[string]interface{}{
-				"comments": comments,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     q.Repository.Discussion.Comments.PageInfo.HasNextPage,
-					"hasPreviousPage": q.Repository.Discussion.Comments.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.Discussion.Comments.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.Discussion.Comments.PageInfo.EndCursor),
+			pageInfo := q.Repository.Discussion.Comments.PageInfo
+			response := DiscussionCommentsResult{
+				Comments: comments,
+				Pagination: Pagination{
+					PageInfo: PageInfo{
+						HasNextPage:     bool(pageInfo.HasNextPage),
+						HasPreviousPage: bool(pageInfo.HasPreviousPage),
+						StartCursor:     string(pageInfo.StartCursor),
+						EndCursor:       string(pageInfo.EndCursor),
+					},
+					TotalCount: q.Repository.Discussion.Comments.TotalCount,
+					NextCall:   CursorNextCall(bool(pageInfo.HasNextPage), string(pageInfo.EndCursor), *paginationParams.First),
 				},
-				"totalCount": q.Repository.Discussion.Comments.TotalCount,
+				FilteredCount: filtered,
 			}
 
 			out, err := json.Marshal(response)
@@ -403,6 +438,26 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 		}
 }
 
+// filterDiscussionComments drops comments not written by author (case-insensitively) or created before since.
+// Empty filters match every comment. It returns the kept comments and how many were dropped.
+func filterDiscussionComments(comments []*github.IssueComment, author string, since time.Time) ([]*github.IssueComment, int) {
+	if author == "" && since.IsZero() {
+		return comments, 0
+	}
+
+	kept := make([]*github.IssueComment, 0, len(comments))
+	for _, comment := range comments {
+		if author != "" && !strings.EqualFold(comment.GetUser().GetLogin(), author) {
+			continue
+		}
+		if !since.IsZero() && comment.GetCreatedAt().Before(since) {
+			continue
+		}
+		kept = append(kept, comment)
+	}
+	return kept, len(comments) - len(kept)
+}
+
 func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_discussion_categories",
 			mcp.WithDescription(t("TOOL_LIST_DISCUSSION_CATEGORIES_DESCRIPTION", "List discussion categories with their id and name, for a repository")),
@@ -425,7 +480,7 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 				Owner string
 				Repo  string
 			}
-			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+			if err := decodeParams(request, &params); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
@@ -460,24 +515,27 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			var categories []map[string]string
+			var categories []DiscussionCategory
 			for _, c := range q.Repository.DiscussionCategories.Nodes {
-				categories = append(categories, map[string]string{
-					"id":   fmt.Sprint(c.ID),
-					"name": string(c.Name),
+				categories = append(categories, DiscussionCategory{
+					ID:   fmt.Sprint(c.ID),
+					Name: string(c.Name),
 				})
 			}
 
 			// Create response with pagination info
-			response := map[string]interface{}{
-				"categories": categories,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     q.Repository.DiscussionCategories.PageInfo.HasNextPage,
-					"hasPreviousPage": q.Repository.DiscussionCategories.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.DiscussionCategories.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.DiscussionCategories.PageInfo.EndCursor),
+			pageInfo := q.Repository.DiscussionCategories.PageInfo
+			response := DiscussionCategoriesResult{
+				Categories: categories,
+				Pagination: Pagination{
+					PageInfo: PageInfo{
+						HasNextPage:     bool(pageInfo.HasNextPage),
+						HasPreviousPage: bool(pageInfo.HasPreviousPage),
+						StartCursor:     string(pageInfo.StartCursor),
+						EndCursor:       string(pageInfo.EndCursor),
+					},
+					TotalCount: q.Repository.DiscussionCategories.TotalCount,
 				},
-				"totalCount": q.Repository.DiscussionCategories.TotalCount,
 			}
 
 			out, err := json.Marshal(response)
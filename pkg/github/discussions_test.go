@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -53,6 +54,20 @@ var (
 			},
 		},
 	})
+	mockResponseListNextPage = githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussions": map[string]any{
+				"nodes": discussionsAll[1:],
+				"pageInfo": map[string]any{
+					"hasNextPage":     true,
+					"hasPreviousPage": true,
+					"startCursor":     "Y3Vyc29yOjI=",
+					"endCursor":       "Y3Vyc29yOjM=",
+				},
+				"totalCount": 5,
+			},
+		},
+	})
 	mockErrorRepoNotFound = githubv4mock.ErrorResponse("repository not found")
 )
 
@@ -86,6 +101,13 @@ func Test_ListDiscussions(t *testing.T) {
 		"after": (*string)(nil),
 	}
 
+	varsNextPage := map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"first": float64(2),
+		"after": "Y3Vyc29yOjE=",
+	}
+
 	varsDiscussionsFiltered := map[string]interface{}{
 		"owner":      "owner",
 		"repo":       "repo",
@@ -95,11 +117,12 @@ func Test_ListDiscussions(t *testing.T) {
 	}
 
 	tests := []struct {
-		name          string
-		reqParams     map[string]interface{}
-		expectError   bool
-		errContains   string
-		expectedCount int
+		name             string
+		reqParams        map[string]interface{}
+		expectError      bool
+		errContains      string
+		expectedCount    int
+		expectedNextCall *NextCall
 	}{
 		{
 			name: "list all discussions without category filter",
@@ -120,6 +143,21 @@ func Test_ListDiscussions(t *testing.T) {
 			expectError:   false,
 			expectedCount: 2, // Only General discussions (matching the category ID)
 		},
+		{
+			name: "page with more results includes next call",
+			reqParams: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"perPage": float64(2),
+				"after":   "Y3Vyc29yOjE=",
+			},
+			expectError:   false,
+			expectedCount: 2,
+			expectedNextCall: &NextCall{
+				After:   "Y3Vyc29yOjM=",
+				PerPage: 2,
+			},
+		},
 		{
 			name: "repository not found error",
 			reqParams: map[string]interface{}{
@@ -129,6 +167,26 @@ func Test_ListDiscussions(t *testing.T) {
 			expectError: true,
 			errContains: "repository not found",
 		},
+		{
+			name: "perPage out of range",
+			reqParams: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"perPage": float64(101),
+			},
+			expectError: true,
+			errContains: "perPage must be between 1 and 100, got 101",
+		},
+		{
+			name: "invalid after cursor",
+			reqParams: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"after": "not a cursor!",
+			},
+			expectError: true,
+			errContains: "invalid cursor",
+		},
 	}
 
 	for _, tc := range tests {
@@ -144,6 +202,11 @@ func Test_ListDiscussions(t *testing.T) {
 				// Simple case - category filter using category ID directly
 				matcher := githubv4mock.NewQueryMatcher(qDiscussionsFiltered, varsDiscussionsFiltered, mockResponseListGeneral)
 				httpClient = githubv4mock.NewMockedHTTPClient(matcher)
+			case "page with more results includes next call":
+				// A non-nil cursor is sent as a non-null String
+				qDiscussionsAfter := strings.Replace(qDiscussions, "$after:String", "$after:String!", 1)
+				matcher := githubv4mock.NewQueryMatcher(qDiscussionsAfter, varsNextPage, mockResponseListNextPage)
+				httpClient = githubv4mock.NewMockedHTTPClient(matcher)
 			case "repository not found error":
 				matcher := githubv4mock.NewQueryMatcher(qDiscussions, varsRepoNotFound, mockErrorRepoNotFound)
 				httpClient = githubv4mock.NewMockedHTTPClient(matcher)
@@ -164,20 +227,12 @@ func Test_ListDiscussions(t *testing.T) {
 			require.NoError(t, err)
 
 			// Parse the structured response with pagination info
-			var response struct {
-				Discussions []*github.Discussion `json:"discussions"`
-				PageInfo    struct {
-					HasNextPage     bool   `json:"hasNextPage"`
-					HasPreviousPage bool   `json:"hasPreviousPage"`
-					StartCursor     string `json:"startCursor"`
-					EndCursor       string `json:"endCursor"`
-				} `json:"pageInfo"`
-				TotalCount int `json:"totalCount"`
-			}
+			var response DiscussionsResult
 			err = json.Unmarshal([]byte(text), &response)
 			require.NoError(t, err)
 
 			assert.Len(t, response.Discussions, tc.expectedCount, "Expected %d discussions, got %d", tc.expectedCount, len(response.Discussions))
+			assert.Equal(t, tc.expectedNextCall, response.NextCall)
 
 			// Verify that all returned discussions have a category if filtered
 			if _, hasCategory := tc.reqParams["category"]; hasCategory {
@@ -284,7 +339,7 @@ func Test_GetDiscussionComments(t *testing.T) {
 	assert.ElementsMatch(t, toolDef.InputSchema.Required, []string{"owner", "repo", "discussionNumber"})
 
 	// Use exact string query that matches implementation output
-	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body,author{login},createdAt},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
 
 	// Variables matching what GraphQL receives after JSON marshaling/unmarshaling
 	vars := map[string]interface{}{
@@ -300,8 +355,8 @@ func Test_GetDiscussionComments(t *testing.T) {
 			"discussion": map[string]any{
 				"comments": map[string]any{
 					"nodes": []map[string]any{
-						{"body": "This is the first comment"},
-						{"body": "This is the second comment"},
+						{"body": "This is the first comment", "author": map[string]any{"login": "octocat"}, "createdAt": "2025-04-01T10:00:00Z"},
+						{"body": "This is the second comment", "author": map[string]any{"login": "hubot"}, "createdAt": "2025-04-02T10:00:00Z"},
 					},
 					"pageInfo": map[string]any{
 						"hasNextPage":     false,
@@ -332,22 +387,239 @@ func Test_GetDiscussionComments(t *testing.T) {
 
 	// (Lines removed)
 
-	var response struct {
-		Comments []*github.IssueComment `json:"comments"`
-		PageInfo struct {
-			HasNextPage     bool   `json:"hasNextPage"`
-			HasPreviousPage bool   `json:"hasPreviousPage"`
-			StartCursor     string `json:"startCursor"`
-			EndCursor       string `json:"endCursor"`
-		} `json:"pageInfo"`
-		TotalCount int `json:"totalCount"`
-	}
+	var response DiscussionCommentsResult
 	err = json.Unmarshal([]byte(textContent.Text), &response)
 	require.NoError(t, err)
 	assert.Len(t, response.Comments, 2)
+	assert.Nil(t, response.NextCall, "last page should not include a next call")
 	expectedBodies := []string{"This is the first comment", "This is the second comment"}
+	expectedAuthors := []string{"octocat", "hubot"}
 	for i, comment := range response.Comments {
 		assert.Equal(t, expectedBodies[i], *comment.Body)
+		assert.Equal(t, expectedAuthors[i], comment.GetUser().GetLogin())
+		assert.False(t, comment.GetCreatedAt().IsZero())
+	}
+
+	t.Run("page with more comments includes next call", func(t *testing.T) {
+		nextPageVars := map[string]interface{}{
+			"owner":            "owner",
+			"repo":             "repo",
+			"discussionNumber": float64(1),
+			"first":            float64(1),
+			"after":            "Y3Vyc29yOjE=",
+		}
+		nextPageResponse := githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"discussion": map[string]any{
+					"comments": map[string]any{
+						"nodes": []map[string]any{
+							{"body": "This is the second comment", "author": map[string]any{"login": "hubot"}, "createdAt": "2025-04-02T10:00:00Z"},
+						},
+						"pageInfo": map[string]any{
+							"hasNextPage":     true,
+							"hasPreviousPage": true,
+							"startCursor":     "Y3Vyc29yOjI=",
+							"endCursor":       "Y3Vyc29yOjI=",
+						},
+						"totalCount": 3,
+					},
+				},
+			},
+		})
+		// A non-nil cursor is sent as a non-null String
+		qGetCommentsAfter := strings.Replace(qGetComments, "$after:String", "$after:String!", 1)
+		matcher := githubv4mock.NewQueryMatcher(qGetCommentsAfter, nextPageVars, nextPageResponse)
+		gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+		_, handler := GetDiscussionComments(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":            "owner",
+			"repo":             "repo",
+			"discussionNumber": int32(1),
+			"perPage":          float64(1),
+			"after":            "Y3Vyc29yOjE=",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var pageResponse DiscussionCommentsResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &pageResponse))
+		assert.Equal(t, &NextCall{
+			After:   "Y3Vyc29yOjI=",
+			PerPage: 1,
+		}, pageResponse.NextCall)
+	})
+
+	t.Run("author and since filter the fetched page", func(t *testing.T) {
+		for _, tc := range []struct {
+			name             string
+			params           map[string]interface{}
+			expectedBodies   []string
+			expectedFiltered int
+		}{
+			{
+				name:             "author",
+				params:           map[string]interface{}{"author": "HUBOT"},
+				expectedBodies:   []string{"This is the second comment"},
+				expectedFiltered: 1,
+			},
+			{
+				name:             "since",
+				params:           map[string]interface{}{"since": "2025-04-01T12:00:00Z"},
+				expectedBodies:   []string{"This is the second comment"},
+				expectedFiltered: 1,
+			},
+			{
+				name:             "author and since",
+				params:           map[string]interface{}{"author": "octocat", "since": "2025-04-02"},
+				expectedBodies:   []string{},
+				expectedFiltered: 2,
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				matcher := githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)
+				gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matcher))
+				_, handler := GetDiscussionComments(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+				args := map[string]interface{}{
+					"owner":            "owner",
+					"repo":             "repo",
+					"discussionNumber": int32(1),
+				}
+				for k, v := range tc.params {
+					args[k] = v
+				}
+
+				result, err := handler(context.Background(), createMCPRequest(args))
+				require.NoError(t, err)
+				require.False(t, result.IsError)
+
+				var filteredResponse struct {
+					Comments      []*github.IssueComment `json:"comments"`
+					TotalCount    int                    `json:"totalCount"`
+					FilteredCount int                    `json:"filteredCount"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &filteredResponse))
+
+				bodies := []string{}
+				for _, comment := range filteredResponse.Comments {
+					bodies = append(bodies, comment.GetBody())
+				}
+				assert.Equal(t, tc.expectedBodies, bodies)
+				assert.Equal(t, tc.expectedFiltered, filteredResponse.FilteredCount)
+				assert.Equal(t, 2, filteredResponse.TotalCount)
+			})
+		}
+	})
+
+	t.Run("invalid pagination parameters are tool errors", func(t *testing.T) {
+		_, handler := GetDiscussionComments(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+		for _, tc := range []struct {
+			name        string
+			params      map[string]interface{}
+			errContains string
+		}{
+			{
+				name:        "perPage out of range",
+				params:      map[string]interface{}{"perPage": float64(101)},
+				errContains: "perPage must be between 1 and 100, got 101",
+			},
+			{
+				name:        "invalid after cursor",
+				params:      map[string]interface{}{"after": "not a cursor!"},
+				errContains: "invalid cursor",
+			},
+			{
+				name:        "invalid since",
+				params:      map[string]interface{}{"since": "yesterday"},
+				errContains: "invalid since",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				args := map[string]interface{}{
+					"owner":            "owner",
+					"repo":             "repo",
+					"discussionNumber": int32(1),
+				}
+				for k, v := range tc.params {
+					args[k] = v
+				}
+
+				result, err := handler(context.Background(), createMCPRequest(args))
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.errContains)
+			})
+		}
+	})
+}
+
+func Test_FilterDiscussionComments(t *testing.T) {
+	comment := func(body, login string, createdAt time.Time) *github.IssueComment {
+		return &github.IssueComment{
+			Body:      github.Ptr(body),
+			User:      &github.User{Login: github.Ptr(login)},
+			CreatedAt: &github.Timestamp{Time: createdAt},
+		}
+	}
+	day := func(d int) time.Time { return time.Date(2025, 4, d, 0, 0, 0, 0, time.UTC) }
+	comments := []*github.IssueComment{
+		comment("a", "octocat", day(1)),
+		comment("b", "hubot", day(2)),
+		comment("c", "Octocat", day(3)),
+		{Body: github.Ptr("d")},
+	}
+
+	tests := []struct {
+		name             string
+		author           string
+		since            time.Time
+		expectedBodies   []string
+		expectedFiltered int
+	}{
+		{
+			name:           "no filters keeps everything",
+			expectedBodies: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:             "author is case-insensitive",
+			author:           "OCTOCAT",
+			expectedBodies:   []string{"a", "c"},
+			expectedFiltered: 2,
+		},
+		{
+			name:             "since is inclusive",
+			since:            day(2),
+			expectedBodies:   []string{"b", "c"},
+			expectedFiltered: 2,
+		},
+		{
+			name:             "author and since combine",
+			author:           "octocat",
+			since:            day(2),
+			expectedBodies:   []string{"c"},
+			expectedFiltered: 3,
+		},
+		{
+			name:             "no matches",
+			author:           "nobody",
+			expectedBodies:   []string{},
+			expectedFiltered: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kept, filtered := filterDiscussionComments(comments, tc.author, tc.since)
+
+			bodies := []string{}
+			for _, c := range kept {
+				bodies = append(bodies, c.GetBody())
+			}
+			assert.Equal(t, tc.expectedBodies, bodies)
+			assert.Equal(t, tc.expectedFiltered, filtered)
+		})
 	}
 }
 
@@ -396,20 +668,10 @@ func Test_ListDiscussionCategories(t *testing.T) {
 
 	text := getTextResult(t, result).Text
 
-	var response struct {
-		Categories []map[string]string `json:"categories"`
-		PageInfo   struct {
-			HasNextPage     bool   `json:"hasNextPage"`
-			HasPreviousPage bool   `json:"hasPreviousPage"`
-			StartCursor     string `json:"startCursor"`
-			EndCursor       string `json:"endCursor"`
-		} `json:"pageInfo"`
-		TotalCount int `json:"totalCount"`
-	}
+	var response DiscussionCategoriesResult
 	require.NoError(t, json.Unmarshal([]byte(text), &response))
-	assert.Len(t, response.Categories, 2)
-	assert.Equal(t, "123", response.Categories[0]["id"])
-	assert.Equal(t, "CategoryOne", response.Categories[0]["name"])
-	assert.Equal(t, "456", response.Categories[1]["id"])
-	assert.Equal(t, "CategoryTwo", response.Categories[1]["name"])
+	assert.Equal(t, []DiscussionCategory{
+		{ID: "123", Name: "CategoryOne"},
+		{ID: "456", Name: "CategoryTwo"},
+	}, response.Categories)
 }
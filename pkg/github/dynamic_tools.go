@@ -12,9 +12,10 @@ import (
 )
 
 func ToolsetEnum(toolsetGroup *toolsets.ToolsetGroup) mcp.PropertyOption {
-	toolsetNames := make([]string, 0, len(toolsetGroup.Toolsets))
-	for name := range toolsetGroup.Toolsets {
-		toolsetNames = append(toolsetNames, name)
+	toolsets := toolsetGroup.List()
+	toolsetNames := make([]string, 0, len(toolsets))
+	for _, toolset := range toolsets {
+		toolsetNames = append(toolsetNames, toolset.Name)
 	}
 	return mcp.Enum(toolsetNames...)
 }
@@ -39,21 +40,23 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			toolset := toolsetGroup.Toolsets[toolsetName]
-			if toolset == nil {
+			toolset, err := toolsetGroup.GetToolset(toolsetName)
+			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
 			}
-			if toolset.Enabled {
+			if toolsetGroup.IsEnabled(toolsetName) {
 				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already enabled", toolsetName)), nil
 			}
 
-			toolset.Enabled = true
+			if err := toolsetGroup.EnableToolset(toolsetName); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			// caution: this currently affects the global tools and notifies all clients:
 			//
 			// Send notification to all initialized sessions
 			// s.sendNotificationToAllClients("notifications/tools/list_changed", nil)
-			s.AddTools(toolset.GetActiveTools()...)
+			s.AddTools(toolset.GetAvailableTools()...)
 
 			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s enabled", toolsetName)), nil
 		}
@@ -68,20 +71,16 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 			}),
 		),
 		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			// We need to convert the toolsetGroup back to a map for JSON serialization
-
+			// We need to convert the toolsetGroup back to a map for JSON serialization.
+			// List comes sorted, so the listing is stable from call to call.
 			payload := []map[string]string{}
-
-			for name, ts := range toolsetGroup.Toolsets {
-				{
-					t := map[string]string{
-						"name":              name,
-						"description":       ts.Description,
-						"can_enable":        "true",
-						"currently_enabled": fmt.Sprintf("%t", ts.Enabled),
-					}
-					payload = append(payload, t)
-				}
+			for _, ts := range toolsetGroup.List() {
+				payload = append(payload, map[string]string{
+					"name":              ts.Name,
+					"description":       ts.Description,
+					"can_enable":        "true",
+					"currently_enabled": fmt.Sprintf("%t", ts.Enabled),
+				})
 			}
 
 			r, err := json.Marshal(payload)
@@ -112,8 +111,8 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			toolset := toolsetGroup.Toolsets[toolsetName]
-			if toolset == nil {
+			toolset, err := toolsetGroup.GetToolset(toolsetName)
+			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
 			}
 			payload := []map[string]string{}
@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListAvailableToolsets(t *testing.T) {
+	tsg, err := DefaultToolsetGroup(ServerInfo{}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+	require.NoError(t, err)
+	require.NoError(t, tsg.EnableToolsets([]string{"repos", "pull_requests"}))
+
+	tool, handler := ListAvailableToolsets(tsg, translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_available_toolsets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	require.NotNil(t, tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var listed []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &listed))
+	require.Len(t, listed, len(tsg.List()))
+
+	for i, entry := range listed {
+		if i > 0 {
+			assert.Less(t, listed[i-1]["name"], entry["name"], "toolsets are listed in name order")
+		}
+		ts, err := tsg.GetToolset(entry["name"])
+		require.NoError(t, err, "listed toolset %s exists", entry["name"])
+		assert.Equal(t, ts.Description, entry["description"])
+
+		want := "false"
+		if entry["name"] == "repos" || entry["name"] == "pull_requests" {
+			want = "true"
+		}
+		assert.Equal(t, want, entry["currently_enabled"], "toolset %s", entry["name"])
+	}
+}
+
+func Test_ListAvailableToolsetsWhileEnabling(t *testing.T) {
+	tsg, err := DefaultToolsetGroup(ServerInfo{}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+	require.NoError(t, err)
+	_, handler := ListAvailableToolsets(tsg, translations.NullTranslationHelper)
+
+	// Run with -race: listing must not read the group while a toolset is being enabled.
+	var wg sync.WaitGroup
+	for _, info := range tsg.List() {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, tsg.EnableToolset(info.Name))
+		}()
+		go func() {
+			defer wg.Done()
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{}))
+			assert.NoError(t, err)
+			assert.False(t, result.IsError)
+		}()
+	}
+	wg.Wait()
+
+	for _, info := range tsg.List() {
+		assert.True(t, info.Enabled, "toolset %s", info.Name)
+	}
+}
@@ -0,0 +1,200 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// eventTypes are the types of events the GitHub events API returns.
+var eventTypes = []string{
+	"CommitCommentEvent",
+	"CreateEvent",
+	"DeleteEvent",
+	"ForkEvent",
+	"GollumEvent",
+	"IssueCommentEvent",
+	"IssuesEvent",
+	"MemberEvent",
+	"PublicEvent",
+	"PullRequestEvent",
+	"PullRequestReviewEvent",
+	"PullRequestReviewCommentEvent",
+	"PullRequestReviewThreadEvent",
+	"PushEvent",
+	"ReleaseEvent",
+	"SponsorshipEvent",
+	"WatchEvent",
+}
+
+// EventSummary is an event from the GitHub events API with a one-line description of what happened.
+type EventSummary struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Actor     string    `json:"actor"`
+	Repo      string    `json:"repo"`
+	CreatedAt time.Time `json:"created_at"`
+	Summary   string    `json:"summary"`
+}
+
+// EventsResult is a page of events. The event type filter applies to the page after it is fetched,
+// so a page can hold fewer events than requested, or none, while later pages still match.
+type EventsResult struct {
+	Events []EventSummary `json:"events"`
+	NextPageInfo
+}
+
+// ListOrgEvents creates a tool to list the public events of an organization.
+func ListOrgEvents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_events",
+			mcp.WithDescription(t("TOOL_LIST_ORG_EVENTS_DESCRIPTION", "List recent public events of an organization, such as pushes, releases and member changes, newest first, each with a one-line summary")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_EVENTS_USER_TITLE", "List organization events"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithArray("event_types",
+				mcp.Description("Only return events of these types, applied to each page after it is fetched"),
+				mcp.Items(map[string]any{
+					"type": "string",
+					"enum": eventTypes,
+				}),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			types, err := OptionalStringArrayParam(request, "event_types")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, eventType := range types {
+				if !slices.Contains(eventTypes, eventType) {
+					return mcp.NewToolResultError(fmt.Sprintf("unknown event type %q, valid types are: %s", eventType, strings.Join(eventTypes, ", "))), nil
+				}
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			events, resp, err := client.Activity.ListEventsForOrganization(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if result, ok, err := handleRESTResponse(ctx, "failed to list organization events", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(EventsResult{
+				Events:       summarizeEvents(events, types),
+				NextPageInfo: nextPageInfo(resp),
+			}), nil
+		}
+}
+
+// summarizeEvents summarizes the events of the given types, or all events if types is empty.
+func summarizeEvents(events []*github.Event, types []string) []EventSummary {
+	summaries := []EventSummary{}
+	for _, event := range events {
+		if len(types) > 0 && !slices.Contains(types, event.GetType()) {
+			continue
+		}
+		summaries = append(summaries, EventSummary{
+			ID:        event.GetID(),
+			Type:      event.GetType(),
+			Actor:     event.GetActor().GetLogin(),
+			Repo:      event.GetRepo().GetName(),
+			CreatedAt: event.GetCreatedAt().Time,
+			Summary:   summarizeEvent(event),
+		})
+	}
+	return summaries
+}
+
+// summarizeEvent describes an event in one line, such as "octocat published release v1.0.0 in octo-org/octo-repo".
+func summarizeEvent(event *github.Event) string {
+	actor := event.GetActor().GetLogin()
+	repo := event.GetRepo().GetName()
+
+	payload, err := event.ParsePayload()
+	if err != nil {
+		return fmt.Sprintf("%s triggered %s in %s", actor, event.GetType(), repo)
+	}
+	switch p := payload.(type) {
+	case *github.PushEvent:
+		commits := p.GetSize()
+		if commits == 0 {
+			commits = len(p.Commits)
+		}
+		return fmt.Sprintf("%s pushed %d %s to %s in %s", actor, commits, plural(commits, "commit"), strings.TrimPrefix(p.GetRef(), "refs/heads/"), repo)
+	case *github.CreateEvent:
+		if p.GetRefType() == "repository" {
+			return fmt.Sprintf("%s created repository %s", actor, repo)
+		}
+		return fmt.Sprintf("%s created %s %s in %s", actor, p.GetRefType(), p.GetRef(), repo)
+	case *github.DeleteEvent:
+		return fmt.Sprintf("%s deleted %s %s in %s", actor, p.GetRefType(), p.GetRef(), repo)
+	case *github.ForkEvent:
+		return fmt.Sprintf("%s forked %s to %s", actor, repo, p.GetForkee().GetFullName())
+	case *github.GollumEvent:
+		return fmt.Sprintf("%s updated %d wiki %s in %s", actor, len(p.Pages), plural(len(p.Pages), "page"), repo)
+	case *github.IssueCommentEvent:
+		return fmt.Sprintf("%s commented on #%d %q in %s", actor, p.GetIssue().GetNumber(), p.GetIssue().GetTitle(), repo)
+	case *github.IssuesEvent:
+		return fmt.Sprintf("%s %s issue #%d %q in %s", actor, p.GetAction(), p.GetIssue().GetNumber(), p.GetIssue().GetTitle(), repo)
+	case *github.MemberEvent:
+		return fmt.Sprintf("%s %s %s as a collaborator on %s", actor, p.GetAction(), p.GetMember().GetLogin(), repo)
+	case *github.PublicEvent:
+		return fmt.Sprintf("%s made %s public", actor, repo)
+	case *github.PullRequestEvent:
+		action := p.GetAction()
+		if action == "closed" && p.GetPullRequest().GetMerged() {
+			action = "merged"
+		}
+		return fmt.Sprintf("%s %s pull request #%d %q in %s", actor, action, p.GetNumber(), p.GetPullRequest().GetTitle(), repo)
+	case *github.PullRequestReviewEvent:
+		return fmt.Sprintf("%s reviewed pull request #%d %q in %s", actor, p.GetPullRequest().GetNumber(), p.GetPullRequest().GetTitle(), repo)
+	case *github.PullRequestReviewCommentEvent:
+		return fmt.Sprintf("%s commented on pull request #%d %q in %s", actor, p.GetPullRequest().GetNumber(), p.GetPullRequest().GetTitle(), repo)
+	case *github.CommitCommentEvent:
+		return fmt.Sprintf("%s commented on commit %s in %s", actor, shortSHA(p.GetComment().GetCommitID()), repo)
+	case *github.ReleaseEvent:
+		return fmt.Sprintf("%s %s release %s in %s", actor, p.GetAction(), p.GetRelease().GetTagName(), repo)
+	case *github.WatchEvent:
+		return fmt.Sprintf("%s starred %s", actor, repo)
+	default:
+		return fmt.Sprintf("%s triggered %s in %s", actor, event.GetType(), repo)
+	}
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return noun
+	}
+	return noun + "s"
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
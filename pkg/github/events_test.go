@@ -0,0 +1,178 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEvent(id, eventType, repo string, payload string) *github.Event {
+	raw := json.RawMessage(payload)
+	return &github.Event{
+		ID:         github.Ptr(id),
+		Type:       github.Ptr(eventType),
+		Actor:      &github.User{Login: github.Ptr("octocat")},
+		Repo:       &github.Repository{Name: github.Ptr(repo)},
+		CreatedAt:  &github.Timestamp{Time: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)},
+		RawPayload: &raw,
+	}
+}
+
+func Test_ListOrgEvents(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgEvents(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_events", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "event_types")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockEvents := []*github.Event{
+		newTestEvent("3", "ReleaseEvent", "octo-org/cli", `{"action": "published", "release": {"tag_name": "v1.2.0"}}`),
+		newTestEvent("2", "PushEvent", "octo-org/cli", `{"ref": "refs/heads/main", "size": 3}`),
+		newTestEvent("1", "MemberEvent", "octo-org/api", `{"action": "added", "member": {"login": "hubot"}}`),
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedEvents   []EventSummary
+		expectedNextPage int
+	}{
+		{
+			name: "lists all events",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsEventsByOrg,
+					expectQueryParams(t, map[string]string{"page": "1", "per_page": "30"}).andThen(
+						mockResponse(t, http.StatusOK, mockEvents),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectedEvents: []EventSummary{
+				{ID: "3", Type: "ReleaseEvent", Actor: "octocat", Repo: "octo-org/cli", CreatedAt: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC), Summary: "octocat published release v1.2.0 in octo-org/cli"},
+				{ID: "2", Type: "PushEvent", Actor: "octocat", Repo: "octo-org/cli", CreatedAt: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC), Summary: "octocat pushed 3 commits to main in octo-org/cli"},
+				{ID: "1", Type: "MemberEvent", Actor: "octocat", Repo: "octo-org/api", CreatedAt: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC), Summary: "octocat added hubot as a collaborator on octo-org/api"},
+			},
+		},
+		{
+			name: "filters by event type and reports the next page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsEventsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Link", `<https://api.github.com/organizations/1/events?page=3>; rel="next"`)
+						_ = json.NewEncoder(w).Encode(mockEvents)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":         "octo-org",
+				"event_types": []any{"ReleaseEvent", "MemberEvent"},
+				"page":        float64(2),
+			},
+			expectedEvents: []EventSummary{
+				{ID: "3", Type: "ReleaseEvent", Actor: "octocat", Repo: "octo-org/cli", CreatedAt: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC), Summary: "octocat published release v1.2.0 in octo-org/cli"},
+				{ID: "1", Type: "MemberEvent", Actor: "octocat", Repo: "octo-org/api", CreatedAt: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC), Summary: "octocat added hubot as a collaborator on octo-org/api"},
+			},
+			expectedNextPage: 3,
+		},
+		{
+			name:         "unknown event type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":         "octo-org",
+				"event_types": []any{"ReleaseEvent", "DeploymentEvent"},
+			},
+			expectError:    true,
+			expectedErrMsg: `unknown event type "DeploymentEvent", valid types are: CommitCommentEvent, CreateEvent`,
+		},
+		{
+			name: "events fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsEventsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization events",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgEvents(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned EventsResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedEvents, returned.Events)
+			assert.Equal(t, tc.expectedNextPage, returned.NextPage)
+		})
+	}
+}
+
+func Test_SummarizeEvent(t *testing.T) {
+	tests := []struct {
+		eventType string
+		payload   string
+		expected  string
+	}{
+		{"PushEvent", `{"ref": "refs/heads/main", "size": 1}`, "octocat pushed 1 commit to main in octo-org/repo"},
+		{"CreateEvent", `{"ref": "v1.0.0", "ref_type": "tag"}`, "octocat created tag v1.0.0 in octo-org/repo"},
+		{"CreateEvent", `{"ref_type": "repository"}`, "octocat created repository octo-org/repo"},
+		{"DeleteEvent", `{"ref": "feature", "ref_type": "branch"}`, "octocat deleted branch feature in octo-org/repo"},
+		{"ForkEvent", `{"forkee": {"full_name": "octocat/repo"}}`, "octocat forked octo-org/repo to octocat/repo"},
+		{"GollumEvent", `{"pages": [{"page_name": "Home"}, {"page_name": "Setup"}]}`, "octocat updated 2 wiki pages in octo-org/repo"},
+		{"IssuesEvent", `{"action": "opened", "issue": {"number": 5, "title": "Crash"}}`, `octocat opened issue #5 "Crash" in octo-org/repo`},
+		{"IssueCommentEvent", `{"action": "created", "issue": {"number": 5, "title": "Crash"}}`, `octocat commented on #5 "Crash" in octo-org/repo`},
+		{"PullRequestEvent", `{"action": "closed", "number": 7, "pull_request": {"title": "Fix", "merged": true}}`, `octocat merged pull request #7 "Fix" in octo-org/repo`},
+		{"PullRequestReviewEvent", `{"pull_request": {"number": 7, "title": "Fix"}}`, `octocat reviewed pull request #7 "Fix" in octo-org/repo`},
+		{"PullRequestReviewCommentEvent", `{"pull_request": {"number": 7, "title": "Fix"}}`, `octocat commented on pull request #7 "Fix" in octo-org/repo`},
+		{"CommitCommentEvent", `{"comment": {"commit_id": "6dcb09b5b57875f334f61aebed695e2e4193db5e"}}`, "octocat commented on commit 6dcb09b in octo-org/repo"},
+		{"PublicEvent", `{}`, "octocat made octo-org/repo public"},
+		{"WatchEvent", `{"action": "started"}`, "octocat starred octo-org/repo"},
+		{"SponsorshipEvent", `{}`, "octocat triggered SponsorshipEvent in octo-org/repo"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.eventType, func(t *testing.T) {
+			event := newTestEvent("1", tc.eventType, "octo-org/repo", tc.payload)
+			assert.Equal(t, tc.expected, summarizeEvent(event))
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultGistMaxBytes is the default limit on the total size of the files of a gist created by create_gist.
+const DefaultGistMaxBytes = 1 << 20
+
+// Gist is a gist as returned by the gist tools, listing its files by name only.
+type Gist struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	Public      bool     `json:"public"`
+	Owner       string   `json:"owner,omitempty"`
+	HTMLURL     string   `json:"html_url"`
+	Files       []string `json:"files"`
+	CreatedAt   string   `json:"created_at,omitempty"`
+	UpdatedAt   string   `json:"updated_at,omitempty"`
+}
+
+func newGist(gist *github.Gist) Gist {
+	files := make([]string, 0, len(gist.Files))
+	for name := range gist.Files {
+		files = append(files, string(name))
+	}
+	slices.Sort(files)
+
+	result := Gist{
+		ID:          gist.GetID(),
+		Description: gist.GetDescription(),
+		Public:      gist.GetPublic(),
+		Owner:       gist.GetOwner().GetLogin(),
+		HTMLURL:     gist.GetHTMLURL(),
+		Files:       files,
+	}
+	if gist.CreatedAt != nil {
+		result.CreatedAt = gist.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if gist.UpdatedAt != nil {
+		result.UpdatedAt = gist.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+// ListGists creates a tool to list the gists of a user.
+func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_gists",
+			mcp.WithDescription(t("TOOL_LIST_GISTS_DESCRIPTION", "List the gists of a user, or of the authenticated user when no username is given. Only the authenticated user's own listing includes secret gists.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_GISTS_USER_TITLE", "List gists"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("Username to list gists for, defaults to the authenticated user"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only list gists updated after this time (ISO 8601 format)"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.GistListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if since != "" {
+				timestamp, err := parseISOTimestamp(since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list gists: %s", err.Error())), nil
+				}
+				opts.Since = timestamp
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			gists, resp, err := client.Gists.List(ctx, username, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list gists", resp, err); !ok {
+				return result, err
+			}
+
+			result := make([]Gist, 0, len(gists))
+			for _, gist := range gists {
+				result = append(result, newGist(gist))
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// CreateGist creates a tool to create a gist. The total size of the gist's files is limited to
+// maxBytes, or to DefaultGistMaxBytes when maxBytes is not positive.
+func CreateGist(getClient GetClientFn, maxBytes int, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultGistMaxBytes
+	}
+
+	return mcp.NewTool("create_gist",
+			mcp.WithDescription(t("TOOL_CREATE_GIST_DESCRIPTION", "Create a gist from one or more files, e.g. to share a code snippet or log output. Gists are secret unless public is set to true; secret gists are not listed publicly but can be seen by anyone with the link.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_GIST_USER_TITLE", "Create gist"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("description",
+				mcp.Description("Description of the gist"),
+			),
+			mcp.WithBoolean("public",
+				mcp.Description("Whether the gist is public"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithObject("files",
+				mcp.Required(),
+				mcp.Description(fmt.Sprintf("Files of the gist, mapping each filename to its content. At most %d bytes in total.", maxBytes)),
+				mcp.AdditionalProperties(map[string]any{
+					"type": "string",
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			public, err := OptionalParam[bool](request, "public")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			requestFiles, ok := request.GetArguments()["files"].(map[string]any)
+			if !ok || len(requestFiles) == 0 {
+				return mcp.NewToolResultError("files must map at least one filename to its content"), nil
+			}
+
+			files := make(map[github.GistFilename]github.GistFile, len(requestFiles))
+			size := 0
+			for name, value := range requestFiles {
+				content, ok := value.(string)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("content of file %q must be a string", name)), nil
+				}
+				size += len(content)
+				files[github.GistFilename(name)] = github.GistFile{Content: github.Ptr(content)}
+			}
+			if size > maxBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("files are %d bytes in total, more than the limit of %d bytes", size, maxBytes)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			gist := &github.Gist{
+				Public: github.Ptr(public),
+				Files:  files,
+			}
+			if description != "" {
+				gist.Description = github.Ptr(description)
+			}
+			created, resp, err := client.Gists.Create(ctx, gist)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create gist", resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(newGist(created)), nil
+		}
+}
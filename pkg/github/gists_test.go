@@ -0,0 +1,334 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListGists(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListGists(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_gists", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	updated := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockGists := []*github.Gist{
+		{
+			ID:          github.Ptr("aa5a315d61ae9438b18d"),
+			Description: github.Ptr("build log"),
+			Public:      github.Ptr(false),
+			Owner:       &github.User{Login: github.Ptr("octocat")},
+			HTMLURL:     github.Ptr("https://gist.github.com/aa5a315d61ae9438b18d"),
+			Files: map[github.GistFilename]github.GistFile{
+				"build.log": {Filename: github.Ptr("build.log")},
+				"README.md": {Filename: github.Ptr("README.md")},
+			},
+			CreatedAt: &github.Timestamp{Time: updated.Add(-time.Hour)},
+			UpdatedAt: &github.Timestamp{Time: updated},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedGists  []Gist
+		expectedErrMsg string
+	}{
+		{
+			name: "lists gists of the authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGists,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockGists),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{},
+			expectedGists: []Gist{
+				{
+					ID:          "aa5a315d61ae9438b18d",
+					Description: "build log",
+					Owner:       "octocat",
+					HTMLURL:     "https://gist.github.com/aa5a315d61ae9438b18d",
+					Files:       []string{"README.md", "build.log"},
+					CreatedAt:   "2024-03-01T11:00:00Z",
+					UpdatedAt:   "2024-03-01T12:00:00Z",
+				},
+			},
+		},
+		{
+			name: "lists gists of a user updated since a time",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersGistsByUsername,
+					expectQueryParams(t, map[string]string{
+						"since":    "2024-01-01T00:00:00Z",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Gist{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+				"since":    "2024-01-01",
+				"page":     float64(2),
+				"perPage":  float64(10),
+			},
+			expectedGists: []Gist{},
+		},
+		{
+			name:         "invalid since",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"since": "yesterday",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list gists",
+		},
+		{
+			name: "user not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersGistsByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "nobody",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list gists",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListGists(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var gists []Gist
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &gists))
+			assert.Equal(t, tc.expectedGists, gists)
+		})
+	}
+}
+
+func Test_CreateGist(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateGist(stubGetClientFn(mockClient), DefaultGistMaxBytes, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_gist", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "public")
+	assert.Contains(t, tool.InputSchema.Properties, "files")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"files"})
+
+	createdGist := &github.Gist{
+		ID:      github.Ptr("aa5a315d61ae9438b18d"),
+		Public:  github.Ptr(false),
+		HTMLURL: github.Ptr("https://gist.github.com/aa5a315d61ae9438b18d"),
+		Files: map[github.GistFilename]github.GistFile{
+			"main.go": {Filename: github.Ptr("main.go")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		maxBytes       int
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedGist   Gist
+		expectedErrMsg string
+	}{
+		{
+			name: "creates a secret gist by default",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostGists,
+					expectRequestBody(t, map[string]any{
+						"description": "snippet",
+						"public":      false,
+						"files": map[string]any{
+							"main.go": map[string]any{"content": "package main\n"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, createdGist),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"description": "snippet",
+				"files":       map[string]any{"main.go": "package main\n"},
+			},
+			expectedGist: Gist{
+				ID:      "aa5a315d61ae9438b18d",
+				HTMLURL: "https://gist.github.com/aa5a315d61ae9438b18d",
+				Files:   []string{"main.go"},
+			},
+		},
+		{
+			name: "creates a public gist of several files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostGists,
+					expectRequestBody(t, map[string]any{
+						"public": true,
+						"files": map[string]any{
+							"a.txt": map[string]any{"content": "a"},
+							"b.txt": map[string]any{"content": "b"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Gist{
+							ID:     github.Ptr("bb"),
+							Public: github.Ptr(true),
+							Files: map[github.GistFilename]github.GistFile{
+								"b.txt": {},
+								"a.txt": {},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"public": true,
+				"files":  map[string]any{"a.txt": "a", "b.txt": "b"},
+			},
+			expectedGist: Gist{
+				ID:     "bb",
+				Public: true,
+				Files:  []string{"a.txt", "b.txt"},
+			},
+		},
+		{
+			name:         "rejects empty files",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"files": map[string]any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "files must map at least one filename to its content",
+		},
+		{
+			name:         "rejects content that is not a string",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"files": map[string]any{"a.json": map[string]any{"a": 1}},
+			},
+			expectError:    true,
+			expectedErrMsg: `content of file "a.json" must be a string`,
+		},
+		{
+			name:         "rejects files over the size limit",
+			maxBytes:     10,
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"files": map[string]any{"a.txt": "123456", "b.txt": "78901"},
+			},
+			expectError:    true,
+			expectedErrMsg: "files are 11 bytes in total, more than the limit of 10 bytes",
+		},
+		{
+			name:         "defaults to a 1 MB limit",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"files": map[string]any{"big.log": strings.Repeat("x", DefaultGistMaxBytes+1)},
+			},
+			expectError:    true,
+			expectedErrMsg: "more than the limit of 1048576 bytes",
+		},
+		{
+			name: "API error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostGists,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"files": map[string]any{"empty.txt": ""},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create gist",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateGist(stubGetClientFn(client), tc.maxBytes, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var gist Gist
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &gist))
+			assert.Equal(t, tc.expectedGist, gist)
+		})
+	}
+}
+
+func Test_CreateGistIsNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		gists, err := tsg.GetToolset("gists")
+		require.NoError(t, err)
+
+		var names []string
+		for _, st := range gists.GetAvailableTools() {
+			names = append(names, st.Tool.Name)
+		}
+		assert.Contains(t, names, "list_gists")
+		if readOnly {
+			assert.NotContains(t, names, "create_gist")
+		} else {
+			assert.Contains(t, names, "create_gist")
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shurcooL/githubv4"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+)
+
+// getPullRequestNodeID looks up the GraphQL node ID of a pull request. Our other Pull Request tools work with
+// the REST client and accept owner, repo and number, so the review tools resolve the ID internally.
+func getPullRequestNodeID(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int32) (githubv4.ID, error) {
+	var getPullRequestQuery struct {
+		Repository struct {
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	if err := client.Query(ctx, &getPullRequestQuery, map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(pullNumber),
+	}); err != nil {
+		return nil, err
+	}
+	return getPullRequestQuery.Repository.PullRequest.ID, nil
+}
+
+// getViewerLogin returns the login of the authenticated user.
+func getViewerLogin(ctx context.Context, client *githubv4.Client) (githubv4.String, error) {
+	var getViewerQuery struct {
+		Viewer struct {
+			Login githubv4.String
+		}
+	}
+	if err := client.Query(ctx, &getViewerQuery, nil); err != nil {
+		return "", err
+	}
+	return getViewerQuery.Viewer.Login, nil
+}
+
+// getPendingReviewID returns the ID of the pending review that the review tools act on: the one the caller gave,
+// or else the current user's pending review on the pull request. When the review cannot be found, the returned
+// result should be passed straight back to the caller.
+func getPendingReviewID(ctx context.Context, client *githubv4.Client, pullRequestReviewID *string, owner, repo string, pullNumber int32) (githubv4.ID, *mcp.CallToolResult) {
+	if pullRequestReviewID != nil && *pullRequestReviewID != "" {
+		return githubv4.ID(*pullRequestReviewID), nil
+	}
+	return getViewerPendingReviewID(ctx, client, owner, repo, pullNumber)
+}
+
+// getViewerPendingReviewID finds the current user's pending review on a pull request. Only one review can be
+// pending per user at a time, so this is how the review tools target it when they are not given a review ID.
+// The reviews are filtered by state, since the user's other, submitted reviews may come first. When the review
+// cannot be found, the returned result should be passed straight back to the caller.
+func getViewerPendingReviewID(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int32) (githubv4.ID, *mcp.CallToolResult) {
+	login, err := getViewerLogin(ctx, client)
+	if err != nil {
+		return nil, ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+			"failed to get current user",
+			err,
+		)
+	}
+
+	var getPendingReviewForViewerQuery struct {
+		Repository struct {
+			PullRequest struct {
+				Reviews struct {
+					Nodes []struct {
+						ID githubv4.ID
+					}
+				} `graphql:"reviews(first: 1, author: $author, states: PENDING)"`
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	vars := map[string]any{
+		"author": login,
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repo),
+		"prNum":  githubv4.Int(pullNumber),
+	}
+
+	if err := client.Query(ctx, &getPendingReviewForViewerQuery, vars); err != nil {
+		return nil, ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+			"failed to get pending review for current user",
+			err,
+		)
+	}
+
+	if len(getPendingReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes) == 0 {
+		return nil, mcp.NewToolResultError("No pending review found for the viewer")
+	}
+	return getPendingReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes[0].ID, nil
+}
+
+// newGQLString like takes something that approximates a string (of which there are many types in shurcooL/githubv4)
+// and constructs a pointer to it, or nil if the string is empty. This is extremely useful because when we parse
+// params from the MCP request, we need to convert them to types that are pointers of type def strings and it's
+// not possible to take a pointer of an anonymous value e.g. &githubv4.String("foo").
+func newGQLStringlike[T ~string](s string) *T {
+	if s == "" {
+		return nil
+	}
+	stringlike := T(s)
+	return &stringlike
+}
+
+func newGQLStringlikePtr[T ~string](s *string) *T {
+	if s == nil {
+		return nil
+	}
+	stringlike := T(*s)
+	return &stringlike
+}
+
+func newGQLIntPtr(i *int32) *githubv4.Int {
+	if i == nil {
+		return nil
+	}
+	gi := githubv4.Int(*i)
+	return &gi
+}
+
+// ClosingPullRequest is a pull request linked to close an issue when it is merged. State is not
+// filtered on, so it tells open pull requests apart from the others.
+type ClosingPullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	State  string `json:"state"`
+}
+
+// maxClosingPullRequests caps how many of the pull requests linked to an issue are fetched.
+const maxClosingPullRequests = 10
+
+// getClosingPullRequests returns the pull requests linked to close an issue through closing keywords
+// or the development sidebar, in any state. The issue's participant count comes from the same
+// query, so it is returned too.
+func getClosingPullRequests(ctx context.Context, client *githubv4.Client, owner, repo string, issueNumber int) ([]ClosingPullRequest, int, error) {
+	var query struct {
+		Repository struct {
+			Issue struct {
+				Participants struct {
+					TotalCount githubv4.Int
+				}
+				ClosedByPullRequestsReferences struct {
+					Nodes []struct {
+						Number githubv4.Int
+						Title  githubv4.String
+						URL    githubv4.String
+						State  githubv4.String
+					}
+				} `graphql:"closedByPullRequestsReferences(first: $first)"`
+			} `graphql:"issue(number: $issueNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	if err := client.Query(ctx, &query, map[string]any{
+		"owner":       githubv4.String(owner),
+		"repo":        githubv4.String(repo),
+		"issueNumber": githubv4.Int(int32(issueNumber)), // #nosec G115 - issue numbers are always small positive integers
+		"first":       githubv4.Int(maxClosingPullRequests),
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	issue := query.Repository.Issue
+	pullRequests := make([]ClosingPullRequest, 0, len(issue.ClosedByPullRequestsReferences.Nodes))
+	for _, node := range issue.ClosedByPullRequestsReferences.Nodes {
+		pullRequests = append(pullRequests, ClosingPullRequest{
+			Number: int(node.Number),
+			Title:  string(node.Title),
+			URL:    string(node.URL),
+			State:  string(node.State),
+		})
+	}
+	return pullRequests, int(issue.Participants.TotalCount), nil
+}
@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewGQLStringlike(t *testing.T) {
+	assert.Nil(t, newGQLStringlike[githubv4.String](""))
+
+	event := newGQLStringlike[githubv4.PullRequestReviewEvent]("APPROVE")
+	require.NotNil(t, event)
+	assert.Equal(t, githubv4.PullRequestReviewEventApprove, *event)
+
+	assert.Nil(t, newGQLStringlikePtr[githubv4.String](nil))
+	empty := ""
+	require.NotNil(t, newGQLStringlikePtr[githubv4.String](&empty))
+
+	assert.Nil(t, newGQLIntPtr(nil))
+	line := int32(7)
+	assert.Equal(t, githubv4.Int(7), *newGQLIntPtr(&line))
+}
+
+func Test_GetPullRequestNodeID(t *testing.T) {
+	pullRequestQuery := struct {
+		Repository struct {
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	vars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"prNum": githubv4.Int(42),
+	}
+
+	tests := []struct {
+		name          string
+		response      githubv4mock.GQLResponse
+		expectedID    githubv4.ID
+		expectedError string
+	}{
+		{
+			name: "resolves node ID",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"id": "PR_kwDODKw3uc6WYN1T",
+					},
+				},
+			}),
+			expectedID: "PR_kwDODKw3uc6WYN1T",
+		},
+		{
+			name:          "lookup fails",
+			response:      githubv4mock.ErrorResponse("Could not resolve to a PullRequest with the number of 42."),
+			expectedError: "Could not resolve to a PullRequest with the number of 42.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(pullRequestQuery, vars, tc.response),
+			))
+
+			id, err := getPullRequestNodeID(context.Background(), client, "owner", "repo", 42)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				assert.Nil(t, id)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedID, id)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package github
+
+import (
+	"bufio"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goGitHubModulePattern matches the module path of a google/go-github major version.
+var goGitHubModulePattern = regexp.MustCompile(`^github\.com/google/go-github/(v\d+)`)
+
+// Test_SingleGoGitHubMajorVersion fails if the module imports more than one major version of
+// go-github. Types such as *github.Client from different versions do not mix, and every extra
+// version grows the binary. Indirect requirements of dependencies, such as go-github-mock, are
+// not counted.
+func Test_SingleGoGitHubMajorVersion(t *testing.T) {
+	root := filepath.Join("..", "..")
+
+	// versions maps each major version to the first place it was found
+	versions := map[string]string{}
+	found := func(version, where string) {
+		if _, ok := versions[version]; !ok {
+			versions[version] = where
+		}
+	}
+	goMod, err := os.Open(filepath.Join(root, "go.mod"))
+	require.NoError(t, err)
+	defer func() { _ = goMod.Close() }()
+	scanner := bufio.NewScanner(goMod)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "require ")
+		if strings.HasSuffix(line, "// indirect") {
+			continue
+		}
+		if match := goGitHubModulePattern.FindStringSubmatch(line); match != nil {
+			found(match[1], "go.mod")
+		}
+	}
+	require.NoError(t, scanner.Err())
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && (d.Name() == "vendor" || strings.HasPrefix(d.Name(), ".")) && path != root {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return err
+			}
+			if match := goGitHubModulePattern.FindStringSubmatch(importPath); match != nil {
+				found(match[1], path)
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, versions, 1, "go-github must be imported at a single major version, found: %v", versions)
+}
@@ -2,24 +2,75 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
+	"math"
 	"net/http"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
-	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
-	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/shurcooL/githubv4"
 )
 
+// ReactionCount is the number of reactions of one type.
+type ReactionCount struct {
+	Content string `json:"content"`
+	Count   int    `json:"count"`
+}
+
+// ReactionsSummary summarizes the reactions on an issue.
+type ReactionsSummary struct {
+	Total int             `json:"total"`
+	Top   []ReactionCount `json:"top"`
+}
+
+// IssueContext is what get_issue adds to an issue when include_context is set.
+type IssueContext struct {
+	Reactions          ReactionsSummary     `json:"reactions"`
+	ParticipantCount   *int                 `json:"participant_count,omitempty"`
+	LinkedPullRequests []ClosingPullRequest `json:"linked_pull_requests,omitempty"`
+	Warnings           []string             `json:"warnings,omitempty"`
+}
+
+// IssueWithContext is an issue along with its context.
+type IssueWithContext struct {
+	*github.Issue
+	Context IssueContext `json:"context"`
+}
+
+// summarizeReactions returns the total number of reactions and the three most used reaction types.
+func summarizeReactions(reactions *github.Reactions) ReactionsSummary {
+	counts := []ReactionCount{
+		{"+1", reactions.GetPlusOne()},
+		{"-1", reactions.GetMinusOne()},
+		{"laugh", reactions.GetLaugh()},
+		{"confused", reactions.GetConfused()},
+		{"heart", reactions.GetHeart()},
+		{"hooray", reactions.GetHooray()},
+		{"rocket", reactions.GetRocket()},
+		{"eyes", reactions.GetEyes()},
+	}
+	// A stable sort keeps ties in the order GitHub lists the reactions
+	sort.SliceStable(counts, func(i, j int) bool { return counts[i].Count > counts[j].Count })
+
+	summary := ReactionsSummary{Total: reactions.GetTotalCount(), Top: []ReactionCount{}}
+	for _, count := range counts {
+		if count.Count == 0 || len(summary.Top) == 3 {
+			break
+		}
+		summary.Top = append(summary.Top, count)
+	}
+	return summary
+}
+
 // GetIssue creates a tool to get details of a specific issue in a GitHub repository.
-func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+func GetIssue(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_issue",
 			mcp.WithDescription(t("TOOL_GET_ISSUE_DESCRIPTION", "Get details of a specific issue in a GitHub repository.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
@@ -38,6 +89,10 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				mcp.Required(),
 				mcp.Description("The number of the issue"),
 			),
+			mcp.WithBoolean("include_context",
+				mcp.Description("Include a reactions summary, the participant count and the pull requests linked to close the issue, each with its state"),
+				mcp.DefaultBool(true),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -52,31 +107,46 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			includeContext, ok, err := OptionalParamOK[bool](request, "include_context")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				includeContext = true
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 			issue, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get issue: %w", err)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get issue", resp, err); !ok {
+				return result, err
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil
+			if !includeContext {
+				return MarshalledTextResult(issue), nil
 			}
 
-			r, err := json.Marshal(issue)
+			// The linked pull requests and participants are only available through GraphQL,
+			// so the issue is still returned if that part fails.
+			result := IssueWithContext{
+				Issue:   issue,
+				Context: IssueContext{Reactions: summarizeReactions(issue.Reactions)},
+			}
+			gqlClient, err := getGQLClient(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal issue: %w", err)
+				result.Context.Warnings = append(result.Context.Warnings, fmt.Sprintf("failed to get GitHub GraphQL client: %v", err))
+				return MarshalledTextResult(result), nil
 			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			pullRequests, participantCount, err := getClosingPullRequests(ctx, gqlClient, owner, repo, issueNumber)
+			if err != nil {
+				result.Context.Warnings = append(result.Context.Warnings, fmt.Sprintf("failed to get linked pull requests and participants: %v", err))
+				return MarshalledTextResult(result), nil
+			}
+			result.Context.ParticipantCount = &participantCount
+			result.Context.LinkedPullRequests = pullRequests
+			return MarshalledTextResult(result), nil
 		}
 }
 
@@ -132,25 +202,71 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 			createdComment, resp, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, comment)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create comment", resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(createdComment), nil
+		}
+}
+
+// UpdateIssueComment creates a tool to edit the body of an issue comment.
+func UpdateIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_issue_comment",
+			mcp.WithDescription(t("TOOL_UPDATE_ISSUE_COMMENT_DESCRIPTION", "Replace the body of a comment on an issue or pull request in a GitHub repository. The comment_id is the id returned by add_issue_comment or get_issue_comments.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_ISSUE_COMMENT_USER_TITLE", "Update issue comment"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("comment_id",
+				mcp.Required(),
+				mcp.Description("ID of the comment to update"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("New comment content"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commentID, err := RequiredInt(request, "comment_id")
 			if err != nil {
-				return nil, fmt.Errorf("failed to create comment: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := RequiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create comment: %s", string(body))), nil
+			comment := &github.IssueComment{
+				Body: github.Ptr(body),
 			}
 
-			r, err := json.Marshal(createdComment)
+			client, err := getClient(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			updatedComment, resp, err := client.Issues.EditComment(ctx, owner, repo, int64(commentID), comment)
+			if result, ok, err := handleRESTResponse(ctx, "failed to update comment", resp, err); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(updatedComment), nil
 		}
 }
 
@@ -215,30 +331,11 @@ func AddSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			}
 
 			subIssue, resp, err := client.SubIssue.Add(ctx, owner, repo, int64(issueNumber), subIssueRequest)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to add sub-issue",
-					resp,
-					err,
-				), nil
-			}
-
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to add sub-issue: %s", string(body))), nil
+			if result, ok, err := handleRESTResponse(ctx, "failed to add sub-issue", resp, err, http.StatusCreated); !ok {
+				return result, err
 			}
 
-			r, err := json.Marshal(subIssue)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(subIssue), nil
 		}
 }
 
@@ -304,30 +401,11 @@ func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc)
 			}
 
 			subIssues, resp, err := client.SubIssue.ListByIssue(ctx, owner, repo, int64(issueNumber), opts)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to list sub-issues",
-					resp,
-					err,
-				), nil
+			if result, ok, err := handleRESTResponse(ctx, "failed to list sub-issues", resp, err); !ok {
+				return result, err
 			}
 
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list sub-issues: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(subIssues)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(subIssues), nil
 		}
 
 }
@@ -384,62 +462,22 @@ func RemoveSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Create the request body
-			requestBody := map[string]interface{}{
-				"sub_issue_id": subIssueID,
-			}
-			reqBodyBytes, err := json.Marshal(requestBody)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			subIssueRequest := github.SubIssueRequest{
+				SubIssueID: int64(subIssueID),
 			}
 
-			// Create the HTTP request
-			url := fmt.Sprintf("%srepos/%s/%s/issues/%d/sub_issue",
-				client.BaseURL.String(), owner, repo, issueNumber)
-			req, err := http.NewRequestWithContext(ctx, "DELETE", url, strings.NewReader(string(reqBodyBytes)))
+			// go-github's SubIssue.Remove uses the sub_issues path, but removal is on sub_issue
+			req, err := client.NewRequest(http.MethodDelete, fmt.Sprintf("repos/%s/%s/issues/%d/sub_issue", owner, repo, issueNumber), subIssueRequest)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create request: %w", err)
 			}
-			req.Header.Set("Accept", "application/vnd.github+json")
-			req.Header.Set("Content-Type", "application/json")
-			req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
-
-			httpClient := client.Client() // Use authenticated GitHub client
-			resp, err := httpClient.Do(req)
-			if err != nil {
-				var ghResp *github.Response
-				if resp != nil {
-					ghResp = &github.Response{Response: resp}
-				}
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to remove sub-issue",
-					ghResp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read response body: %w", err)
-			}
-
-			if resp.StatusCode != http.StatusOK {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to remove sub-issue: %s", string(body))), nil
-			}
-
-			// Parse and re-marshal to ensure consistent formatting
-			var result interface{}
-			if err := json.Unmarshal(body, &result); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-			}
-
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			subIssue := new(github.SubIssue)
+			resp, err := client.Do(ctx, req, subIssue)
+			if result, ok, err := handleRESTResponse(ctx, "failed to remove sub-issue", resp, err); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(subIssue), nil
 		}
 }
 
@@ -529,37 +567,18 @@ func ReprioritizeSubIssue(getClient GetClientFn, t translations.TranslationHelpe
 			}
 
 			subIssue, resp, err := client.SubIssue.Reprioritize(ctx, owner, repo, int64(issueNumber), subIssueRequest)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to reprioritize sub-issue",
-					resp,
-					err,
-				), nil
-			}
-
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to reprioritize sub-issue: %s", string(body))), nil
+			if result, ok, err := handleRESTResponse(ctx, "failed to reprioritize sub-issue", resp, err); !ok {
+				return result, err
 			}
 
-			r, err := json.Marshal(subIssue)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(subIssue), nil
 		}
 }
 
 // SearchIssues creates a tool to search for issues.
 func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_issues",
-			mcp.WithDescription(t("TOOL_SEARCH_ISSUES_DESCRIPTION", "Search for issues in GitHub repositories using issues search syntax already scoped to is:issue")),
+			mcp.WithDescription(t("TOOL_SEARCH_ISSUES_DESCRIPTION", "Search for issues in GitHub repositories using issues search syntax already scoped to is:issue, or to is:pr with is_pr. The result has the total_count of matches and incomplete_results, which is true when the search timed out before finding them all")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_SEARCH_ISSUES_USER_TITLE", "Search issues"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -594,6 +613,10 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Description("Sort order"),
 				mcp.Enum("asc", "desc"),
 			),
+			WithIssueSearchFilters(),
+			mcp.WithBoolean("is_pr",
+				mcp.Description("Search pull requests instead of issues"),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -682,18 +705,21 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			var milestoneNum *int
-			if milestone != 0 {
-				milestoneNum = &milestone
-			}
-
-			// Create the issue request
+			// Create the issue request, leaving out the optional fields not given
 			issueRequest := &github.IssueRequest{
-				Title:     github.Ptr(title),
-				Body:      github.Ptr(body),
-				Assignees: &assignees,
-				Labels:    &labels,
-				Milestone: milestoneNum,
+				Title: github.Ptr(title),
+			}
+			if body != "" {
+				issueRequest.Body = github.Ptr(body)
+			}
+			if len(assignees) > 0 {
+				issueRequest.Assignees = &assignees
+			}
+			if len(labels) > 0 {
+				issueRequest.Labels = &labels
+			}
+			if milestone != 0 {
+				issueRequest.Milestone = &milestone
 			}
 
 			client, err := getClient(ctx)
@@ -701,25 +727,11 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 			issue, resp, err := client.Issues.Create(ctx, owner, repo, issueRequest)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create issue: %w", err)
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create issue: %s", string(body))), nil
+			if result, ok, err := handleRESTResponse(ctx, "failed to create issue", resp, err, http.StatusCreated); !ok {
+				return result, err
 			}
 
-			r, err := json.Marshal(issue)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(issue), nil
 		}
 }
 
@@ -810,12 +822,13 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				opts.Since = timestamp
 			}
 
-			if page, ok := request.GetArguments()["page"].(float64); ok {
-				opts.ListOptions.Page = int(page)
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			if perPage, ok := request.GetArguments()["perPage"].(float64); ok {
-				opts.ListOptions.PerPage = int(perPage)
+			opts.ListOptions = github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
 			}
 
 			client, err := getClient(ctx)
@@ -823,32 +836,18 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 			issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list issues: %w", err)
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list issues: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(issues)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal issues: %w", err)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list issues", resp, err); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(issues), nil
 		}
 }
 
 // UpdateIssue creates a tool to update an existing issue in a GitHub repository.
 func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("update_issue",
-			mcp.WithDescription(t("TOOL_UPDATE_ISSUE_DESCRIPTION", "Update an existing issue in a GitHub repository.")),
+			mcp.WithDescription(t("TOOL_UPDATE_ISSUE_DESCRIPTION", "Update an existing issue in a GitHub repository. Only the fields given are changed.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_UPDATE_ISSUE_USER_TITLE", "Edit issue"),
 				ReadOnlyHint: ToBoolPtr(false),
@@ -875,8 +874,12 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("New state"),
 				mcp.Enum("open", "closed"),
 			),
+			mcp.WithString("state_reason",
+				mcp.Description("Reason for the state change, such as not_planned when closing an issue that will not be worked on"),
+				mcp.Enum("completed", "not_planned", "reopened"),
+			),
 			mcp.WithArray("labels",
-				mcp.Description("New labels"),
+				mcp.Description("New labels, replacing the current ones. An empty array removes all labels"),
 				mcp.Items(
 					map[string]interface{}{
 						"type": "string",
@@ -884,7 +887,7 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				),
 			),
 			mcp.WithArray("assignees",
-				mcp.Description("New assignees"),
+				mcp.Description("New assignees, replacing the current ones. An empty array removes all assignees"),
 				mcp.Items(
 					map[string]interface{}{
 						"type": "string",
@@ -892,7 +895,7 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				),
 			),
 			mcp.WithNumber("milestone",
-				mcp.Description("New milestone number"),
+				mcp.Description("New milestone number, or 0 to remove the milestone"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -911,83 +914,195 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 
 			// Create the issue request with only provided fields
 			issueRequest := &github.IssueRequest{}
+			updateNeeded := false
 
-			// Set optional parameters if provided
-			title, err := OptionalParam[string](request, "title")
-			if err != nil {
+			if title, ok, err := OptionalParamOK[string](request, "title"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
-			}
-			if title != "" {
+			} else if ok {
 				issueRequest.Title = github.Ptr(title)
+				updateNeeded = true
 			}
 
-			body, err := OptionalParam[string](request, "body")
-			if err != nil {
+			if body, ok, err := OptionalParamOK[string](request, "body"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
-			}
-			if body != "" {
+			} else if ok {
 				issueRequest.Body = github.Ptr(body)
+				updateNeeded = true
 			}
 
-			state, err := OptionalParam[string](request, "state")
-			if err != nil {
+			if state, ok, err := OptionalParamOK[string](request, "state"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
-			}
-			if state != "" {
+			} else if ok {
 				issueRequest.State = github.Ptr(state)
+				updateNeeded = true
 			}
 
-			// Get labels
-			labels, err := OptionalStringArrayParam(request, "labels")
-			if err != nil {
+			if stateReason, ok, err := OptionalParamOK[string](request, "state_reason"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				issueRequest.StateReason = github.Ptr(stateReason)
+				updateNeeded = true
 			}
-			if len(labels) > 0 {
+
+			// An empty array is sent as is, clearing the labels or assignees
+			if _, ok := request.GetArguments()["labels"]; ok {
+				labels, err := OptionalStringArrayParam(request, "labels")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
 				issueRequest.Labels = &labels
+				updateNeeded = true
 			}
 
-			// Get assignees
-			assignees, err := OptionalStringArrayParam(request, "assignees")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			if len(assignees) > 0 {
+			if _, ok := request.GetArguments()["assignees"]; ok {
+				assignees, err := OptionalStringArrayParam(request, "assignees")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
 				issueRequest.Assignees = &assignees
+				updateNeeded = true
 			}
 
-			milestone, err := OptionalIntParam(request, "milestone")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			clearMilestone := false
+			if _, ok := request.GetArguments()["milestone"]; ok {
+				milestone, err := OptionalIntParam(request, "milestone")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if milestone == 0 {
+					clearMilestone = true
+				} else {
+					issueRequest.Milestone = &milestone
+				}
+				updateNeeded = true
 			}
-			if milestone != 0 {
-				milestoneNum := milestone
-				issueRequest.Milestone = &milestoneNum
+
+			if !updateNeeded {
+				return mcp.NewToolResultError("No update parameters provided."), nil
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			updatedIssue, resp, err := client.Issues.Edit(ctx, owner, repo, issueNumber, issueRequest)
-			if err != nil {
-				return nil, fmt.Errorf("failed to update issue: %w", err)
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
+			var updatedIssue *github.Issue
+			var resp *github.Response
+			if clearMilestone {
+				req, err := client.NewRequest(http.MethodPatch, fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, issueNumber), clearMilestoneIssueRequest{IssueRequest: issueRequest})
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					return nil, fmt.Errorf("failed to create request: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update issue: %s", string(body))), nil
+				updatedIssue = new(github.Issue)
+				resp, err = client.Do(ctx, req, updatedIssue)
+			} else {
+				updatedIssue, resp, err = client.Issues.Edit(ctx, owner, repo, issueNumber, issueRequest)
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to update issue", resp, err); !ok {
+				return result, err
 			}
 
-			r, err := json.Marshal(updatedIssue)
+			return MarshalledTextResult(updatedIssue), nil
+		}
+}
+
+// clearMilestoneIssueRequest is an update_issue request that removes the milestone. go-github
+// leaves out a nil milestone, and GitHub only removes it when the milestone is an explicit null.
+type clearMilestoneIssueRequest struct {
+	*github.IssueRequest
+	Milestone *int `json:"milestone"`
+}
+
+// IssueAssignees is the result of the add_assignees tool: who is assigned to the issue afterwards.
+type IssueAssignees struct {
+	Number    int      `json:"number"`
+	Assignees []string `json:"assignees"`
+}
+
+// AddAssignees creates a tool to add assignees to, or remove them from, an issue or pull request.
+func AddAssignees(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_assignees",
+			mcp.WithDescription(t("TOOL_ADD_ASSIGNEES_DESCRIPTION", "Add assignees to an issue or pull request, or remove them with remove set to true. Users without access to the repository are ignored by GitHub, so check the returned assignees.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_ASSIGNEES_USER_TITLE", "Add or remove assignees"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithArray("assignees",
+				mcp.Required(),
+				mcp.Description("Logins of the users to add or remove"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithBoolean("remove",
+				mcp.Description("Remove the assignees instead of adding them"),
+				mcp.DefaultBool(false),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, err := OptionalStringArrayParam(request, "assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(assignees) == 0 {
+				return mcp.NewToolResultError("assignees must contain at least one login"), nil
+			}
+			remove, err := OptionalParam[bool](request, "remove")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var issue *github.Issue
+			var resp *github.Response
+			label := "failed to add assignees"
+			if remove {
+				label = "failed to remove assignees"
+				issue, resp, err = client.Issues.RemoveAssignees(ctx, owner, repo, issueNumber, assignees)
+			} else {
+				issue, resp, err = client.Issues.AddAssignees(ctx, owner, repo, issueNumber, assignees)
+			}
+			if result, ok, err := handleRESTResponse(ctx, label, resp, err, http.StatusOK, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			result := IssueAssignees{
+				Number:    issue.GetNumber(),
+				Assignees: make([]string, 0, len(issue.Assignees)),
+			}
+			for _, assignee := range issue.Assignees {
+				result.Assignees = append(result.Assignees, assignee.GetLogin())
+			}
+			return MarshalledTextResult(result), nil
 		}
 }
 
@@ -1011,6 +1126,9 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Required(),
 				mcp.Description("Issue number"),
 			),
+			mcp.WithString("since",
+				mcp.Description("Only return comments updated at or after this time (ISO 8601 timestamp)"),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1026,6 +1144,10 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -1037,34 +1159,299 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 					PerPage: pagination.PerPage,
 				},
 			}
+			if since != "" {
+				timestamp, err := parseISOTimestamp(since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get issue comments: %s", err.Error())), nil
+				}
+				opts.Since = &timestamp
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 			comments, resp, err := client.Issues.ListComments(ctx, owner, repo, issueNumber, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get issue comments", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(comments), nil
+		}
+}
+
+// noisyTimelineEvents are timeline events that get_issue_timeline leaves out unless include_all is set.
+var noisyTimelineEvents = []string{"subscribed", "unsubscribed", "mentioned"}
+
+// TimelineEvent is a condensed issue timeline event as returned by get_issue_timeline. Source is only
+// set for cross-referenced events.
+type TimelineEvent struct {
+	Event     string               `json:"event"`
+	Actor     string               `json:"actor,omitempty"`
+	CreatedAt *time.Time           `json:"created_at,omitempty"`
+	CommitID  string               `json:"commit_id,omitempty"`
+	Source    *TimelineEventSource `json:"source,omitempty"`
+}
+
+// IssueTimeline is a page of the timeline of an issue. Noisy events are left out after the page
+// is fetched, so a page can hold fewer events than requested, or none, while later pages have more.
+type IssueTimeline struct {
+	Events []TimelineEvent `json:"events"`
+	NextPageInfo
+}
+
+// TimelineEventSource is the issue or pull request that cross-referenced an issue.
+type TimelineEventSource struct {
+	Repository  string `json:"repository"`
+	Number      int    `json:"number"`
+	PullRequest bool   `json:"pull_request"`
+}
+
+// condenseTimelineEvent keeps the fields of a timeline event that say what happened, by whom and when.
+// Commits and reviews have no actor, so their author is used instead.
+func condenseTimelineEvent(event *github.Timeline) TimelineEvent {
+	condensed := TimelineEvent{
+		Event:    event.GetEvent(),
+		Actor:    event.GetActor().GetLogin(),
+		CommitID: event.GetCommitID(),
+	}
+	if condensed.Actor == "" {
+		condensed.Actor = event.GetUser().GetLogin()
+	}
+	if condensed.Actor == "" {
+		condensed.Actor = event.GetAuthor().GetName()
+	}
+	if event.CreatedAt != nil {
+		condensed.CreatedAt = &event.CreatedAt.Time
+	} else if event.SubmittedAt != nil {
+		condensed.CreatedAt = &event.SubmittedAt.Time
+	}
+
+	if issue := event.GetSource().GetIssue(); issue != nil {
+		repository := issue.GetRepository().GetFullName()
+		if repository == "" {
+			// The repository URL ends with /repos/{owner}/{repo}
+			if i := strings.Index(issue.GetRepositoryURL(), "/repos/"); i >= 0 {
+				repository = issue.GetRepositoryURL()[i+len("/repos/"):]
+			}
+		}
+		condensed.Source = &TimelineEventSource{
+			Repository:  repository,
+			Number:      issue.GetNumber(),
+			PullRequest: issue.IsPullRequest(),
+		}
+	}
+	return condensed
+}
+
+// GetIssueTimeline creates a tool to get the timeline of an issue or pull request.
+func GetIssueTimeline(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_timeline",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_TIMELINE_DESCRIPTION", "Get the timeline of an issue or pull request: who closed, reopened, labeled, assigned or referenced it and when, including the commits that referenced it and the issues and pull requests that cross-referenced it. Subscribed, unsubscribed and mentioned events are left out unless include_all is set, so a page can hold fewer events than requested; use has_next_page and next_page to page on.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_TIMELINE_USER_TITLE", "Get issue timeline"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithBoolean("include_all",
+				mcp.Description("Also return subscribed, unsubscribed and mentioned events"),
+				mcp.DefaultBool(false),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
 			if err != nil {
-				return nil, fmt.Errorf("failed to get issue comments: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeAll, err := OptionalParam[bool](request, "include_all")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			}
+			events, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, issueNumber, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get issue timeline", resp, err); !ok {
+				return result, err
+			}
+
+			timeline := make([]TimelineEvent, 0, len(events))
+			for _, event := range events {
+				if !includeAll && slices.Contains(noisyTimelineEvents, event.GetEvent()) {
+					continue
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue comments: %s", string(body))), nil
+				timeline = append(timeline, condenseTimelineEvent(event))
 			}
+			return MarshalledTextResult(IssueTimeline{
+				Events:       timeline,
+				NextPageInfo: nextPageInfo(resp),
+			}), nil
+		}
+}
 
-			r, err := json.Marshal(comments)
+// GetMilestoneProgress creates a tool to report how far along a milestone is.
+func GetMilestoneProgress(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_milestone_progress",
+			mcp.WithDescription(t("TOOL_GET_MILESTONE_PROGRESS_DESCRIPTION", "Get the progress of a milestone in a GitHub repository: its completion percentage from open and closed issue counts, how many days remain until its due date or whether it is overdue, and the titles of its open issues and, listed apart, of its open pull requests.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MILESTONE_PROGRESS_USER_TITLE", "Get milestone progress"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Required(),
+				mcp.Description("The number of the milestone"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestoneNumber, err := RequiredInt(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			milestone, resp, err := client.Issues.GetMilestone(ctx, owner, repo, milestoneNumber)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get milestone", resp, err); !ok {
+				return result, err
+			}
+
+			issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+				Milestone:   strconv.Itoa(milestoneNumber),
+				State:       "open",
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if result, ok, err := handleRESTResponse(ctx, "failed to list milestone issues", resp, err); !ok {
+				return result, err
+			}
+
+			progress := MilestoneProgress{
+				Number:           milestone.GetNumber(),
+				Title:            milestone.GetTitle(),
+				State:            milestone.GetState(),
+				URL:              milestone.GetHTMLURL(),
+				OpenIssues:       milestone.GetOpenIssues(),
+				ClosedIssues:     milestone.GetClosedIssues(),
+				PercentComplete:  milestoneCompletionPercent(milestone.GetOpenIssues(), milestone.GetClosedIssues()),
+				OpenIssueTitles:  []MilestoneIssue{},
+				OpenPullRequests: []MilestoneIssue{},
+			}
+			if milestone.DueOn != nil {
+				dueOn := milestone.GetDueOn().Time
+				progress.DueOn = &dueOn
+				daysUntilDue, overdue := milestoneDueStatus(dueOn, milestone.GetState(), time.Now())
+				progress.DaysUntilDue = &daysUntilDue
+				progress.Overdue = overdue
+			}
+			// The issues endpoint lists pull requests too
+			for _, issue := range issues {
+				entry := MilestoneIssue{
+					Number: issue.GetNumber(),
+					Title:  issue.GetTitle(),
+					URL:    issue.GetHTMLURL(),
+				}
+				if issue.IsPullRequest() {
+					progress.OpenPullRequests = append(progress.OpenPullRequests, entry)
+					continue
+				}
+				progress.OpenIssueTitles = append(progress.OpenIssueTitles, entry)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(progress), nil
 		}
 }
 
+// MilestoneProgress is the result of the get_milestone_progress tool. The counts come from the
+// milestone, which counts pull requests as issues. Open issues and open pull requests are listed
+// apart, and limited to the first page of open items in the milestone.
+type MilestoneProgress struct {
+	Number           int              `json:"number"`
+	Title            string           `json:"title"`
+	State            string           `json:"state"`
+	URL              string           `json:"url"`
+	OpenIssues       int              `json:"open_issues"`
+	ClosedIssues     int              `json:"closed_issues"`
+	PercentComplete  float64          `json:"percent_complete"`
+	DueOn            *time.Time       `json:"due_on,omitempty"`
+	DaysUntilDue     *int             `json:"days_until_due,omitempty"`
+	Overdue          bool             `json:"overdue"`
+	OpenIssueTitles  []MilestoneIssue `json:"open_issue_titles"`
+	OpenPullRequests []MilestoneIssue `json:"open_pull_requests"`
+}
+
+// MilestoneIssue is an open issue or pull request in a milestone.
+type MilestoneIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+}
+
+// milestoneCompletionPercent returns the percentage of a milestone's issues that are closed,
+// rounded to one decimal place. A milestone without issues is 0% complete.
+func milestoneCompletionPercent(openIssues, closedIssues int) float64 {
+	total := openIssues + closedIssues
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(closedIssues)/float64(total)*1000) / 10
+}
+
+// milestoneDueStatus returns the number of whole days from now until dueOn, which is negative once
+// the due date has passed, and whether the milestone is overdue. Closed milestones are never overdue.
+func milestoneDueStatus(dueOn time.Time, state string, now time.Time) (daysUntilDue int, overdue bool) {
+	daysUntilDue = int(math.Floor(dueOn.Sub(now).Hours() / 24))
+	overdue = state != "closed" && now.After(dueOn)
+	return daysUntilDue, overdue
+}
+
 // mvpDescription is an MVP idea for generating tool descriptions from structured data in a shared format.
 // It is not intended for widespread usage and is not a complete implementation.
 type mvpDescription struct {
@@ -1132,7 +1519,7 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 				Repo        string
 				IssueNumber int32
 			}
-			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+			if err := decodeParams(request, &params); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
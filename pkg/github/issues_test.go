@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,7 +22,7 @@ import (
 func Test_GetIssue(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := GetIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetIssue(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "get_issue", tool.Name)
@@ -29,6 +30,7 @@ func Test_GetIssue(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "include_context")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
 
 	// Setup mock issue for success case
@@ -41,30 +43,135 @@ func Test_GetIssue(t *testing.T) {
 		User: &github.User{
 			Login: github.Ptr("testuser"),
 		},
+		Reactions: &github.Reactions{
+			TotalCount: github.Ptr(9),
+			PlusOne:    github.Ptr(4),
+			Heart:      github.Ptr(2),
+			Rocket:     github.Ptr(2),
+			Eyes:       github.Ptr(1),
+		},
+	}
+
+	var contextQuery struct {
+		Repository struct {
+			Issue struct {
+				Participants struct {
+					TotalCount githubv4.Int
+				}
+				ClosedByPullRequestsReferences struct {
+					Nodes []struct {
+						Number githubv4.Int
+						Title  githubv4.String
+						URL    githubv4.String
+						State  githubv4.String
+					}
+				} `graphql:"closedByPullRequestsReferences(first: $first)"`
+			} `graphql:"issue(number: $issueNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	contextVars := map[string]any{
+		"owner":       githubv4.String("owner"),
+		"repo":        githubv4.String("repo"),
+		"issueNumber": githubv4.Int(42),
+		"first":       githubv4.Int(10),
+	}
+	expectedReactions := ReactionsSummary{
+		Total: 9,
+		Top: []ReactionCount{
+			{Content: "+1", Count: 4},
+			{Content: "heart", Count: 2},
+			{Content: "rocket", Count: 2},
+		},
 	}
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedIssue  *github.Issue
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		mockedGQLClient *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedIssue   *github.Issue
+		expectedContext *IssueContext
+		expectedWarning string
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful issue retrieval",
+			name: "successful issue retrieval with context",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatch(
 					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
 					mockIssue,
 				),
 			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(contextQuery, contextVars, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"issue": map[string]any{
+							"participants": map[string]any{"totalCount": 3},
+							"closedByPullRequestsReferences": map[string]any{
+								"nodes": []any{
+									map[string]any{
+										"number": 7,
+										"title":  "Fix the test issue",
+										"url":    "https://github.com/owner/repo/pull/7",
+										"state":  "OPEN",
+									},
+								},
+							},
+						},
+					},
+				})),
+			),
 			requestArgs: map[string]interface{}{
 				"owner":        "owner",
 				"repo":         "repo",
 				"issue_number": float64(42),
 			},
-			expectError:   false,
+			expectedIssue: mockIssue,
+			expectedContext: &IssueContext{
+				Reactions:        expectedReactions,
+				ParticipantCount: github.Ptr(3),
+				LinkedPullRequests: []ClosingPullRequest{
+					{Number: 7, Title: "Fix the test issue", URL: "https://github.com/owner/repo/pull/7", State: "OPEN"},
+				},
+			},
+		},
+		{
+			name: "context degrades to a warning when GraphQL fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockIssue,
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(contextQuery, contextVars, githubv4mock.ErrorResponse("Resource not accessible by integration")),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectedIssue: mockIssue,
+			expectedContext: &IssueContext{
+				Reactions: expectedReactions,
+			},
+			expectedWarning: "failed to get linked pull requests and participants: Resource not accessible by integration",
+		},
+		{
+			name: "successful issue retrieval without context",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockIssue,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"issue_number":    float64(42),
+				"include_context": false,
+			},
 			expectedIssue: mockIssue,
 		},
 		{
@@ -89,7 +196,8 @@ func Test_GetIssue(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+			gqlClient := githubv4.NewClient(tc.mockedGQLClient)
+			_, handler := GetIssue(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -99,8 +207,9 @@ func Test_GetIssue(t *testing.T) {
 
 			// Verify results
 			if tc.expectError {
-				require.Error(t, err)
-				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				require.NoError(t, err)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
@@ -108,7 +217,7 @@ func Test_GetIssue(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedIssue github.Issue
+			var returnedIssue IssueWithContext
 			err = json.Unmarshal([]byte(textContent.Text), &returnedIssue)
 			require.NoError(t, err)
 			assert.Equal(t, *tc.expectedIssue.Number, *returnedIssue.Number)
@@ -117,6 +226,17 @@ func Test_GetIssue(t *testing.T) {
 			assert.Equal(t, *tc.expectedIssue.State, *returnedIssue.State)
 			assert.Equal(t, *tc.expectedIssue.HTMLURL, *returnedIssue.HTMLURL)
 			assert.Equal(t, *tc.expectedIssue.User.Login, *returnedIssue.User.Login)
+
+			if tc.expectedContext == nil {
+				assert.NotContains(t, textContent.Text, `"context"`)
+				return
+			}
+			if tc.expectedWarning != "" {
+				require.Len(t, returnedIssue.Context.Warnings, 1)
+				assert.Contains(t, returnedIssue.Context.Warnings[0], tc.expectedWarning)
+				returnedIssue.Context.Warnings = nil
+			}
+			assert.Equal(t, *tc.expectedContext, returnedIssue.Context)
 		})
 	}
 }
@@ -158,7 +278,11 @@ func Test_AddIssueComment(t *testing.T) {
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
-					mockResponse(t, http.StatusCreated, mockComment),
+					expectRequestBody(t, map[string]any{
+						"body": "This is a test comment",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockComment),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
@@ -190,6 +314,40 @@ func Test_AddIssueComment(t *testing.T) {
 			expectError:    false,
 			expectedErrMsg: "missing required parameter: body",
 		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+				"body":         "This is a test comment",
+			},
+			expectError:    false,
+			expectedErrMsg: "failed to create comment",
+		},
+		{
+			name: "comment rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"body":         "This is a test comment",
+			},
+			expectError:    false,
+			expectedErrMsg: "Validation Failed",
+		},
 	}
 
 	for _, tc := range tests {
@@ -212,9 +370,9 @@ func Test_AddIssueComment(t *testing.T) {
 			}
 
 			if tc.expectedErrMsg != "" {
-				require.NotNil(t, result)
-				textContent := getTextResult(t, result)
-				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				require.NoError(t, err)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
@@ -230,7 +388,122 @@ func Test_AddIssueComment(t *testing.T) {
 			assert.Equal(t, *tc.expectedComment.ID, *returnedComment.ID)
 			assert.Equal(t, *tc.expectedComment.Body, *returnedComment.Body)
 			assert.Equal(t, *tc.expectedComment.User.Login, *returnedComment.User.Login)
+			assert.Equal(t, *tc.expectedComment.HTMLURL, *returnedComment.HTMLURL)
+		})
+	}
+}
+
+func Test_UpdateIssueComment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateIssueComment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_issue_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "comment_id")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "comment_id", "body"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	mockComment := &github.IssueComment{
+		ID:      github.Ptr(int64(123)),
+		Body:    github.Ptr("Updated comment"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/42#issuecomment-123"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectedComment *github.IssueComment
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful comment update",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesCommentsByOwnerByRepoByCommentId,
+					expect(t, expectations{
+						path:        "/repos/owner/repo/issues/comments/123",
+						requestBody: map[string]any{"body": "Updated comment"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockComment),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"comment_id": float64(123),
+				"body":       "Updated comment",
+			},
+			expectedComment: mockComment,
+		},
+		{
+			name: "comment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesCommentsByOwnerByRepoByCommentId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"comment_id": float64(999),
+				"body":       "Updated comment",
+			},
+			expectedErrMsg: "failed to update comment",
+		},
+		{
+			name: "comment rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesCommentsByOwnerByRepoByCommentId,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"comment_id": float64(123),
+				"body":       "Updated comment",
+			},
+			expectedErrMsg: "Validation Failed",
+		},
+		{
+			name:         "missing body",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"comment_id": float64(123),
+			},
+			expectedErrMsg: "missing required parameter: body",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateIssueComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
 
+			var returnedComment github.IssueComment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedComment))
+			assert.Equal(t, *tc.expectedComment.ID, *returnedComment.ID)
+			assert.Equal(t, *tc.expectedComment.Body, *returnedComment.Body)
+			assert.Equal(t, *tc.expectedComment.HTMLURL, *returnedComment.HTMLURL)
 		})
 	}
 }
@@ -250,6 +523,10 @@ func Test_SearchIssues(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "order")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "labels")
+	assert.Contains(t, tool.InputSchema.Properties, "assignee")
+	assert.Contains(t, tool.InputSchema.Properties, "is_pr")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
 
 	// Setup mock search results
@@ -410,6 +687,103 @@ func Test_SearchIssues(t *testing.T) {
 			expectError:    false,
 			expectedResult: mockSearchResult,
 		},
+		{
+			name: "issues search with qualifier parameters and pagination",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(
+						t,
+						map[string]string{
+							"q":        `repo:owner/repo is:issue crash state:open label:bug label:"help wanted" assignee:@me`,
+							"page":     "3",
+							"per_page": "50",
+						},
+					).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query":    "crash",
+				"owner":    "owner",
+				"repo":     "repo",
+				"state":    "open",
+				"labels":   []any{"bug", "help wanted"},
+				"assignee": "@me",
+				"page":     float64(3),
+				"perPage":  float64(50),
+			},
+			expectError:    false,
+			expectedResult: mockSearchResult,
+		},
+		{
+			name: "issues search for pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(
+						t,
+						map[string]string{
+							"q":        "is:pr flaky test state:closed",
+							"page":     "1",
+							"per_page": "30",
+						},
+					).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query": "flaky test",
+				"state": "closed",
+				"is_pr": true,
+			},
+			expectError:    false,
+			expectedResult: mockSearchResult,
+		},
+		{
+			name:         "query too long",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"query": strings.Repeat("a", 257),
+			},
+			expectError:    true,
+			expectedErrMsg: "the query is 266 characters long with its qualifiers, but GitHub accepts at most 256",
+		},
+		{
+			name:         "query too long once qualifiers are added",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"query":  strings.Repeat("a", 230),
+				"owner":  "owner",
+				"repo":   "repo",
+				"state":  "open",
+				"labels": []any{"bug"},
+			},
+			expectError:    true,
+			expectedErrMsg: "the query is 276 characters long with its qualifiers, but GitHub accepts at most 256",
+		},
+		{
+			name: "search issues rate limited",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("X-RateLimit-Limit", "30")
+						w.Header().Set("X-RateLimit-Remaining", "0")
+						w.Header().Set("X-RateLimit-Reset", "1893456000")
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "API rate limit exceeded for user ID 1."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query": "bug",
+			},
+			expectError:    true,
+			expectedErrMsg: "API rate limit exceeded",
+		},
 		{
 			name: "search issues fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -443,8 +817,8 @@ func Test_SearchIssues(t *testing.T) {
 
 			// Verify results
 			if tc.expectError {
-				require.Error(t, err)
-				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				require.NoError(t, err)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
 				return
 			}
 
@@ -541,12 +915,16 @@ func Test_CreateIssue(t *testing.T) {
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.PostReposIssuesByOwnerByRepo,
-					mockResponse(t, http.StatusCreated, &github.Issue{
-						Number:  github.Ptr(124),
-						Title:   github.Ptr("Minimal Issue"),
-						HTMLURL: github.Ptr("https://github.com/owner/repo/issues/124"),
-						State:   github.Ptr("open"),
-					}),
+					expectRequestBody(t, map[string]any{
+						"title": "Minimal Issue",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Issue{
+							Number:  github.Ptr(124),
+							Title:   github.Ptr("Minimal Issue"),
+							HTMLURL: github.Ptr("https://github.com/owner/repo/issues/124"),
+							State:   github.Ptr("open"),
+						}),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
@@ -837,6 +1215,7 @@ func Test_UpdateIssue(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "title")
 	assert.Contains(t, tool.InputSchema.Properties, "body")
 	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "state_reason")
 	assert.Contains(t, tool.InputSchema.Properties, "labels")
 	assert.Contains(t, tool.InputSchema.Properties, "assignees")
 	assert.Contains(t, tool.InputSchema.Properties, "milestone")
@@ -898,12 +1277,16 @@ func Test_UpdateIssue(t *testing.T) {
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
-					mockResponse(t, http.StatusOK, &github.Issue{
-						Number:  github.Ptr(123),
-						Title:   github.Ptr("Only Title Updated"),
-						HTMLURL: github.Ptr("https://github.com/owner/repo/issues/123"),
-						State:   github.Ptr("open"),
-					}),
+					expectRequestBody(t, map[string]any{
+						"title": "Only Title Updated",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{
+							Number:  github.Ptr(123),
+							Title:   github.Ptr("Only Title Updated"),
+							HTMLURL: github.Ptr("https://github.com/owner/repo/issues/123"),
+							State:   github.Ptr("open"),
+						}),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
@@ -921,21 +1304,100 @@ func Test_UpdateIssue(t *testing.T) {
 			},
 		},
 		{
-			name: "update issue fails with not found",
+			name: "close issue as not planned and clear its labels",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Issue not found"}`))
-					}),
+					expectRequestBody(t, map[string]any{
+						"state":        "closed",
+						"state_reason": "not_planned",
+						"labels":       []any{},
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{
+							Number:  github.Ptr(123),
+							Title:   github.Ptr("Stale idea"),
+							HTMLURL: github.Ptr("https://github.com/owner/repo/issues/123"),
+							State:   github.Ptr("closed"),
+						}),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner":        "owner",
 				"repo":         "repo",
-				"issue_number": float64(999),
-				"title":        "This issue doesn't exist",
+				"issue_number": float64(123),
+				"state":        "closed",
+				"state_reason": "not_planned",
+				"labels":       []any{},
+			},
+			expectError: false,
+			expectedIssue: &github.Issue{
+				Number:  github.Ptr(123),
+				Title:   github.Ptr("Stale idea"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/issues/123"),
+				State:   github.Ptr("closed"),
+			},
+		},
+		{
+			name: "remove the milestone of an issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"title":     "No milestone",
+						"milestone": nil,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{
+							Number:  github.Ptr(123),
+							Title:   github.Ptr("No milestone"),
+							HTMLURL: github.Ptr("https://github.com/owner/repo/issues/123"),
+							State:   github.Ptr("open"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(123),
+				"title":        "No milestone",
+				"milestone":    float64(0),
+			},
+			expectError: false,
+			expectedIssue: &github.Issue{
+				Number:  github.Ptr(123),
+				Title:   github.Ptr("No milestone"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/issues/123"),
+				State:   github.Ptr("open"),
+			},
+		},
+		{
+			name:         "update issue without update fields",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(123),
+			},
+			expectError:    true,
+			expectedErrMsg: "No update parameters provided.",
+		},
+		{
+			name: "update issue fails with not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Issue not found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+				"title":        "This issue doesn't exist",
 			},
 			expectError:    true,
 			expectedErrMsg: "failed to update issue",
@@ -1031,6 +1493,151 @@ func Test_UpdateIssue(t *testing.T) {
 	}
 }
 
+func Test_AddAssignees(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddAssignees(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_assignees", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "assignees")
+	assert.Contains(t, tool.InputSchema.Properties, "remove")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "assignees"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expected       IssueAssignees
+		expectedErrMsg string
+	}{
+		{
+			name: "adds assignees",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"assignees": []any{"octocat", "hubot"}}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Issue{
+							Number: github.Ptr(42),
+							Assignees: []*github.User{
+								{Login: github.Ptr("monalisa")},
+								{Login: github.Ptr("octocat")},
+								{Login: github.Ptr("hubot")},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    []any{"octocat", "hubot"},
+			},
+			expected: IssueAssignees{Number: 42, Assignees: []string{"monalisa", "octocat", "hubot"}},
+		},
+		{
+			name: "removes assignees",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{"assignees": []any{"octocat"}}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{
+							Number:    github.Ptr(42),
+							Assignees: []*github.User{},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    []any{"octocat"},
+				"remove":       true,
+			},
+			expected: IssueAssignees{Number: 42, Assignees: []string{}},
+		},
+		{
+			name:         "requires at least one assignee",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    []any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "assignees must contain at least one login",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+				"assignees":    []any{"octocat"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add assignees",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddAssignees(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var assignees IssueAssignees
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &assignees))
+			assert.Equal(t, tc.expected, assignees)
+		})
+	}
+}
+
+func Test_AssigneeAndReviewerToolsAreNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+
+		var names []string
+		for _, toolset := range []string{"issues", "pull_requests"} {
+			ts, err := tsg.GetToolset(toolset)
+			require.NoError(t, err)
+			for _, st := range ts.GetAvailableTools() {
+				names = append(names, st.Tool.Name)
+			}
+		}
+		if readOnly {
+			assert.NotContains(t, names, "add_assignees")
+			assert.NotContains(t, names, "request_pull_request_reviewers")
+		} else {
+			assert.Contains(t, names, "add_assignees")
+			assert.Contains(t, names, "request_pull_request_reviewers")
+		}
+	}
+}
+
 func Test_ParseISOTimestamp(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1092,6 +1699,7 @@ func Test_GetIssueComments(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
@@ -1163,6 +1771,54 @@ func Test_GetIssueComments(t *testing.T) {
 			expectError:      false,
 			expectedComments: mockComments,
 		},
+		{
+			name: "successful comments retrieval since a date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{
+						"since":    "2025-04-01T00:00:00Z",
+						"page":     "1",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockComments),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"since":        "2025-04-01",
+				"perPage":      float64(100),
+			},
+			expectError:      false,
+			expectedComments: mockComments,
+		},
+		{
+			name:         "invalid since",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"since":        "last tuesday",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue comments: invalid ISO 8601 timestamp",
+		},
+		{
+			name:         "perPage above 100",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"perPage":      float64(101),
+			},
+			expectError:    true,
+			expectedErrMsg: "perPage must be between 1 and 100, got 101",
+		},
 		{
 			name: "issue not found",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1195,8 +1851,8 @@ func Test_GetIssueComments(t *testing.T) {
 
 			// Verify results
 			if tc.expectError {
-				require.Error(t, err)
-				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				require.NoError(t, err)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
 				return
 			}
 
@@ -1216,6 +1872,193 @@ func Test_GetIssueComments(t *testing.T) {
 	}
 }
 
+func Test_GetIssueTimeline(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueTimeline(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_issue_timeline", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "include_all")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	closedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	referencedAt := time.Date(2024, 4, 30, 9, 30, 0, 0, time.UTC)
+	mockTimeline := []*github.Timeline{
+		{
+			Event:     github.Ptr("mentioned"),
+			Actor:     &github.User{Login: github.Ptr("octocat")},
+			CreatedAt: &github.Timestamp{Time: referencedAt},
+		},
+		{
+			Event:     github.Ptr("subscribed"),
+			Actor:     &github.User{Login: github.Ptr("octocat")},
+			CreatedAt: &github.Timestamp{Time: referencedAt},
+		},
+		{
+			Event:     github.Ptr("cross-referenced"),
+			Actor:     &github.User{Login: github.Ptr("hubot")},
+			CreatedAt: &github.Timestamp{Time: referencedAt},
+			Source: &github.Source{
+				Type: github.Ptr("issue"),
+				Issue: &github.Issue{
+					Number:           github.Ptr(7),
+					RepositoryURL:    github.Ptr("https://api.github.com/repos/other/project"),
+					PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/other/project/pulls/7")},
+				},
+			},
+		},
+		{
+			Event:     github.Ptr("closed"),
+			Actor:     &github.User{Login: github.Ptr("hubot")},
+			CreatedAt: &github.Timestamp{Time: closedAt},
+			CommitID:  github.Ptr("abc123"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedEvents []TimelineEvent
+		expectedPage   NextPageInfo
+		expectedErrMsg string
+	}{
+		{
+			name: "condenses events and leaves out noisy ones",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber, mockTimeline),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectedEvents: []TimelineEvent{
+				{
+					Event:     "cross-referenced",
+					Actor:     "hubot",
+					CreatedAt: &referencedAt,
+					Source:    &TimelineEventSource{Repository: "other/project", Number: 7, PullRequest: true},
+				},
+				{Event: "closed", Actor: "hubot", CreatedAt: &closedAt, CommitID: "abc123"},
+			},
+		},
+		{
+			name: "include_all keeps noisy events and pagination is passed on",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "2",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/issues/42/timeline?page=3&per_page=2>; rel="next"`)
+							w.WriteHeader(http.StatusOK)
+							b, _ := json.Marshal(mockTimeline[:2])
+							_, _ = w.Write(b)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"include_all":  true,
+				"page":         float64(2),
+				"perPage":      float64(2),
+			},
+			expectedEvents: []TimelineEvent{
+				{Event: "mentioned", Actor: "octocat", CreatedAt: &referencedAt},
+				{Event: "subscribed", Actor: "octocat", CreatedAt: &referencedAt},
+			},
+			expectedPage: NextPageInfo{HasNextPage: true, NextPage: 3},
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue timeline",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetIssueTimeline(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var timeline IssueTimeline
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &timeline))
+			assert.Equal(t, tc.expectedPage, timeline.NextPageInfo)
+			events := timeline.Events
+			require.Len(t, events, len(tc.expectedEvents))
+			for i, expected := range tc.expectedEvents {
+				assert.Equal(t, expected.Event, events[i].Event)
+				assert.Equal(t, expected.Actor, events[i].Actor)
+				assert.Equal(t, expected.CommitID, events[i].CommitID)
+				assert.Equal(t, expected.Source, events[i].Source)
+				require.NotNil(t, events[i].CreatedAt)
+				assert.True(t, expected.CreatedAt.Equal(*events[i].CreatedAt))
+			}
+		})
+	}
+}
+
+func Test_CondenseTimelineEvent(t *testing.T) {
+	submittedAt := time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)
+
+	// Reviews have a user and a submission time instead of an actor and a creation time
+	review := condenseTimelineEvent(&github.Timeline{
+		Event:       github.Ptr("reviewed"),
+		User:        &github.User{Login: github.Ptr("reviewer")},
+		SubmittedAt: &github.Timestamp{Time: submittedAt},
+	})
+	assert.Equal(t, "reviewer", review.Actor)
+	require.NotNil(t, review.CreatedAt)
+	assert.True(t, submittedAt.Equal(*review.CreatedAt))
+
+	// The source repository is taken from the issue's repository when present
+	crossReference := condenseTimelineEvent(&github.Timeline{
+		Event: github.Ptr("cross-referenced"),
+		Source: &github.Source{
+			Issue: &github.Issue{
+				Number:     github.Ptr(3),
+				Repository: &github.Repository{FullName: github.Ptr("owner/repo")},
+			},
+		},
+	})
+	assert.Equal(t, &TimelineEventSource{Repository: "owner/repo", Number: 3}, crossReference.Source)
+	assert.Nil(t, crossReference.CreatedAt)
+}
+
 func TestAssignCopilotToIssue(t *testing.T) {
 	t.Parallel()
 
@@ -1636,6 +2479,220 @@ func TestAssignCopilotToIssue(t *testing.T) {
 	}
 }
 
+func Test_GetMilestoneProgress(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMilestoneProgress(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_milestone_progress", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_milestone_progress tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "milestone_number")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone_number"})
+
+	mockMilestone := &github.Milestone{
+		Number:       github.Ptr(3),
+		Title:        github.Ptr("v2.0"),
+		State:        github.Ptr("open"),
+		HTMLURL:      github.Ptr("https://github.com/owner/repo/milestone/3"),
+		OpenIssues:   github.Ptr(2),
+		ClosedIssues: github.Ptr(6),
+		DueOn:        &github.Timestamp{Time: time.Now().Add(-72 * time.Hour)},
+	}
+	mockIssues := []*github.Issue{
+		{Number: github.Ptr(10), Title: github.Ptr("Finish the docs"), HTMLURL: github.Ptr("https://github.com/owner/repo/issues/10")},
+		{Number: github.Ptr(11), Title: github.Ptr("Fix the flaky test"), HTMLURL: github.Ptr("https://github.com/owner/repo/issues/11")},
+		{
+			Number:           github.Ptr(12),
+			Title:            github.Ptr("Add the v2 API"),
+			HTMLURL:          github.Ptr("https://github.com/owner/repo/pull/12"),
+			PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/12")},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedProgress MilestoneProgress
+	}{
+		{
+			name: "overdue milestone with open issues",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposMilestonesByOwnerByRepoByMilestoneNumber,
+					expectPath(t, "/repos/owner/repo/milestones/3").andThen(
+						mockResponse(t, http.StatusOK, mockMilestone),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"milestone": "3",
+						"state":     "open",
+						"per_page":  "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockIssues),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(3),
+			},
+			expectedProgress: MilestoneProgress{
+				Number:          3,
+				Title:           "v2.0",
+				State:           "open",
+				URL:             "https://github.com/owner/repo/milestone/3",
+				OpenIssues:      2,
+				ClosedIssues:    6,
+				PercentComplete: 75,
+				Overdue:         true,
+				OpenIssueTitles: []MilestoneIssue{
+					{Number: 10, Title: "Finish the docs", URL: "https://github.com/owner/repo/issues/10"},
+					{Number: 11, Title: "Fix the flaky test", URL: "https://github.com/owner/repo/issues/11"},
+				},
+				OpenPullRequests: []MilestoneIssue{
+					{Number: 12, Title: "Add the v2 API", URL: "https://github.com/owner/repo/pull/12"},
+				},
+			},
+		},
+		{
+			name: "milestone not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposMilestonesByOwnerByRepoByMilestoneNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(99),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get milestone",
+		},
+		{
+			name: "listing milestone issues fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposMilestonesByOwnerByRepoByMilestoneNumber,
+					mockMilestone,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(3),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list milestone issues",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetMilestoneProgress(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			// Verify results
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned MilestoneProgress
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+
+			// The due date delta depends on the current time, so it is checked loosely
+			require.NotNil(t, returned.DueOn)
+			require.NotNil(t, returned.DaysUntilDue)
+			assert.Negative(t, *returned.DaysUntilDue)
+			returned.DueOn, returned.DaysUntilDue = nil, nil
+			assert.Equal(t, tc.expectedProgress, returned)
+		})
+	}
+}
+
+func Test_MilestoneCompletionPercent(t *testing.T) {
+	tests := []struct {
+		name         string
+		openIssues   int
+		closedIssues int
+		expected     float64
+	}{
+		{name: "no issues", openIssues: 0, closedIssues: 0, expected: 0},
+		{name: "nothing closed", openIssues: 4, closedIssues: 0, expected: 0},
+		{name: "everything closed", openIssues: 0, closedIssues: 4, expected: 100},
+		{name: "three quarters closed", openIssues: 1, closedIssues: 3, expected: 75},
+		{name: "rounded to one decimal place", openIssues: 2, closedIssues: 1, expected: 33.3},
+		{name: "rounded up", openIssues: 1, closedIssues: 2, expected: 66.7},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, milestoneCompletionPercent(tc.openIssues, tc.closedIssues))
+		})
+	}
+}
+
+func Test_MilestoneDueStatus(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		dueOn                time.Time
+		state                string
+		expectedDaysUntilDue int
+		expectedOverdue      bool
+	}{
+		{name: "due in a week", dueOn: now.Add(7 * 24 * time.Hour), state: "open", expectedDaysUntilDue: 7, expectedOverdue: false},
+		{name: "due later today", dueOn: now.Add(6 * time.Hour), state: "open", expectedDaysUntilDue: 0, expectedOverdue: false},
+		{name: "due earlier today", dueOn: now.Add(-6 * time.Hour), state: "open", expectedDaysUntilDue: -1, expectedOverdue: true},
+		{name: "three days overdue", dueOn: now.Add(-3 * 24 * time.Hour), state: "open", expectedDaysUntilDue: -3, expectedOverdue: true},
+		{name: "closed past due date", dueOn: now.Add(-3 * 24 * time.Hour), state: "closed", expectedDaysUntilDue: -3, expectedOverdue: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			daysUntilDue, overdue := milestoneDueStatus(tc.dueOn, tc.state, now)
+			assert.Equal(t, tc.expectedDaysUntilDue, daysUntilDue)
+			assert.Equal(t, tc.expectedOverdue, overdue)
+		})
+	}
+}
+
 func Test_AddSubIssue(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -0,0 +1,381 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListLabels(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListLabels(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_labels", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockLabels := []*github.Label{
+		{
+			ID:          github.Ptr(int64(1)),
+			Name:        github.Ptr("bug"),
+			Color:       github.Ptr("d73a4a"),
+			Description: github.Ptr("Something isn't working"),
+			URL:         github.Ptr("https://api.github.com/repos/owner/repo/labels/bug"),
+		},
+		{
+			ID:    github.Ptr(int64(2)),
+			Name:  github.Ptr("triage"),
+			Color: github.Ptr("ededed"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedLabels []Label
+		expectedErrMsg string
+	}{
+		{
+			name: "lists labels with pagination",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLabelsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "50",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockLabels),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(50),
+			},
+			expectedLabels: []Label{
+				{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+				{Name: "triage", Color: "ededed"},
+			},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLabelsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list labels",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var labels []Label
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &labels))
+			assert.Equal(t, tc.expectedLabels, labels)
+			assert.NotContains(t, textContent.Text, "api.github.com")
+		})
+	}
+}
+
+func Test_CreateLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "color")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "color"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedLabel  Label
+		expectedErrMsg string
+	}{
+		{
+			name: "creates label",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name":        "needs-triage",
+						"color":       "FBCA04",
+						"description": "Waiting for a maintainer",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Label{
+							ID:          github.Ptr(int64(3)),
+							Name:        github.Ptr("needs-triage"),
+							Color:       github.Ptr("FBCA04"),
+							Description: github.Ptr("Waiting for a maintainer"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "needs-triage",
+				"color":       "FBCA04",
+				"description": "Waiting for a maintainer",
+			},
+			expectedLabel: Label{Name: "needs-triage", Color: "FBCA04", Description: "Waiting for a maintainer"},
+		},
+		{
+			name: "omits description when not given",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name":  "docs",
+						"color": "0075ca",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Label{
+							Name:  github.Ptr("docs"),
+							Color: github.Ptr("0075ca"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "docs",
+				"color": "0075ca",
+			},
+			expectedLabel: Label{Name: "docs", Color: "0075ca"},
+		},
+		{
+			name:         "rejects color with leading hash",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "bug",
+				"color": "#d73a4a",
+			},
+			expectError:    true,
+			expectedErrMsg: `color must be six hexadecimal digits without a leading '#', got "#d73a4a"`,
+		},
+		{
+			name:         "rejects color that is not hexadecimal",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "bug",
+				"color": "red",
+			},
+			expectError:    true,
+			expectedErrMsg: "color must be six hexadecimal digits",
+		},
+		{
+			name: "label already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed", "errors": [{"resource": "Label", "code": "already_exists", "field": "name"}]}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "bug",
+				"color": "d73a4a",
+			},
+			expectError:    true,
+			expectedErrMsg: "already_exists",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				if tc.expectedErrMsg == "already_exists" {
+					assert.Contains(t, errorContent.Text, "failed to create label")
+					assert.Contains(t, errorContent.Text, "Validation Failed")
+				}
+				return
+			}
+
+			var label Label
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &label))
+			assert.Equal(t, tc.expectedLabel, label)
+		})
+	}
+}
+
+func Test_AddLabelsToIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddLabelsToIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_labels_to_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "labels")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "labels"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedLabels []Label
+		expectedErrMsg string
+	}{
+		{
+			name: "adds labels and returns all labels of the issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, []any{"bug", "triage"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Label{
+							{Name: github.Ptr("enhancement"), Color: github.Ptr("a2eeef")},
+							{Name: github.Ptr("bug"), Color: github.Ptr("d73a4a")},
+							{Name: github.Ptr("triage"), Color: github.Ptr("ededed")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"labels":       []any{"bug", "triage"},
+			},
+			expectedLabels: []Label{
+				{Name: "enhancement", Color: "a2eeef"},
+				{Name: "bug", Color: "d73a4a"},
+				{Name: "triage", Color: "ededed"},
+			},
+		},
+		{
+			name:         "requires at least one label",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"labels":       []any{},
+			},
+			expectError:    true,
+			expectedErrMsg: "labels must contain at least one label",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+				"labels":       []any{"bug"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add labels to issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddLabelsToIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var labels []Label
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &labels))
+			assert.Equal(t, tc.expectedLabels, labels)
+		})
+	}
+}
+
+func Test_LabelWriteToolsAreNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		issues, err := tsg.GetToolset("issues")
+		require.NoError(t, err)
+
+		var names []string
+		for _, st := range issues.GetAvailableTools() {
+			names = append(names, st.Tool.Name)
+		}
+		assert.Contains(t, names, "list_labels")
+		if readOnly {
+			assert.NotContains(t, names, "create_label")
+			assert.NotContains(t, names, "add_labels_to_issue")
+		} else {
+			assert.Contains(t, names, "create_label")
+			assert.Contains(t, names, "add_labels_to_issue")
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v73/github"
+)
+
+const (
+	// lfsPointerVersion is the first line of every Git LFS pointer file.
+	lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+	// lfsPointerMaxSize is the largest file that can be an LFS pointer, as in git-lfs itself.
+	lfsPointerMaxSize = 1024
+	// lfsMediaType is the media type the LFS batch API speaks.
+	lfsMediaType = "application/vnd.git-lfs+json"
+)
+
+// parseLFSPointer parses a Git LFS pointer file, returning the SHA-256 object ID and the size of the
+// content it stands for. ok is false if body is not a pointer.
+func parseLFSPointer(body []byte) (oid string, size int64, ok bool) {
+	if len(body) > lfsPointerMaxSize {
+		return "", 0, false
+	}
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+	if len(lines) < 3 || lines[0] != lfsPointerVersion {
+		return "", 0, false
+	}
+
+	size = -1
+	for _, line := range lines[1:] {
+		key, value, found := strings.Cut(line, " ")
+		if !found {
+			return "", 0, false
+		}
+		switch key {
+		case "oid":
+			hash, found := strings.CutPrefix(value, "sha256:")
+			if !found || len(hash) != 64 || strings.Trim(hash, "0123456789abcdef") != "" {
+				return "", 0, false
+			}
+			oid = hash
+		case "size":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || parsed < 0 {
+				return "", 0, false
+			}
+			size = parsed
+		}
+	}
+	if oid == "" || size < 0 {
+		return "", 0, false
+	}
+	return oid, size, true
+}
+
+// LFSPointerContent describes a file stored with Git LFS, whose repository content is only a pointer.
+type LFSPointerContent struct {
+	Type        string `json:"type"`
+	Path        string `json:"path"`
+	SHA         string `json:"sha"`
+	LFSPointer  bool   `json:"lfs_pointer"`
+	OID         string `json:"oid"`
+	Size        int64  `json:"size"`
+	DownloadURL string `json:"download_url,omitempty"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+	Note        string `json:"note"`
+}
+
+func newLFSPointerContent(path, sha, oid string, size int64) LFSPointerContent {
+	return LFSPointerContent{
+		Type:       "lfs_pointer",
+		Path:       path,
+		SHA:        sha,
+		LFSPointer: true,
+		OID:        oid,
+		Size:       size,
+		Note:       "This file is stored with Git LFS and the repository only holds a pointer to it. Downloading its content requires a media download URL; call get_file_contents again with resolve_lfs set to true to get one.",
+	}
+}
+
+// lfsBatchRequest is the body of a request to the Git LFS batch API.
+type lfsBatchRequest struct {
+	Operation string      `json:"operation"`
+	Transfers []string    `json:"transfers"`
+	Objects   []lfsObject `json:"objects"`
+}
+
+type lfsObject struct {
+	OID     string `json:"oid"`
+	Size    int64  `json:"size"`
+	Actions *struct {
+		Download *struct {
+			Href      string `json:"href"`
+			ExpiresAt string `json:"expires_at,omitempty"`
+		} `json:"download,omitempty"`
+	} `json:"actions,omitempty"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsObject `json:"objects"`
+}
+
+// lfsBatchURL returns the LFS batch API endpoint of a repository. The API is served from the web host
+// rather than the REST API host, so it is derived from the client's base URL: api.github.com and
+// api.<tenant>.ghe.com drop their "api." prefix, and GHES drops its /api/v3/ path.
+func lfsBatchURL(client *github.Client, owner, repo string) string {
+	u := url.URL{
+		Scheme: client.BaseURL.Scheme,
+		Host:   strings.TrimPrefix(client.BaseURL.Host, "api."),
+		Path:   fmt.Sprintf("/%s/%s.git/info/lfs/objects/batch", owner, repo),
+	}
+	return u.String()
+}
+
+// resolveLFSDownload asks the LFS batch API for the download URL of an object.
+func resolveLFSDownload(ctx context.Context, client *github.Client, owner, repo, oid string, size int64) (href, expiresAt string, err error) {
+	body := lfsBatchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   []lfsObject{{OID: oid, Size: size}},
+	}
+	// go-github does not wrap the LFS batch endpoint
+	req, err := client.NewRequest(http.MethodPost, lfsBatchURL(client, owner, repo), body)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create LFS batch request: %w", err)
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+
+	var batch lfsBatchResponse
+	resp, err := client.Do(ctx, req, &batch)
+	closeResponseBody(resp)
+	if err != nil {
+		return "", "", fmt.Errorf("LFS batch request failed: %w", err)
+	}
+
+	for _, object := range batch.Objects {
+		if object.OID != oid {
+			continue
+		}
+		if object.Error != nil {
+			return "", "", fmt.Errorf("LFS object unavailable: %s (code %d)", object.Error.Message, object.Error.Code)
+		}
+		if object.Actions == nil || object.Actions.Download == nil || object.Actions.Download.Href == "" {
+			return "", "", fmt.Errorf("LFS batch response has no download action for %s", oid)
+		}
+		return object.Actions.Download.Href, object.Actions.Download.ExpiresAt, nil
+	}
+	return "", "", fmt.Errorf("LFS batch response does not include object %s", oid)
+}
@@ -0,0 +1,115 @@
+package github
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseLFSPointer(t *testing.T) {
+	const oid = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+
+	tests := []struct {
+		name         string
+		body         string
+		expectOK     bool
+		expectedSize int64
+	}{
+		{
+			name:         "valid pointer",
+			body:         "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 12345\n",
+			expectOK:     true,
+			expectedSize: 12345,
+		},
+		{
+			name:         "valid pointer without trailing newline",
+			body:         "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 0",
+			expectOK:     true,
+			expectedSize: 0,
+		},
+		{
+			name:         "extension keys are ignored",
+			body:         "version https://git-lfs.github.com/spec/v1\next-0-foo sha256:" + oid + "\noid sha256:" + oid + "\nsize 7\n",
+			expectOK:     true,
+			expectedSize: 7,
+		},
+		{
+			name: "regular text file",
+			body: "# Test Repository\n\nThis is a test repository.",
+		},
+		{
+			name: "unknown version",
+			body: "version https://example.com/spec/v2\noid sha256:" + oid + "\nsize 1\n",
+		},
+		{
+			name: "unsupported hash algorithm",
+			body: "version https://git-lfs.github.com/spec/v1\noid sha1:" + oid[:40] + "\nsize 1\n",
+		},
+		{
+			name: "malformed oid",
+			body: "version https://git-lfs.github.com/spec/v1\noid sha256:" + strings.ToUpper(oid) + "\nsize 1\n",
+		},
+		{
+			name: "missing size",
+			body: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\n",
+		},
+		{
+			name: "negative size",
+			body: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize -1\n",
+		},
+		{
+			name: "too large to be a pointer",
+			body: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 1\n" + strings.Repeat("x", lfsPointerMaxSize),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotOID, gotSize, ok := parseLFSPointer([]byte(tc.body))
+			assert.Equal(t, tc.expectOK, ok)
+			if !tc.expectOK {
+				return
+			}
+			assert.Equal(t, oid, gotOID)
+			assert.Equal(t, tc.expectedSize, gotSize)
+		})
+	}
+}
+
+func Test_LFSBatchURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		expected string
+	}{
+		{
+			name:     "dotcom",
+			baseURL:  "https://api.github.com/",
+			expected: "https://github.com/owner/repo.git/info/lfs/objects/batch",
+		},
+		{
+			name:     "GHEC",
+			baseURL:  "https://api.octocorp.ghe.com/",
+			expected: "https://octocorp.ghe.com/owner/repo.git/info/lfs/objects/batch",
+		},
+		{
+			name:     "GHES",
+			baseURL:  "https://github.example.com/api/v3/",
+			expected: "https://github.example.com/owner/repo.git/info/lfs/objects/batch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(nil)
+			baseURL, err := url.Parse(tc.baseURL)
+			assert.NoError(t, err)
+			client.BaseURL = baseURL
+
+			assert.Equal(t, tc.expected, lfsBatchURL(client, "owner", "repo"))
+		})
+	}
+}
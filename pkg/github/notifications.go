@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
@@ -25,7 +27,7 @@ const (
 // ListNotifications creates a tool to list notifications for the current user.
 func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_notifications",
-			mcp.WithDescription(t("TOOL_LIST_NOTIFICATIONS_DESCRIPTION", "Lists all GitHub notifications for the authenticated user, including unread notifications, mentions, review requests, assignments, and updates on issues or pull requests. Use this tool whenever the user asks what to work on next, requests a summary of their GitHub activity, wants to see pending reviews, or needs to check for new updates or tasks. This tool is the primary way to discover actionable items, reminders, and outstanding work on GitHub. Always call this tool when asked what to work on next, what is pending, or what needs attention in GitHub.")),
+			mcp.WithDescription(t("TOOL_LIST_NOTIFICATIONS_DESCRIPTION", "Lists all GitHub notifications for the authenticated user, including unread notifications, mentions, review requests, assignments, and updates on issues or pull requests. Use this tool whenever the user asks what to work on next, requests a summary of their GitHub activity, wants to see pending reviews, or needs to check for new updates or tasks. This tool is the primary way to discover actionable items, reminders, and outstanding work on GitHub. Always call this tool when asked what to work on next, what is pending, or what needs attention in GitHub. Each notification names the number and web URL of the issue, pull request or commit it is about.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_NOTIFICATIONS_USER_TITLE", "List notifications"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -118,31 +120,98 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 			} else {
 				notifications, resp, err = client.Activity.ListNotifications(ctx, opts)
 			}
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to list notifications",
-					resp,
-					err,
-				), nil
+			if result, ok, err := handleRESTResponse(ctx, "failed to list notifications", resp, err); !ok {
+				return result, err
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get notifications: %s", string(body))), nil
+			result := make([]Notification, 0, len(notifications))
+			for _, notification := range notifications {
+				result = append(result, newNotification(notification))
 			}
+			return MarshalledTextResult(result), nil
+		}
+}
 
-			// Marshal response to JSON
-			r, err := json.Marshal(notifications)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+// Notification is the condensed view of a notification thread returned by list_notifications.
+type Notification struct {
+	ID         string              `json:"id"`
+	Reason     string              `json:"reason"`
+	Unread     bool                `json:"unread"`
+	UpdatedAt  string              `json:"updated_at,omitempty"`
+	Repository string              `json:"repository"`
+	Subject    NotificationSubject `json:"subject"`
+}
 
-			return mcp.NewToolResultText(string(r)), nil
+// NotificationSubject is the issue, pull request, commit or release a notification is about. The
+// API URL of the subject is translated into the number or SHA and the web URL, so that the subject
+// can be passed to other tools or shown to the user.
+type NotificationSubject struct {
+	Title   string `json:"title"`
+	Type    string `json:"type"`
+	Number  int    `json:"number,omitempty"`
+	SHA     string `json:"sha,omitempty"`
+	URL     string `json:"url,omitempty"`
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+func newNotification(notification *github.Notification) Notification {
+	result := Notification{
+		ID:         notification.GetID(),
+		Reason:     notification.GetReason(),
+		Unread:     notification.GetUnread(),
+		Repository: notification.GetRepository().GetFullName(),
+		Subject: NotificationSubject{
+			Title: notification.GetSubject().GetTitle(),
+			Type:  notification.GetSubject().GetType(),
+			URL:   notification.GetSubject().GetURL(),
+		},
+	}
+	if notification.UpdatedAt != nil {
+		result.UpdatedAt = notification.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	result.Subject.HTMLURL, result.Subject.Number, result.Subject.SHA = subjectTarget(result.Subject.URL)
+	return result
+}
+
+// subjectTarget translates the REST API URL of a notification subject, such as
+// https://api.github.com/repos/octocat/hello-world/pulls/123, into the web URL of the subject and
+// its number or commit SHA. It returns zero values for URLs it does not recognize.
+func subjectTarget(apiURL string) (htmlURL string, number int, sha string) {
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Host == "" {
+		return "", 0, ""
+	}
+
+	// github.com serves the API from api.github.com, GitHub Enterprise Server from /api/v3
+	host := strings.TrimPrefix(u.Host, "api.")
+	path := strings.TrimPrefix(u.Path, "/api/v3")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 5 || parts[0] != "repos" {
+		return "", 0, ""
+	}
+	owner, repo, kind, id := parts[1], parts[2], parts[3], parts[4]
+
+	var webPath string
+	switch kind {
+	case "pulls", "issues":
+		number, err = strconv.Atoi(id)
+		if err != nil {
+			return "", 0, ""
 		}
+		webPath = "issues"
+		if kind == "pulls" {
+			webPath = "pull"
+		}
+	case "commits":
+		sha = id
+		webPath = "commit"
+	default:
+		// Releases and other subjects are addressed by database IDs that have no web URL
+		return "", 0, ""
+	}
+
+	web := url.URL{Scheme: u.Scheme, Host: host, Path: fmt.Sprintf("/%s/%s/%s/%s", owner, repo, webPath, id)}
+	return web.String(), number, sha
 }
 
 // DismissNotification creates a tool to mark a notification as read/done.
@@ -191,21 +260,8 @@ func DismissNotification(getclient GetClientFn, t translations.TranslationHelper
 				return mcp.NewToolResultError("Invalid state. Must be one of: read, done."), nil
 			}
 
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to mark notification as %s", state),
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusResetContent && resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to mark notification as %s: %s", state, string(body))), nil
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to mark notification as %s", state), resp, err, http.StatusResetContent, http.StatusOK); !ok {
+				return result, err
 			}
 
 			return mcp.NewToolResultText(fmt.Sprintf("Notification marked as %s", state)), nil
@@ -270,21 +326,8 @@ func MarkAllNotificationsRead(getClient GetClientFn, t translations.TranslationH
 			} else {
 				resp, err = client.Activity.MarkNotificationsRead(ctx, markReadOptions)
 			}
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to mark all notifications as read",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusResetContent && resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to mark all notifications as read: %s", string(body))), nil
+			if result, ok, err := handleRESTResponse(ctx, "failed to mark all notifications as read", resp, err, http.StatusResetContent, http.StatusOK); !ok {
+				return result, err
 			}
 
 			return mcp.NewToolResultText("All notifications marked as read"), nil
@@ -316,29 +359,11 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 			}
 
 			thread, resp, err := client.Activity.GetThread(ctx, notificationID)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to get notification details for ID '%s'", notificationID),
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get notification details: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(thread)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to get notification details for ID '%s'", notificationID), resp, err); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(thread), nil
 		}
 }
 
@@ -523,3 +548,151 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// GetRepositorySubscription creates a tool to get the current user's notification subscription for a repository.
+func GetRepositorySubscription(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_subscription",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_SUBSCRIPTION_DESCRIPTION", "Get whether the current user watches (subscribed) or ignores a repository. A repository that is not watched reports both as false.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_SUBSCRIPTION_USER_TITLE", "Get repository subscription"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The account owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github reports a 404, which is how the API says the repository is not watched,
+			// as a nil subscription without an error.
+			sub, resp, err := client.Activity.GetRepositorySubscription(ctx, owner, repo)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get repository subscription", resp, err, http.StatusOK, http.StatusNotFound); !ok {
+				return result, err
+			}
+			if sub == nil {
+				sub = &github.Subscription{Subscribed: ToBoolPtr(false), Ignored: ToBoolPtr(false)}
+			}
+
+			return MarshalledTextResult(sub), nil
+		}
+}
+
+// SetRepositorySubscription creates a tool to watch or ignore a repository.
+func SetRepositorySubscription(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_repository_subscription",
+			mcp.WithDescription(t("TOOL_SET_REPOSITORY_SUBSCRIPTION_DESCRIPTION", "Watch a repository to receive all its notifications (subscribed), or ignore it to receive none (ignored). A repository cannot be both subscribed and ignored.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_REPOSITORY_SUBSCRIPTION_USER_TITLE", "Set repository subscription"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The account owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithBoolean("subscribed",
+				mcp.Description("Receive notifications for all activity in the repository."),
+			),
+			mcp.WithBoolean("ignored",
+				mcp.Description("Block all notifications from the repository."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subscribed, err := OptionalParam[bool](request, "subscribed")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ignored, err := OptionalParam[bool](request, "ignored")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if subscribed && ignored {
+				return mcp.NewToolResultError("subscribed and ignored cannot both be true"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			sub, resp, err := client.Activity.SetRepositorySubscription(ctx, owner, repo, &github.Subscription{
+				Subscribed: ToBoolPtr(subscribed),
+				Ignored:    ToBoolPtr(ignored),
+			})
+			if result, ok, err := handleRESTResponse(ctx, "failed to set repository subscription", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(sub), nil
+		}
+}
+
+// DeleteRepositorySubscription creates a tool to stop watching or ignoring a repository.
+func DeleteRepositorySubscription(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_repository_subscription",
+			mcp.WithDescription(t("TOOL_DELETE_REPOSITORY_SUBSCRIPTION_DESCRIPTION", "Stop watching or ignoring a repository, so that only participating and @mentions notifications are received.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DELETE_REPOSITORY_SUBSCRIPTION_USER_TITLE", "Delete repository subscription"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The account owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Activity.DeleteRepositorySubscription(ctx, owner, repo)
+			if result, ok, err := handleRESTResponse(ctx, "failed to delete repository subscription", resp, err, http.StatusNoContent); !ok {
+				return result, err
+			}
+
+			return mcp.NewToolResultText("Repository subscription deleted"), nil
+		}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -140,11 +141,115 @@ func Test_ListNotifications(t *testing.T) {
 			require.False(t, result.IsError)
 			textContent := getTextResult(t, result)
 			t.Logf("textContent: %s", textContent.Text)
-			var returned []*github.Notification
+			var returned []Notification
 			err = json.Unmarshal([]byte(textContent.Text), &returned)
 			require.NoError(t, err)
 			require.NotEmpty(t, returned)
-			assert.Equal(t, *tc.expectedResult[0].ID, *returned[0].ID)
+			assert.Equal(t, *tc.expectedResult[0].ID, returned[0].ID)
+		})
+	}
+}
+
+func Test_NewNotification(t *testing.T) {
+	notification := newNotification(&github.Notification{
+		ID:     github.Ptr("123"),
+		Reason: github.Ptr("review_requested"),
+		Unread: github.Ptr(true),
+		Repository: &github.Repository{
+			FullName: github.Ptr("octocat/hello-world"),
+			Owner:    &github.User{Login: github.Ptr("octocat")},
+		},
+		Subject: &github.NotificationSubject{
+			Title:            github.Ptr("Add a feature"),
+			Type:             github.Ptr("PullRequest"),
+			URL:              github.Ptr("https://api.github.com/repos/octocat/hello-world/pulls/42"),
+			LatestCommentURL: github.Ptr("https://api.github.com/repos/octocat/hello-world/issues/comments/1"),
+		},
+		UpdatedAt: &github.Timestamp{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		URL:       github.Ptr("https://api.github.com/notifications/threads/123"),
+	})
+
+	assert.Equal(t, Notification{
+		ID:         "123",
+		Reason:     "review_requested",
+		Unread:     true,
+		UpdatedAt:  "2024-01-02T03:04:05Z",
+		Repository: "octocat/hello-world",
+		Subject: NotificationSubject{
+			Title:   "Add a feature",
+			Type:    "PullRequest",
+			Number:  42,
+			URL:     "https://api.github.com/repos/octocat/hello-world/pulls/42",
+			HTMLURL: "https://github.com/octocat/hello-world/pull/42",
+		},
+	}, notification)
+
+	// Discussions and some other subjects have no URL
+	notification = newNotification(&github.Notification{
+		ID:      github.Ptr("124"),
+		Subject: &github.NotificationSubject{Title: github.Ptr("A question"), Type: github.Ptr("Discussion")},
+	})
+	assert.Equal(t, NotificationSubject{Title: "A question", Type: "Discussion"}, notification.Subject)
+}
+
+func Test_SubjectTarget(t *testing.T) {
+	tests := []struct {
+		name           string
+		apiURL         string
+		expectedURL    string
+		expectedNumber int
+		expectedSHA    string
+	}{
+		{
+			name:           "pull request",
+			apiURL:         "https://api.github.com/repos/octocat/hello-world/pulls/42",
+			expectedURL:    "https://github.com/octocat/hello-world/pull/42",
+			expectedNumber: 42,
+		},
+		{
+			name:           "issue",
+			apiURL:         "https://api.github.com/repos/octocat/hello-world/issues/7",
+			expectedURL:    "https://github.com/octocat/hello-world/issues/7",
+			expectedNumber: 7,
+		},
+		{
+			name:        "commit",
+			apiURL:      "https://api.github.com/repos/octocat/hello-world/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			expectedURL: "https://github.com/octocat/hello-world/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			expectedSHA: "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		},
+		{
+			name:           "GitHub Enterprise Server",
+			apiURL:         "https://github.example.com/api/v3/repos/octocat/hello-world/pulls/42",
+			expectedURL:    "https://github.example.com/octocat/hello-world/pull/42",
+			expectedNumber: 42,
+		},
+		{
+			name:           "GitHub Enterprise Cloud with data residency",
+			apiURL:         "https://api.octocorp.ghe.com/repos/octocat/hello-world/issues/3",
+			expectedURL:    "https://octocorp.ghe.com/octocat/hello-world/issues/3",
+			expectedNumber: 3,
+		},
+		{
+			name:   "release",
+			apiURL: "https://api.github.com/repos/octocat/hello-world/releases/1001",
+		},
+		{
+			name:   "issue number that is not a number",
+			apiURL: "https://api.github.com/repos/octocat/hello-world/issues/latest",
+		},
+		{
+			name:   "empty",
+			apiURL: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			htmlURL, number, sha := subjectTarget(tc.apiURL)
+			assert.Equal(t, tc.expectedURL, htmlURL)
+			assert.Equal(t, tc.expectedNumber, number)
+			assert.Equal(t, tc.expectedSHA, sha)
 		})
 	}
 }
@@ -450,6 +555,204 @@ func Test_ManageRepositoryNotificationSubscription(t *testing.T) {
 	}
 }
 
+func Test_GetRepositorySubscription(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositorySubscription(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_subscription", tool.Name)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedSub    github.Subscription
+	}{
+		{
+			name: "watched repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					&github.Subscription{Subscribed: github.Ptr(true), Ignored: github.Ptr(false)},
+				),
+			),
+			expectedSub: github.Subscription{Subscribed: github.Ptr(true), Ignored: github.Ptr(false)},
+		},
+		{
+			name: "not watched maps 404 to unsubscribed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectedSub: github.Subscription{Subscribed: github.Ptr(false), Ignored: github.Ptr(false)},
+		},
+		{
+			name: "request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposSubscriptionByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get repository subscription",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositorySubscription(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Subscription
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedSub, returned)
+		})
+	}
+}
+
+func Test_SetRepositorySubscription(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRepositorySubscription(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_repository_subscription", tool.Name)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "watch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposSubscriptionByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"subscribed": true,
+						"ignored":    false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Subscription{Subscribed: github.Ptr(true), Ignored: github.Ptr(false)}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"subscribed": true,
+			},
+		},
+		{
+			name: "ignore",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposSubscriptionByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"subscribed": false,
+						"ignored":    true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Subscription{Subscribed: github.Ptr(false), Ignored: github.Ptr(true)}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"ignored": true,
+			},
+		},
+		{
+			name:         "subscribed and ignored are rejected locally",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"subscribed": true,
+				"ignored":    true,
+			},
+			expectError:    true,
+			expectedErrMsg: "subscribed and ignored cannot both be true",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetRepositorySubscription(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Subscription
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.requestArgs["subscribed"] == true, returned.GetSubscribed())
+			assert.Equal(t, tc.requestArgs["ignored"] == true, returned.GetIgnored())
+		})
+	}
+}
+
+func Test_DeleteRepositorySubscription(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRepositorySubscription(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_repository_subscription", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposSubscriptionByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		),
+	))
+	_, handler := DeleteRepositorySubscription(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "Repository subscription deleted", getTextResult(t, result).Text)
+}
+
 func Test_DismissNotification(t *testing.T) {
 	// Verify tool definition and schema
 	mockClient := github.NewClient(nil)
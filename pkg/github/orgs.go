@@ -0,0 +1,198 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OrgRepository is a repository of an organization as returned by list_org_repositories.
+type OrgRepository struct {
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Description   string `json:"description,omitempty"`
+	Private       bool   `json:"private"`
+	Fork          bool   `json:"fork"`
+	Archived      bool   `json:"archived"`
+	Language      string `json:"language,omitempty"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+	HTMLURL       string `json:"html_url"`
+	PushedAt      string `json:"pushed_at,omitempty"`
+}
+
+// OrgRepositoriesResult is a page of the repositories of an organization.
+type OrgRepositoriesResult struct {
+	Repositories []OrgRepository `json:"repositories"`
+	NextPageInfo
+}
+
+// OrgMember is a member of an organization or team as returned by list_org_members and list_team_members.
+type OrgMember struct {
+	Login     string `json:"login"`
+	Type      string `json:"type,omitempty"`
+	SiteAdmin bool   `json:"site_admin,omitempty"`
+	HTMLURL   string `json:"html_url"`
+}
+
+func newOrgMembers(users []*github.User) []OrgMember {
+	members := make([]OrgMember, 0, len(users))
+	for _, user := range users {
+		members = append(members, OrgMember{
+			Login:     user.GetLogin(),
+			Type:      user.GetType(),
+			SiteAdmin: user.GetSiteAdmin(),
+			HTMLURL:   user.GetHTMLURL(),
+		})
+	}
+	return members
+}
+
+// OrgMembersResult is a page of the members of an organization or team.
+type OrgMembersResult struct {
+	Members []OrgMember `json:"members"`
+	NextPageInfo
+}
+
+// ListOrgRepositories creates a tool to list the repositories of an organization.
+func ListOrgRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_repositories",
+			mcp.WithDescription(t("TOOL_LIST_ORG_REPOSITORIES_DESCRIPTION", "List the repositories of an organization that the authenticated user can see. Large organizations span many pages; check has_next_page and fetch next_page to get the rest.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_REPOSITORIES_USER_TITLE", "List organization repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Only list repositories of this type"),
+				mcp.Enum("all", "public", "private", "forks", "sources", "member"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Property to sort the repositories by"),
+				mcp.Enum("created", "updated", "pushed", "full_name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repoType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.RepositoryListByOrgOptions{
+				Type: repoType,
+				Sort: sort,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			repos, resp, err := client.Repositories.ListByOrg(ctx, org, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list organization repositories", resp, err); !ok {
+				return result, err
+			}
+
+			result := OrgRepositoriesResult{
+				Repositories: make([]OrgRepository, 0, len(repos)),
+				NextPageInfo: nextPageInfo(resp),
+			}
+			for _, repo := range repos {
+				entry := OrgRepository{
+					Name:          repo.GetName(),
+					FullName:      repo.GetFullName(),
+					Description:   repo.GetDescription(),
+					Private:       repo.GetPrivate(),
+					Fork:          repo.GetFork(),
+					Archived:      repo.GetArchived(),
+					Language:      repo.GetLanguage(),
+					DefaultBranch: repo.GetDefaultBranch(),
+					HTMLURL:       repo.GetHTMLURL(),
+				}
+				if repo.PushedAt != nil {
+					entry.PushedAt = repo.PushedAt.UTC().Format(time.RFC3339)
+				}
+				result.Repositories = append(result.Repositories, entry)
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// ListOrgMembers creates a tool to list the members of an organization.
+func ListOrgMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_members",
+			mcp.WithDescription(t("TOOL_LIST_ORG_MEMBERS_DESCRIPTION", "List the members of an organization. Only public members are listed unless the authenticated user is a member too. Check has_next_page and fetch next_page to get the rest.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_MEMBERS_USER_TITLE", "List organization members"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Only list members with this role"),
+				mcp.Enum("all", "admin", "member"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ListMembersOptions{
+				Role: role,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			members, resp, err := client.Organizations.ListMembers(ctx, org, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list organization members", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(OrgMembersResult{
+				Members:      newOrgMembers(members),
+				NextPageInfo: nextPageInfo(resp),
+			}), nil
+		}
+}
@@ -0,0 +1,287 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockRepos := []*github.Repository{
+		{
+			Name:          github.Ptr("cli"),
+			FullName:      github.Ptr("octo-org/cli"),
+			Description:   github.Ptr("Command line tool"),
+			Private:       github.Ptr(true),
+			Language:      github.Ptr("Go"),
+			DefaultBranch: github.Ptr("main"),
+			HTMLURL:       github.Ptr("https://github.com/octo-org/cli"),
+			PushedAt:      &github.Timestamp{Time: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)},
+			Owner:         &github.User{Login: github.Ptr("octo-org")},
+		},
+		{
+			Name:     github.Ptr("docs"),
+			FullName: github.Ptr("octo-org/docs"),
+			Fork:     github.Ptr(true),
+			Archived: github.Ptr(true),
+			HTMLURL:  github.Ptr("https://github.com/octo-org/docs"),
+		},
+	}
+	expectedRepos := []OrgRepository{
+		{
+			Name:          "cli",
+			FullName:      "octo-org/cli",
+			Description:   "Command line tool",
+			Private:       true,
+			Language:      "Go",
+			DefaultBranch: "main",
+			HTMLURL:       "https://github.com/octo-org/cli",
+			PushedAt:      "2025-07-01T12:00:00Z",
+		},
+		{
+			Name:     "docs",
+			FullName: "octo-org/docs",
+			Fork:     true,
+			Archived: true,
+			HTMLURL:  "https://github.com/octo-org/docs",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult OrgRepositoriesResult
+		expectedErrMsg string
+	}{
+		{
+			name: "lists repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRepos),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectedResult: OrgRepositoriesResult{Repositories: expectedRepos},
+		},
+		{
+			name: "filters and sorts a full page and reports the next page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					expectQueryParams(t, map[string]string{
+						"type":     "private",
+						"sort":     "pushed",
+						"page":     "2",
+						"per_page": "100",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/orgs/octo-org/repos?page=3&per_page=100>; rel="next"`)
+							_ = json.NewEncoder(w).Encode(mockRepos[:1])
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "octo-org",
+				"type":    "private",
+				"sort":    "pushed",
+				"page":    float64(2),
+				"perPage": float64(100),
+			},
+			expectedResult: OrgRepositoriesResult{
+				Repositories: expectedRepos[:1],
+				NextPageInfo: NextPageInfo{HasNextPage: true, NextPage: 3},
+			},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "no-such-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned OrgRepositoriesResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_ListOrgMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_members", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockMembers := []*github.User{
+		{
+			Login:   github.Ptr("octocat"),
+			Type:    github.Ptr("User"),
+			HTMLURL: github.Ptr("https://github.com/octocat"),
+			Email:   github.Ptr("octocat@github.com"),
+		},
+		{
+			Login:     github.Ptr("hubot"),
+			Type:      github.Ptr("User"),
+			SiteAdmin: github.Ptr(true),
+			HTMLURL:   github.Ptr("https://github.com/hubot"),
+		},
+	}
+	expectedMembers := []OrgMember{
+		{Login: "octocat", Type: "User", HTMLURL: "https://github.com/octocat"},
+		{Login: "hubot", Type: "User", SiteAdmin: true, HTMLURL: "https://github.com/hubot"},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult OrgMembersResult
+		expectedErrMsg string
+	}{
+		{
+			name: "lists members",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockMembers),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectedResult: OrgMembersResult{Members: expectedMembers},
+		},
+		{
+			name: "filters by role and reports the next page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					expectQueryParams(t, map[string]string{
+						"role":     "admin",
+						"page":     "1",
+						"per_page": "100",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/orgs/octo-org/members?role=admin&page=2&per_page=100>; rel="next"`)
+							_ = json.NewEncoder(w).Encode(mockMembers[1:])
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "octo-org",
+				"role":    "admin",
+				"perPage": float64(100),
+			},
+			expectedResult: OrgMembersResult{
+				Members:      expectedMembers[1:],
+				NextPageInfo: NextPageInfo{HasNextPage: true, NextPage: 2},
+			},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "no-such-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization members",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned OrgMembersResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// PullRequestDraftState is the draft state of a pull request after mark_pull_request_ready_for_review
+// or convert_pull_request_to_draft.
+type PullRequestDraftState struct {
+	Number  int    `json:"number"`
+	URL     string `json:"url"`
+	IsDraft bool   `json:"isDraft"`
+}
+
+// pullRequestDraftFields selects the fields of a pull request that make up its PullRequestDraftState.
+type pullRequestDraftFields struct {
+	Number  githubv4.Int
+	URL     githubv4.String `graphql:"url"`
+	IsDraft githubv4.Boolean
+}
+
+func (f pullRequestDraftFields) state() PullRequestDraftState {
+	return PullRequestDraftState{
+		Number:  int(f.Number),
+		URL:     string(f.URL),
+		IsDraft: bool(f.IsDraft),
+	}
+}
+
+// MarkPullRequestReadyForReview creates a tool to take a pull request out of draft.
+func MarkPullRequestReadyForReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("mark_pull_request_ready_for_review",
+			mcp.WithDescription(t("TOOL_MARK_PULL_REQUEST_READY_FOR_REVIEW_DESCRIPTION", "Mark a draft pull request as ready for review, which notifies code owners and allows it to be merged.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MARK_PULL_REQUEST_READY_FOR_REVIEW_USER_TITLE", "Mark pull request ready for review"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+			}
+			if err := decodeParams(request, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			// The REST API cannot change the draft state, so the pull request is looked up by its GQL ID.
+			pullRequestID, err := getPullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to get pull request",
+					err,
+				), nil
+			}
+
+			var markReadyForReviewMutation struct {
+				MarkPullRequestReadyForReview struct {
+					PullRequest pullRequestDraftFields
+				} `graphql:"markPullRequestReadyForReview(input: $input)"`
+			}
+			if err := client.Mutate(
+				ctx,
+				&markReadyForReviewMutation,
+				githubv4.MarkPullRequestReadyForReviewInput{
+					PullRequestID: pullRequestID,
+				},
+				nil,
+			); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to mark pull request ready for review",
+					err,
+				), nil
+			}
+
+			return MarshalledTextResult(markReadyForReviewMutation.MarkPullRequestReadyForReview.PullRequest.state()), nil
+		}
+}
+
+// ConvertPullRequestToDraft creates a tool to convert a pull request back to a draft.
+func ConvertPullRequestToDraft(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("convert_pull_request_to_draft",
+			mcp.WithDescription(t("TOOL_CONVERT_PULL_REQUEST_TO_DRAFT_DESCRIPTION", "Convert a pull request to a draft, so that it cannot be merged until it is marked ready for review again.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CONVERT_PULL_REQUEST_TO_DRAFT_USER_TITLE", "Convert pull request to draft"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+			}
+			if err := decodeParams(request, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			pullRequestID, err := getPullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to get pull request",
+					err,
+				), nil
+			}
+
+			var convertToDraftMutation struct {
+				ConvertPullRequestToDraft struct {
+					PullRequest pullRequestDraftFields
+				} `graphql:"convertPullRequestToDraft(input: $input)"`
+			}
+			if err := client.Mutate(
+				ctx,
+				&convertToDraftMutation,
+				githubv4.ConvertPullRequestToDraftInput{
+					PullRequestID: pullRequestID,
+				},
+				nil,
+			); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to convert pull request to draft",
+					err,
+				), nil
+			}
+
+			return MarshalledTextResult(convertToDraftMutation.ConvertPullRequestToDraft.PullRequest.state()), nil
+		}
+}
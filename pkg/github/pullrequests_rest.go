@@ -0,0 +1,2421 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/github/github-mcp-server/internal/diff"
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// GetPullRequest creates a tool to get details of a specific pull request.
+func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DESCRIPTION", "Get details of a specific pull request in a GitHub repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_USER_TITLE", "Get pull request details"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get pull request", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(pr), nil
+		}
+}
+
+// CreatePullRequest creates a tool to create a new pull request.
+func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("create_pull_request",
+			mcp.WithDescription(t("TOOL_CREATE_PULL_REQUEST_DESCRIPTION", "Create a new pull request in a GitHub repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_PULL_REQUEST_USER_TITLE", "Open new pull request"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("PR title"),
+			),
+			mcp.WithString("body",
+				mcp.Description("PR description"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Branch containing changes"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Branch to merge into"),
+			),
+			mcp.WithBoolean("draft",
+				mcp.Description("Create as draft PR"),
+			),
+			mcp.WithBoolean("maintainer_can_modify",
+				mcp.Description("Allow maintainer edits"),
+			),
+			mcp.WithBoolean("request_codeowners",
+				mcp.Description("After creating the PR, request reviews from the CODEOWNERS of the changed files. The result then lists who was requested for which paths, and any reviewers that could not be requested as warnings"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := RequiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			draft, err := OptionalParam[bool](request, "draft")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			maintainerCanModify, err := OptionalParam[bool](request, "maintainer_can_modify")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			requestCodeowners, err := OptionalParam[bool](request, "request_codeowners")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			newPR := &github.NewPullRequest{
+				Title: github.Ptr(title),
+				Head:  github.Ptr(head),
+				Base:  github.Ptr(base),
+			}
+
+			if body != "" {
+				newPR.Body = github.Ptr(body)
+			}
+
+			newPR.Draft = github.Ptr(draft)
+			newPR.MaintainerCanModify = github.Ptr(maintainerCanModify)
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Create(ctx, owner, repo, newPR)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create pull request", resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			if !requestCodeowners {
+				return MarshalledTextResult(pr), nil
+			}
+
+			// The pull request exists at this point, so failing to request reviews is only a warning.
+			return MarshalledTextResult(CreatePullRequestResult{
+				PullRequest: pr,
+				Codeowners:  requestCodeownerReviews(ctx, client, owner, repo, pr),
+			}), nil
+		}
+}
+
+// CreatePullRequestResult is the result of create_pull_request when code owner reviews are requested.
+type CreatePullRequestResult struct {
+	PullRequest *github.PullRequest     `json:"pull_request"`
+	Codeowners  *CodeownerReviewRequest `json:"codeowners"`
+}
+
+// UpdatePullRequest creates a tool to update an existing pull request.
+func UpdatePullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("update_pull_request",
+			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_DESCRIPTION", "Update an existing pull request in a GitHub repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_PULL_REQUEST_USER_TITLE", "Edit pull request"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number to update"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New title"),
+			),
+			mcp.WithString("body",
+				mcp.Description("New description"),
+			),
+			mcp.WithString("state",
+				mcp.Description("New state"),
+				mcp.Enum("open", "closed"),
+			),
+			mcp.WithString("base",
+				mcp.Description("New base branch name"),
+			),
+			mcp.WithBoolean("maintainer_can_modify",
+				mcp.Description("Allow maintainer edits"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Build the update struct only with provided fields
+			update := &github.PullRequest{}
+			updateNeeded := false
+
+			if title, ok, err := OptionalParamOK[string](request, "title"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				update.Title = github.Ptr(title)
+				updateNeeded = true
+			}
+
+			if body, ok, err := OptionalParamOK[string](request, "body"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				update.Body = github.Ptr(body)
+				updateNeeded = true
+			}
+
+			if state, ok, err := OptionalParamOK[string](request, "state"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				update.State = github.Ptr(state)
+				updateNeeded = true
+			}
+
+			if base, ok, err := OptionalParamOK[string](request, "base"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				update.Base = &github.PullRequestBranch{Ref: github.Ptr(base)}
+				updateNeeded = true
+			}
+
+			if maintainerCanModify, ok, err := OptionalParamOK[bool](request, "maintainer_can_modify"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				update.MaintainerCanModify = github.Ptr(maintainerCanModify)
+				updateNeeded = true
+			}
+
+			if !updateNeeded {
+				return mcp.NewToolResultError("No update parameters provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Edit(ctx, owner, repo, pullNumber, update)
+			if result, ok, err := handleRESTResponse(ctx, "failed to update pull request", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(pr), nil
+		}
+}
+
+// ListPullRequests creates a tool to list and filter repository pull requests.
+func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_pull_requests",
+			mcp.WithDescription(t("TOOL_LIST_PULL_REQUESTS_DESCRIPTION", "List pull requests in a GitHub repository. If the user specifies an author, then DO NOT use this tool and use the search_pull_requests tool instead. When merged_after or merged_before is set, only merged pull requests are returned: the tool switches to the search API under the hood and fetches each result individually so merged_at and merged_by are included.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PULL_REQUESTS_USER_TITLE", "List pull requests"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state"),
+				mcp.Enum("open", "closed", "all"),
+			),
+			mcp.WithString("head",
+				mcp.Description("Filter by head user/org and branch"),
+			),
+			mcp.WithString("base",
+				mcp.Description("Filter by base branch"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort by"),
+				mcp.Enum("created", "updated", "popularity", "long-running"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction"),
+				mcp.Enum("asc", "desc"),
+			),
+			mcp.WithString("merged_after",
+				mcp.Description("Only list pull requests merged on or after this date (ISO 8601 date or timestamp)"),
+			),
+			mcp.WithString("merged_before",
+				mcp.Description("Only list pull requests merged on or before this date (ISO 8601 date or timestamp)"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := OptionalParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := OptionalParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			mergedAfter, err := OptionalParam[string](request, "merged_after")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			mergedBefore, err := OptionalParam[string](request, "merged_before")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if mergedAfter != "" || mergedBefore != "" {
+				if state == "open" {
+					return mcp.NewToolResultError("merged_after and merged_before cannot be combined with state=open"), nil
+				}
+				query, err := mergedPullRequestsQuery(owner, repo, base, head, mergedAfter, mergedBefore)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				searchOpts := &github.SearchOptions{
+					Order: direction,
+					ListOptions: github.ListOptions{
+						PerPage: pagination.PerPage,
+						Page:    pagination.Page,
+					},
+				}
+				// Search can only sort on fields it indexes, so the REST-only orderings fall back to best match.
+				if sort == "created" || sort == "updated" {
+					searchOpts.Sort = sort
+				}
+
+				client, err := getClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				}
+				result, prs, ok, err := listMergedPullRequests(ctx, client, owner, repo, query, searchOpts)
+				if !ok {
+					return result, err
+				}
+				return MarshalledTextResult(prs), nil
+			}
+
+			opts := &github.PullRequestListOptions{
+				State:     state,
+				Head:      head,
+				Base:      base,
+				Sort:      sort,
+				Direction: direction,
+				ListOptions: github.ListOptions{
+					PerPage: pagination.PerPage,
+					Page:    pagination.Page,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list pull requests", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(prs), nil
+		}
+}
+
+// mergedPullRequestsQuery builds the search query list_pull_requests uses for a merge date range, since the
+// REST list endpoint cannot filter by merge date.
+func mergedPullRequestsQuery(owner, repo, base, head, mergedAfter, mergedBefore string) (string, error) {
+	var after, before time.Time
+	var err error
+	if mergedAfter != "" {
+		if after, err = parseISOTimestamp(mergedAfter); err != nil {
+			return "", fmt.Errorf("invalid merged_after: %w", err)
+		}
+	}
+	if mergedBefore != "" {
+		if before, err = parseISOTimestamp(mergedBefore); err != nil {
+			return "", fmt.Errorf("invalid merged_before: %w", err)
+		}
+	}
+	if mergedAfter != "" && mergedBefore != "" && after.After(before) {
+		return "", fmt.Errorf("merged_after (%s) must not be later than merged_before (%s)", mergedAfter, mergedBefore)
+	}
+
+	terms := []string{"is:pr", "is:merged", fmt.Sprintf("repo:%s/%s", owner, repo)}
+	switch {
+	case mergedAfter != "" && mergedBefore != "":
+		terms = append(terms, fmt.Sprintf("merged:%s..%s", mergedAfter, mergedBefore))
+	case mergedAfter != "":
+		terms = append(terms, "merged:>="+mergedAfter)
+	default:
+		terms = append(terms, "merged:<="+mergedBefore)
+	}
+	if base != "" {
+		terms = append(terms, "base:"+base)
+	}
+	if head != "" {
+		// The REST filter takes user:ref-name, whereas search only matches on the branch name.
+		if _, ref, found := strings.Cut(head, ":"); found {
+			head = ref
+		}
+		terms = append(terms, "head:"+head)
+	}
+	return strings.Join(terms, " "), nil
+}
+
+// listMergedPullRequests runs a merged pull request search and fetches each hit as a full pull request, so the
+// result has the same shape as the REST listing plus merged_at and merged_by. The number of fetches is bounded
+// by the page size.
+func listMergedPullRequests(ctx context.Context, client *github.Client, owner, repo, query string, opts *github.SearchOptions) (*mcp.CallToolResult, []*github.PullRequest, bool, error) {
+	searchResult, resp, err := client.Search.Issues(ctx, query, opts)
+	if result, ok, err := handleRESTResponse(ctx, "failed to search merged pull requests", resp, err); !ok {
+		return result, nil, false, err
+	}
+
+	issues := searchResult.Issues
+	if opts.PerPage > 0 && len(issues) > opts.PerPage {
+		issues = issues[:opts.PerPage]
+	}
+
+	prs := make([]*github.PullRequest, 0, len(issues))
+	for _, issue := range issues {
+		pr, resp, err := client.PullRequests.Get(ctx, owner, repo, issue.GetNumber())
+		if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to get pull request #%d", issue.GetNumber()), resp, err); !ok {
+			return result, nil, false, err
+		}
+		prs = append(prs, pr)
+	}
+	return nil, prs, true, nil
+}
+
+// MergePullRequest creates a tool to merge a pull request.
+func MergePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("merge_pull_request",
+			mcp.WithDescription(t("TOOL_MERGE_PULL_REQUEST_DESCRIPTION", "Merge a pull request in a GitHub repository. With enable_auto_merge set, a pull request that cannot be merged yet, e.g. because required checks have not finished, is set to merge automatically once it can be instead.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_MERGE_PULL_REQUEST_USER_TITLE", "Merge pull request"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("commit_title",
+				mcp.Description("Title for merge commit"),
+			),
+			mcp.WithString("commit_message",
+				mcp.Description("Extra detail for merge commit"),
+			),
+			mcp.WithString("merge_method",
+				mcp.Description("Merge method"),
+				mcp.Enum("merge", "squash", "rebase"),
+			),
+			mcp.WithBoolean("enable_auto_merge",
+				mcp.Description("If the pull request cannot be merged yet, enable auto-merge with the chosen merge method instead of failing. Auto-merge must be allowed in the repository settings"),
+				mcp.DefaultBool(false),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commitTitle, err := OptionalParam[string](request, "commit_title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			commitMessage, err := OptionalParam[string](request, "commit_message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			mergeMethod, err := OptionalParam[string](request, "merge_method")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enableAutoMerge, err := OptionalParam[bool](request, "enable_auto_merge")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			options := &github.PullRequestOptions{
+				CommitTitle: commitTitle,
+				MergeMethod: mergeMethod,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			mergeResult, resp, err := client.PullRequests.Merge(ctx, owner, repo, pullNumber, commitMessage, options)
+			// GitHub answers 405 when the pull request is not mergeable yet, e.g. while required
+			// checks are still running or approvals are missing.
+			if enableAutoMerge && err != nil && resp != nil && resp.StatusCode == http.StatusMethodNotAllowed {
+				_ = resp.Body.Close()
+				return enablePullRequestAutoMerge(ctx, getGQLClient, owner, repo, pullNumber, commitTitle, commitMessage, mergeMethod)
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to merge pull request", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(mergeResult), nil
+		}
+}
+
+// enablePullRequestAutoMerge enables auto-merge on a pull request, which is only available through GraphQL.
+func enablePullRequestAutoMerge(ctx context.Context, getGQLClient GetGQLClientFn, owner, repo string, pullNumber int, commitTitle, commitMessage, mergeMethod string) (*mcp.CallToolResult, error) {
+	client, err := getGQLClient(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+	}
+
+	pullRequestID, err := getPullRequestNodeID(ctx, client, owner, repo, int32(pullNumber)) // #nosec G115 - pull request numbers are always small positive integers
+	if err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+			"failed to get pull request",
+			err,
+		), nil
+	}
+
+	input := githubv4.EnablePullRequestAutoMergeInput{
+		PullRequestID: pullRequestID,
+	}
+	if commitTitle != "" {
+		input.CommitHeadline = githubv4.NewString(githubv4.String(commitTitle))
+	}
+	if commitMessage != "" {
+		input.CommitBody = githubv4.NewString(githubv4.String(commitMessage))
+	}
+	if mergeMethod != "" {
+		method := githubv4.PullRequestMergeMethod(strings.ToUpper(mergeMethod))
+		input.MergeMethod = &method
+	}
+
+	var enableAutoMergeMutation struct {
+		EnablePullRequestAutoMerge struct {
+			PullRequest struct {
+				AutoMergeRequest struct {
+					MergeMethod githubv4.PullRequestMergeMethod
+				}
+			}
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}
+	if err := client.Mutate(ctx, &enableAutoMergeMutation, input, nil); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+			"the pull request cannot be merged yet and enabling auto-merge failed",
+			err,
+		), nil
+	}
+
+	method := strings.ToLower(string(enableAutoMergeMutation.EnablePullRequestAutoMerge.PullRequest.AutoMergeRequest.MergeMethod))
+	return mcp.NewToolResultText(fmt.Sprintf("Pull request %s/%s#%d was NOT merged yet because it is not mergeable now. Auto-merge is enabled with the %s method, so GitHub will merge it once all requirements, such as required checks and reviews, are met.", owner, repo, pullNumber, method)), nil
+}
+
+// SearchPullRequests creates a tool to search for pull requests.
+func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_pull_requests",
+			mcp.WithDescription(t("TOOL_SEARCH_PULL_REQUESTS_DESCRIPTION", "Search for pull requests in GitHub repositories using issues search syntax already scoped to is:pr")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_PULL_REQUESTS_USER_TITLE", "Search pull requests"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Search query using GitHub pull request search syntax"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Optional repository owner. If provided with repo, only notifications for this repository are listed."),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Optional repository name. If provided with owner, only notifications for this repository are listed."),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort field by number of matches of categories, defaults to best match"),
+				mcp.Enum(
+					"comments",
+					"reactions",
+					"reactions-+1",
+					"reactions--1",
+					"reactions-smile",
+					"reactions-thinking_face",
+					"reactions-heart",
+					"reactions-tada",
+					"interactions",
+					"created",
+					"updated",
+				),
+			),
+			mcp.WithString("order",
+				mcp.Description("Sort order"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithIssueSearchFilters(),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return searchHandler(ctx, getClient, request, "pr", "failed to search pull requests")
+		}
+}
+
+// GetPullRequestFiles creates a tool to get the list of files changed in a pull request.
+func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_files",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_FILES_DESCRIPTION", "Get the files changed in a specific pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_FILES_USER_TITLE", "Get pull request files"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithBoolean("exclude_generated",
+				mcp.Description("Exclude lockfiles, minified files, vendor/ and dist/ directories, and files marked linguist-generated in .gitattributes. The number of excluded files and their additions and deletions are still reported"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithBoolean("summary_only",
+				mcp.Description("Return totals of files, additions and deletions by directory and by file extension instead of the list of files"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithString("output",
+				mcp.Description("'files' returns the files with their raw patch. 'hunks' returns each file's patch parsed into hunks with old and new line numbers, and the line ranges review comments can be placed on for each side"),
+				mcp.Enum(pullRequestFilesOutputFiles, pullRequestFilesOutputHunks),
+				mcp.DefaultString(pullRequestFilesOutputFiles),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			excludeGenerated, err := OptionalParam[bool](request, "exclude_generated")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			summaryOnly, err := OptionalParam[bool](request, "summary_only")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			output, err := OptionalParam[string](request, "output")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch output {
+			case "":
+				output = pullRequestFilesOutputFiles
+			case pullRequestFilesOutputFiles, pullRequestFilesOutputHunks:
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid output %q, must be %q or %q", output, pullRequestFilesOutputFiles, pullRequestFilesOutputHunks)), nil
+			}
+			if summaryOnly && output == pullRequestFilesOutputHunks {
+				return mcp.NewToolResultError("summary_only cannot be combined with output 'hunks'"), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.ListOptions{
+				PerPage: pagination.PerPage,
+				Page:    pagination.Page,
+			}
+			files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get pull request files", resp, err); !ok {
+				return result, err
+			}
+
+			page := nextPageInfo(resp)
+
+			result := PullRequestFilesResult{Files: files}
+			if excludeGenerated {
+				rules := getPullRequestGeneratedAttributes(ctx, client, owner, repo, pullNumber)
+				result = excludeGeneratedFiles(files, rules)
+			}
+			result.NextPageInfo = page
+
+			if summaryOnly {
+				summary := summarizePullRequestFiles(result.Files)
+				summary.NextPageInfo = page
+				return MarshalledTextResult(summary), nil
+			}
+			if output == pullRequestFilesOutputHunks {
+				return MarshalledTextResult(PullRequestFileHunksResult{
+					Files:             pullRequestFileHunks(result.Files),
+					ExcludedFiles:     result.ExcludedFiles,
+					ExcludedAdditions: result.ExcludedAdditions,
+					ExcludedDeletions: result.ExcludedDeletions,
+					NextPageInfo:      page,
+				}), nil
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// Output modes of get_pull_request_files.
+const (
+	pullRequestFilesOutputFiles = "files"
+	pullRequestFilesOutputHunks = "hunks"
+)
+
+// CommentableLines are the line ranges of a file that review comments can be placed on. Left is
+// the base side of the pull request and right the head side.
+type CommentableLines struct {
+	Left  []diff.LineRange `json:"left"`
+	Right []diff.LineRange `json:"right"`
+}
+
+// PullRequestFileHunks is a file of get_pull_request_files with output 'hunks': its patch parsed
+// into hunks. Files without a patch, such as binary files and renames without changes, have no
+// hunks, and a patch that cannot be parsed is reported in ParseError.
+type PullRequestFileHunks struct {
+	Filename         string           `json:"filename"`
+	PreviousFilename string           `json:"previous_filename,omitempty"`
+	Status           string           `json:"status"`
+	Additions        int              `json:"additions"`
+	Deletions        int              `json:"deletions"`
+	Hunks            []diff.Hunk      `json:"hunks"`
+	CommentableLines CommentableLines `json:"commentable_lines"`
+	ParseError       string           `json:"parse_error,omitempty"`
+}
+
+// PullRequestFileHunksResult is the result of get_pull_request_files with output 'hunks'.
+type PullRequestFileHunksResult struct {
+	Files             []PullRequestFileHunks `json:"files"`
+	ExcludedFiles     int                    `json:"excluded_files"`
+	ExcludedAdditions int                    `json:"excluded_additions"`
+	ExcludedDeletions int                    `json:"excluded_deletions"`
+	NextPageInfo
+}
+
+func pullRequestFileHunks(files []*github.CommitFile) []PullRequestFileHunks {
+	result := make([]PullRequestFileHunks, 0, len(files))
+	for _, file := range files {
+		fileHunks := PullRequestFileHunks{
+			Filename:         file.GetFilename(),
+			PreviousFilename: file.GetPreviousFilename(),
+			Status:           file.GetStatus(),
+			Additions:        file.GetAdditions(),
+			Deletions:        file.GetDeletions(),
+			Hunks:            []diff.Hunk{},
+		}
+		hunks, err := diff.Parse(file.GetPatch())
+		if err != nil {
+			fileHunks.ParseError = err.Error()
+		} else {
+			fileHunks.Hunks = hunks
+		}
+		fileHunks.CommentableLines.Left, fileHunks.CommentableLines.Right = diff.CommentableRanges(fileHunks.Hunks)
+		result = append(result, fileHunks)
+	}
+	return result
+}
+
+// PullRequestFilesResult is the result of get_pull_request_files. When generated files are excluded,
+// the excluded counts keep the change statistics of the pull request honest.
+type PullRequestFilesResult struct {
+	Files             []*github.CommitFile `json:"files"`
+	ExcludedFiles     int                  `json:"excluded_files"`
+	ExcludedAdditions int                  `json:"excluded_additions"`
+	ExcludedDeletions int                  `json:"excluded_deletions"`
+	NextPageInfo
+}
+
+// generatedFilePatterns are the gitattributes style patterns of files that are treated as generated
+// by default: lockfiles, minified assets and vendored or built directories.
+var generatedFilePatterns = []string{
+	"package-lock.json",
+	"npm-shrinkwrap.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"bun.lockb",
+	"go.sum",
+	"Cargo.lock",
+	"Gemfile.lock",
+	"composer.lock",
+	"poetry.lock",
+	"Pipfile.lock",
+	"*.min.js",
+	"*.min.css",
+	"**/vendor/**",
+	"**/dist/**",
+}
+
+// gitattributesRule is a pattern from a .gitattributes file that sets or unsets linguist-generated.
+type gitattributesRule struct {
+	pattern   string
+	generated bool
+}
+
+// getPullRequestGeneratedAttributes returns the linguist-generated rules of the .gitattributes file at the
+// head of a pull request. The file is optional, so if it is missing or cannot be read, there are no rules
+// and only the built-in patterns apply.
+func getPullRequestGeneratedAttributes(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) []gitattributesRule {
+	attributes, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, ".gitattributes", &github.RepositoryContentGetOptions{
+		Ref: fmt.Sprintf("refs/pull/%d/head", pullNumber),
+	})
+	closeResponseBody(resp)
+	if err != nil || attributes == nil {
+		return nil
+	}
+	content, err := attributes.GetContent()
+	if err != nil {
+		return nil
+	}
+	return parseGeneratedAttributes(content)
+}
+
+// parseGeneratedAttributes returns the rules of a .gitattributes file that set or unset the
+// linguist-generated attribute, in file order. Other attributes are ignored.
+func parseGeneratedAttributes(content string) []gitattributesRule {
+	var rules []gitattributesRule
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, attr := range fields[1:] {
+			switch attr {
+			case "linguist-generated", "linguist-generated=true":
+				rules = append(rules, gitattributesRule{pattern: fields[0], generated: true})
+			case "-linguist-generated", "!linguist-generated", "linguist-generated=false":
+				rules = append(rules, gitattributesRule{pattern: fields[0], generated: false})
+			}
+		}
+	}
+	return rules
+}
+
+// isGeneratedFile reports whether the file at filePath is generated, either because it matches one of
+// the built-in patterns or because a .gitattributes rule marks it as such. As in git, the last matching
+// rule wins, so .gitattributes can also unmark a file that a built-in pattern matches.
+func isGeneratedFile(filePath string, rules []gitattributesRule) bool {
+	generated := false
+	for _, pattern := range generatedFilePatterns {
+		if matchGitPattern(pattern, filePath) {
+			generated = true
+			break
+		}
+	}
+	for _, rule := range rules {
+		if matchGitPattern(rule.pattern, filePath) {
+			generated = rule.generated
+		}
+	}
+	return generated
+}
+
+// matchGitPattern reports whether filePath matches a gitattributes style pattern. A pattern without
+// a slash matches the file name at any depth, otherwise it matches the path from the repository root.
+// "**" matches across directories.
+func matchGitPattern(pattern, filePath string) bool {
+	if !strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+		matched, _ := path.Match(pattern, path.Base(filePath))
+		return matched
+	}
+
+	pattern = strings.TrimPrefix(pattern, "/")
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+	expr.WriteString("$")
+
+	matched, err := regexp.MatchString(expr.String(), filePath)
+	return err == nil && matched
+}
+
+// excludeGeneratedFiles splits out the generated files, returning the remaining files along with
+// the count and change totals of those excluded.
+func excludeGeneratedFiles(files []*github.CommitFile, rules []gitattributesRule) PullRequestFilesResult {
+	result := PullRequestFilesResult{
+		Files: []*github.CommitFile{},
+	}
+	for _, file := range files {
+		if !isGeneratedFile(file.GetFilename(), rules) {
+			result.Files = append(result.Files, file)
+			continue
+		}
+		result.ExcludedFiles++
+		result.ExcludedAdditions += file.GetAdditions()
+		result.ExcludedDeletions += file.GetDeletions()
+	}
+	return result
+}
+
+// FileChangeStats counts the files changed in a group of a pull request summary and their additions and deletions.
+type FileChangeStats struct {
+	Files     int `json:"files"`
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+}
+
+// PullRequestFilesSummary is the result of get_pull_request_files with summary_only set.
+type PullRequestFilesSummary struct {
+	Total       FileChangeStats            `json:"total"`
+	ByDirectory map[string]FileChangeStats `json:"by_directory"`
+	ByExtension map[string]FileChangeStats `json:"by_extension"`
+	NextPageInfo
+}
+
+// summarizePullRequestFiles totals the changed files by directory and by extension. Files in the repository root
+// are grouped under "." and files without an extension under "(none)".
+func summarizePullRequestFiles(files []*github.CommitFile) PullRequestFilesSummary {
+	summary := PullRequestFilesSummary{
+		ByDirectory: map[string]FileChangeStats{},
+		ByExtension: map[string]FileChangeStats{},
+	}
+	add := func(stats FileChangeStats, file *github.CommitFile) FileChangeStats {
+		stats.Files++
+		stats.Additions += file.GetAdditions()
+		stats.Deletions += file.GetDeletions()
+		return stats
+	}
+	for _, file := range files {
+		dir := path.Dir(file.GetFilename())
+		ext := path.Ext(file.GetFilename())
+		if ext == "" {
+			ext = "(none)"
+		}
+		summary.Total = add(summary.Total, file)
+		summary.ByDirectory[dir] = add(summary.ByDirectory[dir], file)
+		summary.ByExtension[ext] = add(summary.ByExtension[ext], file)
+	}
+	return summary
+}
+
+// GetPullRequestStatus creates a tool to get the combined status of all status checks for a pull request.
+func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_status",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_STATUS_DESCRIPTION", "Get the CI status of a specific pull request, merging the commit statuses and the check runs of its head commit. The overall state is failure if anything failed, otherwise pending if anything is still running, otherwise success; it is none when nothing has reported.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_STATUS_USER_TITLE", "Get pull request status checks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// First get the PR to find the head SHA
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get pull request", resp, err); !ok {
+				return result, err
+			}
+
+			// Repositories that only use the Checks API have an empty combined status, which on its
+			// own would look green, so the check runs of the head SHA are fetched alongside it.
+			ci := fetchRefCI(ctx, client, owner, repo, pr.GetHead().GetSHA(), "", false)
+			if ci.statusErr != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get combined status", ci.statusResp, ci.statusErr), nil
+			}
+			if ci.checksErr != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list check runs", ci.checksResp, ci.checksErr), nil
+			}
+
+			status := mergePullRequestStatus(ci.status.Statuses, ci.checkRuns.CheckRuns)
+			status.SHA = pr.GetHead().GetSHA()
+			status.Truncated = ci.truncated
+			status.OverallState = capTruncatedCIState(status.OverallState, ci.truncated)
+			return MarshalledTextResult(status), nil
+		}
+}
+
+// PullRequestStatus is the result of the get_pull_request_status tool. OverallState is failure if any
+// commit status or check run failed, otherwise pending if any is still running, otherwise success; it
+// is none when the head commit has neither. Truncated is set when there were too many statuses or
+// check runs to fetch them all, and then OverallState is never success.
+type PullRequestStatus struct {
+	OverallState string   `json:"overall_state"`
+	SHA          string   `json:"sha"`
+	Statuses     []CIItem `json:"statuses"`
+	CheckRuns    []CIItem `json:"check_runs"`
+	Truncated    bool     `json:"truncated,omitempty"`
+}
+
+// mergePullRequestStatus merges the commit statuses and check runs of a pull request's head commit,
+// keeping only the latest run of each check, and rolls them up into an overall state.
+func mergePullRequestStatus(statuses []*github.RepoStatus, checkRuns []*github.CheckRun) PullRequestStatus {
+	items := mergeCIItems(statuses, checkRuns)
+	result := PullRequestStatus{
+		Statuses:  []CIItem{},
+		CheckRuns: []CIItem{},
+	}
+	for _, item := range items {
+		if item.Kind == "check" {
+			result.CheckRuns = append(result.CheckRuns, item)
+		} else {
+			result.Statuses = append(result.Statuses, item)
+		}
+	}
+	result.OverallState, _ = rollupCIState(items, nil)
+	return result
+}
+
+// GetRequiredStatusChecks creates a tool to report which required status checks of a pull request are missing, pending or failing.
+func GetRequiredStatusChecks(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_required_status_checks",
+			mcp.WithDescription(t("TOOL_GET_REQUIRED_STATUS_CHECKS_DESCRIPTION", "Get the status checks that are required before a pull request can be merged, from branch protection and rulesets, and report which of them are passing, pending, failing or missing on the pull request's head commit. Also reports whether signed commits are required.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REQUIRED_STATUS_CHECKS_USER_TITLE", "Get required status checks"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch whose protection rules to check. Defaults to the base branch of the pull request"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get pull request",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if branch == "" {
+				branch = pr.GetBase().GetRef()
+			}
+			result := RequiredStatusChecksResult{
+				Branch:  branch,
+				HeadSHA: pr.GetHead().GetSHA(),
+			}
+
+			var required []requiredCheck
+
+			// Branch protection can only be read with admin access, so failing to read it is not fatal
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			switch {
+			case err == nil:
+				defer func() { _ = resp.Body.Close() }()
+				required = append(required, requiredChecksFromProtection(protection)...)
+				if protection.GetRequiredSignatures().GetEnabled() {
+					result.SignaturesRequired = true
+				}
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				// The branch is not protected, or we lack permission to see that it is
+			default:
+				result.Notes = append(result.Notes, fmt.Sprintf("could not read branch protection, which requires admin access: %s", err.Error()))
+			}
+
+			rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, branch, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				result.Notes = append(result.Notes, fmt.Sprintf("could not read rulesets: %s", err.Error()))
+			} else {
+				defer func() { _ = resp.Body.Close() }()
+				required = append(required, requiredChecksFromRules(rules)...)
+				if len(rules.RequiredSignatures) > 0 {
+					result.SignaturesRequired = true
+				}
+			}
+
+			// Required contexts may be reported through either the Checks API or the Status API
+			ci := fetchRefCI(ctx, client, owner, repo, result.HeadSHA, "", false)
+			if ci.statusErr != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get combined status", ci.statusResp, ci.statusErr), nil
+			}
+			if ci.checksErr != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list check runs", ci.checksResp, ci.checksErr), nil
+			}
+			if ci.truncated {
+				result.Notes = append(result.Notes, fmt.Sprintf("only the first %d statuses and check runs of each kind were fetched, so a check reported as missing may have run", maxCIPages*100))
+			}
+
+			result.Checks = joinRequiredChecks(required, ci.checkRuns.CheckRuns, ci.status.Statuses)
+			result.Missing, result.Pending, result.Failing = []string{}, []string{}, []string{}
+			for _, check := range result.Checks {
+				switch check.State {
+				case requiredCheckMissing:
+					result.Missing = append(result.Missing, check.Context)
+				case requiredCheckPending:
+					result.Pending = append(result.Pending, check.Context)
+				case requiredCheckFailing:
+					result.Failing = append(result.Failing, check.Context)
+				}
+			}
+			result.AllPassing = len(result.Missing) == 0 && len(result.Pending) == 0 && len(result.Failing) == 0
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// States of a required status check on a pull request's head commit.
+const (
+	requiredCheckPassing = "passing"
+	requiredCheckPending = "pending"
+	requiredCheckFailing = "failing"
+	requiredCheckMissing = "missing"
+)
+
+// RequiredStatusChecksResult is the result of the get_required_status_checks tool.
+type RequiredStatusChecksResult struct {
+	Branch             string                `json:"branch"`
+	HeadSHA            string                `json:"head_sha"`
+	SignaturesRequired bool                  `json:"signatures_required"`
+	AllPassing         bool                  `json:"all_passing"`
+	Checks             []RequiredCheckStatus `json:"checks"`
+	Missing            []string              `json:"missing"`
+	Pending            []string              `json:"pending"`
+	Failing            []string              `json:"failing"`
+	Notes              []string              `json:"notes,omitempty"`
+}
+
+// RequiredCheckStatus is the state of a single required status check on a pull request's head commit.
+type RequiredCheckStatus struct {
+	Context    string `json:"context"`
+	Source     string `json:"source"`
+	State      string `json:"state"`
+	Conclusion string `json:"conclusion,omitempty"`
+	DetailsURL string `json:"details_url,omitempty"`
+}
+
+// requiredCheck is a status check context that must pass before merging.
+// If appID is set, only check runs from that GitHub App satisfy it.
+type requiredCheck struct {
+	context string
+	appID   *int64
+	source  string
+}
+
+// requiredChecksFromProtection returns the status checks required by branch protection.
+func requiredChecksFromProtection(protection *github.Protection) []requiredCheck {
+	statusChecks := protection.GetRequiredStatusChecks()
+	if statusChecks == nil {
+		return nil
+	}
+
+	var checks []requiredCheck
+	if statusChecks.Checks != nil {
+		for _, c := range *statusChecks.Checks {
+			checks = append(checks, requiredCheck{context: c.Context, appID: c.AppID, source: "branch_protection"})
+		}
+	} else if statusChecks.Contexts != nil {
+		for _, name := range *statusChecks.Contexts {
+			checks = append(checks, requiredCheck{context: name, source: "branch_protection"})
+		}
+	}
+	return checks
+}
+
+// requiredChecksFromRules returns the status checks required by the rulesets that apply to a branch.
+func requiredChecksFromRules(rules *github.BranchRules) []requiredCheck {
+	var checks []requiredCheck
+	for _, rule := range rules.RequiredStatusChecks {
+		for _, c := range rule.Parameters.RequiredStatusChecks {
+			checks = append(checks, requiredCheck{context: c.Context, appID: c.IntegrationID, source: "ruleset"})
+		}
+	}
+	return checks
+}
+
+// joinRequiredChecks matches each required check to the most recent check run with the same name,
+// or failing that to the commit status with the same context, and reports its state. Statuses carry
+// no app, so they only satisfy checks that any app may provide. A context required by several
+// sources is only reported once.
+func joinRequiredChecks(required []requiredCheck, checkRuns []*github.CheckRun, statuses []*github.RepoStatus) []RequiredCheckStatus {
+	result := []RequiredCheckStatus{}
+	seen := make(map[string]bool)
+	for _, req := range required {
+		if seen[req.context] {
+			continue
+		}
+		seen[req.context] = true
+
+		// An app ID of -1 means that any app may provide the check
+		anyApp := req.appID == nil || *req.appID == -1
+
+		var latest *github.CheckRun
+		for _, run := range checkRuns {
+			if run.GetName() != req.context {
+				continue
+			}
+			if !anyApp && run.GetApp().GetID() != *req.appID {
+				continue
+			}
+			if latest == nil || run.GetID() > latest.GetID() {
+				latest = run
+			}
+		}
+
+		status := RequiredCheckStatus{
+			Context: req.context,
+			Source:  req.source,
+			State:   requiredCheckMissing,
+		}
+		switch {
+		case latest != nil:
+			status.Conclusion = latest.GetConclusion()
+			status.DetailsURL = latest.GetHTMLURL()
+			switch {
+			case latest.GetStatus() != "completed":
+				status.State = requiredCheckPending
+			case latest.GetConclusion() == "success", latest.GetConclusion() == "neutral", latest.GetConclusion() == "skipped":
+				status.State = requiredCheckPassing
+			default:
+				status.State = requiredCheckFailing
+			}
+		case anyApp:
+			// The combined status holds only the latest status of each context
+			idx := slices.IndexFunc(statuses, func(s *github.RepoStatus) bool { return s.GetContext() == req.context })
+			if idx == -1 {
+				break
+			}
+			commitStatus := statuses[idx]
+			status.Conclusion = commitStatus.GetState()
+			status.DetailsURL = commitStatus.GetTargetURL()
+			switch commitStatus.GetState() {
+			case "success":
+				status.State = requiredCheckPassing
+			case "pending":
+				status.State = requiredCheckPending
+			default:
+				status.State = requiredCheckFailing
+			}
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+// GetPullRequestDependencyDiff creates a tool to list the dependencies a pull request adds and removes.
+func GetPullRequestDependencyDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_dependency_diff",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DEPENDENCY_DIFF_DESCRIPTION", "Get the dependencies added and removed by a pull request, using dependency review between its base and head commits. Each package includes its ecosystem, version, license and known security advisories, with a count of the advisories on added packages by severity. Requires the dependency graph to be enabled.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_DEPENDENCY_DIFF_USER_TITLE", "Get pull request dependency diff"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get pull request", resp, err); !ok {
+				return result, err
+			}
+
+			baseSHA, headSHA := pr.GetBase().GetSHA(), pr.GetHead().GetSHA()
+
+			// go-github does not wrap the dependency review endpoint yet
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/dependency-graph/compare/%s...%s", owner, repo, baseSHA, headSHA), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var changes []dependencyReviewChange
+			resp, err = client.Do(ctx, req, &changes)
+			if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultError(fmt.Sprintf("dependency review is not available for %s/%s: enable the dependency graph in the repository's security settings (private repositories also need GitHub Advanced Security), then try again", owner, repo)), nil
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to compare dependencies", resp, err); !ok {
+				return result, err
+			}
+
+			diff := projectDependencyChanges(changes)
+			diff.BaseSHA, diff.HeadSHA = baseSHA, headSHA
+
+			return MarshalledTextResult(diff), nil
+		}
+}
+
+// dependencyReviewChange is a single entry returned by the dependency review compare endpoint.
+type dependencyReviewChange struct {
+	ChangeType      string `json:"change_type"`
+	Manifest        string `json:"manifest"`
+	Ecosystem       string `json:"ecosystem"`
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	PackageURL      string `json:"package_url"`
+	License         string `json:"license"`
+	Scope           string `json:"scope"`
+	Vulnerabilities []struct {
+		Severity        string `json:"severity"`
+		AdvisoryGHSAID  string `json:"advisory_ghsa_id"`
+		AdvisorySummary string `json:"advisory_summary"`
+		AdvisoryURL     string `json:"advisory_url"`
+	} `json:"vulnerabilities"`
+}
+
+// PullRequestDependencyDiff is the result of the get_pull_request_dependency_diff tool.
+type PullRequestDependencyDiff struct {
+	BaseSHA         string                    `json:"base_sha"`
+	HeadSHA         string                    `json:"head_sha"`
+	Added           []DependencyChange        `json:"added"`
+	Removed         []DependencyChange        `json:"removed"`
+	AddedAdvisories DependencySeveritySummary `json:"added_advisories"`
+}
+
+// DependencyChange is a package added or removed by a pull request.
+type DependencyChange struct {
+	Name       string               `json:"name"`
+	Ecosystem  string               `json:"ecosystem"`
+	Version    string               `json:"version"`
+	License    string               `json:"license,omitempty"`
+	Manifest   string               `json:"manifest"`
+	Scope      string               `json:"scope,omitempty"`
+	PackageURL string               `json:"package_url,omitempty"`
+	Advisories []DependencyAdvisory `json:"advisories,omitempty"`
+}
+
+// DependencyAdvisory is a security advisory that affects a dependency.
+type DependencyAdvisory struct {
+	GHSAID   string `json:"ghsa_id"`
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	URL      string `json:"url"`
+}
+
+// DependencySeveritySummary counts advisories by severity.
+type DependencySeveritySummary struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Moderate int `json:"moderate"`
+	Low      int `json:"low"`
+	Total    int `json:"total"`
+}
+
+// projectDependencyChanges splits dependency review changes into added and removed packages,
+// and summarizes the advisories of the added ones, which are the ones the pull request introduces.
+func projectDependencyChanges(changes []dependencyReviewChange) PullRequestDependencyDiff {
+	diff := PullRequestDependencyDiff{
+		Added:   []DependencyChange{},
+		Removed: []DependencyChange{},
+	}
+	for _, change := range changes {
+		dependency := DependencyChange{
+			Name:       change.Name,
+			Ecosystem:  change.Ecosystem,
+			Version:    change.Version,
+			License:    change.License,
+			Manifest:   change.Manifest,
+			Scope:      change.Scope,
+			PackageURL: change.PackageURL,
+		}
+		for _, vulnerability := range change.Vulnerabilities {
+			dependency.Advisories = append(dependency.Advisories, DependencyAdvisory{
+				GHSAID:   vulnerability.AdvisoryGHSAID,
+				Severity: vulnerability.Severity,
+				Summary:  vulnerability.AdvisorySummary,
+				URL:      vulnerability.AdvisoryURL,
+			})
+		}
+
+		switch change.ChangeType {
+		case "added":
+			diff.Added = append(diff.Added, dependency)
+		case "removed":
+			diff.Removed = append(diff.Removed, dependency)
+		}
+	}
+	diff.AddedAdvisories = summarizeAdvisorySeverities(diff.Added)
+	return diff
+}
+
+// summarizeAdvisorySeverities counts the advisories of dependencies by severity.
+// Advisories with an unrecognized severity only count towards the total.
+func summarizeAdvisorySeverities(dependencies []DependencyChange) DependencySeveritySummary {
+	var summary DependencySeveritySummary
+	for _, dependency := range dependencies {
+		for _, advisory := range dependency.Advisories {
+			summary.Total++
+			switch strings.ToLower(advisory.Severity) {
+			case "critical":
+				summary.Critical++
+			case "high":
+				summary.High++
+			case "moderate", "medium":
+				summary.Moderate++
+			case "low":
+				summary.Low++
+			}
+		}
+	}
+	return summary
+}
+
+// UpdatePullRequestBranch creates a tool to update a pull request branch with the latest changes from the base branch.
+func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("update_pull_request_branch",
+			mcp.WithDescription(t("TOOL_UPDATE_PULL_REQUEST_BRANCH_DESCRIPTION", "Update the branch of a pull request with the latest changes from the base branch.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_PULL_REQUEST_BRANCH_USER_TITLE", "Update pull request branch"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("expectedHeadSha",
+				mcp.Description("The expected SHA of the pull request's HEAD ref"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedHeadSHA, err := OptionalParam[string](request, "expectedHeadSha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts := &github.PullRequestBranchUpdateOptions{}
+			if expectedHeadSHA != "" {
+				opts.ExpectedHeadSHA = github.Ptr(expectedHeadSHA)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			result, resp, err := client.PullRequests.UpdateBranch(ctx, owner, repo, pullNumber, opts)
+			if err != nil {
+				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
+				// and it's not a real error.
+				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
+					return mcp.NewToolResultText("Pull request branch update is in progress"), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to update pull request branch",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusAccepted {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update pull request branch: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// PullRequestCommentsResult is a page of the review comments of a pull request. LastPage is the
+// number of the last page, when GitHub reports it, and bounds the total number of comments.
+type PullRequestCommentsResult struct {
+	Comments []*github.PullRequestComment `json:"comments"`
+	NextPageInfo
+	LastPage int `json:"last_page,omitempty"`
+}
+
+// pullRequestCommentsPerPage is the default page size of get_pull_request_comments, which fetched 100
+// comments before it was paginated.
+const pullRequestCommentsPerPage = 100
+
+// GetPullRequestComments creates a tool to get the review comments on a pull request.
+func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_comments",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_COMMENTS_DESCRIPTION", "Get a page of the review comments of a specific pull request. Check has_next_page and fetch next_page to get the rest.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_COMMENTS_USER_TITLE", "Get pull request comments"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort comments by when they were created or last updated"),
+				mcp.Enum("created", "updated"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction, only used with sort"),
+				mcp.Enum("asc", "desc"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only return comments updated at or after this time (ISO 8601 timestamp)"),
+			),
+			WithPaginationPerPage(pullRequestCommentsPerPage),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParamsPerPage(request, pullRequestCommentsPerPage)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.PullRequestListCommentsOptions{
+				Sort:      sort,
+				Direction: direction,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if since != "" {
+				timestamp, err := parseISOTimestamp(since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request comments: %s", err.Error())), nil
+				}
+				opts.Since = timestamp
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			comments, resp, err := client.PullRequests.ListComments(ctx, owner, repo, pullNumber, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get pull request comments", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(PullRequestCommentsResult{
+				Comments:     comments,
+				NextPageInfo: nextPageInfo(resp),
+				LastPage:     resp.LastPage,
+			}), nil
+		}
+}
+
+// GetPullRequestReviews creates a tool to get the reviews on a pull request.
+func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_reviews",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEWS_DESCRIPTION", "Get reviews for a specific pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_REVIEWS_USER_TITLE", "Get pull request reviews"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithArray("state_filter",
+				mcp.Description("Only return reviews in these states. With latest_per_reviewer, this applies to each reviewer's latest review"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+						"enum": reviewStates,
+					},
+				),
+			),
+			mcp.WithBoolean("latest_per_reviewer",
+				mcp.Description("Only return the most recent review of each reviewer"),
+			),
+			mcp.WithBoolean("include_comments",
+				mcp.Description("Nest the inline comments of each review under its comments key"),
+			),
+			mcp.WithNumber("max_comments",
+				mcp.Description("Maximum number of inline comments to fetch across all reviews when include_comments is set"),
+				mcp.Min(1),
+				mcp.DefaultNumber(defaultMaxReviewComments),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			stateFilter, err := OptionalStringArrayParam(request, "state_filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, state := range stateFilter {
+				if !slices.Contains(reviewStates, state) {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid state_filter value %q, must be one of %s", state, strings.Join(reviewStates, ", "))), nil
+				}
+			}
+			latestPerReviewer, err := OptionalParam[bool](request, "latest_per_reviewer")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeComments, err := OptionalParam[bool](request, "include_comments")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxComments, err := OptionalIntParamWithDefault(request, "max_comments", defaultMaxReviewComments)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxComments < 1 {
+				return mcp.NewToolResultError("max_comments must be at least 1"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Without filters we return the first page as-is. Filters only make sense across
+			// every review, so in that case we walk all pages up to a fixed bound first.
+			opts := &github.ListOptions{}
+			maxPages := 1
+			if len(stateFilter) > 0 || latestPerReviewer {
+				opts.PerPage = 100
+				maxPages = maxReviewPages
+			}
+
+			var reviews []*github.PullRequestReview
+			truncated := false
+			for page := 1; ; page++ {
+				pageReviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, opts)
+				if result, ok, err := handleRESTResponse(ctx, "failed to get pull request reviews", resp, err); !ok {
+					return result, err
+				}
+
+				reviews = append(reviews, pageReviews...)
+				if resp.NextPage == 0 {
+					break
+				}
+				if page == maxPages {
+					truncated = maxPages > 1
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			// Collapse to the latest review first, so that a reviewer who has since approved
+			// isn't reported by an earlier CHANGES_REQUESTED review.
+			if latestPerReviewer {
+				reviews = latestReviewPerReviewer(reviews)
+			}
+			if len(stateFilter) > 0 {
+				reviews = filterReviewsByState(reviews, stateFilter)
+			}
+			if !includeComments {
+				return withReviewsTruncatedNote(MarshalledTextResult(reviews), truncated), nil
+			}
+
+			// Comments are fetched review by review until maxComments have been fetched in total.
+			// The reviews after that are marked as truncated rather than left looking comment-less.
+			remaining := maxComments
+			withComments := make([]ReviewWithComments, 0, len(reviews))
+			for _, review := range reviews {
+				entry := ReviewWithComments{PullRequestReview: review, Comments: []*github.PullRequestComment{}}
+				// The page size stays fixed, as the page numbers in resp.NextPage are only valid
+				// for the size they were computed with. Comments past the budget are trimmed here.
+				commentOpts := &github.ListOptions{PerPage: 100}
+				for {
+					if remaining == 0 {
+						entry.CommentsTruncated = true
+						break
+					}
+					comments, resp, err := client.PullRequests.ListReviewComments(ctx, owner, repo, pullNumber, review.GetID(), commentOpts)
+					if result, ok, err := handleRESTResponse(ctx, "failed to get pull request review comments", resp, err); !ok {
+						return result, err
+					}
+					if len(comments) > remaining {
+						entry.Comments = append(entry.Comments, comments[:remaining]...)
+						entry.CommentsTruncated = true
+						remaining = 0
+						break
+					}
+					entry.Comments = append(entry.Comments, comments...)
+					remaining -= len(comments)
+					if resp.NextPage == 0 {
+						break
+					}
+					commentOpts.Page = resp.NextPage
+				}
+				withComments = append(withComments, entry)
+			}
+
+			return withReviewsTruncatedNote(MarshalledTextResult(withComments), truncated), nil
+		}
+}
+
+// withReviewsTruncatedNote adds a second text content to result when the reviews were filtered without
+// reaching their last page, so that the filtered list isn't mistaken for a complete one.
+func withReviewsTruncatedNote(result *mcp.CallToolResult, truncated bool) *mcp.CallToolResult {
+	if !truncated || result.IsError {
+		return result
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(
+		fmt.Sprintf("Only the first %d reviews were filtered, the pull request has more.", maxReviewPages*100),
+	))
+	return result
+}
+
+// ReviewWithComments is a pull request review with its inline comments nested under comments.
+// CommentsTruncated is set when the max_comments bound was reached before all of them were fetched.
+type ReviewWithComments struct {
+	*github.PullRequestReview
+	Comments          []*github.PullRequestComment `json:"comments"`
+	CommentsTruncated bool                         `json:"comments_truncated,omitempty"`
+}
+
+// defaultMaxReviewComments bounds how many inline comments get_pull_request_reviews fetches by default.
+const defaultMaxReviewComments = 100
+
+// reviewStates are the states a pull request review can be in, as reported by the REST API.
+var reviewStates = []string{"APPROVED", "CHANGES_REQUESTED", "COMMENTED", "DISMISSED", "PENDING"}
+
+// maxReviewPages bounds how many pages of reviews are fetched before filtering.
+const maxReviewPages = 10
+
+// filterReviewsByState returns the reviews whose state is one of states, preserving order.
+func filterReviewsByState(reviews []*github.PullRequestReview, states []string) []*github.PullRequestReview {
+	filtered := make([]*github.PullRequestReview, 0, len(reviews))
+	for _, review := range reviews {
+		if slices.Contains(states, review.GetState()) {
+			filtered = append(filtered, review)
+		}
+	}
+	return filtered
+}
+
+// latestReviewPerReviewer collapses the reviews to the most recent one of each reviewer, by submitted_at,
+// preserving the order of the reviews that are kept. Reviews without a submitted_at are pending, and
+// so are considered more recent than any submitted review.
+func latestReviewPerReviewer(reviews []*github.PullRequestReview) []*github.PullRequestReview {
+	latest := make(map[string]*github.PullRequestReview)
+	for _, review := range reviews {
+		login := review.GetUser().GetLogin()
+		current, ok := latest[login]
+		if !ok || isMoreRecentReview(review, current) {
+			latest[login] = review
+		}
+	}
+
+	collapsed := make([]*github.PullRequestReview, 0, len(latest))
+	for _, review := range reviews {
+		if latest[review.GetUser().GetLogin()] == review {
+			collapsed = append(collapsed, review)
+		}
+	}
+	return collapsed
+}
+
+// isMoreRecentReview reports whether a was submitted after b. Later reviews win ties.
+func isMoreRecentReview(a, b *github.PullRequestReview) bool {
+	if a.SubmittedAt == nil {
+		return true
+	}
+	if b.SubmittedAt == nil {
+		return false
+	}
+	return !a.GetSubmittedAt().Before(b.GetSubmittedAt().Time)
+}
+
+// GetPullRequestReviewers creates a tool to get the requested reviewers and review states of a pull request.
+func GetPullRequestReviewers(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_reviewers",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEWERS_DESCRIPTION", "Get the reviewers of a specific pull request: the users and teams whose review is requested, and the latest state of each completed review grouped by reviewer. Team requests that a member of the team has reviewed are marked as satisfied.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_REVIEWERS_USER_TITLE", "Get pull request reviewers"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			requested, resp, err := client.PullRequests.ListReviewers(ctx, owner, repo, pullNumber, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get requested reviewers",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			var reviews []*github.PullRequestReview
+			opts := &github.ListOptions{PerPage: 100}
+			for page := 0; page < maxReviewPages; page++ {
+				pageReviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get pull request reviews",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				reviews = append(reviews, pageReviews...)
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			// Team membership is only needed to tell whether a team request was satisfied, so it is
+			// skipped when nobody has reviewed. When it cannot be read, the team is reported with a
+			// note instead of as unsatisfied.
+			teamMembers := make(map[string][]string)
+			membershipErrors := make(map[string]string)
+			if len(reviews) > 0 {
+				for _, team := range requested.Teams {
+					members, resp, err := client.Teams.ListTeamMembersBySlug(ctx, owner, team.GetSlug(), &github.TeamListTeamMembersOptions{
+						ListOptions: github.ListOptions{PerPage: 100},
+					})
+					closeResponseBody(resp)
+					if err != nil {
+						detail := err.Error()
+						var errResp *github.ErrorResponse
+						if errors.As(err, &errResp) {
+							detail = errResp.Message
+						}
+						membershipErrors[team.GetSlug()] = fmt.Sprintf("failed to list team members, which needs the read:org scope and an organization owner: %s", detail)
+						continue
+					}
+					for _, member := range members {
+						teamMembers[team.GetSlug()] = append(teamMembers[team.GetSlug()], member.GetLogin())
+					}
+				}
+			}
+
+			return MarshalledTextResult(groupPullRequestReviewers(requested, reviews, teamMembers, membershipErrors)), nil
+		}
+}
+
+// PullRequestReviewers is the result of the get_pull_request_reviewers tool.
+type PullRequestReviewers struct {
+	RequestedUsers []string              `json:"requested_users"`
+	RequestedTeams []RequestedTeam       `json:"requested_teams"`
+	Reviews        []ReviewerLatestState `json:"reviews"`
+}
+
+// RequestedTeam is a team whose review of a pull request is requested.
+// SatisfiedBy is set to the login of a team member who has reviewed the pull request.
+// MembershipUnknown is set, with a Note saying why, when the team's members could not be listed,
+// so it is not known whether the request was satisfied.
+type RequestedTeam struct {
+	Slug              string `json:"slug"`
+	Name              string `json:"name"`
+	SatisfiedBy       string `json:"satisfied_by,omitempty"`
+	MembershipUnknown bool   `json:"membership_unknown,omitempty"`
+	Note              string `json:"note,omitempty"`
+}
+
+// ReviewerLatestState is the latest completed review of a pull request by a single reviewer.
+type ReviewerLatestState struct {
+	Reviewer    string     `json:"reviewer"`
+	State       string     `json:"state"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	ReviewCount int        `json:"review_count"`
+}
+
+// groupPullRequestReviewers merges the requested reviewers of a pull request with its reviews.
+// Pending reviews are ignored. teamMembers maps team slugs to the logins of their members, and
+// is used to mark team requests as satisfied by a member's review. membershipErrors maps the slugs
+// of teams whose members could not be listed to why, and marks their membership as unknown.
+func groupPullRequestReviewers(requested *github.Reviewers, reviews []*github.PullRequestReview, teamMembers map[string][]string, membershipErrors map[string]string) PullRequestReviewers {
+	result := PullRequestReviewers{
+		RequestedUsers: []string{},
+		RequestedTeams: []RequestedTeam{},
+		Reviews:        []ReviewerLatestState{},
+	}
+
+	completed := filterReviewsByState(reviews, []string{"APPROVED", "CHANGES_REQUESTED", "COMMENTED", "DISMISSED"})
+	reviewCounts := make(map[string]int)
+	for _, review := range completed {
+		reviewCounts[review.GetUser().GetLogin()]++
+	}
+	for _, review := range latestReviewPerReviewer(completed) {
+		state := ReviewerLatestState{
+			Reviewer:    review.GetUser().GetLogin(),
+			State:       review.GetState(),
+			ReviewCount: reviewCounts[review.GetUser().GetLogin()],
+		}
+		if review.SubmittedAt != nil {
+			submittedAt := review.GetSubmittedAt().Time
+			state.SubmittedAt = &submittedAt
+		}
+		result.Reviews = append(result.Reviews, state)
+	}
+
+	if requested == nil {
+		return result
+	}
+	for _, user := range requested.Users {
+		result.RequestedUsers = append(result.RequestedUsers, user.GetLogin())
+	}
+	for _, team := range requested.Teams {
+		requestedTeam := RequestedTeam{
+			Slug: team.GetSlug(),
+			Name: team.GetName(),
+		}
+		for _, member := range teamMembers[team.GetSlug()] {
+			if reviewCounts[member] > 0 {
+				requestedTeam.SatisfiedBy = member
+				break
+			}
+		}
+		if note, ok := membershipErrors[team.GetSlug()]; ok {
+			requestedTeam.MembershipUnknown = true
+			requestedTeam.Note = note
+		}
+		result.RequestedTeams = append(result.RequestedTeams, requestedTeam)
+	}
+	return result
+}
+
+func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_diff",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the diff of a pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_DIFF_USER_TITLE", "Get pull request diff"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithNumber("max_bytes",
+				mcp.Description("Truncate the diff to at most this many bytes, cutting at a line boundary where possible. A marker at the end tells how much was left out. 0 means the default, the whole diff"),
+				mcp.Min(0),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+			}
+			if err := decodeParams(request, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxBytes, err := optionalByteLimitParam(request, "max_bytes", 0)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub client: %v", err)), nil
+			}
+
+			raw, resp, err := client.PullRequests.GetRaw(
+				ctx,
+				params.Owner,
+				params.Repo,
+				int(params.PullNumber),
+				github.RawOptions{Type: github.Diff},
+			)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get pull request diff", resp, err); !ok {
+				return result, err
+			}
+
+			if maxBytes > 0 {
+				raw = truncateDiff(raw, maxBytes)
+			}
+			return mcp.NewToolResultText(raw), nil
+		}
+}
+
+// truncateDiff cuts a diff to at most maxBytes with lineBoundaryCut. It appends a marker saying
+// how many bytes were left out.
+func truncateDiff(text string, maxBytes int) string {
+	if len(text) <= maxBytes {
+		return text
+	}
+	cut := lineBoundaryCut(text, maxBytes)
+	kept := strings.TrimSuffix(text[:cut], "\n")
+	return fmt.Sprintf("%s\n\n[diff truncated: showing %d of %d bytes; raise max_bytes or use get_pull_request_files for individual files]", kept, cut, len(text))
+}
+
+// RequestedReviewers is the result of the request_pull_request_reviewers tool: the users and teams
+// whose review is requested on the pull request afterwards.
+type RequestedReviewers struct {
+	Reviewers     []string `json:"reviewers"`
+	TeamReviewers []string `json:"team_reviewers"`
+}
+
+// RequestPullRequestReviewers creates a tool to request reviews from users and teams on a pull request.
+func RequestPullRequestReviewers(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("request_pull_request_reviewers",
+			mcp.WithDescription(t("TOOL_REQUEST_PULL_REQUEST_REVIEWERS_DESCRIPTION", "Request reviews on a pull request from users and teams. At least one reviewer or team reviewer is required. A review cannot be requested from the pull request's author.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REQUEST_PULL_REQUEST_REVIEWERS_USER_TITLE", "Request pull request reviewers"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithArray("reviewers",
+				mcp.Description("Logins of the users to request a review from"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("team_reviewers",
+				mcp.Description("Slugs of the teams to request a review from"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reviewers, err := OptionalStringArrayParam(request, "reviewers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamReviewers, err := OptionalStringArrayParam(request, "team_reviewers")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(reviewers) == 0 && len(teamReviewers) == 0 {
+				return mcp.NewToolResultError("at least one of reviewers or team_reviewers is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.RequestReviewers(ctx, owner, repo, pullNumber, github.ReviewersRequest{
+				Reviewers:     reviewers,
+				TeamReviewers: teamReviewers,
+			})
+			if result, ok, err := handleRESTResponse(ctx, "failed to request reviewers", resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			result := RequestedReviewers{
+				Reviewers:     make([]string, 0, len(pr.RequestedReviewers)),
+				TeamReviewers: make([]string, 0, len(pr.RequestedTeams)),
+			}
+			for _, user := range pr.RequestedReviewers {
+				result.Reviewers = append(result.Reviewers, user.GetLogin())
+			}
+			for _, team := range pr.RequestedTeams {
+				result.TeamReviewers = append(result.TeamReviewers, team.GetSlug())
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// RequestCopilotReview creates a tool to request a Copilot review for a pull request.
+// Note that this tool will not work on GHES where this feature is unsupported. In future, we should not expose this
+// tool if the configured host does not support it.
+func RequestCopilotReview(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("request_copilot_review",
+			mcp.WithDescription(t("TOOL_REQUEST_COPILOT_REVIEW_DESCRIPTION", "Request a GitHub Copilot code review for a pull request. Use this for automated feedback on pull requests, usually before requesting a human reviewer.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REQUEST_COPILOT_REVIEW_USER_TITLE", "Request Copilot review"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			_, resp, err := client.PullRequests.RequestReviewers(
+				ctx,
+				owner,
+				repo,
+				pullNumber,
+				github.ReviewersRequest{
+					// The login name of the copilot reviewer bot
+					Reviewers: []string{"copilot-pull-request-reviewer[bot]"},
+				},
+			)
+			if result, ok, err := handleRESTResponse(ctx, "failed to request copilot review", resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			// Return nothing on success, as there's not much value in returning the Pull Request itself
+			return mcp.NewToolResultText(""), nil
+		}
+}
@@ -0,0 +1,654 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// CreateAndSubmitPullRequestReview creates a tool to create and submit a review on a pull request in one step.
+func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("create_and_submit_pull_request_review",
+			mcp.WithDescription(t("TOOL_CREATE_AND_SUBMIT_PULL_REQUEST_REVIEW_DESCRIPTION", "Create and submit a review for a pull request without review comments.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_AND_SUBMIT_PULL_REQUEST_REVIEW_USER_TITLE", "Create and submit a pull request review without comments"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			// Either we need the PR GQL Id directly, or we need owner, repo and PR number to look it up.
+			// Since our other Pull Request tools are working with the REST Client, will handle the lookup
+			// internally for now.
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Review comment text"),
+			),
+			mcp.WithString("event",
+				mcp.Required(),
+				mcp.Description("Review action to perform"),
+				mcp.Enum("APPROVE", "REQUEST_CHANGES", "COMMENT"),
+			),
+			mcp.WithString("commitID",
+				mcp.Description("SHA of commit to review"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+				Body       string
+				Event      string
+				CommitID   *string
+			}
+			if err := decodeParams(request, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Given our owner, repo and PR number, lookup the GQL ID of the PR.
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			pullRequestID, err := getPullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to get pull request",
+					err,
+				), nil
+			}
+
+			// Now we have the GQL ID, we can create a review
+			var addPullRequestReviewMutation struct {
+				AddPullRequestReview struct {
+					PullRequestReview struct {
+						ID githubv4.ID
+					}
+				} `graphql:"addPullRequestReview(input: $input)"`
+			}
+
+			if err := client.Mutate(
+				ctx,
+				&addPullRequestReviewMutation,
+				githubv4.AddPullRequestReviewInput{
+					PullRequestID: pullRequestID,
+					Body:          githubv4.NewString(githubv4.String(params.Body)),
+					Event:         newGQLStringlike[githubv4.PullRequestReviewEvent](params.Event),
+					CommitOID:     newGQLStringlikePtr[githubv4.GitObjectID](params.CommitID),
+				},
+				nil,
+			); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Return nothing interesting, just indicate success for the time being.
+			// In future, we may want to return the review ID, but for the moment, we're not leaking
+			// API implementation details to the LLM.
+			return mcp.NewToolResultText("pull request review submitted successfully"), nil
+		}
+}
+
+// CreatePendingPullRequestReview creates a tool to create a pending review on a pull request.
+func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("create_pending_pull_request_review",
+			mcp.WithDescription(t("TOOL_CREATE_PENDING_PULL_REQUEST_REVIEW_DESCRIPTION", "Create a pending review for a pull request. Call this first before attempting to add comments to a pending review, and ultimately submitting it. A pending pull request review means a pull request review, it is pending because you create it first and submit it later, and the PR author will not see it until it is submitted.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_PENDING_PULL_REQUEST_REVIEW_USER_TITLE", "Create pending pull request review"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			// Either we need the PR GQL Id directly, or we need owner, repo and PR number to look it up.
+			// Since our other Pull Request tools are working with the REST Client, will handle the lookup
+			// internally for now.
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("commitID",
+				mcp.Description("SHA of commit to review"),
+			),
+			// Event is omitted here because we always want to create a pending review.
+			// Threads are omitted for the moment, and we'll see if the LLM can use the appropriate tool.
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+				CommitID   *string
+			}
+			if err := decodeParams(request, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Given our owner, repo and PR number, lookup the GQL ID of the PR.
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			pullRequestID, err := getPullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to get pull request",
+					err,
+				), nil
+			}
+
+			// Now we have the GQL ID, we can create a pending review
+			var addPullRequestReviewMutation struct {
+				AddPullRequestReview struct {
+					PullRequestReview struct {
+						ID githubv4.ID
+					}
+				} `graphql:"addPullRequestReview(input: $input)"`
+			}
+
+			if err := client.Mutate(
+				ctx,
+				&addPullRequestReviewMutation,
+				githubv4.AddPullRequestReviewInput{
+					PullRequestID: pullRequestID,
+					CommitOID:     newGQLStringlikePtr[githubv4.GitObjectID](params.CommitID),
+				},
+				nil,
+			); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// The ID lets clients target this review later, even if the user has other reviews on the pull request.
+			return mcp.NewToolResultText(fmt.Sprintf("pending pull request review created, with the pullRequestReviewID %v", addPullRequestReviewMutation.AddPullRequestReview.PullRequestReview.ID)), nil
+		}
+}
+
+// AddCommentToPendingReview creates a tool to add a comment to a pull request review.
+func AddCommentToPendingReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("add_comment_to_pending_review",
+			mcp.WithDescription(t("TOOL_ADD_COMMENT_TO_PENDING_REVIEW_DESCRIPTION", "Add review comment to a pending pull request review, the requester's pending review unless pullRequestReviewID is given. A pending review needs to already exist to call this (check with the user if not sure).")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_COMMENT_TO_PENDING_REVIEW_USER_TITLE", "Add review comment to the requester's latest pending pull request review"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("pullRequestReviewID",
+				mcp.Description("ID of the pending review, as returned by create_pending_pull_request_review. Defaults to the requester's pending review"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("The relative path to the file that necessitates a comment"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("The text of the review comment"),
+			),
+			mcp.WithString("subjectType",
+				mcp.Required(),
+				mcp.Description("The level at which the comment is targeted"),
+				mcp.Enum("FILE", "LINE"),
+			),
+			mcp.WithNumber("line",
+				mcp.Description("The line of the blob in the pull request diff that the comment applies to. For multi-line comments, the last line of the range"),
+			),
+			mcp.WithString("side",
+				mcp.Description("The side of the diff to comment on. LEFT indicates the previous state, RIGHT indicates the new state"),
+				mcp.Enum("LEFT", "RIGHT"),
+			),
+			mcp.WithNumber("startLine",
+				mcp.Description("For multi-line comments, the first line of the range that the comment applies to"),
+			),
+			mcp.WithString("startSide",
+				mcp.Description("For multi-line comments, the starting side of the diff that the comment applies to. LEFT indicates the previous state, RIGHT indicates the new state"),
+				mcp.Enum("LEFT", "RIGHT"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner               string
+				Repo                string
+				PullNumber          int32
+				PullRequestReviewID *string
+				Path                string
+				Body                string
+				SubjectType         string
+				Line                *int32
+				Side                *string
+				StartLine           *int32
+				StartSide           *string
+			}
+			if err := decodeParams(request, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			reviewID, result := getPendingReviewID(ctx, client, params.PullRequestReviewID, params.Owner, params.Repo, params.PullNumber)
+			if result != nil {
+				return result, nil
+			}
+
+			// Then we can create a new review thread comment on the review.
+			var addPullRequestReviewThreadMutation struct {
+				AddPullRequestReviewThread struct {
+					Thread struct {
+						ID githubv4.ID // We don't need this, but a selector is required or GQL complains.
+					}
+				} `graphql:"addPullRequestReviewThread(input: $input)"`
+			}
+
+			if err := client.Mutate(
+				ctx,
+				&addPullRequestReviewThreadMutation,
+				githubv4.AddPullRequestReviewThreadInput{
+					Path:                githubv4.String(params.Path),
+					Body:                githubv4.String(params.Body),
+					SubjectType:         newGQLStringlikePtr[githubv4.PullRequestReviewThreadSubjectType](&params.SubjectType),
+					Line:                newGQLIntPtr(params.Line),
+					Side:                newGQLStringlikePtr[githubv4.DiffSide](params.Side),
+					StartLine:           newGQLIntPtr(params.StartLine),
+					StartSide:           newGQLStringlikePtr[githubv4.DiffSide](params.StartSide),
+					PullRequestReviewID: &reviewID,
+				},
+				nil,
+			); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Return nothing interesting, just indicate success for the time being.
+			// In future, we may want to return the review ID, but for the moment, we're not leaking
+			// API implementation details to the LLM.
+			return mcp.NewToolResultText("pull request review comment successfully added to pending review"), nil
+		}
+}
+
+// SubmitPendingPullRequestReview creates a tool to submit a pull request review.
+func SubmitPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("submit_pending_pull_request_review",
+			mcp.WithDescription(t("TOOL_SUBMIT_PENDING_PULL_REQUEST_REVIEW_DESCRIPTION", "Submit a pending pull request review, the requester's pending review unless pullRequestReviewID is given. Normally this is a final step after creating a pending review, adding comments first, unless you know that the user already did the first two steps, you should check before calling this.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUBMIT_PENDING_PULL_REQUEST_REVIEW_USER_TITLE", "Submit the requester's latest pending pull request review"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("pullRequestReviewID",
+				mcp.Description("ID of the pending review, as returned by create_pending_pull_request_review. Defaults to the requester's pending review"),
+			),
+			mcp.WithString("event",
+				mcp.Required(),
+				mcp.Description("The event to perform"),
+				mcp.Enum("APPROVE", "REQUEST_CHANGES", "COMMENT"),
+			),
+			mcp.WithString("body",
+				mcp.Description("The text of the review comment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner               string
+				Repo                string
+				PullNumber          int32
+				PullRequestReviewID *string
+				Event               string
+				Body                *string
+			}
+			if err := decodeParams(request, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			reviewID, result := getPendingReviewID(ctx, client, params.PullRequestReviewID, params.Owner, params.Repo, params.PullNumber)
+			if result != nil {
+				return result, nil
+			}
+
+			// Prepare the mutation
+			var submitPullRequestReviewMutation struct {
+				SubmitPullRequestReview struct {
+					PullRequestReview struct {
+						ID githubv4.ID // We don't need this, but a selector is required or GQL complains.
+					}
+				} `graphql:"submitPullRequestReview(input: $input)"`
+			}
+
+			if err := client.Mutate(
+				ctx,
+				&submitPullRequestReviewMutation,
+				githubv4.SubmitPullRequestReviewInput{
+					PullRequestReviewID: &reviewID,
+					Event:               githubv4.PullRequestReviewEvent(params.Event),
+					Body:                newGQLStringlikePtr[githubv4.String](params.Body),
+				},
+				nil,
+			); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to submit pull request review",
+					err,
+				), nil
+			}
+
+			// Return nothing interesting, just indicate success for the time being.
+			// In future, we may want to return the review ID, but for the moment, we're not leaking
+			// API implementation details to the LLM.
+			return mcp.NewToolResultText("pending pull request review successfully submitted"), nil
+		}
+}
+
+// DeletePendingPullRequestReview creates a tool to delete the requester's latest pending pull request review.
+func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_pending_pull_request_review",
+			mcp.WithDescription(t("TOOL_DELETE_PENDING_PULL_REQUEST_REVIEW_DESCRIPTION", "Delete a pending pull request review, the requester's pending review unless pullRequestReviewID is given. Use this after the user decides not to submit a pending review, if you don't know if they already created one then check first.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DELETE_PENDING_PULL_REQUEST_REVIEW_USER_TITLE", "Delete the requester's latest pending pull request review"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("pullRequestReviewID",
+				mcp.Description("ID of the pending review, as returned by create_pending_pull_request_review. Defaults to the requester's pending review"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner               string
+				Repo                string
+				PullNumber          int32
+				PullRequestReviewID *string
+			}
+			if err := decodeParams(request, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			reviewID, result := getPendingReviewID(ctx, client, params.PullRequestReviewID, params.Owner, params.Repo, params.PullNumber)
+			if result != nil {
+				return result, nil
+			}
+
+			// Prepare the mutation
+			var deletePullRequestReviewMutation struct {
+				DeletePullRequestReview struct {
+					PullRequestReview struct {
+						ID githubv4.ID // We don't need this, but a selector is required or GQL complains.
+					}
+				} `graphql:"deletePullRequestReview(input: $input)"`
+			}
+
+			if err := client.Mutate(
+				ctx,
+				&deletePullRequestReviewMutation,
+				githubv4.DeletePullRequestReviewInput{
+					PullRequestReviewID: &reviewID,
+				},
+				nil,
+			); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Return nothing interesting, just indicate success for the time being.
+			// In future, we may want to return the review ID, but for the moment, we're not leaking
+			// API implementation details to the LLM.
+			return mcp.NewToolResultText("pending pull request review successfully deleted"), nil
+		}
+}
+
+// ResolveReviewThread creates a tool to resolve a review thread on a pull request.
+func ResolveReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("resolve_review_thread",
+			mcp.WithDescription(t("TOOL_RESOLVE_REVIEW_THREAD_DESCRIPTION", "Resolve a review conversation thread on a pull request, e.g. once a fix for it has landed. Identify the thread by thread_id, or by path and line to resolve the unresolved thread on that line.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RESOLVE_REVIEW_THREAD_USER_TITLE", "Resolve review thread"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithReviewThreadParams(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setReviewThreadResolved(ctx, getGQLClient, request, true)
+		}
+}
+
+// UnresolveReviewThread creates a tool to unresolve a review thread on a pull request.
+func UnresolveReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("unresolve_review_thread",
+			mcp.WithDescription(t("TOOL_UNRESOLVE_REVIEW_THREAD_DESCRIPTION", "Unresolve a review conversation thread on a pull request, e.g. when a fix turned out to be incomplete. Identify the thread by thread_id, or by path and line to unresolve the resolved thread on that line.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UNRESOLVE_REVIEW_THREAD_USER_TITLE", "Unresolve review thread"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			WithReviewThreadParams(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return setReviewThreadResolved(ctx, getGQLClient, request, false)
+		}
+}
+
+// WithReviewThreadParams adds the parameters that identify a review thread on a pull request.
+func WithReviewThreadParams() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		)(tool)
+		mcp.WithNumber("pullNumber",
+			mcp.Required(),
+			mcp.Description("Pull request number"),
+		)(tool)
+		mcp.WithString("thread_id",
+			mcp.Description("Node ID of the review thread. Either thread_id, or path and line, must be given"),
+		)(tool)
+		mcp.WithString("path",
+			mcp.Description("Path of the file the thread is on, relative to the repository root"),
+		)(tool)
+		mcp.WithNumber("line",
+			mcp.Description("Line of the file the thread is on"),
+		)(tool)
+	}
+}
+
+// ReviewThreadState is the resolution state of a review thread after resolve_review_thread or unresolve_review_thread.
+type ReviewThreadState struct {
+	ThreadID   string `json:"threadId"`
+	IsResolved bool   `json:"isResolved"`
+}
+
+// maxReviewThreadPages caps how many pages of 100 review threads are searched for a thread by path and line.
+const maxReviewThreadPages = 10
+
+// setReviewThreadResolved resolves or unresolves the review thread identified by the request.
+func setReviewThreadResolved(ctx context.Context, getGQLClient GetGQLClientFn, request mcp.CallToolRequest, resolve bool) (*mcp.CallToolResult, error) {
+	var params struct {
+		Owner      string
+		Repo       string
+		PullNumber int32
+		ThreadID   string `mapstructure:"thread_id"`
+		Path       string
+		Line       int32
+	}
+	if err := decodeParams(request, &params); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.ThreadID == "" && (params.Path == "" || params.Line == 0) {
+		return mcp.NewToolResultError("either thread_id, or path and line, must be given"), nil
+	}
+
+	client, err := getGQLClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+	}
+
+	threadID := githubv4.ID(params.ThreadID)
+	if params.ThreadID == "" {
+		id, found, err := findReviewThread(ctx, client, params.Owner, params.Repo, params.PullNumber, params.Path, params.Line, !resolve)
+		if err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+				"failed to get review threads",
+				err,
+			), nil
+		}
+		if !found {
+			wanted := "unresolved"
+			if !resolve {
+				wanted = "resolved"
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("no %s review thread found on %s line %d of pull request %s/%s#%d", wanted, params.Path, params.Line, params.Owner, params.Repo, params.PullNumber)), nil
+		}
+		threadID = id
+	}
+
+	var thread struct {
+		ID         githubv4.ID
+		IsResolved githubv4.Boolean
+	}
+	if resolve {
+		var resolveReviewThreadMutation struct {
+			ResolveReviewThread struct {
+				Thread struct {
+					ID         githubv4.ID
+					IsResolved githubv4.Boolean
+				}
+			} `graphql:"resolveReviewThread(input: $input)"`
+		}
+		if err := client.Mutate(ctx, &resolveReviewThreadMutation, githubv4.ResolveReviewThreadInput{ThreadID: threadID}, nil); err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+				"failed to resolve review thread",
+				err,
+			), nil
+		}
+		thread = resolveReviewThreadMutation.ResolveReviewThread.Thread
+	} else {
+		var unresolveReviewThreadMutation struct {
+			UnresolveReviewThread struct {
+				Thread struct {
+					ID         githubv4.ID
+					IsResolved githubv4.Boolean
+				}
+			} `graphql:"unresolveReviewThread(input: $input)"`
+		}
+		if err := client.Mutate(ctx, &unresolveReviewThreadMutation, githubv4.UnresolveReviewThreadInput{ThreadID: threadID}, nil); err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+				"failed to unresolve review thread",
+				err,
+			), nil
+		}
+		thread = unresolveReviewThreadMutation.UnresolveReviewThread.Thread
+	}
+
+	return MarshalledTextResult(ReviewThreadState{
+		ThreadID:   fmt.Sprint(thread.ID),
+		IsResolved: bool(thread.IsResolved),
+	}), nil
+}
+
+// findReviewThread pages through the review threads of a pull request for the first one on path and line
+// whose resolution state is isResolved.
+func findReviewThread(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int32, path string, line int32, isResolved bool) (githubv4.ID, bool, error) {
+	var query struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes []struct {
+						ID         githubv4.ID
+						IsResolved githubv4.Boolean
+						Path       githubv4.String
+						Line       *githubv4.Int
+					}
+					PageInfo struct {
+						HasNextPage githubv4.Boolean
+						EndCursor   githubv4.String
+					}
+				} `graphql:"reviewThreads(first: 100, after: $after)"`
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(pullNumber),
+		"after": (*githubv4.String)(nil),
+	}
+	for page := 0; page < maxReviewThreadPages; page++ {
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return nil, false, err
+		}
+		threads := query.Repository.PullRequest.ReviewThreads
+		for _, thread := range threads.Nodes {
+			if string(thread.Path) == path && thread.Line != nil && int32(*thread.Line) == line && bool(thread.IsResolved) == isResolved {
+				return thread.ID, true, nil
+			}
+		}
+		if !threads.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = threads.PageInfo.EndCursor
+	}
+	return nil, false, nil
+}
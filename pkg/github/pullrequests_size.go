@@ -0,0 +1,274 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxPullRequestSizePages bounds the pages of files fetched to size a pull request. The API lists at
+// most 3000 files of a pull request, which is 30 pages of 100.
+const maxPullRequestSizePages = 30
+
+// sizeLabelPrefix starts the names of the labels apply_pull_request_size_label applies.
+const sizeLabelPrefix = "size/"
+
+// pullRequestSizeBuckets are the size buckets of a pull request, by the number of changed lines in its
+// source and test files: the first bucket whose limit is above the count applies, and XL has no limit.
+var pullRequestSizeBuckets = []struct {
+	size  string
+	limit int
+	color string
+}{
+	{"XS", 10, "3CBF00"},
+	{"S", 50, "5D9801"},
+	{"M", 250, "7F7203"},
+	{"L", 1000, "A14C05"},
+	{"XL", 0, "C32607"},
+}
+
+// pullRequestSizeBucket returns the size bucket for the given number of changed lines:
+// XS below 10, S below 50, M below 250, L below 1000 and XL from 1000.
+func pullRequestSizeBucket(changedLines int) string {
+	for _, bucket := range pullRequestSizeBuckets[:len(pullRequestSizeBuckets)-1] {
+		if changedLines < bucket.limit {
+			return bucket.size
+		}
+	}
+	return pullRequestSizeBuckets[len(pullRequestSizeBuckets)-1].size
+}
+
+// pullRequestSizeLabelColor returns the color of the label for a size bucket.
+func pullRequestSizeLabelColor(size string) string {
+	for _, bucket := range pullRequestSizeBuckets {
+		if bucket.size == size {
+			return bucket.color
+		}
+	}
+	return ""
+}
+
+// PullRequestSizeAnalysis is the result of analyze_pull_request_size and apply_pull_request_size_label,
+// which alone sets the label fields. ChangedLines and Size count the additions and deletions of source
+// and test files only, since generated files say little about the effort of a review.
+type PullRequestSizeAnalysis struct {
+	Size           string          `json:"size"`
+	ChangedLines   int             `json:"changed_lines"`
+	Source         FileChangeStats `json:"source"`
+	Test           FileChangeStats `json:"test"`
+	Generated      FileChangeStats `json:"generated"`
+	FilesTruncated bool            `json:"files_truncated"`
+	Label          string          `json:"label,omitempty"`
+	LabelCreated   bool            `json:"label_created,omitempty"`
+	RemovedLabels  []string        `json:"removed_labels,omitempty"`
+}
+
+// isTestFile reports whether filePath looks like a test, either by its name, such as foo_test.go,
+// foo.spec.ts or test_foo.py, or by being in a test directory.
+func isTestFile(filePath string) bool {
+	for _, dir := range strings.Split(path.Dir(filePath), "/") {
+		switch dir {
+		case "test", "tests", "__tests__", "spec", "testdata":
+			return true
+		}
+	}
+	name := path.Base(filePath)
+	stem := strings.TrimSuffix(name, path.Ext(name))
+	return strings.HasSuffix(stem, "_test") ||
+		strings.HasSuffix(stem, ".test") ||
+		strings.HasSuffix(stem, ".spec") ||
+		strings.HasPrefix(stem, "test_") ||
+		(path.Ext(name) == ".java" && strings.HasSuffix(stem, "Test"))
+}
+
+// analyzePullRequestSize sorts the changed files into generated, test and source files and buckets the
+// pull request by the changed lines of its source and test files.
+func analyzePullRequestSize(files []*github.CommitFile, rules []gitattributesRule) PullRequestSizeAnalysis {
+	var analysis PullRequestSizeAnalysis
+	for _, file := range files {
+		stats := &analysis.Source
+		switch {
+		case isGeneratedFile(file.GetFilename(), rules):
+			stats = &analysis.Generated
+		case isTestFile(file.GetFilename()):
+			stats = &analysis.Test
+		}
+		stats.Files++
+		stats.Additions += file.GetAdditions()
+		stats.Deletions += file.GetDeletions()
+	}
+	analysis.ChangedLines = analysis.Source.Additions + analysis.Source.Deletions + analysis.Test.Additions + analysis.Test.Deletions
+	analysis.Size = pullRequestSizeBucket(analysis.ChangedLines)
+	return analysis
+}
+
+// fetchPullRequestSizeAnalysis lists the changed files of a pull request and sizes it. When the files
+// cannot be listed, the returned result and error should be passed straight back to the caller.
+func fetchPullRequestSizeAnalysis(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (PullRequestSizeAnalysis, *mcp.CallToolResult, error) {
+	var files []*github.CommitFile
+	truncated := true
+	opts := &github.ListOptions{PerPage: 100}
+	for range maxPullRequestSizePages {
+		page, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+		if result, ok, err := handleRESTResponse(ctx, "failed to get pull request files", resp, err); !ok {
+			return PullRequestSizeAnalysis{}, result, err
+		}
+		files = append(files, page...)
+		if resp.NextPage == 0 {
+			truncated = false
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	analysis := analyzePullRequestSize(files, getPullRequestGeneratedAttributes(ctx, client, owner, repo, pullNumber))
+	analysis.FilesTruncated = truncated
+	return analysis, nil, nil
+}
+
+// AnalyzePullRequestSize creates a tool to size a pull request.
+func AnalyzePullRequestSize(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("analyze_pull_request_size",
+			mcp.WithDescription(t("TOOL_ANALYZE_PULL_REQUEST_SIZE_DESCRIPTION", "Count the changed files and lines of a pull request by source, test and generated files, and size it by the changed lines of its source and test files: XS below 10, S below 50, M below 250, L below 1000, XL from 1000. Use apply_pull_request_size_label to label the pull request with its size.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ANALYZE_PULL_REQUEST_SIZE_USER_TITLE", "Analyze pull request size"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			analysis, result, err := fetchPullRequestSizeAnalysis(ctx, client, owner, repo, pullNumber)
+			if result != nil || err != nil {
+				return result, err
+			}
+			return MarshalledTextResult(analysis), nil
+		}
+}
+
+// ApplyPullRequestSizeLabel creates a tool to size a pull request and label it with its size.
+func ApplyPullRequestSizeLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("apply_pull_request_size_label",
+			mcp.WithDescription(t("TOOL_APPLY_PULL_REQUEST_SIZE_LABEL_DESCRIPTION", "Size a pull request as analyze_pull_request_size does and apply the matching size/* label, creating it if missing and removing other size/* labels.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_APPLY_PULL_REQUEST_SIZE_LABEL_USER_TITLE", "Apply pull request size label"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			analysis, result, err := fetchPullRequestSizeAnalysis(ctx, client, owner, repo, pullNumber)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			label := sizeLabelPrefix + analysis.Size
+			// go-github puts label names in the path as is, and the slash of size/* must be escaped
+			_, resp, err := client.Issues.GetLabel(ctx, owner, repo, url.PathEscape(label))
+			closeResponseBody(resp)
+			if err != nil {
+				if resp == nil || resp.StatusCode != http.StatusNotFound {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get label", resp, err), nil
+				}
+				_, resp, err := client.Issues.CreateLabel(ctx, owner, repo, &github.Label{
+					Name:        github.Ptr(label),
+					Color:       github.Ptr(pullRequestSizeLabelColor(analysis.Size)),
+					Description: github.Ptr("Pull request size, set by apply_pull_request_size_label"),
+				})
+				if result, ok, err := handleRESTResponse(ctx, "failed to create label", resp, err, http.StatusCreated); !ok {
+					return result, err
+				}
+				analysis.LabelCreated = true
+			}
+
+			current, resp, err := client.Issues.ListLabelsByIssue(ctx, owner, repo, pullNumber, &github.ListOptions{PerPage: 100})
+			if result, ok, err := handleRESTResponse(ctx, "failed to list pull request labels", resp, err); !ok {
+				return result, err
+			}
+			for _, existing := range current {
+				name := existing.GetName()
+				if !strings.HasPrefix(name, sizeLabelPrefix) || name == label {
+					continue
+				}
+				resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, pullNumber, url.PathEscape(name))
+				if result, ok, err := handleRESTResponse(ctx, "failed to remove label", resp, err); !ok {
+					return result, err
+				}
+				analysis.RemovedLabels = append(analysis.RemovedLabels, name)
+			}
+
+			_, resp, err = client.Issues.AddLabelsToIssue(ctx, owner, repo, pullNumber, []string{label})
+			if result, ok, err := handleRESTResponse(ctx, "failed to apply label", resp, err); !ok {
+				return result, err
+			}
+			analysis.Label = label
+
+			return MarshalledTextResult(analysis), nil
+		}
+}
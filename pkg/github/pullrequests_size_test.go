@@ -0,0 +1,276 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PullRequestSizeBucket(t *testing.T) {
+	tests := []struct {
+		changedLines int
+		expected     string
+	}{
+		{0, "XS"},
+		{9, "XS"},
+		{10, "S"},
+		{49, "S"},
+		{50, "M"},
+		{249, "M"},
+		{250, "L"},
+		{999, "L"},
+		{1000, "XL"},
+		{250000, "XL"},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprint(tc.changedLines), func(t *testing.T) {
+			assert.Equal(t, tc.expected, pullRequestSizeBucket(tc.changedLines))
+		})
+	}
+}
+
+func Test_IsTestFile(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"pkg/github/issues_test.go", true},
+		{"web/src/button.test.tsx", true},
+		{"web/src/button.spec.js", true},
+		{"app/test_models.py", true},
+		{"src/test/java/com/example/ParserTest.java", true},
+		{"tests/fixtures/data.json", true},
+		{"web/__tests__/render.js", true},
+		{"pkg/github/testdata/response.json", true},
+		{"pkg/github/issues.go", false},
+		{"src/main/java/com/example/Test.java", true},
+		{"src/main/java/com/example/Contest.java", false},
+		{"docs/testing.md", false},
+		{"latest/notes.txt", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isTestFile(tc.path))
+		})
+	}
+}
+
+func Test_AnalyzePullRequestSizeFiles(t *testing.T) {
+	files := []*github.CommitFile{
+		{Filename: github.Ptr("pkg/github/issues.go"), Additions: github.Ptr(30), Deletions: github.Ptr(5)},
+		{Filename: github.Ptr("pkg/github/issues_test.go"), Additions: github.Ptr(40), Deletions: github.Ptr(0)},
+		{Filename: github.Ptr("go.sum"), Additions: github.Ptr(300), Deletions: github.Ptr(200)},
+		{Filename: github.Ptr("api/schema.pb.go"), Additions: github.Ptr(900), Deletions: github.Ptr(0)},
+	}
+	rules := []gitattributesRule{{pattern: "*.pb.go", generated: true}}
+
+	assert.Equal(t, PullRequestSizeAnalysis{
+		Size:         "M",
+		ChangedLines: 75,
+		Source:       FileChangeStats{Files: 1, Additions: 30, Deletions: 5},
+		Test:         FileChangeStats{Files: 1, Additions: 40},
+		Generated:    FileChangeStats{Files: 2, Additions: 1200, Deletions: 200},
+	}, analyzePullRequestSize(files, rules))
+}
+
+// pullRequestSizeFixtures returns mocks of the two pages of files of pull request 42, 20 changed source
+// and test lines in all, and of its missing .gitattributes, along with the analysis they make.
+func pullRequestSizeFixtures(t *testing.T) (mock.MockBackendOption, mock.MockBackendOption, PullRequestSizeAnalysis) {
+	filesPages := mock.WithRequestMatchHandler(
+		mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "2" {
+				_ = json.NewEncoder(w).Encode([]*github.CommitFile{
+					{Filename: github.Ptr("package-lock.json"), Additions: github.Ptr(700), Deletions: github.Ptr(300)},
+				})
+				return
+			}
+			w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/pulls/42/files?page=2&per_page=100>; rel="next"`)
+			_ = json.NewEncoder(w).Encode([]*github.CommitFile{
+				{Filename: github.Ptr("main.go"), Additions: github.Ptr(12), Deletions: github.Ptr(3)},
+				{Filename: github.Ptr("main_test.go"), Additions: github.Ptr(5), Deletions: github.Ptr(0)},
+			})
+		}),
+	)
+	noGitattributes := mock.WithRequestMatchHandler(
+		mock.GetReposContentsByOwnerByRepoByPath,
+		mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+	)
+	expectedAnalysis := PullRequestSizeAnalysis{
+		Size:         "S",
+		ChangedLines: 20,
+		Source:       FileChangeStats{Files: 1, Additions: 12, Deletions: 3},
+		Test:         FileChangeStats{Files: 1, Additions: 5},
+		Generated:    FileChangeStats{Files: 1, Additions: 700, Deletions: 300},
+	}
+	return filesPages, noGitattributes, expectedAnalysis
+}
+
+func Test_AnalyzePullRequestSize(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AnalyzePullRequestSize(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "analyze_pull_request_size", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	filesPages, noGitattributes, expectedAnalysis := pullRequestSizeFixtures(t)
+
+	t.Run("analysis", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(filesPages, noGitattributes))
+		_, handler := AnalyzePullRequestSize(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var analysis PullRequestSizeAnalysis
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &analysis))
+		assert.Equal(t, expectedAnalysis, analysis)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+		))
+		_, handler := AnalyzePullRequestSize(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(999),
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get pull request files")
+	})
+}
+
+func Test_ApplyPullRequestSizeLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ApplyPullRequestSizeLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "apply_pull_request_size_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+
+	filesPages, noGitattributes, expectedAnalysis := pullRequestSizeFixtures(t)
+
+	t.Run("creates and applies the label, replacing a stale size label", func(t *testing.T) {
+		var removed []string
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			filesPages,
+			noGitattributes,
+			mock.WithRequestMatchHandler(
+				mock.GetReposLabelsByOwnerByRepoByName,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "/repos/owner/repo/labels/size%2FS", r.URL.EscapedPath())
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposLabelsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"name":        "size/S",
+					"color":       "5D9801",
+					"description": "Pull request size, set by apply_pull_request_size_label",
+				}).andThen(
+					mockResponse(t, http.StatusCreated, &github.Label{Name: github.Ptr("size/S")}),
+				),
+			),
+			mock.WithRequestMatch(
+				mock.GetReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				[]*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("size/XL")}},
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					removed = append(removed, r.URL.EscapedPath())
+					_ = json.NewEncoder(w).Encode([]*github.Label{{Name: github.Ptr("bug")}})
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				expectRequestBody(t, []any{"size/S"}).andThen(
+					mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("size/S")}}),
+				),
+			),
+		))
+		_, handler := ApplyPullRequestSizeLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var analysis PullRequestSizeAnalysis
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &analysis))
+		expected := expectedAnalysis
+		expected.Label = "size/S"
+		expected.LabelCreated = true
+		expected.RemovedLabels = []string{"size/XL"}
+		assert.Equal(t, expected, analysis)
+		assert.Equal(t, []string{"/repos/owner/repo/issues/42/labels/size%2FXL"}, removed)
+	})
+
+	t.Run("applies an existing label", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			filesPages,
+			noGitattributes,
+			mock.WithRequestMatch(
+				mock.GetReposLabelsByOwnerByRepoByName,
+				&github.Label{Name: github.Ptr("size/S")},
+			),
+			mock.WithRequestMatch(
+				mock.GetReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				[]*github.Label{},
+			),
+			mock.WithRequestMatch(
+				mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+				[]*github.Label{{Name: github.Ptr("size/S")}},
+			),
+		))
+		_, handler := ApplyPullRequestSizeLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var analysis PullRequestSizeAnalysis
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &analysis))
+		assert.Equal(t, "size/S", analysis.Label)
+		assert.False(t, analysis.LabelCreated)
+		assert.Empty(t, analysis.RemovedLabels)
+	})
+}
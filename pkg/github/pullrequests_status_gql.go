@@ -0,0 +1,159 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// OpenPullRequestStatus is an open pull request with its review and check status, as returned by
+// list_open_pull_requests_status. ChecksState is the combined state of the statuses and check runs of
+// the head commit, and is empty when it has none.
+type OpenPullRequestStatus struct {
+	Number         int    `json:"number"`
+	Title          string `json:"title"`
+	URL            string `json:"url"`
+	Author         string `json:"author"`
+	IsDraft        bool   `json:"isDraft"`
+	ReviewDecision string `json:"reviewDecision,omitempty"`
+	Mergeable      string `json:"mergeable"`
+	ChecksState    string `json:"checksState,omitempty"`
+}
+
+// OpenPullRequestsStatusResult is a page of pull requests returned by list_open_pull_requests_status.
+type OpenPullRequestsStatusResult struct {
+	PullRequests []OpenPullRequestStatus `json:"pullRequests"`
+	Pagination
+}
+
+// ListOpenPullRequestsStatus creates a tool to list the open pull requests of a repository along with
+// their review decision, mergeability and check status, in a single GraphQL query.
+func ListOpenPullRequestsStatus(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_open_pull_requests_status",
+			mcp.WithDescription(t("TOOL_LIST_OPEN_PULL_REQUESTS_STATUS_DESCRIPTION", "List the open pull requests of a repository, most recently updated first, with their author, draft state, review decision, mergeability and the combined state of the head commit's statuses and check runs. Use this to find out which pull requests are approved and passing without looking up each one.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_OPEN_PULL_REQUESTS_STATUS_USER_TITLE", "List open pull requests with review and check status"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithCursorPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalCursorPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paginationParams, err := pagination.ToGraphQLParams()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+			}
+
+			var query struct {
+				Repository struct {
+					PullRequests struct {
+						Nodes []struct {
+							Number         githubv4.Int
+							Title          githubv4.String
+							URL            githubv4.String `graphql:"url"`
+							IsDraft        githubv4.Boolean
+							ReviewDecision githubv4.String
+							Mergeable      githubv4.String
+							Author         struct {
+								Login githubv4.String
+							}
+							Commits struct {
+								Nodes []struct {
+									Commit struct {
+										StatusCheckRollup struct {
+											State githubv4.String
+										}
+									}
+								}
+							} `graphql:"commits(last: 1)"`
+						}
+						PageInfo struct {
+							HasNextPage     bool
+							HasPreviousPage bool
+							StartCursor     string
+							EndCursor       string
+						}
+						TotalCount int
+					} `graphql:"pullRequests(states: OPEN, first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC})"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]interface{}{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"first": githubv4.Int(*paginationParams.First),
+			}
+			if paginationParams.After != nil {
+				vars["after"] = githubv4.String(*paginationParams.After)
+			} else {
+				vars["after"] = (*githubv4.String)(nil)
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					"failed to list open pull requests",
+					err,
+				), nil
+			}
+
+			pullRequests := make([]OpenPullRequestStatus, 0, len(query.Repository.PullRequests.Nodes))
+			for _, n := range query.Repository.PullRequests.Nodes {
+				pr := OpenPullRequestStatus{
+					Number:         int(n.Number),
+					Title:          string(n.Title),
+					URL:            string(n.URL),
+					Author:         string(n.Author.Login),
+					IsDraft:        bool(n.IsDraft),
+					ReviewDecision: string(n.ReviewDecision),
+					Mergeable:      string(n.Mergeable),
+				}
+				if len(n.Commits.Nodes) > 0 {
+					pr.ChecksState = string(n.Commits.Nodes[0].Commit.StatusCheckRollup.State)
+				}
+				pullRequests = append(pullRequests, pr)
+			}
+
+			pageInfo := query.Repository.PullRequests.PageInfo
+			return MarshalledTextResult(OpenPullRequestsStatusResult{
+				PullRequests: pullRequests,
+				Pagination: Pagination{
+					PageInfo: PageInfo{
+						HasNextPage:     pageInfo.HasNextPage,
+						HasPreviousPage: pageInfo.HasPreviousPage,
+						StartCursor:     pageInfo.StartCursor,
+						EndCursor:       pageInfo.EndCursor,
+					},
+					TotalCount: query.Repository.PullRequests.TotalCount,
+					NextCall:   CursorNextCall(pageInfo.HasNextPage, pageInfo.EndCursor, *paginationParams.First),
+				},
+			}), nil
+		}
+}
@@ -2,15 +2,25 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"maps"
 	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/github/github-mcp-server/internal/diff"
 	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/shurcooL/githubv4"
 
 	"github.com/migueleliasweb/go-github-mock/src/mock"
@@ -450,10 +460,194 @@ func Test_ListPullRequests(t *testing.T) {
 	}
 }
 
+func Test_ListPullRequestsMergedRange(t *testing.T) {
+	mergedAt := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	searchResult := &github.IssuesSearchResult{
+		Total: github.Ptr(3),
+		Issues: []*github.Issue{
+			{Number: github.Ptr(42)},
+			{Number: github.Ptr(43)},
+			{Number: github.Ptr(44)},
+		},
+	}
+
+	var fetched []string
+	getPullRequest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		number := path.Base(r.URL.Path)
+		fetched = append(fetched, number)
+		n, _ := strconv.Atoi(number)
+		mockResponse(t, http.StatusOK, &github.PullRequest{
+			Number:   github.Ptr(n),
+			Title:    github.Ptr("PR " + number),
+			State:    github.Ptr("closed"),
+			Merged:   github.Ptr(true),
+			MergedAt: &github.Timestamp{Time: mergedAt},
+			MergedBy: &github.User{Login: github.Ptr("merger")},
+		}).ServeHTTP(w, r)
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedFetched []string
+	}{
+		{
+			name: "searches merged range and enriches within page size",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(t, map[string]string{
+						"q":        "is:pr is:merged repo:owner/repo merged:2024-03-01..2024-03-31 base:main",
+						"sort":     "updated",
+						"order":    "desc",
+						"per_page": "2",
+						"page":     "1",
+					}).andThen(
+						mockResponse(t, http.StatusOK, searchResult),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					getPullRequest,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"base":          "main",
+				"sort":          "updated",
+				"direction":     "desc",
+				"merged_after":  "2024-03-01",
+				"merged_before": "2024-03-31",
+				"perPage":       float64(2),
+			},
+			expectedFetched: []string{"42", "43"},
+		},
+		{
+			name: "rejects open state",
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"state":        "open",
+				"merged_after": "2024-03-01",
+			},
+			expectError:    true,
+			expectedErrMsg: "cannot be combined with state=open",
+		},
+		{
+			name: "search fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"merged_before": "2024-03-31",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to search merged pull requests",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fetched = nil
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListPullRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returnedPRs []*github.PullRequest
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedPRs))
+			assert.Equal(t, tc.expectedFetched, fetched)
+			require.Len(t, returnedPRs, len(tc.expectedFetched))
+			for _, pr := range returnedPRs {
+				assert.Equal(t, "merger", pr.GetMergedBy().GetLogin())
+				assert.Equal(t, mergedAt, pr.GetMergedAt().Time)
+			}
+		})
+	}
+}
+
+func Test_MergedPullRequestsQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		base          string
+		head          string
+		mergedAfter   string
+		mergedBefore  string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:         "closed range",
+			mergedAfter:  "2024-01-01",
+			mergedBefore: "2024-02-01",
+			expected:     "is:pr is:merged repo:owner/repo merged:2024-01-01..2024-02-01",
+		},
+		{
+			name:        "open ended after",
+			mergedAfter: "2024-01-01T10:00:00Z",
+			expected:    "is:pr is:merged repo:owner/repo merged:>=2024-01-01T10:00:00Z",
+		},
+		{
+			name:         "open ended before with branch filters",
+			base:         "main",
+			head:         "octocat:feature",
+			mergedBefore: "2024-02-01",
+			expected:     "is:pr is:merged repo:owner/repo merged:<=2024-02-01 base:main head:feature",
+		},
+		{
+			name:          "invalid date",
+			mergedAfter:   "last week",
+			expectedError: "invalid merged_after",
+		},
+		{
+			name:          "inverted range",
+			mergedAfter:   "2024-02-01",
+			mergedBefore:  "2024-01-01",
+			expectedError: "must not be later than merged_before",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := mergedPullRequestsQuery("owner", "repo", tc.base, tc.head, tc.mergedAfter, tc.mergedBefore)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, query)
+		})
+	}
+}
+
 func Test_MergePullRequest(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := MergePullRequest(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := MergePullRequest(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "merge_pull_request", tool.Name)
@@ -464,6 +658,7 @@ func Test_MergePullRequest(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "commit_title")
 	assert.Contains(t, tool.InputSchema.Properties, "commit_message")
 	assert.Contains(t, tool.InputSchema.Properties, "merge_method")
+	assert.Contains(t, tool.InputSchema.Properties, "enable_auto_merge")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
 
 	// Setup mock merge result for success case
@@ -473,12 +668,52 @@ func Test_MergePullRequest(t *testing.T) {
 		SHA:     github.Ptr("abcd1234efgh5678"),
 	}
 
+	notMergeable := mock.WithRequestMatchHandler(
+		mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			_, _ = w.Write([]byte(`{"message": "Required status check \"build\" is expected."}`))
+		}),
+	)
+	pullRequestIDQuery := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				PullRequest struct {
+					ID githubv4.ID
+				} `graphql:"pullRequest(number: $prNum)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"prNum": githubv4.Int(42),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{
+					"id": "PR_kwDODKw3uc6WYN1T",
+				},
+			},
+		}),
+	)
+	enableAutoMergeMutation := struct {
+		EnablePullRequestAutoMerge struct {
+			PullRequest struct {
+				AutoMergeRequest struct {
+					MergeMethod githubv4.PullRequestMergeMethod
+				}
+			}
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}{}
+
 	tests := []struct {
 		name                string
 		mockedClient        *http.Client
+		mockedGQLClient     *http.Client
 		requestArgs         map[string]interface{}
 		expectError         bool
 		expectedMergeResult *github.PullRequestMergeResult
+		expectedText        string
 		expectedErrMsg      string
 	}{
 		{
@@ -506,6 +741,23 @@ func Test_MergePullRequest(t *testing.T) {
 			expectError:         false,
 			expectedMergeResult: mockMergeResult,
 		},
+		{
+			name: "mergeable pull request is merged directly even with enable_auto_merge",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+					mockMergeResult,
+				),
+			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"pullNumber":        float64(42),
+				"enable_auto_merge": true,
+			},
+			expectedMergeResult: mockMergeResult,
+		},
 		{
 			name: "merge fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -517,6 +769,7 @@ func Test_MergePullRequest(t *testing.T) {
 					}),
 				),
 			),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(),
 			requestArgs: map[string]interface{}{
 				"owner":      "owner",
 				"repo":       "repo",
@@ -525,13 +778,71 @@ func Test_MergePullRequest(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to merge pull request",
 		},
+		{
+			name:         "blocked pull request has auto-merge enabled",
+			mockedClient: mock.NewMockedHTTPClient(notMergeable),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				pullRequestIDQuery,
+				githubv4mock.NewMutationMatcher(
+					enableAutoMergeMutation,
+					githubv4.EnablePullRequestAutoMergeInput{
+						PullRequestID:  githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+						CommitHeadline: githubv4.NewString("Add feature (#42)"),
+						MergeMethod:    githubv4mock.Ptr(githubv4.PullRequestMergeMethodSquash),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"enablePullRequestAutoMerge": map[string]any{
+							"pullRequest": map[string]any{
+								"autoMergeRequest": map[string]any{
+									"mergeMethod": "SQUASH",
+								},
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"pullNumber":        float64(42),
+				"commit_title":      "Add feature (#42)",
+				"merge_method":      "squash",
+				"enable_auto_merge": true,
+			},
+			expectedText: "Pull request owner/repo#42 was NOT merged yet because it is not mergeable now. Auto-merge is enabled with the squash method",
+		},
+		{
+			name:         "enabling auto-merge fails",
+			mockedClient: mock.NewMockedHTTPClient(notMergeable),
+			mockedGQLClient: githubv4mock.NewMockedHTTPClient(
+				pullRequestIDQuery,
+				githubv4mock.NewMutationMatcher(
+					enableAutoMergeMutation,
+					githubv4.EnablePullRequestAutoMergeInput{
+						PullRequestID: githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+					},
+					nil,
+					githubv4mock.ErrorResponse("Auto merge is not allowed for this repository"),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"pullNumber":        float64(42),
+				"enable_auto_merge": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "Auto merge is not allowed for this repository",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := MergePullRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+			gqlClient := githubv4.NewClient(tc.mockedGQLClient)
+			_, handler := MergePullRequest(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -554,6 +865,11 @@ func Test_MergePullRequest(t *testing.T) {
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
+			if tc.expectedText != "" {
+				assert.Contains(t, textContent.Text, tc.expectedText)
+				return
+			}
+
 			// Unmarshal and verify the result
 			var returnedResult github.PullRequestMergeResult
 			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
@@ -771,8 +1087,8 @@ func Test_SearchPullRequests(t *testing.T) {
 
 			// Verify results
 			if tc.expectError {
-				require.Error(t, err)
-				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				require.NoError(t, err)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
 				return
 			}
 
@@ -811,6 +1127,8 @@ func Test_GetPullRequestFiles(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude_generated")
+	assert.Contains(t, tool.InputSchema.Properties, "output")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
@@ -841,6 +1159,7 @@ func Test_GetPullRequestFiles(t *testing.T) {
 		requestArgs    map[string]interface{}
 		expectError    bool
 		expectedFiles  []*github.CommitFile
+		expectedPage   NextPageInfo
 		expectedErrMsg string
 	}{
 		{
@@ -858,13 +1177,16 @@ func Test_GetPullRequestFiles(t *testing.T) {
 			},
 			expectError:   false,
 			expectedFiles: mockFiles,
+			expectedPage:  NextPageInfo{},
 		},
 		{
 			name: "successful files fetch with pagination",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
+				mock.WithRequestMatchHandler(
 					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
-					mockFiles,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "10"}).andThen(
+						mockResponse(t, http.StatusOK, mockFiles),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
@@ -876,6 +1198,48 @@ func Test_GetPullRequestFiles(t *testing.T) {
 			},
 			expectError:   false,
 			expectedFiles: mockFiles,
+			expectedPage:  NextPageInfo{},
+		},
+		{
+			name: "full page with more files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Link", `<https://api.github.com/repositories/1/pulls/42/files?page=2&per_page=2>; rel="next", <https://api.github.com/repositories/1/pulls/42/files?page=3&per_page=2>; rel="last"`)
+						_ = json.NewEncoder(w).Encode(mockFiles)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"perPage":    float64(2),
+			},
+			expectedFiles: mockFiles,
+			expectedPage:  NextPageInfo{HasNextPage: true, NextPage: 2},
+		},
+		{
+			name: "full last page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Link", `<https://api.github.com/repositories/1/pulls/42/files?page=2&per_page=2>; rel="prev", <https://api.github.com/repositories/1/pulls/42/files?page=1&per_page=2>; rel="first"`)
+						_ = json.NewEncoder(w).Encode(mockFiles)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"page":       float64(3),
+				"perPage":    float64(2),
+			},
+			expectedFiles: mockFiles,
+			expectedPage:  NextPageInfo{},
 		},
 		{
 			name: "files fetch fails",
@@ -926,9 +1290,11 @@ func Test_GetPullRequestFiles(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedFiles []*github.CommitFile
-			err = json.Unmarshal([]byte(textContent.Text), &returnedFiles)
+			var returned PullRequestFilesResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
 			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPage, returned.NextPageInfo)
+			returnedFiles := returned.Files
 			assert.Len(t, returnedFiles, len(tc.expectedFiles))
 			for i, file := range returnedFiles {
 				assert.Equal(t, *tc.expectedFiles[i].Filename, *file.Filename)
@@ -940,112 +1306,1185 @@ func Test_GetPullRequestFiles(t *testing.T) {
 	}
 }
 
-func Test_GetPullRequestStatus(t *testing.T) {
-	// Verify tool definition once
-	mockClient := github.NewClient(nil)
-	tool, _ := GetPullRequestStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
-	require.NoError(t, toolsnaps.Test(tool.Name, tool))
-
-	assert.Equal(t, "get_pull_request_status", tool.Name)
-	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
-
-	// Setup mock PR for successful PR fetch
-	mockPR := &github.PullRequest{
-		Number:  github.Ptr(42),
-		Title:   github.Ptr("Test PR"),
-		HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42"),
-		Head: &github.PullRequestBranch{
-			SHA: github.Ptr("abcd1234"),
-			Ref: github.Ptr("feature-branch"),
+func Test_GetPullRequestFilesHunks(t *testing.T) {
+	mockFiles := []*github.CommitFile{
+		{
+			Filename:  github.Ptr("main.go"),
+			Status:    github.Ptr("modified"),
+			Additions: github.Ptr(1),
+			Deletions: github.Ptr(1),
+			Patch:     github.Ptr("@@ -3,3 +3,3 @@ func main() {\n \ta := 1\n-\tb := 2\n+\tb := 3\n }"),
 		},
-	}
-
-	// Setup mock status for success case
-	mockStatus := &github.CombinedStatus{
-		State:      github.Ptr("success"),
-		TotalCount: github.Ptr(3),
-		Statuses: []*github.RepoStatus{
-			{
-				State:       github.Ptr("success"),
-				Context:     github.Ptr("continuous-integration/travis-ci"),
-				Description: github.Ptr("Build succeeded"),
-				TargetURL:   github.Ptr("https://travis-ci.org/owner/repo/builds/123"),
-			},
-			{
-				State:       github.Ptr("success"),
-				Context:     github.Ptr("codecov/patch"),
-				Description: github.Ptr("Coverage increased"),
-				TargetURL:   github.Ptr("https://codecov.io/gh/owner/repo/pull/42"),
-			},
-			{
-				State:       github.Ptr("success"),
-				Context:     github.Ptr("lint/golangci-lint"),
-				Description: github.Ptr("No issues found"),
-				TargetURL:   github.Ptr("https://golangci.com/r/owner/repo/pull/42"),
-			},
+		{
+			Filename:         github.Ptr("docs/new.md"),
+			PreviousFilename: github.Ptr("docs/old.md"),
+			Status:           github.Ptr("renamed"),
+		},
+		{
+			Filename:  github.Ptr("broken.txt"),
+			Status:    github.Ptr("modified"),
+			Additions: github.Ptr(10),
+			Deletions: github.Ptr(5),
+			Patch:     github.Ptr("@@ -1,5 +1,10 @@"),
 		},
 	}
 
 	tests := []struct {
 		name           string
-		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedStatus *github.CombinedStatus
 		expectedErrMsg string
 	}{
 		{
-			name: "successful status fetch",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposPullsByOwnerByRepoByPullNumber,
-					mockPR,
-				),
-				mock.WithRequestMatch(
-					mock.GetReposCommitsStatusByOwnerByRepoByRef,
-					mockStatus,
-				),
-			),
+			name: "hunks output",
 			requestArgs: map[string]interface{}{
 				"owner":      "owner",
 				"repo":       "repo",
 				"pullNumber": float64(42),
+				"output":     "hunks",
 			},
-			expectError:    false,
-			expectedStatus: mockStatus,
 		},
 		{
-			name: "PR fetch fails",
-			mockedClient: mock.NewMockedHTTPClient(
+			name: "invalid output",
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"output":     "patch",
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid output "patch"`,
+		},
+		{
+			name: "hunks output cannot be summarized",
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"output":       "hunks",
+				"summary_only": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "summary_only cannot be combined with output 'hunks'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					mockFiles,
+				),
+			))
+			_, handler := GetPullRequestFiles(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var hunksResult PullRequestFileHunksResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &hunksResult))
+			returned := hunksResult.Files
+			require.Len(t, returned, 3)
+
+			assert.Equal(t, PullRequestFileHunks{
+				Filename:  "main.go",
+				Status:    "modified",
+				Additions: 1,
+				Deletions: 1,
+				Hunks: []diff.Hunk{
+					{
+						OldStart: 3, OldLines: 3, NewStart: 3, NewLines: 3, Section: "func main() {",
+						Lines: []diff.Line{
+							{Kind: diff.LineContext, OldLine: 3, NewLine: 3, Content: "\ta := 1"},
+							{Kind: diff.LineDeleted, OldLine: 4, Content: "\tb := 2"},
+							{Kind: diff.LineAdded, NewLine: 4, Content: "\tb := 3"},
+							{Kind: diff.LineContext, OldLine: 5, NewLine: 5, Content: "}"},
+						},
+					},
+				},
+				CommentableLines: CommentableLines{
+					Left:  []diff.LineRange{{Start: 3, End: 5}},
+					Right: []diff.LineRange{{Start: 3, End: 5}},
+				},
+			}, returned[0])
+
+			assert.Equal(t, PullRequestFileHunks{
+				Filename:         "docs/new.md",
+				PreviousFilename: "docs/old.md",
+				Status:           "renamed",
+				Hunks:            []diff.Hunk{},
+				CommentableLines: CommentableLines{Left: []diff.LineRange{}, Right: []diff.LineRange{}},
+			}, returned[1])
+
+			assert.Equal(t, "broken.txt", returned[2].Filename)
+			assert.Empty(t, returned[2].Hunks)
+			assert.Contains(t, returned[2].ParseError, "lines short")
+		})
+	}
+}
+
+func Test_GetPullRequestFilesExcludeGenerated(t *testing.T) {
+	mockFiles := []*github.CommitFile{
+		{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(10), Deletions: github.Ptr(5)},
+		{Filename: github.Ptr("go.sum"), Status: github.Ptr("modified"), Additions: github.Ptr(120), Deletions: github.Ptr(80)},
+		{Filename: github.Ptr("web/dist/app.js"), Status: github.Ptr("modified"), Additions: github.Ptr(300), Deletions: github.Ptr(200)},
+		{Filename: github.Ptr("api/client_gen.go"), Status: github.Ptr("added"), Additions: github.Ptr(50), Deletions: github.Ptr(0)},
+	}
+	mockAttributes := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("*_gen.go linguist-generated=true\n*.go text eol=lf\n"))),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectedFiles  []string
+		expectedResult PullRequestFilesResult
+	}{
+		{
+			name: "built-in patterns and gitattributes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					mockFiles,
+				),
 				mock.WithRequestMatchHandler(
-					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expect(t, expectations{
+						path:        "/repos/owner/repo/contents/.gitattributes",
+						queryParams: map[string]string{"ref": "refs/pull/42/head"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAttributes),
+					),
+				),
+			),
+			expectedFiles: []string{"main.go"},
+			expectedResult: PullRequestFilesResult{
+				ExcludedFiles:     3,
+				ExcludedAdditions: 470,
+				ExcludedDeletions: 280,
+			},
+		},
+		{
+			name: "missing gitattributes uses built-in patterns only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					mockFiles,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 						w.WriteHeader(http.StatusNotFound)
 						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
 					}),
 				),
 			),
+			expectedFiles: []string{"main.go", "api/client_gen.go"},
+			expectedResult: PullRequestFilesResult{
+				ExcludedFiles:     2,
+				ExcludedAdditions: 420,
+				ExcludedDeletions: 280,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestFiles(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"pullNumber":        float64(42),
+				"exclude_generated": true,
+			})
+
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned PullRequestFilesResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+
+			var filenames []string
+			for _, file := range returned.Files {
+				filenames = append(filenames, file.GetFilename())
+			}
+			assert.Equal(t, tc.expectedFiles, filenames)
+			assert.Equal(t, tc.expectedResult.ExcludedFiles, returned.ExcludedFiles)
+			assert.Equal(t, tc.expectedResult.ExcludedAdditions, returned.ExcludedAdditions)
+			assert.Equal(t, tc.expectedResult.ExcludedDeletions, returned.ExcludedDeletions)
+		})
+	}
+}
+
+func Test_GetPullRequestFilesSummaryOnly(t *testing.T) {
+	mockFiles := []*github.CommitFile{
+		{Filename: github.Ptr("pkg/github/server.go"), Status: github.Ptr("modified"), Additions: github.Ptr(10), Deletions: github.Ptr(5)},
+		{Filename: github.Ptr("pkg/github/server_test.go"), Status: github.Ptr("modified"), Additions: github.Ptr(20), Deletions: github.Ptr(1)},
+		{Filename: github.Ptr("README.md"), Status: github.Ptr("modified"), Additions: github.Ptr(3), Deletions: github.Ptr(2)},
+	}
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+			mockFiles,
+		),
+	))
+	_, handler := GetPullRequestFiles(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"pullNumber":   float64(42),
+		"summary_only": true,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	text := getTextResult(t, result).Text
+	assert.NotContains(t, text, "server_test.go")
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(text), &fields))
+	assert.ElementsMatch(t, []string{"total", "by_directory", "by_extension", "has_next_page"}, slices.Collect(maps.Keys(fields)))
+
+	var summary PullRequestFilesSummary
+	require.NoError(t, json.Unmarshal([]byte(text), &summary))
+	assert.Equal(t, FileChangeStats{Files: 3, Additions: 33, Deletions: 8}, summary.Total)
+	assert.Equal(t, FileChangeStats{Files: 2, Additions: 30, Deletions: 6}, summary.ByDirectory["pkg/github"])
+	assert.Equal(t, FileChangeStats{Files: 1, Additions: 3, Deletions: 2}, summary.ByExtension[".md"])
+}
+
+func Test_SummarizePullRequestFiles(t *testing.T) {
+	files := []*github.CommitFile{
+		{Filename: github.Ptr("cmd/main.go"), Additions: github.Ptr(4), Deletions: github.Ptr(1)},
+		{Filename: github.Ptr("pkg/a.go"), Additions: github.Ptr(2), Deletions: github.Ptr(2)},
+		{Filename: github.Ptr("pkg/b.go"), Additions: github.Ptr(1)},
+		{Filename: github.Ptr("Makefile"), Deletions: github.Ptr(7)},
+	}
+
+	summary := summarizePullRequestFiles(files)
+	assert.Equal(t, FileChangeStats{Files: 4, Additions: 7, Deletions: 10}, summary.Total)
+	assert.Equal(t, map[string]FileChangeStats{
+		"cmd": {Files: 1, Additions: 4, Deletions: 1},
+		"pkg": {Files: 2, Additions: 3, Deletions: 2},
+		".":   {Files: 1, Deletions: 7},
+	}, summary.ByDirectory)
+	assert.Equal(t, map[string]FileChangeStats{
+		".go":    {Files: 3, Additions: 7, Deletions: 3},
+		"(none)": {Files: 1, Deletions: 7},
+	}, summary.ByExtension)
+
+	empty := summarizePullRequestFiles(nil)
+	assert.Equal(t, FileChangeStats{}, empty.Total)
+	assert.Empty(t, empty.ByDirectory)
+	assert.Empty(t, empty.ByExtension)
+}
+
+func Test_ParseGeneratedAttributes(t *testing.T) {
+	content := `# Generated code
+*_gen.go linguist-generated
+api/** linguist-generated=true text
+docs/dist/** -linguist-generated
+legacy/** !linguist-generated
+vendor/** linguist-generated=false
+*.go text eol=lf
+
+*.pb.go   binary   linguist-generated
+`
+
+	expected := []gitattributesRule{
+		{pattern: "*_gen.go", generated: true},
+		{pattern: "api/**", generated: true},
+		{pattern: "docs/dist/**", generated: false},
+		{pattern: "legacy/**", generated: false},
+		{pattern: "vendor/**", generated: false},
+		{pattern: "*.pb.go", generated: true},
+	}
+
+	assert.Equal(t, expected, parseGeneratedAttributes(content))
+	assert.Empty(t, parseGeneratedAttributes(""))
+}
+
+func Test_IsGeneratedFile(t *testing.T) {
+	rules := []gitattributesRule{
+		{pattern: "*_gen.go", generated: true},
+		{pattern: "/schema/*.json", generated: true},
+		{pattern: "docs/dist/**", generated: false},
+	}
+
+	tests := []struct {
+		filePath string
+		rules    []gitattributesRule
+		expected bool
+	}{
+		{filePath: "main.go", expected: false},
+		{filePath: "package-lock.json", expected: true},
+		{filePath: "web/package-lock.json", expected: true},
+		{filePath: "go.sum", expected: true},
+		{filePath: "go.mod", expected: false},
+		{filePath: "static/app.min.js", expected: true},
+		{filePath: "static/app.js", expected: false},
+		{filePath: "vendor/github.com/foo/bar.go", expected: true},
+		{filePath: "third_party/vendor/lib.c", expected: true},
+		{filePath: "vendored.go", expected: false},
+		{filePath: "dist/bundle.js", expected: true},
+		{filePath: "distribution/notes.md", expected: false},
+		{filePath: "api/client_gen.go", expected: false},
+		{filePath: "api/client_gen.go", rules: rules, expected: true},
+		{filePath: "schema/user.json", rules: rules, expected: true},
+		{filePath: "other/schema/user.json", rules: rules, expected: false},
+		{filePath: "docs/dist/index.html", expected: true},
+		{filePath: "docs/dist/index.html", rules: rules, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s with %d rules", tc.filePath, len(tc.rules)), func(t *testing.T) {
+			assert.Equal(t, tc.expected, isGeneratedFile(tc.filePath, tc.rules))
+		})
+	}
+}
+
+func Test_GetPullRequestStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	// Setup mock PR for successful PR fetch
+	mockPR := &github.PullRequest{
+		Number:  github.Ptr(42),
+		Title:   github.Ptr("Test PR"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42"),
+		Head: &github.PullRequestBranch{
+			SHA: github.Ptr("abcd1234"),
+			Ref: github.Ptr("feature-branch"),
+		},
+	}
+
+	// Setup mock status for success case
+	mockStatus := &github.CombinedStatus{
+		State:      github.Ptr("success"),
+		TotalCount: github.Ptr(3),
+		Statuses: []*github.RepoStatus{
+			{
+				State:       github.Ptr("success"),
+				Context:     github.Ptr("continuous-integration/travis-ci"),
+				Description: github.Ptr("Build succeeded"),
+				TargetURL:   github.Ptr("https://travis-ci.org/owner/repo/builds/123"),
+			},
+			{
+				State:       github.Ptr("success"),
+				Context:     github.Ptr("codecov/patch"),
+				Description: github.Ptr("Coverage increased"),
+				TargetURL:   github.Ptr("https://codecov.io/gh/owner/repo/pull/42"),
+			},
+			{
+				State:       github.Ptr("success"),
+				Context:     github.Ptr("lint/golangci-lint"),
+				Description: github.Ptr("No issues found"),
+				TargetURL:   github.Ptr("https://golangci.com/r/owner/repo/pull/42"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedStatus PullRequestStatus
+		expectedErrMsg string
+	}{
+		{
+			name: "successful status fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockStatus,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					&github.ListCheckRunsResults{Total: github.Ptr(0)},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError: false,
+			expectedStatus: PullRequestStatus{
+				OverallState: ciStateSuccess,
+				SHA:          "abcd1234",
+				Statuses: []CIItem{
+					{Name: "continuous-integration/travis-ci", Kind: "status", State: ciStateSuccess, URL: "https://travis-ci.org/owner/repo/builds/123"},
+					{Name: "codecov/patch", Kind: "status", State: ciStateSuccess, URL: "https://codecov.io/gh/owner/repo/pull/42"},
+					{Name: "lint/golangci-lint", Kind: "status", State: ciStateSuccess, URL: "https://golangci.com/r/owner/repo/pull/42"},
+				},
+				CheckRuns: []CIItem{},
+			},
+		},
+		{
+			name: "a failing check run is not hidden by an empty combined status",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					&github.CombinedStatus{State: github.Ptr("pending"), TotalCount: github.Ptr(0)},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					&github.ListCheckRunsResults{
+						Total: github.Ptr(1),
+						CheckRuns: []*github.CheckRun{
+							{ID: github.Ptr(int64(7)), Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"), HTMLURL: github.Ptr("https://github.com/owner/repo/runs/7")},
+						},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedStatus: PullRequestStatus{
+				OverallState: ciStateFailure,
+				SHA:          "abcd1234",
+				Statuses:     []CIItem{},
+				CheckRuns: []CIItem{
+					{Name: "test", Kind: "check", State: ciStateFailure, URL: "https://github.com/owner/repo/runs/7"},
+				},
+			},
+		},
+		{
+			name: "a failing check run on a later page is found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					&github.CombinedStatus{State: github.Ptr("pending"), TotalCount: github.Ptr(0)},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						run := &github.CheckRun{ID: github.Ptr(int64(1)), Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")}
+						if r.URL.Query().Get("page") == "2" {
+							run = &github.CheckRun{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")}
+						} else {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/commits/abcd1234/check-runs?page=2>; rel="next"`)
+						}
+						_ = json.NewEncoder(w).Encode(&github.ListCheckRunsResults{Total: github.Ptr(2), CheckRuns: []*github.CheckRun{run}})
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedStatus: PullRequestStatus{
+				OverallState: ciStateFailure,
+				SHA:          "abcd1234",
+				Statuses:     []CIItem{},
+				CheckRuns: []CIItem{
+					{Name: "lint", Kind: "check", State: ciStateSuccess},
+					{Name: "test", Kind: "check", State: ciStateFailure},
+				},
+			},
+		},
+		{
+			name: "too many check runs to fetch is never reported as success",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					&github.CombinedStatus{State: github.Ptr("pending"), TotalCount: github.Ptr(0)},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/commits/abcd1234/check-runs?page=99>; rel="next"`)
+						_ = json.NewEncoder(w).Encode(&github.ListCheckRunsResults{Total: github.Ptr(5000), CheckRuns: []*github.CheckRun{
+							{ID: github.Ptr(int64(1)), Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+						}})
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedStatus: PullRequestStatus{
+				OverallState: ciStatePending,
+				SHA:          "abcd1234",
+				Statuses:     []CIItem{},
+				CheckRuns: []CIItem{
+					{Name: "lint", Kind: "check", State: ciStateSuccess},
+				},
+				Truncated: true,
+			},
+		},
+		{
+			name: "PR fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+		{
+			name: "status fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusesByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get combined status",
+		},
+		{
+			name: "check runs fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockStatus,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list check runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedStatus PullRequestStatus
+			err = json.Unmarshal([]byte(textContent.Text), &returnedStatus)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, returnedStatus)
+		})
+	}
+}
+
+func Test_MergePullRequestStatus(t *testing.T) {
+	status := func(context, state string) *github.RepoStatus {
+		return &github.RepoStatus{Context: github.Ptr(context), State: github.Ptr(state)}
+	}
+	checkRun := func(id int64, name, runStatus, conclusion string) *github.CheckRun {
+		run := &github.CheckRun{ID: github.Ptr(id), Name: github.Ptr(name), Status: github.Ptr(runStatus)}
+		if conclusion != "" {
+			run.Conclusion = github.Ptr(conclusion)
+		}
+		return run
+	}
+
+	tests := []struct {
+		name          string
+		statuses      []*github.RepoStatus
+		checkRuns     []*github.CheckRun
+		expectedState string
+		statusCount   int
+		checkCount    int
+	}{
+		{
+			name:          "nothing reported",
+			expectedState: ciStateNone,
+		},
+		{
+			name:          "only statuses",
+			statuses:      []*github.RepoStatus{status("ci/travis", "success"), status("codecov", "success")},
+			expectedState: ciStateSuccess,
+			statusCount:   2,
+		},
+		{
+			name:          "only failing status",
+			statuses:      []*github.RepoStatus{status("ci/travis", "success"), status("ci/jenkins", "error")},
+			expectedState: ciStateFailure,
+			statusCount:   2,
+		},
+		{
+			name:          "only checks",
+			checkRuns:     []*github.CheckRun{checkRun(1, "build", "completed", "success"), checkRun(2, "docs", "completed", "skipped")},
+			expectedState: ciStateSuccess,
+			checkCount:    2,
+		},
+		{
+			name:          "mixed green",
+			statuses:      []*github.RepoStatus{status("ci/travis", "success")},
+			checkRuns:     []*github.CheckRun{checkRun(1, "build", "completed", "success"), checkRun(2, "lint", "completed", "neutral")},
+			expectedState: ciStateSuccess,
+			statusCount:   1,
+			checkCount:    2,
+		},
+		{
+			name:          "mixed with a failing check",
+			statuses:      []*github.RepoStatus{status("ci/travis", "success")},
+			checkRuns:     []*github.CheckRun{checkRun(1, "build", "completed", "success"), checkRun(2, "test", "completed", "failure")},
+			expectedState: ciStateFailure,
+			statusCount:   1,
+			checkCount:    2,
+		},
+		{
+			name:          "in-progress checks",
+			statuses:      []*github.RepoStatus{status("ci/travis", "success")},
+			checkRuns:     []*github.CheckRun{checkRun(1, "build", "completed", "success"), checkRun(2, "test", "in_progress", "")},
+			expectedState: ciStatePending,
+			statusCount:   1,
+			checkCount:    2,
+		},
+		{
+			name:          "a failure outweighs checks in progress",
+			checkRuns:     []*github.CheckRun{checkRun(1, "build", "queued", ""), checkRun(2, "test", "completed", "timed_out")},
+			expectedState: ciStateFailure,
+			checkCount:    2,
+		},
+		{
+			name:          "a passing re-run replaces the failed run",
+			checkRuns:     []*github.CheckRun{checkRun(1, "test", "completed", "failure"), checkRun(5, "test", "completed", "success")},
+			expectedState: ciStateSuccess,
+			checkCount:    1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := mergePullRequestStatus(tc.statuses, tc.checkRuns)
+			assert.Equal(t, tc.expectedState, result.OverallState)
+			assert.Len(t, result.Statuses, tc.statusCount)
+			assert.Len(t, result.CheckRuns, tc.checkCount)
+		})
+	}
+}
+
+func Test_ListOpenPullRequestsStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListOpenPullRequestsStatus(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_open_pull_requests_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	qOpenPullRequests := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){pullRequests(states: OPEN, first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}){nodes{number,title,url,isDraft,reviewDecision,mergeable,author{login},commits(last: 1){nodes{commit{statusCheckRollup{state}}}}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+
+	pullRequestNodes := []map[string]any{
+		{
+			"number":         42,
+			"title":          "Add feature",
+			"url":            "https://github.com/owner/repo/pull/42",
+			"isDraft":        false,
+			"reviewDecision": "APPROVED",
+			"mergeable":      "MERGEABLE",
+			"author":         map[string]any{"login": "octocat"},
+			"commits": map[string]any{
+				"nodes": []map[string]any{
+					{"commit": map[string]any{"statusCheckRollup": map[string]any{"state": "SUCCESS"}}},
+				},
+			},
+		},
+		{
+			"number":         43,
+			"title":          "Work in progress",
+			"url":            "https://github.com/owner/repo/pull/43",
+			"isDraft":        true,
+			"reviewDecision": nil,
+			"mergeable":      "UNKNOWN",
+			"author":         map[string]any{"login": "hubot"},
+			"commits": map[string]any{
+				"nodes": []map[string]any{
+					{"commit": map[string]any{"statusCheckRollup": nil}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedResult OpenPullRequestsStatusResult
+		expectedErrMsg string
+	}{
+		{
+			name: "lists open pull requests with their status",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					qOpenPullRequests,
+					map[string]any{
+						"owner": "owner",
+						"repo":  "repo",
+						"first": float64(30),
+						"after": (*string)(nil),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{
+							"pullRequests": map[string]any{
+								"nodes": pullRequestNodes,
+								"pageInfo": map[string]any{
+									"hasNextPage":     false,
+									"hasPreviousPage": false,
+									"startCursor":     "Y3Vyc29yOjE=",
+									"endCursor":       "Y3Vyc29yOjI=",
+								},
+								"totalCount": 2,
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedResult: OpenPullRequestsStatusResult{
+				PullRequests: []OpenPullRequestStatus{
+					{
+						Number:         42,
+						Title:          "Add feature",
+						URL:            "https://github.com/owner/repo/pull/42",
+						Author:         "octocat",
+						ReviewDecision: "APPROVED",
+						Mergeable:      "MERGEABLE",
+						ChecksState:    "SUCCESS",
+					},
+					{
+						Number:    43,
+						Title:     "Work in progress",
+						URL:       "https://github.com/owner/repo/pull/43",
+						Author:    "hubot",
+						IsDraft:   true,
+						Mergeable: "UNKNOWN",
+					},
+				},
+				Pagination: Pagination{
+					PageInfo: PageInfo{
+						StartCursor: "Y3Vyc29yOjE=",
+						EndCursor:   "Y3Vyc29yOjI=",
+					},
+					TotalCount: 2,
+				},
+			},
+		},
+		{
+			name: "passes pagination and returns the next call",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					// A non-nil cursor is sent as a non-null String
+					strings.Replace(qOpenPullRequests, "$after:String", "$after:String!", 1),
+					map[string]any{
+						"owner": "owner",
+						"repo":  "repo",
+						"first": float64(1),
+						"after": "Y3Vyc29yOjE=",
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{
+							"pullRequests": map[string]any{
+								"nodes": pullRequestNodes[1:],
+								"pageInfo": map[string]any{
+									"hasNextPage":     true,
+									"hasPreviousPage": true,
+									"startCursor":     "Y3Vyc29yOjI=",
+									"endCursor":       "Y3Vyc29yOjI=",
+								},
+								"totalCount": 3,
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"perPage": float64(1),
+				"after":   "Y3Vyc29yOjE=",
+			},
+			expectedResult: OpenPullRequestsStatusResult{
+				PullRequests: []OpenPullRequestStatus{
+					{
+						Number:    43,
+						Title:     "Work in progress",
+						URL:       "https://github.com/owner/repo/pull/43",
+						Author:    "hubot",
+						IsDraft:   true,
+						Mergeable: "UNKNOWN",
+					},
+				},
+				Pagination: Pagination{
+					PageInfo: PageInfo{
+						HasNextPage:     true,
+						HasPreviousPage: true,
+						StartCursor:     "Y3Vyc29yOjI=",
+						EndCursor:       "Y3Vyc29yOjI=",
+					},
+					TotalCount: 3,
+					NextCall:   &NextCall{After: "Y3Vyc29yOjI=", PerPage: 1},
+				},
+			},
+		},
+		{
+			name: "query fails",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					qOpenPullRequests,
+					map[string]any{
+						"owner": "owner",
+						"repo":  "missing",
+						"first": float64(30),
+						"after": (*string)(nil),
+					},
+					githubv4mock.ErrorResponse("Could not resolve to a Repository with the name 'owner/missing'."),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list open pull requests: Could not resolve to a Repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := ListOpenPullRequestsStatus(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned OpenPullRequestsStatusResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_GetRequiredStatusChecks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRequiredStatusChecks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_required_status_checks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_required_status_checks tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mockPR := &github.PullRequest{
+		Number: github.Ptr(42),
+		Head:   &github.PullRequestBranch{SHA: github.Ptr("abcd1234"), Ref: github.Ptr("feature")},
+		Base:   &github.PullRequestBranch{Ref: github.Ptr("main")},
+	}
+
+	mockCheckRuns := &github.ListCheckRunsResults{
+		Total: github.Ptr(3),
+		CheckRuns: []*github.CheckRun{
+			{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+			{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Status: github.Ptr("in_progress")},
+			{ID: github.Ptr(int64(3)), Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+		},
+	}
+
+	mockStatus := &github.CombinedStatus{
+		State: github.Ptr("success"),
+		Statuses: []*github.RepoStatus{
+			{Context: github.Ptr("ci/jenkins"), State: github.Ptr("success"), TargetURL: github.Ptr("https://jenkins.example.com/job/1")},
+		},
+	}
+
+	mockProtection := &github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Checks: &[]*github.RequiredStatusCheck{
+				{Context: "build"},
+				{Context: "test"},
+				{Context: "ci/jenkins"},
+			},
+		},
+		RequiredSignatures: &github.SignaturesProtectedBranch{Enabled: github.Ptr(true)},
+	}
+
+	mockRules := []map[string]any{
+		{
+			"type":                "required_status_checks",
+			"ruleset_source_type": "Repository",
+			"ruleset_source":      "owner/repo",
+			"ruleset_id":          1,
+			"parameters": map[string]any{
+				"required_status_checks": []map[string]any{
+					{"context": "lint"},
+					{"context": "security-scan"},
+				},
+				"strict_required_status_checks_policy": false,
+			},
+		},
+	}
+
+	notFoundHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Branch not protected"}`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedNote   string
+		expectedResult RequiredStatusChecksResult
+	}{
+		{
+			name: "branch protection requirements",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockStatus,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					expectPath(t, "/repos/owner/repo/branches/main/protection").andThen(
+						mockResponse(t, http.StatusOK, mockProtection),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					[]map[string]any{},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/commits/abcd1234/check-runs").andThen(
+						mockResponse(t, http.StatusOK, mockCheckRuns),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedResult: RequiredStatusChecksResult{
+				Branch:             "main",
+				HeadSHA:            "abcd1234",
+				SignaturesRequired: true,
+				Checks: []RequiredCheckStatus{
+					{Context: "build", Source: "branch_protection", State: "passing", Conclusion: "success"},
+					{Context: "test", Source: "branch_protection", State: "pending"},
+					{Context: "ci/jenkins", Source: "branch_protection", State: "passing", Conclusion: "success", DetailsURL: "https://jenkins.example.com/job/1"},
+				},
+				Missing: []string{},
+				Pending: []string{"test"},
+				Failing: []string{},
+			},
+		},
+		{
+			name: "ruleset requirements on an unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockStatus,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					notFoundHandler,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					expectPath(t, "/repos/owner/repo/rules/branches/release").andThen(
+						mockResponse(t, http.StatusOK, mockRules),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockCheckRuns,
+				),
+			),
 			requestArgs: map[string]interface{}{
 				"owner":      "owner",
 				"repo":       "repo",
-				"pullNumber": float64(999),
+				"pullNumber": float64(42),
+				"branch":     "release",
+			},
+			expectedResult: RequiredStatusChecksResult{
+				Branch:  "release",
+				HeadSHA: "abcd1234",
+				Checks: []RequiredCheckStatus{
+					{Context: "lint", Source: "ruleset", State: "failing", Conclusion: "failure"},
+					{Context: "security-scan", Source: "ruleset", State: "missing"},
+				},
+				Missing: []string{"security-scan"},
+				Pending: []string{},
+				Failing: []string{"lint"},
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to get pull request",
 		},
 		{
-			name: "status fetch fails",
+			name: "unreadable branch protection becomes a note",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatch(
 					mock.GetReposPullsByOwnerByRepoByPullNumber,
 					mockPR,
 				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockStatus,
+				),
 				mock.WithRequestMatchHandler(
-					mock.GetReposCommitsStatusesByOwnerByRepoByRef,
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					[]map[string]any{},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockCheckRuns,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedNote: "could not read branch protection",
+			expectedResult: RequiredStatusChecksResult{
+				Branch:     "main",
+				HeadSHA:    "abcd1234",
+				AllPassing: true,
+				Checks:     []RequiredCheckStatus{},
+				Missing:    []string{},
+				Pending:    []string{},
+				Failing:    []string{},
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 						w.WriteHeader(http.StatusNotFound)
 						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
@@ -1055,10 +2494,10 @@ func Test_GetPullRequestStatus(t *testing.T) {
 			requestArgs: map[string]interface{}{
 				"owner":      "owner",
 				"repo":       "repo",
-				"pullNumber": float64(42),
+				"pullNumber": float64(999),
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to get combined status",
+			expectedErrMsg: "failed to get pull request",
 		},
 	}
 
@@ -1066,45 +2505,422 @@ func Test_GetPullRequestStatus(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetPullRequestStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetRequiredStatusChecks(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
 
 			// Call handler
 			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
 
 			// Verify results
 			if tc.expectError {
-				require.NoError(t, err)
 				require.True(t, result.IsError)
 				errorContent := getErrorResult(t, result)
 				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
 			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			// Unmarshal and verify the result
-			var returnedStatus github.CombinedStatus
-			err = json.Unmarshal([]byte(textContent.Text), &returnedStatus)
+			var returned RequiredStatusChecksResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+
+			// Notes carry API error text, so they are checked separately
+			if tc.expectedNote != "" {
+				require.Len(t, returned.Notes, 1)
+				assert.Contains(t, returned.Notes[0], tc.expectedNote)
+				returned.Notes = nil
+			}
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_JoinRequiredChecks(t *testing.T) {
+	appID := int64(15368)
+	anyApp := int64(-1)
+
+	tests := []struct {
+		name      string
+		required  []requiredCheck
+		checkRuns []*github.CheckRun
+		statuses  []*github.RepoStatus
+		expected  []RequiredCheckStatus
+	}{
+		{
+			name:     "no required checks",
+			required: nil,
+			checkRuns: []*github.CheckRun{
+				{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+			},
+			expected: []RequiredCheckStatus{},
+		},
+		{
+			name:      "required check without a run is missing",
+			required:  []requiredCheck{{context: "build", source: "ruleset"}},
+			checkRuns: nil,
+			expected: []RequiredCheckStatus{
+				{Context: "build", Source: "ruleset", State: "missing"},
+			},
+		},
+		{
+			name: "conclusions map to states",
+			required: []requiredCheck{
+				{context: "success", source: "ruleset"},
+				{context: "neutral", source: "ruleset"},
+				{context: "skipped", source: "ruleset"},
+				{context: "cancelled", source: "ruleset"},
+				{context: "timed_out", source: "ruleset"},
+				{context: "queued", source: "ruleset"},
+			},
+			checkRuns: []*github.CheckRun{
+				{ID: github.Ptr(int64(1)), Name: github.Ptr("success"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+				{ID: github.Ptr(int64(2)), Name: github.Ptr("neutral"), Status: github.Ptr("completed"), Conclusion: github.Ptr("neutral")},
+				{ID: github.Ptr(int64(3)), Name: github.Ptr("skipped"), Status: github.Ptr("completed"), Conclusion: github.Ptr("skipped")},
+				{ID: github.Ptr(int64(4)), Name: github.Ptr("cancelled"), Status: github.Ptr("completed"), Conclusion: github.Ptr("cancelled")},
+				{ID: github.Ptr(int64(5)), Name: github.Ptr("timed_out"), Status: github.Ptr("completed"), Conclusion: github.Ptr("timed_out")},
+				{ID: github.Ptr(int64(6)), Name: github.Ptr("queued"), Status: github.Ptr("queued")},
+			},
+			expected: []RequiredCheckStatus{
+				{Context: "success", Source: "ruleset", State: "passing", Conclusion: "success"},
+				{Context: "neutral", Source: "ruleset", State: "passing", Conclusion: "neutral"},
+				{Context: "skipped", Source: "ruleset", State: "passing", Conclusion: "skipped"},
+				{Context: "cancelled", Source: "ruleset", State: "failing", Conclusion: "cancelled"},
+				{Context: "timed_out", Source: "ruleset", State: "failing", Conclusion: "timed_out"},
+				{Context: "queued", Source: "ruleset", State: "pending"},
+			},
+		},
+		{
+			name:     "most recent run of a rerun check wins",
+			required: []requiredCheck{{context: "build", source: "branch_protection"}},
+			checkRuns: []*github.CheckRun{
+				{ID: github.Ptr(int64(2)), Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), HTMLURL: github.Ptr("https://github.com/owner/repo/runs/2")},
+				{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+			},
+			expected: []RequiredCheckStatus{
+				{Context: "build", Source: "branch_protection", State: "passing", Conclusion: "success", DetailsURL: "https://github.com/owner/repo/runs/2"},
+			},
+		},
+		{
+			name: "app restricted checks only match runs from that app",
+			required: []requiredCheck{
+				{context: "build", appID: &appID, source: "branch_protection"},
+				{context: "test", appID: &anyApp, source: "branch_protection"},
+			},
+			checkRuns: []*github.CheckRun{
+				{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), App: &github.App{ID: github.Ptr(int64(1))}},
+				{ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), App: &github.App{ID: github.Ptr(int64(1))}},
+			},
+			expected: []RequiredCheckStatus{
+				{Context: "build", Source: "branch_protection", State: "missing"},
+				{Context: "test", Source: "branch_protection", State: "passing", Conclusion: "success"},
+			},
+		},
+		{
+			name: "required context reported only through the Status API",
+			required: []requiredCheck{
+				{context: "ci/jenkins", source: "branch_protection"},
+				{context: "ci/travis", appID: &anyApp, source: "ruleset"},
+				{context: "ci/circle", source: "ruleset"},
+				{context: "ci/restricted", appID: &appID, source: "ruleset"},
+			},
+			statuses: []*github.RepoStatus{
+				{Context: github.Ptr("ci/jenkins"), State: github.Ptr("success"), TargetURL: github.Ptr("https://jenkins.example.com/job/1")},
+				{Context: github.Ptr("ci/travis"), State: github.Ptr("pending")},
+				{Context: github.Ptr("ci/circle"), State: github.Ptr("error")},
+				{Context: github.Ptr("ci/restricted"), State: github.Ptr("success")},
+			},
+			expected: []RequiredCheckStatus{
+				{Context: "ci/jenkins", Source: "branch_protection", State: "passing", Conclusion: "success", DetailsURL: "https://jenkins.example.com/job/1"},
+				{Context: "ci/travis", Source: "ruleset", State: "pending", Conclusion: "pending"},
+				{Context: "ci/circle", Source: "ruleset", State: "failing", Conclusion: "error"},
+				{Context: "ci/restricted", Source: "ruleset", State: "missing"},
+			},
+		},
+		{
+			name:     "a check run takes precedence over a status of the same name",
+			required: []requiredCheck{{context: "build", source: "ruleset"}},
+			checkRuns: []*github.CheckRun{
+				{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+			},
+			statuses: []*github.RepoStatus{
+				{Context: github.Ptr("build"), State: github.Ptr("failure")},
+			},
+			expected: []RequiredCheckStatus{
+				{Context: "build", Source: "ruleset", State: "passing", Conclusion: "success"},
+			},
+		},
+		{
+			name: "context required by several sources is reported once",
+			required: []requiredCheck{
+				{context: "build", source: "branch_protection"},
+				{context: "build", source: "ruleset"},
+			},
+			checkRuns: []*github.CheckRun{
+				{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+			},
+			expected: []RequiredCheckStatus{
+				{Context: "build", Source: "branch_protection", State: "passing", Conclusion: "success"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, joinRequiredChecks(tc.required, tc.checkRuns, tc.statuses))
+		})
+	}
+}
+
+func Test_GetPullRequestDependencyDiff(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestDependencyDiff(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_dependency_diff", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mockPR := &github.PullRequest{
+		Number: github.Ptr(42),
+		Base:   &github.PullRequestBranch{Ref: github.Ptr("main"), SHA: github.Ptr("base123")},
+		Head:   &github.PullRequestBranch{Ref: github.Ptr("feature"), SHA: github.Ptr("head456")},
+	}
+	mockChanges := []map[string]any{
+		{
+			"change_type": "added",
+			"manifest":    "package.json",
+			"ecosystem":   "npm",
+			"name":        "lodash",
+			"version":     "4.17.20",
+			"package_url": "pkg:npm/lodash@4.17.20",
+			"license":     "MIT",
+			"scope":       "runtime",
+			"vulnerabilities": []map[string]any{
+				{
+					"severity":         "high",
+					"advisory_ghsa_id": "GHSA-35jh-r3h4-6jhm",
+					"advisory_summary": "Command Injection in lodash",
+					"advisory_url":     "https://github.com/advisories/GHSA-35jh-r3h4-6jhm",
+				},
+			},
+		},
+		{
+			"change_type":     "removed",
+			"manifest":        "package.json",
+			"ecosystem":       "npm",
+			"name":            "underscore",
+			"version":         "1.12.0",
+			"license":         "MIT",
+			"scope":           "runtime",
+			"vulnerabilities": []map[string]any{},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedDiff   PullRequestDependencyDiff
+	}{
+		{
+			name: "successful dependency diff",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/dependency-graph/compare/base123...head456").andThen(
+						mockResponse(t, http.StatusOK, mockChanges),
+					),
+				),
+			),
+			expectedDiff: PullRequestDependencyDiff{
+				BaseSHA: "base123",
+				HeadSHA: "head456",
+				Added: []DependencyChange{
+					{
+						Name:       "lodash",
+						Ecosystem:  "npm",
+						Version:    "4.17.20",
+						License:    "MIT",
+						Manifest:   "package.json",
+						Scope:      "runtime",
+						PackageURL: "pkg:npm/lodash@4.17.20",
+						Advisories: []DependencyAdvisory{
+							{
+								GHSAID:   "GHSA-35jh-r3h4-6jhm",
+								Severity: "high",
+								Summary:  "Command Injection in lodash",
+								URL:      "https://github.com/advisories/GHSA-35jh-r3h4-6jhm",
+							},
+						},
+					},
+				},
+				Removed: []DependencyChange{
+					{Name: "underscore", Ecosystem: "npm", Version: "1.12.0", License: "MIT", Manifest: "package.json", Scope: "runtime"},
+				},
+				AddedAdvisories: DependencySeveritySummary{High: 1, Total: 1},
+			},
+		},
+		{
+			name: "dependency graph disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Dependency graph is disabled for this repository."}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "enable the dependency graph",
+		},
+		{
+			name: "compare fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockPR,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Dependency review is not supported on this repository."}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to compare dependencies",
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestDependencyDiff(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
+			result, err := handler(context.Background(), request)
 			require.NoError(t, err)
-			assert.Equal(t, *tc.expectedStatus.State, *returnedStatus.State)
-			assert.Equal(t, *tc.expectedStatus.TotalCount, *returnedStatus.TotalCount)
-			assert.Len(t, returnedStatus.Statuses, len(tc.expectedStatus.Statuses))
-			for i, status := range returnedStatus.Statuses {
-				assert.Equal(t, *tc.expectedStatus.Statuses[i].State, *status.State)
-				assert.Equal(t, *tc.expectedStatus.Statuses[i].Context, *status.Context)
-				assert.Equal(t, *tc.expectedStatus.Statuses[i].Description, *status.Description)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
 			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned PullRequestDependencyDiff
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedDiff, returned)
+		})
+	}
+}
+
+func Test_SummarizeAdvisorySeverities(t *testing.T) {
+	advisories := func(severities ...string) []DependencyAdvisory {
+		var result []DependencyAdvisory
+		for _, severity := range severities {
+			result = append(result, DependencyAdvisory{Severity: severity})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name         string
+		dependencies []DependencyChange
+		expected     DependencySeveritySummary
+	}{
+		{
+			name:     "no dependencies",
+			expected: DependencySeveritySummary{},
+		},
+		{
+			name: "counts across dependencies",
+			dependencies: []DependencyChange{
+				{Name: "a", Advisories: advisories("critical", "high", "high")},
+				{Name: "b"},
+				{Name: "c", Advisories: advisories("moderate", "low", "Critical")},
+			},
+			expected: DependencySeveritySummary{Critical: 2, High: 2, Moderate: 1, Low: 1, Total: 6},
+		},
+		{
+			name: "medium counts as moderate and unknown only towards total",
+			dependencies: []DependencyChange{
+				{Name: "a", Advisories: advisories("medium", "unknown")},
+			},
+			expected: DependencySeveritySummary{Moderate: 1, Total: 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, summarizeAdvisorySeverities(tc.dependencies))
 		})
 	}
 }
 
+func Test_ProjectDependencyChanges(t *testing.T) {
+	var changes []dependencyReviewChange
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"change_type": "added", "name": "left-pad", "ecosystem": "npm", "version": "1.3.0", "vulnerabilities": [{"severity": "low", "advisory_ghsa_id": "GHSA-1"}]},
+		{"change_type": "removed", "name": "old-lib", "ecosystem": "pip", "version": "0.1.0", "vulnerabilities": [{"severity": "critical", "advisory_ghsa_id": "GHSA-2"}]},
+		{"change_type": "unchanged", "name": "ignored", "ecosystem": "npm", "version": "1.0.0"}
+	]`), &changes))
+
+	diff := projectDependencyChanges(changes)
+
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "left-pad", diff.Added[0].Name)
+	assert.Equal(t, []DependencyAdvisory{{GHSAID: "GHSA-1", Severity: "low"}}, diff.Added[0].Advisories)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "old-lib", diff.Removed[0].Name)
+	assert.Equal(t, "pip", diff.Removed[0].Ecosystem)
+	// Advisories on removed packages are fixed by the pull request, so they are not counted
+	assert.Equal(t, DependencySeveritySummary{Low: 1, Total: 1}, diff.AddedAdvisories)
+
+	empty := projectDependencyChanges(nil)
+	assert.NotNil(t, empty.Added)
+	assert.NotNil(t, empty.Removed)
+}
+
 func Test_UpdatePullRequestBranch(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1236,6 +3052,11 @@ func Test_GetPullRequestComments(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "direction")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
 
 	// Setup mock PR comments for success case
@@ -1274,23 +3095,75 @@ func Test_GetPullRequestComments(t *testing.T) {
 		requestArgs      map[string]interface{}
 		expectError      bool
 		expectedComments []*github.PullRequestComment
+		expectedNextPage int
+		expectedLastPage int
 		expectedErrMsg   string
 	}{
 		{
 			name: "successful comments fetch",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
+					// The page size defaults to 100, as the tool fetched before it was paginated
+					expectQueryParams(t, map[string]string{"page": "1", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, mockComments),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:      false,
+			expectedComments: mockComments,
+		},
+		{
+			name: "comments fetch with pagination, sort and since",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
 					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
-					mockComments,
+					expectQueryParams(t, map[string]string{
+						"sort":      "updated",
+						"direction": "desc",
+						"since":     "2025-04-01T00:00:00Z",
+						"page":      "2",
+						"per_page":  "2",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/pulls/42/comments?page=3&per_page=2>; rel="next", `+
+								`<https://api.github.com/repos/owner/repo/pulls/42/comments?page=5&per_page=2>; rel="last"`)
+							_ = json.NewEncoder(w).Encode(mockComments)
+						}),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner":      "owner",
 				"repo":       "repo",
 				"pullNumber": float64(42),
+				"sort":       "updated",
+				"direction":  "desc",
+				"since":      "2025-04-01",
+				"page":       float64(2),
+				"perPage":    float64(2),
+			},
+			expectError:      false,
+			expectedComments: mockComments,
+			expectedNextPage: 3,
+			expectedLastPage: 5,
+		},
+		{
+			name:         "comments fetch with invalid since",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"since":      "yesterday",
 			},
-			expectError:      false,
-			expectedComments: mockComments,
+			expectError:    true,
+			expectedErrMsg: "invalid ISO 8601 timestamp",
 		},
 		{
 			name: "comments fetch fails",
@@ -1341,9 +3214,13 @@ func Test_GetPullRequestComments(t *testing.T) {
 			textContent := getTextResult(t, result)
 
 			// Unmarshal and verify the result
-			var returnedComments []*github.PullRequestComment
-			err = json.Unmarshal([]byte(textContent.Text), &returnedComments)
+			var returned PullRequestCommentsResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
 			require.NoError(t, err)
+			assert.Equal(t, tc.expectedNextPage > 0, returned.HasNextPage)
+			assert.Equal(t, tc.expectedNextPage, returned.NextPage)
+			assert.Equal(t, tc.expectedLastPage, returned.LastPage)
+			returnedComments := returned.Comments
 			assert.Len(t, returnedComments, len(tc.expectedComments))
 			for i, comment := range returnedComments {
 				assert.Equal(t, *tc.expectedComments[i].ID, *comment.ID)
@@ -1367,6 +3244,10 @@ func Test_GetPullRequestReviews(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "state_filter")
+	assert.Contains(t, tool.InputSchema.Properties, "latest_per_reviewer")
+	assert.Contains(t, tool.InputSchema.Properties, "include_comments")
+	assert.Contains(t, tool.InputSchema.Properties, "max_comments")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
 
 	// Setup mock PR reviews for success case
@@ -1390,40 +3271,581 @@ func Test_GetPullRequestReviews(t *testing.T) {
 			User: &github.User{
 				Login: github.Ptr("reviewer"),
 			},
-			CommitID:    github.Ptr("abcdef123456"),
-			SubmittedAt: &github.Timestamp{Time: time.Now().Add(-12 * time.Hour)},
+			CommitID:    github.Ptr("abcdef123456"),
+			SubmittedAt: &github.Timestamp{Time: time.Now().Add(-12 * time.Hour)},
+		},
+	}
+
+	// One reviewer reviews several times across two pages, another reviews once
+	now := time.Now()
+	mockMultiReviews := []*github.PullRequestReview{
+		{
+			ID:          github.Ptr(int64(301)),
+			State:       github.Ptr("COMMENTED"),
+			Body:        github.Ptr("A few questions"),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-301"),
+			User:        &github.User{Login: github.Ptr("reviewer")},
+			SubmittedAt: &github.Timestamp{Time: now.Add(-72 * time.Hour)},
+		},
+		{
+			ID:          github.Ptr(int64(302)),
+			State:       github.Ptr("CHANGES_REQUESTED"),
+			Body:        github.Ptr("Please fix the tests"),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-302"),
+			User:        &github.User{Login: github.Ptr("reviewer")},
+			SubmittedAt: &github.Timestamp{Time: now.Add(-48 * time.Hour)},
+		},
+		{
+			ID:          github.Ptr(int64(303)),
+			State:       github.Ptr("APPROVED"),
+			Body:        github.Ptr("LGTM now"),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-303"),
+			User:        &github.User{Login: github.Ptr("reviewer")},
+			SubmittedAt: &github.Timestamp{Time: now.Add(-24 * time.Hour)},
+		},
+		{
+			ID:          github.Ptr(int64(304)),
+			State:       github.Ptr("COMMENTED"),
+			Body:        github.Ptr("Nit: typo"),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-304"),
+			User:        &github.User{Login: github.Ptr("bot")},
+			SubmittedAt: &github.Timestamp{Time: now.Add(-36 * time.Hour)},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedReviews []*github.PullRequestReview
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful reviews fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					mockReviews,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:     false,
+			expectedReviews: mockReviews,
+		},
+		{
+			name: "filters reviews by state across pages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchPages(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					mockMultiReviews[:2],
+					mockMultiReviews[2:],
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"state_filter": []interface{}{"APPROVED", "CHANGES_REQUESTED"},
+			},
+			expectError:     false,
+			expectedReviews: []*github.PullRequestReview{mockMultiReviews[1], mockMultiReviews[2]},
+		},
+		{
+			name: "collapses to the latest review per reviewer",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchPages(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					mockMultiReviews[:2],
+					mockMultiReviews[2:],
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":               "owner",
+				"repo":                "repo",
+				"pullNumber":          float64(42),
+				"latest_per_reviewer": true,
+			},
+			expectError:     false,
+			expectedReviews: []*github.PullRequestReview{mockMultiReviews[2], mockMultiReviews[3]},
+		},
+		{
+			name: "combines state filter with latest per reviewer",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchPages(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					mockMultiReviews[:2],
+					mockMultiReviews[2:],
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":               "owner",
+				"repo":                "repo",
+				"pullNumber":          float64(42),
+				"state_filter":        []interface{}{"COMMENTED", "CHANGES_REQUESTED"},
+				"latest_per_reviewer": true,
+			},
+			expectError: false,
+			// reviewer's latest review approves, so the earlier CHANGES_REQUESTED no longer counts
+			expectedReviews: []*github.PullRequestReview{mockMultiReviews[3]},
+		},
+		{
+			name:         "invalid state filter",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"state_filter": []interface{}{"MERGED"},
+			},
+			expectError:    true,
+			expectedErrMsg: "invalid state_filter value \"MERGED\"",
+		},
+		{
+			name: "reviews fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request reviews",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestReviews(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedReviews []*github.PullRequestReview
+			err = json.Unmarshal([]byte(textContent.Text), &returnedReviews)
+			require.NoError(t, err)
+			assert.Len(t, returnedReviews, len(tc.expectedReviews))
+			for i, review := range returnedReviews {
+				assert.Equal(t, *tc.expectedReviews[i].ID, *review.ID)
+				assert.Equal(t, *tc.expectedReviews[i].State, *review.State)
+				assert.Equal(t, *tc.expectedReviews[i].Body, *review.Body)
+				assert.Equal(t, *tc.expectedReviews[i].User.Login, *review.User.Login)
+				assert.Equal(t, *tc.expectedReviews[i].HTMLURL, *review.HTMLURL)
+			}
+		})
+	}
+}
+
+func Test_GetPullRequestReviewsIncludeComments(t *testing.T) {
+	mockReviews := []*github.PullRequestReview{
+		{ID: github.Ptr(int64(201)), State: github.Ptr("COMMENTED"), User: &github.User{Login: github.Ptr("reviewer")}},
+		{ID: github.Ptr(int64(202)), State: github.Ptr("APPROVED"), User: &github.User{Login: github.Ptr("approver")}},
+	}
+	comment := func(id int64) *github.PullRequestComment {
+		return &github.PullRequestComment{ID: github.Ptr(id), Body: github.Ptr(fmt.Sprintf("comment %d", id))}
+	}
+
+	// Review 201 has three comments over two pages, review 202 has one
+	var commentRequests []string
+	commentsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commentRequests = append(commentRequests, r.URL.Path+"?"+r.URL.RawQuery)
+		var comments []*github.PullRequestComment
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/reviews/201/comments") && r.URL.Query().Get("page") == "":
+			w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/pulls/42/reviews/201/comments?page=2>; rel="next"`)
+			comments = []*github.PullRequestComment{comment(1), comment(2)}
+		case strings.HasSuffix(r.URL.Path, "/reviews/201/comments"):
+			comments = []*github.PullRequestComment{comment(3)}
+		case strings.HasSuffix(r.URL.Path, "/reviews/202/comments"):
+			comments = []*github.PullRequestComment{comment(4)}
+		}
+		_ = json.NewEncoder(w).Encode(comments)
+	})
+
+	tests := []struct {
+		name             string
+		requestArgs      map[string]interface{}
+		expectedComments map[int64][]int64
+		expectTruncated  map[int64]bool
+		expectedRequests int
+	}{
+		{
+			name:             "without include_comments no comments are fetched",
+			requestArgs:      map[string]interface{}{},
+			expectedRequests: 0,
+		},
+		{
+			name:             "nests every comment under its review",
+			requestArgs:      map[string]interface{}{"include_comments": true},
+			expectedComments: map[int64][]int64{201: {1, 2, 3}, 202: {4}},
+			expectTruncated:  map[int64]bool{},
+			expectedRequests: 3,
+		},
+		{
+			name:             "stops fetching once max_comments is reached",
+			requestArgs:      map[string]interface{}{"include_comments": true, "max_comments": float64(2)},
+			expectedComments: map[int64][]int64{201: {1, 2}, 202: {}},
+			expectTruncated:  map[int64]bool{201: true, 202: true},
+			expectedRequests: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			commentRequests = nil
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsReviewsByOwnerByRepoByPullNumber, mockReviews),
+				mock.WithRequestMatchHandler(mock.GetReposPullsReviewsCommentsByOwnerByRepoByPullNumberByReviewId, commentsHandler),
+			))
+			_, handler := GetPullRequestReviews(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{"owner": "owner", "repo": "repo", "pullNumber": float64(42)}
+			maps.Copy(args, tc.requestArgs)
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Len(t, commentRequests, tc.expectedRequests)
+
+			if tc.expectedComments == nil {
+				assert.NotContains(t, textContent.Text, `"comments"`)
+				return
+			}
+
+			var returned []struct {
+				ID                int64                        `json:"id"`
+				Comments          []*github.PullRequestComment `json:"comments"`
+				CommentsTruncated bool                         `json:"comments_truncated"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			require.Len(t, returned, len(mockReviews))
+			for _, review := range returned {
+				ids := []int64{}
+				for _, c := range review.Comments {
+					ids = append(ids, c.GetID())
+				}
+				assert.Equal(t, tc.expectedComments[review.ID], ids, "review %d", review.ID)
+				assert.Equal(t, tc.expectTruncated[review.ID], review.CommentsTruncated, "review %d", review.ID)
+			}
+		})
+	}
+}
+
+func Test_GetPullRequestReviewsFilterPageCap(t *testing.T) {
+	// Every page links to another, so filtering stops at the page cap
+	var requests int
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/repos/owner/repo/pulls/42/reviews?page=%d>; rel="next"`, requests+1))
+		_ = json.NewEncoder(w).Encode([]*github.PullRequestReview{
+			{ID: github.Ptr(int64(requests)), State: github.Ptr("APPROVED"), User: &github.User{Login: github.Ptr(fmt.Sprintf("reviewer%d", requests))}},
+		})
+	})
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(mock.GetReposPullsReviewsByOwnerByRepoByPullNumber, handler),
+	))
+	_, toolHandler := GetPullRequestReviews(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := toolHandler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"pullNumber":   float64(42),
+		"state_filter": []interface{}{"APPROVED"},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, maxReviewPages, requests)
+
+	require.Len(t, result.Content, 2)
+	var returned []*github.PullRequestReview
+	data, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	require.NoError(t, json.Unmarshal([]byte(data.Text), &returned))
+	assert.Len(t, returned, maxReviewPages)
+	note, ok := result.Content[1].(mcp.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, note.Text, "the pull request has more")
+}
+
+func Test_GetPullRequestReviewsCommentsAcrossPages(t *testing.T) {
+	mockReviews := []*github.PullRequestReview{
+		{ID: github.Ptr(int64(301)), State: github.Ptr("COMMENTED"), User: &github.User{Login: github.Ptr("reviewer")}},
+	}
+
+	// The handler serves 250 comments, paging by per_page the way the API does, so a page
+	// size that changes between requests would show up as gaps or duplicates.
+	const totalComments = 250
+	var perPages []string
+	commentsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPages = append(perPages, r.URL.Query().Get("per_page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if perPage == 0 {
+			perPage = 30
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		start := (page - 1) * perPage
+		end := min(start+perPage, totalComments)
+		if end < totalComments {
+			w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/repos/owner/repo/pulls/42/reviews/301/comments?page=%d>; rel="next"`, page+1))
+		}
+		comments := []*github.PullRequestComment{}
+		for id := start + 1; id <= end; id++ {
+			comments = append(comments, &github.PullRequestComment{ID: github.Ptr(int64(id))})
+		}
+		_ = json.NewEncoder(w).Encode(comments)
+	})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposPullsReviewsByOwnerByRepoByPullNumber, mockReviews),
+		mock.WithRequestMatchHandler(mock.GetReposPullsReviewsCommentsByOwnerByRepoByPullNumberByReviewId, commentsHandler),
+	))
+	_, handler := GetPullRequestReviews(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"pullNumber":       float64(42),
+		"include_comments": true,
+		"max_comments":     float64(150),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []struct {
+		Comments          []*github.PullRequestComment `json:"comments"`
+		CommentsTruncated bool                         `json:"comments_truncated"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 1)
+
+	require.Len(t, returned[0].Comments, 150)
+	for i, c := range returned[0].Comments {
+		assert.Equal(t, int64(i+1), c.GetID(), "comment %d", i)
+	}
+	assert.True(t, returned[0].CommentsTruncated)
+	assert.Equal(t, []string{"100", "100"}, perPages)
+}
+
+func Test_LatestReviewPerReviewer(t *testing.T) {
+	now := time.Now()
+	review := func(id int64, login string, submittedAt *time.Time) *github.PullRequestReview {
+		r := &github.PullRequestReview{
+			ID:   github.Ptr(id),
+			User: &github.User{Login: github.Ptr(login)},
+		}
+		if submittedAt != nil {
+			r.SubmittedAt = &github.Timestamp{Time: *submittedAt}
+		}
+		return r
+	}
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(d)
+		return &ts
+	}
+
+	tests := []struct {
+		name        string
+		reviews     []*github.PullRequestReview
+		expectedIDs []int64
+	}{
+		{
+			name:        "no reviews",
+			reviews:     nil,
+			expectedIDs: []int64{},
+		},
+		{
+			name: "keeps the most recent review even when returned out of order",
+			reviews: []*github.PullRequestReview{
+				review(1, "alice", at(-1*time.Hour)),
+				review(2, "alice", at(-3*time.Hour)),
+				review(3, "bob", at(-2*time.Hour)),
+			},
+			expectedIDs: []int64{1, 3},
+		},
+		{
+			name: "later review wins a tie",
+			reviews: []*github.PullRequestReview{
+				review(1, "alice", at(-1*time.Hour)),
+				review(2, "alice", at(-1*time.Hour)),
+			},
+			expectedIDs: []int64{2},
+		},
+		{
+			name: "pending review is newer than submitted reviews",
+			reviews: []*github.PullRequestReview{
+				review(1, "alice", nil),
+				review(2, "alice", at(-1*time.Hour)),
+			},
+			expectedIDs: []int64{1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ids := []int64{}
+			for _, r := range latestReviewPerReviewer(tc.reviews) {
+				ids = append(ids, r.GetID())
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func Test_GetPullRequestReviewers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestReviewers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_reviewers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_pull_request_reviewers tool should be read-only")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	submittedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockRequestedReviewers := &github.Reviewers{
+		Users: []*github.User{
+			{Login: github.Ptr("carol")},
+		},
+		Teams: []*github.Team{
+			{Slug: github.Ptr("backend"), Name: github.Ptr("Backend")},
+			{Slug: github.Ptr("frontend"), Name: github.Ptr("Frontend")},
+		},
+	}
+	mockReviews := []*github.PullRequestReview{
+		{ID: github.Ptr(int64(1)), State: github.Ptr("CHANGES_REQUESTED"), User: &github.User{Login: github.Ptr("alice")}, SubmittedAt: &github.Timestamp{Time: submittedAt.Add(-time.Hour)}},
+		{ID: github.Ptr(int64(2)), State: github.Ptr("APPROVED"), User: &github.User{Login: github.Ptr("alice")}, SubmittedAt: &github.Timestamp{Time: submittedAt}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       PullRequestReviewers
+	}{
+		{
+			name: "user and team review requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					expectPath(t, "/repos/owner/repo/pulls/42/requested_reviewers").andThen(
+						mockResponse(t, http.StatusOK, mockRequestedReviewers),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					mockReviews,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						switch r.URL.Path {
+						case "/orgs/owner/teams/backend/members":
+							mockResponse(t, http.StatusOK, []*github.User{{Login: github.Ptr("alice")}, {Login: github.Ptr("dave")}})(w, r)
+						default:
+							mockResponse(t, http.StatusOK, []*github.User{{Login: github.Ptr("erin")}})(w, r)
+						}
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expected: PullRequestReviewers{
+				RequestedUsers: []string{"carol"},
+				RequestedTeams: []RequestedTeam{
+					{Slug: "backend", Name: "Backend", SatisfiedBy: "alice"},
+					{Slug: "frontend", Name: "Frontend"},
+				},
+				Reviews: []ReviewerLatestState{
+					{Reviewer: "alice", State: "APPROVED", SubmittedAt: &submittedAt, ReviewCount: 2},
+				},
+			},
 		},
-	}
-
-	tests := []struct {
-		name            string
-		mockedClient    *http.Client
-		requestArgs     map[string]interface{}
-		expectError     bool
-		expectedReviews []*github.PullRequestReview
-		expectedErrMsg  string
-	}{
 		{
-			name: "successful reviews fetch",
+			name: "team members cannot be listed",
 			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					&github.Reviewers{Teams: []*github.Team{{Slug: github.Ptr("backend"), Name: github.Ptr("Backend")}}},
+				),
 				mock.WithRequestMatch(
 					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
 					mockReviews,
 				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner":      "owner",
 				"repo":       "repo",
 				"pullNumber": float64(42),
 			},
-			expectError:     false,
-			expectedReviews: mockReviews,
+			expected: PullRequestReviewers{
+				RequestedUsers: []string{},
+				RequestedTeams: []RequestedTeam{
+					{
+						Slug:              "backend",
+						Name:              "Backend",
+						MembershipUnknown: true,
+						Note:              "failed to list team members, which needs the read:org scope and an organization owner: Resource not accessible by integration",
+					},
+				},
+				Reviews: []ReviewerLatestState{
+					{Reviewer: "alice", State: "APPROVED", SubmittedAt: &submittedAt, ReviewCount: 2},
+				},
+			},
 		},
 		{
-			name: "reviews fetch fails",
+			name: "requested reviewers fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+					mock.GetReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 						w.WriteHeader(http.StatusNotFound)
 						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
@@ -1436,7 +3858,7 @@ func Test_GetPullRequestReviews(t *testing.T) {
 				"pullNumber": float64(999),
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to get pull request reviews",
+			expectedErrMsg: "failed to get requested reviewers",
 		},
 	}
 
@@ -1444,41 +3866,145 @@ func Test_GetPullRequestReviews(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetPullRequestReviews(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetPullRequestReviewers(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
 
 			// Call handler
 			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
 
 			// Verify results
 			if tc.expectError {
-				require.NoError(t, err)
 				require.True(t, result.IsError)
 				errorContent := getErrorResult(t, result)
 				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
 			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			// Unmarshal and verify the result
-			var returnedReviews []*github.PullRequestReview
-			err = json.Unmarshal([]byte(textContent.Text), &returnedReviews)
-			require.NoError(t, err)
-			assert.Len(t, returnedReviews, len(tc.expectedReviews))
-			for i, review := range returnedReviews {
-				assert.Equal(t, *tc.expectedReviews[i].ID, *review.ID)
-				assert.Equal(t, *tc.expectedReviews[i].State, *review.State)
-				assert.Equal(t, *tc.expectedReviews[i].Body, *review.Body)
-				assert.Equal(t, *tc.expectedReviews[i].User.Login, *review.User.Login)
-				assert.Equal(t, *tc.expectedReviews[i].HTMLURL, *review.HTMLURL)
-			}
+			var returned PullRequestReviewers
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_GroupPullRequestReviewers(t *testing.T) {
+	now := time.Now().UTC()
+	review := func(login, state string, submittedAt time.Time) *github.PullRequestReview {
+		return &github.PullRequestReview{
+			User:        &github.User{Login: github.Ptr(login)},
+			State:       github.Ptr(state),
+			SubmittedAt: &github.Timestamp{Time: submittedAt},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		requested        *github.Reviewers
+		reviews          []*github.PullRequestReview
+		teamMembers      map[string][]string
+		membershipErrors map[string]string
+		expected         PullRequestReviewers
+	}{
+		{
+			name:      "nothing requested or reviewed",
+			requested: &github.Reviewers{},
+			expected: PullRequestReviewers{
+				RequestedUsers: []string{},
+				RequestedTeams: []RequestedTeam{},
+				Reviews:        []ReviewerLatestState{},
+			},
+		},
+		{
+			name:      "reviews grouped by reviewer with latest state",
+			requested: &github.Reviewers{},
+			reviews: []*github.PullRequestReview{
+				review("alice", "COMMENTED", now.Add(-2*time.Hour)),
+				review("bob", "CHANGES_REQUESTED", now.Add(-90*time.Minute)),
+				review("alice", "APPROVED", now.Add(-1*time.Hour)),
+			},
+			expected: PullRequestReviewers{
+				RequestedUsers: []string{},
+				RequestedTeams: []RequestedTeam{},
+				Reviews: []ReviewerLatestState{
+					{Reviewer: "bob", State: "CHANGES_REQUESTED", SubmittedAt: github.Ptr(now.Add(-90 * time.Minute)), ReviewCount: 1},
+					{Reviewer: "alice", State: "APPROVED", SubmittedAt: github.Ptr(now.Add(-1 * time.Hour)), ReviewCount: 2},
+				},
+			},
+		},
+		{
+			name:      "pending reviews are ignored",
+			requested: &github.Reviewers{Users: []*github.User{{Login: github.Ptr("alice")}}},
+			reviews: []*github.PullRequestReview{
+				{User: &github.User{Login: github.Ptr("alice")}, State: github.Ptr("PENDING")},
+			},
+			expected: PullRequestReviewers{
+				RequestedUsers: []string{"alice"},
+				RequestedTeams: []RequestedTeam{},
+				Reviews:        []ReviewerLatestState{},
+			},
+		},
+		{
+			name: "team requests satisfied by a member's review",
+			requested: &github.Reviewers{
+				Teams: []*github.Team{
+					{Slug: github.Ptr("backend"), Name: github.Ptr("Backend")},
+					{Slug: github.Ptr("frontend"), Name: github.Ptr("Frontend")},
+					{Slug: github.Ptr("unknown"), Name: github.Ptr("Unknown")},
+				},
+			},
+			reviews: []*github.PullRequestReview{
+				review("alice", "COMMENTED", now),
+			},
+			teamMembers: map[string][]string{
+				"backend":  {"dave", "alice"},
+				"frontend": {"erin"},
+			},
+			expected: PullRequestReviewers{
+				RequestedUsers: []string{},
+				RequestedTeams: []RequestedTeam{
+					{Slug: "backend", Name: "Backend", SatisfiedBy: "alice"},
+					{Slug: "frontend", Name: "Frontend"},
+					{Slug: "unknown", Name: "Unknown"},
+				},
+				Reviews: []ReviewerLatestState{
+					{Reviewer: "alice", State: "COMMENTED", SubmittedAt: github.Ptr(now), ReviewCount: 1},
+				},
+			},
+		},
+		{
+			name: "team membership that could not be listed is unknown",
+			requested: &github.Reviewers{
+				Teams: []*github.Team{
+					{Slug: github.Ptr("backend"), Name: github.Ptr("Backend")},
+				},
+			},
+			reviews: []*github.PullRequestReview{
+				review("alice", "APPROVED", now),
+			},
+			membershipErrors: map[string]string{
+				"backend": "failed to list team members",
+			},
+			expected: PullRequestReviewers{
+				RequestedUsers: []string{},
+				RequestedTeams: []RequestedTeam{
+					{Slug: "backend", Name: "Backend", MembershipUnknown: true, Note: "failed to list team members"},
+				},
+				Reviews: []ReviewerLatestState{
+					{Reviewer: "alice", State: "APPROVED", SubmittedAt: github.Ptr(now), ReviewCount: 1},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, groupPullRequestReviewers(tc.requested, tc.reviews, tc.teamMembers, tc.membershipErrors))
 		})
 	}
 }
@@ -1641,6 +4167,176 @@ func Test_CreatePullRequest(t *testing.T) {
 	}
 }
 
+func Test_CreatePullRequestRequestCodeowners(t *testing.T) {
+	mockPR := &github.PullRequest{
+		Number: github.Ptr(42),
+		Title:  github.Ptr("Test PR"),
+		User:   &github.User{Login: github.Ptr("author")},
+		Base:   &github.PullRequestBranch{Ref: github.Ptr("main")},
+	}
+	codeowners := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("*.go @gopher @author\n/docs/ @octo-org/writers\n"))),
+	}
+	changedFiles := []*github.CommitFile{
+		{Filename: github.Ptr("main.go")},
+		{Filename: github.Ptr("docs/intro.md")},
+	}
+
+	// CODEOWNERS is not in .github, so the lookup falls through to the repository root.
+	getCodeowners := mock.WithRequestMatchHandler(
+		mock.GetReposContentsByOwnerByRepoByPath,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "main", r.URL.Query().Get("ref"))
+			if r.URL.Path != "/repos/octo-org/repo/contents/CODEOWNERS" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, codeowners).ServeHTTP(w, r)
+		}),
+	)
+
+	type reviewersRequest struct {
+		Reviewers     []string `json:"reviewers"`
+		TeamReviewers []string `json:"team_reviewers"`
+	}
+
+	tests := []struct {
+		name              string
+		reviewersHandler  func(t *testing.T, requests *[]reviewersRequest) http.HandlerFunc
+		codeownersHandler mock.MockBackendOption
+		expectedRequests  []reviewersRequest
+		expectedRequested []RequestedCodeowner
+		expectedWarnings  []string
+	}{
+		{
+			name: "requests owners of changed files in one call",
+			reviewersHandler: func(t *testing.T, requests *[]reviewersRequest) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					var body reviewersRequest
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					*requests = append(*requests, body)
+					mockResponse(t, http.StatusCreated, mockPR).ServeHTTP(w, r)
+				}
+			},
+			codeownersHandler: getCodeowners,
+			expectedRequests: []reviewersRequest{
+				{Reviewers: []string{"gopher"}, TeamReviewers: []string{"writers"}},
+			},
+			expectedRequested: []RequestedCodeowner{
+				{Owner: "gopher", Type: "user", Paths: []string{"main.go"}},
+				{Owner: "writers", Type: "team", Paths: []string{"docs/intro.md"}},
+			},
+		},
+		{
+			name: "falls back to one call per reviewer and reports failures",
+			reviewersHandler: func(t *testing.T, requests *[]reviewersRequest) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					var body reviewersRequest
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					*requests = append(*requests, body)
+					if len(body.TeamReviewers) > 0 {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Reviews may only be requested from collaborators."}`))
+						return
+					}
+					mockResponse(t, http.StatusCreated, mockPR).ServeHTTP(w, r)
+				}
+			},
+			codeownersHandler: getCodeowners,
+			expectedRequests: []reviewersRequest{
+				{Reviewers: []string{"gopher"}, TeamReviewers: []string{"writers"}},
+				{Reviewers: []string{"gopher"}},
+				{TeamReviewers: []string{"writers"}},
+			},
+			expectedRequested: []RequestedCodeowner{
+				{Owner: "gopher", Type: "user", Paths: []string{"main.go"}},
+			},
+			expectedWarnings: []string{"failed to request review from team writers"},
+		},
+		{
+			name: "missing CODEOWNERS is a warning",
+			codeownersHandler: mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				}),
+			),
+			expectedRequested: []RequestedCodeowner{},
+			expectedWarnings:  []string{"no CODEOWNERS file found"},
+		},
+		{
+			name: "empty CODEOWNERS is a warning of its own",
+			codeownersHandler: mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path != "/repos/octo-org/repo/contents/.github/CODEOWNERS" {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+						return
+					}
+					mockResponse(t, http.StatusOK, &github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("# owners to be added\n"))),
+					}).ServeHTTP(w, r)
+				}),
+			),
+			expectedRequested: []RequestedCodeowner{},
+			expectedWarnings:  []string{"CODEOWNERS file .github/CODEOWNERS is empty or only has comments"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []reviewersRequest
+			options := []mock.MockBackendOption{
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsByOwnerByRepo,
+					mockResponse(t, http.StatusCreated, mockPR),
+				),
+				tc.codeownersHandler,
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					changedFiles,
+				),
+			}
+			if tc.reviewersHandler != nil {
+				options = append(options, mock.WithRequestMatchHandler(
+					mock.PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					tc.reviewersHandler(t, &requests),
+				))
+			}
+			client := github.NewClient(mock.NewMockedHTTPClient(options...))
+			_, handler := CreatePullRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":              "octo-org",
+				"repo":               "repo",
+				"title":              "Test PR",
+				"head":               "feature",
+				"base":               "main",
+				"request_codeowners": true,
+			}))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var returned CreatePullRequestResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, 42, returned.PullRequest.GetNumber())
+			assert.Equal(t, tc.expectedRequests, requests)
+			assert.Equal(t, tc.expectedRequested, returned.Codeowners.Requested)
+			require.Len(t, returned.Codeowners.Warnings, len(tc.expectedWarnings))
+			for i, warning := range tc.expectedWarnings {
+				assert.Contains(t, returned.Codeowners.Warnings[i], warning)
+			}
+		})
+	}
+}
+
 func TestCreateAndSubmitPullRequestReview(t *testing.T) {
 	t.Parallel()
 
@@ -1815,23 +4511,164 @@ func TestCreateAndSubmitPullRequestReview(t *testing.T) {
 			client := githubv4.NewClient(tc.mockedClient)
 			_, handler := CreateAndSubmitPullRequestReview(stubGetGQLClientFn(client), translations.NullTranslationHelper)
 
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			// Parse the result and get the text content if no error
+			require.Equal(t, textContent.Text, "pull request review submitted successfully")
+		})
+	}
+}
+
+func Test_RequestPullRequestReviewers(t *testing.T) {
+	t.Parallel()
+
+	mockClient := github.NewClient(nil)
+	tool, _ := RequestPullRequestReviewers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "request_pull_request_reviewers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "reviewers")
+	assert.Contains(t, tool.InputSchema.Properties, "team_reviewers")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expected       RequestedReviewers
+		expectedErrMsg string
+	}{
+		{
+			name: "requests users and teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					expect(t, expectations{
+						path: "/repos/owner/repo/pulls/42/requested_reviewers",
+						requestBody: map[string]any{
+							"reviewers":      []any{"octocat"},
+							"team_reviewers": []any{"platform"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.PullRequest{
+							Number: github.Ptr(42),
+							RequestedReviewers: []*github.User{
+								{Login: github.Ptr("hubot")},
+								{Login: github.Ptr("octocat")},
+							},
+							RequestedTeams: []*github.Team{
+								{Slug: github.Ptr("platform")},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"pullNumber":     float64(42),
+				"reviewers":      []any{"octocat"},
+				"team_reviewers": []any{"platform"},
+			},
+			expected: RequestedReviewers{
+				Reviewers:     []string{"hubot", "octocat"},
+				TeamReviewers: []string{"platform"},
+			},
+		},
+		{
+			name: "requests only teams",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]any{
+						"team_reviewers": []any{"platform"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.PullRequest{
+							Number:         github.Ptr(42),
+							RequestedTeams: []*github.Team{{Slug: github.Ptr("platform")}},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"pullNumber":     float64(42),
+				"team_reviewers": []any{"platform"},
+			},
+			expected: RequestedReviewers{
+				Reviewers:     []string{},
+				TeamReviewers: []string{"platform"},
+			},
+		},
+		{
+			name:         "requires a reviewer or team",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "at least one of reviewers or team_reviewers is required",
+		},
+		{
+			name: "review requested from the author",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Review cannot be requested from pull request author."}`),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"reviewers":  []any{"author"},
+			},
+			expectError:    true,
+			expectedErrMsg: "Review cannot be requested from pull request author",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RequestPullRequestReviewers(stubGetClientFn(client), translations.NullTranslationHelper)
 
-			// Call handler
-			result, err := handler(context.Background(), request)
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
 			require.NoError(t, err)
 
-			textContent := getTextResult(t, result)
-
-			if tc.expectToolError {
+			if tc.expectError {
 				require.True(t, result.IsError)
-				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			// Parse the result and get the text content if no error
-			require.Equal(t, textContent.Text, "pull request review submitted successfully")
+			var requested RequestedReviewers
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &requested))
+			assert.Equal(t, tc.expected, requested)
 		})
 	}
 }
@@ -2011,7 +4848,13 @@ func TestCreatePendingPullRequestReview(t *testing.T) {
 						CommitOID:     githubv4.NewGitObjectID("abcd1234"),
 					},
 					nil,
-					githubv4mock.DataResponse(map[string]any{}),
+					githubv4mock.DataResponse(map[string]any{
+						"addPullRequestReview": map[string]any{
+							"pullRequestReview": map[string]any{
+								"id": "PRR_kwDODKw3uc6s2eDi",
+							},
+						},
+					}),
 				),
 			),
 			requestArgs: map[string]any{
@@ -2127,7 +4970,7 @@ func TestCreatePendingPullRequestReview(t *testing.T) {
 			}
 
 			// Parse the result and get the text content if no error
-			require.Equal(t, textContent.Text, "pending pull request created")
+			require.Equal(t, "pending pull request review created, with the pullRequestReviewID PRR_kwDODKw3uc6s2eDi", textContent.Text)
 		})
 	}
 }
@@ -2145,6 +4988,7 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "pullRequestReviewID")
 	assert.Contains(t, tool.InputSchema.Properties, "path")
 	assert.Contains(t, tool.InputSchema.Properties, "body")
 	assert.Contains(t, tool.InputSchema.Properties, "subjectType")
@@ -2185,9 +5029,7 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 
 					reviews: []getLatestPendingReviewQueryReview{
 						{
-							id:    "PR_kwDODKw3uc6WYN1T",
-							state: "PENDING",
-							url:   "https://github.com/owner/repo/pull/42",
+							id: "PR_kwDODKw3uc6WYN1T",
 						},
 					},
 				}),
@@ -2214,6 +5056,37 @@ func TestAddPullRequestReviewCommentToPendingReview(t *testing.T) {
 				),
 			),
 		},
+		{
+			name: "comment added to the given review without looking it up",
+			requestArgs: map[string]any{
+				"owner":               "owner",
+				"repo":                "repo",
+				"pullNumber":          float64(42),
+				"pullRequestReviewID": "PRR_kwDODKw3uc6s2eDi",
+				"path":                "file.go",
+				"body":                "This is a test comment",
+				"subjectType":         "FILE",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					struct {
+						AddPullRequestReviewThread struct {
+							Thread struct {
+								ID githubv4.String // We don't need this, but a selector is required or GQL complains.
+							}
+						} `graphql:"addPullRequestReviewThread(input: $input)"`
+					}{},
+					githubv4.AddPullRequestReviewThreadInput{
+						Path:                githubv4.String("file.go"),
+						Body:                githubv4.String("This is a test comment"),
+						SubjectType:         githubv4mock.Ptr(githubv4.PullRequestReviewThreadSubjectTypeFile),
+						PullRequestReviewID: githubv4.NewID("PRR_kwDODKw3uc6s2eDi"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{}),
+				),
+			),
+		},
 	}
 
 	for _, tc := range tests {
@@ -2258,6 +5131,7 @@ func TestSubmitPendingPullRequestReview(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "pullRequestReviewID")
 	assert.Contains(t, tool.InputSchema.Properties, "event")
 	assert.Contains(t, tool.InputSchema.Properties, "body")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "event"})
@@ -2288,9 +5162,7 @@ func TestSubmitPendingPullRequestReview(t *testing.T) {
 
 					reviews: []getLatestPendingReviewQueryReview{
 						{
-							id:    "PR_kwDODKw3uc6WYN1T",
-							state: "PENDING",
-							url:   "https://github.com/owner/repo/pull/42",
+							id: "PR_kwDODKw3uc6WYN1T",
 						},
 					},
 				}),
@@ -2312,6 +5184,33 @@ func TestSubmitPendingPullRequestReview(t *testing.T) {
 				),
 			),
 		},
+		{
+			name: "given review submitted without looking it up",
+			requestArgs: map[string]any{
+				"owner":               "owner",
+				"repo":                "repo",
+				"pullNumber":          float64(42),
+				"pullRequestReviewID": "PRR_kwDODKw3uc6s2eDi",
+				"event":               "APPROVE",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					struct {
+						SubmitPullRequestReview struct {
+							PullRequestReview struct {
+								ID githubv4.ID
+							}
+						} `graphql:"submitPullRequestReview(input: $input)"`
+					}{},
+					githubv4.SubmitPullRequestReviewInput{
+						PullRequestReviewID: githubv4.NewID("PRR_kwDODKw3uc6s2eDi"),
+						Event:               githubv4.PullRequestReviewEventApprove,
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{}),
+				),
+			),
+		},
 	}
 
 	for _, tc := range tests {
@@ -2356,6 +5255,7 @@ func TestDeletePendingPullRequestReview(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "pullRequestReviewID")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
 
 	tests := []struct {
@@ -2382,9 +5282,7 @@ func TestDeletePendingPullRequestReview(t *testing.T) {
 
 					reviews: []getLatestPendingReviewQueryReview{
 						{
-							id:    "PR_kwDODKw3uc6WYN1T",
-							state: "PENDING",
-							url:   "https://github.com/owner/repo/pull/42",
+							id: "PR_kwDODKw3uc6WYN1T",
 						},
 					},
 				}),
@@ -2404,6 +5302,31 @@ func TestDeletePendingPullRequestReview(t *testing.T) {
 				),
 			),
 		},
+		{
+			name: "given review deleted without looking it up",
+			requestArgs: map[string]any{
+				"owner":               "owner",
+				"repo":                "repo",
+				"pullNumber":          float64(42),
+				"pullRequestReviewID": "PRR_kwDODKw3uc6s2eDi",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					struct {
+						DeletePullRequestReview struct {
+							PullRequestReview struct {
+								ID githubv4.ID
+							}
+						} `graphql:"deletePullRequestReview(input: $input)"`
+					}{},
+					githubv4.DeletePullRequestReviewInput{
+						PullRequestReviewID: githubv4.NewID("PRR_kwDODKw3uc6s2eDi"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{}),
+				),
+			),
+		},
 	}
 
 	for _, tc := range tests {
@@ -2448,6 +5371,7 @@ func TestGetPullRequestDiff(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "max_bytes")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
 
 	stubbedDiff := `diff --git a/README.md b/README.md
@@ -2469,6 +5393,7 @@ index 5d6e7b2..8a4f5c3 100644
 		mockedClient       *http.Client
 		expectToolError    bool
 		expectedToolErrMsg string
+		expectedDiff       string
 	}{
 		{
 			name: "successful diff retrieval",
@@ -2487,6 +5412,45 @@ index 5d6e7b2..8a4f5c3 100644
 				),
 			),
 			expectToolError: false,
+			expectedDiff:    stubbedDiff,
+		},
+		{
+			name: "diff truncated at max_bytes",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"max_bytes":  float64(100),
+			},
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusOK, stubbedDiff),
+				),
+			),
+			expectToolError: false,
+			expectedDiff: `diff --git a/README.md b/README.md
+index 5d6e7b2..8a4f5c3 100644
+--- a/README.md
++++ b/README.md
+
+[diff truncated: showing 97 of 229 bytes; raise max_bytes or use get_pull_request_files for individual files]`,
+		},
+		{
+			name: "pull request not found",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "failed to get pull request diff",
 		},
 	}
 
@@ -2514,11 +5478,21 @@ index 5d6e7b2..8a4f5c3 100644
 			}
 
 			// Parse the result and get the text content if no error
-			require.Equal(t, stubbedDiff, textContent.Text)
+			require.Equal(t, tc.expectedDiff, textContent.Text)
 		})
 	}
 }
 
+func Test_TruncateDiff(t *testing.T) {
+	t.Parallel()
+
+	text := "@@ -1 +1 @@\n-old\n+new\n"
+	assert.Equal(t, text, truncateDiff(text, len(text)))
+	assert.Equal(t, "@@ -1 +1 @@\n-old\n\n[diff truncated: showing 17 of 22 bytes; raise max_bytes or use get_pull_request_files for individual files]", truncateDiff(text, 20))
+	// Without a whole line that fits, the cut falls on a character boundary
+	assert.Equal(t, "+h\n\n[diff truncated: showing 2 of 5 bytes; raise max_bytes or use get_pull_request_files for individual files]", truncateDiff("+hé\n", 3))
+}
+
 func viewerQuery(login string) githubv4mock.Matcher {
 	return githubv4mock.NewQueryMatcher(
 		struct {
@@ -2536,9 +5510,7 @@ func viewerQuery(login string) githubv4mock.Matcher {
 }
 
 type getLatestPendingReviewQueryReview struct {
-	id    string
-	state string
-	url   string
+	id string
 }
 
 type getLatestPendingReviewQueryParams struct {
@@ -2557,11 +5529,9 @@ func getLatestPendingReviewQuery(p getLatestPendingReviewQueryParams) githubv4mo
 				PullRequest struct {
 					Reviews struct {
 						Nodes []struct {
-							ID    githubv4.ID
-							State githubv4.PullRequestReviewState
-							URL   githubv4.URI
+							ID githubv4.ID
 						}
-					} `graphql:"reviews(first: 1, author: $author)"`
+					} `graphql:"reviews(first: 1, author: $author, states: PENDING)"`
 				} `graphql:"pullRequest(number: $prNum)"`
 			} `graphql:"repository(owner: $owner, name: $name)"`
 		}{},
@@ -2578,9 +5548,7 @@ func getLatestPendingReviewQuery(p getLatestPendingReviewQueryParams) githubv4mo
 						"reviews": map[string]any{
 							"nodes": []any{
 								map[string]any{
-									"id":    p.reviews[0].id,
-									"state": p.reviews[0].state,
-									"url":   p.reviews[0].url,
+									"id": p.reviews[0].id,
 								},
 							},
 						},
@@ -2590,3 +5558,375 @@ func getLatestPendingReviewQuery(p getLatestPendingReviewQueryParams) githubv4mo
 		),
 	)
 }
+
+func TestPullRequestDraftStateTools(t *testing.T) {
+	t.Parallel()
+
+	pullRequestIDQuery := func(response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					PullRequest struct {
+						ID githubv4.ID
+					} `graphql:"pullRequest(number: $prNum)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"prNum": githubv4.Int(42),
+			},
+			response,
+		)
+	}
+	foundPullRequest := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"pullRequest": map[string]any{
+				"id": "PR_kwDODKw3uc6WYN1T",
+			},
+		},
+	})
+	draftState := func(isDraft bool) map[string]any {
+		return map[string]any{
+			"pullRequest": map[string]any{
+				"number":  42,
+				"url":     "https://github.com/owner/repo/pull/42",
+				"isDraft": isDraft,
+			},
+		}
+	}
+
+	markReadyMutation := githubv4mock.NewMutationMatcher(
+		struct {
+			MarkPullRequestReadyForReview struct {
+				PullRequest pullRequestDraftFields
+			} `graphql:"markPullRequestReadyForReview(input: $input)"`
+		}{},
+		githubv4.MarkPullRequestReadyForReviewInput{
+			PullRequestID: githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{"markPullRequestReadyForReview": draftState(false)}),
+	)
+	convertToDraftMutation := githubv4mock.NewMutationMatcher(
+		struct {
+			ConvertPullRequestToDraft struct {
+				PullRequest pullRequestDraftFields
+			} `graphql:"convertPullRequestToDraft(input: $input)"`
+		}{},
+		githubv4.ConvertPullRequestToDraftInput{
+			PullRequestID: githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+		},
+		nil,
+		githubv4mock.DataResponse(map[string]any{"convertPullRequestToDraft": draftState(true)}),
+	)
+
+	tools := []struct {
+		name     string
+		newTool  func(GetGQLClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		mutation githubv4mock.Matcher
+		isDraft  bool
+	}{
+		{name: "mark_pull_request_ready_for_review", newTool: MarkPullRequestReadyForReview, mutation: markReadyMutation, isDraft: false},
+		{name: "convert_pull_request_to_draft", newTool: ConvertPullRequestToDraft, mutation: convertToDraftMutation, isDraft: true},
+	}
+
+	for _, tool := range tools {
+		t.Run(tool.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Verify tool definition once
+			definition, _ := tool.newTool(stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+			require.NoError(t, toolsnaps.Test(definition.Name, definition))
+			assert.Equal(t, tool.name, definition.Name)
+			assert.NotEmpty(t, definition.Description)
+			assert.ElementsMatch(t, definition.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+			requestArgs := map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}
+
+			t.Run("returns the new draft state", func(t *testing.T) {
+				client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(pullRequestIDQuery(foundPullRequest), tool.mutation))
+				_, handler := tool.newTool(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+				result, err := handler(context.Background(), createMCPRequest(requestArgs))
+				require.NoError(t, err)
+
+				var state PullRequestDraftState
+				require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &state))
+				assert.Equal(t, PullRequestDraftState{
+					Number:  42,
+					URL:     "https://github.com/owner/repo/pull/42",
+					IsDraft: tool.isDraft,
+				}, state)
+			})
+
+			t.Run("pull request not found", func(t *testing.T) {
+				client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+					pullRequestIDQuery(githubv4mock.ErrorResponse("Could not resolve to a PullRequest with the number of 42.")),
+				))
+				_, handler := tool.newTool(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+				result, err := handler(context.Background(), createMCPRequest(requestArgs))
+				require.NoError(t, err)
+
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, "failed to get pull request")
+				assert.Contains(t, errorContent.Text, "Could not resolve to a PullRequest")
+			})
+		})
+	}
+}
+
+func TestPullRequestDraftStateToolsAreNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		pullRequests, err := tsg.GetToolset("pull_requests")
+		require.NoError(t, err)
+
+		var names []string
+		for _, st := range pullRequests.GetAvailableTools() {
+			names = append(names, st.Tool.Name)
+		}
+		for _, name := range []string{"mark_pull_request_ready_for_review", "convert_pull_request_to_draft"} {
+			if readOnly {
+				assert.NotContains(t, names, name)
+			} else {
+				assert.Contains(t, names, name)
+			}
+		}
+	}
+}
+
+func TestResolveReviewThread(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ResolveReviewThread(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "resolve_review_thread", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "thread_id")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "line")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	reviewThreadsQuery := func(after any, response githubv4mock.GQLResponse) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					PullRequest struct {
+						ReviewThreads struct {
+							Nodes []struct {
+								ID         githubv4.ID
+								IsResolved githubv4.Boolean
+								Path       githubv4.String
+								Line       *githubv4.Int
+							}
+							PageInfo struct {
+								HasNextPage githubv4.Boolean
+								EndCursor   githubv4.String
+							}
+						} `graphql:"reviewThreads(first: 100, after: $after)"`
+					} `graphql:"pullRequest(number: $prNum)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"prNum": githubv4.Int(42),
+				"after": after,
+			},
+			response,
+		)
+	}
+	threadsPage := func(hasNextPage bool, endCursor string, threads ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{
+					"reviewThreads": map[string]any{
+						"nodes": threads,
+						"pageInfo": map[string]any{
+							"hasNextPage": hasNextPage,
+							"endCursor":   endCursor,
+						},
+					},
+				},
+			},
+		})
+	}
+	resolveMutation := func(threadID string) githubv4mock.Matcher {
+		return githubv4mock.NewMutationMatcher(
+			struct {
+				ResolveReviewThread struct {
+					Thread struct {
+						ID         githubv4.ID
+						IsResolved githubv4.Boolean
+					}
+				} `graphql:"resolveReviewThread(input: $input)"`
+			}{},
+			githubv4.ResolveReviewThreadInput{
+				ThreadID: githubv4.ID(threadID),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"resolveReviewThread": map[string]any{
+					"thread": map[string]any{
+						"id":         threadID,
+						"isResolved": true,
+					},
+				},
+			}),
+		)
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedState      ReviewThreadState
+	}{
+		{
+			name:         "resolves a thread by id",
+			mockedClient: githubv4mock.NewMockedHTTPClient(resolveMutation("PRRT_1")),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"thread_id":  "PRRT_1",
+			},
+			expectedState: ReviewThreadState{ThreadID: "PRRT_1", IsResolved: true},
+		},
+		{
+			name: "resolves the unresolved thread on a line, looking through all pages",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				reviewThreadsQuery((*githubv4.String)(nil), threadsPage(true, "Y3Vyc29yOjE=",
+					map[string]any{"id": "PRRT_1", "isResolved": true, "path": "main.go", "line": 10},
+					map[string]any{"id": "PRRT_2", "isResolved": false, "path": "main.go", "line": 11},
+				)),
+				reviewThreadsQuery(githubv4.String("Y3Vyc29yOjE="), threadsPage(false, "Y3Vyc29yOjI=",
+					map[string]any{"id": "PRRT_3", "isResolved": false, "path": "main.go", "line": nil},
+					map[string]any{"id": "PRRT_4", "isResolved": false, "path": "main.go", "line": 10},
+				)),
+				resolveMutation("PRRT_4"),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "main.go",
+				"line":       float64(10),
+			},
+			expectedState: ReviewThreadState{ThreadID: "PRRT_4", IsResolved: true},
+		},
+		{
+			name: "no matching thread",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				reviewThreadsQuery((*githubv4.String)(nil), threadsPage(false, "Y3Vyc29yOjE=",
+					map[string]any{"id": "PRRT_1", "isResolved": true, "path": "main.go", "line": 10},
+				)),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "main.go",
+				"line":       float64(10),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "no unresolved review thread found on main.go line 10 of pull request owner/repo#42",
+		},
+		{
+			name:         "neither thread_id nor path and line",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "main.go",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "either thread_id, or path and line, must be given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := ResolveReviewThread(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			var state ReviewThreadState
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &state))
+			assert.Equal(t, tc.expectedState, state)
+		})
+	}
+}
+
+func TestUnresolveReviewThread(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := UnresolveReviewThread(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "unresolve_review_thread", tool.Name)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewMutationMatcher(
+			struct {
+				UnresolveReviewThread struct {
+					Thread struct {
+						ID         githubv4.ID
+						IsResolved githubv4.Boolean
+					}
+				} `graphql:"unresolveReviewThread(input: $input)"`
+			}{},
+			githubv4.UnresolveReviewThreadInput{
+				ThreadID: githubv4.ID("PRRT_1"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"unresolveReviewThread": map[string]any{
+					"thread": map[string]any{
+						"id":         "PRRT_1",
+						"isResolved": false,
+					},
+				},
+			}),
+		),
+	))
+	_, handler := UnresolveReviewThread(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+		"thread_id":  "PRRT_1",
+	}))
+	require.NoError(t, err)
+
+	var state ReviewThreadState
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &state))
+	assert.Equal(t, ReviewThreadState{ThreadID: "PRRT_1", IsResolved: false}, state)
+}
@@ -0,0 +1,450 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultReleaseAssetMaxBytes is the default limit on the size of an asset downloaded by download_release_asset.
+const DefaultReleaseAssetMaxBytes = 100 << 20
+
+const (
+	// releaseAssetContentLimit is the largest asset whose content download_release_asset returns.
+	// Larger assets are still downloaded to compute their digest.
+	releaseAssetContentLimit = 1 << 20
+	// checksumsFileLimit is the largest checksums file that is read.
+	checksumsFileLimit = 1 << 20
+)
+
+// ReleaseAssetDownload is the result of download_release_asset. Verified is null when the release
+// has no checksums file that lists the asset.
+type ReleaseAssetDownload struct {
+	AssetID         int64  `json:"asset_id"`
+	Name            string `json:"name"`
+	ContentType     string `json:"content_type,omitempty"`
+	Size            int64  `json:"size"`
+	SHA256          string `json:"sha256"`
+	Verified        *bool  `json:"verified"`
+	ExpectedSHA256  string `json:"expected_sha256,omitempty"`
+	ChecksumsFile   string `json:"checksums_file,omitempty"`
+	DownloadURL     string `json:"download_url,omitempty"`
+	ContentIncluded bool   `json:"content_included"`
+	Note            string `json:"note,omitempty"`
+}
+
+// DownloadReleaseAsset creates a tool to download a release asset and verify its SHA256 digest. Assets
+// larger than maxBytes, or DefaultReleaseAssetMaxBytes when maxBytes is not positive, are refused.
+func DownloadReleaseAsset(getClient GetClientFn, maxBytes int, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultReleaseAssetMaxBytes
+	}
+	return mcp.NewTool("download_release_asset",
+			mcp.WithDescription(t("TOOL_DOWNLOAD_RELEASE_ASSET_DESCRIPTION", fmt.Sprintf("Download a release asset, by asset ID or by release tag and asset name, and compute its SHA256 digest. If the release has a checksums file, such as SHA256SUMS or <asset>.sha256, the digest is checked against it. The content is returned for assets up to %d bytes; for larger assets only the digest and the download URL are returned. Assets over %d bytes are not downloaded", releaseAssetContentLimit, maxBytes))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DOWNLOAD_RELEASE_ASSET_USER_TITLE", "Download release asset"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("asset_id",
+				mcp.Description("Asset ID. Pass tag as well to check the asset against the checksums file of its release"),
+			),
+			mcp.WithString("tag",
+				mcp.Description("Release tag, required with asset_name"),
+			),
+			mcp.WithString("asset_name",
+				mcp.Description("Asset name, used with tag instead of asset_id"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assetID, err := OptionalIntParam(request, "asset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := OptionalParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assetName, err := OptionalParam[string](request, "asset_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (assetID == 0) == (assetName == "") {
+				return mcp.NewToolResultError("either asset_id or tag and asset_name must be provided"), nil
+			}
+			if assetName != "" && tag == "" {
+				return mcp.NewToolResultError("tag is required with asset_name"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var release *github.RepositoryRelease
+			if tag != "" {
+				var resp *github.Response
+				release, resp, err = client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+				if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to get release %s", tag), resp, err); !ok {
+					return result, err
+				}
+			}
+
+			var asset *github.ReleaseAsset
+			if assetID != 0 {
+				var resp *github.Response
+				asset, resp, err = client.Repositories.GetReleaseAsset(ctx, owner, repo, int64(assetID))
+				if result, ok, err := handleRESTResponse(ctx, "failed to get release asset", resp, err); !ok {
+					return result, err
+				}
+			} else {
+				for _, candidate := range release.Assets {
+					if candidate.GetName() == assetName {
+						asset = candidate
+						break
+					}
+				}
+				if asset == nil {
+					return mcp.NewToolResultError(fmt.Sprintf("release %s has no asset named %s", tag, assetName)), nil
+				}
+			}
+			if asset.GetSize() > maxBytes {
+				return mcp.NewToolResultError(fmt.Sprintf("asset %s is %d bytes, larger than the %d bytes that can be downloaded", asset.GetName(), asset.GetSize(), maxBytes)), nil
+			}
+
+			body, downloadURL, err := openReleaseAsset(ctx, client, owner, repo, asset.GetID())
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to download release asset: %v", err)), nil
+			}
+			digest := sha256.New()
+			var content bytes.Buffer
+			size, err := io.Copy(io.MultiWriter(digest, &limitedWriter{w: &content, n: releaseAssetContentLimit}), io.LimitReader(body, int64(maxBytes)+1))
+			_ = body.Close()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to download release asset: %v", err)), nil
+			}
+			if size > int64(maxBytes) {
+				return mcp.NewToolResultError(fmt.Sprintf("asset %s is larger than the %d bytes that can be downloaded", asset.GetName(), maxBytes)), nil
+			}
+			if downloadURL == "" {
+				downloadURL = asset.GetBrowserDownloadURL()
+			}
+
+			result := ReleaseAssetDownload{
+				AssetID:         asset.GetID(),
+				Name:            asset.GetName(),
+				ContentType:     asset.GetContentType(),
+				Size:            size,
+				SHA256:          hex.EncodeToString(digest.Sum(nil)),
+				DownloadURL:     downloadURL,
+				ContentIncluded: size <= releaseAssetContentLimit,
+			}
+
+			switch checksums := findChecksumsAsset(release, asset.GetName()); {
+			case release == nil:
+				result.Note = "pass the release tag to check the digest against the release's checksums file"
+			case checksums == nil:
+				result.Note = fmt.Sprintf("release %s has no checksums file", release.GetTagName())
+			default:
+				result.ChecksumsFile = checksums.GetName()
+				expected, err := readExpectedChecksum(ctx, client, owner, repo, checksums, asset.GetName())
+				switch {
+				case err != nil:
+					result.Note = fmt.Sprintf("failed to read checksums file %s: %v", checksums.GetName(), err)
+				case expected == "":
+					result.Note = fmt.Sprintf("checksums file %s does not list %s", checksums.GetName(), asset.GetName())
+				default:
+					verified := expected == result.SHA256
+					result.Verified = &verified
+					result.ExpectedSHA256 = expected
+				}
+			}
+
+			if !result.ContentIncluded {
+				return MarshalledTextResult(result), nil
+			}
+			summary, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal result: %w", err)
+			}
+			resourceURI, err := url.JoinPath("repo://", owner, repo, "releases", "assets", strconv.FormatInt(asset.GetID(), 10))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create resource URI: %w", err)
+			}
+			mimeType := asset.GetContentType()
+			if mimeType == "" {
+				mimeType = "application/octet-stream"
+			}
+			return mcp.NewToolResultResource(string(summary), mcp.BlobResourceContents{
+				URI:      resourceURI,
+				Blob:     base64.StdEncoding.EncodeToString(content.Bytes()),
+				MIMEType: mimeType,
+			}), nil
+		}
+}
+
+// CreateRelease creates a tool to create a release.
+func CreateRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_release",
+			mcp.WithDescription(t("TOOL_CREATE_RELEASE_DESCRIPTION", "Create a release for a tag, creating the tag from target_commitish if it does not exist. Returns the release, including the upload_url for attaching assets")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_CREATE_RELEASE_USER_TITLE", "Create release"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag_name",
+				mcp.Required(),
+				mcp.Description("Tag of the release"),
+			),
+			mcp.WithString("target_commitish",
+				mcp.Description("Branch or commit SHA the tag is created from if it does not exist. Defaults to the default branch"),
+			),
+			mcp.WithString("name",
+				mcp.Description("Release title"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Release description in Markdown"),
+			),
+			mcp.WithBoolean("draft",
+				mcp.Description("Create an unpublished draft release"),
+			),
+			mcp.WithBoolean("prerelease",
+				mcp.Description("Mark the release as a prerelease"),
+			),
+			mcp.WithBoolean("generate_release_notes",
+				mcp.Description("Generate the release name and notes from the changes since the previous release. A provided name is kept and a provided body is put before the notes"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tagName, err := RequiredParam[string](request, "tag_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			targetCommitish, err := OptionalParam[string](request, "target_commitish")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := OptionalParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			draft, draftSet, err := OptionalParamOK[bool](request, "draft")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			prerelease, prereleaseSet, err := OptionalParamOK[bool](request, "prerelease")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			generateNotes, generateNotesSet, err := OptionalParamOK[bool](request, "generate_release_notes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Only the parameters that were provided are sent, so GitHub applies its defaults to the rest
+			release := &github.RepositoryRelease{TagName: github.Ptr(tagName)}
+			if targetCommitish != "" {
+				release.TargetCommitish = github.Ptr(targetCommitish)
+			}
+			if name != "" {
+				release.Name = github.Ptr(name)
+			}
+			if body != "" {
+				release.Body = github.Ptr(body)
+			}
+			if draftSet {
+				release.Draft = github.Ptr(draft)
+			}
+			if prereleaseSet {
+				release.Prerelease = github.Ptr(prerelease)
+			}
+			if generateNotesSet {
+				release.GenerateReleaseNotes = github.Ptr(generateNotes)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created, resp, err := client.Repositories.CreateRelease(ctx, owner, repo, release)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create release", resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+			return MarshalledTextResult(created), nil
+		}
+}
+
+// limitedWriter writes up to n bytes to w and discards the rest.
+type limitedWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.n > 0 {
+		keep := p
+		if int64(len(keep)) > l.n {
+			keep = keep[:l.n]
+		}
+		written, err := l.w.Write(keep)
+		l.n -= int64(written)
+		if err != nil {
+			return written, err
+		}
+	}
+	return len(p), nil
+}
+
+// openReleaseAsset opens the content of a release asset. Assets are usually served from a signed
+// URL that GitHub redirects to; that URL is returned as well. It is fetched with the HTTP client of
+// client, so that the request shares its limits and retries. The storage the URL points to rejects
+// requests that carry the API token, so the server's transport only authenticates requests to the
+// hosts of the GitHub API.
+func openReleaseAsset(ctx context.Context, client *github.Client, owner, repo string, id int64) (io.ReadCloser, string, error) {
+	body, redirectURL, err := client.Repositories.DownloadReleaseAsset(ctx, owner, repo, id, nil)
+	if err != nil || redirectURL == "" {
+		return body, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, redirectURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	resp, err := client.Client().Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.Body, redirectURL, nil
+}
+
+// checksumsFilePattern matches the names of combined checksums files, such as SHA256SUMS,
+// checksums.txt and app_1.0_checksums.txt.
+var checksumsFilePattern = regexp.MustCompile(`(?i)(^|[._-])(sha256sums?|checksums?)([._-]|$)`)
+
+// findChecksumsAsset returns the asset of release that holds the checksum of assetName: a file
+// for that asset alone, such as app.tar.gz.sha256, or else a combined checksums file.
+func findChecksumsAsset(release *github.RepositoryRelease, assetName string) *github.ReleaseAsset {
+	if release == nil {
+		return nil
+	}
+	var combined *github.ReleaseAsset
+	for _, candidate := range release.Assets {
+		name := candidate.GetName()
+		switch {
+		case name == assetName:
+			continue
+		case strings.EqualFold(name, assetName+".sha256") || strings.EqualFold(name, assetName+".sha256sum"):
+			return candidate
+		case combined == nil && checksumsFilePattern.MatchString(name):
+			combined = candidate
+		}
+	}
+	return combined
+}
+
+// readExpectedChecksum reads the checksums file asset and returns the SHA256 digest it lists for
+// assetName, or "" if it does not list the asset.
+func readExpectedChecksum(ctx context.Context, client *github.Client, owner, repo string, checksums *github.ReleaseAsset, assetName string) (string, error) {
+	body, _, err := openReleaseAsset(ctx, client, owner, repo, checksums.GetID())
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = body.Close() }()
+	content, err := io.ReadAll(io.LimitReader(body, checksumsFileLimit))
+	if err != nil {
+		return "", err
+	}
+	return parseChecksums(string(content), assetName), nil
+}
+
+var (
+	sha256Pattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+	// bsdChecksumPattern matches the BSD style lines of shasum --tag: SHA256 (name) = digest
+	bsdChecksumPattern = regexp.MustCompile(`^SHA256 \((.+)\) = ([0-9a-fA-F]{64})$`)
+)
+
+// parseChecksums returns the SHA256 digest that a checksums file lists for assetName, in lower
+// case, or "" if it does not list it. Lines are in the format of sha256sum, "digest  name" with
+// an optional * before binary file names, or of shasum --tag. A file for a single asset may hold
+// only the digest.
+func parseChecksums(content, assetName string) string {
+	var bare string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if match := bsdChecksumPattern.FindStringSubmatch(line); match != nil {
+			if path.Base(match[1]) == assetName {
+				return strings.ToLower(match[2])
+			}
+			continue
+		}
+		digest, name, found := strings.Cut(line, " ")
+		if !sha256Pattern.MatchString(digest) {
+			continue
+		}
+		if !found {
+			bare = strings.ToLower(digest)
+			continue
+		}
+		if path.Base(strings.TrimPrefix(strings.TrimSpace(name), "*")) == assetName {
+			return strings.ToLower(digest)
+		}
+	}
+	return bare
+}
@@ -0,0 +1,518 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// releaseAssetHandler serves release asset metadata as JSON, and the asset content for requests
+// that accept application/octet-stream. Content in redirects is served by redirecting there instead.
+func releaseAssetHandler(assets map[string]*github.ReleaseAsset, content map[string]string, redirects map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		asset, ok := assets[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		if r.Header.Get("Accept") != "application/octet-stream" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(asset)
+			return
+		}
+		if location, ok := redirects[id]; ok {
+			w.Header().Set("Location", location)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte(content[id]))
+	}
+}
+
+// storageTransport sends requests for the host of storage to it and all others to api, like the
+// transport of the server, which serves GitHub's storage URLs through the client of the API.
+type storageTransport struct {
+	api     http.RoundTripper
+	storage *httptest.Server
+	fetched int
+}
+
+func (t *storageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if "http://"+req.URL.Host == t.storage.URL {
+		t.fetched++
+		return t.storage.Client().Transport.RoundTrip(req)
+	}
+	return t.api.RoundTrip(req)
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func Test_DownloadReleaseAsset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DownloadReleaseAsset(stubGetClientFn(mockClient), 0, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "download_release_asset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "asset_id")
+	assert.Contains(t, tool.InputSchema.Properties, "tag")
+	assert.Contains(t, tool.InputSchema.Properties, "asset_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	binary := "\x7fELF binary"
+	archive := "tarball bytes"
+	large := strings.Repeat("x", releaseAssetContentLimit+1)
+
+	// Signed asset URLs point outside of the API, so they are served by a separate server
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(binary))
+	}))
+	defer storage.Close()
+
+	assets := map[string]*github.ReleaseAsset{
+		"1": {ID: github.Ptr(int64(1)), Name: github.Ptr("app-linux-amd64"), ContentType: github.Ptr("application/octet-stream"), Size: github.Ptr(len(binary)), BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.0.0/app-linux-amd64")},
+		"2": {ID: github.Ptr(int64(2)), Name: github.Ptr("app.tar.gz"), ContentType: github.Ptr("application/gzip"), Size: github.Ptr(len(archive))},
+		"3": {ID: github.Ptr(int64(3)), Name: github.Ptr("SHA256SUMS"), Size: github.Ptr(200)},
+		"4": {ID: github.Ptr(int64(4)), Name: github.Ptr("app.tar.gz.sha256"), Size: github.Ptr(64)},
+		"5": {ID: github.Ptr(int64(5)), Name: github.Ptr("large.bin"), Size: github.Ptr(len(large))},
+	}
+	content := map[string]string{
+		"2": archive,
+		"3": sha256Hex(binary) + "  app-linux-amd64\n" + sha256Hex(large) + " *large.bin\n",
+		"4": strings.Repeat("0", 64) + "\n",
+		"5": large,
+	}
+	redirects := map[string]string{
+		"1": storage.URL + "/signed/app-linux-amd64?token=abc",
+	}
+	release := &github.RepositoryRelease{
+		TagName: github.Ptr("v1.0.0"),
+		Assets:  []*github.ReleaseAsset{assets["1"], assets["2"], assets["3"], assets["4"], assets["5"]},
+	}
+	releaseWithoutChecksums := &github.RepositoryRelease{
+		TagName: github.Ptr("v0.9.0"),
+		Assets:  []*github.ReleaseAsset{assets["2"]},
+	}
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]interface{}
+		maxBytes        int
+		expectError     bool
+		expectedErrMsg  string
+		expectedResult  ReleaseAssetDownload
+		expectedContent string
+		expectedFetched int
+	}{
+		{
+			name: "verified against SHA256SUMS through the signed URL",
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v1.0.0",
+				"asset_name": "app-linux-amd64",
+			},
+			expectedResult: ReleaseAssetDownload{
+				AssetID:         1,
+				Name:            "app-linux-amd64",
+				ContentType:     "application/octet-stream",
+				Size:            int64(len(binary)),
+				SHA256:          sha256Hex(binary),
+				Verified:        github.Ptr(true),
+				ExpectedSHA256:  sha256Hex(binary),
+				ChecksumsFile:   "SHA256SUMS",
+				DownloadURL:     storage.URL + "/signed/app-linux-amd64?token=abc",
+				ContentIncluded: true,
+			},
+			expectedContent: binary,
+			expectedFetched: 1,
+		},
+		{
+			name: "mismatch against a per-asset checksum file",
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v1.0.0",
+				"asset_name": "app.tar.gz",
+			},
+			expectedResult: ReleaseAssetDownload{
+				AssetID:         2,
+				Name:            "app.tar.gz",
+				ContentType:     "application/gzip",
+				Size:            int64(len(archive)),
+				SHA256:          sha256Hex(archive),
+				Verified:        github.Ptr(false),
+				ExpectedSHA256:  strings.Repeat("0", 64),
+				ChecksumsFile:   "app.tar.gz.sha256",
+				ContentIncluded: true,
+			},
+			expectedContent: archive,
+		},
+		{
+			name: "release without checksums file",
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v0.9.0",
+				"asset_name": "app.tar.gz",
+			},
+			expectedResult: ReleaseAssetDownload{
+				AssetID:         2,
+				Name:            "app.tar.gz",
+				ContentType:     "application/gzip",
+				Size:            int64(len(archive)),
+				SHA256:          sha256Hex(archive),
+				ContentIncluded: true,
+				Note:            "release v0.9.0 has no checksums file",
+			},
+			expectedContent: archive,
+		},
+		{
+			name: "asset by ID without tag is not verified",
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"asset_id": float64(2),
+			},
+			expectedResult: ReleaseAssetDownload{
+				AssetID:         2,
+				Name:            "app.tar.gz",
+				ContentType:     "application/gzip",
+				Size:            int64(len(archive)),
+				SHA256:          sha256Hex(archive),
+				ContentIncluded: true,
+				Note:            "pass the release tag to check the digest against the release's checksums file",
+			},
+			expectedContent: archive,
+		},
+		{
+			name: "asset over the content limit only returns the digest",
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"asset_id": float64(5),
+				"tag":      "v1.0.0",
+			},
+			expectedResult: ReleaseAssetDownload{
+				AssetID:        5,
+				Name:           "large.bin",
+				Size:           int64(len(large)),
+				SHA256:         sha256Hex(large),
+				Verified:       github.Ptr(true),
+				ExpectedSHA256: sha256Hex(large),
+				ChecksumsFile:  "SHA256SUMS",
+			},
+		},
+		{
+			name: "larger than the configured limit",
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"asset_id": float64(5),
+			},
+			maxBytes:       releaseAssetContentLimit,
+			expectError:    true,
+			expectedErrMsg: fmt.Sprintf("asset large.bin is %d bytes, larger than the %d bytes that can be downloaded", len(large), releaseAssetContentLimit),
+		},
+		{
+			name: "unknown asset name",
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"tag":        "v1.0.0",
+				"asset_name": "app-windows.exe",
+			},
+			expectError:    true,
+			expectedErrMsg: "release v1.0.0 has no asset named app-windows.exe",
+		},
+		{
+			name: "asset name without tag",
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"asset_name": "app.tar.gz",
+			},
+			expectError:    true,
+			expectedErrMsg: "tag is required with asset_name",
+		},
+		{
+			name: "neither asset ID nor name",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "either asset_id or tag and asset_name must be provided",
+		},
+		{
+			name: "asset not found",
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"asset_id": float64(99),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get release asset",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			api := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesTagsByOwnerByRepoByTag,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if strings.HasSuffix(r.URL.Path, "/v0.9.0") {
+							_ = json.NewEncoder(w).Encode(releaseWithoutChecksums)
+							return
+						}
+						_ = json.NewEncoder(w).Encode(release)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesAssetsByOwnerByRepoByAssetId,
+					releaseAssetHandler(assets, content, redirects),
+				),
+			)
+			transport := &storageTransport{api: api.Transport, storage: storage}
+			client := github.NewClient(&http.Client{Transport: transport})
+			_, handler := DownloadReleaseAsset(stubGetClientFn(client), tc.maxBytes, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var text string
+			if tc.expectedContent != "" {
+				blob := getBlobResourceResult(t, result)
+				assert.Equal(t, "repo://owner/repo/releases/assets/"+strconv.FormatInt(tc.expectedResult.AssetID, 10), blob.URI)
+				decoded, err := base64.StdEncoding.DecodeString(blob.Blob)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedContent, string(decoded))
+				text = result.Content[0].(mcp.TextContent).Text
+			} else {
+				text = getTextResult(t, result).Text
+			}
+
+			var returned ReleaseAssetDownload
+			require.NoError(t, json.Unmarshal([]byte(text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+			// The signed URL is fetched through the transport of the GitHub client
+			assert.Equal(t, tc.expectedFetched, transport.fetched)
+		})
+	}
+}
+
+func Test_ParseChecksums(t *testing.T) {
+	digest := sha256Hex("app")
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "sha256sum text mode",
+			content:  sha256Hex("other") + "  other.zip\n" + digest + "  app.zip\n",
+			expected: digest,
+		},
+		{
+			name:     "sha256sum binary mode with path",
+			content:  strings.ToUpper(digest) + " *dist/app.zip\r\n",
+			expected: digest,
+		},
+		{
+			name:     "shasum tag format",
+			content:  "SHA256 (app.zip) = " + digest + "\n",
+			expected: digest,
+		},
+		{
+			name:     "digest only",
+			content:  digest + "\n",
+			expected: digest,
+		},
+		{
+			name:     "asset not listed",
+			content:  sha256Hex("other") + "  other.zip\n",
+			expected: "",
+		},
+		{
+			name:     "not a checksums file",
+			content:  "# Release notes\nThis is not a checksum\n",
+			expected: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseChecksums(tc.content, "app.zip"))
+		})
+	}
+}
+
+func Test_CreateRelease(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRelease(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_release", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.False(t, *tool.Annotations.DestructiveHint)
+	assert.Contains(t, tool.InputSchema.Properties, "tag_name")
+	assert.Contains(t, tool.InputSchema.Properties, "target_commitish")
+	assert.Contains(t, tool.InputSchema.Properties, "generate_release_notes")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag_name"})
+
+	mockRelease := &github.RepositoryRelease{
+		ID:        github.Ptr(int64(1)),
+		TagName:   github.Ptr("v1.0.0"),
+		Name:      github.Ptr("v1.0.0"),
+		Draft:     github.Ptr(true),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+		UploadURL: github.Ptr("https://uploads.github.com/repos/owner/repo/releases/1/assets{?name,label}"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "all parameters are sent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag_name":               "v1.0.0",
+						"target_commitish":       "main",
+						"name":                   "First release",
+						"body":                   "Highlights",
+						"draft":                  true,
+						"prerelease":             false,
+						"generate_release_notes": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRelease),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"tag_name":               "v1.0.0",
+				"target_commitish":       "main",
+				"name":                   "First release",
+				"body":                   "Highlights",
+				"draft":                  true,
+				"prerelease":             false,
+				"generate_release_notes": true,
+			},
+		},
+		{
+			name: "omitted parameters are not sent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag_name": "v1.0.0",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRelease),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"tag_name": "v1.0.0",
+			},
+		},
+		{
+			name: "release creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed", "errors": [{"resource": "Release", "code": "already_exists", "field": "tag_name"}]}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"tag_name": "v1.0.0",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create release",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRelease(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.RepositoryRelease
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, mockRelease.GetTagName(), returned.GetTagName())
+			assert.Equal(t, mockRelease.GetUploadURL(), returned.GetUploadURL())
+		})
+	}
+}
+
+func Test_CreateReleaseIsNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		repos, err := tsg.GetToolset("repos")
+		require.NoError(t, err)
+
+		var names []string
+		for _, st := range repos.GetAvailableTools() {
+			names = append(names, st.Tool.Name)
+		}
+		if readOnly {
+			assert.NotContains(t, names, "create_release")
+		} else {
+			assert.Contains(t, names, "create_release")
+		}
+	}
+}
@@ -1,14 +1,23 @@
 package github
 
 import (
+	"container/list"
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/raw"
@@ -37,6 +46,28 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Required(),
 				mcp.Description("Commit SHA, branch name, or tag name"),
 			),
+			mcp.WithString("output",
+				mcp.Description("'full' returns the commit as GitHub returns it. 'summary' returns the short SHA, author, author date and first line of the message, and the changed files and stats without their patches"),
+				mcp.Enum(commitOutputFull, commitOutputSummary),
+				mcp.DefaultString(commitOutputFull),
+			),
+			mcp.WithBoolean("include_patches",
+				mcp.Description("Include the patch of each file. Ignored with output 'summary', which never includes them"),
+				mcp.DefaultBool(true),
+			),
+			mcp.WithNumber("max_patch_bytes",
+				mcp.Description(fmt.Sprintf("Cut the patch of each file to at most this many bytes, at a line boundary where possible. A marker at the end of a cut patch tells how much was left out. 0 means the default of %d", defaultCommitMaxPatchBytes)),
+				mcp.Min(0),
+				mcp.DefaultNumber(defaultCommitMaxPatchBytes),
+			),
+			mcp.WithArray("paths",
+				mcp.Description("Only return the files whose path starts with one of these prefixes, e.g. 'pkg/github/'. The stats still cover the whole commit"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -52,6 +83,25 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			output, err := commitOutputParam(request, commitOutputFull, commitOutputSummary)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includePatches, ok, err := OptionalParamOK[bool](request, "include_patches")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				includePatches = true
+			}
+			maxPatchBytes, err := optionalByteLimitParam(request, "max_patch_bytes", defaultCommitMaxPatchBytes)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -67,30 +117,87 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 			commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, opts)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to get commit: %s", sha),
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to get commit: %s", sha), resp, err); !ok {
+				return result, err
+			}
+
+			files, filesOmitted := filterCommitFiles(commit.Files, paths)
+			if output == commitOutputSummary {
+				filtered := *commit
+				filtered.Files = files
+				summary := summarizeCommit(&filtered)
+				summary.FilesOmitted = filesOmitted
+				return MarshalledTextResult(summary), nil
+			}
+
+			result := CommitResult{
+				RepositoryCommit: commit,
+				FilesOmitted:     filesOmitted,
+			}
+			for _, file := range files {
+				changed := ChangedFile{CommitFile: file}
+				switch {
+				case !includePatches:
+					withoutPatch := *file
+					withoutPatch.Patch = nil
+					changed.CommitFile = &withoutPatch
+				case len(file.GetPatch()) > maxPatchBytes:
+					cutPatch := *file
+					cutPatch.Patch = github.Ptr(truncatePatch(file.GetPatch(), maxPatchBytes))
+					changed.CommitFile = &cutPatch
+					changed.PatchTruncated = true
+					result.PatchesTruncated++
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
+				result.Files = append(result.Files, changed)
 			}
+			return MarshalledTextResult(result), nil
+		}
+}
 
-			r, err := json.Marshal(commit)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+// defaultCommitMaxPatchBytes bounds the patch of each file returned by get_commit by default.
+const defaultCommitMaxPatchBytes = 8 * 1024
+
+// CommitResult is a commit as returned by get_commit. Its stats cover the whole commit, while Files
+// only lists the files that match the paths asked for; FilesOmitted counts the others.
+type CommitResult struct {
+	*github.RepositoryCommit
+	Files            []ChangedFile `json:"files,omitempty"`
+	FilesOmitted     int           `json:"files_omitted,omitempty"`
+	PatchesTruncated int           `json:"patches_truncated,omitempty"`
+}
 
-			return mcp.NewToolResultText(string(r)), nil
+// ChangedFile is a file changed by a commit as returned by get_commit. PatchTruncated is set when the
+// patch was cut to max_patch_bytes.
+type ChangedFile struct {
+	*github.CommitFile
+	PatchTruncated bool `json:"patch_truncated,omitempty"`
+}
+
+// filterCommitFiles keeps the files whose path, or previous path for renamed files, starts with one of
+// the prefixes, and also returns how many were left out. No prefixes keeps every file.
+func filterCommitFiles(files []*github.CommitFile, prefixes []string) ([]*github.CommitFile, int) {
+	if len(prefixes) == 0 {
+		return files, 0
+	}
+	kept := make([]*github.CommitFile, 0, len(files))
+	for _, file := range files {
+		matches := slices.ContainsFunc(prefixes, func(prefix string) bool {
+			return strings.HasPrefix(file.GetFilename(), prefix) ||
+				(file.GetPreviousFilename() != "" && strings.HasPrefix(file.GetPreviousFilename(), prefix))
+		})
+		if matches {
+			kept = append(kept, file)
 		}
+	}
+	return kept, len(files) - len(kept)
+}
+
+// truncatePatch cuts a patch longer than maxBytes with lineBoundaryCut and appends a marker saying how
+// much was kept.
+func truncatePatch(patch string, maxBytes int) string {
+	cut := lineBoundaryCut(patch, maxBytes)
+	kept := strings.TrimSuffix(patch[:cut], "\n")
+	return fmt.Sprintf("%s\n[patch truncated: showing %d of %d bytes; raise max_patch_bytes to see more]", kept, cut, len(patch))
 }
 
 // ListCommits creates a tool to get commits of a branch in a repository.
@@ -115,6 +222,15 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithString("author",
 				mcp.Description("Author username or email address to filter commits by"),
 			),
+			mcp.WithString("output",
+				mcp.Description("'full' returns the commits as GitHub returns them. 'summary' returns only the short SHA, author, author date and first line of the message of each commit. 'counts' returns counts of the commits by author and by day instead of the list of commits"),
+				mcp.Enum(commitOutputFull, commitOutputSummary, commitOutputCounts),
+				mcp.DefaultString(commitOutputFull),
+			),
+			mcp.WithBoolean("summary_only",
+				mcp.Description("Deprecated: use output 'counts'"),
+				mcp.DefaultBool(false),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -134,21 +250,31 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			pagination, err := OptionalPaginationParams(request)
+			output, err := commitOutputParam(request, commitOutputFull, commitOutputSummary, commitOutputCounts)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// summary_only is the deprecated spelling of output 'counts'
+			summaryOnly, err := OptionalParam[bool](request, "summary_only")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			// Set default perPage to 30 if not provided
-			perPage := pagination.PerPage
-			if perPage == 0 {
-				perPage = 30
+			if summaryOnly {
+				if explicit, _ := OptionalParam[string](request, "output"); explicit != "" && explicit != commitOutputCounts {
+					return mcp.NewToolResultError(fmt.Sprintf("summary_only cannot be combined with output '%s', use output 'counts' instead", explicit)), nil
+				}
+				output = commitOutputCounts
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 			opts := &github.CommitsListOptions{
 				SHA:    sha,
 				Author: author,
 				ListOptions: github.ListOptions{
 					Page:    pagination.Page,
-					PerPage: perPage,
+					PerPage: pagination.PerPage,
 				},
 			}
 
@@ -157,38 +283,178 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 			commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to list commits: %s", sha),
-					resp,
-					err,
-				), nil
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to list commits: %s", sha), resp, err); !ok {
+				return result, err
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+			switch output {
+			case commitOutputCounts:
+				return MarshalledTextResult(summarizeCommits(commits)), nil
+			case commitOutputSummary:
+				summaries := make([]CommitSummary, 0, len(commits))
+				for _, commit := range commits {
+					summaries = append(summaries, summarizeCommit(commit))
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil
+				return MarshalledTextResult(summaries), nil
+			default:
+				return MarshalledTextResult(commits), nil
 			}
+		}
+}
 
-			r, err := json.Marshal(commits)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+// Output modes of list_commits and get_commit. Only list_commits offers 'counts'.
+const (
+	commitOutputFull    = "full"
+	commitOutputSummary = "summary"
+	commitOutputCounts  = "counts"
+)
+
+// commitSummarySHALength is the length of the short SHAs in commit summaries.
+const commitSummarySHALength = 12
+
+// CommitSummary is a commit as returned by list_commits and get_commit with output 'summary'. Files and
+// Stats are only known to get_commit, and the files are listed without their patches.
+type CommitSummary struct {
+	SHA        string              `json:"sha"`
+	Author     string              `json:"author"`
+	AuthorDate string              `json:"author_date,omitempty"`
+	Message    string              `json:"message"`
+	Files      []ComparedFile      `json:"files,omitempty"`
+	Stats      *github.CommitStats `json:"stats,omitempty"`
+	// FilesOmitted counts the files of get_commit left out by its paths filter.
+	FilesOmitted int `json:"files_omitted,omitempty"`
+}
+
+// commitOutputParam reads the output parameter of list_commits and get_commit, which must be one of modes,
+// defaulting to 'full'.
+func commitOutputParam(request mcp.CallToolRequest, modes ...string) (string, error) {
+	output, err := OptionalParam[string](request, "output")
+	if err != nil {
+		return "", err
+	}
+	if output == "" {
+		return commitOutputFull, nil
+	}
+	if slices.Contains(modes, output) {
+		return output, nil
+	}
+	quoted := make([]string, len(modes))
+	for i, mode := range modes {
+		quoted[i] = strconv.Quote(mode)
+	}
+	last := len(quoted) - 1
+	return "", fmt.Errorf("invalid output %q, must be %s or %s", output, strings.Join(quoted[:last], ", "), quoted[last])
+}
+
+// summarizeCommit trims a commit down to its CommitSummary. The author is the GitHub login, falling back to
+// the git author name for commits that are not linked to an account, and only the first line of the message
+// is kept.
+func summarizeCommit(commit *github.RepositoryCommit) CommitSummary {
+	sha := commit.GetSHA()
+	if len(sha) > commitSummarySHALength {
+		sha = sha[:commitSummarySHALength]
+	}
+	author := commit.GetAuthor().GetLogin()
+	if author == "" {
+		author = commit.GetCommit().GetAuthor().GetName()
+	}
+	message, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
 
-			return mcp.NewToolResultText(string(r)), nil
+	summary := CommitSummary{
+		SHA:     sha,
+		Author:  author,
+		Message: strings.TrimSpace(message),
+		Stats:   commit.Stats,
+	}
+	if date := commit.GetCommit().GetAuthor().GetDate(); !date.IsZero() {
+		summary.AuthorDate = date.UTC().Format(time.RFC3339)
+	}
+	for _, file := range commit.Files {
+		summary.Files = append(summary.Files, ComparedFile{
+			Filename:         file.GetFilename(),
+			PreviousFilename: file.GetPreviousFilename(),
+			Status:           file.GetStatus(),
+			Additions:        file.GetAdditions(),
+			Deletions:        file.GetDeletions(),
+		})
+	}
+	return summary
+}
+
+// CommitsSummary is the result of list_commits with output 'counts'.
+type CommitsSummary struct {
+	TotalCommits int            `json:"total_commits"`
+	ByAuthor     map[string]int `json:"by_author"`
+	ByDay        map[string]int `json:"by_day"`
+}
+
+// summarizeCommits counts commits by author and by UTC day of authoring. Authors are identified by their GitHub
+// login, falling back to the git author name for commits that are not linked to an account.
+func summarizeCommits(commits []*github.RepositoryCommit) CommitsSummary {
+	summary := CommitsSummary{
+		TotalCommits: len(commits),
+		ByAuthor:     map[string]int{},
+		ByDay:        map[string]int{},
+	}
+	for _, commit := range commits {
+		author := commit.GetAuthor().GetLogin()
+		if author == "" {
+			author = commit.GetCommit().GetAuthor().GetName()
 		}
+		if author == "" {
+			author = "(unknown)"
+		}
+		summary.ByAuthor[author]++
+
+		if date := commit.GetCommit().GetAuthor().GetDate(); !date.IsZero() {
+			summary.ByDay[date.UTC().Format("2006-01-02")]++
+		}
+	}
+	return summary
 }
 
-// ListBranches creates a tool to list branches in a GitHub repository.
-func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("list_branches",
-			mcp.WithDescription(t("TOOL_LIST_BRANCHES_DESCRIPTION", "List branches in a GitHub repository")),
+// CompareCommitsResult is the result of compare_commits.
+type CompareCommitsResult struct {
+	Status       string `json:"status"`
+	AheadBy      int    `json:"ahead_by"`
+	BehindBy     int    `json:"behind_by"`
+	TotalCommits int    `json:"total_commits"`
+	// CommitsTruncated is set when no page was requested and GitHub listed fewer commits than
+	// total_commits, as it lists at most 250 commits without pagination. Request pages to get the rest.
+	CommitsTruncated bool             `json:"commits_truncated"`
+	Commits          []ComparedCommit `json:"commits"`
+	Files            []ComparedFile   `json:"files"`
+	NextPageInfo
+}
+
+// ComparedCommit is a commit listed by compare_commits.
+type ComparedCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author"`
+}
+
+// ComparedFile is a file changed between the refs compared by compare_commits. PatchTruncated is set
+// when the patch was cut to max_patch_bytes.
+type ComparedFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Patch            string `json:"patch,omitempty"`
+	PatchTruncated   bool   `json:"patch_truncated,omitempty"`
+}
+
+// defaultMaxPatchBytes bounds the patch of each file returned by compare_commits by default.
+const defaultMaxPatchBytes = 4000
+
+// CompareCommits creates a tool to compare two refs in a repository.
+func CompareCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_commits",
+			mcp.WithDescription(t("TOOL_COMPARE_COMMITS_DESCRIPTION", "Compare two refs of a GitHub repository: how far head is ahead of and behind base, the commits in head that are not in base, and the files changed. Use this to find what changed between two releases or branches. Without page or perPage up to 250 commits are listed, and commits_truncated tells whether there are more; pass page or perPage to paginate the commits. The changed files are only listed on the first page.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_LIST_BRANCHES_USER_TITLE", "List branches"),
+				Title:        t("TOOL_COMPARE_COMMITS_USER_TITLE", "Compare commits"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
@@ -199,6 +465,19 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Base branch, tag or commit SHA"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Head branch, tag or commit SHA. Use user:branch for a branch of a fork"),
+			),
+			mcp.WithNumber("max_patch_bytes",
+				mcp.Description(fmt.Sprintf("Cut the patch of each file to at most this many bytes, at a line boundary where possible. 0 means the default of %d", defaultMaxPatchBytes)),
+				mcp.Min(0),
+				mcp.DefaultNumber(defaultMaxPatchBytes),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -210,84 +489,121 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			pagination, err := OptionalPaginationParams(request)
+			base, err := RequiredParam[string](request, "base")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			opts := &github.BranchListOptions{
-				ListOptions: github.ListOptions{
-					Page:    pagination.Page,
-					PerPage: pagination.PerPage,
-				},
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			client, err := getClient(ctx)
+			maxPatchBytes, err := optionalByteLimitParam(request, "max_patch_bytes", defaultMaxPatchBytes)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, opts)
+			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to list branches",
-					resp,
-					err,
-				), nil
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// Without page or perPage, GitHub lists up to 250 commits in a single response.
+			// With either, the commits are paginated and next_page leads to the rest.
+			var opts *github.ListOptions
+			_, paged := request.GetArguments()["page"]
+			_, sized := request.GetArguments()["perPage"]
+			if paged || sized {
+				opts = &github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list branches: %s", string(body))), nil
+			}
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to compare %s...%s", base, head), resp, err); !ok {
+				return result, err
 			}
 
-			r, err := json.Marshal(branches)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			result := summarizeComparison(comparison, maxPatchBytes)
+			if opts == nil {
+				result.CommitsTruncated = len(comparison.Commits) < result.TotalCommits
 			}
+			result.HasNextPage = resp.NextPage != 0
+			result.NextPage = resp.NextPage
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// summarizeComparison condenses a comparison, cutting the patch of each file to maxPatchBytes.
+// A maxPatchBytes of 0 leaves the patches out.
+func summarizeComparison(comparison *github.CommitsComparison, maxPatchBytes int) CompareCommitsResult {
+	result := CompareCommitsResult{
+		Status:       comparison.GetStatus(),
+		AheadBy:      comparison.GetAheadBy(),
+		BehindBy:     comparison.GetBehindBy(),
+		TotalCommits: comparison.GetTotalCommits(),
+		Commits:      make([]ComparedCommit, 0, len(comparison.Commits)),
+		Files:        make([]ComparedFile, 0, len(comparison.Files)),
+	}
+
+	for _, commit := range comparison.Commits {
+		author := commit.GetAuthor().GetLogin()
+		if author == "" {
+			author = commit.GetCommit().GetAuthor().GetName()
+		}
+		result.Commits = append(result.Commits, ComparedCommit{
+			SHA:     commit.GetSHA(),
+			Message: commit.GetCommit().GetMessage(),
+			Author:  author,
+		})
+	}
 
-			return mcp.NewToolResultText(string(r)), nil
+	for _, file := range comparison.Files {
+		compared := ComparedFile{
+			Filename:         file.GetFilename(),
+			PreviousFilename: file.GetPreviousFilename(),
+			Status:           file.GetStatus(),
+			Additions:        file.GetAdditions(),
+			Deletions:        file.GetDeletions(),
+			Patch:            file.GetPatch(),
 		}
+		if len(compared.Patch) > maxPatchBytes {
+			compared.Patch = compared.Patch[:lineBoundaryCut(compared.Patch, maxPatchBytes)]
+			compared.PatchTruncated = true
+		}
+		result.Files = append(result.Files, compared)
+	}
+	return result
 }
 
-// CreateOrUpdateFile creates a tool to create or update a file in a GitHub repository.
-func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("create_or_update_file",
-			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository. If updating, you must provide the SHA of the file you want to update. Use this tool to create or update a file in a GitHub repository remotely; do not use it for local file operations.")),
+// GetRefCIState creates a tool to get the combined commit statuses and check runs for a ref.
+func GetRefCIState(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ref_ci_state",
+			mcp.WithDescription(t("TOOL_GET_REF_CI_STATE_DESCRIPTION", "Get the CI state of a commit, branch or tag, merging commit statuses (Status API) and check runs (Checks API) into one list. The overall state is failure if anything failed, otherwise pending if anything is still running or missing, otherwise success; it is none when there is nothing to report. With required_only set, only the checks required by branch protection and rulesets count towards the overall state.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_CREATE_OR_UPDATE_FILE_USER_TITLE", "Create or update file"),
-				ReadOnlyHint: ToBoolPtr(false),
+				Title:        t("TOOL_GET_REF_CI_STATE_USER_TITLE", "Get CI state for a ref"),
+				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
-				mcp.Description("Repository owner (username or organization)"),
+				mcp.Description("Repository owner"),
 			),
 			mcp.WithString("repo",
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("path",
-				mcp.Required(),
-				mcp.Description("Path where to create/update the file"),
-			),
-			mcp.WithString("content",
+			mcp.WithString("ref",
 				mcp.Required(),
-				mcp.Description("Content of the file"),
+				mcp.Description("Commit SHA, branch name, or tag name"),
 			),
-			mcp.WithString("message",
-				mcp.Required(),
-				mcp.Description("Commit message"),
+			mcp.WithBoolean("required_only",
+				mcp.Description("Only count checks required by branch protection and rulesets towards the overall state"),
+				mcp.DefaultBool(false),
 			),
 			mcp.WithString("branch",
-				mcp.Required(),
-				mcp.Description("Branch to create/update the file in"),
-			),
-			mcp.WithString("sha",
-				mcp.Description("Required if updating an existing file. The blob SHA of the file being replaced."),
+				mcp.Description("Branch whose required checks to use with required_only. Defaults to ref"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -299,178 +615,351 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			path, err := RequiredParam[string](request, "path")
+			ref, err := RequiredParam[string](request, "ref")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			content, err := RequiredParam[string](request, "content")
+			requiredOnly, err := OptionalParam[bool](request, "required_only")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			message, err := RequiredParam[string](request, "message")
+			branch, err := OptionalParam[string](request, "branch")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			branch, err := RequiredParam[string](request, "branch")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			if branch == "" {
+				branch = ref
 			}
 
-			// json.Marshal encodes byte arrays with base64, which is required for the API.
-			contentBytes := []byte(content)
-
-			// Create the file options
-			opts := &github.RepositoryContentFileOptions{
-				Message: github.Ptr(message),
-				Content: contentBytes,
-				Branch:  github.Ptr(branch),
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// If SHA is provided, set it (for updates)
-			sha, err := OptionalParam[string](request, "sha")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			ci := fetchRefCI(ctx, client, owner, repo, ref, branch, requiredOnly)
+			if ci.statusErr != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get combined status", ci.statusResp, ci.statusErr), nil
 			}
-			if sha != "" {
-				opts.SHA = github.Ptr(sha)
+			if ci.checksErr != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list check runs", ci.checksResp, ci.checksErr), nil
 			}
 
-			// Create or update the file
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
-			fileContent, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to create/update file",
-					resp,
-					err,
-				), nil
+			result := RefCIState{
+				Ref:          ref,
+				SHA:          ci.status.GetSHA(),
+				RequiredOnly: requiredOnly,
+				Items:        mergeCIItems(ci.status.Statuses, ci.checkRuns.CheckRuns),
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != 200 && resp.StatusCode != 201 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+			var required []string
+			if requiredOnly {
+				result.Branch = branch
+				switch {
+				case ci.protectionErr == nil:
+					for _, check := range requiredChecksFromProtection(ci.protection) {
+						required = append(required, check.context)
+					}
+				case ci.protectionResp != nil && ci.protectionResp.StatusCode == http.StatusNotFound:
+					// The branch is not protected, or we lack permission to see that it is
+				default:
+					result.Notes = append(result.Notes, fmt.Sprintf("could not read branch protection, which requires admin access: %s", ci.protectionErr.Error()))
+				}
+				if ci.rulesErr != nil {
+					result.Notes = append(result.Notes, fmt.Sprintf("could not read rulesets: %s", ci.rulesErr.Error()))
+				} else {
+					for _, check := range requiredChecksFromRules(ci.rules) {
+						required = append(required, check.context)
+					}
+				}
+				if required == nil {
+					required = []string{}
+					result.Notes = append(result.Notes, fmt.Sprintf("no required checks found for branch %s", branch))
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create/update file: %s", string(body))), nil
 			}
-
-			r, err := json.Marshal(fileContent)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			result.State, result.Missing = rollupCIState(result.Items, required)
+			if ci.truncated {
+				result.Truncated = true
+				result.State = capTruncatedCIState(result.State, true)
+				result.Notes = append(result.Notes, fmt.Sprintf("only the first %d statuses and check runs of each kind were fetched, so the state is at best pending", maxCIPages*100))
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(result), nil
 		}
 }
 
-// CreateRepository creates a tool to create a new GitHub repository.
-func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("create_repository",
-			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_DESCRIPTION", "Create a new GitHub repository in your account")),
-			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_CREATE_REPOSITORY_USER_TITLE", "Create repository"),
-				ReadOnlyHint: ToBoolPtr(false),
-			}),
-			mcp.WithString("name",
-				mcp.Required(),
-				mcp.Description("Repository name"),
-			),
-			mcp.WithString("description",
-				mcp.Description("Repository description"),
-			),
-			mcp.WithBoolean("private",
-				mcp.Description("Whether repo should be private"),
-			),
-			mcp.WithBoolean("autoInit",
-				mcp.Description("Initialize with README"),
-			),
-		),
-		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			name, err := RequiredParam[string](request, "name")
+// Unified states of a commit status or check run.
+const (
+	ciStateSuccess = "success"
+	ciStatePending = "pending"
+	ciStateFailure = "failure"
+	ciStateNone    = "none"
+)
+
+// RefCIState is the result of the get_ref_ci_state tool.
+type RefCIState struct {
+	Ref          string   `json:"ref"`
+	SHA          string   `json:"sha"`
+	State        string   `json:"state"`
+	RequiredOnly bool     `json:"required_only"`
+	Branch       string   `json:"branch,omitempty"`
+	Items        []CIItem `json:"items"`
+	Missing      []string `json:"missing,omitempty"`
+	Notes        []string `json:"notes,omitempty"`
+	Truncated    bool     `json:"truncated,omitempty"`
+}
+
+// CIItem is a commit status or check run reported on a ref.
+type CIItem struct {
+	Name        string            `json:"name"`
+	Kind        string            `json:"kind"`
+	State       string            `json:"state"`
+	URL         string            `json:"url,omitempty"`
+	CompletedAt *github.Timestamp `json:"completed_at,omitempty"`
+}
+
+// refCI holds the responses fetched for get_ref_ci_state.
+type refCI struct {
+	status     *github.CombinedStatus
+	statusResp *github.Response
+	statusErr  error
+
+	checkRuns  *github.ListCheckRunsResults
+	checksResp *github.Response
+	checksErr  error
+
+	protection     *github.Protection
+	protectionResp *github.Response
+	protectionErr  error
+
+	rules    *github.BranchRules
+	rulesErr error
+
+	// truncated is set when there were more than maxCIPages pages of statuses or check runs
+	truncated bool
+}
+
+// maxCIPages bounds how many pages of 100 commit statuses, and of 100 check runs, are fetched for a ref.
+const maxCIPages = 10
+
+// fetchRefCI fetches the combined status and check runs for ref concurrently, along with
+// the branch protection and rulesets of branch when the required checks are needed.
+func fetchRefCI(ctx context.Context, client *github.Client, owner, repo, ref, branch string, withRequired bool) refCI {
+	var ci refCI
+	var wg sync.WaitGroup
+
+	var statusesTruncated, checksTruncated bool
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		opts := &github.ListOptions{PerPage: 100}
+		for page := 1; ; page++ {
+			status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+			closeResponseBody(resp)
+			ci.statusResp, ci.statusErr = resp, err
 			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return
 			}
-			description, err := OptionalParam[string](request, "description")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			if ci.status == nil {
+				ci.status = status
+			} else {
+				ci.status.Statuses = append(ci.status.Statuses, status.Statuses...)
 			}
-			private, err := OptionalParam[bool](request, "private")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			if resp.NextPage == 0 {
+				return
 			}
-			autoInit, err := OptionalParam[bool](request, "autoInit")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			if page == maxCIPages {
+				statusesTruncated = true
+				return
 			}
+			opts.Page = resp.NextPage
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		for page := 1; ; page++ {
+			checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+			closeResponseBody(resp)
+			ci.checksResp, ci.checksErr = resp, err
+			if err != nil {
+				return
+			}
+			if ci.checkRuns == nil {
+				ci.checkRuns = checkRuns
+			} else {
+				ci.checkRuns.CheckRuns = append(ci.checkRuns.CheckRuns, checkRuns.CheckRuns...)
+			}
+			if resp.NextPage == 0 {
+				return
+			}
+			if page == maxCIPages {
+				checksTruncated = true
+				return
+			}
+			opts.Page = resp.NextPage
+		}
+	}()
+
+	if withRequired {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ci.protection, ci.protectionResp, ci.protectionErr = client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			closeResponseBody(ci.protectionResp)
+		}()
+		go func() {
+			defer wg.Done()
+			var resp *github.Response
+			ci.rules, resp, ci.rulesErr = client.Repositories.GetRulesForBranch(ctx, owner, repo, branch, &github.ListOptions{PerPage: 100})
+			closeResponseBody(resp)
+		}()
+	}
 
-			repo := &github.Repository{
-				Name:        github.Ptr(name),
-				Description: github.Ptr(description),
-				Private:     github.Ptr(private),
-				AutoInit:    github.Ptr(autoInit),
-			}
+	wg.Wait()
+	ci.truncated = statusesTruncated || checksTruncated
+	return ci
+}
 
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
-			createdRepo, resp, err := client.Repositories.Create(ctx, "", repo)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to create repository",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
+// capTruncatedCIState keeps a truncated list of statuses and check runs from reading as success, as
+// a failure may be among those that were not fetched.
+func capTruncatedCIState(state string, truncated bool) string {
+	if truncated && (state == ciStateSuccess || state == ciStateNone) {
+		return ciStatePending
+	}
+	return state
+}
 
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create repository: %s", string(body))), nil
-			}
+// mergeCIItems converts commit statuses and check runs into a single list. Only the most recent
+// check run for each name is kept, as re-runs leave the earlier runs attached to the commit.
+func mergeCIItems(statuses []*github.RepoStatus, checkRuns []*github.CheckRun) []CIItem {
+	items := []CIItem{}
+	for _, status := range statuses {
+		item := CIItem{
+			Name: status.GetContext(),
+			Kind: "status",
+			URL:  status.GetTargetURL(),
+		}
+		switch status.GetState() {
+		case "success":
+			item.State = ciStateSuccess
+			item.CompletedAt = status.UpdatedAt
+		case "pending":
+			item.State = ciStatePending
+		default:
+			item.State = ciStateFailure
+			item.CompletedAt = status.UpdatedAt
+		}
+		items = append(items, item)
+	}
 
-			r, err := json.Marshal(createdRepo)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+	latest := make(map[string]*github.CheckRun)
+	var names []string
+	for _, run := range checkRuns {
+		existing, ok := latest[run.GetName()]
+		if !ok {
+			names = append(names, run.GetName())
+		}
+		if !ok || run.GetID() > existing.GetID() {
+			latest[run.GetName()] = run
+		}
+	}
+	for _, name := range names {
+		run := latest[name]
+		item := CIItem{
+			Name:        name,
+			Kind:        "check",
+			URL:         run.GetHTMLURL(),
+			CompletedAt: run.CompletedAt,
+		}
+		switch {
+		case run.GetStatus() != "completed":
+			item.State = ciStatePending
+		case run.GetConclusion() == "success", run.GetConclusion() == "neutral", run.GetConclusion() == "skipped":
+			item.State = ciStateSuccess
+		default:
+			item.State = ciStateFailure
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// rollupCIState computes the overall state of items: failure takes precedence over pending,
+// which takes precedence over success. If required is not nil, only items named in required
+// count, and required names without an item are reported as missing and count as pending.
+// The state is none when nothing counts.
+func rollupCIState(items []CIItem, required []string) (string, []string) {
+	counted := items
+	var missing []string
+	if required != nil {
+		requiredSet := make(map[string]bool, len(required))
+		for _, name := range required {
+			requiredSet[name] = true
+		}
+		counted = nil
+		found := make(map[string]bool)
+		for _, item := range items {
+			if requiredSet[item.Name] {
+				counted = append(counted, item)
+				found[item.Name] = true
+			}
+		}
+		for _, name := range required {
+			if !found[name] && !slices.Contains(missing, name) {
+				missing = append(missing, name)
 			}
+		}
+	}
+
+	if len(counted) == 0 && len(missing) == 0 {
+		return ciStateNone, missing
+	}
 
-			return mcp.NewToolResultText(string(r)), nil
+	state := ciStateSuccess
+	if len(missing) > 0 {
+		state = ciStatePending
+	}
+	for _, item := range counted {
+		switch item.State {
+		case ciStateFailure:
+			return ciStateFailure, missing
+		case ciStatePending:
+			state = ciStatePending
 		}
+	}
+	return state, missing
 }
 
-// GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
-func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("get_file_contents",
-			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository")),
+// ListBranches creates a tool to list branches in a GitHub repository.
+func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_branches",
+			mcp.WithDescription(t("TOOL_LIST_BRANCHES_DESCRIPTION", fmt.Sprintf("List branches in a GitHub repository. With include_divergence set, each branch also reports how many commits it is ahead of and behind compare_to and the date of its last commit; pages are then limited to %d branches.", maxBranchDivergencePerPage))),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
+				Title:        t("TOOL_LIST_BRANCHES_USER_TITLE", "List branches"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
-				mcp.Description("Repository owner (username or organization)"),
+				mcp.Description("Repository owner"),
 			),
 			mcp.WithString("repo",
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("path",
-				mcp.Description("Path to file/directory (directories must end with a slash '/')"),
-				mcp.DefaultString("/"),
+			mcp.WithBoolean("include_divergence",
+				mcp.Description("Add ahead_by, behind_by and last_commit_date to each branch. Values that cannot be computed are null"),
+				mcp.DefaultBool(false),
 			),
-			mcp.WithString("ref",
-				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`"),
+			mcp.WithString("compare_to",
+				mcp.Description("Branch to compute divergence against when include_divergence is set. Defaults to the repository's default branch"),
 			),
-			mcp.WithString("sha",
-				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			mcp.WithBoolean("protected",
+				mcp.Description("Only list protected branches when true, or only unprotected branches when false"),
+			),
+			mcp.WithString("pattern",
+				mcp.Description(fmt.Sprintf("Only list branches whose name matches this glob, e.g. 'release/*'. '*' does not match '/'. Pages are scanned from page until perPage matches are found, at most %d pages at a time, and each match is returned with its head SHA and protected flag. Continue from next_page while has_next_page is set. Cannot be combined with include_divergence", maxBranchPatternPages)),
 			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -481,173 +970,198 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			path, err := RequiredParam[string](request, "path")
+			includeDivergence, err := OptionalParam[bool](request, "include_divergence")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			ref, err := OptionalParam[string](request, "ref")
+			compareTo, err := OptionalParam[string](request, "compare_to")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			sha, err := OptionalParam[string](request, "sha")
+			protected, protectedSet, err := OptionalParamOK[bool](request, "protected")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			client, err := getClient(ctx)
+			pattern, err := OptionalParam[string](request, "pattern")
 			if err != nil {
-				return mcp.NewToolResultError("failed to get GitHub client"), nil
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if pattern != "" {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid pattern %q: %v", pattern, err)), nil
+				}
+				if includeDivergence {
+					return mcp.NewToolResultError("pattern cannot be combined with include_divergence"), nil
+				}
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// Each branch costs a compare call, so keep pages small when computing divergence.
+			if includeDivergence && pagination.PerPage > maxBranchDivergencePerPage {
+				pagination.PerPage = maxBranchDivergencePerPage
 			}
 
-			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			opts := &github.BranchListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if protectedSet {
+				opts.Protected = github.Ptr(protected)
+			}
+
+			client, err := getClient(ctx)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// If the path is (most likely) not to be a directory, we will
-			// first try to get the raw content from the GitHub raw content API.
-			if path != "" && !strings.HasSuffix(path, "/") {
-				// First, get file info from Contents API to retrieve SHA
-				var fileSHA string
-				opts := &github.RepositoryContentGetOptions{Ref: ref}
-				fileContent, _, respContents, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
-				if respContents != nil {
-					defer func() { _ = respContents.Body.Close() }()
-				}
-				if err != nil {
-					return ghErrors.NewGitHubAPIErrorResponse(ctx,
-						"failed to get file SHA",
-						respContents,
-						err,
-					), nil
-				}
-				if fileContent == nil || fileContent.SHA == nil {
-					return mcp.NewToolResultError("file content SHA is nil"), nil
+			if pattern != "" {
+				result, errResult, err := findMatchingBranches(ctx, client, owner, repo, pattern, opts)
+				if errResult != nil || err != nil {
+					return errResult, err
 				}
-				fileSHA = *fileContent.SHA
+				return MarshalledTextResult(result), nil
+			}
 
-				rawClient, err := getRawClient(ctx)
-				if err != nil {
-					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
-				}
-				resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
-				if err != nil {
-					return mcp.NewToolResultError("failed to get raw repository content"), nil
+			branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list branches", resp, err); !ok {
+				return result, err
+			}
+
+			if !includeDivergence {
+				return MarshalledTextResult(branches), nil
+			}
+
+			if compareTo == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if result, ok, err := handleRESTResponse(ctx, "failed to get repository", resp, err); !ok {
+					return result, err
 				}
-				defer func() {
-					_ = resp.Body.Close()
-				}()
+				compareTo = repository.GetDefaultBranch()
+			}
 
-				if resp.StatusCode == http.StatusOK {
-					// If the raw content is found, return it directly
-					body, err := io.ReadAll(resp.Body)
-					if err != nil {
-						return mcp.NewToolResultError("failed to read response body"), nil
-					}
-					contentType := resp.Header.Get("Content-Type")
+			return MarshalledTextResult(branchesWithDivergence(ctx, client, owner, repo, compareTo, branches)), nil
+		}
+}
 
-					var resourceURI string
-					switch {
-					case sha != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					case ref != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, ref, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					default:
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					}
+// maxBranchPatternPages bounds the pages of branches list_branches scans for matches of a pattern in one call.
+const maxBranchPatternPages = 10
 
-					if strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text") {
-						result := mcp.TextResourceContents{
-							URI:      resourceURI,
-							Text:     string(body),
-							MIMEType: contentType,
-						}
-						// Include SHA in the result metadata
-						if fileSHA != "" {
-							return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded text file (SHA: %s)", fileSHA), result), nil
-						}
-						return mcp.NewToolResultResource("successfully downloaded text file", result), nil
-					}
+// MatchingBranch is a branch returned by list_branches with a pattern.
+type MatchingBranch struct {
+	Name      string `json:"name"`
+	SHA       string `json:"sha"`
+	Protected bool   `json:"protected"`
+}
 
-					result := mcp.BlobResourceContents{
-						URI:      resourceURI,
-						Blob:     base64.StdEncoding.EncodeToString(body),
-						MIMEType: contentType,
-					}
-					// Include SHA in the result metadata
-					if fileSHA != "" {
-						return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded binary file (SHA: %s)", fileSHA), result), nil
-					}
-					return mcp.NewToolResultResource("successfully downloaded binary file", result), nil
+// MatchingBranchesResult is the result of list_branches with a pattern. NextPage is the page to continue
+// scanning from.
+type MatchingBranchesResult struct {
+	Branches []MatchingBranch `json:"branches"`
+	NextPageInfo
+}
 
-				}
+// findMatchingBranches scans pages of branches from opts.Page on, keeping the branches whose name matches
+// pattern, until it has found opts.PerPage matches, run out of branches or scanned maxBranchPatternPages
+// pages. All the matches of the pages scanned are kept, so there can be more than opts.PerPage of them. When
+// the branches cannot be listed, the returned result and error should be passed straight back to the caller.
+func findMatchingBranches(ctx context.Context, client *github.Client, owner, repo, pattern string, opts *github.BranchListOptions) (MatchingBranchesResult, *mcp.CallToolResult, error) {
+	result := MatchingBranchesResult{Branches: []MatchingBranch{}}
+	for scanned := 0; scanned < maxBranchPatternPages; scanned++ {
+		branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, opts)
+		if errResult, ok, err := handleRESTResponse(ctx, "failed to list branches", resp, err); !ok {
+			return MatchingBranchesResult{}, errResult, err
+		}
+		for _, branch := range branches {
+			// The pattern was checked up front, so matching cannot fail.
+			if matched, _ := path.Match(pattern, branch.GetName()); matched {
+				result.Branches = append(result.Branches, MatchingBranch{
+					Name:      branch.GetName(),
+					SHA:       branch.GetCommit().GetSHA(),
+					Protected: branch.GetProtected(),
+				})
 			}
+		}
+		result.NextPage = resp.NextPage
+		if resp.NextPage == 0 || len(result.Branches) >= opts.PerPage {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	result.HasNextPage = result.NextPage > 0
+	return result, nil, nil
+}
 
-			if rawOpts.SHA != "" {
-				ref = rawOpts.SHA
-			}
-			if strings.HasSuffix(path, "/") {
-				opts := &github.RepositoryContentGetOptions{Ref: ref}
-				_, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
-				if err == nil && resp.StatusCode == http.StatusOK {
-					defer func() { _ = resp.Body.Close() }()
-					r, err := json.Marshal(dirContent)
-					if err != nil {
-						return mcp.NewToolResultError("failed to marshal response"), nil
-					}
-					return mcp.NewToolResultText(string(r)), nil
-				}
-			}
+const (
+	// maxBranchDivergencePerPage caps the page size of list_branches when divergence is requested.
+	maxBranchDivergencePerPage = 20
+	// branchDivergenceConcurrency bounds the number of compare calls in flight at once.
+	branchDivergenceConcurrency = 5
+)
 
-			// The path does not point to a file or directory.
-			// Instead let's try to find it in the Git Tree by matching the end of the path.
+// BranchWithDivergence is a branch returned by list_branches with include_divergence set.
+// The counts and date are null when the comparison for the branch failed.
+type BranchWithDivergence struct {
+	*github.Branch
+	AheadBy        *int              `json:"ahead_by"`
+	BehindBy       *int              `json:"behind_by"`
+	LastCommitDate *github.Timestamp `json:"last_commit_date"`
+}
 
-			// Step 1: Get Git Tree recursively
-			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get git tree",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
+// branchesWithDivergence compares each branch with compareTo, running at most branchDivergenceConcurrency
+// comparisons at once. The branch is used as the base of the comparison, which makes the base commit of the
+// response the branch head, so its date comes for free; the ahead and behind counts are swapped accordingly.
+func branchesWithDivergence(ctx context.Context, client *github.Client, owner, repo, compareTo string, branches []*github.Branch) []BranchWithDivergence {
+	result := make([]BranchWithDivergence, len(branches))
+	sem := make(chan struct{}, branchDivergenceConcurrency)
+	var wg sync.WaitGroup
+
+	for i, branch := range branches {
+		result[i].Branch = branch
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, branch.GetName(), compareTo, &github.ListOptions{PerPage: 1})
+			closeResponseBody(resp)
+			if err != nil {
+				return
+			}
+			result[i].AheadBy = github.Ptr(comparison.GetBehindBy())
+			result[i].BehindBy = github.Ptr(comparison.GetAheadBy())
+			if date := comparison.GetBaseCommit().GetCommit().GetCommitter().Date; date != nil {
+				result[i].LastCommitDate = date
+			}
+		}()
+	}
 
-			// Step 2: Filter tree for matching paths
-			const maxMatchingFiles = 3
-			matchingFiles := filterPaths(tree.Entries, path, maxMatchingFiles)
-			if len(matchingFiles) > 0 {
-				matchingFilesJSON, err := json.Marshal(matchingFiles)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal matching files: %s", err)), nil
-				}
-				resolvedRefs, err := json.Marshal(rawOpts)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal resolved refs: %s", err)), nil
-				}
-				return mcp.NewToolResultText(fmt.Sprintf("Path did not point to a file or directory, but resolved git ref to %s with possible path matches: %s", resolvedRefs, matchingFilesJSON)), nil
-			}
+	wg.Wait()
+	return result
+}
 
-			return mcp.NewToolResultError("Failed to get file contents. The path does not point to a file or directory, or the file does not exist in the repository."), nil
-		}
+// BranchProtectionSummary is the condensed branch protection returned by get_branch_protection.
+type BranchProtectionSummary struct {
+	Branch                       string   `json:"branch"`
+	RequiredStatusChecks         []string `json:"required_status_checks"`
+	StrictStatusChecks           bool     `json:"strict_status_checks"`
+	RequiredApprovingReviewCount int      `json:"required_approving_review_count"`
+	RequireCodeOwnerReviews      bool     `json:"require_code_owner_reviews"`
+	EnforceAdmins                bool     `json:"enforce_admins"`
+	AllowForcePushes             bool     `json:"allow_force_pushes"`
 }
 
-// ForkRepository creates a tool to fork a repository.
-func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("fork_repository",
-			mcp.WithDescription(t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization")),
+// GetBranchProtection creates a tool to get the protection of a branch in a GitHub repository.
+func GetBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_protection",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_PROTECTION_DESCRIPTION", "Get the protection of a branch in a GitHub repository: the required status checks, the number of approving reviews required, and whether admins are included and force pushes are allowed. Use this before pushing to or merging into a branch. Reading branch protection requires admin access to the repository.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_FORK_REPOSITORY_USER_TITLE", "Fork repository"),
-				ReadOnlyHint: ToBoolPtr(false),
+				Title:        t("TOOL_GET_BRANCH_PROTECTION_USER_TITLE", "Get branch protection"),
+				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -657,8 +1171,9 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("organization",
-				mcp.Description("Organization to fork to"),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -670,7 +1185,1078 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			org, err := OptionalParam[string](request, "organization")
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			// An unprotected branch is an answer, not a failure. Other 404s, such as a missing branch, are still errors.
+			if errors.Is(err, github.ErrBranchNotProtected) {
+				closeResponseBody(resp)
+				return mcp.NewToolResultText(fmt.Sprintf("Branch protection is not configured for branch %s of %s/%s.", branch, owner, repo)), nil
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to get branch protection", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(summarizeBranchProtection(branch, protection)), nil
+		}
+}
+
+// summarizeBranchProtection condenses protection to the settings that decide whether a push or merge is accepted.
+func summarizeBranchProtection(branch string, protection *github.Protection) BranchProtectionSummary {
+	summary := BranchProtectionSummary{
+		Branch:               branch,
+		RequiredStatusChecks: []string{},
+	}
+	for _, check := range requiredChecksFromProtection(protection) {
+		summary.RequiredStatusChecks = append(summary.RequiredStatusChecks, check.context)
+	}
+	if statusChecks := protection.GetRequiredStatusChecks(); statusChecks != nil {
+		summary.StrictStatusChecks = statusChecks.Strict
+	}
+	if reviews := protection.GetRequiredPullRequestReviews(); reviews != nil {
+		summary.RequiredApprovingReviewCount = reviews.RequiredApprovingReviewCount
+		summary.RequireCodeOwnerReviews = reviews.RequireCodeOwnerReviews
+	}
+	if enforceAdmins := protection.GetEnforceAdmins(); enforceAdmins != nil {
+		summary.EnforceAdmins = enforceAdmins.Enabled
+	}
+	if allowForcePushes := protection.GetAllowForcePushes(); allowForcePushes != nil {
+		summary.AllowForcePushes = allowForcePushes.Enabled
+	}
+	return summary
+}
+
+// UpdateBranchProtection creates a tool to replace the protection of a branch in a GitHub repository.
+func UpdateBranchProtection(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_branch_protection",
+			mcp.WithDescription(t("TOOL_UPDATE_BRANCH_PROTECTION_DESCRIPTION", "Set the protection of a branch in a GitHub repository. This replaces the entire protection configuration: any setting left out is disabled, so read the current protection with get_branch_protection first and pass every setting to keep. Requires admin access to the repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_UPDATE_BRANCH_PROTECTION_USER_TITLE", "Update branch protection"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+			mcp.WithObject("required_status_checks",
+				mcp.Description("Status checks that must pass before merging. Omit to require no status checks"),
+				mcp.Properties(map[string]any{
+					"strict": map[string]any{
+						"type":        "boolean",
+						"description": "Require branches to be up to date with the base branch before merging",
+					},
+					"contexts": map[string]any{
+						"type":        "array",
+						"description": "Names of the status checks that must pass",
+						"items":       map[string]any{"type": "string"},
+					},
+				}),
+			),
+			mcp.WithNumber("required_approving_review_count",
+				mcp.Description("Number of approving reviews required before merging. Omit this and dismiss_stale_reviews to require no pull request reviews"),
+				mcp.Min(0),
+				mcp.Max(6),
+			),
+			mcp.WithBoolean("dismiss_stale_reviews",
+				mcp.Description("Dismiss approving reviews when new commits are pushed"),
+			),
+			mcp.WithBoolean("enforce_admins",
+				mcp.Description("Apply the protection to repository administrators too"),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithObject("restrictions",
+				mcp.Description("Only these users and teams may push to the branch; empty lists leave only administrators. Omit to let everyone with write access push. Only available for organization repositories"),
+				mcp.Properties(map[string]any{
+					"users": map[string]any{
+						"type":        "array",
+						"description": "Logins of the users who may push",
+						"items":       map[string]any{"type": "string"},
+					},
+					"teams": map[string]any{
+						"type":        "array",
+						"description": "Slugs of the teams who may push",
+						"items":       map[string]any{"type": "string"},
+					},
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			protectionRequest, err := protectionRequestFromParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			protection, resp, err := client.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, protectionRequest)
+			if result, ok, err := handleRESTResponse(ctx, "failed to update branch protection", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(summarizeBranchProtection(branch, protection)), nil
+		}
+}
+
+// protectionRequestFromParams builds the request of update_branch_protection. The API replaces the whole
+// protection, so settings that were not given are sent as null, which disables them, rather than left out.
+// Given lists are always sent as arrays: an empty contexts list still requires status checks to be
+// reported, and empty users and teams lists leave only administrators able to push.
+func protectionRequestFromParams(request mcp.CallToolRequest) (*github.ProtectionRequest, error) {
+	enforceAdmins, err := OptionalParam[bool](request, "enforce_admins")
+	if err != nil {
+		return nil, err
+	}
+	protectionRequest := &github.ProtectionRequest{EnforceAdmins: enforceAdmins}
+
+	statusChecks, ok, err := optionalObjectParam(request, "required_status_checks")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		strict, err := OptionalParam[bool](statusChecks, "strict")
+		if err != nil {
+			return nil, fmt.Errorf("required_status_checks: %w", err)
+		}
+		contexts, err := OptionalStringArrayParam(statusChecks, "contexts")
+		if err != nil {
+			return nil, fmt.Errorf("required_status_checks: %w", err)
+		}
+		protectionRequest.RequiredStatusChecks = &github.RequiredStatusChecks{Strict: strict, Contexts: &contexts}
+	}
+
+	reviewCount, hasReviewCount, err := OptionalParamOK[float64](request, "required_approving_review_count")
+	if err != nil {
+		return nil, err
+	}
+	dismissStale, hasDismissStale, err := OptionalParamOK[bool](request, "dismiss_stale_reviews")
+	if err != nil {
+		return nil, err
+	}
+	if hasReviewCount || hasDismissStale {
+		protectionRequest.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			RequiredApprovingReviewCount: int(reviewCount),
+			DismissStaleReviews:          dismissStale,
+		}
+	}
+
+	restrictions, ok, err := optionalObjectParam(request, "restrictions")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		users, err := OptionalStringArrayParam(restrictions, "users")
+		if err != nil {
+			return nil, fmt.Errorf("restrictions: %w", err)
+		}
+		teams, err := OptionalStringArrayParam(restrictions, "teams")
+		if err != nil {
+			return nil, fmt.Errorf("restrictions: %w", err)
+		}
+		protectionRequest.Restrictions = &github.BranchRestrictionsRequest{Users: users, Teams: teams, Apps: []string{}}
+	}
+
+	return protectionRequest, nil
+}
+
+// optionalObjectParam returns the object parameter p as a request of its own, so that its fields
+// can be read with the usual parameter helpers. ok is false when p is absent or null.
+func optionalObjectParam(r mcp.CallToolRequest, p string) (object mcp.CallToolRequest, ok bool, err error) {
+	value, present := r.GetArguments()[p]
+	if !present || value == nil {
+		return object, false, nil
+	}
+	fields, isObject := value.(map[string]any)
+	if !isObject {
+		return object, false, fmt.Errorf("parameter %s is not of type object, is %T", p, value)
+	}
+	object.Params.Arguments = fields
+	return object, true, nil
+}
+
+// CreateOrUpdateFile creates a tool to create or update a file in a GitHub repository.
+func CreateOrUpdateFile(getClient GetClientFn, guard *DefaultBranchGuard, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_or_update_file",
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository. If updating, you must provide the SHA of the file you want to update. Use this tool to create or update a file in a GitHub repository remotely; do not use it for local file operations.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_OR_UPDATE_FILE_USER_TITLE", "Create or update file"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path where to create/update the file"),
+			),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Content of the file"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to create/update the file in"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Required if updating an existing file. The blob SHA of the file being replaced."),
+			),
+			WithAllowDefaultBranch(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := RequiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowDefaultBranch, err := OptionalParam[bool](request, "allow_default_branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// json.Marshal encodes byte arrays with base64, which is required for the API.
+			contentBytes := []byte(content)
+
+			// Create the file options
+			opts := &github.RepositoryContentFileOptions{
+				Message: github.Ptr(message),
+				Content: contentBytes,
+				Branch:  github.Ptr(branch),
+			}
+
+			// If SHA is provided, set it (for updates)
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if sha != "" {
+				opts.SHA = github.Ptr(sha)
+			}
+
+			// Create or update the file
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result := guard.Check(ctx, client, owner, repo, branch, allowDefaultBranch); result != nil {
+				return result, nil
+			}
+			fileContent, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create/update file", resp, err, http.StatusOK, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(fileContent), nil
+		}
+}
+
+// CreateRepository creates a tool to create a new GitHub repository.
+func CreateRepository(getClient GetClientFn, guard *DefaultBranchGuard, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_DESCRIPTION", "Create a new GitHub repository in your account")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REPOSITORY_USER_TITLE", "Create repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Repository description"),
+			),
+			mcp.WithBoolean("private",
+				mcp.Description("Whether repo should be private"),
+			),
+			mcp.WithBoolean("autoInit",
+				mcp.Description("Initialize with README"),
+			),
+			mcp.WithString("gitignore_template",
+				mcp.Description("Name of a .gitignore template to apply, as returned by list_gitignore_templates (e.g. 'Go')"),
+			),
+			mcp.WithString("license_template",
+				mcp.Description("Key of a license template to apply (e.g. 'mit'). list_license_templates returns the commonly used ones, but any license key GitHub knows is accepted (e.g. 'isc')"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			private, err := OptionalParam[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			autoInit, err := OptionalParam[bool](request, "autoInit")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			gitignoreTemplate, err := OptionalParam[string](request, "gitignore_template")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			licenseTemplate, err := OptionalParam[string](request, "license_template")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			repo := &github.Repository{
+				Name:        github.Ptr(name),
+				Description: github.Ptr(description),
+				Private:     github.Ptr(private),
+				AutoInit:    github.Ptr(autoInit),
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Check the .gitignore template up front: GitHub rejects an unknown one only after
+			// validating everything else, and without saying what would have matched. The check
+			// is a convenience, so a failed listing leaves the name for GitHub to judge.
+			if gitignoreTemplate != "" {
+				repo.GitignoreTemplate = github.Ptr(gitignoreTemplate)
+				if names, err := listGitignoreTemplateNames(ctx, client); err == nil {
+					template, found := matchTemplate(gitignoreTemplate, names)
+					if !found {
+						return unknownTemplateResult("gitignore", gitignoreTemplate, names), nil
+					}
+					repo.GitignoreTemplate = github.Ptr(template)
+				}
+			}
+			// The license list only holds the featured licenses, so any other key is passed on as
+			// is. License keys are lower case.
+			if licenseTemplate != "" {
+				repo.LicenseTemplate = github.Ptr(strings.ToLower(licenseTemplate))
+			}
+			createdRepo, resp, err := client.Repositories.Create(ctx, "", repo)
+			label := "failed to create repository"
+			if err != nil && licenseTemplate != "" && resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+				label = fmt.Sprintf("failed to create repository (if license template %q is the problem, check its key with get_license_template)", licenseTemplate)
+			}
+			if result, ok, err := handleRESTResponse(ctx, label, resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+			guard.Forget(client, createdRepo.GetOwner().GetLogin(), createdRepo.GetName())
+
+			return MarshalledTextResult(createdRepo), nil
+		}
+}
+
+// RepositoryDetails is the condensed repository returned by get_repository.
+type RepositoryDetails struct {
+	FullName        string            `json:"full_name"`
+	Description     string            `json:"description,omitempty"`
+	DefaultBranch   string            `json:"default_branch"`
+	Visibility      string            `json:"visibility"`
+	Topics          []string          `json:"topics"`
+	Language        string            `json:"language,omitempty"`
+	License         string            `json:"license,omitempty"`
+	Archived        bool              `json:"archived"`
+	Disabled        bool              `json:"disabled"`
+	Fork            bool              `json:"fork"`
+	Parent          string            `json:"parent,omitempty"`
+	OpenIssuesCount int               `json:"open_issues_count"`
+	PushedAt        *github.Timestamp `json:"pushed_at,omitempty"`
+}
+
+// GetRepository creates a tool to get the details of a GitHub repository.
+func GetRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_DESCRIPTION", "Get the details of a GitHub repository: default branch, visibility, topics, license, and whether it is archived or a fork")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_USER_TITLE", "Get repository"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("raw",
+				mcp.Description("Return the full repository object as returned by the GitHub API instead of the condensed details"),
+				mcp.DefaultBool(false),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rawOutput, err := OptionalParam[bool](request, "raw")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get repository", resp, err); !ok {
+				return result, err
+			}
+
+			if rawOutput {
+				return MarshalledTextResult(repository), nil
+			}
+			return MarshalledTextResult(repositoryDetails(repository)), nil
+		}
+}
+
+// repositoryDetails condenses repository to the fields returned by get_repository.
+func repositoryDetails(repository *github.Repository) RepositoryDetails {
+	details := RepositoryDetails{
+		FullName:        repository.GetFullName(),
+		Description:     repository.GetDescription(),
+		DefaultBranch:   repository.GetDefaultBranch(),
+		Visibility:      repository.GetVisibility(),
+		Topics:          repository.Topics,
+		Language:        repository.GetLanguage(),
+		License:         repository.GetLicense().GetSPDXID(),
+		Archived:        repository.GetArchived(),
+		Disabled:        repository.GetDisabled(),
+		Fork:            repository.GetFork(),
+		Parent:          repository.GetParent().GetFullName(),
+		OpenIssuesCount: repository.GetOpenIssuesCount(),
+		PushedAt:        repository.PushedAt,
+	}
+	if details.Topics == nil {
+		details.Topics = []string{}
+	}
+	return details
+}
+
+// GetRepositoryTopics creates a tool to get the topics of a GitHub repository.
+func GetRepositoryTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_topics",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TOPICS_DESCRIPTION", "Get the topics of a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TOPICS_USER_TITLE", "Get repository topics"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			topics, resp, err := client.Repositories.ListAllTopics(ctx, owner, repo)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get repository topics", resp, err); !ok {
+				return result, err
+			}
+
+			if topics == nil {
+				topics = []string{}
+			}
+			return MarshalledTextResult(topics), nil
+		}
+}
+
+// ReplaceRepositoryTopics creates a tool to replace the topics of a GitHub repository.
+func ReplaceRepositoryTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("replace_repository_topics",
+			mcp.WithDescription(t("TOOL_REPLACE_REPOSITORY_TOPICS_DESCRIPTION", fmt.Sprintf("Replace all topics of a GitHub repository with the given topics. An empty list removes every topic. Topics must be lowercase letters, numbers and hyphens, start with a letter or number, and be at most %d characters long.", maxTopicLength))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REPLACE_REPOSITORY_TOPICS_USER_TITLE", "Replace repository topics"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("topics",
+				mcp.Required(),
+				mcp.Description("The topics the repository will have"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, ok := request.GetArguments()["topics"]; !ok {
+				return mcp.NewToolResultError("missing required parameter: topics"), nil
+			}
+			topics, err := OptionalStringArrayParam(request, "topics")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// GitHub rejects the whole request for one bad topic with a terse 422, so list every bad one up front
+			var invalid []string
+			for _, topic := range topics {
+				if !validTopic.MatchString(topic) {
+					invalid = append(invalid, fmt.Sprintf("%q", topic))
+				}
+			}
+			if len(invalid) > 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid topics: %s. Topics must be lowercase letters, numbers and hyphens, start with a letter or number, and be at most %d characters long", strings.Join(invalid, ", "), maxTopicLength)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			replaced, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+			if result, ok, err := handleRESTResponse(ctx, "failed to replace repository topics", resp, err); !ok {
+				return result, err
+			}
+
+			if replaced == nil {
+				replaced = []string{}
+			}
+			return MarshalledTextResult(replaced), nil
+		}
+}
+
+// maxTopicLength is the longest topic GitHub accepts.
+const maxTopicLength = 50
+
+// validTopic matches the topics GitHub accepts.
+var validTopic = regexp.MustCompile(fmt.Sprintf("^[a-z0-9][a-z0-9-]{0,%d}$", maxTopicLength-1))
+
+// GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
+func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_contents",
+			mcp.WithDescription(t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Path to file/directory (directories must end with a slash '/')"),
+				mcp.DefaultString("/"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`"),
+			),
+			mcp.WithString("sha",
+				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
+			),
+			mcp.WithBoolean("resolve_lfs",
+				mcp.Description("If the file is a Git LFS pointer, resolve a download URL for its content through the LFS batch API"),
+			),
+			mcp.WithBoolean("follow_symlinks",
+				mcp.Description("If the path is a symlink to a directory or another symlink in the repository, return what it points to instead of describing the symlink. Only one link is followed"),
+				mcp.DefaultBool(true),
+			),
+			mcp.WithNumber("max_size",
+				mcp.Description("Refuse to download files larger than this many bytes"),
+				mcp.Min(1),
+				mcp.DefaultNumber(defaultMaxFileSize),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("For text files, the first line to return, counting from 1"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("For text files, the last line to return, inclusive. Defaults to the end of the file"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := OptionalParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			resolveLFS, err := OptionalParam[bool](request, "resolve_lfs")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			followSymlinks := true
+			if value, ok, err := OptionalParamOK[bool](request, "follow_symlinks"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				followSymlinks = value
+			}
+			maxSize, err := OptionalIntParamWithDefault(request, "max_size", defaultMaxFileSize)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxSize < 1 {
+				return mcp.NewToolResultError("max_size must be at least 1"), nil
+			}
+			startLine, err := OptionalIntParam(request, "start_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			endLine, err := OptionalIntParam(request, "end_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if startLine < 0 || endLine < 0 {
+				return mcp.NewToolResultError("start_line and end_line must be at least 1"), nil
+			}
+			if endLine > 0 && endLine < max(startLine, 1) {
+				return mcp.NewToolResultError(fmt.Sprintf("end_line %d is before start_line %d", endLine, max(startLine, 1))), nil
+			}
+			lineRange := startLine > 0 || endLine > 0
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub client"), nil
+			}
+
+			rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil
+			}
+
+			// If the path is (most likely) not to be a directory, we will
+			// first try to get the raw content from the GitHub raw content API.
+			if path != "" && !strings.HasSuffix(path, "/") {
+				// First, get file info from Contents API to retrieve SHA
+				var fileSHA string
+				opts := &github.RepositoryContentGetOptions{Ref: ref}
+				fileContent, _, respContents, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+				if respContents != nil {
+					defer func() { _ = respContents.Body.Close() }()
+				}
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get file SHA",
+						respContents,
+						err,
+					), nil
+				}
+				// The API follows symlinks to files itself, so a symlink is only returned when it points
+				// to a directory, to another symlink or out of the repository.
+				if fileContent.GetType() == "symlink" {
+					target, inRepo := resolveSymlinkTarget(path, fileContent.GetTarget())
+					if !followSymlinks || !inRepo {
+						return MarshalledTextResult(newSymlinkContent(fileContent, target, inRepo)), nil
+					}
+					targetContent, targetDirContent, respTarget, err := client.Repositories.GetContents(ctx, owner, repo, target, opts)
+					if respTarget != nil {
+						defer func() { _ = respTarget.Body.Close() }()
+					}
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx,
+							fmt.Sprintf("failed to get symlink target %s", target),
+							respTarget,
+							err,
+						), nil
+					}
+					if targetDirContent != nil {
+						return MarshalledTextResult(targetDirContent), nil
+					}
+					if targetContent.GetType() == "symlink" {
+						next, nextInRepo := resolveSymlinkTarget(target, targetContent.GetTarget())
+						return MarshalledTextResult(newSymlinkContent(targetContent, next, nextInRepo)), nil
+					}
+					fileContent = targetContent
+					path = target
+				}
+				if fileContent == nil || fileContent.SHA == nil {
+					return mcp.NewToolResultError("file content SHA is nil"), nil
+				}
+				// Submodules have no raw content in this repository, so describe
+				// the pinned commit instead of attempting a raw download.
+				if fileContent.GetType() == "submodule" {
+					return MarshalledTextResult(newSubmoduleContent(fileContent)), nil
+				}
+				fileSHA = *fileContent.SHA
+				if size := fileContent.GetSize(); size > maxSize {
+					return mcp.NewToolResultError(fileTooLargeMessage(path, size, maxSize)), nil
+				}
+
+				rawClient, err := getRawClient(ctx)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+				}
+				resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
+				if err != nil {
+					return mcp.NewToolResultError("failed to get raw repository content"), nil
+				}
+				defer func() {
+					_ = resp.Body.Close()
+				}()
+
+				if resp.StatusCode == http.StatusOK {
+					if resp.ContentLength > int64(maxSize) {
+						return mcp.NewToolResultError(fileTooLargeMessage(path, int(resp.ContentLength), maxSize)), nil
+					}
+					// If the raw content is found, return it directly. The size reported by the API can be
+					// missing, so the read is bounded too.
+					body, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+					if err != nil {
+						return mcp.NewToolResultError("failed to read response body"), nil
+					}
+					if len(body) > maxSize {
+						return mcp.NewToolResultError(fmt.Sprintf("file %s is larger than max_size of %d bytes. Raise max_size to download it", path, maxSize)), nil
+					}
+					// Files stored with Git LFS only have a pointer in the repository,
+					// so describe the object instead of returning the pointer text.
+					if oid, size, ok := parseLFSPointer(body); ok {
+						lfsContent := newLFSPointerContent(path, fileSHA, oid, size)
+						if resolveLFS {
+							href, expiresAt, err := resolveLFSDownload(ctx, client, owner, repo, oid, size)
+							if err != nil {
+								lfsContent.Note = fmt.Sprintf("This file is stored with Git LFS and the repository only holds a pointer to it. Resolving a media download URL failed: %s", err)
+							} else {
+								lfsContent.DownloadURL = href
+								lfsContent.ExpiresAt = expiresAt
+								lfsContent.Note = "This file is stored with Git LFS. Its content can be downloaded from download_url, which is short-lived."
+							}
+						}
+						return MarshalledTextResult(lfsContent), nil
+					}
+					contentType := resp.Header.Get("Content-Type")
+
+					var resourceURI string
+					switch {
+					case sha != "":
+						resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
+						if err != nil {
+							return nil, fmt.Errorf("failed to create resource URI: %w", err)
+						}
+					case ref != "":
+						resourceURI, err = url.JoinPath("repo://", owner, repo, ref, "contents", path)
+						if err != nil {
+							return nil, fmt.Errorf("failed to create resource URI: %w", err)
+						}
+					default:
+						resourceURI, err = url.JoinPath("repo://", owner, repo, "contents", path)
+						if err != nil {
+							return nil, fmt.Errorf("failed to create resource URI: %w", err)
+						}
+					}
+
+					if strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text") {
+						text := string(body)
+						message := "successfully downloaded text file"
+						if lineRange {
+							sliced, first, last, total, err := sliceLines(text, startLine, endLine)
+							if err != nil {
+								return mcp.NewToolResultError(err.Error()), nil
+							}
+							// The fragment tells which lines the resource holds, as in GitHub blob URLs
+							text = sliced
+							resourceURI += fmt.Sprintf("#L%d-L%d", first, last)
+							message = fmt.Sprintf("successfully downloaded lines %d-%d of %d of text file", first, last, total)
+						}
+						result := mcp.TextResourceContents{
+							URI:      resourceURI,
+							Text:     text,
+							MIMEType: contentType,
+						}
+						// Include SHA in the result metadata
+						if fileSHA != "" {
+							return mcp.NewToolResultResource(fmt.Sprintf("%s (SHA: %s)", message, fileSHA), result), nil
+						}
+						return mcp.NewToolResultResource(message, result), nil
+					}
+
+					if lineRange {
+						return mcp.NewToolResultError(fmt.Sprintf("start_line and end_line only apply to text files, and %s is %s", path, contentType)), nil
+					}
+
+					result := mcp.BlobResourceContents{
+						URI:      resourceURI,
+						Blob:     base64.StdEncoding.EncodeToString(body),
+						MIMEType: contentType,
+					}
+					// Include SHA in the result metadata
+					if fileSHA != "" {
+						return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded binary file (SHA: %s)", fileSHA), result), nil
+					}
+					return mcp.NewToolResultResource("successfully downloaded binary file", result), nil
+
+				}
+			}
+
+			if rawOpts.SHA != "" {
+				ref = rawOpts.SHA
+			}
+			if strings.HasSuffix(path, "/") {
+				opts := &github.RepositoryContentGetOptions{Ref: ref}
+				_, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+				if err == nil && resp.StatusCode == http.StatusOK {
+					defer func() { _ = resp.Body.Close() }()
+					r, err := json.Marshal(dirContent)
+					if err != nil {
+						return mcp.NewToolResultError("failed to marshal response"), nil
+					}
+					return mcp.NewToolResultText(string(r)), nil
+				}
+			}
+
+			// The path does not point to a file or directory.
+			// Instead let's try to find it in the Git Tree by matching the end of the path.
+
+			// Step 1: Get Git Tree recursively
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get git tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Step 2: Filter tree for matching paths
+			const maxMatchingFiles = 3
+			matchingFiles := filterPaths(tree.Entries, path, maxMatchingFiles)
+			if len(matchingFiles) > 0 {
+				matchingFilesJSON, err := json.Marshal(matchingFiles)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal matching files: %s", err)), nil
+				}
+				resolvedRefs, err := json.Marshal(rawOpts)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to marshal resolved refs: %s", err)), nil
+				}
+				return mcp.NewToolResultText(fmt.Sprintf("Path did not point to a file or directory, but resolved git ref to %s with possible path matches: %s", resolvedRefs, matchingFilesJSON)), nil
+			}
+
+			return mcp.NewToolResultError("Failed to get file contents. The path does not point to a file or directory, or the file does not exist in the repository."), nil
+		}
+}
+
+// defaultMaxFileSize is the largest file get_file_contents downloads by default, 1 MiB.
+const defaultMaxFileSize = 1 << 20
+
+// fileTooLargeMessage is the tool error for a file larger than max_size.
+func fileTooLargeMessage(path string, size, maxSize int) string {
+	return fmt.Sprintf("file %s is %d bytes, larger than max_size of %d bytes. Raise max_size to download it", path, size, maxSize)
+}
+
+// sliceLines returns lines start to end of text, counting from 1 and inclusive, along with the
+// range returned and the number of lines in text. A start of 0 means the first line, and an end of
+// 0 or past the last line means the last line. Line endings are kept.
+func sliceLines(text string, start, end int) (sliced string, first, last, total int, err error) {
+	lines := strings.SplitAfter(text, "\n")
+	// A final line ending does not start another line
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	total = len(lines)
+
+	first = max(start, 1)
+	if first > total {
+		return "", 0, 0, total, fmt.Errorf("start_line %d is past the end of the file, which has %d lines", first, total)
+	}
+	last = end
+	if last == 0 || last > total {
+		last = total
+	}
+	return strings.Join(lines[first-1:last], ""), first, last, total, nil
+}
+
+// SymlinkContent describes a path in the repository that is a symlink that was not followed.
+type SymlinkContent struct {
+	Type   string `json:"type"`
+	Path   string `json:"path"`
+	Target string `json:"target"`
+	// ResolvedPath is the target as a path in the repository, when it is in the repository
+	ResolvedPath string `json:"resolved_path,omitempty"`
+	SHA          string `json:"sha"`
+	Hint         string `json:"hint"`
+}
+
+func newSymlinkContent(content *github.RepositoryContent, resolved string, inRepo bool) SymlinkContent {
+	symlink := SymlinkContent{
+		Type:   "symlink",
+		Path:   content.GetPath(),
+		Target: content.GetTarget(),
+		SHA:    content.GetSHA(),
+		Hint:   "This path is a symlink that points out of the repository, so it has no content here.",
+	}
+	if inRepo {
+		symlink.ResolvedPath = resolved
+		symlink.Hint = fmt.Sprintf("This path is a symlink to %s. Request that path to get its contents.", resolved)
+	}
+	return symlink
+}
+
+// resolveSymlinkTarget resolves the target of the symlink at linkPath to a path from the root of the
+// repository. inRepo is false for absolute targets and targets that climb out of the repository.
+func resolveSymlinkTarget(linkPath, target string) (resolved string, inRepo bool) {
+	if target == "" || strings.HasPrefix(target, "/") {
+		return "", false
+	}
+	resolved = path.Join(path.Dir(strings.TrimPrefix(linkPath, "/")), target)
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return "", false
+	}
+	return resolved, true
+}
+
+// SubmoduleContent describes a path in the repository that is a git submodule.
+type SubmoduleContent struct {
+	Type   string `json:"type"`
+	Path   string `json:"path"`
+	GitURL string `json:"git_url"`
+	SHA    string `json:"sha"`
+	Hint   string `json:"hint"`
+}
+
+func newSubmoduleContent(content *github.RepositoryContent) SubmoduleContent {
+	return SubmoduleContent{
+		Type:   "submodule",
+		Path:   content.GetPath(),
+		GitURL: content.GetSubmoduleGitURL(),
+		SHA:    content.GetSHA(),
+		Hint:   fmt.Sprintf("This path is a git submodule pinned to commit %s. Its contents live in the referenced repository (%s); fetch them from there using this SHA as the ref.", content.GetSHA(), content.GetSubmoduleGitURL()),
+	}
+}
+
+// ForkRepository creates a tool to fork a repository.
+func ForkRepository(getClient GetClientFn, guard *DefaultBranchGuard, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("fork_repository",
+			mcp.WithDescription(t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_FORK_REPOSITORY_USER_TITLE", "Fork repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("organization",
+				mcp.Description("Organization to fork to"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			org, err := OptionalParam[string](request, "organization")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -684,72 +2270,901 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			forkedRepo, resp, err := client.Repositories.CreateFork(ctx, owner, repo, opts)
+			forkedRepo, resp, err := client.Repositories.CreateFork(ctx, owner, repo, opts)
+			if err != nil {
+				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
+				// and it's not a real error.
+				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
+					if org != "" {
+						guard.Forget(client, org, repo)
+					}
+					return mcp.NewToolResultText("Fork is in progress"), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to fork repository",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusAccepted {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to fork repository: %s", string(body))), nil
+			}
+			guard.Forget(client, forkedRepo.GetOwner().GetLogin(), forkedRepo.GetName())
+
+			return MarshalledTextResult(forkedRepo), nil
+		}
+}
+
+// TransferRepository creates a tool to transfer a repository to another user or organization.
+func TransferRepository(getClient GetClientFn, guard *DefaultBranchGuard, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("transfer_repository",
+			mcp.WithDescription(t("TOOL_TRANSFER_REPOSITORY_DESCRIPTION", "Transfer a GitHub repository to another user or organization. The new owner must accept the transfer before it completes. Requires confirm to be true and expected_repo to repeat the repository name.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_TRANSFER_REPOSITORY_USER_TITLE", "Transfer repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("new_owner",
+				mcp.Required(),
+				mcp.Description("Username or organization name the repository will be transferred to"),
+			),
+			mcp.WithArray("team_ids",
+				mcp.Description("IDs of teams in the new owner organization to grant access to the repository"),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+			mcp.WithBoolean("confirm",
+				mcp.Required(),
+				mcp.Description("Must be true to confirm the transfer"),
+			),
+			mcp.WithString("expected_repo",
+				mcp.Required(),
+				mcp.Description("Repository name repeated to confirm the transfer, must match repo"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			newOwner, err := RequiredParam[string](request, "new_owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamIDs, err := OptionalInt64ArrayParam(request, "team_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			confirm, err := OptionalParam[bool](request, "confirm")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !confirm {
+				return mcp.NewToolResultError("confirm must be true to transfer a repository"), nil
+			}
+			expectedRepo, err := RequiredParam[string](request, "expected_repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if expectedRepo != repo {
+				return mcp.NewToolResultError(fmt.Sprintf("expected_repo %q does not match repo %q, refusing to transfer", expectedRepo, repo)), nil
+			}
+
+			transferRequest := github.TransferRequest{
+				NewOwner: newOwner,
+			}
+			if len(teamIDs) > 0 {
+				transferRequest.TeamID = teamIDs
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			transferredRepo, resp, err := client.Repositories.Transfer(ctx, owner, repo, transferRequest)
+			// Whatever the outcome, the repository at either name may no longer be the one that was cached
+			guard.Forget(client, owner, repo)
+			guard.Forget(client, newOwner, repo)
+			if err != nil {
+				// Check if it's an acceptedError. An acceptedError indicates that the transfer has been scheduled,
+				// and it's not a real error.
+				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
+					return mcp.NewToolResultText(fmt.Sprintf("Transfer of %s/%s to %s initiated, awaiting acceptance", owner, repo, newOwner)), nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to transfer repository",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(transferredRepo), nil
+		}
+}
+
+// DeleteFile creates a tool to delete a file in a GitHub repository.
+// This tool uses a more roundabout way of deleting a file than just using the client.Repositories.DeleteFile.
+// This is because REST file deletion endpoint (and client.Repositories.DeleteFile) don't add commit signing to the deletion commit,
+// unlike how the endpoint backing the create_or_update_files tool does. This appears to be a quirk of the API.
+// The approach implemented here gets automatic commit signing when used with either the github-actions user or as an app,
+// both of which suit an LLM well.
+func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_file",
+			mcp.WithDescription(t("TOOL_DELETE_FILE_DESCRIPTION", "Delete a file from a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_FILE_USER_TITLE", "Delete file"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file to delete"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to delete the file from"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Get the reference for the branch
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get branch reference", resp, err); !ok {
+				return result, err
+			}
+
+			// Get the commit object that the branch points to
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get base commit", resp, err); !ok {
+				return result, err
+			}
+
+			// Create a tree entry for the file deletion by setting SHA to nil
+			treeEntries := []*github.TreeEntry{
+				{
+					Path: github.Ptr(path),
+					Mode: github.Ptr("100644"), // Regular file mode
+					Type: github.Ptr("blob"),
+					SHA:  nil, // Setting SHA to nil deletes the file
+				},
+			}
+
+			// Create a new tree with the deletion
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, treeEntries)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create tree", resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			// Create a new commit with the new tree
+			commit := &github.Commit{
+				Message: github.Ptr(message),
+				Tree:    newTree,
+				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+			}
+			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create commit", resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			// Update the branch reference to point to the new commit
+			ref.Object.SHA = newCommit.SHA
+			_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, false)
+			if result, ok, err := handleRESTResponse(ctx, "failed to update reference", resp, err); !ok {
+				return result, err
+			}
+
+			// Create a response similar to what the DeleteFile API would return
+			response := DeleteFileResult{Commit: newCommit}
+
+			return MarshalledTextResult(response), nil
+		}
+}
+
+// CreateBranch creates a tool to create a new branch.
+func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_branch",
+			mcp.WithDescription(t("TOOL_CREATE_BRANCH_DESCRIPTION", "Create a new branch in a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_BRANCH_USER_TITLE", "Create branch"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Name for new branch"),
+			),
+			mcp.WithString("from_branch",
+				mcp.Description("Source branch (defaults to repo default)"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fromBranch, err := OptionalParam[string](request, "from_branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Get the source branch SHA
+			var ref *github.Reference
+
+			if fromBranch == "" {
+				// Get default branch if from_branch not specified
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				fromBranch = *repository.DefaultBranch
+			}
+
+			// Get SHA of source branch
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+fromBranch)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get reference",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Create new branch
+			newRef := &github.Reference{
+				Ref:    github.Ptr("refs/heads/" + branch),
+				Object: &github.GitObject{SHA: ref.Object.SHA},
+			}
+
+			createdRef, resp, err := client.Git.CreateRef(ctx, owner, repo, newRef)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create branch",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(createdRef), nil
+		}
+}
+
+// PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
+func PushFiles(getClient GetClientFn, guard *DefaultBranchGuard, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("push_files",
+			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit. Each file can be created or updated, with an optional executable mode, or deleted, so moving files takes one commit.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to push to"),
+			),
+			WithFilesToPush(),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			WithAllowDefaultBranch(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowDefaultBranch, err := OptionalParam[bool](request, "allow_default_branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Parse files parameter - this should be an array of objects with path and content
+			filesObj, ok := request.GetArguments()["files"].([]interface{})
+			if !ok {
+				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
+			}
+			entries, blobs, err := treeEntriesFromFiles(filesObj)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if result := guard.Check(ctx, client, owner, repo, branch, allowDefaultBranch); result != nil {
+				return result, nil
+			}
+
+			// Get the reference for the branch
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
 			if err != nil {
-				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
-				// and it's not a real error.
-				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Fork is in progress"), nil
-				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to fork repository",
+					"failed to get branch reference",
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusAccepted {
-				body, err := io.ReadAll(resp.Body)
+			// Get the commit object that the branch points to
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get base commit",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if err := createBlobs(ctx, client, owner, repo, blobs); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Create a new tree with the file entries
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create tree",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Create a new commit
+			commit := &github.Commit{
+				Message: github.Ptr(message),
+				Tree:    newTree,
+				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+			}
+			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create commit",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Update the reference to point to the new commit
+			ref.Object.SHA = newCommit.SHA
+			updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, ref, false)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to update reference",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(updatedRef), nil
+		}
+}
+
+// WithFilesToPush adds the files parameter shared by the tools that commit several files at once.
+func WithFilesToPush() mcp.ToolOption {
+	return mcp.WithArray("files",
+		mcp.Required(),
+		mcp.Items(
+			map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": false,
+				"required":             []string{"path"},
+				"properties": map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "path to the file",
+					},
+					"content": map[string]interface{}{
+						"type":        "string",
+						"description": "file content, required unless the file is deleted",
+					},
+					"encoding": map[string]interface{}{
+						"type":        "string",
+						"description": "encoding of content, base64 for binary or large files",
+						"enum":        []string{fileEncodingUTF8, fileEncodingBase64},
+						"default":     fileEncodingUTF8,
+					},
+					"operation": map[string]interface{}{
+						"type":        "string",
+						"description": "whether to create or update the file, or delete it",
+						"enum":        []string{fileOperationCreateOrUpdate, fileOperationDelete},
+						"default":     fileOperationCreateOrUpdate,
+					},
+					"mode": map[string]interface{}{
+						"type":        "string",
+						"description": "file mode, 100755 for executables",
+						"enum":        []string{fileModeRegular, fileModeExecutable},
+						"default":     fileModeRegular,
+					},
+				},
+			}),
+		mcp.Description("Array of file objects to push, each object with path (string) and content (string), and optionally encoding (utf-8 or base64), operation (create_or_update or delete) and mode (100644 or 100755)"),
+	)
+}
+
+const (
+	fileOperationCreateOrUpdate = "create_or_update"
+	fileOperationDelete         = "delete"
+
+	fileModeRegular    = "100644"
+	fileModeExecutable = "100755"
+
+	fileEncodingUTF8   = "utf-8"
+	fileEncodingBase64 = "base64"
+
+	// blobConcurrency bounds the number of blobs created at once for base64 encoded files.
+	blobConcurrency = 4
+)
+
+// pendingBlob is a base64 encoded file whose tree entry gets the SHA of a blob created from content.
+type pendingBlob struct {
+	entry   *github.TreeEntry
+	content string
+}
+
+// treeEntriesFromFiles converts the files parameter into blob tree entries. A deleted file becomes an entry
+// without a SHA or content, which the API takes as removing the path from the tree. Base64 encoded files
+// cannot be sent inline, so their entries are returned without content along with the blobs to create for
+// them with createBlobs.
+func treeEntriesFromFiles(files []interface{}) ([]*github.TreeEntry, []pendingBlob, error) {
+	var entries []*github.TreeEntry
+	var blobs []pendingBlob
+	for _, file := range files {
+		fileMap, ok := file.(map[string]interface{})
+		if !ok {
+			return nil, nil, errors.New("each file must be an object with path and content")
+		}
+
+		path, ok := fileMap["path"].(string)
+		if !ok || path == "" {
+			return nil, nil, errors.New("each file must have a path")
+		}
+
+		operation := fileOperationCreateOrUpdate
+		if value, present := fileMap["operation"]; present {
+			operation, ok = value.(string)
+			if !ok || (operation != fileOperationCreateOrUpdate && operation != fileOperationDelete) {
+				return nil, nil, fmt.Errorf("file %s: operation must be %s or %s", path, fileOperationCreateOrUpdate, fileOperationDelete)
+			}
+		}
+
+		mode := fileModeRegular
+		if value, present := fileMap["mode"]; present {
+			mode, ok = value.(string)
+			if !ok || (mode != fileModeRegular && mode != fileModeExecutable) {
+				return nil, nil, fmt.Errorf("file %s: mode must be %s or %s", path, fileModeRegular, fileModeExecutable)
+			}
+		}
+
+		entry := &github.TreeEntry{
+			Path: github.Ptr(path),
+			Mode: github.Ptr(mode),
+			Type: github.Ptr("blob"),
+		}
+		content, hasContent := fileMap["content"]
+		if operation == fileOperationDelete {
+			if hasContent {
+				return nil, nil, fmt.Errorf("file %s: a deleted file must not have content", path)
+			}
+			entries = append(entries, entry)
+			continue
+		}
+
+		text, ok := content.(string)
+		if !ok {
+			return nil, nil, errors.New("each file must have content")
+		}
+		encoding := fileEncodingUTF8
+		if value, present := fileMap["encoding"]; present {
+			encoding, ok = value.(string)
+			if !ok || (encoding != fileEncodingUTF8 && encoding != fileEncodingBase64) {
+				return nil, nil, fmt.Errorf("file %s: encoding must be %s or %s", path, fileEncodingUTF8, fileEncodingBase64)
+			}
+		}
+		if encoding == fileEncodingBase64 {
+			if _, err := base64.StdEncoding.DecodeString(text); err != nil {
+				return nil, nil, fmt.Errorf("file %s: content is not valid base64: %w", path, err)
+			}
+			blobs = append(blobs, pendingBlob{entry: entry, content: text})
+		} else {
+			entry.Content = github.Ptr(text)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, blobs, nil
+}
+
+// createBlobs creates the blobs of base64 encoded files, blobConcurrency at a time, and points their tree
+// entries at them. After the first failure no more blobs are started, and the error names every file whose
+// blob could not be created.
+func createBlobs(ctx context.Context, client *github.Client, owner, repo string, blobs []pendingBlob) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, len(blobs))
+	sem := make(chan struct{}, blobConcurrency)
+	var wg sync.WaitGroup
+	for i, blob := range blobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				return
+			}
+
+			created, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+				Content:  github.Ptr(blob.content),
+				Encoding: github.Ptr(fileEncodingBase64),
+			})
+			closeResponseBody(resp)
+			if err != nil {
+				errs[i] = err
+				cancel()
+				return
+			}
+			blob.entry.SHA = created.SHA
+		}()
+	}
+	wg.Wait()
+
+	// Blobs that were skipped or interrupted because another failed only report the cancellation
+	var failed []string
+	var firstErr error
+	for i, err := range errs {
+		if err == nil || errors.Is(err, context.Canceled) {
+			continue
+		}
+		failed = append(failed, blobs[i].entry.GetPath())
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr == nil {
+		// Nothing failed on its own, so the caller's context was cancelled
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("failed to create blobs for %s: %w", strings.Join(failed, ", "), firstErr)
+}
+
+// CommitToNewBranchResult is returned by commit_changes_to_new_branch.
+type CommitToNewBranchResult struct {
+	Branch        string `json:"branch"`
+	BaseBranch    string `json:"base_branch"`
+	BaseSHA       string `json:"base_sha"`
+	CommitSHA     string `json:"commit_sha"`
+	BranchCreated bool   `json:"branch_created"`
+	CompareURL    string `json:"compare_url,omitempty"`
+}
+
+// CommitChangesToNewBranch creates a tool that creates a branch and pushes files to it in a single commit.
+// Re-running it after a partial failure reuses the branch as long as it still points at the base commit.
+func CommitChangesToNewBranch(getClient GetClientFn, guard *DefaultBranchGuard, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("commit_changes_to_new_branch",
+			mcp.WithDescription(t("TOOL_COMMIT_CHANGES_TO_NEW_BRANCH_DESCRIPTION", "Create a new branch and push multiple files to it in a single commit. Safe to retry: if the branch already exists and still points at the base commit it is reused instead of failing.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMMIT_CHANGES_TO_NEW_BRANCH_USER_TITLE", "Commit changes to new branch"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Name for new branch"),
+			),
+			mcp.WithString("from_branch",
+				mcp.Description("Source branch (defaults to repo default)"),
+			),
+			WithFilesToPush(),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fromBranch, err := OptionalParam[string](request, "from_branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filesObj, ok := request.GetArguments()["files"].([]interface{})
+			if !ok {
+				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
+			}
+			entries, blobs, err := treeEntriesFromFiles(filesObj)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if branch == fromBranch {
+				return mcp.NewToolResultError("branch must differ from from_branch"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// Branching from the default branch already rules out writing to it, so the default
+			// branch is only looked up once, through the guard's cache.
+			if fromBranch == "" {
+				defaultBranch, resp, err := guard.DefaultBranch(ctx, client, owner, repo)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository",
+						resp,
+						err,
+					), nil
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to fork repository: %s", string(body))), nil
+				fromBranch = defaultBranch
+				if branch == fromBranch {
+					return mcp.NewToolResultError("branch must differ from the default branch"), nil
+				}
+			} else if result := guard.Check(ctx, client, owner, repo, branch, false); result != nil {
+				return result, nil
+			}
+
+			baseRef, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+fromBranch)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get base branch reference",
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			baseSHA := baseRef.GetObject().GetSHA()
+
+			result := CommitToNewBranchResult{
+				Branch:     branch,
+				BaseBranch: fromBranch,
+				BaseSHA:    baseSHA,
+			}
+
+			// Reuse a branch left behind by an earlier attempt, but only if nothing has been committed to it since.
+			branchRef, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			switch {
+			case err == nil:
+				_ = resp.Body.Close()
+				if headSHA := branchRef.GetObject().GetSHA(); headSHA != baseSHA {
+					return mcp.NewToolResultError(fmt.Sprintf(
+						"branch %s already exists at %s, which differs from the head of %s (%s). "+
+							"If an earlier call already committed these changes, the branch is ready to use; otherwise use push_files to add commits to it, or choose a new branch name.",
+						branch, headSHA, fromBranch, baseSHA,
+					)), nil
+				}
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				_ = resp.Body.Close()
+				newRef := &github.Reference{
+					Ref:    github.Ptr("refs/heads/" + branch),
+					Object: &github.GitObject{SHA: github.Ptr(baseSHA)},
+				}
+				createdRef, resp, err := client.Git.CreateRef(ctx, owner, repo, newRef)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to create branch",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				branchRef = createdRef
+				result.BranchCreated = true
+			default:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get branch reference",
+					resp,
+					err,
+				), nil
+			}
+
+			// From here on the branch exists, so failures tell the caller to resume rather than recreate it.
+			resumable := func(label string) string {
+				return fmt.Sprintf("%s. Branch %s exists at %s; retry commit_changes_to_new_branch with the same arguments to resume instead of recreating the branch", label, branch, baseSHA)
+			}
+
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, baseSHA)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, resumable("failed to get base commit"), resp, err), nil
 			}
+			_ = resp.Body.Close()
 
-			r, err := json.Marshal(forkedRepo)
+			if err := createBlobs(ctx, client, owner, repo, blobs); err != nil {
+				return mcp.NewToolResultError(resumable(err.Error())), nil
+			}
+
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), entries)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, resumable("failed to create tree"), resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			commit := &github.Commit{
+				Message: github.Ptr(message),
+				Tree:    newTree,
+				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+			}
+			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, resumable("failed to create commit"), resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			branchRef.Object = &github.GitObject{SHA: newCommit.SHA}
+			_, resp, err = client.Git.UpdateRef(ctx, owner, repo, branchRef, false)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, resumable("failed to update branch reference"), resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			result.CommitSHA = newCommit.GetSHA()
+			if repoURL, _, found := strings.Cut(newCommit.GetHTMLURL(), "/commit/"); found {
+				result.CompareURL = fmt.Sprintf("%s/compare/%s...%s", repoURL, url.PathEscape(fromBranch), url.PathEscape(branch))
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(result), nil
 		}
 }
 
-// DeleteFile creates a tool to delete a file in a GitHub repository.
-// This tool uses a more roundabout way of deleting a file than just using the client.Repositories.DeleteFile.
-// This is because REST file deletion endpoint (and client.Repositories.DeleteFile) don't add commit signing to the deletion commit,
-// unlike how the endpoint backing the create_or_update_files tool does. This appears to be a quirk of the API.
-// The approach implemented here gets automatic commit signing when used with either the github-actions user or as an app,
-// both of which suit an LLM well.
-func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("delete_file",
-			mcp.WithDescription(t("TOOL_DELETE_FILE_DESCRIPTION", "Delete a file from a GitHub repository")),
+// ListTags creates a tool to list tags in a GitHub repository.
+func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_tags",
+			mcp.WithDescription(t("TOOL_LIST_TAGS_DESCRIPTION", "List git tags in a GitHub repository")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:           t("TOOL_DELETE_FILE_USER_TITLE", "Delete file"),
-				ReadOnlyHint:    ToBoolPtr(false),
-				DestructiveHint: ToBoolPtr(true),
+				Title:        t("TOOL_LIST_TAGS_USER_TITLE", "List tags"),
+				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
-				mcp.Description("Repository owner (username or organization)"),
+				mcp.Description("Repository owner"),
 			),
 			mcp.WithString("repo",
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("path",
-				mcp.Required(),
-				mcp.Description("Path to the file to delete"),
-			),
-			mcp.WithString("message",
-				mcp.Required(),
-				mcp.Description("Commit message"),
-			),
-			mcp.WithString("branch",
-				mcp.Required(),
-				mcp.Description("Branch to delete the file from"),
-			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -760,17 +3175,14 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			path, err := RequiredParam[string](request, "path")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			message, err := RequiredParam[string](request, "message")
+			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			branch, err := RequiredParam[string](request, "branch")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+
+			opts := &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
 			}
 
 			client, err := getClient(ctx)
@@ -778,126 +3190,77 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Get the reference for the branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get branch reference: %w", err)
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get base commit",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
+			tags, resp, err := client.Repositories.ListTags(ctx, owner, repo, opts)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list tags", resp, err); !ok {
+				return result, err
 			}
 
-			// Create a tree entry for the file deletion by setting SHA to nil
-			treeEntries := []*github.TreeEntry{
-				{
-					Path: github.Ptr(path),
-					Mode: github.Ptr("100644"), // Regular file mode
-					Type: github.Ptr("blob"),
-					SHA:  nil, // Setting SHA to nil deletes the file
-				},
-			}
+			return MarshalledTextResult(tags), nil
+		}
+}
 
-			// Create a new tree with the deletion
-			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, treeEntries)
+// GetTag creates a tool to get details about a specific tag in a GitHub repository.
+func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_tag",
+			mcp.WithDescription(t("TOOL_GET_TAG_DESCRIPTION", "Get details about a specific git tag in a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_TAG_USER_TITLE", "Get tag details"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
 			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to create tree",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create tree: %s", string(body))), nil
-			}
-
-			// Create a new commit with the new tree
-			commit := &github.Commit{
-				Message: github.Ptr(message),
-				Tree:    newTree,
-				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			repo, err := RequiredParam[string](request, "repo")
 			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to create commit",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create commit: %s", string(body))), nil
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			// Update the branch reference to point to the new commit
-			ref.Object.SHA = newCommit.SHA
-			_, resp, err = client.Git.UpdateRef(ctx, owner, repo, ref, false)
+			tag, err := RequiredParam[string](request, "tag")
 			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to update reference",
-					resp,
-					err,
-				), nil
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update reference: %s", string(body))), nil
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Create a response similar to what the DeleteFile API would return
-			response := map[string]interface{}{
-				"commit":  newCommit,
-				"content": nil,
+			// First get the tag reference
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/tags/"+tag)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get tag reference", resp, err); !ok {
+				return result, err
 			}
 
-			r, err := json.Marshal(response)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			// Then get the tag object
+			tagObj, resp, err := client.Git.GetTag(ctx, owner, repo, *ref.Object.SHA)
+			if result, ok, err := handleRESTResponse(ctx, "failed to get tag object", resp, err); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(tagObj), nil
 		}
 }
 
-// CreateBranch creates a tool to create a new branch.
-func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("create_branch",
-			mcp.WithDescription(t("TOOL_CREATE_BRANCH_DESCRIPTION", "Create a new branch in a GitHub repository")),
+// CreateTag creates a tool to create an annotated tag in a GitHub repository.
+func CreateTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_tag",
+			mcp.WithDescription(t("TOOL_CREATE_TAG_DESCRIPTION", "Create an annotated git tag in a GitHub repository, pointing at a commit. Fails if the tag already exists.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_CREATE_BRANCH_USER_TITLE", "Create branch"),
+				Title:        t("TOOL_CREATE_TAG_USER_TITLE", "Create tag"),
 				ReadOnlyHint: ToBoolPtr(false),
 			}),
 			mcp.WithString("owner",
@@ -908,12 +3271,23 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("branch",
+			mcp.WithString("tag",
 				mcp.Required(),
-				mcp.Description("Name for new branch"),
+				mcp.Description("Tag name, e.g. v1.2.0"),
 			),
-			mcp.WithString("from_branch",
-				mcp.Description("Source branch (defaults to repo default)"),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Tag message"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to tag"),
+			),
+			mcp.WithString("tagger_name",
+				mcp.Description("Name of the tagger. Defaults to the authenticated user; requires tagger_email"),
+			),
+			mcp.WithString("tagger_email",
+				mcp.Description("Email of the tagger. Requires tagger_name"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -925,81 +3299,79 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			branch, err := RequiredParam[string](request, "branch")
+			tag, err := RequiredParam[string](request, "tag")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			fromBranch, err := OptionalParam[string](request, "from_branch")
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			taggerName, err := OptionalParam[string](request, "tagger_name")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			taggerEmail, err := OptionalParam[string](request, "tagger_email")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (taggerName == "") != (taggerEmail == "") {
+				return mcp.NewToolResultError("tagger_name and tagger_email must be given together"), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Get the source branch SHA
-			var ref *github.Reference
-
-			if fromBranch == "" {
-				// Get default branch if from_branch not specified
-				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
-				if err != nil {
-					return ghErrors.NewGitHubAPIErrorResponse(ctx,
-						"failed to get repository",
-						resp,
-						err,
-					), nil
-				}
-				defer func() { _ = resp.Body.Close() }()
-
-				fromBranch = *repository.DefaultBranch
+			// An annotated tag is a tag object, which only becomes visible once a ref points at it.
+			newTag := &github.Tag{
+				Tag:     github.Ptr(tag),
+				Message: github.Ptr(message),
+				Object: &github.GitObject{
+					Type: github.Ptr("commit"),
+					SHA:  github.Ptr(sha),
+				},
 			}
-
-			// Get SHA of source branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+fromBranch)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get reference",
-					resp,
-					err,
-				), nil
+			if taggerName != "" {
+				newTag.Tagger = &github.CommitAuthor{
+					Name:  github.Ptr(taggerName),
+					Email: github.Ptr(taggerEmail),
+				}
 			}
-			defer func() { _ = resp.Body.Close() }()
-
-			// Create new branch
-			newRef := &github.Reference{
-				Ref:    github.Ptr("refs/heads/" + branch),
-				Object: &github.GitObject{SHA: ref.Object.SHA},
+			tagObj, resp, err := client.Git.CreateTag(ctx, owner, repo, newTag)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create tag object", resp, err, http.StatusCreated); !ok {
+				return result, err
 			}
 
-			createdRef, resp, err := client.Git.CreateRef(ctx, owner, repo, newRef)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to create branch",
-					resp,
-					err,
-				), nil
+			_, resp, err = client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr("refs/tags/" + tag),
+				Object: &github.GitObject{SHA: tagObj.SHA},
+			})
+			if err != nil && resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create tag %s: the ref refs/tags/%s already exists in %s/%s or is invalid. Delete the existing tag with delete_tag, or choose another name", tag, tag, owner, repo)), nil
 			}
-			defer func() { _ = resp.Body.Close() }()
-
-			r, err := json.Marshal(createdRef)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create tag reference", resp, err, http.StatusCreated); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(tagObj), nil
 		}
 }
 
-// PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
-func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("push_files",
-			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit")),
+// DeleteTag creates a tool to delete a tag from a GitHub repository.
+func DeleteTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_tag",
+			mcp.WithDescription(t("TOOL_DELETE_TAG_DESCRIPTION", "Delete a git tag from a GitHub repository. The tagged commit is not affected.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
-				ReadOnlyHint: ToBoolPtr(false),
+				Title:           t("TOOL_DELETE_TAG_USER_TITLE", "Delete tag"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -1009,33 +3381,9 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("branch",
-				mcp.Required(),
-				mcp.Description("Branch to push to"),
-			),
-			mcp.WithArray("files",
-				mcp.Required(),
-				mcp.Items(
-					map[string]interface{}{
-						"type":                 "object",
-						"additionalProperties": false,
-						"required":             []string{"path", "content"},
-						"properties": map[string]interface{}{
-							"path": map[string]interface{}{
-								"type":        "string",
-								"description": "path to the file",
-							},
-							"content": map[string]interface{}{
-								"type":        "string",
-								"description": "file content",
-							},
-						},
-					}),
-				mcp.Description("Array of file objects to push, each object with path (string) and content (string)"),
-			),
-			mcp.WithString("message",
+			mcp.WithString("tag",
 				mcp.Required(),
-				mcp.Description("Commit message"),
+				mcp.Description("Tag name"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1047,130 +3395,31 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			branch, err := RequiredParam[string](request, "branch")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			message, err := RequiredParam[string](request, "message")
+			tag, err := RequiredParam[string](request, "tag")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			// Parse files parameter - this should be an array of objects with path and content
-			filesObj, ok := request.GetArguments()["files"].([]interface{})
-			if !ok {
-				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
-			}
-
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Get the reference for the branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get branch reference",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get base commit",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			// Create tree entries for all files
-			var entries []*github.TreeEntry
-
-			for _, file := range filesObj {
-				fileMap, ok := file.(map[string]interface{})
-				if !ok {
-					return mcp.NewToolResultError("each file must be an object with path and content"), nil
-				}
-
-				path, ok := fileMap["path"].(string)
-				if !ok || path == "" {
-					return mcp.NewToolResultError("each file must have a path"), nil
-				}
-
-				content, ok := fileMap["content"].(string)
-				if !ok {
-					return mcp.NewToolResultError("each file must have content"), nil
-				}
-
-				// Create a tree entry for the file
-				entries = append(entries, &github.TreeEntry{
-					Path:    github.Ptr(path),
-					Mode:    github.Ptr("100644"), // Regular file mode
-					Type:    github.Ptr("blob"),
-					Content: github.Ptr(content),
-				})
-			}
-
-			// Create a new tree with the file entries
-			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to create tree",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			// Create a new commit
-			commit := &github.Commit{
-				Message: github.Ptr(message),
-				Tree:    newTree,
-				Parents: []*github.Commit{{SHA: baseCommit.SHA}},
-			}
-			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to create commit",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			// Update the reference to point to the new commit
-			ref.Object.SHA = newCommit.SHA
-			updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, ref, false)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to update reference",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			r, err := json.Marshal(updatedRef)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			resp, err := client.Git.DeleteRef(ctx, owner, repo, "refs/tags/"+tag)
+			if result, ok, err := handleRESTResponse(ctx, "failed to delete tag", resp, err, http.StatusNoContent); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return mcp.NewToolResultText(fmt.Sprintf("Tag %s deleted from %s/%s", tag, owner, repo)), nil
 		}
 }
 
-// ListTags creates a tool to list tags in a GitHub repository.
-func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("list_tags",
-			mcp.WithDescription(t("TOOL_LIST_TAGS_DESCRIPTION", "List git tags in a GitHub repository")),
+// GenerateReleaseNotesPreview creates a tool to generate release notes for a tag without creating a release.
+func GenerateReleaseNotesPreview(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("generate_release_notes_preview",
+			mcp.WithDescription(t("TOOL_GENERATE_RELEASE_NOTES_PREVIEW_DESCRIPTION", "Generate the name and markdown body of release notes for a tag in a GitHub repository, without creating a release. Use this to review and edit release notes before publishing a release.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_LIST_TAGS_USER_TITLE", "List tags"),
+				Title:        t("TOOL_GENERATE_RELEASE_NOTES_PREVIEW_USER_TITLE", "Preview generated release notes"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
@@ -1181,7 +3430,16 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			WithPagination(),
+			mcp.WithString("tag_name",
+				mcp.Required(),
+				mcp.Description("Tag name for the release. The tag does not need to exist yet"),
+			),
+			mcp.WithString("previous_tag_name",
+				mcp.Description("Tag to use as the starting point for the release notes. If not provided, the latest release before this one is used"),
+			),
+			mcp.WithString("target_commitish",
+				mcp.Description("Branch or commit SHA the tag will be created from, if the tag does not exist yet. Defaults to the default branch"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -1192,14 +3450,27 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			pagination, err := OptionalPaginationParams(request)
+			tagName, err := RequiredParam[string](request, "tag_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			previousTagName, err := OptionalParam[string](request, "previous_tag_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			targetCommitish, err := OptionalParam[string](request, "target_commitish")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			opts := &github.ListOptions{
-				Page:    pagination.Page,
-				PerPage: pagination.PerPage,
+			opts := &github.GenerateNotesOptions{
+				TagName: tagName,
+			}
+			if previousTagName != "" {
+				opts.PreviousTagName = github.Ptr(previousTagName)
+			}
+			if targetCommitish != "" {
+				opts.TargetCommitish = github.Ptr(targetCommitish)
 			}
 
 			client, err := getClient(ctx)
@@ -1207,39 +3478,29 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			tags, resp, err := client.Repositories.ListTags(ctx, owner, repo, opts)
+			notes, resp, err := client.Repositories.GenerateReleaseNotes(ctx, owner, repo, opts)
 			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to generate release notes for %s: the repository, the previous tag or the target commitish was not found. The tag itself can be new, but target_commitish (or the default branch) and previous_tag_name must exist", tagName)), nil
+				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to list tags",
+					"failed to generate release notes",
 					resp,
 					err,
 				), nil
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list tags: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(tags)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledTextResult(notes), nil
 		}
 }
 
-// GetTag creates a tool to get details about a specific tag in a GitHub repository.
-func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("get_tag",
-			mcp.WithDescription(t("TOOL_GET_TAG_DESCRIPTION", "Get details about a specific git tag in a GitHub repository")),
+// GetRepositoryActivitySummary creates a tool to summarize recent activity in a repository.
+func GetRepositoryActivitySummary(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_activity_summary",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_ACTIVITY_SUMMARY_DESCRIPTION", "Get a summary of activity in a GitHub repository since a given time: commits, merged pull requests, opened and closed issues, and published releases. Returns counts and the most recent items for each category. Each category looks at no more than the 100 most recent items.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_GET_TAG_USER_TITLE", "Get tag details"),
+				Title:        t("TOOL_GET_REPOSITORY_ACTIVITY_SUMMARY_USER_TITLE", "Get repository activity summary"),
 				ReadOnlyHint: ToBoolPtr(true),
 			}),
 			mcp.WithString("owner",
@@ -1250,9 +3511,9 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
-			mcp.WithString("tag",
+			mcp.WithString("since",
 				mcp.Required(),
-				mcp.Description("Tag name"),
+				mcp.Description("Only include activity after this time (RFC3339/ISO8601 format, e.g. 2023-01-01T00:00:00Z)"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1264,61 +3525,270 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			tag, err := RequiredParam[string](request, "tag")
+			sinceParam, err := RequiredParam[string](request, "since")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			since, err := time.Parse(time.RFC3339, sinceParam)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid since time format, should be RFC3339/ISO8601: %v", err)), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// First get the tag reference
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/tags/"+tag)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get tag reference",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
+			activity := fetchRepositoryActivity(ctx, client, owner, repo, since)
+			summary := summarizeRepositoryActivity(owner, repo, since, activity)
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get tag reference: %s", string(body))), nil
-			}
+			return MarshalledTextResult(summary), nil
+		}
+}
 
-			// Then get the tag object
-			tagObj, resp, err := client.Git.GetTag(ctx, owner, repo, *ref.Object.SHA)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get tag object",
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
+// activityPageSize bounds how many items are fetched per category for an activity summary.
+const activityPageSize = 100
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get tag object: %s", string(body))), nil
-			}
+// activityTopItems is the number of most recent items reported per category in an activity summary.
+const activityTopItems = 5
 
-			r, err := json.Marshal(tagObj)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+// ActivityItem is a single entry in a category of a repository activity summary.
+type ActivityItem struct {
+	Title  string    `json:"title"`
+	Number int       `json:"number,omitempty"`
+	SHA    string    `json:"sha,omitempty"`
+	Author string    `json:"author,omitempty"`
+	URL    string    `json:"url"`
+	Date   time.Time `json:"date"`
+}
+
+// ActivityCategory holds the count and most recent items of one kind of repository activity.
+// Note is set instead when the activity could not be fetched.
+type ActivityCategory struct {
+	Count     int            `json:"count"`
+	Truncated bool           `json:"truncated,omitempty"`
+	Items     []ActivityItem `json:"items"`
+	Note      string         `json:"note,omitempty"`
+}
+
+// RepositoryActivitySummary is the result of the get_repository_activity_summary tool.
+type RepositoryActivitySummary struct {
+	Owner              string           `json:"owner"`
+	Repo               string           `json:"repo"`
+	Since              time.Time        `json:"since"`
+	Commits            ActivityCategory `json:"commits"`
+	MergedPullRequests ActivityCategory `json:"merged_pull_requests"`
+	OpenedIssues       ActivityCategory `json:"opened_issues"`
+	ClosedIssues       ActivityCategory `json:"closed_issues"`
+	Releases           ActivityCategory `json:"releases"`
+}
+
+// repositoryActivity is the raw activity fetched for a summary, along with any error per category.
+type repositoryActivity struct {
+	commits         []*github.RepositoryCommit
+	commitsErr      error
+	pullRequests    []*github.PullRequest
+	pullRequestsErr error
+	issues          []*github.Issue
+	issuesErr       error
+	releases        []*github.RepositoryRelease
+	releasesErr     error
+}
+
+// fetchRepositoryActivity concurrently fetches a single bounded page of commits, recently updated
+// closed pull requests, recently updated issues and releases. Failures are recorded per category.
+func fetchRepositoryActivity(ctx context.Context, client *github.Client, owner, repo string, since time.Time) repositoryActivity {
+	var activity repositoryActivity
+	var wg sync.WaitGroup
+	wg.Add(4)
+
+	go func() {
+		defer wg.Done()
+		var resp *github.Response
+		activity.commits, resp, activity.commitsErr = client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{
+			Since:       since,
+			ListOptions: github.ListOptions{PerPage: activityPageSize},
+		})
+		closeResponseBody(resp)
+	}()
+
+	go func() {
+		defer wg.Done()
+		var resp *github.Response
+		activity.pullRequests, resp, activity.pullRequestsErr = client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+			State:       "closed",
+			Sort:        "updated",
+			Direction:   "desc",
+			ListOptions: github.ListOptions{PerPage: activityPageSize},
+		})
+		closeResponseBody(resp)
+	}()
+
+	go func() {
+		defer wg.Done()
+		var resp *github.Response
+		activity.issues, resp, activity.issuesErr = client.Issues.ListByRepo(ctx, owner, repo, &github.IssueListByRepoOptions{
+			State:       "all",
+			Since:       since,
+			Sort:        "updated",
+			Direction:   "desc",
+			ListOptions: github.ListOptions{PerPage: activityPageSize},
+		})
+		closeResponseBody(resp)
+	}()
+
+	go func() {
+		defer wg.Done()
+		var resp *github.Response
+		activity.releases, resp, activity.releasesErr = client.Repositories.ListReleases(ctx, owner, repo, &github.ListOptions{PerPage: activityPageSize})
+		closeResponseBody(resp)
+	}()
+
+	wg.Wait()
+	return activity
+}
+
+// closeResponseBody closes the body of a response, if there is one.
+func closeResponseBody(resp *github.Response) {
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+}
+
+// summarizeRepositoryActivity filters the fetched activity to what happened after since and builds
+// a summary with counts and the most recent items per category.
+func summarizeRepositoryActivity(owner, repo string, since time.Time, activity repositoryActivity) RepositoryActivitySummary {
+	summary := RepositoryActivitySummary{
+		Owner: owner,
+		Repo:  repo,
+		Since: since,
+	}
+
+	var commits []ActivityItem
+	for _, c := range activity.commits {
+		title, _, _ := strings.Cut(c.GetCommit().GetMessage(), "\n")
+		author := c.GetAuthor().GetLogin()
+		if author == "" {
+			author = c.GetCommit().GetAuthor().GetName()
+		}
+		commits = append(commits, ActivityItem{
+			Title:  title,
+			SHA:    c.GetSHA(),
+			Author: author,
+			URL:    c.GetHTMLURL(),
+			Date:   c.GetCommit().GetAuthor().GetDate().Time,
+		})
+	}
+	summary.Commits = newActivityCategory(commits, len(activity.commits) == activityPageSize, activity.commitsErr, "commits")
+
+	var mergedPullRequests []ActivityItem
+	for _, pr := range activity.pullRequests {
+		if pr.MergedAt == nil || pr.GetMergedAt().Before(since) {
+			continue
+		}
+		mergedPullRequests = append(mergedPullRequests, ActivityItem{
+			Title:  pr.GetTitle(),
+			Number: pr.GetNumber(),
+			Author: pr.GetUser().GetLogin(),
+			URL:    pr.GetHTMLURL(),
+			Date:   pr.GetMergedAt().Time,
+		})
+	}
+	// Closed pull requests are not limited by since, but come most recently updated first, and a
+	// pull request merged after since was updated after it too
+	var oldestPullRequestUpdate time.Time
+	if n := len(activity.pullRequests); n > 0 {
+		oldestPullRequestUpdate = activity.pullRequests[n-1].GetUpdatedAt().Time
+	}
+	summary.MergedPullRequests = newActivityCategory(mergedPullRequests, activityPageTruncated(len(activity.pullRequests), oldestPullRequestUpdate, since), activity.pullRequestsErr, "pull requests")
+
+	var openedIssues, closedIssues []ActivityItem
+	for _, issue := range activity.issues {
+		// The issues endpoint also returns pull requests, which are reported separately
+		if issue.IsPullRequest() {
+			continue
+		}
+		item := ActivityItem{
+			Title:  issue.GetTitle(),
+			Number: issue.GetNumber(),
+			Author: issue.GetUser().GetLogin(),
+			URL:    issue.GetHTMLURL(),
+		}
+		if !issue.GetCreatedAt().Before(since) {
+			item.Date = issue.GetCreatedAt().Time
+			openedIssues = append(openedIssues, item)
+		}
+		if issue.ClosedAt != nil && !issue.GetClosedAt().Before(since) {
+			item.Date = issue.GetClosedAt().Time
+			closedIssues = append(closedIssues, item)
+		}
+	}
+	issuesTruncated := len(activity.issues) == activityPageSize
+	summary.OpenedIssues = newActivityCategory(openedIssues, issuesTruncated, activity.issuesErr, "issues")
+	summary.ClosedIssues = newActivityCategory(closedIssues, issuesTruncated, activity.issuesErr, "issues")
+
+	var releases []ActivityItem
+	for _, release := range activity.releases {
+		if release.GetDraft() || release.PublishedAt == nil || release.GetPublishedAt().Before(since) {
+			continue
+		}
+		title := release.GetName()
+		if title == "" {
+			title = release.GetTagName()
+		}
+		releases = append(releases, ActivityItem{
+			Title:  title,
+			Author: release.GetAuthor().GetLogin(),
+			URL:    release.GetHTMLURL(),
+			Date:   release.GetPublishedAt().Time,
+		})
+	}
+	// Releases are not limited by since either, and come most recently created first
+	var oldestRelease time.Time
+	if n := len(activity.releases); n > 0 {
+		oldestRelease = activity.releases[n-1].GetCreatedAt().Time
+		if oldestRelease.IsZero() {
+			oldestRelease = activity.releases[n-1].GetPublishedAt().Time
+		}
+	}
+	summary.Releases = newActivityCategory(releases, activityPageTruncated(len(activity.releases), oldestRelease, since), activity.releasesErr, "releases")
 
-			return mcp.NewToolResultText(string(r)), nil
+	return summary
+}
+
+// activityPageTruncated reports whether a category that is not limited by since may have more
+// activity after since than was fetched: its page is full, and the oldest item on it, dated
+// oldest, is still not before since.
+func activityPageTruncated(count int, oldest, since time.Time) bool {
+	return count == activityPageSize && !oldest.Before(since)
+}
+
+// newActivityCategory builds an activity category from its items, keeping only the most recent ones.
+// If err is set, the category only carries a note explaining that the activity could not be fetched.
+func newActivityCategory(items []ActivityItem, truncated bool, err error, kind string) ActivityCategory {
+	if err != nil {
+		return ActivityCategory{
+			Items: []ActivityItem{},
+			Note:  fmt.Sprintf("failed to list %s: %s", kind, err.Error()),
 		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Date.After(items[j].Date)
+	})
+
+	category := ActivityCategory{
+		Count:     len(items),
+		Truncated: truncated,
+		Items:     items,
+	}
+	if len(category.Items) > activityTopItems {
+		category.Items = category.Items[:activityTopItems]
+	}
+	if category.Items == nil {
+		category.Items = []ActivityItem{}
+	}
+	return category
 }
 
 // filterPaths filters the entries in a GitHub tree to find paths that
@@ -1391,3 +3861,172 @@ func resolveGitReference(ctx context.Context, githubClient *github.Client, owner
 	// Use provided ref, or it will be empty which defaults to the default branch
 	return &raw.ContentOpts{Ref: ref, SHA: sha}, nil
 }
+
+const (
+	// defaultBranchCacheSize bounds how many repositories' default branches are remembered.
+	defaultBranchCacheSize = 1000
+
+	// defaultBranchCacheTTL bounds how long a default branch is remembered, so that a default branch
+	// renamed outside of this server is noticed.
+	defaultBranchCacheTTL = 5 * time.Minute
+)
+
+// defaultBranchEntry is a cached default branch.
+type defaultBranchEntry struct {
+	key       string
+	branch    string
+	fetchedAt time.Time
+}
+
+// defaultBranchCache remembers the default branch of recently looked up repositories, so that repeated
+// writes to the same repository only cost a single API call. It holds at most defaultBranchCacheSize
+// repositories, evicting the least recently used ones, and forgets them after defaultBranchCacheTTL.
+type defaultBranchCache struct {
+	mu      sync.Mutex
+	order   *list.List // most recently used first
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+func newDefaultBranchCache() *defaultBranchCache {
+	return &defaultBranchCache{
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// defaultBranchCacheKey is the cache key of a repository on the host client talks to. One cache serves
+// every account and host, and repositories of the same name on different hosts are unrelated.
+// Repository names are case-insensitive.
+func defaultBranchCacheKey(client *github.Client, owner, repo string) string {
+	host := ""
+	if client.BaseURL != nil {
+		host = client.BaseURL.Host
+	}
+	return strings.ToLower(host + "/" + owner + "/" + repo)
+}
+
+// get returns the default branch for the repository, fetching and caching it if it isn't cached.
+func (c *defaultBranchCache) get(ctx context.Context, client *github.Client, owner, repo string) (string, *github.Response, error) {
+	key := defaultBranchCacheKey(client, owner, repo)
+
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*defaultBranchEntry)
+		if c.now().Sub(e.fetchedAt) < defaultBranchCacheTTL {
+			c.order.MoveToFront(element)
+			c.mu.Unlock()
+			return e.branch, nil, nil
+		}
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return "", resp, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	branch := repository.GetDefaultBranch()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&defaultBranchEntry{key: key, branch: branch, fetchedAt: c.now()})
+	for c.order.Len() > defaultBranchCacheSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*defaultBranchEntry).key)
+	}
+
+	return branch, resp, nil
+}
+
+// forget drops the cached default branch of the repository, if any.
+func (c *defaultBranchCache) forget(client *github.Client, owner, repo string) {
+	key := defaultBranchCacheKey(client, owner, repo)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+}
+
+// DefaultBranchGuard refuses file writes that target a repository's default branch.
+// A nil or disabled guard lets every write through without making any API calls.
+type DefaultBranchGuard struct {
+	enabled bool
+	cache   *defaultBranchCache
+}
+
+// NewDefaultBranchGuard creates a DefaultBranchGuard, which only refuses writes when enabled is true.
+func NewDefaultBranchGuard(enabled bool) *DefaultBranchGuard {
+	return &DefaultBranchGuard{enabled: enabled, cache: newDefaultBranchCache()}
+}
+
+// Forget drops the remembered default branch of a repository. Tools that create, fork or transfer
+// repositories call it, as the repository now at that name may have a different default branch.
+func (g *DefaultBranchGuard) Forget(client *github.Client, owner, repo string) {
+	if g == nil || !g.enabled || owner == "" || repo == "" {
+		return
+	}
+	g.cache.forget(client, owner, repo)
+}
+
+// DefaultBranch returns the default branch of the repository. When the guard is enabled, it is
+// remembered from earlier lookups, so that checking a write and resolving the default branch for
+// it only costs one API call.
+func (g *DefaultBranchGuard) DefaultBranch(ctx context.Context, client *github.Client, owner, repo string) (string, *github.Response, error) {
+	if g != nil && g.enabled {
+		return g.cache.get(ctx, client, owner, repo)
+	}
+
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return "", resp, err
+	}
+	_ = resp.Body.Close()
+	return repository.GetDefaultBranch(), resp, nil
+}
+
+// Check returns a tool error result if the write to branch must be refused, or nil if it may proceed.
+// The default branch is only looked up when the guard is enabled and the caller has not opted out.
+func (g *DefaultBranchGuard) Check(ctx context.Context, client *github.Client, owner, repo, branch string, allowDefaultBranch bool) *mcp.CallToolResult {
+	if g == nil || !g.enabled || allowDefaultBranch {
+		return nil
+	}
+
+	defaultBranch, resp, err := g.cache.get(ctx, client, owner, repo)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get repository default branch",
+			resp,
+			err,
+		)
+	}
+
+	if strings.TrimPrefix(branch, "refs/heads/") != defaultBranch {
+		return nil
+	}
+
+	return mcp.NewToolResultError(fmt.Sprintf(
+		"refusing to write to %s, the default branch of %s/%s, because default branch protection is enabled. "+
+			"Use create_branch to create a new branch, push your changes there, and open a pull request with create_pull_request instead. "+
+			"If writing to the default branch is intended, retry with allow_default_branch set to true.",
+		defaultBranch, owner, repo,
+	))
+}
+
+// WithAllowDefaultBranch adds the parameter used to opt out of default branch protection for a single call.
+func WithAllowDefaultBranch() mcp.ToolOption {
+	return mcp.WithBoolean("allow_default_branch",
+		mcp.Description("Allow writing directly to the repository's default branch when the server protects it. Prefer creating a branch and opening a pull request instead."),
+	)
+}
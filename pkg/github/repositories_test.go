@@ -4,8 +4,15 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -14,6 +21,7 @@ import (
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,11 +41,20 @@ func Test_GetFileContents(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "path")
 	assert.Contains(t, tool.InputSchema.Properties, "ref")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "follow_symlinks")
+	assert.Contains(t, tool.InputSchema.Properties, "max_size")
+	assert.Contains(t, tool.InputSchema.Properties, "start_line")
+	assert.Contains(t, tool.InputSchema.Properties, "end_line")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Mock response for raw content
 	mockRawContent := []byte("# Test Repository\n\nThis is a test repository.")
 
+	// Mock Git LFS pointer and the batch API that resolves it
+	const mockLFSOID = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+	mockLFSPointer := []byte("version https://git-lfs.github.com/spec/v1\noid sha256:" + mockLFSOID + "\nsize 52428800\n")
+	lfsBatchEndpoint := mock.EndpointPattern{Pattern: "/owner/repo.git/info/lfs/objects/batch", Method: "POST"}
+
 	// Setup mock directory content for success case
 	mockDirContent := []*github.RepositoryContent{
 		{
@@ -196,6 +213,228 @@ func Test_GetFileContents(t *testing.T) {
 			expectError:    false,
 			expectedResult: mockDirContent,
 		},
+		{
+			name: "submodule path returns submodule details",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						submoduleContent := &github.RepositoryContent{
+							Name:            github.Ptr("libfoo"),
+							Path:            github.Ptr("third_party/libfoo"),
+							SHA:             github.Ptr("9f1c2e3d4b5a"),
+							Type:            github.Ptr("submodule"),
+							SubmoduleGitURL: github.Ptr("https://github.com/foo/libfoo.git"),
+						}
+						contentBytes, _ := json.Marshal(submoduleContent)
+						_, _ = w.Write(contentBytes)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						t.Error("raw content should not be requested for a submodule")
+						w.WriteHeader(http.StatusNotFound)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "third_party/libfoo",
+				"ref":   "refs/heads/main",
+			},
+			expectError: false,
+			expectedResult: SubmoduleContent{
+				Type:   "submodule",
+				Path:   "third_party/libfoo",
+				GitURL: "https://github.com/foo/libfoo.git",
+				SHA:    "9f1c2e3d4b5a",
+			},
+		},
+		{
+			name: "LFS pointer is described",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Name: github.Ptr("model.bin"),
+						Path: github.Ptr("assets/model.bin"),
+						SHA:  github.Ptr("aa11bb22"),
+						Type: github.Ptr("file"),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write(mockLFSPointer)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "assets/model.bin",
+				"ref":   "refs/heads/main",
+			},
+			expectError: false,
+			expectedResult: LFSPointerContent{
+				Type:       "lfs_pointer",
+				Path:       "assets/model.bin",
+				SHA:        "aa11bb22",
+				LFSPointer: true,
+				OID:        mockLFSOID,
+				Size:       52428800,
+				Note:       "resolve_lfs",
+			},
+		},
+		{
+			name: "LFS pointer is resolved to a download URL",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Name: github.Ptr("model.bin"),
+						Path: github.Ptr("assets/model.bin"),
+						SHA:  github.Ptr("aa11bb22"),
+						Type: github.Ptr("file"),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write(mockLFSPointer)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					lfsBatchEndpoint,
+					expect(t, expectations{
+						requestBody: map[string]any{
+							"operation": "download",
+							"transfers": []any{"basic"},
+							"objects": []any{
+								map[string]any{"oid": mockLFSOID, "size": float64(52428800)},
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{
+							"objects": []any{
+								map[string]any{
+									"oid":  mockLFSOID,
+									"size": 52428800,
+									"actions": map[string]any{
+										"download": map[string]any{
+											"href":       "https://media.example.com/objects/" + mockLFSOID,
+											"expires_at": "2025-01-01T00:00:00Z",
+										},
+									},
+								},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"path":        "assets/model.bin",
+				"ref":         "refs/heads/main",
+				"resolve_lfs": true,
+			},
+			expectError: false,
+			expectedResult: LFSPointerContent{
+				Type:        "lfs_pointer",
+				Path:        "assets/model.bin",
+				SHA:         "aa11bb22",
+				LFSPointer:  true,
+				OID:         mockLFSOID,
+				Size:        52428800,
+				DownloadURL: "https://media.example.com/objects/" + mockLFSOID,
+				ExpiresAt:   "2025-01-01T00:00:00Z",
+				Note:        "download_url",
+			},
+		},
+		{
+			name: "LFS object resolution fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Name: github.Ptr("model.bin"),
+						Path: github.Ptr("assets/model.bin"),
+						SHA:  github.Ptr("aa11bb22"),
+						Type: github.Ptr("file"),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write(mockLFSPointer)
+					}),
+				),
+				mock.WithRequestMatch(
+					lfsBatchEndpoint,
+					map[string]any{
+						"objects": []any{
+							map[string]any{
+								"oid":   mockLFSOID,
+								"size":  52428800,
+								"error": map[string]any{"code": 404, "message": "Object does not exist"},
+							},
+						},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"path":        "assets/model.bin",
+				"ref":         "refs/heads/main",
+				"resolve_lfs": true,
+			},
+			expectError: false,
+			expectedResult: LFSPointerContent{
+				Type:       "lfs_pointer",
+				Path:       "assets/model.bin",
+				SHA:        "aa11bb22",
+				LFSPointer: true,
+				OID:        mockLFSOID,
+				Size:       52428800,
+				Note:       "Object does not exist",
+			},
+		},
 		{
 			name: "content fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -273,6 +512,25 @@ func Test_GetFileContents(t *testing.T) {
 					assert.Equal(t, *expected[i].Path, *content.Path)
 					assert.Equal(t, *expected[i].Type, *content.Type)
 				}
+			case SubmoduleContent:
+				textContent := getTextResult(t, result)
+				var returned SubmoduleContent
+				err = json.Unmarshal([]byte(textContent.Text), &returned)
+				require.NoError(t, err)
+				assert.Equal(t, expected.Type, returned.Type)
+				assert.Equal(t, expected.Path, returned.Path)
+				assert.Equal(t, expected.GitURL, returned.GitURL)
+				assert.Equal(t, expected.SHA, returned.SHA)
+				assert.Contains(t, returned.Hint, "referenced repository")
+			case LFSPointerContent:
+				textContent := getTextResult(t, result)
+				var returned LFSPointerContent
+				err = json.Unmarshal([]byte(textContent.Text), &returned)
+				require.NoError(t, err)
+				// Note is matched on a fragment rather than the full text
+				assert.Contains(t, returned.Note, expected.Note)
+				expected.Note = returned.Note
+				assert.Equal(t, expected, returned)
 			case mcp.TextContent:
 				textContent := getErrorResult(t, result)
 				require.Equal(t, textContent, expected)
@@ -281,345 +539,338 @@ func Test_GetFileContents(t *testing.T) {
 	}
 }
 
-func Test_ForkRepository(t *testing.T) {
-	// Verify tool definition once
-	mockClient := github.NewClient(nil)
-	tool, _ := ForkRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
-	require.NoError(t, toolsnaps.Test(tool.Name, tool))
-
-	assert.Equal(t, "fork_repository", tool.Name)
-	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "organization")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
-
-	// Setup mock forked repo for success case
-	mockForkedRepo := &github.Repository{
-		ID:       github.Ptr(int64(123456)),
-		Name:     github.Ptr("repo"),
-		FullName: github.Ptr("new-owner/repo"),
-		Owner: &github.User{
-			Login: github.Ptr("new-owner"),
-		},
-		HTMLURL:       github.Ptr("https://github.com/new-owner/repo"),
-		DefaultBranch: github.Ptr("main"),
-		Fork:          github.Ptr(true),
-		ForksCount:    github.Ptr(0),
+func Test_GetFileContentsSizeAndLineRange(t *testing.T) {
+	// fileClient serves a file of the given content type, with size as reported by the Contents API
+	fileClient := func(content []byte, contentType string, size int) *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				&github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("")}},
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				mockResponse(t, http.StatusOK, &github.RepositoryContent{
+					Name: github.Ptr("file"),
+					Path: github.Ptr("file"),
+					SHA:  github.Ptr("abc123"),
+					Type: github.Ptr("file"),
+					Size: github.Ptr(size),
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", contentType)
+					_, _ = w.Write(content)
+				}),
+			),
+		)
 	}
+	text := []byte("one\ntwo\nthree\nfour\n")
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedRepo   *github.Repository
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectedText    string
+		expectedURI     string
+		expectedMessage string
+		expectedErrMsg  string
 	}{
 		{
-			name: "successful repository fork",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.PostReposForksByOwnerByRepo,
-					mockResponse(t, http.StatusAccepted, mockForkedRepo),
-				),
-			),
-			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
-			},
-			expectError:  false,
-			expectedRepo: mockForkedRepo,
+			name:           "size reported by the API is over max_size",
+			mockedClient:   fileClient(text, "text/plain", 40*1024*1024),
+			requestArgs:    map[string]interface{}{},
+			expectedErrMsg: "file file is 41943040 bytes, larger than max_size of 1048576 bytes",
 		},
 		{
-			name: "repository fork fails",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.PostReposForksByOwnerByRepo,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusForbidden)
-						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
-					}),
-				),
-			),
-			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
-			},
-			expectError:    true,
-			expectedErrMsg: "failed to fork repository",
+			name:           "downloaded content is over max_size",
+			mockedClient:   fileClient(text, "text/plain", 0),
+			requestArgs:    map[string]interface{}{"max_size": float64(10)},
+			expectedErrMsg: "larger than max_size of 10 bytes",
+		},
+		{
+			name:            "content at max_size is returned",
+			mockedClient:    fileClient(text, "text/plain", len(text)),
+			requestArgs:     map[string]interface{}{"max_size": float64(len(text))},
+			expectedText:    "one\ntwo\nthree\nfour\n",
+			expectedURI:     "repo://owner/repo/refs/heads/main/contents/file",
+			expectedMessage: "successfully downloaded text file (SHA: abc123)",
+		},
+		{
+			name:            "middle lines",
+			mockedClient:    fileClient(text, "text/plain", len(text)),
+			requestArgs:     map[string]interface{}{"start_line": float64(2), "end_line": float64(3)},
+			expectedText:    "two\nthree\n",
+			expectedURI:     "repo://owner/repo/refs/heads/main/contents/file#L2-L3",
+			expectedMessage: "successfully downloaded lines 2-3 of 4 of text file (SHA: abc123)",
+		},
+		{
+			name:            "first line only",
+			mockedClient:    fileClient(text, "text/plain", len(text)),
+			requestArgs:     map[string]interface{}{"end_line": float64(1)},
+			expectedText:    "one\n",
+			expectedURI:     "repo://owner/repo/refs/heads/main/contents/file#L1-L1",
+			expectedMessage: "successfully downloaded lines 1-1 of 4 of text file (SHA: abc123)",
+		},
+		{
+			name:            "last line only",
+			mockedClient:    fileClient(text, "text/plain", len(text)),
+			requestArgs:     map[string]interface{}{"start_line": float64(4)},
+			expectedText:    "four\n",
+			expectedURI:     "repo://owner/repo/refs/heads/main/contents/file#L4-L4",
+			expectedMessage: "successfully downloaded lines 4-4 of 4 of text file (SHA: abc123)",
+		},
+		{
+			name:            "end_line past the end is clamped",
+			mockedClient:    fileClient(text, "text/plain", len(text)),
+			requestArgs:     map[string]interface{}{"start_line": float64(3), "end_line": float64(10)},
+			expectedText:    "three\nfour\n",
+			expectedURI:     "repo://owner/repo/refs/heads/main/contents/file#L3-L4",
+			expectedMessage: "successfully downloaded lines 3-4 of 4 of text file (SHA: abc123)",
+		},
+		{
+			name:           "start_line past the end",
+			mockedClient:   fileClient(text, "text/plain", len(text)),
+			requestArgs:    map[string]interface{}{"start_line": float64(5)},
+			expectedErrMsg: "start_line 5 is past the end of the file, which has 4 lines",
+		},
+		{
+			name:           "end_line before start_line",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{"start_line": float64(3), "end_line": float64(2)},
+			expectedErrMsg: "end_line 2 is before start_line 3",
+		},
+		{
+			name:           "binary files reject line ranges",
+			mockedClient:   fileClient([]byte{0x89, 0x50, 0x4e, 0x47}, "image/png", 4),
+			requestArgs:    map[string]interface{}{"start_line": float64(1)},
+			expectedErrMsg: "start_line and end_line only apply to text files, and file is image/png",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ForkRepository(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
+			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
 
-			// Call handler
-			result, err := handler(context.Background(), request)
+			args := map[string]interface{}{"owner": "owner", "repo": "repo", "path": "file", "ref": "refs/heads/main"}
+			maps.Copy(args, tc.requestArgs)
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
 
-			// Verify results
-			if tc.expectError {
-				require.NoError(t, err)
-				require.True(t, result.IsError)
+			if tc.expectedErrMsg != "" {
 				errorContent := getErrorResult(t, result)
 				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
 			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
-
-			assert.Contains(t, textContent.Text, "Fork is in progress")
+			assert.Equal(t, tc.expectedMessage, result.Content[0].(mcp.TextContent).Text)
+			textResource := getTextResourceResult(t, result)
+			assert.Equal(t, tc.expectedText, textResource.Text)
+			assert.Equal(t, tc.expectedURI, textResource.URI)
 		})
 	}
 }
 
-func Test_CreateBranch(t *testing.T) {
-	// Verify tool definition once
-	mockClient := github.NewClient(nil)
-	tool, _ := CreateBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
-	require.NoError(t, toolsnaps.Test(tool.Name, tool))
-
-	assert.Equal(t, "create_branch", tool.Name)
-	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "branch")
-	assert.Contains(t, tool.InputSchema.Properties, "from_branch")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
-
-	// Setup mock repository for default branch test
-	mockRepo := &github.Repository{
-		DefaultBranch: github.Ptr("main"),
+func Test_GetFileContentsSymlinks(t *testing.T) {
+	symlink := func(linkPath, target string) *github.RepositoryContent {
+		return &github.RepositoryContent{
+			Path:   github.Ptr(linkPath),
+			SHA:    github.Ptr("link-sha"),
+			Type:   github.Ptr("symlink"),
+			Target: github.Ptr(target),
+		}
 	}
-
-	// Setup mock reference for from_branch tests
-	mockSourceRef := &github.Reference{
-		Ref: github.Ptr("refs/heads/main"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("abc123def456"),
-		},
+	guides := []*github.RepositoryContent{
+		{Type: github.Ptr("file"), Name: github.Ptr("intro.md"), Path: github.Ptr("guides/intro.md"), SHA: github.Ptr("abc123")},
 	}
-
-	// Setup mock created reference
-	mockCreatedRef := &github.Reference{
-		Ref: github.Ptr("refs/heads/new-feature"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("abc123def456"),
-		},
+	mockedClient := func() *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposGitRefByOwnerByRepoByRef,
+				&github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("")}},
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var content any
+					switch strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/contents/") {
+					case "docs/current":
+						content = symlink("docs/current", "../guides")
+					case "guides":
+						content = guides
+					case "escape":
+						content = symlink("escape", "../../etc")
+					case "absolute":
+						content = symlink("absolute", "/usr/share/doc")
+					case "chain":
+						content = symlink("chain", "next")
+					case "next":
+						content = symlink("next", "final")
+					default:
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+						return
+					}
+					_ = json.NewEncoder(w).Encode(content)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					t.Error("raw content should not be requested for a symlink")
+					w.WriteHeader(http.StatusNotFound)
+				}),
+			),
+		)
 	}
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedRef    *github.Reference
-		expectedErrMsg string
+		name            string
+		requestArgs     map[string]interface{}
+		expectedSymlink *SymlinkContent
+		expectedDir     []*github.RepositoryContent
 	}{
 		{
-			name: "successful branch creation with from_branch",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockSourceRef,
-				),
-				mock.WithRequestMatch(
-					mock.PostReposGitRefsByOwnerByRepo,
-					mockCreatedRef,
-				),
-			),
-			requestArgs: map[string]interface{}{
-				"owner":       "owner",
-				"repo":        "repo",
-				"branch":      "new-feature",
-				"from_branch": "main",
-			},
-			expectError: false,
-			expectedRef: mockCreatedRef,
+			name:        "symlink to a directory is followed",
+			requestArgs: map[string]interface{}{"path": "docs/current"},
+			expectedDir: guides,
 		},
 		{
-			name: "successful branch creation with default branch",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposByOwnerByRepo,
-					mockRepo,
-				),
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockSourceRef,
-				),
-				mock.WithRequestMatchHandler(
-					mock.PostReposGitRefsByOwnerByRepo,
-					expectRequestBody(t, map[string]interface{}{
-						"ref": "refs/heads/new-feature",
-						"sha": "abc123def456",
-					}).andThen(
-						mockResponse(t, http.StatusCreated, mockCreatedRef),
-					),
-				),
-			),
-			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "new-feature",
+			name:        "symlink to a directory is described when not following",
+			requestArgs: map[string]interface{}{"path": "docs/current", "follow_symlinks": false},
+			expectedSymlink: &SymlinkContent{
+				Type:         "symlink",
+				Path:         "docs/current",
+				Target:       "../guides",
+				ResolvedPath: "guides",
+				SHA:          "link-sha",
 			},
-			expectError: false,
-			expectedRef: mockCreatedRef,
 		},
 		{
-			name: "fail to get repository",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.GetReposByOwnerByRepo,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Repository not found"}`))
-					}),
-				),
-			),
-			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "nonexistent-repo",
-				"branch": "new-feature",
+			name:        "symlink out of the repository is described",
+			requestArgs: map[string]interface{}{"path": "escape"},
+			expectedSymlink: &SymlinkContent{
+				Type:   "symlink",
+				Path:   "escape",
+				Target: "../../etc",
+				SHA:    "link-sha",
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to get repository",
 		},
 		{
-			name: "fail to get reference",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Reference not found"}`))
-					}),
-				),
-			),
-			requestArgs: map[string]interface{}{
-				"owner":       "owner",
-				"repo":        "repo",
-				"branch":      "new-feature",
-				"from_branch": "nonexistent-branch",
+			name:        "absolute symlink is described",
+			requestArgs: map[string]interface{}{"path": "absolute"},
+			expectedSymlink: &SymlinkContent{
+				Type:   "symlink",
+				Path:   "absolute",
+				Target: "/usr/share/doc",
+				SHA:    "link-sha",
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to get reference",
 		},
 		{
-			name: "fail to create branch",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockSourceRef,
-				),
-				mock.WithRequestMatchHandler(
-					mock.PostReposGitRefsByOwnerByRepo,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusUnprocessableEntity)
-						_, _ = w.Write([]byte(`{"message": "Reference already exists"}`))
-					}),
-				),
-			),
-			requestArgs: map[string]interface{}{
-				"owner":       "owner",
-				"repo":        "repo",
-				"branch":      "existing-branch",
-				"from_branch": "main",
+			name:        "only one link is followed",
+			requestArgs: map[string]interface{}{"path": "chain"},
+			expectedSymlink: &SymlinkContent{
+				Type:         "symlink",
+				Path:         "next",
+				Target:       "final",
+				ResolvedPath: "final",
+				SHA:          "link-sha",
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to create branch",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
-			client := github.NewClient(tc.mockedClient)
-			_, handler := CreateBranch(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
+			client := github.NewClient(mockedClient())
+			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
 
-			// Call handler
-			result, err := handler(context.Background(), request)
+			args := map[string]interface{}{"owner": "owner", "repo": "repo", "ref": "refs/heads/main"}
+			maps.Copy(args, tc.requestArgs)
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
 
-			// Verify results
-			if tc.expectError {
-				require.NoError(t, err)
-				require.True(t, result.IsError)
-				errorContent := getErrorResult(t, result)
-				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+			if tc.expectedDir != nil {
+				var returned []*github.RepositoryContent
+				require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+				require.Len(t, returned, len(tc.expectedDir))
+				assert.Equal(t, tc.expectedDir[0].GetPath(), returned[0].GetPath())
 				return
 			}
 
-			require.NoError(t, err)
-			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
+			var returned SymlinkContent
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.NotEmpty(t, returned.Hint)
+			returned.Hint = ""
+			assert.Equal(t, *tc.expectedSymlink, returned)
+		})
+	}
+}
 
-			// Unmarshal and verify the result
-			var returnedRef github.Reference
-			err = json.Unmarshal([]byte(textContent.Text), &returnedRef)
+func Test_SliceLines(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		start, end    int
+		expected      string
+		first, last   int
+		total         int
+		expectedError string
+	}{
+		{name: "whole text", text: "a\nb\nc\n", expected: "a\nb\nc\n", first: 1, last: 3, total: 3},
+		{name: "no final line ending", text: "a\nb\nc", start: 3, expected: "c", first: 3, last: 3, total: 3},
+		{name: "single line", text: "a\nb\nc\n", start: 2, end: 2, expected: "b\n", first: 2, last: 2, total: 3},
+		{name: "crlf endings are kept", text: "a\r\nb\r\n", start: 2, expected: "b\r\n", first: 2, last: 2, total: 2},
+		{name: "empty text", text: "", start: 1, total: 0, expectedError: "start_line 1 is past the end of the file, which has 0 lines"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sliced, first, last, total, err := sliceLines(tc.text, tc.start, tc.end)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
 			require.NoError(t, err)
-			assert.Equal(t, *tc.expectedRef.Ref, *returnedRef.Ref)
-			assert.Equal(t, *tc.expectedRef.Object.SHA, *returnedRef.Object.SHA)
+			assert.Equal(t, tc.expected, sliced)
+			assert.Equal(t, []int{tc.first, tc.last, tc.total}, []int{first, last, total})
 		})
 	}
 }
 
-func Test_GetCommit(t *testing.T) {
+func Test_GetRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := GetCommit(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "get_commit", tool.Name)
+	assert.Equal(t, "get_repository", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "sha")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+	assert.Contains(t, tool.InputSchema.Properties, "raw")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
-	mockCommit := &github.RepositoryCommit{
-		SHA: github.Ptr("abc123def456"),
-		Commit: &github.Commit{
-			Message: github.Ptr("First commit"),
-			Author: &github.CommitAuthor{
-				Name:  github.Ptr("Test User"),
-				Email: github.Ptr("test@example.com"),
-				Date:  &github.Timestamp{Time: time.Now().Add(-48 * time.Hour)},
-			},
-		},
-		Author: &github.User{
-			Login: github.Ptr("testuser"),
-		},
-		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123def456"),
-		Stats: &github.CommitStats{
-			Additions: github.Ptr(10),
-			Deletions: github.Ptr(2),
-			Total:     github.Ptr(12),
-		},
-		Files: []*github.CommitFile{
-			{
-				Filename:  github.Ptr("file1.go"),
-				Status:    github.Ptr("modified"),
-				Additions: github.Ptr(10),
-				Deletions: github.Ptr(2),
-				Changes:   github.Ptr(12),
-				Patch:     github.Ptr("@@ -1,2 +1,10 @@"),
-			},
-		},
+	pushedAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	mockRepo := &github.Repository{
+		FullName:        github.Ptr("owner/repo"),
+		Description:     github.Ptr("A fork of the upstream project"),
+		DefaultBranch:   github.Ptr("main"),
+		Visibility:      github.Ptr("public"),
+		Topics:          []string{"go", "mcp"},
+		Language:        github.Ptr("Go"),
+		License:         &github.License{SPDXID: github.Ptr("MIT")},
+		Archived:        github.Ptr(false),
+		Disabled:        github.Ptr(false),
+		Fork:            github.Ptr(true),
+		Parent:          &github.Repository{FullName: github.Ptr("upstream/repo")},
+		OpenIssuesCount: github.Ptr(7),
+		PushedAt:        &github.Timestamp{Time: pushedAt},
+		StargazersCount: github.Ptr(42),
 	}
 
 	tests := []struct {
@@ -627,222 +878,348 @@ func Test_GetCommit(t *testing.T) {
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedCommit *github.RepositoryCommit
 		expectedErrMsg string
 	}{
 		{
-			name: "successful commit fetch",
+			name: "condensed details",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.GetReposCommitsByOwnerByRepoByRef,
-					mockResponse(t, http.StatusOK, mockCommit),
-				),
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo),
 			),
 			requestArgs: map[string]interface{}{
 				"owner": "owner",
 				"repo":  "repo",
-				"sha":   "abc123def456",
 			},
-			expectError:    false,
-			expectedCommit: mockCommit,
 		},
 		{
-			name: "commit fetch fails",
+			name: "raw repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"raw":   true,
+			},
+		},
+		{
+			name: "repository not found",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposCommitsByOwnerByRepoByRef,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
-					}),
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner": "owner",
-				"repo":  "repo",
-				"sha":   "nonexistent-sha",
+				"repo":  "missing",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to get commit",
+			expectedErrMsg: "failed to get repository",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetCommit(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
+			_, handler := GetRepository(stubGetClientFn(client), translations.NullTranslationHelper)
 
-			// Call handler
-			result, err := handler(context.Background(), request)
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
 
-			// Verify results
 			if tc.expectError {
-				require.NoError(t, err)
-				require.True(t, result.IsError)
 				errorContent := getErrorResult(t, result)
 				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
-			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
+			if tc.requestArgs["raw"] == true {
+				var returned github.Repository
+				require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+				assert.Equal(t, "owner/repo", returned.GetFullName())
+				assert.Equal(t, 42, returned.GetStargazersCount())
+				assert.Equal(t, "upstream/repo", returned.GetParent().GetFullName())
+				return
+			}
 
-			// Unmarshal and verify the result
-			var returnedCommit github.RepositoryCommit
-			err = json.Unmarshal([]byte(textContent.Text), &returnedCommit)
-			require.NoError(t, err)
-
-			assert.Equal(t, *tc.expectedCommit.SHA, *returnedCommit.SHA)
-			assert.Equal(t, *tc.expectedCommit.Commit.Message, *returnedCommit.Commit.Message)
-			assert.Equal(t, *tc.expectedCommit.Author.Login, *returnedCommit.Author.Login)
-			assert.Equal(t, *tc.expectedCommit.HTMLURL, *returnedCommit.HTMLURL)
+			var details RepositoryDetails
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &details))
+			assert.Equal(t, RepositoryDetails{
+				FullName:        "owner/repo",
+				Description:     "A fork of the upstream project",
+				DefaultBranch:   "main",
+				Visibility:      "public",
+				Topics:          []string{"go", "mcp"},
+				Language:        "Go",
+				License:         "MIT",
+				Fork:            true,
+				Parent:          "upstream/repo",
+				OpenIssuesCount: 7,
+				PushedAt:        &github.Timestamp{Time: pushedAt},
+			}, details)
+			assert.NotContains(t, textContent.Text, "stargazers_count")
 		})
 	}
 }
 
-func Test_ListCommits(t *testing.T) {
+func Test_GetRepositoryTopics(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := ListCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetRepositoryTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "list_commits", tool.Name)
+	assert.Equal(t, "get_repository_topics", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "sha")
-	assert.Contains(t, tool.InputSchema.Properties, "author")
-	assert.Contains(t, tool.InputSchema.Properties, "page")
-	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
-	// Setup mock commits for success case
-	mockCommits := []*github.RepositoryCommit{
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedTopics []string
+		expectedErrMsg string
+	}{
 		{
-			SHA: github.Ptr("abc123def456"),
-			Commit: &github.Commit{
-				Message: github.Ptr("First commit"),
-				Author: &github.CommitAuthor{
-					Name:  github.Ptr("Test User"),
-					Email: github.Ptr("test@example.com"),
-					Date:  &github.Timestamp{Time: time.Now().Add(-48 * time.Hour)},
-				},
-			},
-			Author: &github.User{
-				Login: github.Ptr("testuser"),
-			},
-			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123def456"),
+			name: "topics listed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposTopicsByOwnerByRepo, map[string]any{"names": []string{"go", "mcp"}}),
+			),
+			expectedTopics: []string{"go", "mcp"},
 		},
 		{
-			SHA: github.Ptr("def456abc789"),
-			Commit: &github.Commit{
-				Message: github.Ptr("Second commit"),
-				Author: &github.CommitAuthor{
-					Name:  github.Ptr("Another User"),
-					Email: github.Ptr("another@example.com"),
-					Date:  &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
-				},
-			},
-			Author: &github.User{
-				Login: github.Ptr("anotheruser"),
-			},
-			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/def456abc789"),
+			name: "no topics",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposTopicsByOwnerByRepo, map[string]any{"names": []string{}}),
+			),
+			expectedTopics: []string{},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTopicsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get repository topics",
 		},
 	}
 
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var topics []string
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &topics))
+			assert.Equal(t, tc.expectedTopics, topics)
+		})
+	}
+}
+
+func Test_ReplaceRepositoryTopics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReplaceRepositoryTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "replace_repository_topics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "topics")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "topics"})
+
 	tests := []struct {
-		name            string
-		mockedClient    *http.Client
-		requestArgs     map[string]interface{}
-		expectError     bool
-		expectedCommits []*github.RepositoryCommit
-		expectedErrMsg  string
+		name           string
+		mockedClient   *http.Client
+		topics         any
+		expectError    bool
+		expectedTopics []string
+		expectedErrMsg string
 	}{
 		{
-			name: "successful commits fetch with default params",
+			name: "topics replaced",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
-					mock.GetReposCommitsByOwnerByRepo,
-					mockCommits,
+				mock.WithRequestMatchHandler(
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"names": []any{"go", "model-context-protocol"}}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"names": []string{"go", "model-context-protocol"}}),
+					),
 				),
 			),
-			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
-			},
-			expectError:     false,
-			expectedCommits: mockCommits,
+			topics:         []any{"go", "model-context-protocol"},
+			expectedTopics: []string{"go", "model-context-protocol"},
 		},
 		{
-			name: "successful commits fetch with branch",
+			name: "empty list removes every topic",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposCommitsByOwnerByRepo,
-					expectQueryParams(t, map[string]string{
-						"author":   "username",
-						"sha":      "main",
-						"page":     "1",
-						"per_page": "30",
-					}).andThen(
-						mockResponse(t, http.StatusOK, mockCommits),
+					mock.PutReposTopicsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{"names": []any{}}).andThen(
+						mockResponse(t, http.StatusOK, map[string]any{"names": []string{}}),
 					),
 				),
 			),
-			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"sha":    "main",
-				"author": "username",
-			},
-			expectError:     false,
-			expectedCommits: mockCommits,
+			topics:         []any{},
+			expectedTopics: []string{},
 		},
 		{
-			name: "successful commits fetch with pagination",
+			name:           "invalid topics are listed without calling the API",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			topics:         []any{"go", "Go", "has space", "-leading-hyphen", strings.Repeat("a", 51), "under_score"},
+			expectError:    true,
+			expectedErrMsg: `invalid topics: "Go", "has space", "-leading-hyphen", "` + strings.Repeat("a", 51) + `", "under_score"`,
+		},
+		{
+			name:           "topics are required",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: topics",
+		},
+		{
+			name: "API rejects the topics",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposCommitsByOwnerByRepo,
-					expectQueryParams(t, map[string]string{
-						"page":     "2",
-						"per_page": "10",
-					}).andThen(
-						mockResponse(t, http.StatusOK, mockCommits),
-					),
+					mock.PutReposTopicsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			topics:         []any{"go"},
+			expectError:    true,
+			expectedErrMsg: "failed to replace repository topics",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReplaceRepositoryTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{"owner": "owner", "repo": "repo"}
+			if tc.topics != nil {
+				args["topics"] = tc.topics
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var topics []string
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &topics))
+			assert.Equal(t, tc.expectedTopics, topics)
+		})
+	}
+}
+
+func Test_ReplaceRepositoryTopicsIsNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		repos, err := tsg.GetToolset("repos")
+		require.NoError(t, err)
+
+		var names []string
+		for _, st := range repos.GetAvailableTools() {
+			names = append(names, st.Tool.Name)
+		}
+		assert.Contains(t, names, "get_repository_topics")
+		if readOnly {
+			assert.NotContains(t, names, "replace_repository_topics")
+		} else {
+			assert.Contains(t, names, "replace_repository_topics")
+		}
+	}
+}
+
+func Test_ForkRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ForkRepository(stubGetClientFn(mockClient), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "fork_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "organization")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Setup mock forked repo for success case
+	mockForkedRepo := &github.Repository{
+		ID:       github.Ptr(int64(123456)),
+		Name:     github.Ptr("repo"),
+		FullName: github.Ptr("new-owner/repo"),
+		Owner: &github.User{
+			Login: github.Ptr("new-owner"),
+		},
+		HTMLURL:       github.Ptr("https://github.com/new-owner/repo"),
+		DefaultBranch: github.Ptr("main"),
+		Fork:          github.Ptr(true),
+		ForksCount:    github.Ptr(0),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRepo   *github.Repository
+		expectedErrMsg string
+	}{
+		{
+			name: "successful repository fork",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposForksByOwnerByRepo,
+					mockResponse(t, http.StatusAccepted, mockForkedRepo),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"page":    float64(2),
-				"perPage": float64(10),
+				"owner": "owner",
+				"repo":  "repo",
 			},
-			expectError:     false,
-			expectedCommits: mockCommits,
+			expectError:  false,
+			expectedRepo: mockForkedRepo,
 		},
 		{
-			name: "commits fetch fails",
+			name: "repository fork fails",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposCommitsByOwnerByRepo,
+					mock.PostReposForksByOwnerByRepo,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
 					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner": "owner",
-				"repo":  "nonexistent-repo",
+				"repo":  "repo",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to list commits",
+			expectedErrMsg: "failed to fork repository",
 		},
 	}
 
@@ -850,7 +1227,7 @@ func Test_ListCommits(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ListCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := ForkRepository(stubGetClientFn(client), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -873,142 +1250,120 @@ func Test_ListCommits(t *testing.T) {
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			// Unmarshal and verify the result
-			var returnedCommits []*github.RepositoryCommit
-			err = json.Unmarshal([]byte(textContent.Text), &returnedCommits)
-			require.NoError(t, err)
-			assert.Len(t, returnedCommits, len(tc.expectedCommits))
-			for i, commit := range returnedCommits {
-				assert.Equal(t, *tc.expectedCommits[i].Author, *commit.Author)
-				assert.Equal(t, *tc.expectedCommits[i].SHA, *commit.SHA)
-				assert.Equal(t, *tc.expectedCommits[i].Commit.Message, *commit.Commit.Message)
-				assert.Equal(t, *tc.expectedCommits[i].Author.Login, *commit.Author.Login)
-				assert.Equal(t, *tc.expectedCommits[i].HTMLURL, *commit.HTMLURL)
-			}
+			assert.Contains(t, textContent.Text, "Fork is in progress")
 		})
 	}
 }
 
-func Test_CreateOrUpdateFile(t *testing.T) {
+func Test_TransferRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := CreateOrUpdateFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := TransferRepository(stubGetClientFn(mockClient), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "create_or_update_file", tool.Name)
+	assert.Equal(t, "transfer_repository", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "path")
-	assert.Contains(t, tool.InputSchema.Properties, "content")
-	assert.Contains(t, tool.InputSchema.Properties, "message")
-	assert.Contains(t, tool.InputSchema.Properties, "branch")
-	assert.Contains(t, tool.InputSchema.Properties, "sha")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "content", "message", "branch"})
-
-	// Setup mock file content response
-	mockFileResponse := &github.RepositoryContentResponse{
-		Content: &github.RepositoryContent{
-			Name:        github.Ptr("example.md"),
-			Path:        github.Ptr("docs/example.md"),
-			SHA:         github.Ptr("abc123def456"),
-			Size:        github.Ptr(42),
-			HTMLURL:     github.Ptr("https://github.com/owner/repo/blob/main/docs/example.md"),
-			DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/docs/example.md"),
-		},
-		Commit: github.Commit{
-			SHA:     github.Ptr("def456abc789"),
-			Message: github.Ptr("Add example file"),
-			Author: &github.CommitAuthor{
-				Name:  github.Ptr("Test User"),
-				Email: github.Ptr("test@example.com"),
-				Date:  &github.Timestamp{Time: time.Now()},
-			},
-			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/def456abc789"),
-		},
+	assert.Contains(t, tool.InputSchema.Properties, "new_owner")
+	assert.Contains(t, tool.InputSchema.Properties, "team_ids")
+	assert.Contains(t, tool.InputSchema.Properties, "confirm")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "new_owner", "confirm", "expected_repo"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	mockTransferredRepo := &github.Repository{
+		Name:     github.Ptr("repo"),
+		FullName: github.Ptr("owner/repo"),
 	}
 
 	tests := []struct {
-		name            string
-		mockedClient    *http.Client
-		requestArgs     map[string]interface{}
-		expectError     bool
-		expectedContent *github.RepositoryContentResponse
-		expectedErrMsg  string
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
 	}{
 		{
-			name: "successful file creation",
+			name: "transfer accepted",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
+					mock.PostReposTransferByOwnerByRepo,
 					expectRequestBody(t, map[string]interface{}{
-						"message": "Add example file",
-						"content": "IyBFeGFtcGxlCgpUaGlzIGlzIGFuIGV4YW1wbGUgZmlsZS4=", // Base64 encoded content
-						"branch":  "main",
+						"new_owner": "new-org",
+						"team_ids":  []interface{}{float64(12), float64(34)},
 					}).andThen(
-						mockResponse(t, http.StatusOK, mockFileResponse),
+						mockResponse(t, http.StatusAccepted, mockTransferredRepo),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "# Example\n\nThis is an example file.",
-				"message": "Add example file",
-				"branch":  "main",
+				"owner":         "owner",
+				"repo":          "repo",
+				"new_owner":     "new-org",
+				"team_ids":      []interface{}{float64(12), float64(34)},
+				"confirm":       true,
+				"expected_repo": "repo",
 			},
-			expectError:     false,
-			expectedContent: mockFileResponse,
+			expectError:  false,
+			expectedText: "Transfer of owner/repo to new-org initiated, awaiting acceptance",
 		},
 		{
-			name: "successful file update with SHA",
-			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
-					expectRequestBody(t, map[string]interface{}{
-						"message": "Update example file",
-						"content": "IyBVcGRhdGVkIEV4YW1wbGUKClRoaXMgZmlsZSBoYXMgYmVlbiB1cGRhdGVkLg==", // Base64 encoded content
-						"branch":  "main",
-						"sha":     "abc123def456",
-					}).andThen(
-						mockResponse(t, http.StatusOK, mockFileResponse),
-					),
-				),
-			),
+			name:         "confirm is false",
+			mockedClient: mock.NewMockedHTTPClient(),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "# Updated Example\n\nThis file has been updated.",
-				"message": "Update example file",
-				"branch":  "main",
-				"sha":     "abc123def456",
+				"owner":         "owner",
+				"repo":          "repo",
+				"new_owner":     "new-org",
+				"confirm":       false,
+				"expected_repo": "repo",
 			},
-			expectError:     false,
-			expectedContent: mockFileResponse,
+			expectError:    true,
+			expectedErrMsg: "confirm must be true",
 		},
 		{
-			name: "file creation fails",
+			name:         "expected_repo does not match",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"new_owner":     "new-org",
+				"confirm":       true,
+				"expected_repo": "other-repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "expected_repo \"other-repo\" does not match repo \"repo\"",
+		},
+		{
+			name:         "expected_repo missing",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"new_owner": "new-org",
+				"confirm":   true,
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: expected_repo",
+		},
+		{
+			name: "transfer fails",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.PutReposContentsByOwnerByRepoByPath,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusUnprocessableEntity)
-						_, _ = w.Write([]byte(`{"message": "Invalid request"}`))
-					}),
+					mock.PostReposTransferByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"content": "#Invalid Content",
-				"message": "Invalid request",
-				"branch":  "nonexistent-branch",
+				"owner":         "owner",
+				"repo":          "repo",
+				"new_owner":     "new-org",
+				"confirm":       true,
+				"expected_repo": "repo",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to create/update file",
+			expectedErrMsg: "failed to transfer repository",
 		},
 	}
 
@@ -1016,7 +1371,7 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := CreateOrUpdateFile(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := TransferRepository(stubGetClientFn(client), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1025,61 +1380,52 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 			result, err := handler(context.Background(), request)
 
 			// Verify results
+			require.NoError(t, err)
 			if tc.expectError {
-				require.NoError(t, err)
-				require.True(t, result.IsError)
 				errorContent := getErrorResult(t, result)
 				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
 			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
-
-			// Unmarshal and verify the result
-			var returnedContent github.RepositoryContentResponse
-			err = json.Unmarshal([]byte(textContent.Text), &returnedContent)
-			require.NoError(t, err)
-
-			// Verify content
-			assert.Equal(t, *tc.expectedContent.Content.Name, *returnedContent.Content.Name)
-			assert.Equal(t, *tc.expectedContent.Content.Path, *returnedContent.Content.Path)
-			assert.Equal(t, *tc.expectedContent.Content.SHA, *returnedContent.Content.SHA)
-
-			// Verify commit
-			assert.Equal(t, *tc.expectedContent.Commit.SHA, *returnedContent.Commit.SHA)
-			assert.Equal(t, *tc.expectedContent.Commit.Message, *returnedContent.Commit.Message)
+			assert.Equal(t, tc.expectedText, textContent.Text)
 		})
 	}
 }
 
-func Test_CreateRepository(t *testing.T) {
+func Test_CreateBranch(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := CreateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := CreateBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "create_repository", tool.Name)
+	assert.Equal(t, "create_branch", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "name")
-	assert.Contains(t, tool.InputSchema.Properties, "description")
-	assert.Contains(t, tool.InputSchema.Properties, "private")
-	assert.Contains(t, tool.InputSchema.Properties, "autoInit")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "from_branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
 
-	// Setup mock repository response
+	// Setup mock repository for default branch test
 	mockRepo := &github.Repository{
-		Name:        github.Ptr("test-repo"),
-		Description: github.Ptr("Test repository"),
-		Private:     github.Ptr(true),
-		HTMLURL:     github.Ptr("https://github.com/testuser/test-repo"),
-		CloneURL:    github.Ptr("https://github.com/testuser/test-repo.git"),
-		CreatedAt:   &github.Timestamp{Time: time.Now()},
-		Owner: &github.User{
-			Login: github.Ptr("testuser"),
+		DefaultBranch: github.Ptr("main"),
+	}
+
+	// Setup mock reference for from_branch tests
+	mockSourceRef := &github.Reference{
+		Ref: github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("abc123def456"),
+		},
+	}
+
+	// Setup mock created reference
+	mockCreatedRef := &github.Reference{
+		Ref: github.Ptr("refs/heads/new-feature"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("abc123def456"),
 		},
 	}
 
@@ -1088,145 +1434,3594 @@ func Test_CreateRepository(t *testing.T) {
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedRepo   *github.Repository
+		expectedRef    *github.Reference
 		expectedErrMsg string
 	}{
 		{
-			name: "successful repository creation with all parameters",
+			name: "successful branch creation with from_branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockSourceRef,
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitRefsByOwnerByRepo,
+					mockCreatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"branch":      "new-feature",
+				"from_branch": "main",
+			},
+			expectError: false,
+			expectedRef: mockCreatedRef,
+		},
+		{
+			name: "successful branch creation with default branch",
 			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockSourceRef,
+				),
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/user/repos",
-						Method:  "POST",
-					},
+					mock.PostReposGitRefsByOwnerByRepo,
 					expectRequestBody(t, map[string]interface{}{
-						"name":        "test-repo",
-						"description": "Test repository",
-						"private":     true,
-						"auto_init":   true,
+						"ref": "refs/heads/new-feature",
+						"sha": "abc123def456",
 					}).andThen(
-						mockResponse(t, http.StatusCreated, mockRepo),
+						mockResponse(t, http.StatusCreated, mockCreatedRef),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name":        "test-repo",
-				"description": "Test repository",
-				"private":     true,
-				"autoInit":    true,
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "new-feature",
 			},
-			expectError:  false,
-			expectedRepo: mockRepo,
+			expectError: false,
+			expectedRef: mockCreatedRef,
 		},
 		{
-			name: "successful repository creation with minimal parameters",
+			name: "fail to get repository",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/user/repos",
-						Method:  "POST",
-					},
-					expectRequestBody(t, map[string]interface{}{
-						"name":        "test-repo",
-						"auto_init":   false,
-						"description": "",
-						"private":     false,
-					}).andThen(
-						mockResponse(t, http.StatusCreated, mockRepo),
-					),
+					mock.GetReposByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Repository not found"}`))
+					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name": "test-repo",
+				"owner":  "owner",
+				"repo":   "nonexistent-repo",
+				"branch": "new-feature",
 			},
-			expectError:  false,
-			expectedRepo: mockRepo,
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
 		},
 		{
-			name: "repository creation fails",
+			name: "fail to get reference",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.EndpointPattern{
-						Pattern: "/user/repos",
-						Method:  "POST",
-					},
+					mock.GetReposGitRefByOwnerByRepoByRef,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusUnprocessableEntity)
-						_, _ = w.Write([]byte(`{"message": "Repository creation failed"}`))
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Reference not found"}`))
 					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"name": "invalid-repo",
+				"owner":       "owner",
+				"repo":        "repo",
+				"branch":      "new-feature",
+				"from_branch": "nonexistent-branch",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to create repository",
+			expectedErrMsg: "failed to get reference",
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
-			client := github.NewClient(tc.mockedClient)
-			_, handler := CreateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
-
-			// Call handler
-			result, err := handler(context.Background(), request)
-
-			// Verify results
-			if tc.expectError {
-				require.NoError(t, err)
-				require.True(t, result.IsError)
-				errorContent := getErrorResult(t, result)
-				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
-				return
+		{
+			name: "fail to create branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockSourceRef,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Reference already exists"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"branch":      "existing-branch",
+				"from_branch": "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create branch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedRef github.Reference
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRef)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedRef.Ref, *returnedRef.Ref)
+			assert.Equal(t, *tc.expectedRef.Object.SHA, *returnedRef.Object.SHA)
+		})
+	}
+}
+
+func Test_GetCommit(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCommit(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_commit", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "output")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	mockCommit := &github.RepositoryCommit{
+		SHA: github.Ptr("abc123def456"),
+		Commit: &github.Commit{
+			Message: github.Ptr("First commit"),
+			Author: &github.CommitAuthor{
+				Name:  github.Ptr("Test User"),
+				Email: github.Ptr("test@example.com"),
+				Date:  &github.Timestamp{Time: time.Now().Add(-48 * time.Hour)},
+			},
+		},
+		Author: &github.User{
+			Login: github.Ptr("testuser"),
+		},
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123def456"),
+		Stats: &github.CommitStats{
+			Additions: github.Ptr(10),
+			Deletions: github.Ptr(2),
+			Total:     github.Ptr(12),
+		},
+		Files: []*github.CommitFile{
+			{
+				Filename:  github.Ptr("file1.go"),
+				Status:    github.Ptr("modified"),
+				Additions: github.Ptr(10),
+				Deletions: github.Ptr(2),
+				Changes:   github.Ptr(12),
+				Patch:     github.Ptr("@@ -1,2 +1,10 @@"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedCommit *github.RepositoryCommit
+		expectedErrMsg string
+	}{
+		{
+			name: "successful commit fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusOK, mockCommit),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123def456",
+			},
+			expectError:    false,
+			expectedCommit: mockCommit,
+		},
+		{
+			name: "commit fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "nonexistent-sha",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get commit",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedCommit github.RepositoryCommit
+			err = json.Unmarshal([]byte(textContent.Text), &returnedCommit)
+			require.NoError(t, err)
+
+			assert.Equal(t, *tc.expectedCommit.SHA, *returnedCommit.SHA)
+			assert.Equal(t, *tc.expectedCommit.Commit.Message, *returnedCommit.Commit.Message)
+			assert.Equal(t, *tc.expectedCommit.Author.Login, *returnedCommit.Author.Login)
+			assert.Equal(t, *tc.expectedCommit.HTMLURL, *returnedCommit.HTMLURL)
+		})
+	}
+}
+
+func Test_GetCommitFiles(t *testing.T) {
+	patch := "@@ -1,2 +1,2 @@\n-old line\n+new line\n"
+	mockCommit := &github.RepositoryCommit{
+		SHA: github.Ptr("abc123def456"),
+		Stats: &github.CommitStats{
+			Additions: github.Ptr(3),
+			Deletions: github.Ptr(3),
+			Total:     github.Ptr(6),
+		},
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("pkg/github/tools.go"), Status: github.Ptr("modified"), Additions: github.Ptr(1), Deletions: github.Ptr(1), Patch: github.Ptr(patch)},
+			{Filename: github.Ptr("pkg/log/io.go"), Status: github.Ptr("modified"), Additions: github.Ptr(1), Deletions: github.Ptr(1), Patch: github.Ptr(patch)},
+			{Filename: github.Ptr("docs/server.md"), PreviousFilename: github.Ptr("pkg/github/README.md"), Status: github.Ptr("renamed"), Additions: github.Ptr(1), Deletions: github.Ptr(1), Patch: github.Ptr(patch)},
+		},
+	}
+
+	tests := []struct {
+		name                     string
+		requestArgs              map[string]interface{}
+		expectedFiles            []string
+		expectedPatches          []string
+		expectedTruncated        []bool
+		expectedFilesOmitted     int
+		expectedPatchesTruncated int
+	}{
+		{
+			name:              "every file with its full patch by default",
+			requestArgs:       map[string]interface{}{},
+			expectedFiles:     []string{"pkg/github/tools.go", "pkg/log/io.go", "docs/server.md"},
+			expectedPatches:   []string{patch, patch, patch},
+			expectedTruncated: []bool{false, false, false},
+		},
+		{
+			name:                 "filters by path prefix, matching renamed files by their previous path",
+			requestArgs:          map[string]interface{}{"paths": []interface{}{"pkg/github/"}},
+			expectedFiles:        []string{"pkg/github/tools.go", "docs/server.md"},
+			expectedPatches:      []string{patch, patch},
+			expectedTruncated:    []bool{false, false},
+			expectedFilesOmitted: 1,
+		},
+		{
+			name:              "a patch of exactly max_patch_bytes is kept whole",
+			requestArgs:       map[string]interface{}{"paths": []interface{}{"pkg/log/"}, "max_patch_bytes": float64(len(patch))},
+			expectedFiles:     []string{"pkg/log/io.go"},
+			expectedPatches:   []string{patch},
+			expectedTruncated: []bool{false},
+			// The other files are filtered out.
+			expectedFilesOmitted: 2,
+		},
+		{
+			name:                     "a longer patch is cut at the last whole line and marked",
+			requestArgs:              map[string]interface{}{"paths": []interface{}{"pkg/log/"}, "max_patch_bytes": float64(len(patch) - 1)},
+			expectedFiles:            []string{"pkg/log/io.go"},
+			expectedPatches:          []string{"@@ -1,2 +1,2 @@\n-old line\n[patch truncated: showing 26 of 36 bytes; raise max_patch_bytes to see more]"},
+			expectedTruncated:        []bool{true},
+			expectedFilesOmitted:     2,
+			expectedPatchesTruncated: 1,
+		},
+		{
+			name:              "include_patches false removes the patches",
+			requestArgs:       map[string]interface{}{"include_patches": false, "max_patch_bytes": float64(1)},
+			expectedFiles:     []string{"pkg/github/tools.go", "pkg/log/io.go", "docs/server.md"},
+			expectedPatches:   []string{"", "", ""},
+			expectedTruncated: []bool{false, false, false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepoByRef, mockCommit),
+			))
+			_, handler := GetCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123def456",
+			}
+			maps.Copy(args, tc.requestArgs)
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			text := getTextResult(t, result).Text
+			var returned struct {
+				Stats *github.CommitStats `json:"stats"`
+				Files []struct {
+					Filename       string  `json:"filename"`
+					Patch          *string `json:"patch"`
+					PatchTruncated bool    `json:"patch_truncated"`
+				} `json:"files"`
+				FilesOmitted     int `json:"files_omitted"`
+				PatchesTruncated int `json:"patches_truncated"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(text), &returned))
+
+			assert.Equal(t, mockCommit.Stats, returned.Stats)
+			require.Len(t, returned.Files, len(tc.expectedFiles))
+			for i, file := range returned.Files {
+				assert.Equal(t, tc.expectedFiles[i], file.Filename)
+				if tc.expectedPatches[i] == "" {
+					assert.Nil(t, file.Patch)
+				} else {
+					require.NotNil(t, file.Patch)
+					assert.Equal(t, tc.expectedPatches[i], *file.Patch)
+				}
+				assert.Equal(t, tc.expectedTruncated[i], file.PatchTruncated)
+			}
+			assert.Equal(t, tc.expectedFilesOmitted, returned.FilesOmitted)
+			assert.Equal(t, tc.expectedPatchesTruncated, returned.PatchesTruncated)
+			if tc.requestArgs["include_patches"] == false {
+				assert.NotContains(t, text, `"patch"`)
+			}
+		})
+	}
+}
+
+func Test_ListCommits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_commits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "output")
+	assert.Contains(t, tool.InputSchema.Properties, "author")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Setup mock commits for success case
+	mockCommits := []*github.RepositoryCommit{
+		{
+			SHA: github.Ptr("abc123def456"),
+			Commit: &github.Commit{
+				Message: github.Ptr("First commit"),
+				Author: &github.CommitAuthor{
+					Name:  github.Ptr("Test User"),
+					Email: github.Ptr("test@example.com"),
+					Date:  &github.Timestamp{Time: time.Now().Add(-48 * time.Hour)},
+				},
+			},
+			Author: &github.User{
+				Login: github.Ptr("testuser"),
+			},
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123def456"),
+		},
+		{
+			SHA: github.Ptr("def456abc789"),
+			Commit: &github.Commit{
+				Message: github.Ptr("Second commit"),
+				Author: &github.CommitAuthor{
+					Name:  github.Ptr("Another User"),
+					Email: github.Ptr("another@example.com"),
+					Date:  &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
+				},
+			},
+			Author: &github.User{
+				Login: github.Ptr("anotheruser"),
+			},
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/def456abc789"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedCommits []*github.RepositoryCommit
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful commits fetch with default params",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepo,
+					mockCommits,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:     false,
+			expectedCommits: mockCommits,
+		},
+		{
+			name: "successful commits fetch with branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"author":   "username",
+						"sha":      "main",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCommits),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"sha":    "main",
+				"author": "username",
+			},
+			expectError:     false,
+			expectedCommits: mockCommits,
+		},
+		{
+			name: "successful commits fetch with pagination",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCommits),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectError:     false,
+			expectedCommits: mockCommits,
+		},
+		{
+			name: "commits fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "nonexistent-repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list commits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedCommits []*github.RepositoryCommit
+			err = json.Unmarshal([]byte(textContent.Text), &returnedCommits)
+			require.NoError(t, err)
+			assert.Len(t, returnedCommits, len(tc.expectedCommits))
+			for i, commit := range returnedCommits {
+				assert.Equal(t, *tc.expectedCommits[i].Author, *commit.Author)
+				assert.Equal(t, *tc.expectedCommits[i].SHA, *commit.SHA)
+				assert.Equal(t, *tc.expectedCommits[i].Commit.Message, *commit.Commit.Message)
+				assert.Equal(t, *tc.expectedCommits[i].Author.Login, *commit.Author.Login)
+				assert.Equal(t, *tc.expectedCommits[i].HTMLURL, *commit.HTMLURL)
+			}
+		})
+	}
+}
+
+func Test_ListCommitsCounts(t *testing.T) {
+	day := time.Date(2024, 5, 1, 23, 30, 0, 0, time.UTC)
+	commits := []*github.RepositoryCommit{
+		{SHA: github.Ptr("abc123def456"), Author: &github.User{Login: github.Ptr("octocat")}, Commit: &github.Commit{Author: &github.CommitAuthor{Name: github.Ptr("Octo Cat"), Date: &github.Timestamp{Time: day}}}},
+		{SHA: github.Ptr("def456abc789"), Author: &github.User{Login: github.Ptr("octocat")}, Commit: &github.Commit{Author: &github.CommitAuthor{Name: github.Ptr("Octo Cat"), Date: &github.Timestamp{Time: day.Add(time.Hour)}}}},
+	}
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposCommitsByOwnerByRepo,
+			commits,
+			commits,
+		),
+	))
+	_, handler := ListCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":  "owner",
+		"repo":   "repo",
+		"output": "counts",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	text := getTextResult(t, result).Text
+	assert.NotContains(t, text, "abc123def456")
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(text), &fields))
+	assert.ElementsMatch(t, []string{"total_commits", "by_author", "by_day"}, slices.Collect(maps.Keys(fields)))
+
+	var summary CommitsSummary
+	require.NoError(t, json.Unmarshal([]byte(text), &summary))
+	assert.Equal(t, CommitsSummary{
+		TotalCommits: 2,
+		ByAuthor:     map[string]int{"octocat": 2},
+		ByDay:        map[string]int{"2024-05-01": 1, "2024-05-02": 1},
+	}, summary)
+
+	// The deprecated summary_only is output 'counts'
+	deprecated, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"summary_only": true,
+	}))
+	require.NoError(t, err)
+	assert.JSONEq(t, text, getTextResult(t, deprecated).Text)
+
+	conflicting, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"summary_only": true,
+		"output":       "summary",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "summary_only cannot be combined with output 'summary', use output 'counts' instead", getErrorResult(t, conflicting).Text)
+}
+
+func Test_SummarizeCommits(t *testing.T) {
+	day := time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	commits := []*github.RepositoryCommit{
+		{Author: &github.User{Login: github.Ptr("octocat")}, Commit: &github.Commit{Author: &github.CommitAuthor{Date: &github.Timestamp{Time: day}}}},
+		{Commit: &github.Commit{Author: &github.CommitAuthor{Name: github.Ptr("Unlinked Dev"), Date: &github.Timestamp{Time: day.AddDate(0, 0, 1)}}}},
+		{Commit: &github.Commit{}},
+	}
+
+	assert.Equal(t, CommitsSummary{
+		TotalCommits: 3,
+		ByAuthor:     map[string]int{"octocat": 1, "Unlinked Dev": 1, "(unknown)": 1},
+		ByDay:        map[string]int{"2024-05-01": 1, "2024-05-02": 1},
+	}, summarizeCommits(commits))
+
+	assert.Equal(t, CommitsSummary{ByAuthor: map[string]int{}, ByDay: map[string]int{}}, summarizeCommits(nil))
+}
+
+func Test_CommitSummaryOutput(t *testing.T) {
+	date := time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	commit := &github.RepositoryCommit{
+		SHA:     github.Ptr("6dcb09b5b57875f334f61aebed695e2e4193db5e"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e"),
+		Author:  &github.User{Login: github.Ptr("octocat")},
+		Commit: &github.Commit{
+			Message:      github.Ptr("Fix the widget\n\nThe widget broke when the gadget was missing."),
+			Author:       &github.CommitAuthor{Name: github.Ptr("Octo Cat"), Date: &github.Timestamp{Time: date}},
+			Verification: &github.SignatureVerification{Verified: github.Ptr(true), Signature: github.Ptr("-----BEGIN PGP SIGNATURE-----")},
+		},
+		Stats: &github.CommitStats{Additions: github.Ptr(10), Deletions: github.Ptr(2), Total: github.Ptr(12)},
+		Files: []*github.CommitFile{
+			{
+				Filename:  github.Ptr("widget.go"),
+				Status:    github.Ptr("modified"),
+				Additions: github.Ptr(10),
+				Deletions: github.Ptr(2),
+				Changes:   github.Ptr(12),
+				Patch:     github.Ptr("@@ -1,2 +1,10 @@"),
+			},
+		},
+	}
+	// Commits are listed without their files and stats.
+	listed := *commit
+	listed.Files = nil
+	listed.Stats = nil
+
+	tests := []struct {
+		name         string
+		newTool      func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		mockedClient *http.Client
+		requestArgs  map[string]interface{}
+		expectedJSON string
+	}{
+		{
+			name:    "list_commits",
+			newTool: ListCommits,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepo, []*github.RepositoryCommit{&listed}),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"output": "summary",
+			},
+			expectedJSON: `[{
+				"sha": "6dcb09b5b578",
+				"author": "octocat",
+				"author_date": "2024-05-01T10:00:00Z",
+				"message": "Fix the widget"
+			}]`,
+		},
+		{
+			name:    "get_commit",
+			newTool: GetCommit,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepoByRef, commit),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"sha":    "6dcb09b",
+				"output": "summary",
+			},
+			expectedJSON: `{
+				"sha": "6dcb09b5b578",
+				"author": "octocat",
+				"author_date": "2024-05-01T10:00:00Z",
+				"message": "Fix the widget",
+				"files": [{"filename": "widget.go", "status": "modified", "additions": 10, "deletions": 2}],
+				"stats": {"additions": 10, "deletions": 2, "total": 12}
+			}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := tc.newTool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			assert.JSONEq(t, tc.expectedJSON, getTextResult(t, result).Text)
+		})
+	}
+
+	t.Run("invalid output", func(t *testing.T) {
+		_, handler := ListCommits(stubGetClientFn(github.NewClient(nil)), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":  "owner",
+			"repo":   "repo",
+			"output": "short",
+		}))
+		require.NoError(t, err)
+
+		errorContent := getErrorResult(t, result)
+		assert.Equal(t, `invalid output "short", must be "full", "summary" or "counts"`, errorContent.Text)
+	})
+}
+
+func Test_SummarizeCommit(t *testing.T) {
+	tests := []struct {
+		name     string
+		commit   *github.RepositoryCommit
+		expected CommitSummary
+	}{
+		{
+			name: "author not linked to an account",
+			commit: &github.RepositoryCommit{
+				SHA:    github.Ptr("def456abc789def456abc789def456abc789def4"),
+				Commit: &github.Commit{Message: github.Ptr("Update docs"), Author: &github.CommitAuthor{Name: github.Ptr("Unlinked Dev")}},
+			},
+			expected: CommitSummary{SHA: "def456abc789", Author: "Unlinked Dev", Message: "Update docs"},
+		},
+		{
+			name: "windows line endings",
+			commit: &github.RepositoryCommit{
+				SHA:    github.Ptr("abc123"),
+				Author: &github.User{Login: github.Ptr("octocat")},
+				Commit: &github.Commit{Message: github.Ptr("Fix the widget\r\n\r\nDetails")},
+			},
+			expected: CommitSummary{SHA: "abc123", Author: "octocat", Message: "Fix the widget"},
+		},
+		{
+			name:     "empty commit",
+			commit:   &github.RepositoryCommit{},
+			expected: CommitSummary{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, summarizeCommit(tc.commit))
+		})
+	}
+}
+
+func Test_CompareCommits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_commits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.Contains(t, tool.InputSchema.Properties, "max_patch_bytes")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	longPatch := "@@ -1,2 +1,3 @@\n line one\n+line two\n line three"
+	mockComparison := &github.CommitsComparison{
+		Status:       github.Ptr("ahead"),
+		AheadBy:      github.Ptr(300),
+		BehindBy:     github.Ptr(0),
+		TotalCommits: github.Ptr(300),
+		Commits: []*github.RepositoryCommit{
+			{
+				SHA:    github.Ptr("abc123"),
+				Author: &github.User{Login: github.Ptr("octocat")},
+				Commit: &github.Commit{Message: github.Ptr("Add feature"), Author: &github.CommitAuthor{Name: github.Ptr("The Octocat")}},
+			},
+			{
+				SHA:    github.Ptr("def456"),
+				Commit: &github.Commit{Message: github.Ptr("Fix bug"), Author: &github.CommitAuthor{Name: github.Ptr("Unlinked Author")}},
+			},
+		},
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("feature.go"), Status: github.Ptr("modified"), Additions: github.Ptr(1), Patch: github.Ptr(longPatch)},
+			{Filename: github.Ptr("new.go"), PreviousFilename: github.Ptr("old.go"), Status: github.Ptr("renamed"), Patch: github.Ptr("@@ -1 +1 @@")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult CompareCommitsResult
+		expectedErrMsg string
+	}{
+		{
+			name: "compares base...head and cuts long patches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectPath(t, "/repos/owner/repo/compare/v1.2.0...main").andThen(
+						expectQueryParams(t, map[string]string{}).andThen(
+							mockResponse(t, http.StatusOK, mockComparison),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"base":            "v1.2.0",
+				"head":            "main",
+				"max_patch_bytes": float64(30),
+			},
+			expectedResult: CompareCommitsResult{
+				Status:           "ahead",
+				AheadBy:          300,
+				TotalCommits:     300,
+				CommitsTruncated: true,
+				Commits: []ComparedCommit{
+					{SHA: "abc123", Message: "Add feature", Author: "octocat"},
+					{SHA: "def456", Message: "Fix bug", Author: "Unlinked Author"},
+				},
+				Files: []ComparedFile{
+					{Filename: "feature.go", Status: "modified", Additions: 1, Patch: "@@ -1,2 +1,3 @@\n line one\n", PatchTruncated: true},
+					{Filename: "new.go", PreviousFilename: "old.go", Status: "renamed", Patch: "@@ -1 +1 @@"},
+				},
+			},
+		},
+		{
+			name: "passes pagination and reports the next page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectQueryParams(t, map[string]string{"page": "2", "per_page": "2"}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/compare/v1.2.0...main?page=3&per_page=2>; rel="next"`)
+							_ = json.NewEncoder(w).Encode(&github.CommitsComparison{
+								Status:       github.Ptr("ahead"),
+								AheadBy:      github.Ptr(6),
+								TotalCommits: github.Ptr(6),
+							})
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"base":    "v1.2.0",
+				"head":    "main",
+				"page":    float64(2),
+				"perPage": float64(2),
+			},
+			expectedResult: CompareCommitsResult{
+				Status:       "ahead",
+				AheadBy:      6,
+				TotalCommits: 6,
+				Commits:      []ComparedCommit{},
+				Files:        []ComparedFile{},
+				NextPageInfo: NextPageInfo{HasNextPage: true, NextPage: 3},
+			},
+		},
+		{
+			name: "the last page is not reported as truncated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectQueryParams(t, map[string]string{"page": "3", "per_page": "2"}).andThen(
+						mockResponse(t, http.StatusOK, &github.CommitsComparison{
+							Status:       github.Ptr("ahead"),
+							AheadBy:      github.Ptr(6),
+							TotalCommits: github.Ptr(6),
+							Commits: []*github.RepositoryCommit{
+								{SHA: github.Ptr("abc123"), Commit: &github.Commit{Message: github.Ptr("Add feature")}},
+								{SHA: github.Ptr("def456"), Commit: &github.Commit{Message: github.Ptr("Fix bug")}},
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"base":    "v1.2.0",
+				"head":    "main",
+				"page":    float64(3),
+				"perPage": float64(2),
+			},
+			expectedResult: CompareCommitsResult{
+				Status:       "ahead",
+				AheadBy:      6,
+				TotalCommits: 6,
+				Commits: []ComparedCommit{
+					{SHA: "abc123", Message: "Add feature"},
+					{SHA: "def456", Message: "Fix bug"},
+				},
+				Files: []ComparedFile{},
+			},
+		},
+		{
+			name: "unknown ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v9.9.9",
+				"head":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare v9.9.9...main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned CompareCommitsResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_GetRefCIState(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRefCIState(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_ref_ci_state", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "required_only")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	mockStatus := &github.CombinedStatus{
+		SHA:   github.Ptr("abcd1234"),
+		State: github.Ptr("failure"),
+		Statuses: []*github.RepoStatus{
+			{Context: github.Ptr("ci/jenkins"), State: github.Ptr("success"), TargetURL: github.Ptr("https://ci.example.com/1")},
+			{Context: github.Ptr("coverage"), State: github.Ptr("failure")},
+		},
+	}
+	mockCheckRuns := &github.ListCheckRunsResults{
+		Total: github.Ptr(3),
+		CheckRuns: []*github.CheckRun{
+			{ID: github.Ptr(int64(1)), Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+			{ID: github.Ptr(int64(2)), Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"), HTMLURL: github.Ptr("https://github.com/owner/repo/runs/2")},
+			{ID: github.Ptr(int64(3)), Name: github.Ptr("test"), Status: github.Ptr("in_progress")},
+		},
+	}
+	mockProtection := &github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Checks: &[]*github.RequiredStatusCheck{
+				{Context: "ci/jenkins"},
+				{Context: "build"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedState   string
+		expectedItems   []CIItem
+		expectedMissing []string
+		expectedNote    string
+	}{
+		{
+			name: "statuses and check runs are merged",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/commits/main/status").andThen(
+						mockResponse(t, http.StatusOK, mockStatus),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/commits/main/check-runs").andThen(
+						mockResponse(t, http.StatusOK, mockCheckRuns),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectedState: "failure",
+			expectedItems: []CIItem{
+				{Name: "ci/jenkins", Kind: "status", State: "success", URL: "https://ci.example.com/1"},
+				{Name: "coverage", Kind: "status", State: "failure"},
+				{Name: "build", Kind: "check", State: "success", URL: "https://github.com/owner/repo/runs/2"},
+				{Name: "test", Kind: "check", State: "pending"},
+			},
+		},
+		{
+			name: "required only ignores optional failures",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					mockStatus,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockCheckRuns,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					expectPath(t, "/repos/owner/repo/branches/main/protection").andThen(
+						mockResponse(t, http.StatusOK, mockProtection),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					expectPath(t, "/repos/owner/repo/rules/branches/main").andThen(
+						mockResponse(t, http.StatusOK, []map[string]any{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"ref":           "abcd1234",
+				"branch":        "main",
+				"required_only": true,
+			},
+			expectedState: "success",
+			expectedItems: []CIItem{
+				{Name: "ci/jenkins", Kind: "status", State: "success", URL: "https://ci.example.com/1"},
+				{Name: "coverage", Kind: "status", State: "failure"},
+				{Name: "build", Kind: "check", State: "success", URL: "https://github.com/owner/repo/runs/2"},
+				{Name: "test", Kind: "check", State: "pending"},
+			},
+		},
+		{
+			name: "required only without protection access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					&github.CombinedStatus{SHA: github.Ptr("abcd1234")},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockCheckRuns,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Must have admin rights to Repository."}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					[]map[string]any{
+						{
+							"type": "required_status_checks",
+							"parameters": map[string]any{
+								"required_status_checks": []map[string]any{
+									{"context": "test"},
+									{"context": "security-scan"},
+								},
+								"strict_required_status_checks_policy": false,
+							},
+						},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":         "owner",
+				"repo":          "repo",
+				"ref":           "main",
+				"required_only": true,
+			},
+			expectedState: "pending",
+			expectedItems: []CIItem{
+				{Name: "build", Kind: "check", State: "success", URL: "https://github.com/owner/repo/runs/2"},
+				{Name: "test", Kind: "check", State: "pending"},
+			},
+			expectedMissing: []string{"security-scan"},
+			expectedNote:    "could not read branch protection",
+		},
+		{
+			name: "status fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsStatusByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "No commit found for SHA: nope"}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					mockCheckRuns,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "nope",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get combined status",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRefCIState(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned RefCIState
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "abcd1234", returned.SHA)
+			assert.Equal(t, tc.expectedState, returned.State)
+			assert.Equal(t, tc.expectedItems, returned.Items)
+			assert.Equal(t, tc.expectedMissing, returned.Missing)
+			if tc.expectedNote != "" {
+				require.Len(t, returned.Notes, 1)
+				assert.Contains(t, returned.Notes[0], tc.expectedNote)
+			} else {
+				assert.Empty(t, returned.Notes)
+			}
+		})
+	}
+}
+
+func Test_RollupCIState(t *testing.T) {
+	item := func(name, state string) CIItem {
+		return CIItem{Name: name, Kind: "check", State: state}
+	}
+
+	tests := []struct {
+		name            string
+		items           []CIItem
+		required        []string
+		expectedState   string
+		expectedMissing []string
+	}{
+		{
+			name:          "nothing reported",
+			expectedState: "none",
+		},
+		{
+			name:          "all successful",
+			items:         []CIItem{item("build", "success"), item("test", "success")},
+			expectedState: "success",
+		},
+		{
+			name:          "pending beats success",
+			items:         []CIItem{item("build", "success"), item("test", "pending")},
+			expectedState: "pending",
+		},
+		{
+			name:          "failure beats pending",
+			items:         []CIItem{item("build", "pending"), item("test", "failure"), item("lint", "success")},
+			expectedState: "failure",
+		},
+		{
+			name:          "required only ignores other failures",
+			items:         []CIItem{item("build", "success"), item("lint", "failure")},
+			required:      []string{"build"},
+			expectedState: "success",
+		},
+		{
+			name:            "missing required check is pending",
+			items:           []CIItem{item("build", "success")},
+			required:        []string{"build", "deploy", "deploy"},
+			expectedState:   "pending",
+			expectedMissing: []string{"deploy"},
+		},
+		{
+			name:            "failure beats missing",
+			items:           []CIItem{item("build", "failure")},
+			required:        []string{"build", "deploy"},
+			expectedState:   "failure",
+			expectedMissing: []string{"deploy"},
+		},
+		{
+			name:          "no required checks",
+			items:         []CIItem{item("lint", "failure")},
+			required:      []string{},
+			expectedState: "none",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			state, missing := rollupCIState(tc.items, tc.required)
+			assert.Equal(t, tc.expectedState, state)
+			assert.Equal(t, tc.expectedMissing, missing)
+		})
+	}
+}
+
+func Test_CreateOrUpdateFile(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrUpdateFile(stubGetClientFn(mockClient), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_or_update_file", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "content", "message", "branch"})
+
+	// Setup mock file content response
+	mockFileResponse := &github.RepositoryContentResponse{
+		Content: &github.RepositoryContent{
+			Name:        github.Ptr("example.md"),
+			Path:        github.Ptr("docs/example.md"),
+			SHA:         github.Ptr("abc123def456"),
+			Size:        github.Ptr(42),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/blob/main/docs/example.md"),
+			DownloadURL: github.Ptr("https://raw.githubusercontent.com/owner/repo/main/docs/example.md"),
+		},
+		Commit: github.Commit{
+			SHA:     github.Ptr("def456abc789"),
+			Message: github.Ptr("Add example file"),
+			Author: &github.CommitAuthor{
+				Name:  github.Ptr("Test User"),
+				Email: github.Ptr("test@example.com"),
+				Date:  &github.Timestamp{Time: time.Now()},
+			},
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/def456abc789"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedContent *github.RepositoryContentResponse
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful file creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Add example file",
+						"content": "IyBFeGFtcGxlCgpUaGlzIGlzIGFuIGV4YW1wbGUgZmlsZS4=", // Base64 encoded content
+						"branch":  "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Example\n\nThis is an example file.",
+				"message": "Add example file",
+				"branch":  "main",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "successful file update with SHA",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update example file",
+						"content": "IyBVcGRhdGVkIEV4YW1wbGUKClRoaXMgZmlsZSBoYXMgYmVlbiB1cGRhdGVkLg==", // Base64 encoded content
+						"branch":  "main",
+						"sha":     "abc123def456",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Updated Example\n\nThis file has been updated.",
+				"message": "Update example file",
+				"branch":  "main",
+				"sha":     "abc123def456",
+			},
+			expectError:     false,
+			expectedContent: mockFileResponse,
+		},
+		{
+			name: "file creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Invalid request"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "#Invalid Content",
+				"message": "Invalid request",
+				"branch":  "nonexistent-branch",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create/update file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrUpdateFile(stubGetClientFn(client), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedContent github.RepositoryContentResponse
+			err = json.Unmarshal([]byte(textContent.Text), &returnedContent)
+			require.NoError(t, err)
+
+			// Verify content
+			assert.Equal(t, *tc.expectedContent.Content.Name, *returnedContent.Content.Name)
+			assert.Equal(t, *tc.expectedContent.Content.Path, *returnedContent.Content.Path)
+			assert.Equal(t, *tc.expectedContent.Content.SHA, *returnedContent.Content.SHA)
+
+			// Verify commit
+			assert.Equal(t, *tc.expectedContent.Commit.SHA, *returnedContent.Commit.SHA)
+			assert.Equal(t, *tc.expectedContent.Commit.Message, *returnedContent.Commit.Message)
+		})
+	}
+}
+
+func Test_CreateRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRepository(stubGetClientFn(mockClient), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "private")
+	assert.Contains(t, tool.InputSchema.Properties, "autoInit")
+	assert.Contains(t, tool.InputSchema.Properties, "gitignore_template")
+	assert.Contains(t, tool.InputSchema.Properties, "license_template")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	// Setup mock repository response
+	mockRepo := &github.Repository{
+		Name:        github.Ptr("test-repo"),
+		Description: github.Ptr("Test repository"),
+		Private:     github.Ptr(true),
+		HTMLURL:     github.Ptr("https://github.com/testuser/test-repo"),
+		CloneURL:    github.Ptr("https://github.com/testuser/test-repo.git"),
+		CreatedAt:   &github.Timestamp{Time: time.Now()},
+		Owner: &github.User{
+			Login: github.Ptr("testuser"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRepo   *github.Repository
+		expectedErrMsg string
+	}{
+		{
+			name: "successful repository creation with all parameters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "test-repo",
+						"description": "Test repository",
+						"private":     true,
+						"auto_init":   true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":        "test-repo",
+				"description": "Test repository",
+				"private":     true,
+				"autoInit":    true,
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "successful repository creation with minimal parameters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "test-repo",
+						"auto_init":   false,
+						"description": "",
+						"private":     false,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "test-repo",
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "repository creation with templates",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetGitignoreTemplates,
+					[]string{"Go", "Node", "Python"},
+				),
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":               "test-repo",
+						"auto_init":          false,
+						"description":        "",
+						"private":            false,
+						"gitignore_template": "Go",
+						"license_template":   "mit",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":               "test-repo",
+				"gitignore_template": "go",
+				"license_template":   "MIT",
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "unknown gitignore template suggests close matches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetGitignoreTemplates,
+					[]string{"Go", "Node", "Python"},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":               "test-repo",
+				"gitignore_template": "Python3",
+			},
+			expectError:    true,
+			expectedErrMsg: `unknown gitignore template "Python3"; did you mean: Python`,
+		},
+		{
+			name: "gitignore template is passed on when the listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGitignoreTemplates,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":               "test-repo",
+						"auto_init":          false,
+						"description":        "",
+						"private":            false,
+						"gitignore_template": "Go",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":               "test-repo",
+				"gitignore_template": "Go",
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "license template outside the featured list is passed on",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					expectRequestBody(t, map[string]interface{}{
+						"name":             "test-repo",
+						"auto_init":        false,
+						"description":      "",
+						"private":          false,
+						"license_template": "isc",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRepo),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":             "test-repo",
+				"license_template": "ISC",
+			},
+			expectError:  false,
+			expectedRepo: mockRepo,
+		},
+		{
+			name: "rejected license template points at get_license_template",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Repository creation failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name":             "test-repo",
+				"license_template": "not-a-license",
+			},
+			expectError:    true,
+			expectedErrMsg: `failed to create repository (if license template "not-a-license" is the problem, check its key with get_license_template)`,
+		},
+		{
+			name: "repository creation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.EndpointPattern{
+						Pattern: "/user/repos",
+						Method:  "POST",
+					},
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Repository creation failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "invalid-repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRepository(stubGetClientFn(client), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedRepo github.Repository
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRepo)
+			assert.NoError(t, err)
+
+			// Verify repository details
+			assert.Equal(t, *tc.expectedRepo.Name, *returnedRepo.Name)
+			assert.Equal(t, *tc.expectedRepo.Description, *returnedRepo.Description)
+			assert.Equal(t, *tc.expectedRepo.Private, *returnedRepo.Private)
+			assert.Equal(t, *tc.expectedRepo.HTMLURL, *returnedRepo.HTMLURL)
+			assert.Equal(t, *tc.expectedRepo.Owner.Login, *returnedRepo.Owner.Login)
+		})
+	}
+}
+
+func Test_PushFiles(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := PushFiles(stubGetClientFn(mockClient), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "push_files", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "files")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "files", "message"})
+
+	// Setup mock objects
+	mockRef := &github.Reference{
+		Ref: github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("abc123"),
+			URL: github.Ptr("https://api.github.com/repos/owner/repo/git/trees/abc123"),
+		},
+	}
+
+	mockCommit := &github.Commit{
+		SHA: github.Ptr("abc123"),
+		Tree: &github.Tree{
+			SHA: github.Ptr("def456"),
+		},
+	}
+
+	mockTree := &github.Tree{
+		SHA: github.Ptr("ghi789"),
+	}
+
+	mockNewCommit := &github.Commit{
+		SHA:     github.Ptr("jkl012"),
+		Message: github.Ptr("Update multiple files"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
+	}
+
+	mockUpdatedRef := &github.Reference{
+		Ref: github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("jkl012"),
+			URL: github.Ptr("https://api.github.com/repos/owner/repo/git/trees/jkl012"),
+		},
+	}
+
+	// Creates a blob whose SHA is derived from its content, failing for the given contents
+	mockBlobs := func(failing ...string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var blob github.Blob
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&blob))
+			assert.Equal(t, "base64", blob.GetEncoding())
+			if slices.Contains(failing, blob.GetContent()) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"message": "Server Error"}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(&github.Blob{SHA: github.Ptr("blob-" + blob.GetContent())})
+		}
+	}
+
+	// Define test cases
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRef    *github.Reference
+		expectedErrMsg string
+	}{
+		{
+			name: "successful push of multiple files",
+			mockedClient: mock.NewMockedHTTPClient(
+				// Get branch reference
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				// Get commit
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Create tree
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path":    "README.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "# Updated README\n\nThis is an updated README file.",
+							},
+							map[string]interface{}{
+								"path":    "docs/example.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "# Example\n\nThis is an example file.",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				// Create commit
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update multiple files",
+						"tree":    "ghi789",
+						"parents": []interface{}{"abc123"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockNewCommit),
+					),
+				),
+				// Update reference
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{
+						"sha":   "jkl012",
+						"force": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockUpdatedRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# Updated README\n\nThis is an updated README file.",
+					},
+					map[string]interface{}{
+						"path":    "docs/example.md",
+						"content": "# Example\n\nThis is an example file.",
+					},
+				},
+				"message": "Update multiple files",
+			},
+			expectError: false,
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name: "successful push of mixed creates, deletes and executables",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path":    "scripts/build.sh",
+								"mode":    "100755",
+								"type":    "blob",
+								"content": "#!/bin/sh\nmake\n",
+							},
+							map[string]interface{}{
+								"path":    "docs/new.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "# Moved",
+							},
+							map[string]interface{}{
+								"path": "docs/old.md",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  nil,
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockNewCommit,
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockUpdatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "scripts/build.sh",
+						"content": "#!/bin/sh\nmake\n",
+						"mode":    "100755",
+					},
+					map[string]interface{}{
+						"path":      "docs/new.md",
+						"content":   "# Moved",
+						"operation": "create_or_update",
+					},
+					map[string]interface{}{
+						"path":      "docs/old.md",
+						"operation": "delete",
+					},
+				},
+				"message": "Move docs",
+			},
+			expectError: false,
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name:         "fails when a deleted file has content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":      "docs/old.md",
+						"content":   "leftover",
+						"operation": "delete",
+					},
+				},
+				"message": "Delete file",
+			},
+			expectedErrMsg: "file docs/old.md: a deleted file must not have content",
+		},
+		{
+			name:         "fails when mode is not allowed",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "link",
+						"content": "target",
+						"mode":    "120000",
+					},
+				},
+				"message": "Add link",
+			},
+			expectedErrMsg: "file link: mode must be 100644 or 100755",
+		},
+		{
+			name:         "fails when operation is unknown",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":      "README.md",
+						"operation": "rename",
+					},
+				},
+				"message": "Rename file",
+			},
+			expectedErrMsg: "file README.md: operation must be create_or_update or delete",
+		},
+		{
+			name: "successful push of base64 encoded files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					mockBlobs(),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path": "assets/logo.png",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  "blob-aW1n",
+							},
+							map[string]interface{}{
+								"path":    "README.md",
+								"mode":    "100644",
+								"type":    "blob",
+								"content": "# Logo",
+							},
+							map[string]interface{}{
+								"path": "assets/data.bin",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  "blob-ZGF0YQ==",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockNewCommit,
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockUpdatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":     "assets/logo.png",
+						"content":  "aW1n",
+						"encoding": "base64",
+					},
+					map[string]interface{}{
+						"path":     "README.md",
+						"content":  "# Logo",
+						"encoding": "utf-8",
+					},
+					map[string]interface{}{
+						"path":     "assets/data.bin",
+						"content":  "ZGF0YQ==",
+						"encoding": "base64",
+					},
+				},
+				"message": "Add assets",
+			},
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name: "fails when a blob cannot be created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					mockBlobs("ZGF0YQ=="),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":     "assets/data.bin",
+						"content":  "ZGF0YQ==",
+						"encoding": "base64",
+					},
+				},
+				"message": "Add assets",
+			},
+			expectedErrMsg: "failed to create blobs for assets/data.bin",
+		},
+		{
+			name:         "fails when base64 content is invalid",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":     "assets/logo.png",
+						"content":  "not base64!",
+						"encoding": "base64",
+					},
+				},
+				"message": "Add logo",
+			},
+			expectedErrMsg: "file assets/logo.png: content is not valid base64",
+		},
+		{
+			name:         "fails when files parameter is invalid",
+			mockedClient: mock.NewMockedHTTPClient(
+			// No requests expected
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"files":   "invalid-files-parameter", // Not an array
+				"message": "Update multiple files",
+			},
+			expectError:    false, // This returns a tool error, not a Go error
+			expectedErrMsg: "files parameter must be an array",
+		},
+		{
+			name:         "fails when files contains object without path",
+			mockedClient: mock.NewMockedHTTPClient(
+			// Files are validated before any request
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"content": "# Missing path",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    false, // This returns a tool error, not a Go error
+			expectedErrMsg: "each file must have a path",
+		},
+		{
+			name:         "fails when files contains object without content",
+			mockedClient: mock.NewMockedHTTPClient(
+			// Files are validated before any request
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path": "README.md",
+						// Missing content
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    false, // This returns a tool error, not a Go error
+			expectedErrMsg: "each file must have content",
+		},
+		{
+			name: "fails to get branch reference",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "non-existent-branch",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get branch reference",
+		},
+		{
+			name: "fails to get base commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				// Get branch reference
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				// Fail to get commit
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusNotFound, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get base commit",
+		},
+		{
+			name: "fails to create tree",
+			mockedClient: mock.NewMockedHTTPClient(
+				// Get branch reference
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				// Get commit
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Fail to create tree
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					mockResponse(t, http.StatusInternalServerError, nil),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create tree",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := PushFiles(stubGetClientFn(client), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			if tc.expectedErrMsg != "" {
+				require.NotNil(t, result)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedRef github.Reference
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRef)
+			require.NoError(t, err)
+
+			assert.Equal(t, *tc.expectedRef.Ref, *returnedRef.Ref)
+			assert.Equal(t, *tc.expectedRef.Object.SHA, *returnedRef.Object.SHA)
+		})
+	}
+}
+
+func Test_CommitChangesToNewBranch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CommitChangesToNewBranch(stubGetClientFn(mockClient), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "commit_changes_to_new_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "from_branch")
+	assert.Contains(t, tool.InputSchema.Properties, "files")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "files", "message"})
+
+	mockBaseRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("abc123")},
+	}
+	mockBaseCommit := &github.Commit{
+		SHA:  github.Ptr("abc123"),
+		Tree: &github.Tree{SHA: github.Ptr("def456")},
+	}
+	mockTree := &github.Tree{SHA: github.Ptr("ghi789")}
+	mockNewCommit := &github.Commit{
+		SHA:     github.Ptr("jkl012"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
+	}
+	mockUpdatedRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/feature"),
+		Object: &github.GitObject{SHA: github.Ptr("jkl012")},
+	}
+
+	// getRefs serves the base branch and, when featureSHA is set, an existing feature branch.
+	getRefs := func(featureSHA string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposGitRefByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/heads/main"):
+					mockResponse(t, http.StatusOK, mockBaseRef)(w, r)
+				case featureSHA != "":
+					mockResponse(t, http.StatusOK, &github.Reference{
+						Ref:    github.Ptr("refs/heads/feature"),
+						Object: &github.GitObject{SHA: github.Ptr(featureSHA)},
+					})(w, r)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				}
+			}),
+		)
+	}
+	commitOptions := func() []mock.MockBackendOption {
+		return []mock.MockBackendOption{
+			mock.WithRequestMatch(
+				mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+				mockBaseCommit,
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitTreesByOwnerByRepo,
+				expectRequestBody(t, map[string]interface{}{
+					"base_tree": "def456",
+					"tree": []interface{}{
+						map[string]interface{}{
+							"path":    "README.md",
+							"mode":    "100644",
+							"type":    "blob",
+							"content": "# Updated",
+						},
+					},
+				}).andThen(
+					mockResponse(t, http.StatusCreated, mockTree),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PostReposGitCommitsByOwnerByRepo,
+				expectRequestBody(t, map[string]interface{}{
+					"message": "Update README",
+					"tree":    "ghi789",
+					"parents": []interface{}{"abc123"},
+				}).andThen(
+					mockResponse(t, http.StatusCreated, mockNewCommit),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PatchReposGitRefsByOwnerByRepoByRef,
+				expectPath(t, "/repos/owner/repo/git/refs/heads/feature").andThen(
+					mockResponse(t, http.StatusOK, mockUpdatedRef),
+				),
+			),
+		}
+	}
+	createRef := mock.WithRequestMatchHandler(
+		mock.PostReposGitRefsByOwnerByRepo,
+		expectRequestBody(t, map[string]interface{}{
+			"ref": "refs/heads/feature",
+			"sha": "abc123",
+		}).andThen(
+			mockResponse(t, http.StatusCreated, &github.Reference{
+				Ref:    github.Ptr("refs/heads/feature"),
+				Object: &github.GitObject{SHA: github.Ptr("abc123")},
+			}),
+		),
+	)
+
+	requestArgs := map[string]interface{}{
+		"owner":       "owner",
+		"repo":        "repo",
+		"branch":      "feature",
+		"from_branch": "main",
+		"files": []interface{}{
+			map[string]interface{}{
+				"path":    "README.md",
+				"content": "# Updated",
+			},
+		},
+		"message": "Update README",
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedResult CommitToNewBranchResult
+		expectedErrMsg []string
+	}{
+		{
+			name:         "creates branch and commits files",
+			mockedClient: mock.NewMockedHTTPClient(append([]mock.MockBackendOption{getRefs(""), createRef}, commitOptions()...)...),
+			expectedResult: CommitToNewBranchResult{
+				Branch:        "feature",
+				BaseBranch:    "main",
+				BaseSHA:       "abc123",
+				CommitSHA:     "jkl012",
+				BranchCreated: true,
+				CompareURL:    "https://github.com/owner/repo/compare/main...feature",
+			},
+		},
+		{
+			name: "re-run after failure reuses branch at base",
+			mockedClient: mock.NewMockedHTTPClient(append([]mock.MockBackendOption{
+				getRefs("abc123"),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						t.Error("branch should not be recreated")
+						w.WriteHeader(http.StatusUnprocessableEntity)
+					}),
+				),
+			}, commitOptions()...)...),
+			expectedResult: CommitToNewBranchResult{
+				Branch:        "feature",
+				BaseBranch:    "main",
+				BaseSHA:       "abc123",
+				CommitSHA:     "jkl012",
+				BranchCreated: false,
+				CompareURL:    "https://github.com/owner/repo/compare/main...feature",
+			},
+		},
+		{
+			name: "existing branch with diverged head",
+			mockedClient: mock.NewMockedHTTPClient(
+				getRefs("zzz999"),
+			),
+			expectError:    true,
+			expectedErrMsg: []string{"branch feature already exists at zzz999", "differs from the head of main (abc123)"},
+		},
+		{
+			name: "failure after branch creation reports the branch exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				getRefs(""),
+				createRef,
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockBaseCommit,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: []string{"failed to create tree", "Branch feature exists at abc123", "retry commit_changes_to_new_branch with the same arguments"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CommitChangesToNewBranch(stubGetClientFn(client), NewDefaultBranchGuard(false), translations.NullTranslationHelper)
+
+			request := createMCPRequest(requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				for _, msg := range tc.expectedErrMsg {
+					assert.Contains(t, errorContent.Text, msg)
+				}
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned CommitToNewBranchResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_CommitChangesToNewBranchLooksUpDefaultBranchOnce(t *testing.T) {
+	lookups := 0
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lookups++
+				mockResponse(t, http.StatusOK, &github.Repository{DefaultBranch: github.Ptr("main")})(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitRefByOwnerByRepoByRef,
+			expectPath(t, "/repos/owner/repo/git/ref/heads/main").andThen(
+				mockResponse(t, http.StatusInternalServerError, `{"message": "stop here"}`),
+			),
+		),
+	))
+
+	for _, protect := range []bool{false, true} {
+		lookups = 0
+		_, handler := CommitChangesToNewBranch(stubGetClientFn(client), NewDefaultBranchGuard(protect), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":   "owner",
+			"repo":    "repo",
+			"branch":  "feature",
+			"message": "Update README",
+			"files": []interface{}{
+				map[string]interface{}{"path": "README.md", "content": "# Updated"},
+			},
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to get base branch reference")
+		assert.Equal(t, 1, lookups, "protect=%t", protect)
+	}
+}
+
+func Test_DefaultBranchGuard(t *testing.T) {
+	mockRepo := &github.Repository{
+		Name:          github.Ptr("repo"),
+		DefaultBranch: github.Ptr("main"),
+	}
+
+	mockFileResponse := &github.RepositoryContentResponse{
+		Content: &github.RepositoryContent{
+			Path: github.Ptr("docs/example.md"),
+			SHA:  github.Ptr("abc123def456"),
+		},
+		Commit: github.Commit{
+			SHA: github.Ptr("def456abc789"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		protect        bool
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:    "refuses write to default branch when protected",
+			protect: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Example",
+				"message": "Add example file",
+				"branch":  "main",
+			},
+			expectedErrMsg: "Use create_branch to create a new branch",
+		},
+		{
+			name:    "allows write to other branch when protected",
+			protect: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					mockRepo,
+				),
+				mock.WithRequestMatch(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					mockFileResponse,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Example",
+				"message": "Add example file",
+				"branch":  "feature",
+			},
+		},
+		{
+			name:    "allow_default_branch overrides protection without looking up the repository",
+			protect: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					mockFileResponse,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                "owner",
+				"repo":                 "repo",
+				"path":                 "docs/example.md",
+				"content":              "# Example",
+				"message":              "Add example file",
+				"branch":               "main",
+				"allow_default_branch": true,
+			},
+		},
+		{
+			name:    "writes to default branch pass through when protection is off",
+			protect: false,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					mockFileResponse,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Example",
+				"message": "Add example file",
+				"branch":  "main",
+			},
+		},
+		{
+			name:    "repository lookup failure is reported",
+			protect: true,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "# Example",
+				"message": "Add example file",
+				"branch":  "main",
+			},
+			expectedErrMsg: "failed to get repository default branch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrUpdateFile(stubGetClientFn(client), NewDefaultBranchGuard(tc.protect), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+		})
+	}
+
+	t.Run("push_files refuses write to default branch when protected", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetReposByOwnerByRepo,
+				mockRepo,
+			),
+		))
+		_, handler := PushFiles(stubGetClientFn(client), NewDefaultBranchGuard(true), translations.NullTranslationHelper)
+
+		request := createMCPRequest(map[string]interface{}{
+			"owner":  "owner",
+			"repo":   "repo",
+			"branch": "refs/heads/main",
+			"files": []interface{}{
+				map[string]interface{}{
+					"path":    "README.md",
+					"content": "# README",
+				},
+			},
+			"message": "Update file",
+		})
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+
+		errorContent := getErrorResult(t, result)
+		assert.Contains(t, errorContent.Text, "allow_default_branch")
+	})
+
+	t.Run("default branch is looked up once per repository", func(t *testing.T) {
+		lookups := 0
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					lookups++
+					mockResponse(t, http.StatusOK, mockRepo)(w, r)
+				}),
+			),
+		))
+		guard := NewDefaultBranchGuard(true)
+
+		for i := 0; i < 2; i++ {
+			result := guard.Check(context.Background(), client, "owner", "repo", "main", false)
+			require.NotNil(t, result)
+			require.True(t, result.IsError)
+		}
+		assert.Equal(t, 1, lookups)
+	})
+
+	t.Run("default branch is looked up again once stale or forgotten", func(t *testing.T) {
+		lookups := 0
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					lookups++
+					mockResponse(t, http.StatusOK, mockRepo)(w, r)
+				}),
+			),
+		))
+		guard := NewDefaultBranchGuard(true)
+		now := time.Now()
+		guard.cache.now = func() time.Time { return now }
+
+		check := func() {
+			result := guard.Check(context.Background(), client, "owner", "repo", "main", false)
+			require.NotNil(t, result)
+			require.True(t, result.IsError)
+		}
+
+		check()
+		now = now.Add(defaultBranchCacheTTL - time.Second)
+		check()
+		assert.Equal(t, 1, lookups)
+
+		now = now.Add(time.Second)
+		check()
+		assert.Equal(t, 2, lookups)
+
+		guard.Forget(client, "Owner", "Repo")
+		check()
+		assert.Equal(t, 3, lookups)
+	})
+
+	t.Run("create_repository forgets the default branch of the created repository", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PostUserRepos,
+				mockResponse(t, http.StatusCreated, &github.Repository{
+					Name:  github.Ptr("repo"),
+					Owner: &github.User{Login: github.Ptr("owner")},
+				}),
+			),
+		))
+		guard := NewDefaultBranchGuard(true)
+		guard.cache.entries[defaultBranchCacheKey(client, "owner", "repo")] = guard.cache.order.PushFront(&defaultBranchEntry{
+			key:       defaultBranchCacheKey(client, "owner", "repo"),
+			branch:    "master",
+			fetchedAt: time.Now(),
+		})
+
+		_, handler := CreateRepository(stubGetClientFn(client), guard, translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{"name": "repo"}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Zero(t, guard.cache.order.Len())
+	})
+}
+
+func Test_DefaultBranchCacheIsKeyedByHost(t *testing.T) {
+	newClient := func(host, defaultBranch string) *github.Client {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr(defaultBranch)}),
+		))
+		client.BaseURL = &url.URL{Scheme: "https", Host: host, Path: "/"}
+		return client
+	}
+	cache := newDefaultBranchCache()
+
+	branch, _, err := cache.get(context.Background(), newClient("ghes-one.example.com", "main"), "owner", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch)
+
+	// The same repository name on another host is looked up rather than answered from the cache
+	branch, _, err = cache.get(context.Background(), newClient("ghes-two.example.com", "trunk"), "owner", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "trunk", branch)
+	assert.Equal(t, 2, cache.order.Len())
+}
+
+func Test_DefaultBranchCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mockResponse(t, http.StatusOK, &github.Repository{DefaultBranch: github.Ptr("main")})(w, r)
+			}),
+		),
+	))
+	cache := newDefaultBranchCache()
+
+	for i := 0; i <= defaultBranchCacheSize; i++ {
+		_, _, err := cache.get(context.Background(), client, "owner", fmt.Sprintf("repo%d", i))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, defaultBranchCacheSize, cache.order.Len())
+	assert.NotContains(t, cache.entries, defaultBranchCacheKey(client, "owner", "repo0"))
+	assert.Contains(t, cache.entries, defaultBranchCacheKey(client, "owner", fmt.Sprintf("repo%d", defaultBranchCacheSize)))
+}
+
+func Test_ListBranches(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_branches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "protected")
+	assert.Contains(t, tool.InputSchema.Properties, "pattern")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Setup mock branches for success case
+	mockBranches := []*github.Branch{
+		{
+			Name:   github.Ptr("main"),
+			Commit: &github.RepositoryCommit{SHA: github.Ptr("abc123")},
+		},
+		{
+			Name:   github.Ptr("develop"),
+			Commit: &github.RepositoryCommit{SHA: github.Ptr("def456")},
+		},
+	}
+
+	// Test cases
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		mockResponses []mock.MockBackendOption
+		wantErr       bool
+		errContains   string
+	}{
+		{
+			name: "success",
+			args: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"page":  float64(2),
+			},
+			mockResponses: []mock.MockBackendOption{
+				mock.WithRequestMatch(
+					mock.GetReposBranchesByOwnerByRepo,
+					mockBranches,
+				),
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing owner",
+			args: map[string]interface{}{
+				"repo": "repo",
+			},
+			mockResponses: []mock.MockBackendOption{},
+			wantErr:       false,
+			errContains:   "missing required parameter: owner",
+		},
+		{
+			name: "missing repo",
+			args: map[string]interface{}{
+				"owner": "owner",
+			},
+			mockResponses: []mock.MockBackendOption{},
+			wantErr:       false,
+			errContains:   "missing required parameter: repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock client
+			mockClient := github.NewClient(mock.NewMockedHTTPClient(tt.mockResponses...))
+			_, handler := ListBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+			// Create request
+			request := createMCPRequest(tt.args)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.errContains != "" {
+					assert.Contains(t, err.Error(), tt.errContains)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			if tt.errContains != "" {
+				textContent := getTextResult(t, result)
+				assert.Contains(t, textContent.Text, tt.errContains)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			require.NotEmpty(t, textContent.Text)
+
+			// Verify response
+			var branches []*github.Branch
+			err = json.Unmarshal([]byte(textContent.Text), &branches)
+			require.NoError(t, err)
+			assert.Len(t, branches, 2)
+			assert.Equal(t, "main", *branches[0].Name)
+			assert.Equal(t, "develop", *branches[1].Name)
+		})
+	}
+}
+
+func Test_ListBranchesDivergence(t *testing.T) {
+	lastCommit := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	branchNames := []string{"main", "feature-a", "feature-b", "broken", "feature-c", "feature-d", "feature-e", "feature-f"}
+	var branches []*github.Branch
+	for _, name := range branchNames {
+		branches = append(branches, &github.Branch{Name: github.Ptr(name), Commit: &github.RepositoryCommit{SHA: github.Ptr(name + "-sha")}})
+	}
+
+	var calls, inFlight, maxInFlight atomic.Int32
+	compareHandler := func(expectedHead string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				peak := maxInFlight.Load()
+				if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			base, head, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/compare/"), "...")
+			assert.Equal(t, expectedHead, head)
+			if base == "broken" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			mockResponse(t, http.StatusOK, &github.CommitsComparison{
+				AheadBy:  github.Ptr(3),
+				BehindBy: github.Ptr(1),
+				BaseCommit: &github.RepositoryCommit{
+					Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: lastCommit}}},
+				},
+			}).ServeHTTP(w, r)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		args          map[string]interface{}
+		mockResponses []mock.MockBackendOption
+	}{
+		{
+			name: "compares with default branch and caps page size",
+			args: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"include_divergence": true,
+			},
+			mockResponses: []mock.MockBackendOption{
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"page": "1", "per_page": "20"}).andThen(
+						mockResponse(t, http.StatusOK, branches),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					compareHandler("main"),
+				),
+			},
+		},
+		{
+			name: "compares with explicit branch and caps larger page size",
+			args: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"include_divergence": true,
+				"compare_to":         "release",
+				"perPage":            float64(100),
+			},
+			mockResponses: []mock.MockBackendOption{
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"page": "1", "per_page": "20"}).andThen(
+						mockResponse(t, http.StatusOK, branches),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					compareHandler("release"),
+				),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls.Store(0)
+			maxInFlight.Store(0)
+			client := github.NewClient(mock.NewMockedHTTPClient(tc.mockResponses...))
+			_, handler := ListBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			assert.Equal(t, int32(len(branchNames)), calls.Load())
+			assert.Greater(t, maxInFlight.Load(), int32(1))
+			assert.LessOrEqual(t, maxInFlight.Load(), int32(branchDivergenceConcurrency))
+
+			var returned []map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned, len(branchNames))
+			for i, branch := range returned {
+				assert.Equal(t, branchNames[i], branch["name"])
+				assert.Contains(t, branch, "commit")
+				if branchNames[i] == "broken" {
+					assert.Nil(t, branch["ahead_by"])
+					assert.Nil(t, branch["behind_by"])
+					assert.Nil(t, branch["last_commit_date"])
+					continue
+				}
+				assert.Equal(t, float64(1), branch["ahead_by"])
+				assert.Equal(t, float64(3), branch["behind_by"])
+				assert.Equal(t, "2024-06-01T09:00:00Z", branch["last_commit_date"])
+			}
+		})
+	}
+}
+
+func Test_ListBranchesPattern(t *testing.T) {
+	branch := func(name string, protected bool) *github.Branch {
+		return &github.Branch{Name: github.Ptr(name), Protected: github.Ptr(protected), Commit: &github.RepositoryCommit{SHA: github.Ptr(name + "-sha")}}
+	}
+	// pagedBranches serves pages of branches by the page query parameter, linking to the next page
+	// until the last one, and counts the pages requested.
+	pagedBranches := func(t *testing.T, calls *atomic.Int32, pages [][]*github.Branch) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			page, err := strconv.Atoi(r.URL.Query().Get("page"))
+			require.NoError(t, err)
+			require.LessOrEqual(t, page, len(pages))
+			if page < len(pages) {
+				w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com/repos/owner/repo/branches?page=%d&per_page=2>; rel="next"`, page+1))
+			}
+			_ = json.NewEncoder(w).Encode(pages[page-1])
+		}
+	}
+
+	tests := []struct {
+		name           string
+		pages          [][]*github.Branch
+		args           map[string]interface{}
+		expectedCalls  int32
+		expectedResult MatchingBranchesResult
+		expectedErrMsg string
+	}{
+		{
+			name:  "matches a glob within a path segment",
+			pages: [][]*github.Branch{{branch("release/1.0", true), branch("release/1.0/hotfix", false), branch("main", true)}},
+			args: map[string]interface{}{
+				"pattern": "release/*",
+				"perPage": float64(3),
+			},
+			expectedCalls: 1,
+			expectedResult: MatchingBranchesResult{
+				Branches: []MatchingBranch{{Name: "release/1.0", SHA: "release/1.0-sha", Protected: true}},
+			},
+		},
+		{
+			name:  "matches character classes and single characters",
+			pages: [][]*github.Branch{{branch("v1", false), branch("v2", false), branch("v10", false), branch("va", false)}},
+			args: map[string]interface{}{
+				"pattern": "v[0-9]?",
+				"perPage": float64(4),
+			},
+			expectedCalls: 1,
+			expectedResult: MatchingBranchesResult{
+				Branches: []MatchingBranch{{Name: "v10", SHA: "v10-sha"}},
+			},
+		},
+		{
+			name: "scans pages until perPage matches are found",
+			pages: [][]*github.Branch{
+				{branch("main", true), branch("release/1.0", true)},
+				{branch("feature", false), branch("develop", false)},
+				{branch("release/2.0", true), branch("release/3.0", false)},
+				{branch("release/4.0", false), branch("other", false)},
+			},
+			args: map[string]interface{}{
+				"pattern": "release/*",
+				"perPage": float64(2),
+			},
+			expectedCalls: 3,
+			expectedResult: MatchingBranchesResult{
+				Branches: []MatchingBranch{
+					{Name: "release/1.0", SHA: "release/1.0-sha", Protected: true},
+					{Name: "release/2.0", SHA: "release/2.0-sha", Protected: true},
+					{Name: "release/3.0", SHA: "release/3.0-sha"},
+				},
+				NextPageInfo: NextPageInfo{HasNextPage: true, NextPage: 4},
+			},
+		},
+		{
+			name: "stops when the branches run out",
+			pages: [][]*github.Branch{
+				{branch("main", true), branch("release/1.0", true)},
+				{branch("feature", false)},
+			},
+			args: map[string]interface{}{
+				"pattern": "release/*",
+				"perPage": float64(2),
+			},
+			expectedCalls: 2,
+			expectedResult: MatchingBranchesResult{
+				Branches: []MatchingBranch{{Name: "release/1.0", SHA: "release/1.0-sha", Protected: true}},
+			},
+		},
+		{
+			name: "stops after max pages",
+			pages: func() [][]*github.Branch {
+				var pages [][]*github.Branch
+				for i := 0; i < 2*maxBranchPatternPages; i++ {
+					pages = append(pages, []*github.Branch{branch(fmt.Sprintf("feature-%d", i), false)})
+				}
+				return pages
+			}(),
+			args: map[string]interface{}{
+				"pattern": "release/*",
+				"page":    float64(3),
+				"perPage": float64(1),
+			},
+			expectedCalls: maxBranchPatternPages,
+			expectedResult: MatchingBranchesResult{
+				Branches:     []MatchingBranch{},
+				NextPageInfo: NextPageInfo{HasNextPage: true, NextPage: 3 + maxBranchPatternPages},
+			},
+		},
+		{
+			name: "invalid pattern",
+			args: map[string]interface{}{
+				"pattern": "release/[",
+			},
+			expectedErrMsg: `invalid pattern "release/["`,
+		},
+		{
+			name: "pattern with divergence",
+			args: map[string]interface{}{
+				"pattern":            "release/*",
+				"include_divergence": true,
+			},
+			expectedErrMsg: "pattern cannot be combined with include_divergence",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesByOwnerByRepo,
+					pagedBranches(t, &calls, tc.pages),
+				),
+			))
+			_, handler := ListBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			maps.Copy(args, tc.args)
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				assert.Zero(t, calls.Load())
+				return
+			}
+
+			var returned MatchingBranchesResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+			assert.Equal(t, tc.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func Test_ListBranchesProtected(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposBranchesByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"protected": "false",
+				"page":      "1",
+				"per_page":  "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.Branch{{Name: github.Ptr("feature"), Protected: github.Ptr(false)}}),
+			),
+		),
+	))
+	_, handler := ListBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":     "owner",
+		"repo":      "repo",
+		"protected": false,
+	}))
+	require.NoError(t, err)
+
+	var branches []*github.Branch
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &branches))
+	require.Len(t, branches, 1)
+	assert.Equal(t, "feature", branches[0].GetName())
+}
+
+func Test_GetBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_branch_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	mockProtection := &github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict: true,
+			Checks: &[]*github.RequiredStatusCheck{{Context: "build"}, {Context: "test"}},
+		},
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 2,
+			RequireCodeOwnerReviews:      true,
+		},
+		EnforceAdmins:    &github.AdminEnforcement{Enabled: true},
+		AllowForcePushes: &github.AllowForcePushes{Enabled: false},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectedSummary *BranchProtectionSummary
+		expectedText    string
+		expectedErrMsg  string
+	}{
+		{
+			name: "protected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					expectPath(t, "/repos/owner/repo/branches/main/protection").andThen(
+						mockResponse(t, http.StatusOK, mockProtection),
+					),
+				),
+			),
+			expectedSummary: &BranchProtectionSummary{
+				Branch:                       "main",
+				RequiredStatusChecks:         []string{"build", "test"},
+				StrictStatusChecks:           true,
+				RequiredApprovingReviewCount: 2,
+				RequireCodeOwnerReviews:      true,
+				EnforceAdmins:                true,
+				AllowForcePushes:             false,
+			},
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+				),
+			),
+			expectedText: "Branch protection is not configured for branch main of owner/repo.",
+		},
+		{
+			name: "branch not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get branch protection",
+		},
+		{
+			name: "permission denied",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "Must have admin rights to Repository.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			})
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+
+			var summary BranchProtectionSummary
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &summary))
+			assert.Equal(t, *tc.expectedSummary, summary)
+		})
+	}
+}
+
+func Test_UpdateBranchProtection(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateBranchProtection(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_branch_protection", tool.Name)
+	assert.Contains(t, tool.Description, "replaces the entire protection configuration")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "required_status_checks")
+	assert.Contains(t, tool.InputSchema.Properties, "required_approving_review_count")
+	assert.Contains(t, tool.InputSchema.Properties, "dismiss_stale_reviews")
+	assert.Contains(t, tool.InputSchema.Properties, "enforce_admins")
+	assert.Contains(t, tool.InputSchema.Properties, "restrictions")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	mockProtection := &github.Protection{
+		RequiredStatusChecks: &github.RequiredStatusChecks{Strict: true, Contexts: &[]string{"build"}},
+		EnforceAdmins:        &github.AdminEnforcement{Enabled: true},
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedBody   map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "every setting given",
+			requestArgs: map[string]interface{}{
+				"required_status_checks":          map[string]any{"strict": true, "contexts": []any{"build", "test"}},
+				"required_approving_review_count": float64(2),
+				"dismiss_stale_reviews":           true,
+				"enforce_admins":                  true,
+				"restrictions":                    map[string]any{"users": []any{"octocat"}, "teams": []any{"core"}},
+			},
+			expectedBody: map[string]any{
+				"required_status_checks": map[string]any{"strict": true, "contexts": []any{"build", "test"}},
+				"required_pull_request_reviews": map[string]any{
+					"required_approving_review_count": float64(2),
+					"dismiss_stale_reviews":           true,
+					"require_code_owner_reviews":      false,
+				},
+				"enforce_admins": true,
+				"restrictions":   map[string]any{"users": []any{"octocat"}, "teams": []any{"core"}, "apps": []any{}},
+			},
+		},
+		{
+			name:        "settings left out are sent as null",
+			requestArgs: map[string]interface{}{},
+			expectedBody: map[string]any{
+				"required_status_checks":        nil,
+				"required_pull_request_reviews": nil,
+				"enforce_admins":                false,
+				"restrictions":                  nil,
+			},
+		},
+		{
+			name: "empty lists are sent as empty arrays",
+			requestArgs: map[string]interface{}{
+				"required_status_checks": map[string]any{"strict": false},
+				"restrictions":           map[string]any{},
+			},
+			expectedBody: map[string]any{
+				"required_status_checks":        map[string]any{"strict": false, "contexts": []any{}},
+				"required_pull_request_reviews": nil,
+				"enforce_admins":                false,
+				"restrictions":                  map[string]any{"users": []any{}, "teams": []any{}, "apps": []any{}},
+			},
+		},
+		{
+			name: "restrictions must be an object",
+			requestArgs: map[string]interface{}{
+				"restrictions": []any{"octocat"},
+			},
+			expectError:    true,
+			expectedErrMsg: "parameter restrictions is not of type object",
+		},
+		{
+			name: "contexts must be strings",
+			requestArgs: map[string]interface{}{
+				"required_status_checks": map[string]any{"contexts": []any{float64(1)}},
+			},
+			expectError:    true,
+			expectedErrMsg: "required_status_checks: parameter contexts is not of type string",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var mockedClient *http.Client
+			if tc.expectedBody != nil {
+				mockedClient = mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(
+						mock.PutReposBranchesProtectionByOwnerByRepoByBranch,
+						expectRequestBody(t, tc.expectedBody).andThen(
+							mockResponse(t, http.StatusOK, mockProtection),
+						),
+					),
+				)
+			} else {
+				mockedClient = mock.NewMockedHTTPClient()
 			}
+			client := github.NewClient(mockedClient)
+			_, handler := UpdateBranchProtection(stubGetClientFn(client), translations.NullTranslationHelper)
 
+			args := map[string]interface{}{"owner": "owner", "repo": "repo", "branch": "main"}
+			maps.Copy(args, tc.requestArgs)
+			result, err := handler(context.Background(), createMCPRequest(args))
 			require.NoError(t, err)
-			require.False(t, result.IsError)
 
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
-
-			// Unmarshal and verify the result
-			var returnedRepo github.Repository
-			err = json.Unmarshal([]byte(textContent.Text), &returnedRepo)
-			assert.NoError(t, err)
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
 
-			// Verify repository details
-			assert.Equal(t, *tc.expectedRepo.Name, *returnedRepo.Name)
-			assert.Equal(t, *tc.expectedRepo.Description, *returnedRepo.Description)
-			assert.Equal(t, *tc.expectedRepo.Private, *returnedRepo.Private)
-			assert.Equal(t, *tc.expectedRepo.HTMLURL, *returnedRepo.HTMLURL)
-			assert.Equal(t, *tc.expectedRepo.Owner.Login, *returnedRepo.Owner.Login)
+			textContent := getTextResult(t, result)
+			var summary BranchProtectionSummary
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &summary))
+			assert.Equal(t, []string{"build"}, summary.RequiredStatusChecks)
+			assert.True(t, summary.EnforceAdmins)
 		})
 	}
 }
 
-func Test_PushFiles(t *testing.T) {
+func Test_UpdateBranchProtectionIsNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		repos, err := tsg.GetToolset("repos")
+		require.NoError(t, err)
+
+		var names []string
+		for _, st := range repos.GetAvailableTools() {
+			names = append(names, st.Tool.Name)
+		}
+		if readOnly {
+			assert.NotContains(t, names, "update_branch_protection")
+		} else {
+			assert.Contains(t, names, "update_branch_protection")
+		}
+	}
+}
+
+func Test_DeleteFile(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := PushFiles(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := DeleteFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "push_files", tool.Name)
+	assert.Equal(t, "delete_file", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "branch")
-	assert.Contains(t, tool.InputSchema.Properties, "files")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
 	assert.Contains(t, tool.InputSchema.Properties, "message")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "files", "message"})
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	// SHA is no longer required since we're using Git Data API
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "message", "branch"})
 
-	// Setup mock objects
+	// Setup mock objects for Git Data API
 	mockRef := &github.Reference{
 		Ref: github.Ptr("refs/heads/main"),
 		Object: &github.GitObject{
 			SHA: github.Ptr("abc123"),
-			URL: github.Ptr("https://api.github.com/repos/owner/repo/git/trees/abc123"),
 		},
 	}
 
@@ -1243,29 +5038,20 @@ func Test_PushFiles(t *testing.T) {
 
 	mockNewCommit := &github.Commit{
 		SHA:     github.Ptr("jkl012"),
-		Message: github.Ptr("Update multiple files"),
+		Message: github.Ptr("Delete example file"),
 		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
 	}
 
-	mockUpdatedRef := &github.Reference{
-		Ref: github.Ptr("refs/heads/main"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("jkl012"),
-			URL: github.Ptr("https://api.github.com/repos/owner/repo/git/trees/jkl012"),
-		},
-	}
-
-	// Define test cases
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedRef    *github.Reference
-		expectedErrMsg string
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedCommitSHA string
+		expectedErrMsg    string
 	}{
 		{
-			name: "successful push of multiple files",
+			name: "successful file deletion using Git Data API",
 			mockedClient: mock.NewMockedHTTPClient(
 				// Get branch reference
 				mock.WithRequestMatch(
@@ -1284,16 +5070,10 @@ func Test_PushFiles(t *testing.T) {
 						"base_tree": "def456",
 						"tree": []interface{}{
 							map[string]interface{}{
-								"path":    "README.md",
-								"mode":    "100644",
-								"type":    "blob",
-								"content": "# Updated README\n\nThis is an updated README file.",
-							},
-							map[string]interface{}{
-								"path":    "docs/example.md",
-								"mode":    "100644",
-								"type":    "blob",
-								"content": "# Example\n\nThis is an example file.",
+								"path": "docs/example.md",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  nil,
 							},
 						},
 					}).andThen(
@@ -1304,7 +5084,7 @@ func Test_PushFiles(t *testing.T) {
 				mock.WithRequestMatchHandler(
 					mock.PostReposGitCommitsByOwnerByRepo,
 					expectRequestBody(t, map[string]interface{}{
-						"message": "Update multiple files",
+						"message": "Delete example file",
 						"tree":    "ghi789",
 						"parents": []interface{}{"abc123"},
 					}).andThen(
@@ -1318,186 +5098,319 @@ func Test_PushFiles(t *testing.T) {
 						"sha":   "jkl012",
 						"force": false,
 					}).andThen(
-						mockResponse(t, http.StatusOK, mockUpdatedRef),
+						mockResponse(t, http.StatusOK, &github.Reference{
+							Ref: github.Ptr("refs/heads/main"),
+							Object: &github.GitObject{
+								SHA: github.Ptr("jkl012"),
+							},
+						}),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "main",
-				"files": []interface{}{
-					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# Updated README\n\nThis is an updated README file.",
-					},
-					map[string]interface{}{
-						"path":    "docs/example.md",
-						"content": "# Example\n\nThis is an example file.",
-					},
-				},
-				"message": "Update multiple files",
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"message": "Delete example file",
+				"branch":  "main",
 			},
-			expectError: false,
-			expectedRef: mockUpdatedRef,
+			expectError:       false,
+			expectedCommitSHA: "jkl012",
 		},
 		{
-			name:         "fails when files parameter is invalid",
+			name: "file deletion fails - branch not found",
 			mockedClient: mock.NewMockedHTTPClient(
-			// No requests expected
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Reference not found"}`))
+					}),
+				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner":   "owner",
 				"repo":    "repo",
-				"branch":  "main",
-				"files":   "invalid-files-parameter", // Not an array
-				"message": "Update multiple files",
+				"path":    "docs/nonexistent.md",
+				"message": "Delete nonexistent file",
+				"branch":  "nonexistent-branch",
 			},
-			expectError:    false, // This returns a tool error, not a Go error
-			expectedErrMsg: "files parameter must be an array",
+			expectError:    true,
+			expectedErrMsg: "failed to get branch reference",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteFile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var response DeleteFileResult
+			err = json.Unmarshal([]byte(textContent.Text), &response)
+			require.NoError(t, err)
+
+			// Verify the response contains the expected commit
+			require.NotNil(t, response.Commit)
+			assert.Equal(t, tc.expectedCommitSHA, response.Commit.GetSHA())
+			assert.Nil(t, response.Content)
+		})
+	}
+}
+
+func Test_ListTags(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTags(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_tags", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	// Setup mock tags for success case
+	mockTags := []*github.RepositoryTag{
+		{
+			Name: github.Ptr("v1.0.0"),
+			Commit: &github.Commit{
+				SHA: github.Ptr("v1.0.0-tag-sha"),
+				URL: github.Ptr("https://api.github.com/repos/owner/repo/commits/abc123"),
+			},
+			ZipballURL: github.Ptr("https://github.com/owner/repo/zipball/v1.0.0"),
+			TarballURL: github.Ptr("https://github.com/owner/repo/tarball/v1.0.0"),
+		},
+		{
+			Name: github.Ptr("v0.9.0"),
+			Commit: &github.Commit{
+				SHA: github.Ptr("v0.9.0-tag-sha"),
+				URL: github.Ptr("https://api.github.com/repos/owner/repo/commits/def456"),
+			},
+			ZipballURL: github.Ptr("https://github.com/owner/repo/zipball/v0.9.0"),
+			TarballURL: github.Ptr("https://github.com/owner/repo/tarball/v0.9.0"),
 		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedTags   []*github.RepositoryTag
+		expectedErrMsg string
+	}{
 		{
-			name: "fails when files contains object without path",
+			name: "successful tags list",
 			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Get commit
-				mock.WithRequestMatch(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockCommit,
+				mock.WithRequestMatchHandler(
+					mock.GetReposTagsByOwnerByRepo,
+					expectPath(
+						t,
+						"/repos/owner/repo/tags",
+					).andThen(
+						mockResponse(t, http.StatusOK, mockTags),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "main",
-				"files": []interface{}{
-					map[string]interface{}{
-						"content": "# Missing path",
-					},
-				},
-				"message": "Update file",
+				"owner": "owner",
+				"repo":  "repo",
 			},
-			expectError:    false, // This returns a tool error, not a Go error
-			expectedErrMsg: "each file must have a path",
+			expectError:  false,
+			expectedTags: mockTags,
 		},
 		{
-			name: "fails when files contains object without content",
+			name: "list tags fails",
 			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Get commit
-				mock.WithRequestMatch(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockCommit,
+				mock.WithRequestMatchHandler(
+					mock.GetReposTagsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "main",
-				"files": []interface{}{
-					map[string]interface{}{
-						"path": "README.md",
-						// Missing content
-					},
-				},
-				"message": "Update file",
+				"owner": "owner",
+				"repo":  "repo",
 			},
-			expectError:    false, // This returns a tool error, not a Go error
-			expectedErrMsg: "each file must have content",
+			expectError:    true,
+			expectedErrMsg: "failed to list tags",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTags(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.NoError(t, err)
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Parse and verify the result
+			var returnedTags []*github.RepositoryTag
+			err = json.Unmarshal([]byte(textContent.Text), &returnedTags)
+			require.NoError(t, err)
+
+			// Verify each tag
+			require.Equal(t, len(tc.expectedTags), len(returnedTags))
+			for i, expectedTag := range tc.expectedTags {
+				assert.Equal(t, *expectedTag.Name, *returnedTags[i].Name)
+				assert.Equal(t, *expectedTag.Commit.SHA, *returnedTags[i].Commit.SHA)
+			}
+		})
+	}
+}
+
+func Test_GetTag(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_tag", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "tag")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag"})
+
+	mockTagRef := &github.Reference{
+		Ref: github.Ptr("refs/tags/v1.0.0"),
+		Object: &github.GitObject{
+			SHA: github.Ptr("v1.0.0-tag-sha"),
+		},
+	}
+
+	mockTagObj := &github.Tag{
+		SHA:     github.Ptr("v1.0.0-tag-sha"),
+		Tag:     github.Ptr("v1.0.0"),
+		Message: github.Ptr("Release v1.0.0"),
+		Object: &github.GitObject{
+			Type: github.Ptr("commit"),
+			SHA:  github.Ptr("abc123"),
 		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedTag    *github.Tag
+		expectedErrMsg string
+	}{
 		{
-			name: "fails to get branch reference",
+			name: "successful tag retrieval",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockResponse(t, http.StatusNotFound, nil),
+					expectPath(
+						t,
+						"/repos/owner/repo/git/ref/tags/v1.0.0",
+					).andThen(
+						mockResponse(t, http.StatusOK, mockTagRef),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTagsByOwnerByRepoByTagSha,
+					expectPath(
+						t,
+						"/repos/owner/repo/git/tags/v1.0.0-tag-sha",
+					).andThen(
+						mockResponse(t, http.StatusOK, mockTagObj),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "non-existent-branch",
-				"files": []interface{}{
-					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# README",
-					},
-				},
-				"message": "Update file",
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to get branch reference",
+			expectError: false,
+			expectedTag: mockTagObj,
 		},
 		{
-			name: "fails to get base commit",
+			name: "tag reference not found",
 			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Fail to get commit
 				mock.WithRequestMatchHandler(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockResponse(t, http.StatusNotFound, nil),
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Reference does not exist"}`))
+					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "main",
-				"files": []interface{}{
-					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# README",
-					},
-				},
-				"message": "Update file",
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to get base commit",
+			expectedErrMsg: "failed to get tag reference",
 		},
 		{
-			name: "fails to create tree",
+			name: "tag object not found",
 			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
 				mock.WithRequestMatch(
 					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Get commit
-				mock.WithRequestMatch(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockCommit,
+					mockTagRef,
 				),
-				// Fail to create tree
 				mock.WithRequestMatchHandler(
-					mock.PostReposGitTreesByOwnerByRepo,
-					mockResponse(t, http.StatusInternalServerError, nil),
+					mock.GetReposGitTagsByOwnerByRepoByTagSha,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Tag object does not exist"}`))
+					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner":  "owner",
-				"repo":   "repo",
-				"branch": "main",
-				"files": []interface{}{
-					map[string]interface{}{
-						"path":    "README.md",
-						"content": "# README",
-					},
-				},
-				"message": "Update file",
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.0.0",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to create tree",
+			expectedErrMsg: "failed to get tag object",
 		},
 	}
 
@@ -1505,7 +5418,7 @@ func Test_PushFiles(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := PushFiles(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetTag(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -1522,352 +5435,267 @@ func Test_PushFiles(t *testing.T) {
 				return
 			}
 
-			if tc.expectedErrMsg != "" {
-				require.NotNil(t, result)
-				require.True(t, result.IsError)
-				errorContent := getErrorResult(t, result)
-				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
-				return
-			}
-
 			require.NoError(t, err)
 			require.False(t, result.IsError)
 
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			// Unmarshal and verify the result
-			var returnedRef github.Reference
-			err = json.Unmarshal([]byte(textContent.Text), &returnedRef)
+			// Parse and verify the result
+			var returnedTag github.Tag
+			err = json.Unmarshal([]byte(textContent.Text), &returnedTag)
 			require.NoError(t, err)
 
-			assert.Equal(t, *tc.expectedRef.Ref, *returnedRef.Ref)
-			assert.Equal(t, *tc.expectedRef.Object.SHA, *returnedRef.Object.SHA)
+			assert.Equal(t, *tc.expectedTag.SHA, *returnedTag.SHA)
+			assert.Equal(t, *tc.expectedTag.Tag, *returnedTag.Tag)
+			assert.Equal(t, *tc.expectedTag.Message, *returnedTag.Message)
+			assert.Equal(t, *tc.expectedTag.Object.Type, *returnedTag.Object.Type)
+			assert.Equal(t, *tc.expectedTag.Object.SHA, *returnedTag.Object.SHA)
 		})
 	}
 }
 
-func Test_ListBranches(t *testing.T) {
+func Test_CreateTag(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := ListBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := CreateTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "list_branches", tool.Name)
+	assert.Equal(t, "create_tag", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "page")
-	assert.Contains(t, tool.InputSchema.Properties, "perPage")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.Contains(t, tool.InputSchema.Properties, "tagger_name")
+	assert.Contains(t, tool.InputSchema.Properties, "tagger_email")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag", "message", "sha"})
 
-	// Setup mock branches for success case
-	mockBranches := []*github.Branch{
-		{
-			Name:   github.Ptr("main"),
-			Commit: &github.RepositoryCommit{SHA: github.Ptr("abc123")},
-		},
-		{
-			Name:   github.Ptr("develop"),
-			Commit: &github.RepositoryCommit{SHA: github.Ptr("def456")},
-		},
+	mockTagObj := &github.Tag{
+		SHA:     github.Ptr("tag-object-sha"),
+		Tag:     github.Ptr("v1.2.0"),
+		Message: github.Ptr("Release v1.2.0"),
+		Object:  &github.GitObject{Type: github.Ptr("commit"), SHA: github.Ptr("commit-sha")},
 	}
 
-	// Test cases
 	tests := []struct {
-		name          string
-		args          map[string]interface{}
-		mockResponses []mock.MockBackendOption
-		wantErr       bool
-		errContains   string
+		name           string
+		requestArgs    map[string]interface{}
+		tagBody        map[string]any
+		refResponse    http.HandlerFunc
+		expectedCalls  []string
+		expectError    bool
+		expectedErrMsg string
 	}{
 		{
-			name: "success",
-			args: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
-				"page":  float64(2),
+			name: "creates the tag object, then the ref pointing at it",
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"tag":          "v1.2.0",
+				"message":      "Release v1.2.0",
+				"sha":          "commit-sha",
+				"tagger_name":  "Release Bot",
+				"tagger_email": "release-bot@example.com",
 			},
-			mockResponses: []mock.MockBackendOption{
-				mock.WithRequestMatch(
-					mock.GetReposBranchesByOwnerByRepo,
-					mockBranches,
-				),
+			tagBody: map[string]any{
+				"tag":     "v1.2.0",
+				"message": "Release v1.2.0",
+				"object":  "commit-sha",
+				"type":    "commit",
+				"tagger": map[string]any{
+					"name":  "Release Bot",
+					"email": "release-bot@example.com",
+				},
 			},
-			wantErr: false,
+			refResponse: mockResponse(t, http.StatusCreated, &github.Reference{
+				Ref:    github.Ptr("refs/tags/v1.2.0"),
+				Object: &github.GitObject{SHA: github.Ptr("tag-object-sha")},
+			}),
+			expectedCalls: []string{"tag", "ref"},
 		},
 		{
-			name: "missing owner",
-			args: map[string]interface{}{
-				"repo": "repo",
+			name: "tag already exists",
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"tag":     "v1.2.0",
+				"message": "Release v1.2.0",
+				"sha":     "commit-sha",
 			},
-			mockResponses: []mock.MockBackendOption{},
-			wantErr:       false,
-			errContains:   "missing required parameter: owner",
+			tagBody: map[string]any{
+				"tag":     "v1.2.0",
+				"message": "Release v1.2.0",
+				"object":  "commit-sha",
+				"type":    "commit",
+			},
+			refResponse:    mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Reference already exists"}`),
+			expectedCalls:  []string{"tag", "ref"},
+			expectError:    true,
+			expectedErrMsg: "failed to create tag v1.2.0: the ref refs/tags/v1.2.0 already exists in owner/repo",
 		},
 		{
-			name: "missing repo",
-			args: map[string]interface{}{
-				"owner": "owner",
+			name: "tagger name without email",
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"tag":         "v1.2.0",
+				"message":     "Release v1.2.0",
+				"sha":         "commit-sha",
+				"tagger_name": "Release Bot",
 			},
-			mockResponses: []mock.MockBackendOption{},
-			wantErr:       false,
-			errContains:   "missing required parameter: repo",
+			expectError:    true,
+			expectedErrMsg: "tagger_name and tagger_email must be given together",
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Create mock client
-			mockClient := github.NewClient(mock.NewMockedHTTPClient(tt.mockResponses...))
-			_, handler := ListBranches(stubGetClientFn(mockClient), translations.NullTranslationHelper)
-
-			// Create request
-			request := createMCPRequest(tt.args)
-
-			// Call handler
-			result, err := handler(context.Background(), request)
-			if tt.wantErr {
-				require.Error(t, err)
-				if tt.errContains != "" {
-					assert.Contains(t, err.Error(), tt.errContains)
-				}
-				return
-			}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTagsByOwnerByRepo,
+					expectRequestBody(t, tc.tagBody).andThen(
+						func(w http.ResponseWriter, r *http.Request) {
+							calls = append(calls, "tag")
+							mockResponse(t, http.StatusCreated, mockTagObj).ServeHTTP(w, r)
+						},
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"ref": "refs/tags/v1.2.0",
+						"sha": "tag-object-sha",
+					}).andThen(
+						func(w http.ResponseWriter, r *http.Request) {
+							calls = append(calls, "ref")
+							tc.refResponse.ServeHTTP(w, r)
+						},
+					),
+				),
+			))
+			_, handler := CreateTag(stubGetClientFn(client), translations.NullTranslationHelper)
 
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
 			require.NoError(t, err)
-			require.NotNil(t, result)
+			assert.Equal(t, tc.expectedCalls, calls)
 
-			if tt.errContains != "" {
-				textContent := getTextResult(t, result)
-				assert.Contains(t, textContent.Text, tt.errContains)
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			textContent := getTextResult(t, result)
-			require.NotEmpty(t, textContent.Text)
-
-			// Verify response
-			var branches []*github.Branch
-			err = json.Unmarshal([]byte(textContent.Text), &branches)
-			require.NoError(t, err)
-			assert.Len(t, branches, 2)
-			assert.Equal(t, "main", *branches[0].Name)
-			assert.Equal(t, "develop", *branches[1].Name)
+			var returnedTag github.Tag
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedTag))
+			assert.Equal(t, *mockTagObj, returnedTag)
 		})
 	}
 }
 
-func Test_DeleteFile(t *testing.T) {
+func Test_DeleteTag(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := DeleteFile(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := DeleteTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "delete_file", tool.Name)
+	assert.Equal(t, "delete_tag", tool.Name)
 	assert.NotEmpty(t, tool.Description)
-	assert.Contains(t, tool.InputSchema.Properties, "owner")
-	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "path")
-	assert.Contains(t, tool.InputSchema.Properties, "message")
-	assert.Contains(t, tool.InputSchema.Properties, "branch")
-	// SHA is no longer required since we're using Git Data API
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "message", "branch"})
-
-	// Setup mock objects for Git Data API
-	mockRef := &github.Reference{
-		Ref: github.Ptr("refs/heads/main"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("abc123"),
-		},
-	}
-
-	mockCommit := &github.Commit{
-		SHA: github.Ptr("abc123"),
-		Tree: &github.Tree{
-			SHA: github.Ptr("def456"),
-		},
-	}
-
-	mockTree := &github.Tree{
-		SHA: github.Ptr("ghi789"),
-	}
-
-	mockNewCommit := &github.Commit{
-		SHA:     github.Ptr("jkl012"),
-		Message: github.Ptr("Delete example file"),
-		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/jkl012"),
-	}
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag"})
 
 	tests := []struct {
-		name              string
-		mockedClient      *http.Client
-		requestArgs       map[string]interface{}
-		expectError       bool
-		expectedCommitSHA string
-		expectedErrMsg    string
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
 	}{
 		{
-			name: "successful file deletion using Git Data API",
+			name: "deletes the tag ref",
 			mockedClient: mock.NewMockedHTTPClient(
-				// Get branch reference
-				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockRef,
-				),
-				// Get commit
-				mock.WithRequestMatch(
-					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
-					mockCommit,
-				),
-				// Create tree
-				mock.WithRequestMatchHandler(
-					mock.PostReposGitTreesByOwnerByRepo,
-					expectRequestBody(t, map[string]interface{}{
-						"base_tree": "def456",
-						"tree": []interface{}{
-							map[string]interface{}{
-								"path": "docs/example.md",
-								"mode": "100644",
-								"type": "blob",
-								"sha":  nil,
-							},
-						},
-					}).andThen(
-						mockResponse(t, http.StatusCreated, mockTree),
-					),
-				),
-				// Create commit
-				mock.WithRequestMatchHandler(
-					mock.PostReposGitCommitsByOwnerByRepo,
-					expectRequestBody(t, map[string]interface{}{
-						"message": "Delete example file",
-						"tree":    "ghi789",
-						"parents": []interface{}{"abc123"},
-					}).andThen(
-						mockResponse(t, http.StatusCreated, mockNewCommit),
-					),
-				),
-				// Update reference
 				mock.WithRequestMatchHandler(
-					mock.PatchReposGitRefsByOwnerByRepoByRef,
-					expectRequestBody(t, map[string]interface{}{
-						"sha":   "jkl012",
-						"force": false,
-					}).andThen(
-						mockResponse(t, http.StatusOK, &github.Reference{
-							Ref: github.Ptr("refs/heads/main"),
-							Object: &github.GitObject{
-								SHA: github.Ptr("jkl012"),
-							},
-						}),
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/refs/tags/v1.2.0").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
 					),
 				),
 			),
-			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/example.md",
-				"message": "Delete example file",
-				"branch":  "main",
-			},
-			expectError:       false,
-			expectedCommitSHA: "jkl012",
+			expectedText: "Tag v1.2.0 deleted from owner/repo",
 		},
 		{
-			name: "file deletion fails - branch not found",
+			name: "tag not found",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Reference not found"}`))
-					}),
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Reference does not exist"}`),
 				),
 			),
-			requestArgs: map[string]interface{}{
-				"owner":   "owner",
-				"repo":    "repo",
-				"path":    "docs/nonexistent.md",
-				"message": "Delete nonexistent file",
-				"branch":  "nonexistent-branch",
-			},
 			expectError:    true,
-			expectedErrMsg: "failed to get branch reference",
+			expectedErrMsg: "failed to delete tag",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := DeleteFile(stubGetClientFn(client), translations.NullTranslationHelper)
-
-			// Create call request
-			request := createMCPRequest(tc.requestArgs)
-
-			// Call handler
-			result, err := handler(context.Background(), request)
-
-			// Verify results
-			if tc.expectError {
-				require.Error(t, err)
-				assert.Contains(t, err.Error(), tc.expectedErrMsg)
-				return
-			}
+			_, handler := DeleteTag(stubGetClientFn(client), translations.NullTranslationHelper)
 
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"tag":   "v1.2.0",
+			}))
 			require.NoError(t, err)
 
-			// Parse the result and get the text content if no error
-			textContent := getTextResult(t, result)
-
-			// Unmarshal and verify the result
-			var response map[string]interface{}
-			err = json.Unmarshal([]byte(textContent.Text), &response)
-			require.NoError(t, err)
-
-			// Verify the response contains the expected commit
-			commit, ok := response["commit"].(map[string]interface{})
-			require.True(t, ok)
-			commitSHA, ok := commit["sha"].(string)
-			require.True(t, ok)
-			assert.Equal(t, tc.expectedCommitSHA, commitSHA)
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
 		})
 	}
 }
 
-func Test_ListTags(t *testing.T) {
+func Test_TagWriteToolsAreNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		repos, err := tsg.GetToolset("repos")
+		require.NoError(t, err)
+
+		var names []string
+		for _, st := range repos.GetAvailableTools() {
+			names = append(names, st.Tool.Name)
+		}
+		for _, name := range []string{"create_tag", "delete_tag"} {
+			if readOnly {
+				assert.NotContains(t, names, name)
+			} else {
+				assert.Contains(t, names, name)
+			}
+		}
+	}
+}
+
+func Test_GenerateReleaseNotesPreview(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := ListTags(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GenerateReleaseNotesPreview(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "list_tags", tool.Name)
+	assert.Equal(t, "generate_release_notes_preview", tool.Name)
 	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "generate_release_notes_preview tool should be read-only")
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
-
-	// Setup mock tags for success case
-	mockTags := []*github.RepositoryTag{
-		{
-			Name: github.Ptr("v1.0.0"),
-			Commit: &github.Commit{
-				SHA: github.Ptr("v1.0.0-tag-sha"),
-				URL: github.Ptr("https://api.github.com/repos/owner/repo/commits/abc123"),
-			},
-			ZipballURL: github.Ptr("https://github.com/owner/repo/zipball/v1.0.0"),
-			TarballURL: github.Ptr("https://github.com/owner/repo/tarball/v1.0.0"),
-		},
-		{
-			Name: github.Ptr("v0.9.0"),
-			Commit: &github.Commit{
-				SHA: github.Ptr("v0.9.0-tag-sha"),
-				URL: github.Ptr("https://api.github.com/repos/owner/repo/commits/def456"),
-			},
-			ZipballURL: github.Ptr("https://github.com/owner/repo/zipball/v0.9.0"),
-			TarballURL: github.Ptr("https://github.com/owner/repo/tarball/v0.9.0"),
-		},
+	assert.Contains(t, tool.InputSchema.Properties, "tag_name")
+	assert.Contains(t, tool.InputSchema.Properties, "previous_tag_name")
+	assert.Contains(t, tool.InputSchema.Properties, "target_commitish")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag_name"})
+
+	mockNotes := &github.RepositoryReleaseNotes{
+		Name: "v1.1.0",
+		Body: "## What's Changed\n* Fix bug by @testuser in #1",
 	}
 
 	tests := []struct {
@@ -1875,46 +5703,91 @@ func Test_ListTags(t *testing.T) {
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedTags   []*github.RepositoryTag
+		expectedNotes  *github.RepositoryReleaseNotes
 		expectedErrMsg string
 	}{
 		{
-			name: "successful tags list",
+			name: "successful generation with all options",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposTagsByOwnerByRepo,
-					expectPath(
-						t,
-						"/repos/owner/repo/tags",
-					).andThen(
-						mockResponse(t, http.StatusOK, mockTags),
+					mock.PostReposReleasesGenerateNotesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"tag_name":          "v1.1.0",
+						"previous_tag_name": "v1.0.0",
+						"target_commitish":  "main",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockNotes),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
+				"owner":             "owner",
+				"repo":              "repo",
+				"tag_name":          "v1.1.0",
+				"previous_tag_name": "v1.0.0",
+				"target_commitish":  "main",
 			},
-			expectError:  false,
-			expectedTags: mockTags,
+			expectError:   false,
+			expectedNotes: mockNotes,
 		},
 		{
-			name: "list tags fails",
+			name: "successful generation with only a tag",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposTagsByOwnerByRepo,
+					mock.PostReposReleasesGenerateNotesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"tag_name": "v1.1.0",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockNotes),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"tag_name": "v1.1.0",
+			},
+			expectError:   false,
+			expectedNotes: mockNotes,
+		},
+		{
+			name: "target commitish not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesGenerateNotesByOwnerByRepo,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusInternalServerError)
-						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
 					}),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
+				"owner":            "owner",
+				"repo":             "repo",
+				"tag_name":         "v1.1.0",
+				"target_commitish": "missing-branch",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to list tags",
+			expectedErrMsg: "The tag itself can be new, but target_commitish",
+		},
+		{
+			name: "generation fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesGenerateNotesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"tag_name": "v1.1.0",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to generate release notes",
 		},
 	}
 
@@ -1922,153 +5795,257 @@ func Test_ListTags(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ListTags(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GenerateReleaseNotesPreview(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
 
 			// Call handler
 			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
 
 			// Verify results
 			if tc.expectError {
-				require.NoError(t, err)
 				require.True(t, result.IsError)
 				errorContent := getErrorResult(t, result)
 				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
 			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			// Parse and verify the result
-			var returnedTags []*github.RepositoryTag
-			err = json.Unmarshal([]byte(textContent.Text), &returnedTags)
-			require.NoError(t, err)
-
-			// Verify each tag
-			require.Equal(t, len(tc.expectedTags), len(returnedTags))
-			for i, expectedTag := range tc.expectedTags {
-				assert.Equal(t, *expectedTag.Name, *returnedTags[i].Name)
-				assert.Equal(t, *expectedTag.Commit.SHA, *returnedTags[i].Commit.SHA)
-			}
+			var returnedNotes github.RepositoryReleaseNotes
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedNotes))
+			assert.Equal(t, tc.expectedNotes.Name, returnedNotes.Name)
+			assert.Equal(t, tc.expectedNotes.Body, returnedNotes.Body)
 		})
 	}
 }
 
-func Test_GetTag(t *testing.T) {
+func Test_GetRepositoryActivitySummary(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := GetTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetRepositoryActivitySummary(stubGetClientFn(mockClient), translations.NullTranslationHelper)
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
-	assert.Equal(t, "get_tag", tool.Name)
+	assert.Equal(t, "get_repository_activity_summary", tool.Name)
 	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint, "get_repository_activity_summary tool should be read-only")
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
-	assert.Contains(t, tool.InputSchema.Properties, "tag")
-	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "tag"})
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "since"})
 
-	mockTagRef := &github.Reference{
-		Ref: github.Ptr("refs/tags/v1.0.0"),
-		Object: &github.GitObject{
-			SHA: github.Ptr("v1.0.0-tag-sha"),
-		},
-	}
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	after := since.Add(24 * time.Hour)
+	before := since.Add(-24 * time.Hour)
 
-	mockTagObj := &github.Tag{
-		SHA:     github.Ptr("v1.0.0-tag-sha"),
-		Tag:     github.Ptr("v1.0.0"),
-		Message: github.Ptr("Release v1.0.0"),
-		Object: &github.GitObject{
-			Type: github.Ptr("commit"),
-			SHA:  github.Ptr("abc123"),
+	mockCommits := []*github.RepositoryCommit{
+		{
+			SHA:     github.Ptr("abc123"),
+			HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
+			Commit: &github.Commit{
+				Message: github.Ptr("Fix bug\n\nLonger description"),
+				Author:  &github.CommitAuthor{Name: github.Ptr("Test User"), Date: &github.Timestamp{Time: after}},
+			},
+			Author: &github.User{Login: github.Ptr("testuser")},
 		},
 	}
+	mockPullRequests := []*github.PullRequest{
+		{Number: github.Ptr(1), Title: github.Ptr("Merged after"), MergedAt: &github.Timestamp{Time: after}},
+		{Number: github.Ptr(2), Title: github.Ptr("Merged before"), MergedAt: &github.Timestamp{Time: before}},
+		{Number: github.Ptr(3), Title: github.Ptr("Closed without merging")},
+	}
+	mockIssues := []*github.Issue{
+		{Number: github.Ptr(10), Title: github.Ptr("Opened"), CreatedAt: &github.Timestamp{Time: after}},
+		{Number: github.Ptr(11), Title: github.Ptr("Closed"), CreatedAt: &github.Timestamp{Time: before}, ClosedAt: &github.Timestamp{Time: after}},
+		{Number: github.Ptr(12), Title: github.Ptr("A pull request"), CreatedAt: &github.Timestamp{Time: after}, PullRequestLinks: &github.PullRequestLinks{}},
+	}
+	mockReleases := []*github.RepositoryRelease{
+		{TagName: github.Ptr("v1.0.0"), PublishedAt: &github.Timestamp{Time: after}},
+		{TagName: github.Ptr("v0.9.0"), PublishedAt: &github.Timestamp{Time: before}},
+	}
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedTag    *github.Tag
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedSummary func(t *testing.T, summary RepositoryActivitySummary)
 	}{
 		{
-			name: "successful tag retrieval",
+			name: "successful activity summary",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					expectPath(
-						t,
-						"/repos/owner/repo/git/ref/tags/v1.0.0",
-					).andThen(
-						mockResponse(t, http.StatusOK, mockTagRef),
+					mock.GetReposCommitsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"since":    "2024-01-01T00:00:00Z",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockCommits),
 					),
 				),
 				mock.WithRequestMatchHandler(
-					mock.GetReposGitTagsByOwnerByRepoByTagSha,
-					expectPath(
-						t,
-						"/repos/owner/repo/git/tags/v1.0.0-tag-sha",
-					).andThen(
-						mockResponse(t, http.StatusOK, mockTagObj),
+					mock.GetReposPullsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "closed",
+						"sort":      "updated",
+						"direction": "desc",
+						"per_page":  "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockPullRequests),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "all",
+						"since":     "2024-01-01T00:00:00Z",
+						"sort":      "updated",
+						"direction": "desc",
+						"per_page":  "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockIssues),
 					),
 				),
+				mock.WithRequestMatch(
+					mock.GetReposReleasesByOwnerByRepo,
+					mockReleases,
+				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner": "owner",
 				"repo":  "repo",
-				"tag":   "v1.0.0",
+				"since": "2024-01-01T00:00:00Z",
+			},
+			expectedSummary: func(t *testing.T, summary RepositoryActivitySummary) {
+				assert.Equal(t, 1, summary.Commits.Count)
+				assert.Equal(t, "Fix bug", summary.Commits.Items[0].Title)
+				assert.Equal(t, "testuser", summary.Commits.Items[0].Author)
+				assert.Equal(t, 1, summary.MergedPullRequests.Count)
+				assert.Equal(t, 1, summary.MergedPullRequests.Items[0].Number)
+				assert.Equal(t, 1, summary.OpenedIssues.Count)
+				assert.Equal(t, 10, summary.OpenedIssues.Items[0].Number)
+				assert.Equal(t, 1, summary.ClosedIssues.Count)
+				assert.Equal(t, 11, summary.ClosedIssues.Items[0].Number)
+				assert.Equal(t, 1, summary.Releases.Count)
+				assert.Equal(t, "v1.0.0", summary.Releases.Items[0].Title)
 			},
-			expectError: false,
-			expectedTag: mockTagObj,
 		},
 		{
-			name: "tag reference not found",
+			name: "failing category degrades to a note",
 			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepo,
+					mockCommits,
+				),
 				mock.WithRequestMatchHandler(
-					mock.GetReposGitRefByOwnerByRepoByRef,
+					mock.GetReposPullsByOwnerByRepo,
 					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Reference does not exist"}`))
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible"}`))
 					}),
 				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepo,
+					mockIssues,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposReleasesByOwnerByRepo,
+					mockReleases,
+				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner": "owner",
 				"repo":  "repo",
-				"tag":   "v1.0.0",
+				"since": "2024-01-01T00:00:00Z",
+			},
+			expectedSummary: func(t *testing.T, summary RepositoryActivitySummary) {
+				assert.Equal(t, 1, summary.Commits.Count)
+				assert.Empty(t, summary.Commits.Note)
+				assert.Zero(t, summary.MergedPullRequests.Count)
+				assert.Contains(t, summary.MergedPullRequests.Note, "failed to list pull requests")
+				assert.Contains(t, summary.MergedPullRequests.Note, "Resource not accessible")
+				assert.Equal(t, 1, summary.OpenedIssues.Count)
+				assert.Equal(t, 1, summary.Releases.Count)
 			},
-			expectError:    true,
-			expectedErrMsg: "failed to get tag reference",
 		},
 		{
-			name: "tag object not found",
+			name: "full pages of closed pull requests and releases before since are not truncated",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatch(
-					mock.GetReposGitRefByOwnerByRepoByRef,
-					mockTagRef,
+					mock.GetReposCommitsByOwnerByRepo,
+					[]*github.RepositoryCommit{},
 				),
-				mock.WithRequestMatchHandler(
-					mock.GetReposGitTagsByOwnerByRepoByTagSha,
-					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-						w.WriteHeader(http.StatusNotFound)
-						_, _ = w.Write([]byte(`{"message": "Tag object does not exist"}`))
-					}),
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepo,
+					fullPullRequestPage(after, before),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepo,
+					[]*github.Issue{},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposReleasesByOwnerByRepo,
+					fullReleasePage(after, before),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner": "owner",
 				"repo":  "repo",
-				"tag":   "v1.0.0",
+				"since": "2024-01-01T00:00:00Z",
+			},
+			expectedSummary: func(t *testing.T, summary RepositoryActivitySummary) {
+				assert.Equal(t, 1, summary.MergedPullRequests.Count)
+				assert.False(t, summary.MergedPullRequests.Truncated)
+				assert.Equal(t, 1, summary.Releases.Count)
+				assert.False(t, summary.Releases.Truncated)
+			},
+		},
+		{
+			name: "full pages of closed pull requests and releases after since are truncated",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposCommitsByOwnerByRepo,
+					[]*github.RepositoryCommit{},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepo,
+					fullPullRequestPage(after, after),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepo,
+					[]*github.Issue{},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposReleasesByOwnerByRepo,
+					fullReleasePage(after, after),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "2024-01-01T00:00:00Z",
+			},
+			expectedSummary: func(t *testing.T, summary RepositoryActivitySummary) {
+				assert.Equal(t, activityPageSize, summary.MergedPullRequests.Count)
+				assert.True(t, summary.MergedPullRequests.Truncated)
+				assert.Equal(t, activityPageSize, summary.Releases.Count)
+				assert.True(t, summary.Releases.Truncated)
+			},
+		},
+		{
+			name:         "invalid since",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"since": "yesterday",
 			},
 			expectError:    true,
-			expectedErrMsg: "failed to get tag object",
+			expectedErrMsg: "invalid since time format, should be RFC3339/ISO8601",
 		},
 	}
 
@@ -2076,43 +6053,130 @@ func Test_GetTag(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetTag(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetRepositoryActivitySummary(stubGetClientFn(client), translations.NullTranslationHelper)
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
 
 			// Call handler
 			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
 
 			// Verify results
 			if tc.expectError {
-				require.NoError(t, err)
 				require.True(t, result.IsError)
 				errorContent := getErrorResult(t, result)
 				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
 				return
 			}
 
-			require.NoError(t, err)
 			require.False(t, result.IsError)
-
-			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			// Parse and verify the result
-			var returnedTag github.Tag
-			err = json.Unmarshal([]byte(textContent.Text), &returnedTag)
-			require.NoError(t, err)
-
-			assert.Equal(t, *tc.expectedTag.SHA, *returnedTag.SHA)
-			assert.Equal(t, *tc.expectedTag.Tag, *returnedTag.Tag)
-			assert.Equal(t, *tc.expectedTag.Message, *returnedTag.Message)
-			assert.Equal(t, *tc.expectedTag.Object.Type, *returnedTag.Object.Type)
-			assert.Equal(t, *tc.expectedTag.Object.SHA, *returnedTag.Object.SHA)
+			var summary RepositoryActivitySummary
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &summary))
+			assert.Equal(t, "owner", summary.Owner)
+			assert.Equal(t, "repo", summary.Repo)
+			assert.True(t, since.Equal(summary.Since))
+			tc.expectedSummary(t, summary)
 		})
 	}
 }
 
+// fullPullRequestPage returns a full activity page of merged pull requests, most recently updated
+// first. The first was merged and updated at newest, and all others at oldest.
+func fullPullRequestPage(newest, oldest time.Time) []*github.PullRequest {
+	pullRequests := make([]*github.PullRequest, activityPageSize)
+	for i := range pullRequests {
+		date := oldest
+		if i == 0 {
+			date = newest
+		}
+		pullRequests[i] = &github.PullRequest{
+			Number:    github.Ptr(i + 1),
+			MergedAt:  &github.Timestamp{Time: date},
+			UpdatedAt: &github.Timestamp{Time: date},
+		}
+	}
+	return pullRequests
+}
+
+// fullReleasePage returns a full activity page of published releases, most recently created first.
+// The first was created and published at newest, and all others at oldest.
+func fullReleasePage(newest, oldest time.Time) []*github.RepositoryRelease {
+	releases := make([]*github.RepositoryRelease, activityPageSize)
+	for i := range releases {
+		date := oldest
+		if i == 0 {
+			date = newest
+		}
+		releases[i] = &github.RepositoryRelease{
+			TagName:     github.Ptr(fmt.Sprintf("v%d.0.0", activityPageSize-i)),
+			CreatedAt:   &github.Timestamp{Time: date},
+			PublishedAt: &github.Timestamp{Time: date},
+		}
+	}
+	return releases
+}
+
+func Test_summarizeRepositoryActivity(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("keeps the most recent items and reports the full count", func(t *testing.T) {
+		var pullRequests []*github.PullRequest
+		for i := 1; i <= activityTopItems+2; i++ {
+			pullRequests = append(pullRequests, &github.PullRequest{
+				Number:   github.Ptr(i),
+				MergedAt: &github.Timestamp{Time: since.Add(time.Duration(i) * time.Hour)},
+			})
+		}
+
+		summary := summarizeRepositoryActivity("owner", "repo", since, repositoryActivity{pullRequests: pullRequests})
+
+		assert.Equal(t, activityTopItems+2, summary.MergedPullRequests.Count)
+		require.Len(t, summary.MergedPullRequests.Items, activityTopItems)
+		assert.Equal(t, activityTopItems+2, summary.MergedPullRequests.Items[0].Number, "most recently merged should be first")
+		assert.False(t, summary.MergedPullRequests.Truncated)
+	})
+
+	t.Run("marks a full page as truncated", func(t *testing.T) {
+		commits := make([]*github.RepositoryCommit, activityPageSize)
+		for i := range commits {
+			commits[i] = &github.RepositoryCommit{SHA: github.Ptr(fmt.Sprintf("sha%d", i))}
+		}
+
+		summary := summarizeRepositoryActivity("owner", "repo", since, repositoryActivity{commits: commits})
+
+		assert.Equal(t, activityPageSize, summary.Commits.Count)
+		assert.True(t, summary.Commits.Truncated)
+	})
+
+	t.Run("excludes drafts and activity before since", func(t *testing.T) {
+		summary := summarizeRepositoryActivity("owner", "repo", since, repositoryActivity{
+			releases: []*github.RepositoryRelease{
+				{TagName: github.Ptr("draft"), Draft: github.Ptr(true), PublishedAt: &github.Timestamp{Time: since.Add(time.Hour)}},
+				{TagName: github.Ptr("old"), PublishedAt: &github.Timestamp{Time: since.Add(-time.Hour)}},
+				{TagName: github.Ptr("v1.0.0"), Name: github.Ptr("First release"), PublishedAt: &github.Timestamp{Time: since}},
+			},
+		})
+
+		assert.Equal(t, 1, summary.Releases.Count)
+		assert.Equal(t, "First release", summary.Releases.Items[0].Title)
+	})
+
+	t.Run("errors become notes", func(t *testing.T) {
+		summary := summarizeRepositoryActivity("owner", "repo", since, repositoryActivity{
+			issuesErr: errors.New("boom"),
+		})
+
+		assert.Equal(t, "failed to list issues: boom", summary.OpenedIssues.Note)
+		assert.Equal(t, "failed to list issues: boom", summary.ClosedIssues.Note)
+		assert.Empty(t, summary.OpenedIssues.Items)
+		assert.Empty(t, summary.Commits.Note)
+		assert.NotNil(t, summary.Commits.Items)
+	})
+}
+
 func Test_filterPaths(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -2,21 +2,57 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 
-	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// MinimalRepository is the output type of a search_repositories match.
+type MinimalRepository struct {
+	FullName        string `json:"full_name"`
+	Description     string `json:"description,omitempty"`
+	StargazersCount int    `json:"stargazers_count"`
+	Language        string `json:"language,omitempty"`
+	License         string `json:"license,omitempty"`
+	Archived        bool   `json:"archived"`
+	HTMLURL         string `json:"html_url"`
+}
+
+type MinimalSearchRepositoriesResult struct {
+	TotalCount        int                 `json:"total_count"`
+	IncompleteResults bool                `json:"incomplete_results"`
+	Items             []MinimalRepository `json:"items"`
+}
+
+// minimalRepositoriesSearchResult trims a repository search result to what helps choose a repository.
+// Archived repositories are kept, and flagged.
+func minimalRepositoriesSearchResult(result *github.RepositoriesSearchResult) MinimalSearchRepositoriesResult {
+	minimal := MinimalSearchRepositoriesResult{
+		TotalCount:        result.GetTotal(),
+		IncompleteResults: result.GetIncompleteResults(),
+		Items:             make([]MinimalRepository, 0, len(result.Repositories)),
+	}
+	for _, repo := range result.Repositories {
+		minimal.Items = append(minimal.Items, MinimalRepository{
+			FullName:        repo.GetFullName(),
+			Description:     repo.GetDescription(),
+			StargazersCount: repo.GetStargazersCount(),
+			Language:        repo.GetLanguage(),
+			License:         repo.GetLicense().GetSPDXID(),
+			Archived:        repo.GetArchived(),
+			HTMLURL:         repo.GetHTMLURL(),
+		})
+	}
+	return minimal
+}
+
 // SearchRepositories creates a tool to search for GitHub repositories.
 func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_repositories",
-			mcp.WithDescription(t("TOOL_SEARCH_REPOSITORIES_DESCRIPTION", "Search for GitHub repositories")),
+			mcp.WithDescription(t("TOOL_SEARCH_REPOSITORIES_DESCRIPTION", "Search for GitHub repositories. Each match lists the full name, description, stars, language, license SPDX id, archived flag and URL of the repository")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_SEARCH_REPOSITORIES_USER_TITLE", "Search repositories"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -25,6 +61,14 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("Search query"),
 			),
+			mcp.WithString("sort",
+				mcp.Description("Sort field, defaults to best match"),
+				mcp.Enum("stars", "forks", "updated"),
+			),
+			mcp.WithString("order",
+				mcp.Description("Sort order"),
+				mcp.Enum("asc", "desc"),
+			),
 			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -32,12 +76,23 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			query, warnings := checkSearchQuery(searchTypeRepositories, query)
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			order, err := OptionalParam[string](request, "order")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			opts := &github.SearchOptions{
+				Sort:  sort,
+				Order: order,
 				ListOptions: github.ListOptions{
 					Page:    pagination.Page,
 					PerPage: pagination.PerPage,
@@ -49,36 +104,57 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 			result, resp, err := client.Search.Repositories(ctx, query, opts)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to search repositories with query '%s'", query),
-					resp,
-					err,
-				), nil
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to search repositories with query '%s'", query), resp, err); !ok {
+				return result, err
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to search repositories: %s", string(body))), nil
-			}
+			return withSearchQueryWarnings(MarshalledTextResult(minimalRepositoriesSearchResult(result)), query, warnings), nil
+		}
+}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+// MinimalCodeResult is the output type of a search_code match: the file and the fragments of it
+// that matched, which carry the surrounding lines.
+type MinimalCodeResult struct {
+	Repo      string   `json:"repo"`
+	Path      string   `json:"path"`
+	HTMLURL   string   `json:"html_url"`
+	Fragments []string `json:"fragments,omitempty"`
+}
+
+type MinimalSearchCodeResult struct {
+	TotalCount        int                 `json:"total_count"`
+	IncompleteResults bool                `json:"incomplete_results"`
+	Items             []MinimalCodeResult `json:"items"`
+}
 
-			return mcp.NewToolResultText(string(r)), nil
+// minimalCodeSearchResult trims a code search result to the matched files and their content fragments.
+func minimalCodeSearchResult(result *github.CodeSearchResult) MinimalSearchCodeResult {
+	minimal := MinimalSearchCodeResult{
+		TotalCount:        result.GetTotal(),
+		IncompleteResults: result.GetIncompleteResults(),
+		Items:             make([]MinimalCodeResult, 0, len(result.CodeResults)),
+	}
+	for _, code := range result.CodeResults {
+		item := MinimalCodeResult{
+			Repo:    code.GetRepository().GetFullName(),
+			Path:    code.GetPath(),
+			HTMLURL: code.GetHTMLURL(),
 		}
+		for _, match := range code.TextMatches {
+			// Matches on the path add nothing to the path itself
+			if match.GetProperty() == "content" && match.GetFragment() != "" {
+				item.Fragments = append(item.Fragments, match.GetFragment())
+			}
+		}
+		minimal.Items = append(minimal.Items, item)
+	}
+	return minimal
 }
 
 // SearchCode creates a tool to search for code across GitHub repositories.
 func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_code",
-			mcp.WithDescription(t("TOOL_SEARCH_CODE_DESCRIPTION", "Search for code across GitHub repositories")),
+			mcp.WithDescription(t("TOOL_SEARCH_CODE_DESCRIPTION", "Search for code across GitHub repositories. Each match lists the repository, path and URL of the file, with the fragments of it that matched and their surrounding lines")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_SEARCH_CODE_USER_TITLE", "Search code"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -87,6 +163,12 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Required(),
 				mcp.Description("Search query using GitHub code search syntax"),
 			),
+			mcp.WithString("owner",
+				mcp.Description("Optional repository owner. If provided with repo, only code in this repository is searched; if provided alone, only code in the repositories of this user or organization is searched."),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Optional repository name. Requires owner; only code in this repository is searched."),
+			),
 			mcp.WithString("sort",
 				mcp.Description("Sort field ('indexed' only)"),
 			),
@@ -101,6 +183,23 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			query, warnings := checkSearchQuery(searchTypeCode, query)
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch {
+			case owner != "" && repo != "":
+				query = fmt.Sprintf("repo:%s/%s %s", owner, repo, query)
+			case owner != "":
+				query = fmt.Sprintf("user:%s %s", owner, query)
+			case repo != "":
+				return mcp.NewToolResultError("repo requires owner"), nil
+			}
 			sort, err := OptionalParam[string](request, "sort")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -117,6 +216,8 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			opts := &github.SearchOptions{
 				Sort:  sort,
 				Order: order,
+				// Asks for the matched fragments of each file with the text-match media type
+				TextMatch: true,
 				ListOptions: github.ListOptions{
 					PerPage: pagination.PerPage,
 					Page:    pagination.Page,
@@ -129,29 +230,11 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			}
 
 			result, resp, err := client.Search.Code(ctx, query, opts)
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to search code with query '%s'", query),
-					resp,
-					err,
-				), nil
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to search code: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to search code with query '%s'", query), resp, err); !ok {
+				return result, err
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return withSearchQueryWarnings(MarshalledTextResult(minimalCodeSearchResult(result)), query, warnings), nil
 		}
 }
 
@@ -205,21 +288,8 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 
 		searchQuery := "type:" + accountType + " " + query
 		result, resp, err := client.Search.Users(ctx, searchQuery, opts)
-		if err != nil {
-			return ghErrors.NewGitHubAPIErrorResponse(ctx,
-				fmt.Sprintf("failed to search %ss with query '%s'", accountType, query),
-				resp,
-				err,
-			), nil
-		}
-		defer func() { _ = resp.Body.Close() }()
-
-		if resp.StatusCode != 200 {
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read response body: %w", err)
-			}
-			return mcp.NewToolResultError(fmt.Sprintf("failed to search %ss: %s", accountType, string(body))), nil
+		if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to search %ss with query '%s'", accountType, query), resp, err); !ok {
+			return result, err
 		}
 
 		minimalUsers := make([]MinimalUser, 0, len(result.Users))
@@ -247,11 +317,7 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) server.ToolHand
 			minimalResp.IncompleteResults = *result.IncompleteResults
 		}
 
-		r, err := json.Marshal(minimalResp)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal response: %w", err)
-		}
-		return mcp.NewToolResultText(string(r)), nil
+		return MarshalledTextResult(minimalResp), nil
 	}
 }
 
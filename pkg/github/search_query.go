@@ -0,0 +1,239 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Search types checked by checkSearchQuery. "issue" and "pr" match the types searchHandler scopes to.
+const (
+	searchTypeIssue        = "issue"
+	searchTypePullRequest  = "pr"
+	searchTypeCode         = "code"
+	searchTypeRepositories = "repositories"
+)
+
+// issueSearchQualifiers are the qualifiers of the issues and pull requests search.
+var issueSearchQualifiers = qualifierSet(
+	"type", "is", "in", "user", "org", "repo", "author", "assignee", "mentions", "commenter", "involves",
+	"team", "state", "reason", "label", "milestone", "project", "status", "head", "base", "language",
+	"comments", "interactions", "reactions", "draft", "review", "reviewed-by", "review-requested",
+	"user-review-requested", "team-review-requested", "created", "updated", "closed", "merged", "archived",
+	"no", "linked", "sort", "parent-issue", "sub-issue",
+)
+
+var searchQualifiers = map[string]map[string]bool{
+	searchTypeIssue:       issueSearchQualifiers,
+	searchTypePullRequest: issueSearchQualifiers,
+	searchTypeCode: qualifierSet(
+		"in", "user", "org", "repo", "path", "language", "size", "filename", "extension", "fork", "is",
+	),
+	searchTypeRepositories: qualifierSet(
+		"in", "user", "org", "repo", "size", "followers", "forks", "stars", "created", "pushed", "language",
+		"topic", "topics", "license", "is", "archived", "mirror", "template", "good-first-issues",
+		"help-wanted-issues", "sort", "fork",
+	),
+}
+
+// searchQualifierAliases maps qualifiers that models commonly write to the ones GitHub understands.
+var searchQualifierAliases = map[string]string{
+	"lang":         "language",
+	"repository":   "repo",
+	"organization": "org",
+	"labels":       "label",
+	"assignees":    "assignee",
+	"ext":          "extension",
+	"file":         "filename",
+	"star":         "stars",
+}
+
+func qualifierSet(qualifiers ...string) map[string]bool {
+	set := make(map[string]bool, len(qualifiers))
+	for _, qualifier := range qualifiers {
+		set[qualifier] = true
+	}
+	return set
+}
+
+var qualifierKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// allSearchQualifiers holds the qualifiers of every search type, so that a qualifier used with the
+// wrong search type is still recognised as one.
+var allSearchQualifiers = func() map[string]bool {
+	all := map[string]bool{}
+	for _, qualifiers := range searchQualifiers {
+		for qualifier := range qualifiers {
+			all[qualifier] = true
+		}
+	}
+	return all
+}()
+
+// isSearchQualifier reports whether key, in any case, is a qualifier of some search type or one of
+// their aliases. Anything else followed by a colon, such as "TODO:", is treated as text.
+func isSearchQualifier(key string) bool {
+	key = strings.ToLower(key)
+	_, aliased := searchQualifierAliases[key]
+	return allSearchQualifiers[key] || aliased
+}
+
+// checkSearchQuery checks the qualifiers of a search query of the given type. It fixes what it
+// safely can, returning the rewritten query and a warning for each change and for each qualifier
+// it does not know. Only known qualifiers and their aliases are rewritten: other terms are left as
+// they are, with a warning if they look like a qualifier with a value, as GitHub may accept them.
+func checkSearchQuery(searchType, query string) (string, []string) {
+	var warnings []string
+	tokens, unterminated := splitSearchQuery(query)
+	if unterminated {
+		warnings = append(warnings, "closed an unterminated quote at the end of the query")
+	}
+
+	known := searchQualifiers[searchType]
+	rewritten := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		prefix := ""
+		if strings.HasPrefix(token, "-") {
+			prefix = "-"
+		}
+
+		// "label:help wanted" in quotes is a phrase to GitHub; the quotes belong around the value
+		if requoted, ok := requoteQualifierPhrase(strings.TrimPrefix(token, prefix)); ok {
+			warnings = append(warnings, fmt.Sprintf("quoted the value of %s rather than the whole qualifier", requoted))
+			token = prefix + requoted
+		}
+
+		key, value, found := strings.Cut(strings.TrimPrefix(token, "-"), ":")
+		if !found || !qualifierKeyPattern.MatchString(key) || strings.HasPrefix(value, "//") {
+			rewritten = append(rewritten, token)
+			continue
+		}
+		if !isSearchQualifier(key) {
+			if value != "" && known != nil {
+				warnings = append(warnings, fmt.Sprintf("unknown %s search qualifier %s:, it may be ignored or matched as text", searchTypeName(searchType), key))
+			}
+			rewritten = append(rewritten, token)
+			continue
+		}
+
+		// "label: bug" is split into two terms; the value belongs to the qualifier
+		if value == "" && i+1 < len(tokens) {
+			i++
+			value = tokens[i]
+			warnings = append(warnings, fmt.Sprintf("removed the space after %s:", key))
+		}
+
+		// GitHub only understands double quotes, so 'good first issue' would become three terms
+		if strings.HasPrefix(value, "'") {
+			end := i
+			for !strings.HasSuffix(tokens[end], "'") && end+1 < len(tokens) {
+				end++
+			}
+			if end > i || (len(value) > 1 && strings.HasSuffix(value, "'")) {
+				value = strings.Join(append([]string{value}, tokens[i+1:end+1]...), " ")
+				value = `"` + strings.Trim(value, "'") + `"`
+				warnings = append(warnings, fmt.Sprintf("quoted %s:%s with double quotes", key, value))
+				i = end
+			}
+		}
+
+		qualifier := strings.ToLower(key)
+		if alias, ok := searchQualifierAliases[qualifier]; ok && !known[qualifier] {
+			warnings = append(warnings, fmt.Sprintf("rewrote %s: to %s:", key, alias))
+			qualifier = alias
+		}
+
+		if qualifier == "state" && strings.EqualFold(value, "merged") {
+			if searchType == searchTypePullRequest {
+				warnings = append(warnings, "rewrote state:merged to is:merged")
+				qualifier, value = "is", "merged"
+			} else {
+				warnings = append(warnings, "state:merged only matches pull requests and state can only be open or closed; search pull requests with is:merged instead")
+			}
+		}
+
+		if known != nil && !known[qualifier] {
+			warnings = append(warnings, fmt.Sprintf("unknown %s search qualifier %s:, it may be ignored or matched as text", searchTypeName(searchType), qualifier))
+		}
+
+		rewritten = append(rewritten, prefix+qualifier+":"+value)
+	}
+	return strings.Join(rewritten, " "), warnings
+}
+
+// requoteQualifierPhrase turns a quoted phrase that starts with a known qualifier and has a
+// multi-word value, such as "label:help wanted", into the qualifier with a quoted value,
+// label:"help wanted". ok is false for any other term.
+func requoteQualifierPhrase(token string) (string, bool) {
+	if len(token) < 2 || !strings.HasPrefix(token, `"`) || !strings.HasSuffix(token, `"`) {
+		return "", false
+	}
+	key, value, found := strings.Cut(token[1:len(token)-1], ":")
+	if !found || !qualifierKeyPattern.MatchString(key) || !isSearchQualifier(key) {
+		return "", false
+	}
+	value = strings.TrimSpace(value)
+	if !strings.ContainsAny(value, " \t") || strings.Contains(value, `"`) {
+		return "", false
+	}
+	return key + `:"` + value + `"`, true
+}
+
+// splitSearchQuery splits a query into whitespace separated terms, keeping quoted text together.
+// If the last quote is not closed, it is closed and unterminated is true.
+func splitSearchQuery(query string) (tokens []string, unterminated bool) {
+	var current strings.Builder
+	inQuote := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			current.WriteRune(r)
+		case !inQuote && (r == ' ' || r == '\t' || r == '\n'):
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuote {
+		current.WriteRune('"')
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, inQuote
+}
+
+func searchTypeName(searchType string) string {
+	switch searchType {
+	case searchTypeIssue:
+		return "issues"
+	case searchTypePullRequest:
+		return "pull requests"
+	default:
+		return searchType
+	}
+}
+
+// withSearchQueryWarnings attaches the warnings of checkSearchQuery to a search result: in the
+// result metadata for clients, and as a second text content so that the model sees them too.
+func withSearchQueryWarnings(result *mcp.CallToolResult, query string, warnings []string) *mcp.CallToolResult {
+	if len(warnings) == 0 || result == nil {
+		return result
+	}
+	if result.Meta == nil {
+		result.Meta = map[string]any{}
+	}
+	result.Meta["search_query"] = query
+	result.Meta["search_query_warnings"] = warnings
+	result.Content = append(result.Content, mcp.NewTextContent(
+		fmt.Sprintf("Search query warnings (query sent: %s):\n- %s", query, strings.Join(warnings, "\n- ")),
+	))
+	return result
+}
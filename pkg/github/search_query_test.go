@@ -0,0 +1,179 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CheckSearchQuery(t *testing.T) {
+	tests := []struct {
+		name             string
+		searchType       string
+		query            string
+		expectedQuery    string
+		expectedWarnings []string
+	}{
+		{
+			name:          "valid issues query is unchanged",
+			searchType:    searchTypeIssue,
+			query:         `repo:github/github-mcp-server is:open label:bug -label:"needs triage" created:>2024-01-01`,
+			expectedQuery: `repo:github/github-mcp-server is:open label:bug -label:"needs triage" created:>2024-01-01`,
+		},
+		{
+			name:          "plain text terms are unchanged",
+			searchType:    searchTypeCode,
+			query:         `"func main" fmt.Println`,
+			expectedQuery: `"func main" fmt.Println`,
+		},
+		{
+			name:          "URLs are not qualifiers",
+			searchType:    searchTypeIssue,
+			query:         "https://github.com/octo-org/octo-repo/issues/1",
+			expectedQuery: "https://github.com/octo-org/octo-repo/issues/1",
+		},
+		{
+			name:          "qualifier keys are lowercased",
+			searchType:    searchTypeRepositories,
+			query:         "Language:Go stars:>100",
+			expectedQuery: "language:Go stars:>100",
+		},
+		{
+			name:             "lang is rewritten to language",
+			searchType:       searchTypeCode,
+			query:            "lang:go http.Client",
+			expectedQuery:    "language:go http.Client",
+			expectedWarnings: []string{"rewrote lang: to language:"},
+		},
+		{
+			name:             "aliases keep negation",
+			searchType:       searchTypeIssue,
+			query:            "-labels:wontfix repository:octo-org/octo-repo",
+			expectedQuery:    "-label:wontfix repo:octo-org/octo-repo",
+			expectedWarnings: []string{"rewrote labels: to label:", "rewrote repository: to repo:"},
+		},
+		{
+			name:             "code aliases",
+			searchType:       searchTypeCode,
+			query:            "ext:go file:main.go",
+			expectedQuery:    "extension:go filename:main.go",
+			expectedWarnings: []string{"rewrote ext: to extension:", "rewrote file: to filename:"},
+		},
+		{
+			name:             "state:merged becomes is:merged for pull requests",
+			searchType:       searchTypePullRequest,
+			query:            "repo:octo-org/octo-repo state:merged",
+			expectedQuery:    "repo:octo-org/octo-repo is:merged",
+			expectedWarnings: []string{"rewrote state:merged to is:merged"},
+		},
+		{
+			name:          "state:merged is kept but flagged for issues",
+			searchType:    searchTypeIssue,
+			query:         "state:merged",
+			expectedQuery: "state:merged",
+			expectedWarnings: []string{
+				"state:merged only matches pull requests and state can only be open or closed; search pull requests with is:merged instead",
+			},
+		},
+		{
+			name:             "unknown qualifier is kept with a warning",
+			searchType:       searchTypeIssue,
+			query:            "priority:high is:open",
+			expectedQuery:    "priority:high is:open",
+			expectedWarnings: []string{"unknown issues search qualifier priority:, it may be ignored or matched as text"},
+		},
+		{
+			name:             "qualifier valid for another search type is flagged",
+			searchType:       searchTypeRepositories,
+			query:            "label:bug",
+			expectedQuery:    "label:bug",
+			expectedWarnings: []string{"unknown repositories search qualifier label:, it may be ignored or matched as text"},
+		},
+		{
+			name:             "space after the colon is removed",
+			searchType:       searchTypeIssue,
+			query:            "label: bug is:open",
+			expectedQuery:    "label:bug is:open",
+			expectedWarnings: []string{"removed the space after label:"},
+		},
+		{
+			name:             "single quoted multi-word value is double quoted",
+			searchType:       searchTypeIssue,
+			query:            "label:'good first issue' is:open",
+			expectedQuery:    `label:"good first issue" is:open`,
+			expectedWarnings: []string{`quoted label:"good first issue" with double quotes`},
+		},
+		{
+			name:             "single quoted single word value is double quoted",
+			searchType:       searchTypeIssue,
+			query:            "milestone:'v1'",
+			expectedQuery:    `milestone:"v1"`,
+			expectedWarnings: []string{`quoted milestone:"v1" with double quotes`},
+		},
+		{
+			name:             "unterminated quote is closed",
+			searchType:       searchTypeIssue,
+			query:            `label:"help wanted`,
+			expectedQuery:    `label:"help wanted"`,
+			expectedWarnings: []string{"closed an unterminated quote at the end of the query"},
+		},
+		{
+			name:          "free text with a colon is left as it is",
+			searchType:    searchTypeIssue,
+			query:         "TODO: remove is:open",
+			expectedQuery: "TODO: remove is:open",
+		},
+		{
+			name:          "unknown qualifier-like terms keep their case",
+			searchType:    searchTypeCode,
+			query:         "Note:Keep FIXME:",
+			expectedQuery: "Note:Keep FIXME:",
+			expectedWarnings: []string{
+				"unknown code search qualifier Note:, it may be ignored or matched as text",
+			},
+		},
+		{
+			name:             "quoted qualifier with a multi-word value is requoted",
+			searchType:       searchTypeIssue,
+			query:            `"label:help wanted" is:open`,
+			expectedQuery:    `label:"help wanted" is:open`,
+			expectedWarnings: []string{`quoted the value of label:"help wanted" rather than the whole qualifier`},
+		},
+		{
+			name:          "negated quoted qualifier is requoted and normalised",
+			searchType:    searchTypeIssue,
+			query:         `-"Labels:needs triage"`,
+			expectedQuery: `-label:"needs triage"`,
+			expectedWarnings: []string{
+				`quoted the value of Labels:"needs triage" rather than the whole qualifier`,
+				"rewrote Labels: to label:",
+			},
+		},
+		{
+			name:          "quoted phrases that are not qualifiers are unchanged",
+			searchType:    searchTypeIssue,
+			query:         `"note: read this first"`,
+			expectedQuery: `"note: read this first"`,
+		},
+		{
+			name:          "extra whitespace is collapsed",
+			searchType:    searchTypeRepositories,
+			query:         "  topic:cli \t language:rust  ",
+			expectedQuery: "topic:cli language:rust",
+		},
+		{
+			name:          "empty query",
+			searchType:    searchTypeIssue,
+			query:         "",
+			expectedQuery: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, warnings := checkSearchQuery(tc.searchType, tc.query)
+			assert.Equal(t, tc.expectedQuery, query)
+			assert.Equal(t, tc.expectedWarnings, warnings)
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,6 +25,8 @@ func Test_SearchRepositories(t *testing.T) {
 	assert.Equal(t, "search_repositories", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "order")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
@@ -47,6 +51,30 @@ func Test_SearchRepositories(t *testing.T) {
 				HTMLURL:         github.Ptr("https://github.com/owner/repo-2"),
 				Description:     github.Ptr("Test repository 2"),
 				StargazersCount: github.Ptr(50),
+				Language:        github.Ptr("Go"),
+				License:         &github.License{SPDXID: github.Ptr("MIT"), Name: github.Ptr("MIT License")},
+				Archived:        github.Ptr(true),
+				Owner:           &github.User{Login: github.Ptr("owner")},
+			},
+		},
+	}
+	expectedResult := MinimalSearchRepositoriesResult{
+		TotalCount: 2,
+		Items: []MinimalRepository{
+			{
+				FullName:        "owner/repo-1",
+				Description:     "Test repository 1",
+				StargazersCount: 100,
+				HTMLURL:         "https://github.com/owner/repo-1",
+			},
+			{
+				FullName:        "owner/repo-2",
+				Description:     "Test repository 2",
+				StargazersCount: 50,
+				Language:        "Go",
+				License:         "MIT",
+				Archived:        true,
+				HTMLURL:         "https://github.com/owner/repo-2",
 			},
 		},
 	}
@@ -56,7 +84,6 @@ func Test_SearchRepositories(t *testing.T) {
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedResult *github.RepositoriesSearchResult
 		expectedErrMsg string
 	}{
 		{
@@ -66,6 +93,8 @@ func Test_SearchRepositories(t *testing.T) {
 					mock.GetSearchRepositories,
 					expectQueryParams(t, map[string]string{
 						"q":        "golang test",
+						"sort":     "stars",
+						"order":    "desc",
 						"page":     "2",
 						"per_page": "10",
 					}).andThen(
@@ -75,11 +104,12 @@ func Test_SearchRepositories(t *testing.T) {
 			),
 			requestArgs: map[string]interface{}{
 				"query":   "golang test",
+				"sort":    "stars",
+				"order":   "desc",
 				"page":    float64(2),
 				"perPage": float64(10),
 			},
-			expectError:    false,
-			expectedResult: mockSearchResult,
+			expectError: false,
 		},
 		{
 			name: "repository search with default pagination",
@@ -98,8 +128,7 @@ func Test_SearchRepositories(t *testing.T) {
 			requestArgs: map[string]interface{}{
 				"query": "golang test",
 			},
-			expectError:    false,
-			expectedResult: mockSearchResult,
+			expectError: false,
 		},
 		{
 			name: "search fails",
@@ -147,20 +176,11 @@ func Test_SearchRepositories(t *testing.T) {
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			// Unmarshal and verify the result
-			var returnedResult github.RepositoriesSearchResult
+			// Unmarshal and verify the condensed result, with the archived repository flagged
+			var returnedResult MinimalSearchRepositoriesResult
 			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
 			require.NoError(t, err)
-			assert.Equal(t, *tc.expectedResult.Total, *returnedResult.Total)
-			assert.Equal(t, *tc.expectedResult.IncompleteResults, *returnedResult.IncompleteResults)
-			assert.Len(t, returnedResult.Repositories, len(tc.expectedResult.Repositories))
-			for i, repo := range returnedResult.Repositories {
-				assert.Equal(t, *tc.expectedResult.Repositories[i].ID, *repo.ID)
-				assert.Equal(t, *tc.expectedResult.Repositories[i].Name, *repo.Name)
-				assert.Equal(t, *tc.expectedResult.Repositories[i].FullName, *repo.FullName)
-				assert.Equal(t, *tc.expectedResult.Repositories[i].HTMLURL, *repo.HTMLURL)
-			}
-
+			assert.Equal(t, expectedResult, returnedResult)
 		})
 	}
 }
@@ -174,6 +194,8 @@ func Test_SearchCode(t *testing.T) {
 	assert.Equal(t, "search_code", tool.Name)
 	assert.NotEmpty(t, tool.Description)
 	assert.Contains(t, tool.InputSchema.Properties, "q")
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "sort")
 	assert.Contains(t, tool.InputSchema.Properties, "order")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
@@ -191,6 +213,17 @@ func Test_SearchCode(t *testing.T) {
 				SHA:        github.Ptr("abc123def456"),
 				HTMLURL:    github.Ptr("https://github.com/owner/repo/blob/main/path/to/file1.go"),
 				Repository: &github.Repository{Name: github.Ptr("repo"), FullName: github.Ptr("owner/repo")},
+				TextMatches: []*github.TextMatch{
+					{
+						Property: github.Ptr("content"),
+						Fragment: github.Ptr("func main() {\n\tfmt.Println(\"hello\")\n}"),
+						Matches:  []*github.Match{{Text: github.Ptr("fmt.Println"), Indices: []int{15, 26}}},
+					},
+					{
+						Property: github.Ptr("path"),
+						Fragment: github.Ptr("path/to/file1.go"),
+					},
+				},
 			},
 			{
 				Name:       github.Ptr("file2.go"),
@@ -201,13 +234,36 @@ func Test_SearchCode(t *testing.T) {
 			},
 		},
 	}
+	expectedResult := MinimalSearchCodeResult{
+		TotalCount: 2,
+		Items: []MinimalCodeResult{
+			{
+				Repo:      "owner/repo",
+				Path:      "path/to/file1.go",
+				HTMLURL:   "https://github.com/owner/repo/blob/main/path/to/file1.go",
+				Fragments: []string{"func main() {\n\tfmt.Println(\"hello\")\n}"},
+			},
+			{
+				Repo:    "owner/repo",
+				Path:    "path/to/file2.go",
+				HTMLURL: "https://github.com/owner/repo/blob/main/path/to/file2.go",
+			},
+		},
+	}
+
+	// expectTextMatch checks that the text-match media type is requested, for the fragments
+	expectTextMatch := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Contains(t, r.Header.Get("Accept"), "application/vnd.github.v3.text-match+json")
+			next(w, r)
+		}
+	}
 
 	tests := []struct {
 		name           string
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedResult *github.CodeSearchResult
 		expectedErrMsg string
 	}{
 		{
@@ -215,46 +271,76 @@ func Test_SearchCode(t *testing.T) {
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetSearchCode,
-					expectQueryParams(t, map[string]string{
-						"q":        "fmt.Println language:go",
+					expectTextMatch(expectQueryParams(t, map[string]string{
+						"q":        "repo:owner/repo fmt.Println language:go",
 						"sort":     "indexed",
 						"order":    "desc",
-						"page":     "1",
-						"per_page": "30",
+						"page":     "2",
+						"per_page": "10",
 					}).andThen(
 						mockResponse(t, http.StatusOK, mockSearchResult),
-					),
+					)),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"q":       "fmt.Println language:go",
+				"owner":   "owner",
+				"repo":    "repo",
 				"sort":    "indexed",
 				"order":   "desc",
-				"page":    float64(1),
-				"perPage": float64(30),
+				"page":    float64(2),
+				"perPage": float64(10),
 			},
-			expectError:    false,
-			expectedResult: mockSearchResult,
+			expectError: false,
 		},
 		{
 			name: "code search with minimal parameters",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetSearchCode,
-					expectQueryParams(t, map[string]string{
+					expectTextMatch(expectQueryParams(t, map[string]string{
 						"q":        "fmt.Println language:go",
 						"page":     "1",
 						"per_page": "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, mockSearchResult),
-					),
+					)),
 				),
 			),
 			requestArgs: map[string]interface{}{
 				"q": "fmt.Println language:go",
 			},
-			expectError:    false,
-			expectedResult: mockSearchResult,
+			expectError: false,
+		},
+		{
+			name: "code search with only owner parameter",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCode,
+					expectQueryParams(t, map[string]string{
+						"q":        "user:owner fmt.Println",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"q":     "fmt.Println",
+				"owner": "owner",
+			},
+			expectError: false,
+		},
+		{
+			name:         "code search with repo but no owner",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"q":    "fmt.Println",
+				"repo": "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "repo requires owner",
 		},
 		{
 			name: "search code fails",
@@ -302,20 +388,23 @@ func Test_SearchCode(t *testing.T) {
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
-			// Unmarshal and verify the result
-			var returnedResult github.CodeSearchResult
+			// Unmarshal and verify the trimmed result
+			var returnedResult MinimalSearchCodeResult
 			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
 			require.NoError(t, err)
-			assert.Equal(t, *tc.expectedResult.Total, *returnedResult.Total)
-			assert.Equal(t, *tc.expectedResult.IncompleteResults, *returnedResult.IncompleteResults)
-			assert.Len(t, returnedResult.CodeResults, len(tc.expectedResult.CodeResults))
-			for i, code := range returnedResult.CodeResults {
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].Name, *code.Name)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].Path, *code.Path)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].SHA, *code.SHA)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].HTMLURL, *code.HTMLURL)
-				assert.Equal(t, *tc.expectedResult.CodeResults[i].Repository.FullName, *code.Repository.FullName)
+			assert.Equal(t, expectedResult, returnedResult)
+
+			// Only the trimmed fields are returned
+			var raw struct {
+				Items []map[string]any `json:"items"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &raw))
+			require.Len(t, raw.Items, 2)
+			var fields []string
+			for field := range raw.Items[0] {
+				fields = append(fields, field)
 			}
+			assert.ElementsMatch(t, []string{"repo", "path", "html_url", "fragments"}, fields)
 		})
 	}
 }
@@ -598,3 +687,118 @@ func Test_SearchOrgs(t *testing.T) {
 		})
 	}
 }
+
+func Test_SearchQueryWarnings(t *testing.T) {
+	tests := []struct {
+		name             string
+		newTool          func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		endpoint         mock.EndpointPattern
+		response         any
+		requestArgs      map[string]interface{}
+		expectedQuery    string
+		expectedWarnings []string
+	}{
+		{
+			name:     "search_repositories",
+			newTool:  SearchRepositories,
+			endpoint: mock.GetSearchRepositories,
+			response: &github.RepositoriesSearchResult{Total: github.Ptr(0), Repositories: []*github.Repository{}},
+			requestArgs: map[string]interface{}{
+				"query": "lang:go topic:cli",
+			},
+			expectedQuery:    "language:go topic:cli",
+			expectedWarnings: []string{"rewrote lang: to language:"},
+		},
+		{
+			name:     "search_code",
+			newTool:  SearchCode,
+			endpoint: mock.GetSearchCode,
+			response: &github.CodeSearchResult{Total: github.Ptr(0), CodeResults: []*github.CodeResult{}},
+			requestArgs: map[string]interface{}{
+				"q": "http.Client owner:octocat",
+			},
+			expectedQuery:    "http.Client owner:octocat",
+			expectedWarnings: []string{"unknown code search qualifier owner:, it may be ignored or matched as text"},
+		},
+		{
+			name:     "search_issues",
+			newTool:  SearchIssues,
+			endpoint: mock.GetSearchIssues,
+			response: &github.IssuesSearchResult{Total: github.Ptr(0), Issues: []*github.Issue{}},
+			requestArgs: map[string]interface{}{
+				"query": "labels:bug state:merged",
+				"owner": "octo-org",
+				"repo":  "octo-repo",
+			},
+			expectedQuery: "repo:octo-org/octo-repo is:issue label:bug state:merged",
+			expectedWarnings: []string{
+				"rewrote labels: to label:",
+				"state:merged only matches pull requests and state can only be open or closed; search pull requests with is:merged instead",
+			},
+		},
+		{
+			name:     "search_pull_requests",
+			newTool:  SearchPullRequests,
+			endpoint: mock.GetSearchIssues,
+			response: &github.IssuesSearchResult{Total: github.Ptr(0), Issues: []*github.Issue{}},
+			requestArgs: map[string]interface{}{
+				"query": "author:octocat state:merged",
+			},
+			expectedQuery:    "is:pr author:octocat is:merged",
+			expectedWarnings: []string{"rewrote state:merged to is:merged"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					tc.endpoint,
+					expectQueryParams(t, map[string]string{
+						"q":        tc.expectedQuery,
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, tc.response),
+					),
+				),
+			))
+			_, handler := tc.newTool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			// The search results come first, followed by the warnings
+			require.Len(t, result.Content, 2)
+			results, ok := result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.True(t, json.Valid([]byte(results.Text)))
+			warnings, ok := result.Content[1].(mcp.TextContent)
+			require.True(t, ok)
+			for _, warning := range tc.expectedWarnings {
+				assert.Contains(t, warnings.Text, warning)
+			}
+
+			assert.Equal(t, tc.expectedQuery, result.Meta["search_query"])
+			assert.Equal(t, tc.expectedWarnings, result.Meta["search_query_warnings"])
+		})
+	}
+
+	t.Run("valid query has no warnings", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetSearchRepositories,
+				&github.RepositoriesSearchResult{Total: github.Ptr(0)},
+			),
+		))
+		_, handler := SearchRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"query": "language:go stars:>100",
+		}))
+		require.NoError(t, err)
+		getTextResult(t, result)
+		assert.Nil(t, result.Meta)
+	})
+}
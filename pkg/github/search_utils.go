@@ -2,15 +2,75 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// maxSearchQueryLength is the longest query the GitHub search API accepts.
+const maxSearchQueryLength = 256
+
+// WithIssueSearchFilters adds the state, labels and assignee parameters, which searchHandler
+// appends to an issues or pull requests search query as qualifiers.
+func WithIssueSearchFilters() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("state",
+			mcp.Description("Only match issues in this state, appended to the query as state:"),
+			mcp.Enum("open", "closed"),
+		)(tool)
+
+		mcp.WithArray("labels",
+			mcp.Description("Only match issues with all of these labels, appended to the query as label: qualifiers"),
+			mcp.Items(
+				map[string]any{
+					"type": "string",
+				},
+			),
+		)(tool)
+
+		mcp.WithString("assignee",
+			mcp.Description("Only match issues assigned to this user, appended to the query as assignee:. Use @me for the authenticated user"),
+		)(tool)
+	}
+}
+
+// issueSearchFilterQualifiers returns the qualifiers of the WithIssueSearchFilters parameters of request.
+func issueSearchFilterQualifiers(request mcp.CallToolRequest) ([]string, error) {
+	var qualifiers []string
+
+	state, err := OptionalParam[string](request, "state")
+	if err != nil {
+		return nil, err
+	}
+	if state != "" {
+		qualifiers = append(qualifiers, "state:"+state)
+	}
+
+	labels, err := OptionalStringArrayParam(request, "labels")
+	if err != nil {
+		return nil, err
+	}
+	for _, label := range labels {
+		if strings.ContainsAny(label, " \t") {
+			label = `"` + label + `"`
+		}
+		qualifiers = append(qualifiers, "label:"+label)
+	}
+
+	assignee, err := OptionalParam[string](request, "assignee")
+	if err != nil {
+		return nil, err
+	}
+	if assignee != "" {
+		qualifiers = append(qualifiers, "assignee:"+assignee)
+	}
+
+	return qualifiers, nil
+}
+
 func searchHandler(
 	ctx context.Context,
 	getClient GetClientFn,
@@ -22,7 +82,24 @@ func searchHandler(
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	isPR, err := OptionalParam[bool](request, "is_pr")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if isPR {
+		searchType = searchTypePullRequest
+	}
+
+	filters, err := issueSearchFilterQualifiers(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	query, warnings := checkSearchQuery(searchType, query)
 	query = fmt.Sprintf("is:%s %s", searchType, query)
+	if len(filters) > 0 {
+		query = query + " " + strings.Join(filters, " ")
+	}
 
 	owner, err := OptionalParam[string](request, "owner")
 	if err != nil {
@@ -38,6 +115,11 @@ func searchHandler(
 		query = fmt.Sprintf("repo:%s/%s %s", owner, repo, query)
 	}
 
+	// The limit applies to the query as sent, with the qualifiers added above
+	if length := utf8.RuneCountInString(query); length > maxSearchQueryLength {
+		return mcp.NewToolResultError(fmt.Sprintf("%s: the query is %d characters long with its qualifiers, but GitHub accepts at most %d; shorten the search terms or use fewer qualifiers", errorPrefix, length, maxSearchQueryLength)), nil
+	}
+
 	sort, err := OptionalParam[string](request, "sort")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
@@ -66,23 +148,9 @@ func searchHandler(
 		return nil, fmt.Errorf("%s: failed to get GitHub client: %w", errorPrefix, err)
 	}
 	result, resp, err := client.Search.Issues(ctx, query, opts)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errorPrefix, err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("%s: failed to read response body: %w", errorPrefix, err)
-		}
-		return mcp.NewToolResultError(fmt.Sprintf("%s: %s", errorPrefix, string(body))), nil
-	}
-
-	r, err := json.Marshal(result)
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to marshal response: %w", errorPrefix, err)
+	if result, ok, err := handleRESTResponse(ctx, errorPrefix, resp, err); !ok {
+		return result, err
 	}
 
-	return mcp.NewToolResultText(string(r)), nil
+	return withSearchQueryWarnings(MarshalledTextResult(result), query, warnings), nil
 }
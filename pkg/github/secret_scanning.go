@@ -2,22 +2,23 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"time"
 
-	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// maxSecretScanningLocationPages bounds the pages of 100 locations fetched for a secret scanning
+// alert. A secret committed to many branches or forks can have more.
+const maxSecretScanningLocationPages = 10
+
 func GetSecretScanningAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool(
 			"get_secret_scanning_alert",
-			mcp.WithDescription(t("TOOL_GET_SECRET_SCANNING_ALERT_DESCRIPTION", "Get details of a specific secret scanning alert in a GitHub repository.")),
+			mcp.WithDescription(t("TOOL_GET_SECRET_SCANNING_ALERT_DESCRIPTION", "Get details of a specific secret scanning alert in a GitHub repository, including where the secret was found, up to 1000 locations. The secret itself is never returned.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_SECRET_SCANNING_ALERT_USER_TITLE", "Get secret scanning alert"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -55,36 +56,50 @@ func GetSecretScanningAlert(getClient GetClientFn, t translations.TranslationHel
 			}
 
 			alert, resp, err := client.SecretScanning.GetAlert(ctx, owner, repo, int64(alertNumber))
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to get alert with number '%d'", alertNumber),
-					resp,
-					err,
-				), nil
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to get alert with number '%d'", alertNumber), resp, err); !ok {
+				return result, err
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+			var locations []*github.SecretScanningAlertLocation
+			locationsTruncated := true
+			opts := &github.ListOptions{PerPage: 100}
+			for range maxSecretScanningLocationPages {
+				page, resp, err := client.SecretScanning.ListLocationsForAlert(ctx, owner, repo, int64(alertNumber), opts)
+				if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to get locations of alert with number '%d'", alertNumber), resp, err); !ok {
+					return result, err
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get alert: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(alert)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alert: %w", err)
+				locations = append(locations, page...)
+				if resp.NextPage == 0 {
+					locationsTruncated = false
+					break
+				}
+				opts.Page = resp.NextPage
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			details := SecretScanningAlertDetails{
+				SecretScanningAlert: newSecretScanningAlert(alert),
+				ResolutionComment:   alert.GetResolutionComment(),
+				Validity:            alert.GetValidity(),
+				PubliclyLeaked:      alert.GetPubliclyLeaked(),
+				Locations:           make([]SecretScanningAlertLocation, 0, len(locations)),
+				LocationsTruncated:  locationsTruncated,
+			}
+			for _, location := range locations {
+				details.Locations = append(details.Locations, SecretScanningAlertLocation{
+					Type:      location.GetType(),
+					Path:      location.GetDetails().GetPath(),
+					StartLine: location.GetDetails().GetStartline(),
+					CommitSHA: location.GetDetails().GetCommitSHA(),
+				})
+			}
+			return MarshalledTextResult(details), nil
 		}
 }
 
 func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool(
 			"list_secret_scanning_alerts",
-			mcp.WithDescription(t("TOOL_LIST_SECRET_SCANNING_ALERTS_DESCRIPTION", "List secret scanning alerts in a GitHub repository.")),
+			mcp.WithDescription(t("TOOL_LIST_SECRET_SCANNING_ALERTS_DESCRIPTION", "List secret scanning alerts in a GitHub repository with the type, state and resolution of each. The secrets themselves are never returned.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_LIST_SECRET_SCANNING_ALERTS_USER_TITLE", "List secret scanning alerts"),
 				ReadOnlyHint: ToBoolPtr(true),
@@ -108,6 +123,7 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 				mcp.Description("Filter by resolution"),
 				mcp.Enum("false_positive", "wont_fix", "revoked", "pattern_edited", "pattern_deleted", "used_in_tests"),
 			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -130,34 +146,80 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			alerts, resp, err := client.SecretScanning.ListAlertsForRepo(ctx, owner, repo, &github.SecretScanningAlertListOptions{State: state, SecretType: secretType, Resolution: resolution})
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					fmt.Sprintf("failed to list alerts for repository '%s/%s'", owner, repo),
-					resp,
-					err,
-				), nil
+			alerts, resp, err := client.SecretScanning.ListAlertsForRepo(ctx, owner, repo, &github.SecretScanningAlertListOptions{
+				State:      state,
+				SecretType: secretType,
+				Resolution: resolution,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if result, ok, err := handleRESTResponse(ctx, fmt.Sprintf("failed to list alerts for repository '%s/%s'", owner, repo), resp, err); !ok {
+				return result, err
 			}
-			defer func() { _ = resp.Body.Close() }()
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
+			result := make([]SecretScanningAlert, 0, len(alerts))
+			for _, alert := range alerts {
+				result = append(result, newSecretScanningAlert(alert))
 			}
+			return MarshalledTextResult(result), nil
+		}
+}
 
-			r, err := json.Marshal(alerts)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
-			}
+// SecretScanningAlert is a secret scanning alert as returned by list_secret_scanning_alerts. It
+// deliberately has no field for the secret, so that the secret never reaches the model.
+type SecretScanningAlert struct {
+	Number                int    `json:"number"`
+	SecretType            string `json:"secret_type"`
+	SecretTypeDisplayName string `json:"secret_type_display_name,omitempty"`
+	State                 string `json:"state"`
+	Resolution            string `json:"resolution,omitempty"`
+	CreatedAt             string `json:"created_at,omitempty"`
+	HTMLURL               string `json:"html_url"`
+}
 
-			return mcp.NewToolResultText(string(r)), nil
-		}
+// SecretScanningAlertDetails is a secret scanning alert with the locations of the secret, as
+// returned by get_secret_scanning_alert. LocationsTruncated is set when the alert has more
+// locations than were fetched.
+type SecretScanningAlertDetails struct {
+	SecretScanningAlert
+	ResolutionComment  string                        `json:"resolution_comment,omitempty"`
+	Validity           string                        `json:"validity,omitempty"`
+	PubliclyLeaked     bool                          `json:"publicly_leaked,omitempty"`
+	Locations          []SecretScanningAlertLocation `json:"locations"`
+	LocationsTruncated bool                          `json:"locations_truncated"`
+}
+
+// SecretScanningAlertLocation is where a secret was found. Path, StartLine and CommitSHA are only
+// set for locations of type commit.
+type SecretScanningAlertLocation struct {
+	Type      string `json:"type"`
+	Path      string `json:"path,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	CommitSHA string `json:"commit_sha,omitempty"`
+}
+
+func newSecretScanningAlert(alert *github.SecretScanningAlert) SecretScanningAlert {
+	result := SecretScanningAlert{
+		Number:                alert.GetNumber(),
+		SecretType:            alert.GetSecretType(),
+		SecretTypeDisplayName: alert.GetSecretTypeDisplayName(),
+		State:                 alert.GetState(),
+		Resolution:            alert.GetResolution(),
+		HTMLURL:               alert.GetHTMLURL(),
+	}
+	if alert.CreatedAt != nil {
+		result.CreatedAt = alert.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	return result
 }
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
@@ -13,9 +15,13 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// mockSecret is the secret of the mocked alerts. It must never appear in a tool result.
+const mockSecret = "aio_SECRETSECRETSECRETSECRET1234"
+
 func Test_GetSecretScanningAlert(t *testing.T) {
 	mockClient := github.NewClient(nil)
 	tool, _ := GetSecretScanningAlert(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "get_secret_scanning_alert", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -26,9 +32,28 @@ func Test_GetSecretScanningAlert(t *testing.T) {
 
 	// Setup mock alert for success case
 	mockAlert := &github.SecretScanningAlert{
-		Number:  github.Ptr(42),
-		State:   github.Ptr("open"),
-		HTMLURL: github.Ptr("https://github.com/owner/private-repo/security/secret-scanning/42"),
+		Number:            github.Ptr(42),
+		State:             github.Ptr("open"),
+		SecretType:        github.Ptr("adafruit_io_key"),
+		Secret:            github.Ptr(mockSecret),
+		HTMLURL:           github.Ptr("https://github.com/owner/private-repo/security/secret-scanning/42"),
+		CreatedAt:         &github.Timestamp{Time: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)},
+		Validity:          github.Ptr("active"),
+		ResolutionComment: github.Ptr(""),
+	}
+	mockLocations := []*github.SecretScanningAlertLocation{
+		{
+			Type: github.Ptr("commit"),
+			Details: &github.SecretScanningAlertLocationDetails{
+				Path:      github.Ptr("config/settings.yml"),
+				Startline: github.Ptr(12),
+				CommitSHA: github.Ptr("f14d7debf9775f957cf4f1e8176da0786431f72b"),
+			},
+		},
+		{
+			Type:    github.Ptr("issue_body"),
+			Details: &github.SecretScanningAlertLocationDetails{},
+		},
 	}
 
 	tests := []struct {
@@ -36,24 +61,134 @@ func Test_GetSecretScanningAlert(t *testing.T) {
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedAlert  *github.SecretScanningAlert
+		expectedAlert  SecretScanningAlertDetails
 		expectedErrMsg string
 	}{
 		{
-			name: "successful alert fetch",
+			name: "successful alert fetch with locations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposSecretScanningAlertsByOwnerByRepoByAlertNumber,
+					mockAlert,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposSecretScanningAlertsLocationsByOwnerByRepoByAlertNumber,
+					expectPath(t, "/repos/owner/repo/secret-scanning/alerts/42/locations").andThen(
+						mockResponse(t, http.StatusOK, mockLocations),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+			},
+			expectError: false,
+			expectedAlert: SecretScanningAlertDetails{
+				SecretScanningAlert: SecretScanningAlert{
+					Number:     42,
+					SecretType: "adafruit_io_key",
+					State:      "open",
+					CreatedAt:  "2024-05-06T07:08:09Z",
+					HTMLURL:    "https://github.com/owner/private-repo/security/secret-scanning/42",
+				},
+				Validity: "active",
+				Locations: []SecretScanningAlertLocation{
+					{
+						Type:      "commit",
+						Path:      "config/settings.yml",
+						StartLine: 12,
+						CommitSHA: "f14d7debf9775f957cf4f1e8176da0786431f72b",
+					},
+					{Type: "issue_body"},
+				},
+			},
+		},
+		{
+			name: "locations are fetched from every page",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatch(
 					mock.GetReposSecretScanningAlertsByOwnerByRepoByAlertNumber,
 					mockAlert,
 				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposSecretScanningAlertsLocationsByOwnerByRepoByAlertNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						page := mockLocations[1:]
+						if r.URL.Query().Get("page") == "" {
+							page = mockLocations[:1]
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/secret-scanning/alerts/42/locations?page=2&per_page=100>; rel="next"`)
+						}
+						w.WriteHeader(http.StatusOK)
+						b, _ := json.Marshal(page)
+						_, _ = w.Write(b)
+					}),
+				),
 			),
 			requestArgs: map[string]interface{}{
 				"owner":       "owner",
 				"repo":        "repo",
 				"alertNumber": float64(42),
 			},
-			expectError:   false,
-			expectedAlert: mockAlert,
+			expectError: false,
+			expectedAlert: SecretScanningAlertDetails{
+				SecretScanningAlert: SecretScanningAlert{
+					Number:     42,
+					SecretType: "adafruit_io_key",
+					State:      "open",
+					CreatedAt:  "2024-05-06T07:08:09Z",
+					HTMLURL:    "https://github.com/owner/private-repo/security/secret-scanning/42",
+				},
+				Validity: "active",
+				Locations: []SecretScanningAlertLocation{
+					{
+						Type:      "commit",
+						Path:      "config/settings.yml",
+						StartLine: 12,
+						CommitSHA: "f14d7debf9775f957cf4f1e8176da0786431f72b",
+					},
+					{Type: "issue_body"},
+				},
+			},
+		},
+		{
+			name: "locations are truncated after the page limit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposSecretScanningAlertsByOwnerByRepoByAlertNumber,
+					mockAlert,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposSecretScanningAlertsLocationsByOwnerByRepoByAlertNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/secret-scanning/alerts/42/locations?page=99&per_page=100>; rel="next"`)
+						w.WriteHeader(http.StatusOK)
+						b, _ := json.Marshal(mockLocations[1:])
+						_, _ = w.Write(b)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+			},
+			expectError: false,
+			expectedAlert: SecretScanningAlertDetails{
+				SecretScanningAlert: SecretScanningAlert{
+					Number:     42,
+					SecretType: "adafruit_io_key",
+					State:      "open",
+					CreatedAt:  "2024-05-06T07:08:09Z",
+					HTMLURL:    "https://github.com/owner/private-repo/security/secret-scanning/42",
+				},
+				Validity: "active",
+				Locations: []SecretScanningAlertLocation{
+					{Type: "issue_body"}, {Type: "issue_body"}, {Type: "issue_body"}, {Type: "issue_body"}, {Type: "issue_body"},
+					{Type: "issue_body"}, {Type: "issue_body"}, {Type: "issue_body"}, {Type: "issue_body"}, {Type: "issue_body"},
+				},
+				LocationsTruncated: true,
+			},
 		},
 		{
 			name: "alert fetch fails",
@@ -74,6 +209,26 @@ func Test_GetSecretScanningAlert(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to get alert",
 		},
+		{
+			name: "locations fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposSecretScanningAlertsByOwnerByRepoByAlertNumber,
+					mockAlert,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposSecretScanningAlertsLocationsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Internal Server Error"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get locations of alert with number '42'",
+		},
 	}
 
 	for _, tc := range tests {
@@ -102,15 +257,13 @@ func Test_GetSecretScanningAlert(t *testing.T) {
 
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
+			assert.NotContains(t, textContent.Text, mockSecret)
 
 			// Unmarshal and verify the result
-			var returnedAlert github.Alert
+			var returnedAlert SecretScanningAlertDetails
 			err = json.Unmarshal([]byte(textContent.Text), &returnedAlert)
-			assert.NoError(t, err)
-			assert.Equal(t, *tc.expectedAlert.Number, *returnedAlert.Number)
-			assert.Equal(t, *tc.expectedAlert.State, *returnedAlert.State)
-			assert.Equal(t, *tc.expectedAlert.HTMLURL, *returnedAlert.HTMLURL)
-
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAlert, returnedAlert)
 		})
 	}
 }
@@ -119,6 +272,7 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
 	tool, _ := ListSecretScanningAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "list_secret_scanning_alerts", tool.Name)
 	assert.NotEmpty(t, tool.Description)
@@ -127,6 +281,8 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "state")
 	assert.Contains(t, tool.InputSchema.Properties, "secret_type")
 	assert.Contains(t, tool.InputSchema.Properties, "resolution")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Setup mock alerts for success case
@@ -136,13 +292,16 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 		State:      github.Ptr("resolved"),
 		Resolution: github.Ptr("false_positive"),
 		SecretType: github.Ptr("adafruit_io_key"),
+		Secret:     github.Ptr(mockSecret),
+		CreatedAt:  &github.Timestamp{Time: time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)},
 	}
 	openAlert := github.SecretScanningAlert{
-		Number:     github.Ptr(2),
-		HTMLURL:    github.Ptr("https://github.com/owner/private-repo/security/secret-scanning/3"),
-		State:      github.Ptr("open"),
-		Resolution: github.Ptr("false_positive"),
-		SecretType: github.Ptr("adafruit_io_key"),
+		Number:                github.Ptr(3),
+		HTMLURL:               github.Ptr("https://github.com/owner/private-repo/security/secret-scanning/3"),
+		State:                 github.Ptr("open"),
+		SecretType:            github.Ptr("adafruit_io_key"),
+		SecretTypeDisplayName: github.Ptr("Adafruit IO Key"),
+		Secret:                github.Ptr(mockSecret),
 	}
 
 	tests := []struct {
@@ -150,7 +309,7 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 		mockedClient   *http.Client
 		requestArgs    map[string]interface{}
 		expectError    bool
-		expectedAlerts []*github.SecretScanningAlert
+		expectedAlerts []SecretScanningAlert
 		expectedErrMsg string
 	}{
 		{
@@ -159,36 +318,72 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 				mock.WithRequestMatchHandler(
 					mock.GetReposSecretScanningAlertsByOwnerByRepo,
 					expectQueryParams(t, map[string]string{
-						"state": "resolved",
+						"state":       "resolved",
+						"secret_type": "adafruit_io_key",
+						"resolution":  "false_positive",
+						"page":        "1",
+						"per_page":    "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.SecretScanningAlert{&resolvedAlert}),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
-				"state": "resolved",
+				"owner":       "owner",
+				"repo":        "repo",
+				"state":       "resolved",
+				"secret_type": "adafruit_io_key",
+				"resolution":  "false_positive",
+			},
+			expectError: false,
+			expectedAlerts: []SecretScanningAlert{
+				{
+					Number:     2,
+					SecretType: "adafruit_io_key",
+					State:      "resolved",
+					Resolution: "false_positive",
+					CreatedAt:  "2024-05-06T07:08:09Z",
+					HTMLURL:    "https://github.com/owner/private-repo/security/secret-scanning/2",
+				},
 			},
-			expectError:    false,
-			expectedAlerts: []*github.SecretScanningAlert{&resolvedAlert},
 		},
 		{
 			name: "successful alerts listing",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetReposSecretScanningAlertsByOwnerByRepo,
-					expectQueryParams(t, map[string]string{}).andThen(
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "5",
+					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.SecretScanningAlert{&resolvedAlert, &openAlert}),
 					),
 				),
 			),
 			requestArgs: map[string]interface{}{
-				"owner": "owner",
-				"repo":  "repo",
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(5),
+			},
+			expectError: false,
+			expectedAlerts: []SecretScanningAlert{
+				{
+					Number:     2,
+					SecretType: "adafruit_io_key",
+					State:      "resolved",
+					Resolution: "false_positive",
+					CreatedAt:  "2024-05-06T07:08:09Z",
+					HTMLURL:    "https://github.com/owner/private-repo/security/secret-scanning/2",
+				},
+				{
+					Number:                3,
+					SecretType:            "adafruit_io_key",
+					SecretTypeDisplayName: "Adafruit IO Key",
+					State:                 "open",
+					HTMLURL:               "https://github.com/owner/private-repo/security/secret-scanning/3",
+				},
 			},
-			expectError:    false,
-			expectedAlerts: []*github.SecretScanningAlert{&resolvedAlert, &openAlert},
 		},
 		{
 			name: "alerts listing fails",
@@ -231,19 +426,13 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 			require.False(t, result.IsError)
 
 			textContent := getTextResult(t, result)
+			assert.NotContains(t, textContent.Text, mockSecret)
 
 			// Unmarshal and verify the result
-			var returnedAlerts []*github.SecretScanningAlert
+			var returnedAlerts []SecretScanningAlert
 			err = json.Unmarshal([]byte(textContent.Text), &returnedAlerts)
-			assert.NoError(t, err)
-			assert.Len(t, returnedAlerts, len(tc.expectedAlerts))
-			for i, alert := range returnedAlerts {
-				assert.Equal(t, *tc.expectedAlerts[i].Number, *alert.Number)
-				assert.Equal(t, *tc.expectedAlerts[i].HTMLURL, *alert.HTMLURL)
-				assert.Equal(t, *tc.expectedAlerts[i].State, *alert.State)
-				assert.Equal(t, *tc.expectedAlerts[i].Resolution, *alert.Resolution)
-				assert.Equal(t, *tc.expectedAlerts[i].SecretType, *alert.SecretType)
-			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAlerts, returnedAlerts)
 		})
 	}
 }
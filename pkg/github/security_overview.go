@@ -0,0 +1,216 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// securityOverviewPerPage is the page size used to fetch alerts for the security overview.
+	securityOverviewPerPage = 100
+	// defaultSecurityOverviewMaxPages bounds the pages fetched per alert type unless max_pages says otherwise.
+	defaultSecurityOverviewMaxPages = 5
+	maxSecurityOverviewMaxPages     = 10
+)
+
+// securityAlert is the part of an alert the security overview counts.
+type securityAlert struct {
+	Repo     string
+	Severity string
+}
+
+// RepoAlertCounts counts the alerts of one repository.
+type RepoAlertCounts struct {
+	Repo       string         `json:"repo"`
+	Total      int            `json:"total"`
+	BySeverity map[string]int `json:"by_severity"`
+}
+
+// AlertCounts counts the alerts of one type across an organization. Truncated is set when there
+// were more alerts than the pages fetched, and Error when fetching stopped because of an error;
+// in both cases the counts cover only the alerts fetched.
+type AlertCounts struct {
+	Total      int               `json:"total"`
+	BySeverity map[string]int    `json:"by_severity"`
+	ByRepo     []RepoAlertCounts `json:"by_repo"`
+	Truncated  bool              `json:"truncated"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// SecurityOverview is the result of get_org_security_overview. Partial is set when the counts of
+// any alert type are truncated or incomplete.
+type SecurityOverview struct {
+	Org          string      `json:"org"`
+	State        string      `json:"state"`
+	Dependabot   AlertCounts `json:"dependabot"`
+	CodeScanning AlertCounts `json:"code_scanning"`
+	Partial      bool        `json:"partial"`
+}
+
+// aggregateAlerts counts alerts by severity and by repository. Repositories are ordered by their
+// number of alerts, most first. Alerts without a severity are counted as "unknown".
+func aggregateAlerts(alerts []securityAlert, truncated bool) AlertCounts {
+	counts := AlertCounts{
+		Total:      len(alerts),
+		BySeverity: map[string]int{},
+		ByRepo:     []RepoAlertCounts{},
+		Truncated:  truncated,
+	}
+	repoIndex := map[string]int{}
+	for _, alert := range alerts {
+		severity := alert.Severity
+		if severity == "" {
+			severity = "unknown"
+		}
+		counts.BySeverity[severity]++
+
+		i, ok := repoIndex[alert.Repo]
+		if !ok {
+			i = len(counts.ByRepo)
+			repoIndex[alert.Repo] = i
+			counts.ByRepo = append(counts.ByRepo, RepoAlertCounts{Repo: alert.Repo, BySeverity: map[string]int{}})
+		}
+		counts.ByRepo[i].Total++
+		counts.ByRepo[i].BySeverity[severity]++
+	}
+	sort.SliceStable(counts.ByRepo, func(i, j int) bool {
+		if counts.ByRepo[i].Total != counts.ByRepo[j].Total {
+			return counts.ByRepo[i].Total > counts.ByRepo[j].Total
+		}
+		return counts.ByRepo[i].Repo < counts.ByRepo[j].Repo
+	})
+	return counts
+}
+
+// GetOrgSecurityOverview creates a tool that counts the Dependabot and code scanning alerts of an
+// organization by severity and by repository.
+func GetOrgSecurityOverview(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_security_overview",
+			mcp.WithDescription(t("TOOL_GET_ORG_SECURITY_OVERVIEW_DESCRIPTION", "Count the dependabot and code scanning alerts of a GitHub organization by severity and by repository. Alerts are fetched a bounded number of pages at a time, so counts are flagged as truncated when the organization has more alerts than were fetched.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_SECURITY_OVERVIEW_USER_TITLE", "Get organization security overview"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization login."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Only count alerts in this state. Defaults to open"),
+				mcp.DefaultString("open"),
+				mcp.Enum("open", "fixed", "dismissed"),
+			),
+			mcp.WithNumber("max_pages",
+				mcp.Description(fmt.Sprintf("Maximum number of pages of %d alerts to fetch per alert type. Defaults to %d", securityOverviewPerPage, defaultSecurityOverviewMaxPages)),
+				mcp.Min(1),
+				mcp.Max(maxSecurityOverviewMaxPages),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if state == "" {
+				state = "open"
+			}
+			maxPages, err := OptionalIntParamWithDefault(request, "max_pages", defaultSecurityOverviewMaxPages)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxPages < 1 || maxPages > maxSecurityOverviewMaxPages {
+				return mcp.NewToolResultError(fmt.Sprintf("max_pages must be between 1 and %d", maxSecurityOverviewMaxPages)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			dependabotAlerts, dependabotTruncated, dependabotErr := fetchOrgDependabotAlerts(ctx, client, org, state, maxPages)
+			codeScanningAlerts, codeScanningTruncated, codeScanningErr := fetchOrgCodeScanningAlerts(ctx, client, org, state, maxPages)
+
+			overview := SecurityOverview{
+				Org:          org,
+				State:        state,
+				Dependabot:   aggregateAlerts(dependabotAlerts, dependabotTruncated),
+				CodeScanning: aggregateAlerts(codeScanningAlerts, codeScanningTruncated),
+			}
+			if dependabotErr != nil {
+				overview.Dependabot.Error = fmt.Sprintf("failed to list dependabot alerts: %v", dependabotErr)
+			}
+			if codeScanningErr != nil {
+				overview.CodeScanning.Error = fmt.Sprintf("failed to list code scanning alerts: %v", codeScanningErr)
+			}
+			overview.Partial = dependabotTruncated || codeScanningTruncated || dependabotErr != nil || codeScanningErr != nil
+
+			return MarshalledTextResult(overview), nil
+		}
+}
+
+// fetchOrgDependabotAlerts fetches up to maxPages pages of an organization's Dependabot alerts.
+// truncated reports whether more pages were left. On error it returns the alerts fetched so far.
+func fetchOrgDependabotAlerts(ctx context.Context, client *github.Client, org, state string, maxPages int) (alerts []securityAlert, truncated bool, err error) {
+	opts := &github.ListAlertsOptions{
+		State:             github.Ptr(state),
+		ListCursorOptions: github.ListCursorOptions{PerPage: securityOverviewPerPage},
+	}
+	for range maxPages {
+		page, resp, err := client.Dependabot.ListOrgAlerts(ctx, org, opts)
+		closeResponseBody(resp)
+		if err != nil {
+			return alerts, false, err
+		}
+		for _, alert := range page {
+			severity := alert.GetSecurityAdvisory().GetSeverity()
+			if severity == "" {
+				severity = alert.GetSecurityVulnerability().GetSeverity()
+			}
+			alerts = append(alerts, securityAlert{Repo: alert.GetRepository().GetFullName(), Severity: severity})
+		}
+		if resp.After == "" {
+			return alerts, false, nil
+		}
+		opts.After = resp.After
+	}
+	return alerts, true, nil
+}
+
+// fetchOrgCodeScanningAlerts fetches up to maxPages pages of an organization's code scanning alerts.
+// truncated reports whether more pages were left. On error it returns the alerts fetched so far.
+func fetchOrgCodeScanningAlerts(ctx context.Context, client *github.Client, org, state string, maxPages int) (alerts []securityAlert, truncated bool, err error) {
+	opts := &github.AlertListOptions{
+		State:       state,
+		ListOptions: github.ListOptions{Page: 1, PerPage: securityOverviewPerPage},
+	}
+	for range maxPages {
+		page, resp, err := client.CodeScanning.ListAlertsForOrg(ctx, org, opts)
+		closeResponseBody(resp)
+		if err != nil {
+			return alerts, false, err
+		}
+		for _, alert := range page {
+			// Security queries carry a security severity level, others only a rule severity
+			severity := alert.GetRule().GetSecuritySeverityLevel()
+			if severity == "" {
+				severity = alert.GetRule().GetSeverity()
+			}
+			alerts = append(alerts, securityAlert{Repo: alert.GetRepository().GetFullName(), Severity: severity})
+		}
+		if resp.NextPage == 0 {
+			return alerts, false, nil
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	return alerts, true, nil
+}
@@ -0,0 +1,186 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AggregateAlerts(t *testing.T) {
+	alerts := []securityAlert{
+		{Repo: "octo-org/web", Severity: "high"},
+		{Repo: "octo-org/api", Severity: "critical"},
+		{Repo: "octo-org/api", Severity: "high"},
+		{Repo: "octo-org/cli", Severity: ""},
+		{Repo: "octo-org/api", Severity: "high"},
+		{Repo: "octo-org/cli", Severity: "low"},
+	}
+
+	assert.Equal(t, AlertCounts{
+		Total:      6,
+		BySeverity: map[string]int{"critical": 1, "high": 3, "low": 1, "unknown": 1},
+		ByRepo: []RepoAlertCounts{
+			{Repo: "octo-org/api", Total: 3, BySeverity: map[string]int{"critical": 1, "high": 2}},
+			{Repo: "octo-org/cli", Total: 2, BySeverity: map[string]int{"low": 1, "unknown": 1}},
+			{Repo: "octo-org/web", Total: 1, BySeverity: map[string]int{"high": 1}},
+		},
+		Truncated: true,
+	}, aggregateAlerts(alerts, true))
+
+	assert.Equal(t, AlertCounts{
+		BySeverity: map[string]int{},
+		ByRepo:     []RepoAlertCounts{},
+	}, aggregateAlerts(nil, false))
+}
+
+func Test_GetOrgSecurityOverview(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgSecurityOverview(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_security_overview", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "max_pages")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	dependabotAlert := func(repo, severity string) *github.DependabotAlert {
+		return &github.DependabotAlert{
+			Repository:       &github.Repository{FullName: github.Ptr(repo)},
+			SecurityAdvisory: &github.DependabotSecurityAdvisory{Severity: github.Ptr(severity)},
+		}
+	}
+	codeScanningAlerts := []*github.Alert{
+		{
+			Repository: &github.Repository{FullName: github.Ptr("octo-org/api")},
+			Rule:       &github.Rule{Severity: github.Ptr("error"), SecuritySeverityLevel: github.Ptr("high")},
+		},
+		{
+			Repository: &github.Repository{FullName: github.Ptr("octo-org/web")},
+			Rule:       &github.Rule{Severity: github.Ptr("warning")},
+		},
+	}
+
+	// The dependabot endpoint always has another page, so fetching stops at max_pages
+	dependabotCalls := 0
+	endlessDependabotAlerts := mock.WithRequestMatchHandler(
+		mock.GetOrgsDependabotAlertsByOrg,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dependabotCalls++
+			assert.Equal(t, "fixed", r.URL.Query().Get("state"))
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			if dependabotCalls > 1 {
+				assert.Equal(t, "cursor-1", r.URL.Query().Get("after"))
+			}
+			w.Header().Set("Link", `<https://api.github.com/orgs/octo-org/dependabot/alerts?per_page=100&after=cursor-1>; rel="next"`)
+			_ = json.NewEncoder(w).Encode([]*github.DependabotAlert{
+				dependabotAlert("octo-org/api", "critical"),
+				dependabotAlert("octo-org/web", "low"),
+			})
+		}),
+	)
+
+	t.Run("counts alerts of both types and flags truncation", func(t *testing.T) {
+		dependabotCalls = 0
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			endlessDependabotAlerts,
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsCodeScanningAlertsByOrg,
+				expectQueryParams(t, map[string]string{
+					"state":    "fixed",
+					"page":     "1",
+					"per_page": "100",
+				}).andThen(
+					mockResponse(t, http.StatusOK, codeScanningAlerts),
+				),
+			),
+		))
+		_, handler := GetOrgSecurityOverview(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"org":       "octo-org",
+			"state":     "fixed",
+			"max_pages": float64(2),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var overview SecurityOverview
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &overview))
+		assert.Equal(t, 2, dependabotCalls)
+		assert.Equal(t, SecurityOverview{
+			Org:   "octo-org",
+			State: "fixed",
+			Dependabot: AlertCounts{
+				Total:      4,
+				BySeverity: map[string]int{"critical": 2, "low": 2},
+				ByRepo: []RepoAlertCounts{
+					{Repo: "octo-org/api", Total: 2, BySeverity: map[string]int{"critical": 2}},
+					{Repo: "octo-org/web", Total: 2, BySeverity: map[string]int{"low": 2}},
+				},
+				Truncated: true,
+			},
+			CodeScanning: AlertCounts{
+				Total:      2,
+				BySeverity: map[string]int{"high": 1, "warning": 1},
+				ByRepo: []RepoAlertCounts{
+					{Repo: "octo-org/api", Total: 1, BySeverity: map[string]int{"high": 1}},
+					{Repo: "octo-org/web", Total: 1, BySeverity: map[string]int{"warning": 1}},
+				},
+			},
+			Partial: true,
+		}, overview)
+	})
+
+	t.Run("reports an alert type that cannot be listed", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetOrgsDependabotAlertsByOrg,
+				[]*github.DependabotAlert{dependabotAlert("octo-org/api", "high")},
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsCodeScanningAlertsByOrg,
+				mockResponse(t, http.StatusForbidden, `{"message": "Advanced Security must be enabled"}`),
+			),
+		))
+		_, handler := GetOrgSecurityOverview(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"org": "octo-org",
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var overview SecurityOverview
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &overview))
+		assert.Equal(t, "open", overview.State)
+		assert.Equal(t, 1, overview.Dependabot.Total)
+		assert.False(t, overview.Dependabot.Truncated)
+		assert.Empty(t, overview.Dependabot.Error)
+		assert.Equal(t, 0, overview.CodeScanning.Total)
+		assert.Contains(t, overview.CodeScanning.Error, "failed to list code scanning alerts")
+		assert.Contains(t, overview.CodeScanning.Error, "Advanced Security must be enabled")
+		assert.True(t, overview.Partial)
+	})
+
+	t.Run("rejects max_pages out of bounds", func(t *testing.T) {
+		_, handler := GetOrgSecurityOverview(stubGetClientFn(github.NewClient(mock.NewMockedHTTPClient())), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"org":       "octo-org",
+			"max_pages": float64(11),
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "max_pages must be between 1 and 10")
+	})
+}
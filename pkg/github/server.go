@@ -1,10 +1,19 @@
 package github
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
-
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v73/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -68,6 +77,10 @@ func isAcceptedError(err error) bool {
 func RequiredParam[T comparable](r mcp.CallToolRequest, p string) (T, error) {
 	var zero T
 
+	if err := normalizeParam(r, p); err != nil {
+		return zero, err
+	}
+
 	// Check if the parameter is present in the request
 	if _, ok := r.GetArguments()[p]; !ok {
 		return zero, fmt.Errorf("missing required parameter: %s", p)
@@ -106,6 +119,10 @@ func RequiredInt(r mcp.CallToolRequest, p string) (int, error) {
 func OptionalParam[T any](r mcp.CallToolRequest, p string) (T, error) {
 	var zero T
 
+	if err := normalizeParam(r, p); err != nil {
+		return zero, err
+	}
+
 	// Check if the parameter is present in the request
 	if _, ok := r.GetArguments()[p]; !ok {
 		return zero, nil
@@ -119,6 +136,75 @@ func OptionalParam[T any](r mcp.CallToolRequest, p string) (T, error) {
 	return r.GetArguments()[p].(T), nil
 }
 
+// normalizeParam runs the shared validation for parameters that need it before they are read.
+func normalizeParam(r mcp.CallToolRequest, p string) error {
+	if p == "owner" || p == "repo" {
+		return normalizeOwnerRepo(r.GetArguments())
+	}
+	return nil
+}
+
+// decodeParams decodes the request arguments into params, after the same validation
+// that RequiredParam and OptionalParam apply.
+func decodeParams(r mcp.CallToolRequest, params any) error {
+	if err := normalizeOwnerRepo(r.GetArguments()); err != nil {
+		return err
+	}
+	return mapstructure.Decode(r.Params.Arguments, params)
+}
+
+// normalizeOwnerRepo catches owner and repo values in the wrong format, which otherwise
+// surface as confusing 404s. An "owner/repo" value is split when that is unambiguous:
+// in owner when repo is empty, or in repo when owner is empty or matches. URLs and other
+// values containing a slash are rejected with the expected format. args is updated in place.
+func normalizeOwnerRepo(args map[string]any) error {
+	owner, _ := args["owner"].(string)
+	repo, _ := args["repo"].(string)
+
+	if isURL(owner) {
+		return fmt.Errorf("invalid owner %q: expected a user or organization login such as \"octocat\", not a URL", owner)
+	}
+	if isURL(repo) {
+		return fmt.Errorf("invalid repo %q: expected a repository name such as \"hello-world\", not a URL", repo)
+	}
+
+	if strings.Contains(owner, "/") {
+		splitOwner, splitRepo, ok := splitOwnerRepo(owner)
+		if !ok || repo != "" {
+			return fmt.Errorf("invalid owner %q: expected only the user or organization login, with the repository name passed separately in repo", owner)
+		}
+		args["owner"], args["repo"] = splitOwner, splitRepo
+		return nil
+	}
+
+	if strings.Contains(repo, "/") {
+		splitOwner, splitRepo, ok := splitOwnerRepo(repo)
+		if !ok || (owner != "" && !strings.EqualFold(owner, splitOwner)) {
+			return fmt.Errorf("invalid repo %q: expected only the repository name, with the user or organization login passed separately in owner", repo)
+		}
+		if owner == "" {
+			args["owner"] = splitOwner
+		}
+		args["repo"] = splitRepo
+	}
+
+	return nil
+}
+
+// splitOwnerRepo splits an "owner/repo" value, reporting false unless both parts are non-empty.
+func splitOwnerRepo(value string) (string, string, bool) {
+	owner, repo, found := strings.Cut(value, "/")
+	if !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", false
+	}
+	return owner, repo, true
+}
+
+// isURL reports whether value looks like a URL rather than a plain name.
+func isURL(value string) bool {
+	return strings.Contains(value, "://") || strings.HasPrefix(strings.ToLower(value), "github.com/")
+}
+
 // OptionalIntParam is a helper function that can be used to fetch a requested parameter from the request.
 // It does the following checks:
 // 1. Checks if the parameter is present in the request, if not, it returns its zero-value
@@ -174,19 +260,64 @@ func OptionalStringArrayParam(r mcp.CallToolRequest, p string) ([]string, error)
 	}
 }
 
+// OptionalInt64ArrayParam is a helper function that can be used to fetch a requested parameter from the request.
+// It does the following checks:
+// 1. Checks if the parameter is present in the request, if not, it returns its zero-value
+// 2. If it is present, iterates the elements and checks each is a number
+func OptionalInt64ArrayParam(r mcp.CallToolRequest, p string) ([]int64, error) {
+	// Check if the parameter is present in the request
+	if _, ok := r.GetArguments()[p]; !ok {
+		return []int64{}, nil
+	}
+
+	switch v := r.GetArguments()[p].(type) {
+	case nil:
+		return []int64{}, nil
+	case []int64:
+		return v, nil
+	case []any:
+		intSlice := make([]int64, len(v))
+		for i, v := range v {
+			f, ok := v.(float64)
+			if !ok {
+				return []int64{}, fmt.Errorf("parameter %s is not of type number, is %T", p, v)
+			}
+			intSlice[i] = int64(f)
+		}
+		return intSlice, nil
+	default:
+		return []int64{}, fmt.Errorf("parameter %s could not be coerced to []int64, is %T", p, r.GetArguments()[p])
+	}
+}
+
+const (
+	// defaultPerPage is the page size used when a tool call does not set perPage.
+	defaultPerPage = 30
+	// maxPerPage is the largest page size the GitHub APIs accept.
+	maxPerPage = 100
+)
+
 // WithPagination adds REST API pagination parameters to a tool.
 // https://docs.github.com/en/rest/using-the-rest-api/using-pagination-in-the-rest-api
 func WithPagination() mcp.ToolOption {
+	return WithPaginationPerPage(defaultPerPage)
+}
+
+// WithPaginationPerPage adds REST API pagination parameters to a tool whose pages hold perPage
+// results by default. Its handler reads them with OptionalPaginationParamsPerPage.
+func WithPaginationPerPage(perPage int) mcp.ToolOption {
 	return func(tool *mcp.Tool) {
 		mcp.WithNumber("page",
-			mcp.Description("Page number for pagination (min 1)"),
+			mcp.Description("Page number for pagination (min 1, default 1)"),
 			mcp.Min(1),
+			mcp.DefaultNumber(1),
 		)(tool)
 
 		mcp.WithNumber("perPage",
-			mcp.Description("Results per page for pagination (min 1, max 100)"),
+			mcp.Description(fmt.Sprintf("Results per page for pagination (min 1, max %d, default %d)", maxPerPage, perPage)),
 			mcp.Min(1),
-			mcp.Max(100),
+			mcp.Max(maxPerPage),
+			mcp.DefaultNumber(float64(perPage)),
 		)(tool)
 	}
 }
@@ -196,14 +327,16 @@ func WithPagination() mcp.ToolOption {
 func WithUnifiedPagination() mcp.ToolOption {
 	return func(tool *mcp.Tool) {
 		mcp.WithNumber("page",
-			mcp.Description("Page number for pagination (min 1)"),
+			mcp.Description("Page number for pagination (min 1, default 1)"),
 			mcp.Min(1),
+			mcp.DefaultNumber(1),
 		)(tool)
 
 		mcp.WithNumber("perPage",
-			mcp.Description("Results per page for pagination (min 1, max 100)"),
+			mcp.Description("Results per page for pagination (min 1, max 100, default 30)"),
 			mcp.Min(1),
-			mcp.Max(100),
+			mcp.Max(maxPerPage),
+			mcp.DefaultNumber(defaultPerPage),
 		)(tool)
 
 		mcp.WithString("after",
@@ -216,9 +349,10 @@ func WithUnifiedPagination() mcp.ToolOption {
 func WithCursorPagination() mcp.ToolOption {
 	return func(tool *mcp.Tool) {
 		mcp.WithNumber("perPage",
-			mcp.Description("Results per page for pagination (min 1, max 100)"),
+			mcp.Description("Results per page for pagination (min 1, max 100, default 30)"),
 			mcp.Min(1),
-			mcp.Max(100),
+			mcp.Max(maxPerPage),
+			mcp.DefaultNumber(defaultPerPage),
 		)(tool)
 
 		mcp.WithString("after",
@@ -235,15 +369,26 @@ type PaginationParams struct {
 
 // OptionalPaginationParams returns the "page", "perPage", and "after" parameters from the request,
 // or their default values if not present, "page" default is 1, "perPage" default is 30.
+// Values outside the bounds declared by WithPagination are rejected rather than clamped, so the
+// model learns the limits instead of silently getting a different page size than it asked for.
 // In future, we may want to make the default values configurable, or even have this
 // function returned from `withPagination`, where the defaults are provided alongside
 // the min/max values.
 func OptionalPaginationParams(r mcp.CallToolRequest) (PaginationParams, error) {
-	page, err := OptionalIntParamWithDefault(r, "page", 1)
+	return OptionalPaginationParamsPerPage(r, defaultPerPage)
+}
+
+// OptionalPaginationParamsPerPage is OptionalPaginationParams for tools declared with
+// WithPaginationPerPage, whose "perPage" defaults to perPageDefault.
+func OptionalPaginationParamsPerPage(r mcp.CallToolRequest, perPageDefault int) (PaginationParams, error) {
+	page, err := optionalPaginationInt(r, "page", 1)
 	if err != nil {
 		return PaginationParams{}, err
 	}
-	perPage, err := OptionalIntParamWithDefault(r, "perPage", 30)
+	if page < 1 {
+		return PaginationParams{}, fmt.Errorf("page must be at least 1, got %d", page)
+	}
+	perPage, err := optionalPerPageParam(r, perPageDefault)
 	if err != nil {
 		return PaginationParams{}, err
 	}
@@ -261,7 +406,7 @@ func OptionalPaginationParams(r mcp.CallToolRequest) (PaginationParams, error) {
 // OptionalCursorPaginationParams returns the "perPage" and "after" parameters from the request,
 // without the "page" parameter, suitable for cursor-based pagination only.
 func OptionalCursorPaginationParams(r mcp.CallToolRequest) (CursorPaginationParams, error) {
-	perPage, err := OptionalIntParamWithDefault(r, "perPage", 30)
+	perPage, err := optionalPerPageParam(r, defaultPerPage)
 	if err != nil {
 		return CursorPaginationParams{}, err
 	}
@@ -275,11 +420,64 @@ func OptionalCursorPaginationParams(r mcp.CallToolRequest) (CursorPaginationPara
 	}, nil
 }
 
+// optionalPerPageParam returns the "perPage" parameter, defaulting to d, and rejects values
+// outside 1 to 100.
+func optionalPerPageParam(r mcp.CallToolRequest, d int) (int, error) {
+	perPage, err := optionalPaginationInt(r, "perPage", d)
+	if err != nil {
+		return 0, err
+	}
+	if perPage < 1 || perPage > maxPerPage {
+		return 0, fmt.Errorf("perPage must be between 1 and %d, got %d", maxPerPage, perPage)
+	}
+	return perPage, nil
+}
+
+// optionalByteLimitParam returns the byte limit parameter p of the tools that cut long text. Like
+// the size limits of the server flags, 0 means the same as leaving it out: the tool's default d,
+// where a d of 0 means no limit. Negative limits are rejected.
+func optionalByteLimitParam(r mcp.CallToolRequest, p string, d int) (int, error) {
+	v, err := OptionalIntParamWithDefault(r, p, d)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got %d", p, v)
+	}
+	return v, nil
+}
+
+// optionalPaginationInt returns the integer parameter p, or d when it is absent. Unlike
+// OptionalIntParamWithDefault, an explicit 0 is returned as is, so that it can be rejected.
+func optionalPaginationInt(r mcp.CallToolRequest, p string, d int) (int, error) {
+	v, ok, err := OptionalParamOK[float64](r, p)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return d, nil
+	}
+	return int(v), nil
+}
+
 type CursorPaginationParams struct {
 	PerPage int
 	After   string
 }
 
+// CursorNextCall returns the arguments to pass to a cursor-paginated tool to fetch the next page,
+// so that callers do not need to know which parameters carry the cursor. It returns nil when there
+// are no more pages.
+func CursorNextCall(hasNextPage bool, endCursor string, perPage int32) *NextCall {
+	if !hasNextPage || endCursor == "" {
+		return nil
+	}
+	return &NextCall{
+		After:   endCursor,
+		PerPage: perPage,
+	}
+}
+
 // ToGraphQLParams converts cursor pagination parameters to GraphQL-specific parameters.
 func (p CursorPaginationParams) ToGraphQLParams() (*GraphQLPaginationParams, error) {
 	if p.PerPage > 100 {
@@ -288,6 +486,9 @@ func (p CursorPaginationParams) ToGraphQLParams() (*GraphQLPaginationParams, err
 	if p.PerPage < 0 {
 		return nil, fmt.Errorf("perPage value %d cannot be negative", p.PerPage)
 	}
+	if p.After != "" && !isValidCursor(p.After) {
+		return nil, fmt.Errorf("invalid cursor %q: use the endCursor value from the previous page's PageInfo", p.After)
+	}
 	first := int32(p.PerPage)
 
 	var after *string
@@ -301,6 +502,17 @@ func (p CursorPaginationParams) ToGraphQLParams() (*GraphQLPaginationParams, err
 	}, nil
 }
 
+// isValidCursor reports whether a cursor looks like one returned by the GraphQL API.
+// Cursors are opaque, but they are always base64 encoded.
+func isValidCursor(cursor string) bool {
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if _, err := enc.DecodeString(cursor); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 type GraphQLPaginationParams struct {
 	First *int32
 	After *string
@@ -318,11 +530,75 @@ func (p PaginationParams) ToGraphQLParams() (*GraphQLPaginationParams, error) {
 	return cursor.ToGraphQLParams()
 }
 
+// MarshalledTextResult returns v as JSON text. Nil slices are marshalled as [] rather than null, both at the
+// top level and in the fields of a top level struct, since models tend to read null as an error or as
+// missing data rather than as an empty list.
 func MarshalledTextResult(v any) *mcp.CallToolResult {
-	data, err := json.Marshal(v)
+	data, err := json.Marshal(emptyNilSlices(v))
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("failed to marshal text result to json", err)
 	}
 
 	return mcp.NewToolResultText(string(data))
 }
+
+// emptyNilSlices replaces a nil slice with an empty one. For a struct, or a pointer to one, it returns a copy
+// with its nil slice fields replaced instead. Any other value is returned unchanged.
+func emptyNilSlices(v any) any {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.Slice:
+		if rv.IsNil() {
+			return reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+		}
+		return v
+	case rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct:
+		normalized := reflect.New(rv.Elem().Type())
+		normalized.Elem().Set(emptyNilSliceFields(rv.Elem()))
+		return normalized.Interface()
+	case rv.Kind() == reflect.Struct:
+		return emptyNilSliceFields(rv).Interface()
+	default:
+		return v
+	}
+}
+
+func emptyNilSliceFields(rv reflect.Value) reflect.Value {
+	normalized := reflect.New(rv.Type()).Elem()
+	normalized.Set(rv)
+	for i := 0; i < normalized.NumField(); i++ {
+		field := normalized.Field(i)
+		if field.Kind() == reflect.Slice && field.IsNil() && field.CanSet() {
+			field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+		}
+	}
+	return normalized
+}
+
+// handleRESTResponse checks the outcome of a REST API call, and closes the response body.
+// If the call failed, or the response status is not one of expectedStatuses (http.StatusOK if none
+// are given), ok is false and the handler should return result and err as they are: a tool error
+// prefixed with label, or an error if the body of the unexpected response could not be read.
+func handleRESTResponse(ctx context.Context, label string, resp *github.Response, err error, expectedStatuses ...int) (result *mcp.CallToolResult, ok bool, _ error) {
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			label,
+			resp,
+			err,
+		), false, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if len(expectedStatuses) == 0 {
+		expectedStatuses = []int{http.StatusOK}
+	}
+	if !slices.Contains(expectedStatuses, resp.StatusCode) {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %s", label, string(body))), false, nil
+	}
+
+	return nil, true, nil
+}
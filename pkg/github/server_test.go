@@ -5,13 +5,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func stubGetClientFn(client *github.Client) GetClientFn {
@@ -201,6 +208,148 @@ func Test_OptionalStringParam(t *testing.T) {
 	}
 }
 
+func Test_NormalizeOwnerRepo(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        map[string]interface{}
+		expectedOwner interface{}
+		expectedRepo  interface{}
+		expectedErr   string
+	}{
+		{
+			name:          "plain values pass through untouched",
+			params:        map[string]interface{}{"owner": "octocat", "repo": "hello-world"},
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+		},
+		{
+			name:          "owner without repo passes through untouched",
+			params:        map[string]interface{}{"owner": "octocat"},
+			expectedOwner: "octocat",
+			expectedRepo:  nil,
+		},
+		{
+			name:          "owner/repo in owner is split when repo is missing",
+			params:        map[string]interface{}{"owner": "octocat/hello-world"},
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+		},
+		{
+			name:          "owner/repo in owner is split when repo is empty",
+			params:        map[string]interface{}{"owner": "octocat/hello-world", "repo": ""},
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+		},
+		{
+			name:          "owner/repo in repo is split when owner is missing",
+			params:        map[string]interface{}{"repo": "octocat/hello-world"},
+			expectedOwner: "octocat",
+			expectedRepo:  "hello-world",
+		},
+		{
+			name:          "owner/repo in repo is trimmed when owner matches",
+			params:        map[string]interface{}{"owner": "OctoCat", "repo": "octocat/hello-world"},
+			expectedOwner: "OctoCat",
+			expectedRepo:  "hello-world",
+		},
+		{
+			name:        "owner/repo in owner with a repo is ambiguous",
+			params:      map[string]interface{}{"owner": "octocat/hello-world", "repo": "spoon-knife"},
+			expectedErr: `invalid owner "octocat/hello-world": expected only the user or organization login`,
+		},
+		{
+			name:        "owner/repo in repo with a different owner is ambiguous",
+			params:      map[string]interface{}{"owner": "github", "repo": "octocat/hello-world"},
+			expectedErr: `invalid repo "octocat/hello-world": expected only the repository name`,
+		},
+		{
+			name:        "more than one slash is rejected",
+			params:      map[string]interface{}{"owner": "octocat/hello-world/tree/main"},
+			expectedErr: `invalid owner "octocat/hello-world/tree/main"`,
+		},
+		{
+			name:        "URL in owner is rejected",
+			params:      map[string]interface{}{"owner": "https://github.com/octocat/hello-world"},
+			expectedErr: "not a URL",
+		},
+		{
+			name:        "URL without scheme in owner is rejected",
+			params:      map[string]interface{}{"owner": "github.com/octocat"},
+			expectedErr: "not a URL",
+		},
+		{
+			name:        "URL in repo is rejected",
+			params:      map[string]interface{}{"owner": "octocat", "repo": "https://github.com/octocat/hello-world.git"},
+			expectedErr: "not a URL",
+		},
+		{
+			name:          "non-string values are left for the type checks",
+			params:        map[string]interface{}{"owner": 123, "repo": "hello-world"},
+			expectedOwner: 123,
+			expectedRepo:  "hello-world",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := normalizeOwnerRepo(tc.params)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOwner, tc.params["owner"])
+			assert.Equal(t, tc.expectedRepo, tc.params["repo"])
+		})
+	}
+}
+
+func Test_OwnerRepoParamHelpers(t *testing.T) {
+	t.Run("repo can be read before owner after an auto-split", func(t *testing.T) {
+		request := createMCPRequest(map[string]interface{}{"owner": "octocat/hello-world"})
+
+		repo, err := RequiredParam[string](request, "repo")
+		require.NoError(t, err)
+		owner, err := RequiredParam[string](request, "owner")
+		require.NoError(t, err)
+
+		assert.Equal(t, "octocat", owner)
+		assert.Equal(t, "hello-world", repo)
+	})
+
+	t.Run("optional owner reports URLs", func(t *testing.T) {
+		request := createMCPRequest(map[string]interface{}{"owner": "https://github.com/octocat"})
+
+		_, err := OptionalParam[string](request, "owner")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a URL")
+	})
+
+	t.Run("decodeParams splits before decoding", func(t *testing.T) {
+		request := createMCPRequest(map[string]interface{}{"owner": "octocat/hello-world", "pullNumber": float64(1)})
+
+		var params struct {
+			Owner      string
+			Repo       string
+			PullNumber int32
+		}
+		require.NoError(t, decodeParams(request, &params))
+		assert.Equal(t, "octocat", params.Owner)
+		assert.Equal(t, "hello-world", params.Repo)
+		assert.Equal(t, int32(1), params.PullNumber)
+	})
+
+	t.Run("other parameters are not validated", func(t *testing.T) {
+		request := createMCPRequest(map[string]interface{}{"owner": "https://github.com/octocat", "path": "a/b"})
+
+		path, err := RequiredParam[string](request, "path")
+		require.NoError(t, err)
+		assert.Equal(t, "a/b", path)
+	})
+}
+
 func Test_RequiredInt(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -357,6 +506,34 @@ func Test_OptionalNumberParamWithDefault(t *testing.T) {
 	}
 }
 
+func Test_OptionalByteLimitParam(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         map[string]interface{}
+		defaultVal     int
+		expected       int
+		expectedErrMsg string
+	}{
+		{name: "limit given", params: map[string]interface{}{"max_bytes": float64(512)}, defaultVal: 4000, expected: 512},
+		{name: "missing uses the default", params: map[string]interface{}{}, defaultVal: 4000, expected: 4000},
+		{name: "zero uses the default", params: map[string]interface{}{"max_bytes": float64(0)}, defaultVal: 4000, expected: 4000},
+		{name: "zero without a default is no limit", params: map[string]interface{}{"max_bytes": float64(0)}, defaultVal: 0, expected: 0},
+		{name: "negative", params: map[string]interface{}{"max_bytes": float64(-1)}, defaultVal: 4000, expectedErrMsg: "max_bytes must not be negative, got -1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := optionalByteLimitParam(createMCPRequest(tc.params), "max_bytes", tc.defaultVal)
+			if tc.expectedErrMsg != "" {
+				require.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
 func Test_OptionalBooleanParam(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -478,12 +655,72 @@ func TestOptionalStringArrayParam(t *testing.T) {
 	}
 }
 
-func TestOptionalPaginationParams(t *testing.T) {
+func TestOptionalInt64ArrayParam(t *testing.T) {
 	tests := []struct {
 		name        string
-		params      map[string]any
-		expected    PaginationParams
+		params      map[string]interface{}
+		paramName   string
+		expected    []int64
 		expectError bool
+	}{
+		{
+			name:        "parameter not in request",
+			params:      map[string]any{},
+			paramName:   "ids",
+			expected:    []int64{},
+			expectError: false,
+		},
+		{
+			name: "valid any array parameter",
+			params: map[string]any{
+				"ids": []any{float64(1), float64(2)},
+			},
+			paramName:   "ids",
+			expected:    []int64{1, 2},
+			expectError: false,
+		},
+		{
+			name: "wrong type parameter",
+			params: map[string]any{
+				"ids": "1,2",
+			},
+			paramName:   "ids",
+			expected:    []int64{},
+			expectError: true,
+		},
+		{
+			name: "wrong slice type parameter",
+			params: map[string]any{
+				"ids": []any{float64(1), "2"},
+			},
+			paramName:   "ids",
+			expected:    []int64{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.params)
+			result, err := OptionalInt64ArrayParam(request, tc.paramName)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestOptionalPaginationParams(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         map[string]any
+		expected       PaginationParams
+		expectError    bool
+		expectedErrMsg string
 	}{
 		{
 			name:   "no pagination parameters, default values",
@@ -544,6 +781,67 @@ func TestOptionalPaginationParams(t *testing.T) {
 			expected:    PaginationParams{},
 			expectError: true,
 		},
+		{
+			name: "smallest page and perPage",
+			params: map[string]any{
+				"page":    float64(1),
+				"perPage": float64(1),
+			},
+			expected: PaginationParams{
+				Page:    1,
+				PerPage: 1,
+			},
+		},
+		{
+			name: "largest perPage",
+			params: map[string]any{
+				"perPage": float64(100),
+			},
+			expected: PaginationParams{
+				Page:    1,
+				PerPage: 100,
+			},
+		},
+		{
+			name: "page zero",
+			params: map[string]any{
+				"page": float64(0),
+			},
+			expectError:    true,
+			expectedErrMsg: "page must be at least 1, got 0",
+		},
+		{
+			name: "negative page",
+			params: map[string]any{
+				"page": float64(-2),
+			},
+			expectError:    true,
+			expectedErrMsg: "page must be at least 1, got -2",
+		},
+		{
+			name: "perPage zero",
+			params: map[string]any{
+				"perPage": float64(0),
+			},
+			expectError:    true,
+			expectedErrMsg: "perPage must be between 1 and 100, got 0",
+		},
+		{
+			name: "perPage just above maximum",
+			params: map[string]any{
+				"perPage": float64(101),
+			},
+			expectError:    true,
+			expectedErrMsg: "perPage must be between 1 and 100, got 101",
+		},
+		{
+			name: "perPage far above maximum",
+			params: map[string]any{
+				"perPage": float64(500),
+			},
+			expectError:    true,
+			expectedErrMsg: "perPage must be between 1 and 100, got 500",
+		},
 	}
 
 	for _, tc := range tests {
@@ -553,6 +851,9 @@ func TestOptionalPaginationParams(t *testing.T) {
 
 			if tc.expectError {
 				assert.Error(t, err)
+				if tc.expectedErrMsg != "" {
+					assert.EqualError(t, err, tc.expectedErrMsg)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expected, result)
@@ -560,3 +861,320 @@ func TestOptionalPaginationParams(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionalCursorPaginationParams(t *testing.T) {
+	result, err := OptionalCursorPaginationParams(createMCPRequest(map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, CursorPaginationParams{PerPage: 30}, result)
+
+	result, err = OptionalCursorPaginationParams(createMCPRequest(map[string]any{"perPage": float64(100), "after": "Y3Vyc29yOjE="}))
+	require.NoError(t, err)
+	assert.Equal(t, CursorPaginationParams{PerPage: 100, After: "Y3Vyc29yOjE="}, result)
+
+	_, err = OptionalCursorPaginationParams(createMCPRequest(map[string]any{"perPage": float64(0)}))
+	assert.EqualError(t, err, "perPage must be between 1 and 100, got 0")
+
+	_, err = OptionalCursorPaginationParams(createMCPRequest(map[string]any{"perPage": float64(101)}))
+	assert.EqualError(t, err, "perPage must be between 1 and 100, got 101")
+}
+
+func TestCursorNextCall(t *testing.T) {
+	assert.Equal(t, &NextCall{After: "Y3Vyc29yOjE=", PerPage: 25}, CursorNextCall(true, "Y3Vyc29yOjE=", 25))
+	assert.Nil(t, CursorNextCall(false, "Y3Vyc29yOjE=", 25), "no next call on the last page")
+	assert.Nil(t, CursorNextCall(true, "", 25), "no next call without a cursor")
+}
+
+func TestCursorPaginationParamsToGraphQLParams(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      CursorPaginationParams
+		expectedErr string
+	}{
+		{
+			name:   "no cursor",
+			params: CursorPaginationParams{PerPage: 30},
+		},
+		{
+			name:   "cursor from a previous page",
+			params: CursorPaginationParams{PerPage: 30, After: "Y3Vyc29yOnYyOpK5MjAyMy0wMS0wMVQwMDowMDowMFo="},
+		},
+		{
+			name:   "unpadded cursor",
+			params: CursorPaginationParams{PerPage: 30, After: "Y3Vyc29yOjE"},
+		},
+		{
+			name:        "perPage exceeds maximum",
+			params:      CursorPaginationParams{PerPage: 101},
+			expectedErr: "perPage value 101 exceeds maximum of 100",
+		},
+		{
+			name:        "negative perPage",
+			params:      CursorPaginationParams{PerPage: -1},
+			expectedErr: "perPage value -1 cannot be negative",
+		},
+		{
+			name:        "garbage cursor",
+			params:      CursorPaginationParams{PerPage: 30, After: "not a cursor!"},
+			expectedErr: "invalid cursor",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.params.ToGraphQLParams()
+
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int32(tc.params.PerPage), *result.First)
+			if tc.params.After == "" {
+				assert.Nil(t, result.After)
+			} else {
+				assert.Equal(t, tc.params.After, *result.After)
+			}
+		})
+	}
+}
+
+func Test_HandleRESTResponse(t *testing.T) {
+	newResponse := func(statusCode int, body string) *github.Response {
+		return &github.Response{
+			Response: &http.Response{
+				StatusCode: statusCode,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		resp             *github.Response
+		err              error
+		expectedStatuses []int
+		expectOK         bool
+		expectedErrMsg   string
+		expectedGoErr    string
+	}{
+		{
+			name:     "success with default expected status",
+			resp:     newResponse(http.StatusOK, `{"name":"foo"}`),
+			expectOK: true,
+		},
+		{
+			name:             "success with one of several expected statuses",
+			resp:             newResponse(http.StatusCreated, `{"name":"foo"}`),
+			expectedStatuses: []int{http.StatusOK, http.StatusCreated},
+			expectOK:         true,
+		},
+		{
+			name:           "API error",
+			resp:           newResponse(http.StatusNotFound, `{"message":"Not Found"}`),
+			err:            errors.New("404 Not Found"),
+			expectedErrMsg: "failed to get item: 404 Not Found",
+		},
+		{
+			name:           "API error without a response",
+			err:            errors.New("connection refused"),
+			expectedErrMsg: "failed to get item: connection refused",
+		},
+		{
+			name:           "unexpected status",
+			resp:           newResponse(http.StatusAccepted, `still processing`),
+			expectedErrMsg: "failed to get item: still processing",
+		},
+		{
+			name:             "unexpected status with custom expected status",
+			resp:             newResponse(http.StatusOK, `not created`),
+			expectedStatuses: []int{http.StatusCreated},
+			expectedErrMsg:   "failed to get item: not created",
+		},
+		{
+			name: "unreadable body of an unexpected status",
+			resp: &github.Response{
+				Response: &http.Response{
+					StatusCode: http.StatusAccepted,
+					Body:       io.NopCloser(iotest.ErrReader(errors.New("connection reset"))),
+				},
+			},
+			expectedGoErr: "failed to read response body: connection reset",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, ok, err := handleRESTResponse(context.Background(), "failed to get item", tc.resp, tc.err, tc.expectedStatuses...)
+
+			if tc.expectOK {
+				assert.True(t, ok)
+				assert.Nil(t, result)
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.False(t, ok)
+			if tc.expectedGoErr != "" {
+				// As before the helper existed, a body that cannot be read is a Go error, not a tool error
+				assert.Nil(t, result)
+				assert.EqualError(t, err, tc.expectedGoErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.True(t, result.IsError)
+			assert.Equal(t, tc.expectedErrMsg, getErrorResult(t, result).Text)
+		})
+	}
+}
+
+// Test_RESTErrorMessagesAreUnchanged pins what handlers that moved onto handleRESTResponse return for an
+// unexpected status, so that clients matching on it keep working.
+func Test_RESTErrorMessagesAreUnchanged(t *testing.T) {
+	// go-github accepts a 2xx status other than 202, so 203 reaches the handlers' own status check. By then
+	// go-github has decoded and closed the body, so reading it fails, which is a Go error rather than a tool one.
+	nonAuthoritative := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNonAuthoritativeInfo)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	tests := []struct {
+		name         string
+		handler      func(*github.Client) server.ToolHandlerFunc
+		mockedClient *http.Client
+		requestArgs  map[string]any
+		expectedErr  string
+	}{
+		{
+			name: "get_pull_request unexpected status",
+			handler: func(client *github.Client) server.ToolHandlerFunc {
+				_, handler := GetPullRequest(stubGetClientFn(client), translations.NullTranslationHelper)
+				return handler
+			},
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposPullsByOwnerByRepoByPullNumber, nonAuthoritative),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)},
+			expectedErr: "failed to read response body: http: read on closed response body",
+		},
+		{
+			name: "delete_file base commit unexpected status",
+			handler: func(client *github.Client) server.ToolHandlerFunc {
+				_, handler := DeleteFile(stubGetClientFn(client), translations.NullTranslationHelper)
+				return handler
+			},
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					&github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr("abc123")}},
+				),
+				mock.WithRequestMatchHandler(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, nonAuthoritative),
+			),
+			requestArgs: map[string]any{"owner": "owner", "repo": "repo", "path": "README.md", "message": "Delete", "branch": "main"},
+			expectedErr: "failed to read response body: http: read on closed response body",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := tc.handler(github.NewClient(tc.mockedClient))
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			assert.Nil(t, result)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMarshalledTextResultEmptiesNilSlices(t *testing.T) {
+	type envelope struct {
+		Items []string `json:"items"`
+		Total int      `json:"total"`
+	}
+
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "nil slice", value: []*github.Branch(nil), expected: `[]`},
+		{name: "non-nil slice", value: []string{"a"}, expected: `["a"]`},
+		{name: "struct field", value: envelope{Total: 0}, expected: `{"items":[],"total":0}`},
+		{name: "pointer to struct field", value: &envelope{Total: 0}, expected: `{"items":[],"total":0}`},
+		{name: "nil pointer", value: (*envelope)(nil), expected: `null`},
+		{name: "map", value: map[string]int{"a": 1}, expected: `{"a":1}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := MarshalledTextResult(tc.value)
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expected, getTextResult(t, result).Text)
+		})
+	}
+
+	// The value passed in is not modified.
+	original := &envelope{}
+	_ = MarshalledTextResult(original)
+	assert.Nil(t, original.Items)
+}
+
+func TestListToolsReturnEmptyArrays(t *testing.T) {
+	// The API answering null must not reach the model as null.
+	tests := []struct {
+		name    string
+		handler func(client *github.Client) server.ToolHandlerFunc
+		pattern mock.EndpointPattern
+	}{
+		{
+			name: "list_commits",
+			handler: func(client *github.Client) server.ToolHandlerFunc {
+				_, handler := ListCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+				return handler
+			},
+			pattern: mock.GetReposCommitsByOwnerByRepo,
+		},
+		{
+			name: "list_branches",
+			handler: func(client *github.Client) server.ToolHandlerFunc {
+				_, handler := ListBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+				return handler
+			},
+			pattern: mock.GetReposBranchesByOwnerByRepo,
+		},
+		{
+			name: "list_pull_requests",
+			handler: func(client *github.Client) server.ToolHandlerFunc {
+				_, handler := ListPullRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+				return handler
+			},
+			pattern: mock.GetReposPullsByOwnerByRepo,
+		},
+	}
+
+	for _, tc := range tests {
+		for _, body := range []string{`null`, `[]`} {
+			t.Run(tc.name+" "+body, func(t *testing.T) {
+				client := github.NewClient(mock.NewMockedHTTPClient(
+					mock.WithRequestMatchHandler(
+						tc.pattern,
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusOK)
+							_, _ = w.Write([]byte(body))
+						}),
+					),
+				))
+
+				result, err := tc.handler(client)(context.Background(), createMCPRequest(map[string]any{
+					"owner": "owner",
+					"repo":  "repo",
+				}))
+				require.NoError(t, err)
+				require.False(t, result.IsError)
+				assert.Equal(t, "[]", getTextResult(t, result).Text)
+			})
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultStatusContext is the context of commit statuses created without one.
+	defaultStatusContext = "github-mcp-server"
+	// maxStatusDescriptionLength is the longest description, in characters, that the API accepts for a commit status.
+	maxStatusDescriptionLength = 140
+)
+
+var commitStatusStates = []string{"error", "failure", "pending", "success"}
+
+// CreateCommitStatus creates a tool to set a commit status on a commit.
+func CreateCommitStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_commit_status",
+			mcp.WithDescription(t("TOOL_CREATE_COMMIT_STATUS_DESCRIPTION", "Set a commit status on a commit, e.g. to report the result of an external CI job. A later status with the same context replaces the earlier one.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_COMMIT_STATUS_USER_TITLE", "Create commit status"),
+				ReadOnlyHint: ToBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit"),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("State of the status"),
+				mcp.Enum(commitStatusStates...),
+			),
+			mcp.WithString("target_url",
+				mcp.Description("URL to link the status to, e.g. the CI job's log"),
+			),
+			mcp.WithString("description",
+				mcp.Description(fmt.Sprintf("Short description of the status, at most %d characters", maxStatusDescriptionLength)),
+			),
+			mcp.WithString("context",
+				mcp.Description("Label that tells this status apart from the statuses of other systems"),
+				mcp.DefaultString(defaultStatusContext),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := RequiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := RequiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !slices.Contains(commitStatusStates, state) {
+				return mcp.NewToolResultError(fmt.Sprintf("unknown state %q, valid states are: %s", state, strings.Join(commitStatusStates, ", "))), nil
+			}
+			targetURL, err := OptionalParam[string](request, "target_url")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if length := utf8.RuneCountInString(description); length > maxStatusDescriptionLength {
+				return mcp.NewToolResultError(fmt.Sprintf("description is %d characters long, more than the limit of %d", length, maxStatusDescriptionLength)), nil
+			}
+			statusContext, err := OptionalParam[string](request, "context")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if statusContext == "" {
+				statusContext = defaultStatusContext
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			status := &github.RepoStatus{
+				State:   github.Ptr(state),
+				Context: github.Ptr(statusContext),
+			}
+			if targetURL != "" {
+				status.TargetURL = github.Ptr(targetURL)
+			}
+			if description != "" {
+				status.Description = github.Ptr(description)
+			}
+			created, resp, err := client.Repositories.CreateStatus(ctx, owner, repo, sha, status)
+			if result, ok, err := handleRESTResponse(ctx, "failed to create commit status", resp, err, http.StatusCreated); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(created), nil
+		}
+}
@@ -0,0 +1,190 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateCommitStatus(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommitStatus(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_commit_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "target_url")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "context")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "state"})
+
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedStatus *github.RepoStatus
+		expectedErrMsg string
+	}{
+		{
+			name: "uses the default context",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					expectRequestBody(t, map[string]any{
+						"state":   "pending",
+						"context": "github-mcp-server",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepoStatus{
+							ID:      github.Ptr(int64(1)),
+							State:   github.Ptr("pending"),
+							Context: github.Ptr("github-mcp-server"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   sha,
+				"state": "pending",
+			},
+			expectedStatus: &github.RepoStatus{
+				ID:      github.Ptr(int64(1)),
+				State:   github.Ptr("pending"),
+				Context: github.Ptr("github-mcp-server"),
+			},
+		},
+		{
+			name: "sends all fields",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					expectRequestBody(t, map[string]any{
+						"state":       "failure",
+						"target_url":  "https://ci.example.com/jobs/42",
+						"description": "2 of 120 tests failed",
+						"context":     "ci/integration",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepoStatus{
+							ID:          github.Ptr(int64(2)),
+							State:       github.Ptr("failure"),
+							TargetURL:   github.Ptr("https://ci.example.com/jobs/42"),
+							Description: github.Ptr("2 of 120 tests failed"),
+							Context:     github.Ptr("ci/integration"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"sha":         sha,
+				"state":       "failure",
+				"target_url":  "https://ci.example.com/jobs/42",
+				"description": "2 of 120 tests failed",
+				"context":     "ci/integration",
+			},
+			expectedStatus: &github.RepoStatus{
+				ID:          github.Ptr(int64(2)),
+				State:       github.Ptr("failure"),
+				TargetURL:   github.Ptr("https://ci.example.com/jobs/42"),
+				Description: github.Ptr("2 of 120 tests failed"),
+				Context:     github.Ptr("ci/integration"),
+			},
+		},
+		{
+			name:         "rejects a description over 140 characters",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"sha":         sha,
+				"state":       "success",
+				"description": strings.Repeat("é", 141),
+			},
+			expectError:    true,
+			expectedErrMsg: "description is 141 characters long, more than the limit of 140",
+		},
+		{
+			name:         "rejects an unknown state",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   sha,
+				"state": "passed",
+			},
+			expectError:    true,
+			expectedErrMsg: `unknown state "passed", valid states are: error, failure, pending, success`,
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposStatusesByOwnerByRepoBySha,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: 6dcb09b"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   sha,
+				"state": "success",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create commit status",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommitStatus(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var status github.RepoStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, tc.expectedStatus, &status)
+		})
+	}
+}
+
+func Test_CreateCommitStatusIsNotOfferedReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		tsg, err := DefaultToolsetGroup(ServerInfo{ReadOnly: readOnly}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+		require.NoError(t, err)
+		repos, err := tsg.GetToolset("repos")
+		require.NoError(t, err)
+
+		var names []string
+		for _, st := range repos.GetAvailableTools() {
+			names = append(names, st.Tool.Name)
+		}
+		if readOnly {
+			assert.NotContains(t, names, "create_commit_status")
+		} else {
+			assert.Contains(t, names, "create_commit_status")
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Team is a team of an organization as returned by list_teams.
+type Team struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Privacy     string `json:"privacy,omitempty"`
+	Parent      string `json:"parent,omitempty"`
+	HTMLURL     string `json:"html_url"`
+}
+
+// TeamsResult is a page of the teams of an organization.
+type TeamsResult struct {
+	Teams []Team `json:"teams"`
+	NextPageInfo
+}
+
+// TeamMembership is the membership of a user in a team. State is "pending" while the user has not
+// yet accepted an invitation to the organization, and "active" once they are a member.
+type TeamMembership struct {
+	Username string `json:"username"`
+	Team     string `json:"team"`
+	Role     string `json:"role"`
+	State    string `json:"state"`
+}
+
+// ListTeams creates a tool to list the teams of an organization.
+func ListTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_teams",
+			mcp.WithDescription(t("TOOL_LIST_TEAMS_DESCRIPTION", "List the teams of an organization that the authenticated user can see, with the slugs the other team tools take. Check has_next_page and fetch next_page to get the rest.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAMS_USER_TITLE", "List teams"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			teams, resp, err := client.Teams.ListTeams(ctx, org, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if result, ok, err := handleRESTResponse(ctx, "failed to list teams", resp, err); !ok {
+				return result, err
+			}
+
+			result := TeamsResult{
+				Teams:        make([]Team, 0, len(teams)),
+				NextPageInfo: nextPageInfo(resp),
+			}
+			for _, team := range teams {
+				result.Teams = append(result.Teams, Team{
+					Slug:        team.GetSlug(),
+					Name:        team.GetName(),
+					Description: team.GetDescription(),
+					Privacy:     team.GetPrivacy(),
+					Parent:      team.GetParent().GetSlug(),
+					HTMLURL:     team.GetHTMLURL(),
+				})
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// ListTeamMembers creates a tool to list the members of a team.
+func ListTeamMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_members",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_MEMBERS_DESCRIPTION", "List the members of a team, including the members of its child teams. Check has_next_page and fetch next_page to get the rest.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_MEMBERS_USER_TITLE", "List team members"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Team slug"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Only list members with this role in the team"),
+				mcp.Enum("all", "member", "maintainer"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			members, resp, err := client.Teams.ListTeamMembersBySlug(ctx, org, teamSlug, &github.TeamListTeamMembersOptions{
+				Role: role,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			})
+			if result, ok, err := handleRESTResponse(ctx, "failed to list team members", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(OrgMembersResult{
+				Members:      newOrgMembers(members),
+				NextPageInfo: nextPageInfo(resp),
+			}), nil
+		}
+}
+
+// GetTeamMembershipForUser creates a tool to check whether a user is a member of a team.
+func GetTeamMembershipForUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_team_membership_for_user",
+			mcp.WithDescription(t("TOOL_GET_TEAM_MEMBERSHIP_FOR_USER_DESCRIPTION", "Check whether a user is a member of a team, e.g. before requesting their review on behalf of the team. Returns the user's role and membership state, or says that the user is not a member.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_TEAM_MEMBERSHIP_FOR_USER_USER_TITLE", "Get team membership for user"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Team slug"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Username to check"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := RequiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API answers 404 both when the user is not a member and when the team cannot be
+			// seen, and the first is the answer being asked for.
+			membership, resp, err := client.Teams.GetTeamMembershipBySlug(ctx, org, teamSlug, username)
+			if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultText(fmt.Sprintf("%s is not a member of the team %s/%s, or the team does not exist or is not visible to you", username, org, teamSlug)), nil
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to get team membership", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(TeamMembership{
+				Username: username,
+				Team:     org + "/" + teamSlug,
+				Role:     membership.GetRole(),
+				State:    membership.GetState(),
+			}), nil
+		}
+}
@@ -0,0 +1,291 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_teams", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockTeams := []*github.Team{
+		{
+			Slug:        github.Ptr("reviewers"),
+			Name:        github.Ptr("Reviewers"),
+			Description: github.Ptr("Reviews all pull requests"),
+			Privacy:     github.Ptr("closed"),
+			HTMLURL:     github.Ptr("https://github.com/orgs/octo-org/teams/reviewers"),
+		},
+		{
+			Slug:    github.Ptr("go-reviewers"),
+			Name:    github.Ptr("Go reviewers"),
+			Privacy: github.Ptr("closed"),
+			Parent:  &github.Team{Slug: github.Ptr("reviewers")},
+			HTMLURL: github.Ptr("https://github.com/orgs/octo-org/teams/go-reviewers"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult TeamsResult
+		expectedErrMsg string
+	}{
+		{
+			name: "lists a page of teams and reports the next page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "2",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/orgs/octo-org/teams?page=3&per_page=2>; rel="next"`)
+							_ = json.NewEncoder(w).Encode(mockTeams)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "octo-org",
+				"page":    float64(2),
+				"perPage": float64(2),
+			},
+			expectedResult: TeamsResult{
+				Teams: []Team{
+					{Slug: "reviewers", Name: "Reviewers", Description: "Reviews all pull requests", Privacy: "closed", HTMLURL: "https://github.com/orgs/octo-org/teams/reviewers"},
+					{Slug: "go-reviewers", Name: "Go reviewers", Privacy: "closed", Parent: "reviewers", HTMLURL: "https://github.com/orgs/octo-org/teams/go-reviewers"},
+				},
+				NextPageInfo: NextPageInfo{HasNextPage: true, NextPage: 3},
+			},
+		},
+		{
+			name: "last page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetOrgsTeamsByOrg, []*github.Team{}),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectedResult: TeamsResult{Teams: []Team{}},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "no-such-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list teams",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned TeamsResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_ListTeamMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_team_members", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult OrgMembersResult
+		expectedErrMsg string
+	}{
+		{
+			name: "lists maintainers and reports the next page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					expectQueryParams(t, map[string]string{
+						"role":     "maintainer",
+						"page":     "1",
+						"per_page": "1",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/organizations/1/team/2/members?role=maintainer&page=2&per_page=1>; rel="next"`)
+							_ = json.NewEncoder(w).Encode([]*github.User{
+								{Login: github.Ptr("octocat"), Type: github.Ptr("User"), HTMLURL: github.Ptr("https://github.com/octocat")},
+							})
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "reviewers",
+				"role":      "maintainer",
+				"perPage":   float64(1),
+			},
+			expectedResult: OrgMembersResult{
+				Members:      []OrgMember{{Login: "octocat", Type: "User", HTMLURL: "https://github.com/octocat"}},
+				NextPageInfo: NextPageInfo{HasNextPage: true, NextPage: 2},
+			},
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "no-such-team",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list team members",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTeamMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned OrgMembersResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_GetTeamMembershipForUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetTeamMembershipForUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_team_membership_for_user", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "username"})
+
+	requestArgs := map[string]interface{}{
+		"org":       "octo-org",
+		"team_slug": "reviewers",
+		"username":  "octocat",
+	}
+
+	t.Run("member", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(
+				mock.GetOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+				&github.Membership{Role: github.Ptr("maintainer"), State: github.Ptr("active")},
+			),
+		))
+		_, handler := GetTeamMembershipForUser(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(requestArgs))
+		require.NoError(t, err)
+
+		var membership TeamMembership
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &membership))
+		assert.Equal(t, TeamMembership{
+			Username: "octocat",
+			Team:     "octo-org/reviewers",
+			Role:     "maintainer",
+			State:    "active",
+		}, membership)
+	})
+
+	t.Run("not a member is an answer, not an error", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			),
+		))
+		_, handler := GetTeamMembershipForUser(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(requestArgs))
+		require.NoError(t, err)
+
+		text := getTextResult(t, result).Text
+		assert.Contains(t, text, "octocat is not a member of the team octo-org/reviewers")
+	})
+
+	t.Run("other failures are errors", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+				mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+			),
+		))
+		_, handler := GetTeamMembershipForUser(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(requestArgs))
+		require.NoError(t, err)
+
+		errorContent := getErrorResult(t, result)
+		assert.Contains(t, errorContent.Text, "failed to get team membership")
+	})
+}
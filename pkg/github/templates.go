@@ -0,0 +1,195 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ListGitignoreTemplates creates a tool to list the .gitignore templates available on GitHub.
+func ListGitignoreTemplates(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_gitignore_templates",
+			mcp.WithDescription(t("TOOL_LIST_GITIGNORE_TEMPLATES_DESCRIPTION", "List the names of the .gitignore templates that can be used when creating a repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_GITIGNORE_TEMPLATES_USER_TITLE", "List .gitignore templates"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			names, resp, err := client.Gitignores.List(ctx)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list .gitignore templates", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(names), nil
+		}
+}
+
+// GetGitignoreTemplate creates a tool to get a .gitignore template by name.
+func GetGitignoreTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_gitignore_template",
+			mcp.WithDescription(t("TOOL_GET_GITIGNORE_TEMPLATE_DESCRIPTION", "Get the content of a .gitignore template by name")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GITIGNORE_TEMPLATE_USER_TITLE", "Get .gitignore template"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Template name, as returned by list_gitignore_templates (e.g. 'Go' or 'Node')"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := RequiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			template, resp, err := client.Gitignores.Get(ctx, name)
+			if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+				if names, listErr := listGitignoreTemplateNames(ctx, client); listErr == nil {
+					return unknownTemplateResult("gitignore", name, names), nil
+				}
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to get .gitignore template", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(template), nil
+		}
+}
+
+// ListLicenseTemplates creates a tool to list the license templates available on GitHub.
+func ListLicenseTemplates(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_license_templates",
+			mcp.WithDescription(t("TOOL_LIST_LICENSE_TEMPLATES_DESCRIPTION", "List the commonly used license templates that can be used when creating a repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_LICENSE_TEMPLATES_USER_TITLE", "List license templates"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+		),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			licenses, resp, err := client.Licenses.List(ctx)
+			if result, ok, err := handleRESTResponse(ctx, "failed to list license templates", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(licenses), nil
+		}
+}
+
+// GetLicenseTemplate creates a tool to get a license template by key.
+func GetLicenseTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_license_template",
+			mcp.WithDescription(t("TOOL_GET_LICENSE_TEMPLATE_DESCRIPTION", "Get a license template, including its full text, by key")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_LICENSE_TEMPLATE_USER_TITLE", "Get license template"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("license",
+				mcp.Required(),
+				mcp.Description("License key, as returned by list_license_templates (e.g. 'mit' or 'apache-2.0')"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			key, err := RequiredParam[string](request, "license")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			license, resp, err := client.Licenses.Get(ctx, key)
+			if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+				if keys, listErr := listLicenseTemplateKeys(ctx, client); listErr == nil {
+					return unknownTemplateResult("license", key, keys), nil
+				}
+			}
+			if result, ok, err := handleRESTResponse(ctx, "failed to get license template", resp, err); !ok {
+				return result, err
+			}
+
+			return MarshalledTextResult(license), nil
+		}
+}
+
+func listGitignoreTemplateNames(ctx context.Context, client *github.Client) ([]string, error) {
+	names, resp, err := client.Gitignores.List(ctx)
+	closeResponseBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list .gitignore templates: %w", err)
+	}
+	return names, nil
+}
+
+func listLicenseTemplateKeys(ctx context.Context, client *github.Client) ([]string, error) {
+	licenses, resp, err := client.Licenses.List(ctx)
+	closeResponseBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list license templates: %w", err)
+	}
+	keys := make([]string, 0, len(licenses))
+	for _, license := range licenses {
+		keys = append(keys, license.GetKey())
+	}
+	return keys, nil
+}
+
+// matchTemplate returns the template in available that name refers to, ignoring case.
+func matchTemplate(name string, available []string) (string, bool) {
+	for _, template := range available {
+		if strings.EqualFold(template, name) {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+// templateSuggestions returns the templates in available that start with name, ignoring case. If there
+// are none, it returns the templates that name starts with instead, so that "Python3" suggests "Python".
+func templateSuggestions(name string, available []string) []string {
+	lower := strings.ToLower(name)
+	var suggestions []string
+	for _, template := range available {
+		if strings.HasPrefix(strings.ToLower(template), lower) {
+			suggestions = append(suggestions, template)
+		}
+	}
+	if len(suggestions) > 0 {
+		return suggestions
+	}
+	for _, template := range available {
+		if template != "" && strings.HasPrefix(lower, strings.ToLower(template)) {
+			suggestions = append(suggestions, template)
+		}
+	}
+	return suggestions
+}
+
+// unknownTemplateResult reports a template name that does not exist, with the closest matches if any.
+func unknownTemplateResult(kind, name string, available []string) *mcp.CallToolResult {
+	suggestions := templateSuggestions(name, available)
+	if len(suggestions) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("unknown %s template %q; use list_%s_templates to see the available templates", kind, name, kind))
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("unknown %s template %q; did you mean: %s", kind, name, strings.Join(suggestions, ", ")))
+}
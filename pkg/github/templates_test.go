@@ -0,0 +1,297 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListGitignoreTemplates(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListGitignoreTemplates(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_gitignore_templates", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetGitignoreTemplates,
+			[]string{"Go", "Node", "Python"},
+		),
+	))
+	_, handler := ListGitignoreTemplates(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var names []string
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &names))
+	assert.Equal(t, []string{"Go", "Node", "Python"}, names)
+}
+
+func Test_GetGitignoreTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitignoreTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_gitignore_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	mockTemplate := &github.Gitignore{
+		Name:   github.Ptr("Go"),
+		Source: github.Ptr("# Binaries\n*.exe\n"),
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedTemplate *github.Gitignore
+		expectedErrMsg   string
+	}{
+		{
+			name: "successful template fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetGitignoreTemplatesByName,
+					mockTemplate,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "Go",
+			},
+			expectedTemplate: mockTemplate,
+		},
+		{
+			name: "unknown template suggests close matches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGitignoreTemplatesByName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetGitignoreTemplates,
+					[]string{"Go", "Godot", "Node"},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "Go-lang",
+			},
+			expectError:    true,
+			expectedErrMsg: `unknown gitignore template "Go-lang"; did you mean: Go`,
+		},
+		{
+			name: "unknown template without close matches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGitignoreTemplatesByName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetGitignoreTemplates,
+					[]string{"Go", "Node"},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"name": "Rust",
+			},
+			expectError:    true,
+			expectedErrMsg: "use list_gitignore_templates to see the available templates",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGitignoreTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.Gitignore
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, *tc.expectedTemplate, returned)
+		})
+	}
+}
+
+func Test_ListLicenseTemplates(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListLicenseTemplates(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_license_templates", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockLicenses := []*github.License{
+		{Key: github.Ptr("apache-2.0"), Name: github.Ptr("Apache License 2.0"), SPDXID: github.Ptr("Apache-2.0")},
+		{Key: github.Ptr("mit"), Name: github.Ptr("MIT License"), SPDXID: github.Ptr("MIT")},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetLicenses,
+			mockLicenses,
+		),
+	))
+	_, handler := ListLicenseTemplates(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var licenses []*github.License
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &licenses))
+	require.Len(t, licenses, 2)
+	assert.Equal(t, "apache-2.0", licenses[0].GetKey())
+	assert.Equal(t, "MIT", licenses[1].GetSPDXID())
+}
+
+func Test_GetLicenseTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetLicenseTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_license_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "license")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"license"})
+
+	mockLicense := &github.License{
+		Key:  github.Ptr("mit"),
+		Name: github.Ptr("MIT License"),
+		Body: github.Ptr("MIT License\n\nCopyright (c) [year] [fullname]\n"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedLicense *github.License
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful license fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetLicensesByLicense,
+					mockLicense,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"license": "mit",
+			},
+			expectedLicense: mockLicense,
+		},
+		{
+			name: "unknown license suggests close matches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetLicensesByLicense,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+				mock.WithRequestMatch(
+					mock.GetLicenses,
+					[]*github.License{
+						{Key: github.Ptr("gpl-2.0")},
+						{Key: github.Ptr("gpl-3.0")},
+						{Key: github.Ptr("mit")},
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"license": "gpl",
+			},
+			expectError:    true,
+			expectedErrMsg: `unknown license template "gpl"; did you mean: gpl-2.0, gpl-3.0`,
+		},
+		{
+			name: "license fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetLicensesByLicense,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal Server Error"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"license": "mit",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get license template",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetLicenseTemplate(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned github.License
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedLicense.GetKey(), returned.GetKey())
+			assert.Equal(t, tc.expectedLicense.GetBody(), returned.GetBody())
+		})
+	}
+}
+
+func Test_TemplateSuggestions(t *testing.T) {
+	available := []string{"Go", "Godot", "Node", "Python"}
+
+	assert.Equal(t, []string{"Go", "Godot"}, templateSuggestions("go", available))
+	assert.Equal(t, []string{"Python"}, templateSuggestions("Python3", available))
+	assert.Empty(t, templateSuggestions("Rust", available))
+
+	template, found := matchTemplate("python", available)
+	assert.True(t, found)
+	assert.Equal(t, "Python", template)
+	_, found = matchTemplate("Pyth", available)
+	assert.False(t, found)
+}
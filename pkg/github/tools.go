@@ -16,29 +16,75 @@ type GetGQLClientFn func(context.Context) (*githubv4.Client, error)
 
 var DefaultTools = []string{"all"}
 
-func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) *toolsets.ToolsetGroup {
-	tsg := toolsets.NewToolsetGroup(readOnly)
+// ServerInfo describes how the server was configured. It is retained when the toolset group is
+// constructed so that it can be inspected later, e.g. by the get_server_info tool.
+type ServerInfo struct {
+	// Version of the server
+	Version string
+
+	// Host is the configured GitHub host, empty for github.com
+	Host string
+
+	// ReadOnly indicates if only read-only tools are offered
+	ReadOnly bool
+
+	// ProtectDefaultBranch indicates if file write tools refuse to write to a repository's default branch
+	ProtectDefaultBranch bool
+
+	// GistMaxBytes limits the total size of the files of a created gist, DefaultGistMaxBytes if zero
+	GistMaxBytes int
+
+	// ReleaseAssetMaxBytes limits the size of a release asset download, DefaultReleaseAssetMaxBytes if zero
+	ReleaseAssetMaxBytes int
+}
+
+func DefaultToolsetGroup(info ServerInfo, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (*toolsets.ToolsetGroup, error) {
+	tsg := toolsets.NewToolsetGroup(info.ReadOnly)
+	branchGuard := NewDefaultBranchGuard(info.ProtectDefaultBranch)
 
 	// Define all available features with their default state (disabled)
 	// Create toolsets
 	repos := toolsets.NewToolset("repos", "GitHub Repository related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
+			toolsets.NewServerTool(GetRepository(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryTopics(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
+			toolsets.NewServerTool(GetRefCIState(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
+			toolsets.NewServerTool(CompareCommits(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
+			toolsets.NewServerTool(GetBranchProtection(getClient, t)),
+			toolsets.NewServerTool(ListCollaborators(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryActivitySummary(getClient, t)),
+			toolsets.NewServerTool(GenerateReleaseNotesPreview(getClient, t)),
+			toolsets.NewServerTool(DownloadReleaseAsset(getClient, info.ReleaseAssetMaxBytes, t)),
+			toolsets.NewServerTool(ListGitignoreTemplates(getClient, t)),
+			toolsets.NewServerTool(GetGitignoreTemplate(getClient, t)),
+			toolsets.NewServerTool(ListLicenseTemplates(getClient, t)),
+			toolsets.NewServerTool(GetLicenseTemplate(getClient, t)),
+			toolsets.NewServerTool(GetRepositoryCustomPropertyValues(getClient, t)),
 		).
 		AddWriteTools(
-			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
-			toolsets.NewServerTool(CreateRepository(getClient, t)),
-			toolsets.NewServerTool(ForkRepository(getClient, t)),
+			toolsets.NewServerTool(CreateOrUpdateFile(getClient, branchGuard, t)),
+			toolsets.NewServerTool(CreateRepository(getClient, branchGuard, t)),
+			toolsets.NewServerTool(ForkRepository(getClient, branchGuard, t)),
+			toolsets.NewServerTool(TransferRepository(getClient, branchGuard, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
-			toolsets.NewServerTool(PushFiles(getClient, t)),
+			toolsets.NewServerTool(CreateTag(getClient, t)),
+			toolsets.NewServerTool(DeleteTag(getClient, t)),
+			toolsets.NewServerTool(UpdateBranchProtection(getClient, t)),
+			toolsets.NewServerTool(AddCollaborator(getClient, t)),
+			toolsets.NewServerTool(ReplaceRepositoryTopics(getClient, t)),
+			toolsets.NewServerTool(PushFiles(getClient, branchGuard, t)),
+			toolsets.NewServerTool(CommitChangesToNewBranch(getClient, branchGuard, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(CreateRelease(getClient, t)),
+			toolsets.NewServerTool(CreateCommitStatus(getClient, t)),
 		).
 		AddResourceTemplates(
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, t)),
@@ -49,16 +95,23 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		)
 	issues := toolsets.NewToolset("issues", "GitHub Issues related tools").
 		AddReadTools(
-			toolsets.NewServerTool(GetIssue(getClient, t)),
+			toolsets.NewServerTool(GetIssue(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
+			toolsets.NewServerTool(GetIssueTimeline(getClient, t)),
+			toolsets.NewServerTool(ListLabels(getClient, t)),
 			toolsets.NewServerTool(ListSubIssues(getClient, t)),
+			toolsets.NewServerTool(GetMilestoneProgress(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
+			toolsets.NewServerTool(UpdateIssueComment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, t)),
+			toolsets.NewServerTool(CreateLabel(getClient, t)),
+			toolsets.NewServerTool(AddLabelsToIssue(getClient, t)),
+			toolsets.NewServerTool(AddAssignees(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
 			toolsets.NewServerTool(AddSubIssue(getClient, t)),
 			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
@@ -71,6 +124,16 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	orgs := toolsets.NewToolset("orgs", "GitHub Organization related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchOrgs(getClient, t)),
+			toolsets.NewServerTool(GetCopilotBillingSeats(getClient, t)),
+			toolsets.NewServerTool(GetCopilotUsageSummary(getClient, t)),
+			toolsets.NewServerTool(ListOrgCustomProperties(getClient, t)),
+			toolsets.NewServerTool(ListOrgEvents(getClient, t)),
+			toolsets.NewServerTool(ListOrgRepositories(getClient, t)),
+			toolsets.NewServerTool(ListOrgMembers(getClient, t)),
+			toolsets.NewServerTool(ListTeams(getClient, t)),
+			toolsets.NewServerTool(ListTeamMembers(getClient, t)),
+			toolsets.NewServerTool(GetTeamMembershipForUser(getClient, t)),
+			toolsets.NewServerTool(GetOrgSecurityOverview(getClient, t)),
 		)
 	pullRequests := toolsets.NewToolset("pull_requests", "GitHub Pull Request related tools").
 		AddReadTools(
@@ -79,15 +142,24 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetPullRequestFiles(getClient, t)),
 			toolsets.NewServerTool(SearchPullRequests(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestStatus(getClient, t)),
+			toolsets.NewServerTool(ListOpenPullRequestsStatus(getGQLClient, t)),
+			toolsets.NewServerTool(GetRequiredStatusChecks(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestDependencyDiff(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestComments(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestReviewers(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestDiff(getClient, t)),
+			toolsets.NewServerTool(AnalyzePullRequestSize(getClient, t)),
 		).
 		AddWriteTools(
-			toolsets.NewServerTool(MergePullRequest(getClient, t)),
+			toolsets.NewServerTool(ApplyPullRequestSizeLabel(getClient, t)),
+			toolsets.NewServerTool(MergePullRequest(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(UpdatePullRequestBranch(getClient, t)),
 			toolsets.NewServerTool(CreatePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequest(getClient, t)),
+			toolsets.NewServerTool(MarkPullRequestReadyForReview(getGQLClient, t)),
+			toolsets.NewServerTool(ConvertPullRequestToDraft(getGQLClient, t)),
+			toolsets.NewServerTool(RequestPullRequestReviewers(getClient, t)),
 			toolsets.NewServerTool(RequestCopilotReview(getClient, t)),
 
 			// Reviews
@@ -96,11 +168,19 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(AddCommentToPendingReview(getGQLClient, t)),
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
+			toolsets.NewServerTool(ResolveReviewThread(getGQLClient, t)),
+			toolsets.NewServerTool(UnresolveReviewThread(getGQLClient, t)),
 		)
 	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetCodeScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListOrgCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(GetCodeScanningAlertAutofix(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateCodeScanningAlert(getClient, t)),
+			toolsets.NewServerTool(CommitCodeScanningAlertAutofix(getClient, branchGuard, t)),
 		)
 	secretProtection := toolsets.NewToolset("secret_protection", "Secret protection related tools, such as GitHub Secret Scanning").
 		AddReadTools(
@@ -111,18 +191,22 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(GetDependabotAlert(getClient, t)),
 			toolsets.NewServerTool(ListDependabotAlerts(getClient, t)),
+			toolsets.NewServerTool(ListOrgDependabotAlerts(getClient, t)),
 		)
 
 	notifications := toolsets.NewToolset("notifications", "GitHub Notifications related tools").
 		AddReadTools(
 			toolsets.NewServerTool(ListNotifications(getClient, t)),
 			toolsets.NewServerTool(GetNotificationDetails(getClient, t)),
+			toolsets.NewServerTool(GetRepositorySubscription(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(DismissNotification(getClient, t)),
 			toolsets.NewServerTool(MarkAllNotificationsRead(getClient, t)),
 			toolsets.NewServerTool(ManageNotificationSubscription(getClient, t)),
 			toolsets.NewServerTool(ManageRepositoryNotificationSubscription(getClient, t)),
+			toolsets.NewServerTool(SetRepositorySubscription(getClient, t)),
+			toolsets.NewServerTool(DeleteRepositorySubscription(getClient, t)),
 		)
 
 	discussions := toolsets.NewToolset("discussions", "GitHub Discussions related tools").
@@ -133,6 +217,14 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(ListDiscussionCategories(getGQLClient, t)),
 		)
 
+	gists := toolsets.NewToolset("gists", "GitHub Gist related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListGists(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateGist(getClient, info.GistMaxBytes, t)),
+		)
+
 	actions := toolsets.NewToolset("actions", "GitHub Actions workflows and CI/CD operations").
 		AddReadTools(
 			toolsets.NewServerTool(ListWorkflows(getClient, t)),
@@ -140,17 +232,23 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowJobs(getClient, t)),
+			toolsets.NewServerTool(ListCheckRunsForRef(getClient, t)),
 			toolsets.NewServerTool(GetJobLogs(getClient, t)),
 			toolsets.NewServerTool(ListWorkflowRunArtifacts(getClient, t)),
 			toolsets.NewServerTool(DownloadWorkflowRunArtifact(getClient, t)),
 			toolsets.NewServerTool(GetWorkflowRunUsage(getClient, t)),
+			toolsets.NewServerTool(ListPendingDeployments(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(RunWorkflow(getClient, t)),
+			toolsets.NewServerTool(CreateRepositoryDispatch(getClient, t)),
 			toolsets.NewServerTool(RerunWorkflowRun(getClient, t)),
 			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
 			toolsets.NewServerTool(CancelWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(RerequestCheckRun(getClient, t)),
+			toolsets.NewServerTool(RerequestCheckSuite(getClient, t)),
 			toolsets.NewServerTool(DeleteWorkflowRunLogs(getClient, t)),
+			toolsets.NewServerTool(ReviewPendingDeployments(getClient, t)),
 		)
 
 	// Keep experiments alive so the system doesn't error out when it's always enabled
@@ -159,24 +257,33 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	contextTools := toolsets.NewToolset("context", "Tools that provide context about the current user and GitHub context you are operating in").
 		AddReadTools(
 			toolsets.NewServerTool(GetMe(getClient, t)),
+			toolsets.NewServerTool(GetServerInfo(info, tsg, t)),
+			toolsets.NewServerTool(GetRateLimit(getClient, t)),
 		)
 
 	// Add toolsets to the group
-	tsg.AddToolset(contextTools)
-	tsg.AddToolset(repos)
-	tsg.AddToolset(issues)
-	tsg.AddToolset(orgs)
-	tsg.AddToolset(users)
-	tsg.AddToolset(pullRequests)
-	tsg.AddToolset(actions)
-	tsg.AddToolset(codeSecurity)
-	tsg.AddToolset(secretProtection)
-	tsg.AddToolset(dependabot)
-	tsg.AddToolset(notifications)
-	tsg.AddToolset(experiments)
-	tsg.AddToolset(discussions)
-
-	return tsg
+	for _, ts := range []*toolsets.Toolset{
+		contextTools,
+		repos,
+		issues,
+		orgs,
+		users,
+		pullRequests,
+		actions,
+		codeSecurity,
+		secretProtection,
+		dependabot,
+		notifications,
+		experiments,
+		discussions,
+		gists,
+	} {
+		if err := tsg.AddToolset(ts); err != nil {
+			return nil, err
+		}
+	}
+
+	return tsg, nil
 }
 
 // InitDynamicToolset creates a dynamic toolset that can be used to enable other toolsets, and so requires the server and toolset group as arguments
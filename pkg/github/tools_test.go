@@ -0,0 +1,112 @@
+package github
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v73/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DefaultToolsetGroupToolSnaps(t *testing.T) {
+	tsg, err := DefaultToolsetGroup(ServerInfo{}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+	require.NoError(t, err)
+
+	var toolNames []string
+	for _, toolset := range tsg.Toolsets() {
+		for _, st := range toolset.GetAvailableTools() {
+			toolNames = append(toolNames, st.Tool.Name)
+			t.Run(st.Tool.Name, func(t *testing.T) {
+				require.NoError(t, toolsnaps.Test(st.Tool.Name, st.Tool))
+			})
+		}
+	}
+
+	// Every snapshot must belong to a registered tool, so that renamed or removed tools are caught
+	require.NoError(t, toolsnaps.CheckOrphans(toolNames))
+}
+
+func Test_DefaultToolsetGroupToolNames(t *testing.T) {
+	tsg, err := DefaultToolsetGroup(ServerInfo{}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+	require.NoError(t, err)
+	dynamic := InitDynamicToolset(nil, tsg, translations.NullTranslationHelper)
+
+	validName := regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	owners := map[string]string{}
+	check := func(toolsetName string, name string) {
+		assert.Regexp(t, validName, name, "tool name in toolset %s", toolsetName)
+		if owner, exists := owners[name]; exists {
+			t.Errorf("tool %s is registered by both %s and %s", name, owner, toolsetName)
+		}
+		owners[name] = toolsetName
+	}
+
+	for _, toolset := range tsg.Toolsets() {
+		for _, st := range toolset.GetAvailableTools() {
+			check(toolset.Name, st.Tool.Name)
+		}
+	}
+	for _, st := range dynamic.GetAvailableTools() {
+		check(dynamic.Name, st.Tool.Name)
+	}
+	assert.NotEmpty(t, owners)
+}
+
+func Test_DefaultToolsetGroupEnableSubset(t *testing.T) {
+	tsg, err := DefaultToolsetGroup(ServerInfo{}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+	require.NoError(t, err)
+
+	require.NoError(t, tsg.EnableToolsets([]string{"repos", "pull_requests"}))
+
+	for _, toolset := range tsg.List() {
+		want := toolset.Name == "repos" || toolset.Name == "pull_requests"
+		assert.Equal(t, want, toolset.Enabled, "toolset %s", toolset.Name)
+		assert.Equal(t, want, tsg.IsEnabled(toolset.Name), "toolset %s", toolset.Name)
+	}
+}
+
+func Test_DefaultToolsetGroupUnknownToolset(t *testing.T) {
+	tsg, err := DefaultToolsetGroup(ServerInfo{}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), translations.NullTranslationHelper)
+	require.NoError(t, err)
+
+	err = tsg.EnableToolsets([]string{"repos", "pull_request"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, toolsets.NewToolsetDoesNotExistError("pull_request"))
+	assert.Contains(t, err.Error(), "toolset pull_request does not exist, valid toolsets are: ")
+	for _, toolset := range tsg.List() {
+		assert.Contains(t, err.Error(), toolset.Name)
+	}
+	assert.Contains(t, err.Error(), "all")
+}
+
+func Test_DefaultToolsetGroupLocalizedDescriptions(t *testing.T) {
+	bundles := translations.Bundles{
+		"ja": {"TOOL_GET_ME_DESCRIPTION": "認証済みユーザーの詳細を取得します。"},
+		"de": {"TOOL_GET_ME_DESCRIPTION": "Details des authentifizierten Benutzers abrufen."},
+	}
+
+	descriptionFor := func(locale string) string {
+		tsg, err := DefaultToolsetGroup(ServerInfo{}, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(nil), stubGetRawClientFn(nil),
+			bundles.Helper(translations.NullTranslationHelper, locale))
+		require.NoError(t, err)
+		for _, toolset := range tsg.Toolsets() {
+			for _, st := range toolset.GetAvailableTools() {
+				if st.Tool.Name == "get_me" {
+					return st.Tool.Description
+				}
+			}
+		}
+		t.Fatalf("get_me tool not found")
+		return ""
+	}
+
+	ja := descriptionFor("ja")
+	de := descriptionFor("de")
+	assert.Equal(t, "認証済みユーザーの詳細を取得します。", ja)
+	assert.Equal(t, "Details des authentifizierten Benutzers abrufen.", de)
+	assert.NotEqual(t, descriptionFor(translations.DefaultLocale), ja)
+}
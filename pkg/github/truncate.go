@@ -0,0 +1,28 @@
+package github
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// runeBoundaryCut returns the length to cut text longer than maxBytes to: at most maxBytes,
+// without splitting a UTF-8 sequence.
+func runeBoundaryCut(text string, maxBytes int) int {
+	if len(text) <= maxBytes {
+		return len(text)
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return cut
+}
+
+// lineBoundaryCut returns the length to cut text longer than maxBytes to: the end of the last
+// whole line that fits, or a character boundary if not even the first line fits.
+func lineBoundaryCut(text string, maxBytes int) int {
+	if cut := strings.LastIndexByte(text[:maxBytes], '\n') + 1; cut > 0 {
+		return cut
+	}
+	return runeBoundaryCut(text, maxBytes)
+}
@@ -0,0 +1,35 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RuneBoundaryCut(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxBytes int
+		expected int
+	}{
+		{name: "fits", text: "abc", maxBytes: 3, expected: 3},
+		{name: "ascii", text: "abcdef", maxBytes: 4, expected: 4},
+		{name: "inside a two byte rune", text: "aéé", maxBytes: 4, expected: 3},
+		{name: "inside a three byte rune", text: "日本語", maxBytes: 5, expected: 3},
+		{name: "on a rune boundary", text: "日本語", maxBytes: 6, expected: 6},
+		{name: "inside the first rune", text: "日本語", maxBytes: 2, expected: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, runeBoundaryCut(tc.text, tc.maxBytes))
+		})
+	}
+}
+
+func Test_LineBoundaryCut(t *testing.T) {
+	assert.Equal(t, len("line one\n"), lineBoundaryCut("line one\nline two\n", 12))
+	// Without a whole line that fits, the cut falls back to a rune boundary
+	assert.Equal(t, 3, lineBoundaryCut("aééé\n", 4))
+}
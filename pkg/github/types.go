@@ -0,0 +1,69 @@
+package github
+
+import "github.com/google/go-github/v73/github"
+
+// PageInfo describes the position of a page in a cursor-paginated GraphQL connection.
+type PageInfo struct {
+	HasNextPage     bool   `json:"hasNextPage"`
+	HasPreviousPage bool   `json:"hasPreviousPage"`
+	StartCursor     string `json:"startCursor"`
+	EndCursor       string `json:"endCursor"`
+}
+
+// NextCall holds the arguments to pass to a cursor-paginated tool to fetch the next page.
+type NextCall struct {
+	After   string `json:"after"`
+	PerPage int32  `json:"perPage"`
+}
+
+// NextPageInfo is embedded in the results of page-numbered REST tools, after the page items. NextPage is
+// the page argument that fetches the next page, and is left out on the last page.
+type NextPageInfo struct {
+	HasNextPage bool `json:"has_next_page"`
+	NextPage    int  `json:"next_page,omitempty"`
+}
+
+// nextPageInfo returns the NextPageInfo of the page a REST response holds.
+func nextPageInfo(resp *github.Response) NextPageInfo {
+	return NextPageInfo{HasNextPage: resp.NextPage > 0, NextPage: resp.NextPage}
+}
+
+// Pagination is embedded in the results of cursor-paginated tools, after the page items.
+type Pagination struct {
+	PageInfo   PageInfo  `json:"pageInfo"`
+	TotalCount int       `json:"totalCount"`
+	NextCall   *NextCall `json:"next_call,omitempty"`
+}
+
+// DiscussionsResult is a page of discussions returned by list_discussions.
+type DiscussionsResult struct {
+	Discussions []*github.Discussion `json:"discussions"`
+	Pagination
+}
+
+// DiscussionCommentsResult is a page of comments returned by get_discussion_comments. FilteredCount is
+// the number of comments on the page left out by the author and since filters.
+type DiscussionCommentsResult struct {
+	Comments []*github.IssueComment `json:"comments"`
+	Pagination
+	FilteredCount int `json:"filteredCount"`
+}
+
+// DiscussionCategory identifies a discussion category of a repository.
+type DiscussionCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// DiscussionCategoriesResult is a page of categories returned by list_discussion_categories.
+type DiscussionCategoriesResult struct {
+	Categories []DiscussionCategory `json:"categories"`
+	Pagination
+}
+
+// DeleteFileResult mirrors the response of the contents API when a file is deleted: the commit that
+// removed it, and a content that is always null.
+type DeleteFileResult struct {
+	Commit  *github.Commit            `json:"commit"`
+	Content *github.RepositoryContent `json:"content"`
+}
@@ -0,0 +1,136 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/v73/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ResultTypesMarshalDeterministically(t *testing.T) {
+	pagination := Pagination{
+		PageInfo: PageInfo{
+			HasNextPage:     true,
+			HasPreviousPage: false,
+			StartCursor:     "Y3Vyc29yOjE=",
+			EndCursor:       "Y3Vyc29yOjI=",
+		},
+		TotalCount: 3,
+		NextCall:   CursorNextCall(true, "Y3Vyc29yOjI=", 2),
+	}
+
+	tests := []struct {
+		name   string
+		result any
+		// expected is the result as the map it replaced, to check that field names are unchanged.
+		expected map[string]any
+	}{
+		{
+			name: "discussions",
+			result: DiscussionsResult{
+				Discussions: []*github.Discussion{{Number: github.Ptr(1), Title: github.Ptr("First")}},
+				Pagination:  pagination,
+			},
+			expected: map[string]any{
+				"discussions": []any{map[string]any{"number": float64(1), "title": "First"}},
+				"pageInfo": map[string]any{
+					"hasNextPage":     true,
+					"hasPreviousPage": false,
+					"startCursor":     "Y3Vyc29yOjE=",
+					"endCursor":       "Y3Vyc29yOjI=",
+				},
+				"totalCount": float64(3),
+				"next_call":  map[string]any{"after": "Y3Vyc29yOjI=", "perPage": float64(2)},
+			},
+		},
+		{
+			name: "discussion comments",
+			result: DiscussionCommentsResult{
+				Comments:      []*github.IssueComment{{Body: github.Ptr("Hello")}},
+				Pagination:    Pagination{PageInfo: PageInfo{StartCursor: "a", EndCursor: "b"}, TotalCount: 1},
+				FilteredCount: 2,
+			},
+			expected: map[string]any{
+				"comments": []any{map[string]any{"body": "Hello"}},
+				"pageInfo": map[string]any{
+					"hasNextPage":     false,
+					"hasPreviousPage": false,
+					"startCursor":     "a",
+					"endCursor":       "b",
+				},
+				"totalCount":    float64(1),
+				"filteredCount": float64(2),
+			},
+		},
+		{
+			name: "discussion categories",
+			result: DiscussionCategoriesResult{
+				Categories: []DiscussionCategory{{ID: "123", Name: "General"}},
+				Pagination: Pagination{TotalCount: 1},
+			},
+			expected: map[string]any{
+				"categories": []any{map[string]any{"id": "123", "name": "General"}},
+				"pageInfo": map[string]any{
+					"hasNextPage":     false,
+					"hasPreviousPage": false,
+					"startCursor":     "",
+					"endCursor":       "",
+				},
+				"totalCount": float64(1),
+			},
+		},
+		{
+			name:   "deleted file",
+			result: DeleteFileResult{Commit: &github.Commit{SHA: github.Ptr("abc123")}},
+			expected: map[string]any{
+				"commit":  map[string]any{"sha": "abc123"},
+				"content": nil,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			first, err := json.Marshal(tc.result)
+			require.NoError(t, err)
+			for range 10 {
+				again, err := json.Marshal(tc.result)
+				require.NoError(t, err)
+				assert.Equal(t, string(first), string(again))
+			}
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(first, &fields))
+			assert.Equal(t, tc.expected, fields)
+		})
+	}
+}
+
+func Test_NextPageInfoMarshalsFlat(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     *github.Response
+		expected string
+	}{
+		{
+			name:     "more pages",
+			resp:     &github.Response{NextPage: 3},
+			expected: `{"teams":[],"has_next_page":true,"next_page":3}`,
+		},
+		{
+			name:     "last page",
+			resp:     &github.Response{},
+			expected: `{"teams":[],"has_next_page":false}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(TeamsResult{Teams: []Team{}, NextPageInfo: nextPageInfo(tc.resp)})
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(data))
+		})
+	}
+}
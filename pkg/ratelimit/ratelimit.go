@@ -0,0 +1,132 @@
+// Package ratelimit retries GitHub API requests that were turned away by a rate limit.
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultMaxRetries is the default number of times a rate limited request is retried.
+const DefaultMaxRetries = 3
+
+// defaultWait is how long to wait when GitHub says a request was rate limited but not for how long.
+// GitHub asks clients to wait at least a minute before retrying in that case.
+const defaultWait = time.Minute
+
+// Transport retries requests that GitHub answers with 403 or 429 and either a Retry-After header
+// or no remaining rate limit, waiting as long as the response asks. A request is given up on, and
+// the rate limited response returned, once it has been retried maxRetries times or when the wait
+// would outlast the request's context.
+type Transport struct {
+	transport  http.RoundTripper
+	maxRetries int
+	logger     *log.Logger
+	clock      clock
+}
+
+// clock tells the time and waits, so that tests do not have to.
+type clock interface {
+	Now() time.Time
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NewTransport returns transport retrying rate limited requests up to maxRetries times, logging
+// each retry to logger at debug level. A maxRetries of zero or less leaves transport as it is.
+func NewTransport(transport http.RoundTripper, maxRetries int, logger *log.Logger) http.RoundTripper {
+	if maxRetries <= 0 {
+		return transport
+	}
+	if logger == nil {
+		logger = log.StandardLogger()
+	}
+	return &Transport{
+		transport:  transport,
+		maxRetries: maxRetries,
+		logger:     logger,
+		clock:      realClock{},
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil || attempt == t.maxRetries {
+			return resp, err
+		}
+
+		wait, limited := t.retryAfter(resp)
+		if !limited {
+			return resp, nil
+		}
+		// A request body can only be sent again if it can be recreated
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && t.clock.Now().Add(wait).After(deadline) {
+			return resp, nil
+		}
+
+		t.logger.Debugf("rate limited on %s %s, retrying in %s (retry %d of %d)", req.Method, req.URL.Path, wait, attempt+1, t.maxRetries)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if err := t.clock.Sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// retryAfter reports whether resp was rate limited and how long to wait before retrying. The
+// Retry-After header takes precedence over the time the rate limit resets.
+func (t *Transport) retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return max(time.Duration(seconds)*time.Second, 0), true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return max(at.Sub(t.clock.Now()), 0), true
+		}
+		return defaultWait, true
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return max(time.Unix(reset, 0).Sub(t.clock.Now()), 0), true
+	}
+	return defaultWait, true
+}
@@ -0,0 +1,176 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock records the waits asked of it instead of sleeping.
+type fakeClock struct {
+	now   time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.slept = append(c.slept, d)
+	c.now = c.now.Add(d)
+	return ctx.Err()
+}
+
+// limitedServer answers the first limited requests with status and headers, and any others with 200.
+func limitedServer(t *testing.T, limited int, status int, headers map[string]string) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if int(n) <= limited {
+			for k, v := range headers {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit."}`))
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newTestTransport(maxRetries int, clock *fakeClock) (*Transport, *bytes.Buffer) {
+	var logs bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&logs)
+	logger.SetLevel(log.DebugLevel)
+
+	transport := NewTransport(http.DefaultTransport, maxRetries, logger).(*Transport)
+	transport.clock = clock
+	return transport, &logs
+}
+
+func TestTransportRetriesAfterRetryAfter(t *testing.T) {
+	server, requests := limitedServer(t, 1, http.StatusForbidden, map[string]string{"Retry-After": "7"})
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	transport, logs := newTestTransport(3, clock)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/repos/owner/repo/issues", strings.NewReader(`{"title": "hello"}`))
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, []time.Duration{7 * time.Second}, clock.slept)
+
+	// The request body is sent again with the retry
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"title": "hello"}`, string(body))
+
+	assert.Contains(t, logs.String(), "level=debug")
+	assert.Contains(t, logs.String(), "POST /repos/owner/repo/issues")
+	assert.Contains(t, logs.String(), "retrying in 7s")
+}
+
+func TestTransportWaitsForRateLimitReset(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	server, requests := limitedServer(t, 1, http.StatusTooManyRequests, map[string]string{
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     strconv.FormatInt(clock.now.Add(42*time.Second).Unix(), 10),
+	})
+	transport, _ := newTestTransport(3, clock)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/user", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, []time.Duration{42 * time.Second}, clock.slept)
+}
+
+func TestTransportGivesUpAfterMaxRetries(t *testing.T) {
+	server, requests := limitedServer(t, 10, http.StatusForbidden, map[string]string{"Retry-After": "1"})
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	transport, _ := newTestTransport(2, clock)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/user", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Len(t, clock.slept, 2)
+}
+
+func TestTransportDoesNotRetryOtherResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+	}{
+		{name: "forbidden without rate limit headers", status: http.StatusForbidden},
+		{name: "forbidden with remaining rate limit", status: http.StatusForbidden, headers: map[string]string{"X-RateLimit-Remaining": "12"}},
+		{name: "server error with retry after", status: http.StatusServiceUnavailable, headers: map[string]string{"Retry-After": "1"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, requests := limitedServer(t, 1, tc.status, tc.headers)
+			clock := &fakeClock{now: time.Unix(1700000000, 0)}
+			transport, _ := newTestTransport(3, clock)
+
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/user", nil)
+			require.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, tc.status, resp.StatusCode)
+			assert.Equal(t, int32(1), requests.Load())
+			assert.Empty(t, clock.slept)
+		})
+	}
+}
+
+func TestTransportDoesNotWaitPastContextDeadline(t *testing.T) {
+	server, requests := limitedServer(t, 1, http.StatusForbidden, map[string]string{"Retry-After": "60"})
+	clock := &fakeClock{now: time.Now()}
+	transport, _ := newTestTransport(3, clock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/user", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Empty(t, clock.slept)
+}
+
+func TestNewTransportWithoutRetries(t *testing.T) {
+	assert.Equal(t, http.DefaultTransport, NewTransport(http.DefaultTransport, 0, nil))
+}
@@ -2,6 +2,9 @@ package toolsets
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -9,10 +12,15 @@ import (
 
 type ToolsetDoesNotExistError struct {
 	Name string
+	// Valid lists the toolsets that do exist, so that a misspelt name can be corrected.
+	Valid []string
 }
 
 func (e *ToolsetDoesNotExistError) Error() string {
-	return fmt.Sprintf("toolset %s does not exist", e.Name)
+	if len(e.Valid) == 0 {
+		return fmt.Sprintf("toolset %s does not exist", e.Name)
+	}
+	return fmt.Sprintf("toolset %s does not exist, valid toolsets are: %s", e.Name, strings.Join(e.Valid, ", "))
 }
 
 func (e *ToolsetDoesNotExistError) Is(target error) bool {
@@ -29,6 +37,17 @@ func NewToolsetDoesNotExistError(name string) *ToolsetDoesNotExistError {
 	return &ToolsetDoesNotExistError{Name: name}
 }
 
+// DuplicateToolError is raised when two toolsets register a tool with the same name. The MCP server
+// keys tools by name, so one would silently replace the other.
+type DuplicateToolError struct {
+	Name     string
+	Toolsets []string
+}
+
+func (e *DuplicateToolError) Error() string {
+	return fmt.Sprintf("tool %s is registered more than once, by toolsets: %s", e.Name, strings.Join(e.Toolsets, ", "))
+}
+
 func NewServerTool(tool mcp.Tool, handler server.ToolHandlerFunc) server.ServerTool {
 	return server.ServerTool{Tool: tool, Handler: handler}
 }
@@ -88,7 +107,15 @@ func (t *Toolset) GetAvailableTools() []server.ServerTool {
 	if t.readOnly {
 		return t.readTools
 	}
-	return append(t.readTools, t.writeTools...)
+	// Copy rather than append to readTools, whose spare capacity concurrent callers would share.
+	return t.allTools()
+}
+
+// allTools returns every tool of the toolset, whether or not it is enabled or read-only.
+func (t *Toolset) allTools() []server.ServerTool {
+	tools := make([]server.ServerTool, 0, len(t.readTools)+len(t.writeTools))
+	tools = append(tools, t.readTools...)
+	return append(tools, t.writeTools...)
 }
 
 func (t *Toolset) RegisterTools(s *server.MCPServer) {
@@ -173,24 +200,58 @@ func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 }
 
 type ToolsetGroup struct {
-	Toolsets     map[string]*Toolset
+	// mu guards the group, which dynamic toolsets change while requests are served.
+	mu           sync.Mutex
+	toolsets     map[string]*Toolset
 	everythingOn bool
 	readOnly     bool
 }
 
 func NewToolsetGroup(readOnly bool) *ToolsetGroup {
 	return &ToolsetGroup{
-		Toolsets:     make(map[string]*Toolset),
+		toolsets:     make(map[string]*Toolset),
 		everythingOn: false,
 		readOnly:     readOnly,
 	}
 }
 
-func (tg *ToolsetGroup) AddToolset(ts *Toolset) {
+// AddToolset adds a toolset to the group, replacing any toolset of the same name. It returns a
+// *DuplicateToolError, and leaves the group unchanged, if one of its tools has the name of a tool
+// in another toolset of the group.
+func (tg *ToolsetGroup) AddToolset(ts *Toolset) error {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
+	if err := tg.checkToolNames(ts); err != nil {
+		return err
+	}
 	if tg.readOnly {
 		ts.SetReadOnly()
 	}
-	tg.Toolsets[ts.Name] = ts
+	tg.toolsets[ts.Name] = ts
+	return nil
+}
+
+// checkToolNames returns a *DuplicateToolError if ts repeats a tool name, either within itself or
+// from another toolset of the group. Write tools are checked even in read-only mode so that a
+// duplicate cannot hide until the server is run with write access.
+func (tg *ToolsetGroup) checkToolNames(ts *Toolset) error {
+	owners := make(map[string]string)
+	for name, other := range tg.toolsets {
+		if name == ts.Name {
+			continue
+		}
+		for _, tool := range other.allTools() {
+			owners[tool.Tool.Name] = name
+		}
+	}
+	for _, tool := range ts.allTools() {
+		if owner, exists := owners[tool.Tool.Name]; exists {
+			return &DuplicateToolError{Name: tool.Tool.Name, Toolsets: []string{owner, ts.Name}}
+		}
+		owners[tool.Tool.Name] = ts.Name
+	}
+	return nil
 }
 
 func NewToolset(name string, description string) *Toolset {
@@ -203,12 +264,15 @@ func NewToolset(name string, description string) *Toolset {
 }
 
 func (tg *ToolsetGroup) IsEnabled(name string) bool {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
 	// If everythingOn is true, all features are enabled
 	if tg.everythingOn {
 		return true
 	}
 
-	feature, exists := tg.Toolsets[name]
+	feature, exists := tg.toolsets[name]
 	if !exists {
 		return false
 	}
@@ -216,21 +280,24 @@ func (tg *ToolsetGroup) IsEnabled(name string) bool {
 }
 
 func (tg *ToolsetGroup) EnableToolsets(names []string) error {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
 	// Special case for "all"
 	for _, name := range names {
 		if name == "all" {
 			tg.everythingOn = true
 			break
 		}
-		err := tg.EnableToolset(name)
+		err := tg.enableToolset(name)
 		if err != nil {
 			return err
 		}
 	}
 	// Do this after to ensure all toolsets are enabled if "all" is present anywhere in list
 	if tg.everythingOn {
-		for name := range tg.Toolsets {
-			err := tg.EnableToolset(name)
+		for name := range tg.toolsets {
+			err := tg.enableToolset(name)
 			if err != nil {
 				return err
 			}
@@ -241,17 +308,39 @@ func (tg *ToolsetGroup) EnableToolsets(names []string) error {
 }
 
 func (tg *ToolsetGroup) EnableToolset(name string) error {
-	toolset, exists := tg.Toolsets[name]
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
+	return tg.enableToolset(name)
+}
+
+func (tg *ToolsetGroup) enableToolset(name string) error {
+	toolset, exists := tg.toolsets[name]
 	if !exists {
-		return NewToolsetDoesNotExistError(name)
+		err := NewToolsetDoesNotExistError(name)
+		err.Valid = tg.toolsetNames()
+		return err
 	}
 	toolset.Enabled = true
-	tg.Toolsets[name] = toolset
 	return nil
 }
 
+// toolsetNames returns the names of the group's toolsets in sorted order, plus "all". The caller
+// must hold tg.mu.
+func (tg *ToolsetGroup) toolsetNames() []string {
+	toolsets := tg.sortedToolsets()
+	names := make([]string, 0, len(toolsets)+1)
+	for _, toolset := range toolsets {
+		names = append(names, toolset.Name)
+	}
+	return append(names, "all")
+}
+
 func (tg *ToolsetGroup) RegisterAll(s *server.MCPServer) {
-	for _, toolset := range tg.Toolsets {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
+	for _, toolset := range tg.toolsets {
 		toolset.RegisterTools(s)
 		toolset.RegisterResourcesTemplates(s)
 		toolset.RegisterPrompts(s)
@@ -259,9 +348,55 @@ func (tg *ToolsetGroup) RegisterAll(s *server.MCPServer) {
 }
 
 func (tg *ToolsetGroup) GetToolset(name string) (*Toolset, error) {
-	toolset, exists := tg.Toolsets[name]
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
+	toolset, exists := tg.toolsets[name]
 	if !exists {
 		return nil, NewToolsetDoesNotExistError(name)
 	}
 	return toolset, nil
 }
+
+// ToolsetInfo is a snapshot of a toolset of a group, taken by ToolsetGroup.List.
+type ToolsetInfo struct {
+	Name        string
+	Description string
+	Enabled     bool
+}
+
+// List returns a snapshot of the group's toolsets, sorted by name. Unlike the toolsets themselves,
+// the snapshot is safe to read while dynamic toolsets are being enabled.
+func (tg *ToolsetGroup) List() []ToolsetInfo {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
+	toolsets := tg.sortedToolsets()
+	infos := make([]ToolsetInfo, 0, len(toolsets))
+	for _, toolset := range toolsets {
+		infos = append(infos, ToolsetInfo{
+			Name:        toolset.Name,
+			Description: toolset.Description,
+			Enabled:     toolset.Enabled,
+		})
+	}
+	return infos
+}
+
+// Toolsets returns the group's toolsets, sorted by name.
+func (tg *ToolsetGroup) Toolsets() []*Toolset {
+	tg.mu.Lock()
+	defer tg.mu.Unlock()
+
+	return tg.sortedToolsets()
+}
+
+// sortedToolsets returns the group's toolsets, sorted by name. The caller must hold tg.mu.
+func (tg *ToolsetGroup) sortedToolsets() []*Toolset {
+	toolsets := make([]*Toolset, 0, len(tg.toolsets))
+	for _, toolset := range tg.toolsets {
+		toolsets = append(toolsets, toolset)
+	}
+	sort.Slice(toolsets, func(i, j int) bool { return toolsets[i].Name < toolsets[j].Name })
+	return toolsets
+}
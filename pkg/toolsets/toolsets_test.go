@@ -2,13 +2,35 @@ package toolsets
 
 import (
 	"errors"
+	"sync"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
+func readTool(name string) server.ServerTool {
+	readOnly := true
+	return NewServerTool(mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly})), nil)
+}
+
+func writeTool(name string) server.ServerTool {
+	readOnly := false
+	return NewServerTool(mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly})), nil)
+}
+
+// mustAddToolset adds ts to tsg, failing the test if the group rejects it.
+func mustAddToolset(t *testing.T, tsg *ToolsetGroup, ts *Toolset) {
+	t.Helper()
+	if err := tsg.AddToolset(ts); err != nil {
+		t.Fatalf("Expected no error adding %s, got %v", ts.Name, err)
+	}
+}
+
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
 	tsg := NewToolsetGroup(false)
-	if len(tsg.Toolsets) != 0 {
-		t.Fatalf("Expected Toolsets map to be empty, got %d items", len(tsg.Toolsets))
+	if len(tsg.toolsets) != 0 {
+		t.Fatalf("Expected Toolsets map to be empty, got %d items", len(tsg.toolsets))
 	}
 	if tsg.everythingOn {
 		t.Fatal("Expected everythingOn to be initialized as false")
@@ -21,14 +43,14 @@ func TestAddToolset(t *testing.T) {
 	// Test adding a toolset
 	toolset := NewToolset("test-toolset", "A test toolset")
 	toolset.Enabled = true
-	tsg.AddToolset(toolset)
+	mustAddToolset(t, tsg, toolset)
 
 	// Verify toolset was added correctly
-	if len(tsg.Toolsets) != 1 {
-		t.Errorf("Expected 1 toolset, got %d", len(tsg.Toolsets))
+	if len(tsg.toolsets) != 1 {
+		t.Errorf("Expected 1 toolset, got %d", len(tsg.toolsets))
 	}
 
-	toolset, exists := tsg.Toolsets["test-toolset"]
+	toolset, exists := tsg.toolsets["test-toolset"]
 	if !exists {
 		t.Fatal("Feature was not added to the map")
 	}
@@ -47,17 +69,17 @@ func TestAddToolset(t *testing.T) {
 
 	// Test adding another toolset
 	anotherToolset := NewToolset("another-toolset", "Another test toolset")
-	tsg.AddToolset(anotherToolset)
+	mustAddToolset(t, tsg, anotherToolset)
 
-	if len(tsg.Toolsets) != 2 {
-		t.Errorf("Expected 2 toolsets, got %d", len(tsg.Toolsets))
+	if len(tsg.toolsets) != 2 {
+		t.Errorf("Expected 2 toolsets, got %d", len(tsg.toolsets))
 	}
 
 	// Test overriding existing toolset
 	updatedToolset := NewToolset("test-toolset", "Updated description")
-	tsg.AddToolset(updatedToolset)
+	mustAddToolset(t, tsg, updatedToolset)
 
-	toolset = tsg.Toolsets["test-toolset"]
+	toolset = tsg.toolsets["test-toolset"]
 	if toolset.Description != "Updated description" {
 		t.Errorf("Expected toolset description to be updated to 'Updated description', got '%s'", toolset.Description)
 	}
@@ -77,7 +99,7 @@ func TestIsEnabled(t *testing.T) {
 
 	// Test with disabled toolset
 	disabledToolset := NewToolset("disabled-toolset", "A disabled toolset")
-	tsg.AddToolset(disabledToolset)
+	mustAddToolset(t, tsg, disabledToolset)
 	if tsg.IsEnabled("disabled-toolset") {
 		t.Error("Expected IsEnabled to return false for disabled toolset")
 	}
@@ -85,7 +107,7 @@ func TestIsEnabled(t *testing.T) {
 	// Test with enabled toolset
 	enabledToolset := NewToolset("enabled-toolset", "An enabled toolset")
 	enabledToolset.Enabled = true
-	tsg.AddToolset(enabledToolset)
+	mustAddToolset(t, tsg, enabledToolset)
 	if !tsg.IsEnabled("enabled-toolset") {
 		t.Error("Expected IsEnabled to return true for enabled toolset")
 	}
@@ -102,7 +124,7 @@ func TestEnableFeature(t *testing.T) {
 
 	// Test enabling toolset
 	testToolset := NewToolset("test-toolset", "A test toolset")
-	tsg.AddToolset(testToolset)
+	mustAddToolset(t, tsg, testToolset)
 
 	if tsg.IsEnabled("test-toolset") {
 		t.Error("Expected toolset to be disabled initially")
@@ -130,8 +152,8 @@ func TestEnableToolsets(t *testing.T) {
 	// Prepare toolsets
 	toolset1 := NewToolset("toolset1", "Feature 1")
 	toolset2 := NewToolset("toolset2", "Feature 2")
-	tsg.AddToolset(toolset1)
-	tsg.AddToolset(toolset2)
+	mustAddToolset(t, tsg, toolset1)
+	mustAddToolset(t, tsg, toolset2)
 
 	// Test enabling multiple toolsets
 	err := tsg.EnableToolsets([]string{"toolset1", "toolset2"})
@@ -179,7 +201,7 @@ func TestEnableEverything(t *testing.T) {
 
 	// Add a disabled toolset
 	testToolset := NewToolset("test-toolset", "A test toolset")
-	tsg.AddToolset(testToolset)
+	mustAddToolset(t, tsg, testToolset)
 
 	// Verify it's disabled
 	if tsg.IsEnabled("test-toolset") {
@@ -230,7 +252,7 @@ func TestIsEnabledWithEverythingOn(t *testing.T) {
 func TestToolsetGroup_GetToolset(t *testing.T) {
 	tsg := NewToolsetGroup(false)
 	toolset := NewToolset("my-toolset", "desc")
-	tsg.AddToolset(toolset)
+	mustAddToolset(t, tsg, toolset)
 
 	// Should find the toolset
 	got, err := tsg.GetToolset("my-toolset")
@@ -250,3 +272,164 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func TestAddToolsetDetectsDuplicateToolNames(t *testing.T) {
+	tests := []struct {
+		name             string
+		readOnly         bool
+		toolsets         []*Toolset
+		expectedTool     string
+		expectedToolsets []string
+	}{
+		{
+			name: "distinct tools",
+			toolsets: []*Toolset{
+				NewToolset("issues", "").AddReadTools(readTool("get_issue")),
+				NewToolset("pull_requests", "").AddReadTools(readTool("get_pull_request")),
+			},
+		},
+		{
+			name: "same tool in two toolsets",
+			toolsets: []*Toolset{
+				NewToolset("issues", "").AddReadTools(readTool("get_issue")),
+				NewToolset("pull_requests", "").AddReadTools(readTool("get_pull_request"), readTool("get_issue")),
+			},
+			expectedTool:     "get_issue",
+			expectedToolsets: []string{"issues", "pull_requests"},
+		},
+		{
+			name: "same tool twice in one toolset",
+			toolsets: []*Toolset{
+				NewToolset("issues", "").AddReadTools(readTool("get_issue")).AddWriteTools(writeTool("get_issue")),
+			},
+			expectedTool:     "get_issue",
+			expectedToolsets: []string{"issues", "issues"},
+		},
+		{
+			name:     "write tools are checked in read-only mode",
+			readOnly: true,
+			toolsets: []*Toolset{
+				NewToolset("issues", "").AddWriteTools(writeTool("create_issue")),
+				NewToolset("repos", "").AddWriteTools(writeTool("create_issue")),
+			},
+			expectedTool:     "create_issue",
+			expectedToolsets: []string{"issues", "repos"},
+		},
+		{
+			name: "replacing a toolset keeps its tool names",
+			toolsets: []*Toolset{
+				NewToolset("issues", "").AddReadTools(readTool("get_issue")),
+				NewToolset("issues", "Updated").AddReadTools(readTool("get_issue")),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tsg := NewToolsetGroup(tc.readOnly)
+			var err error
+			for _, ts := range tc.toolsets {
+				if err = tsg.AddToolset(ts); err != nil {
+					break
+				}
+			}
+
+			if tc.expectedTool == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var duplicate *DuplicateToolError
+			if !errors.As(err, &duplicate) {
+				t.Fatalf("Expected DuplicateToolError, got %v", err)
+			}
+			if duplicate.Name != tc.expectedTool {
+				t.Errorf("Expected duplicate tool %s, got %s", tc.expectedTool, duplicate.Name)
+			}
+			if len(duplicate.Toolsets) != 2 || duplicate.Toolsets[0] != tc.expectedToolsets[0] || duplicate.Toolsets[1] != tc.expectedToolsets[1] {
+				t.Errorf("Expected toolsets %v, got %v", tc.expectedToolsets, duplicate.Toolsets)
+			}
+		})
+	}
+}
+
+func TestToolsetGroupConcurrentAccess(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	names := []string{"issues", "repos", "users", "orgs"}
+	for _, name := range names {
+		mustAddToolset(t, tsg, NewToolset(name, ""))
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if err := tsg.EnableToolset(name); err != nil {
+				t.Errorf("Expected no error enabling %s, got %v", name, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_ = tsg.IsEnabled(name)
+		}()
+		go func() {
+			defer wg.Done()
+			if err := tsg.AddToolset(NewToolset(name+"-extra", "")); err != nil {
+				t.Errorf("Expected no error adding %s-extra, got %v", name, err)
+			}
+			_ = tsg.List()
+		}()
+	}
+	wg.Wait()
+
+	for _, name := range names {
+		if !tsg.IsEnabled(name) {
+			t.Errorf("Expected toolset %s to be enabled", name)
+		}
+	}
+}
+
+func TestAddToolsetDuplicateLeavesGroupUnchanged(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	mustAddToolset(t, tsg, NewToolset("issues", "").AddReadTools(readTool("get_issue")))
+
+	err := tsg.AddToolset(NewToolset("pull_requests", "").AddReadTools(readTool("get_issue")))
+	var duplicate *DuplicateToolError
+	if !errors.As(err, &duplicate) {
+		t.Fatalf("Expected DuplicateToolError, got %v", err)
+	}
+	if _, err := tsg.GetToolset("pull_requests"); err == nil {
+		t.Error("Expected the rejected toolset not to be added")
+	}
+}
+
+func TestToolsetGroupList(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	mustAddToolset(t, tsg, NewToolset("repos", "Repositories"))
+	mustAddToolset(t, tsg, NewToolset("issues", "Issues"))
+	if err := tsg.EnableToolset("repos"); err != nil {
+		t.Fatalf("Expected no error enabling repos, got %v", err)
+	}
+
+	expected := []ToolsetInfo{
+		{Name: "issues", Description: "Issues"},
+		{Name: "repos", Description: "Repositories", Enabled: true},
+	}
+	infos := tsg.List()
+	if len(infos) != len(expected) {
+		t.Fatalf("Expected %d toolsets, got %d", len(expected), len(infos))
+	}
+	for i, info := range infos {
+		if info != expected[i] {
+			t.Errorf("Expected toolset %d to be %+v, got %+v", i, expected[i], info)
+		}
+	}
+
+	toolsets := tsg.Toolsets()
+	if len(toolsets) != 2 || toolsets[0].Name != "issues" || toolsets[1].Name != "repos" {
+		t.Errorf("Expected toolsets issues and repos in order, got %v", toolsets)
+	}
+}
@@ -0,0 +1,145 @@
+package translations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is the locale of the descriptions built into the binary.
+const DefaultLocale = "en"
+
+// LocaleHeader is the request header that selects a locale, taking precedence over Accept-Language.
+const LocaleHeader = "X-Locale"
+
+type localeKey struct{}
+
+// ContextWithLocale returns a context carrying the locale negotiated for a request.
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale of the request, or DefaultLocale if none was negotiated.
+func LocaleFromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Bundles holds the translations of each locale, keyed by lowercase locale tag.
+// Each bundle uses the same keys as github-mcp-server-config.json.
+type Bundles map[string]map[string]string
+
+// LoadBundles reads every translations.<locale>.json file in dir,
+// e.g. translations.ja.json or translations.pt-br.json.
+func LoadBundles(dir string) (Bundles, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "translations.*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing translations in %s: %v", dir, err)
+	}
+
+	bundles := Bundles{}
+	for _, path := range paths {
+		locale := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "translations."), ".json")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", path, err)
+		}
+		var values map[string]string
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+
+		bundle := make(map[string]string, len(values))
+		for key, value := range values {
+			bundle[strings.ToUpper(key)] = value
+		}
+		bundles[strings.ToLower(locale)] = bundle
+	}
+	return bundles, nil
+}
+
+// Locales returns the loaded locales in sorted order.
+func (b Bundles) Locales() []string {
+	locales := make([]string, 0, len(b))
+	for locale := range b {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Negotiate picks the best loaded locale for a list of language tags in Accept-Language form,
+// e.g. "ja-JP, de;q=0.8". A tag matches a bundle exactly or by its primary language, so "ja-JP"
+// selects "ja". DefaultLocale is returned when nothing matches or English is preferred.
+func (b Bundles) Negotiate(tags string) string {
+	type weightedTag struct {
+		tag    string
+		weight float64
+	}
+
+	var candidates []weightedTag
+	for _, part := range strings.Split(tags, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+		if tag == "" || tag == "*" {
+			continue
+		}
+		weight := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				weight = parsed
+			}
+		}
+		if weight > 0 {
+			candidates = append(candidates, weightedTag{tag: tag, weight: weight})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+
+	for _, candidate := range candidates {
+		if _, ok := b[candidate.tag]; ok {
+			return candidate.tag
+		}
+		primary, _, _ := strings.Cut(candidate.tag, "-")
+		if _, ok := b[primary]; ok {
+			return primary
+		}
+		if primary == DefaultLocale {
+			return DefaultLocale
+		}
+	}
+	return DefaultLocale
+}
+
+// NegotiateRequest picks the best loaded locale for an HTTP request from its X-Locale header,
+// falling back to its Accept-Language header.
+func (b Bundles) NegotiateRequest(r *http.Request) string {
+	if tags := r.Header.Get(LocaleHeader); tags != "" {
+		return b.Negotiate(tags)
+	}
+	return b.Negotiate(r.Header.Get("Accept-Language"))
+}
+
+// Helper returns a TranslationHelperFunc for locale that falls back key by key to base, which
+// provides the English descriptions and any overrides. base is always consulted so that an export
+// of the translations stays complete whatever the locale.
+func (b Bundles) Helper(base TranslationHelperFunc, locale string) TranslationHelperFunc {
+	bundle := b[strings.ToLower(locale)]
+	return func(key string, defaultValue string) string {
+		value := base(key, defaultValue)
+		if translated, ok := bundle[strings.ToUpper(key)]; ok {
+			return translated
+		}
+		return value
+	}
+}
@@ -0,0 +1,107 @@
+package translations
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBundle(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+}
+
+func TestLoadBundles(t *testing.T) {
+	dir := t.TempDir()
+	writeBundle(t, dir, "translations.ja.json", `{"tool_get_me_description": "認証済みユーザーの詳細を取得"}`)
+	writeBundle(t, dir, "translations.pt-BR.json", `{"TOOL_GET_ME_DESCRIPTION": "Obter detalhes do usuário"}`)
+	writeBundle(t, dir, "github-mcp-server-config.json", `{"TOOL_GET_ME_DESCRIPTION": "ignored"}`)
+
+	bundles, err := LoadBundles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ja", "pt-br"}, bundles.Locales())
+	assert.Equal(t, "認証済みユーザーの詳細を取得", bundles["ja"]["TOOL_GET_ME_DESCRIPTION"])
+
+	writeBundle(t, dir, "translations.de.json", `not json`)
+	_, err = LoadBundles(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "translations.de.json")
+}
+
+func TestBundlesHelperFallback(t *testing.T) {
+	bundles := Bundles{
+		"ja": {"TOOL_A_DESCRIPTION": "ツールA"},
+	}
+	var seen []string
+	base := func(key string, defaultValue string) string {
+		seen = append(seen, key)
+		return defaultValue
+	}
+
+	ja := bundles.Helper(base, "ja")
+	assert.Equal(t, "ツールA", ja("TOOL_A_DESCRIPTION", "Tool A"))
+	assert.Equal(t, "ツールA", ja("tool_a_description", "Tool A"))
+	assert.Equal(t, "Tool B", ja("TOOL_B_DESCRIPTION", "Tool B"))
+
+	unknown := bundles.Helper(base, "fr")
+	assert.Equal(t, "Tool A", unknown("TOOL_A_DESCRIPTION", "Tool A"))
+
+	// The English helper sees every key, so exported translations stay complete.
+	assert.Equal(t, []string{"TOOL_A_DESCRIPTION", "tool_a_description", "TOOL_B_DESCRIPTION", "TOOL_A_DESCRIPTION"}, seen)
+}
+
+func TestBundlesNegotiate(t *testing.T) {
+	bundles := Bundles{
+		"ja":    {},
+		"de":    {},
+		"pt-br": {},
+	}
+
+	tests := []struct {
+		tags     string
+		expected string
+	}{
+		{tags: "", expected: DefaultLocale},
+		{tags: "ja", expected: "ja"},
+		{tags: "ja-JP", expected: "ja"},
+		{tags: "pt_BR", expected: "pt-br"},
+		{tags: "fr, de;q=0.8, ja;q=0.5", expected: "de"},
+		{tags: "ja;q=0.5, de;q=0.9", expected: "de"},
+		{tags: "en-US, ja;q=0.9", expected: DefaultLocale},
+		{tags: "de;q=0, ja;q=0.1", expected: "ja"},
+		{tags: "fr, *", expected: DefaultLocale},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tags, func(t *testing.T) {
+			assert.Equal(t, tc.expected, bundles.Negotiate(tc.tags))
+		})
+	}
+}
+
+func TestBundlesNegotiateRequest(t *testing.T) {
+	bundles := Bundles{
+		"ja": {},
+		"de": {},
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	assert.Equal(t, DefaultLocale, bundles.NegotiateRequest(req))
+
+	req.Header.Set("Accept-Language", "de-DE, en;q=0.5")
+	assert.Equal(t, "de", bundles.NegotiateRequest(req))
+
+	// X-Locale takes precedence over Accept-Language
+	req.Header.Set(LocaleHeader, "ja-JP")
+	assert.Equal(t, "ja", bundles.NegotiateRequest(req))
+}
+
+func TestLocaleFromContext(t *testing.T) {
+	assert.Equal(t, DefaultLocale, LocaleFromContext(context.Background()))
+	assert.Equal(t, "ja", LocaleFromContext(ContextWithLocale(context.Background(), "ja")))
+}